@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/cli"
+)
+
+// Command line client of the ZG Data Guard API. Run it without args to list its commands.
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/webserver/router"
 )
 
@@ -33,6 +34,8 @@ import (
 // @Tag.description It represents the user that can be created in a specific database instance (cluster) with a specific role. e.g. foo.bar, john.doe, etc.
 // @Tag.name Access Permission
 // @Tag.description It represents the permission that can be granted to a user to connect in a specific database. e.g. foo.bar (user) can connect in zg-data-guard (database) with developer role.
+// @Tag.name Job
+// @Tag.description It represents an operation processed in background, requested with async=true. e.g. a grant of access to many users in many instances.
 
 // @securityDefinitions.apiKey ApiKeyAuth
 // @in header
@@ -42,5 +45,6 @@ func main() {
 	config.Init()
 	// Initialize WebServer
 	router.Init()
+	connector.CloseConnections()
 	config.Cleanup()
 }
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const defaultAccessRequestTTL = 24 * time.Hour
+
+// GetAccessRequestTTL godoc
+// Time an access request waits for review before it expires, e.g. 8h, 72h
+func GetAccessRequestTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("ACCESS_REQUEST_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultAccessRequestTTL
+	}
+	return ttl
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const defaultBreakGlassWindow = time.Hour
+
+// GetBreakGlassWindow godoc
+// Time the break-glass access lasts before it's revoked automatically, e.g. 30m, 2h
+func GetBreakGlassWindow() time.Duration {
+	window, err := time.ParseDuration(os.Getenv("BREAK_GLASS_WINDOW"))
+	if err != nil || window <= 0 {
+		return defaultBreakGlassWindow
+	}
+	return window
+}
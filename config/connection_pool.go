@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultTargetMaxConnectionsPerInstance = 10
+	defaultTargetPoolIdleTimeout           = 5 * time.Minute
+)
+
+// GetTargetMaxConnectionsPerInstance godoc
+// Maximum number of connections opened to each target database instance (cluster), shared by all its databases
+func GetTargetMaxConnectionsPerInstance() int {
+	return getPositiveIntEnv("TARGET_MAX_CONNECTIONS_PER_INSTANCE", defaultTargetMaxConnectionsPerInstance)
+}
+
+// GetTargetPoolIdleTimeout godoc
+// Time after which an unused connection pool to a target database is closed, e.g. 90s, 5m
+func GetTargetPoolIdleTimeout() time.Duration {
+	idleTimeout, err := time.ParseDuration(os.Getenv("TARGET_POOL_IDLE_TIMEOUT"))
+	if err != nil || idleTimeout <= 0 {
+		return defaultTargetPoolIdleTimeout
+	}
+	return idleTimeout
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/zgsolucoes/zg-data-guard/pkg/executor"
+)
+
+const (
+	defaultExecutorMaxConcurrency            = 16
+	defaultExecutorMaxConcurrencyPerInstance = 4
+)
+
+var (
+	executorOnce sync.Once
+	taskExecutor *executor.Executor
+)
+
+// GetExecutor godoc
+// Returns the executor shared by the operations that fan out to the database instances (grant, revoke, sync, etc.)
+func GetExecutor() *executor.Executor {
+	executorOnce.Do(func() {
+		maxConcurrency := getPositiveIntEnv("EXECUTOR_MAX_CONCURRENCY", defaultExecutorMaxConcurrency)
+		maxPerInstance := getPositiveIntEnv("EXECUTOR_MAX_CONCURRENCY_PER_INSTANCE", defaultExecutorMaxConcurrencyPerInstance)
+		log.Printf("Executor initialized with %d concurrent tasks in total and %d per instance", maxConcurrency, maxPerInstance)
+		taskExecutor = executor.NewExecutor(maxConcurrency, maxPerInstance)
+	})
+	return taskExecutor
+}
+
+func getPositiveIntEnv(name string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+)
+
+const (
+	notificationWebhookTimeout    = 10 * time.Second
+	notificationWebhookURLEnvName = "NOTIFICATION_WEBHOOK_URL"
+)
+
+var (
+	notifierOnce sync.Once
+	appNotifier  notifier.Notifier
+)
+
+// GetNotifier godoc
+// Returns the notifier of the events that need attention, like the break-glass access. The notifications are posted to
+// the webhook of NOTIFICATION_WEBHOOK_URL when it's set, otherwise they are only written to the log.
+func GetNotifier() notifier.Notifier {
+	notifierOnce.Do(func() {
+		webhookURL := os.Getenv(notificationWebhookURLEnvName)
+		if webhookURL == "" {
+			log.Printf("WARN: %s not set, notifications will only be written to the log", notificationWebhookURLEnvName)
+			appNotifier = notifier.NewLogNotifier()
+			return
+		}
+		appNotifier = notifier.NewWebhookNotifier(webhookURL, notificationWebhookTimeout)
+	})
+	return appNotifier
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultAccessExpirationCheckInterval = time.Minute
+	defaultRoleNamesRefreshInterval      = time.Minute
+)
+
+// GetAccessExpirationCheckInterval godoc
+// Interval between the checks of expired access permissions, that are revoked from the instances, e.g. 30s, 5m
+func GetAccessExpirationCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("ACCESS_EXPIRATION_CHECK_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return defaultAccessExpirationCheckInterval
+	}
+	return interval
+}
+
+// GetRoleNamesRefreshInterval godoc
+// Interval between the reloads of the names of the database roles, so the roles created or deleted through other
+// replicas of the API are recognized, e.g. 30s, 5m
+func GetRoleNamesRefreshInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("ROLE_NAMES_REFRESH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return defaultRoleNamesRefreshInterval
+	}
+	return interval
+}
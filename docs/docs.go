@@ -23,14 +23,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/access-permission/grant": {
-            "post": {
+        "/access-group": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Grant connection access to a set of users to a set of instances and their respective databases",
+                "description": "Get an access group with its targets and members",
                 "consumes": [
                     "application/json"
                 ],
@@ -38,25 +38,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access Permission"
+                    "Access Group"
                 ],
-                "summary": "Grant connection access to a set of users to a set of instances and their respective databases",
+                "summary": "Get an access group",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.GrantAccessInputDTO"
-                        }
+                        "type": "string",
+                        "description": "Access group ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GrantAccessResponse"
+                            "$ref": "#/definitions/handler.AccessGroupResponse"
                         }
                     },
                     "400": {
@@ -78,16 +76,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/access-permission/logs": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing access permission logs",
+                "description": "Create an access group with its targets, the instances and databases its members get access to. An instance without databases targets all its enabled databases.\nInstances of ecosystems that require approval can't be targets. The group is created without members, so nothing is granted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -95,28 +91,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access Permission"
+                    "Access Group"
                 ],
-                "summary": "List all existing access permission logs",
+                "summary": "Create an access group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessGroupInputDTO"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListAccessPermissionLogsResponse"
+                            "$ref": "#/definitions/handler.AccessGroupResponse"
                         }
                     },
                     "400": {
@@ -125,6 +118,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -134,14 +145,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/access-permission/revoke": {
-            "post": {
+        "/access-group/member": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "If no instance is provided, it revokes access from all instances accessible by the user.",
+                "description": "Remove the database user from the group and revoke the databases of its targets, except those still covered by another group of the user. The permissions granted directly are kept, even to databases the group covers.",
                 "consumes": [
                     "application/json"
                 ],
@@ -149,25 +160,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access Permission"
+                    "Access Group"
                 ],
-                "summary": "Revoke connection access to a specific user from a set of instances and their respective databases",
+                "summary": "Remove a member from an access group",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.RevokeAccessInputDTO"
-                        }
+                        "type": "string",
+                        "description": "Access group ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "databaseUserId",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.RevokeAccessResponse"
+                            "$ref": "#/definitions/handler.AccessGroupChangeResponse"
                         }
                     },
                     "400": {
@@ -191,14 +207,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/access-permissions": {
-            "get": {
+        "/access-group/members": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing access permissions established between users and databases",
+                "description": "Add the database users to the group and grant them the access of its targets.\nThe members are kept even if the grant fails, adding them again grants the access that couldn't be granted before.",
                 "consumes": [
                     "application/json"
                 ],
@@ -206,34 +222,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Access Permission"
+                    "Access Group"
                 ],
-                "summary": "List all existing access permissions established between users and databases",
+                "summary": "Add members to an access group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Database ID",
-                        "name": "databaseId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Database User ID",
-                        "name": "databaseUserId",
-                        "in": "query"
+                        "description": "Access group ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Database Instance ID",
-                        "name": "databaseInstanceId",
-                        "in": "query"
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessGroupMembersInputDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListAccessPermissionsResponse"
+                            "$ref": "#/definitions/handler.AccessGroupChangeResponse"
                         }
                     },
                     "400": {
@@ -242,6 +256,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -251,14 +271,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database": {
-            "get": {
+        "/access-group/targets": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an existing database",
+                "description": "Replace the targets of the group and reconcile the access of every member: the targets added are granted to all members, and the databases of the targets removed are revoked from each member.\nOnly the permissions granted by groups to databases no longer covered by a target of the groups of the member are revoked, keeping the permissions granted directly. The user is removed from an instance once no permission is left there.",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,23 +286,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database"
+                    "Access Group"
                 ],
-                "summary": "Get a database",
+                "summary": "Replace the targets of an access group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Database ID",
+                        "description": "Access group ID",
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessGroupTargetsInputDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetDatabaseResponse"
+                            "$ref": "#/definitions/handler.AccessGroupChangeResponse"
                         }
                     },
                     "400": {
@@ -291,6 +320,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -306,14 +341,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance": {
+        "/access-groups": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an existing database instance",
+                "description": "List the access groups by name, with the count of their targets and members",
                 "consumes": [
                     "application/json"
                 ],
@@ -321,35 +356,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Group"
                 ],
-                "summary": "Get a database instance",
+                "summary": "List the access groups",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Database Instance ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetDatabaseInstanceResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
+                            "$ref": "#/definitions/handler.ListAccessGroupsResponse"
                         }
                     },
                     "500": {
@@ -359,14 +387,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/access-permission/break-glass": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing database instance",
+                "description": "Grant the devops role to a user in the databases informed for a short, fixed window (BREAK_GLASS_WINDOW), even in ecosystems that require approval.\nThe incident reference is required. Every grant is logged with the BREAK_GLASS type, the access granted is notified and the role is revoked automatically when the window closes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -374,24 +404,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Update a database instance",
+                "summary": "Grant emergency access to a user in a set of databases, without approval",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Database Instance ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DatabaseInstanceInputDTO"
+                            "$ref": "#/definitions/dto.BreakGlassAccessInputDTO"
                         }
                     }
                 ],
@@ -399,7 +422,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDatabaseInstanceResponse"
+                            "$ref": "#/definitions/handler.BreakGlassAccessResponse"
                         }
                     },
                     "400": {
@@ -427,14 +450,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/access-permission/change-role": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new database instance",
+                "description": "Grant the privileges of the role in the database in place of the ones of the current role, without revoking the access of the user. Choosing the user's own role makes the permission follow it again.\nThe application role can't be given per database (400), and the permissions of instances of ecosystems that require approval can't be changed (403).\nThe own role of the user applies to the whole instance, so a role with no privilege beyond it is refused (400), except in the instances where the user has scoped access.",
                 "consumes": [
                     "application/json"
                 ],
@@ -442,25 +467,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Create a database instance",
+                "summary": "Change the role of a user in the database of an access permission",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access permission ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DatabaseInstanceInputDTO"
+                            "$ref": "#/definitions/dto.ChangeAccessPermissionRoleInputDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDatabaseInstanceResponse"
+                            "$ref": "#/definitions/handler.AccessPermissionResponse"
                         }
                     },
                     "400": {
@@ -469,14 +501,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -490,14 +522,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance/change-status": {
-            "patch": {
+        "/access-permission/grant": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Change the status of a database instance (cluster), enabling or disabling it. If disabled, it removes all access permissions from all database users that have access to the instance. It also deactivates all databases from the instance.",
+                "description": "Grant connection access to a set of users to a set of instances and their respective databases\nThe access to instances of ecosystems that require approval is refused (403), it must be requested through an access request\nThe users have their own role in the databases, unless a role is informed for a database in databasesRolesIds. The application role can't be informed per database.\nThe own role of a user applies to the whole instance, so a role with no privilege beyond it is refused (400), except in the instances where the user has scoped access.\nWith dryRun, the grant is validated and the statements it would execute are returned in the plan, by instance and database. Nothing is executed in the instances nor persisted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -505,9 +537,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Change the status of a database instance (cluster), enabling or disabling it. If disabled, it removes all access permissions from all database users that have access to the instance. It also deactivates all databases from the instance.",
+                "summary": "Grant connection access to a set of users to a set of instances and their respective databases",
                 "parameters": [
                     {
                         "description": "Request body",
@@ -515,15 +547,27 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangeStatusInputDTO"
+                            "$ref": "#/definitions/dto.GrantAccessInputDTO"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ChangeStatusResponse"
+                            "$ref": "#/definitions/handler.GrantAccessResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
                         }
                     },
                     "400": {
@@ -532,6 +576,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -547,38 +597,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance/credentials": {
-            "get": {
+        "/access-permission/grant/stream": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get credentials of an existing database instance",
+                "description": "Same as the grant of access, answered with a text/event-stream. Each message about an instance, a user or a database is sent as a \"progress\" event (dto.ProgressEventDTO) and each user processed in an instance as an \"item\" event (dto.ItemProgressEventDTO).\nThe stream ends with a \"result\" event with the dto.GrantAccessOutputDTO, or an \"error\" event with the body of an error response.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Get credentials of a specific database instance",
+                "summary": "Grant connection access streaming the progress of the operation as Server-Sent Events",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Database Instance ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GrantAccessInputDTO"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Data of the result event",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetDatabaseInstanceCredentialsResponse"
+                            "$ref": "#/definitions/dto.GrantAccessOutputDTO"
                         }
                     },
                     "400": {
@@ -587,8 +639,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -602,14 +654,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance/propagate-roles": {
-            "post": {
+        "/access-permission/logs": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances",
+                "description": "List all existing access permission logs",
                 "consumes": [
                     "application/json"
                 ],
@@ -617,24 +669,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances",
+                "summary": "List all existing access permission logs",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.PropagateRolesInputDTO"
-                        }
+                        "enum": [
+                            "ACCESS",
+                            "BREAK_GLASS",
+                            "RECONCILE"
+                        ],
+                        "type": "string",
+                        "description": "Log type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.PropagateRolesResponse"
+                            "$ref": "#/definitions/handler.ListAccessPermissionLogsResponse"
                         }
                     },
                     "400": {
@@ -643,12 +710,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -658,14 +719,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance/sync-databases": {
+        "/access-permission/reconcile": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Sync databases from selected database instances, if instances ids are not provided, sync all enabled instances",
+                "description": "Reports, for each instance, the drifts between the stored access permissions and the access the users have in the instance: MISSING_USER (the user doesn't exist in the instance), MISSING_GRANT (the user can't connect to a permitted database or lacks its role) and EXTRA_GRANT (the user can connect to a database without permission, or exists in the instance without any permission).\nWith apply, the drifts are corrected by granting the access again, recreating the missing users, removing the users without permissions and revoking the access to the extra databases. Each correction is logged as a RECONCILE access permission log.",
                 "consumes": [
                     "application/json"
                 ],
@@ -673,24 +734,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Sync databases from selected database instances, if instances ids are not provided, sync all enabled instances",
+                "summary": "Compare the access permissions with the effective access in the selected database instances, if instances ids are not provided, compare in all enabled instances",
                 "parameters": [
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/dto.SyncDatabasesInputDTO"
+                            "$ref": "#/definitions/dto.ReconcileAccessInputDTO"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SyncDatabasesResponse"
+                            "$ref": "#/definitions/handler.ReconcileAccessResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
                         }
                     },
                     "400": {
@@ -714,14 +787,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instance/test-connection": {
+        "/access-permission/revoke": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Test connection with the selected database instances, if instances ids are not provided, test connection with all enabled instances",
+                "description": "If no instance is provided, it revokes access from all instances accessible by the user.\nWith dryRun, the statements the revoke would execute are returned in the plan, by instance and database. Nothing is executed in the instances nor persisted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -729,16 +802,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "Test connection with the selected database instances, if instances ids are not provided, test connection with all enabled instances",
+                "summary": "Revoke connection access to a specific user from a set of instances and their respective databases",
                 "parameters": [
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TestConnectionInputDTO"
+                            "$ref": "#/definitions/dto.RevokeAccessInputDTO"
                         }
                     }
                 ],
@@ -746,7 +820,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.TestConnectionResponse"
+                            "$ref": "#/definitions/handler.RevokeAccessResponse"
                         }
                     },
                     "400": {
@@ -770,49 +844,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-instances": {
-            "get": {
+        "/access-permission/revoke/stream": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing database instances",
+                "description": "Same as the revoke of access, answered with a text/event-stream. Each message about an instance is sent as a \"progress\" event (dto.ProgressEventDTO) and each instance processed as an \"item\" event (dto.ItemProgressEventDTO).\nThe stream ends with a \"result\" event with the dto.RevokeAccessOutputDTO, or an \"error\" event with the body of an error response.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Database Instance"
+                    "Access Permission"
                 ],
-                "summary": "List all existing database instances",
+                "summary": "Revoke connection access streaming the progress of the operation as Server-Sent Events",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Ecosystem ID",
-                        "name": "ecosystemId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Database Technology ID",
-                        "name": "technologyId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Only Enabled Database Instances",
-                        "name": "onlyEnabled",
-                        "in": "query"
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevokeAccessInputDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Data of the result event",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListDatabaseInstancesResponse"
+                            "$ref": "#/definitions/dto.RevokeAccessOutputDTO"
                         }
                     },
                     "400": {
@@ -830,14 +895,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-roles": {
+        "/access-permissions": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing database roles",
+                "description": "List all existing access permissions established between users and databases",
                 "consumes": [
                     "application/json"
                 ],
@@ -845,14 +910,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database Role"
+                    "Access Permission"
+                ],
+                "summary": "List all existing access permissions established between users and databases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database ID",
+                        "name": "databaseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "databaseUserId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database Instance ID",
+                        "name": "databaseInstanceId",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all existing database roles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListDatabaseRolesResponse"
+                            "$ref": "#/definitions/handler.ListAccessPermissionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
                     "500": {
@@ -864,14 +955,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-user": {
+        "/access-request": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an existing database user",
+                "description": "Get an access request with its trail: the access requested and its justification, the review and the result of the grant once approved",
                 "consumes": [
                     "application/json"
                 ],
@@ -879,13 +970,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database User"
+                    "Access Request"
                 ],
-                "summary": "Get a database user",
+                "summary": "Get an access request",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Database User ID",
+                        "description": "Access request ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -895,7 +986,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetDatabaseUserResponse"
+                            "$ref": "#/definitions/handler.AccessRequestResponse"
                         }
                     },
                     "400": {
@@ -918,13 +1009,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing database user",
+                "description": "Request the same access of a grant, with a justification. The access is granted once the request is approved by another user, the only way to grant access to instances of ecosystems that require approval.\nThe request expires when not reviewed within ACCESS_REQUEST_TTL, or earlier when the access requested expires before that.",
                 "consumes": [
                     "application/json"
                 ],
@@ -932,32 +1023,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database User"
+                    "Access Request"
                 ],
-                "summary": "Update a database user",
+                "summary": "Request access to a set of instances and their respective databases",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Database User ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateDatabaseUserInputDTO"
+                            "$ref": "#/definitions/dto.AccessRequestInputDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDatabaseUserResponse"
+                            "$ref": "#/definitions/handler.AccessRequestResponse"
                         }
                     },
                     "400": {
@@ -966,18 +1050,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -985,14 +1057,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/access-request/approve": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new database user",
+                "description": "Approve a pending access request and grant the access requested right away, with the approver as operator. The approver must be a user other than the requester.\nThe request ends as EXECUTED with the result of the grant, that may have errors in some instances like a direct grant, or as FAILED when the grant could not run.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1000,25 +1074,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database User"
+                    "Access Request"
                 ],
-                "summary": "Create a database user",
+                "summary": "Approve an access request, granting the access requested",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access request ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.DatabaseUserInputDTO"
+                            "$ref": "#/definitions/dto.ReviewAccessRequestInputDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateDatabaseUserResponse"
+                            "$ref": "#/definitions/handler.AccessRequestResponse"
                         }
                     },
                     "400": {
@@ -1027,6 +1107,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1048,14 +1134,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-user/change-status": {
-            "patch": {
+        "/access-request/reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Change the status of a database user, enabling or disabling it. If disabled, it revokes access from all instances accessible by the user.",
+                "description": "Reject a pending access request, nothing is granted. The reviewer must be a user other than the requester.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1063,17 +1149,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database User"
+                    "Access Request"
                 ],
-                "summary": "Change the status of a database user, enabling or disabling it. If disabled, it revokes access from all instances accessible by the user.",
+                "summary": "Reject an access request",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access request ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ChangeStatusInputDTO"
+                            "$ref": "#/definitions/dto.ReviewAccessRequestInputDTO"
                         }
                     }
                 ],
@@ -1081,7 +1173,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ChangeStatusResponse"
+                            "$ref": "#/definitions/handler.AccessRequestResponse"
                         }
                     },
                     "400": {
@@ -1090,6 +1182,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1111,14 +1209,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-user/credentials": {
+        "/access-requests": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get credentials of an existing database user",
+                "description": "List the access requests from the most recent, with their review. The result of the grant of each request is returned by the get access request endpoint.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1126,23 +1224,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database User"
+                    "Access Request"
                 ],
-                "summary": "Get credentials of a specific database user",
+                "summary": "List the access requests",
                 "parameters": [
                     {
+                        "enum": [
+                            "REQUESTED",
+                            "APPROVED",
+                            "REJECTED",
+                            "EXECUTED",
+                            "FAILED",
+                            "EXPIRED"
+                        ],
                         "type": "string",
-                        "description": "Database User ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Access request status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the application user who requested the access",
+                        "name": "requestedByUserId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetDatabaseUserCredentialsResponse"
+                            "$ref": "#/definitions/handler.ListAccessRequestsResponse"
                         }
                     },
                     "400": {
@@ -1151,12 +1274,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1166,56 +1283,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/database-users": {
+        "/database": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing database users",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Database User"
-                ],
-                "summary": "List all existing database users",
-                "parameters": [
-                    {
-                        "type": "boolean",
-                        "description": "Only Enabled Users",
-                        "name": "onlyEnabled",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ListDatabaseUsersResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/database/setup-roles": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances",
+                "description": "Get an existing database",
                 "consumes": [
                     "application/json"
                 ],
@@ -1225,22 +1300,21 @@ const docTemplate = `{
                 "tags": [
                     "Database"
                 ],
-                "summary": "Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances",
+                "summary": "Get a database",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SetupRolesInputDTO"
-                        }
+                        "type": "string",
+                        "description": "Database ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.SetupRolesResponse"
+                            "$ref": "#/definitions/handler.GetDatabaseResponse"
                         }
                     },
                     "400": {
@@ -1264,14 +1338,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/databases": {
+        "/database-instance": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing databases",
+                "description": "Get an existing database instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1279,28 +1353,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Database"
+                    "Database Instance"
                 ],
-                "summary": "List all existing databases",
+                "summary": "Get a database instance",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Ecosystem ID",
-                        "name": "ecosystemId",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Database Instance ID",
-                        "name": "databaseInstanceId",
-                        "in": "query"
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListDatabasesResponse"
+                            "$ref": "#/definitions/handler.GetDatabaseInstanceResponse"
                         }
                     },
                     "400": {
@@ -1309,6 +1378,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1316,16 +1391,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/ecosystem": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an existing ecosystem",
+                "description": "Update an existing database instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1333,23 +1406,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ecosystem"
+                    "Database Instance"
                 ],
-                "summary": "Get an ecosystem",
+                "summary": "Update a database instance",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ecosystem ID",
+                        "description": "Database Instance ID",
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DatabaseInstanceInputDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetEcosystemResponse"
+                            "$ref": "#/definitions/handler.CreateDatabaseInstanceResponse"
                         }
                     },
                     "400": {
@@ -1364,6 +1446,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1372,13 +1460,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing ecosystem",
+                "description": "Create a new database instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1386,32 +1474,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ecosystem"
+                    "Database Instance"
                 ],
-                "summary": "Update an ecosystem",
+                "summary": "Create a database instance",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Ecosystem ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.EcosystemInputDTO"
+                            "$ref": "#/definitions/dto.DatabaseInstanceInputDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateEcosystemResponse"
+                            "$ref": "#/definitions/handler.CreateDatabaseInstanceResponse"
                         }
                     },
                     "400": {
@@ -1439,14 +1520,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/database-instance/change-status": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new ecosystem",
+                "description": "Change the status of a database instance (cluster), enabling or disabling it. If disabled, it removes all access permissions from all database users that have access to the instance. It also deactivates all databases from the instance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1454,9 +1537,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ecosystem"
+                    "Database Instance"
                 ],
-                "summary": "Create an ecosystem",
+                "summary": "Change the status of a database instance (cluster), enabling or disabling it. If disabled, it removes all access permissions from all database users that have access to the instance. It also deactivates all databases from the instance.",
                 "parameters": [
                     {
                         "description": "Request body",
@@ -1464,15 +1547,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.EcosystemInputDTO"
+                            "$ref": "#/definitions/dto.ChangeStatusInputDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateEcosystemResponse"
+                            "$ref": "#/definitions/handler.ChangeStatusResponse"
                         }
                     },
                     "400": {
@@ -1481,8 +1564,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
@@ -1494,14 +1577,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/database-instance/credentials": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing ecosystem",
+                "description": "Get credentials of an existing database instance",
                 "consumes": [
                     "application/json"
                 ],
@@ -1509,13 +1594,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ecosystem"
+                    "Database Instance"
                 ],
-                "summary": "Delete an ecosystem",
+                "summary": "Get credentials of a specific database instance",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ecosystem ID",
+                        "description": "Database Instance ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -1525,7 +1610,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteEcosystemResponse"
+                            "$ref": "#/definitions/handler.GetDatabaseInstanceCredentialsResponse"
                         }
                     },
                     "400": {
@@ -1549,14 +1634,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ecosystems": {
-            "get": {
+        "/database-instance/discover-roles": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing ecosystems",
+                "description": "Reads the logins of each instance and classifies them as MANAGED, MISMATCHED (a Data Guard user whose permissions don't match), UNKNOWN (created outside Data Guard), PRIVILEGED (CREATEROLE or BYPASSRLS) or SUPERUSER. The findings replace the ones of the previous discovery of the instance and are listed by the role findings endpoint.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1564,20 +1649,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ecosystem"
+                    "Database Instance"
                 ],
-                "summary": "List all existing ecosystems",
+                "summary": "Discover the logins of the selected database instances, if instances ids are not provided, discover in all enabled instances",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DiscoverRolesInputDTO"
+                        }
                     },
                     {
-                        "type": "integer",
-                        "description": "Limit per page",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
                         "in": "query"
                     }
                 ],
@@ -1585,7 +1672,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListEcosystemsResponse"
+                            "$ref": "#/definitions/handler.DiscoverRolesResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
                         }
                     },
                     "400": {
@@ -1594,6 +1687,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1603,14 +1702,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/technologies": {
-            "get": {
+        "/database-instance/propagate-roles": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "List all existing technologies",
+                "description": "Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances\nWith dryRun, each result has the statements that would be executed in the instance, by database. Nothing is executed nor persisted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1618,20 +1717,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Technology"
+                    "Database Instance"
                 ],
-                "summary": "List all existing technologies",
+                "summary": "Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PropagateRolesInputDTO"
+                        }
                     },
                     {
-                        "type": "integer",
-                        "description": "Limit per page",
-                        "name": "limit",
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
                         "in": "query"
                     }
                 ],
@@ -1639,7 +1740,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.ListTechnologiesResponse"
+                            "$ref": "#/definitions/handler.PropagateRolesResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
                         }
                     },
                     "400": {
@@ -1648,6 +1755,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1657,14 +1770,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/technology": {
-            "get": {
+        "/database-instance/sync-databases": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get an existing technology",
+                "description": "Sync databases from selected database instances, if instances ids are not provided, sync all enabled instances",
                 "consumes": [
                     "application/json"
                 ],
@@ -1672,14 +1785,198 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Technology"
+                    "Database Instance"
                 ],
-                "summary": "Get a technology",
+                "summary": "Sync databases from selected database instances, if instances ids are not provided, sync all enabled instances",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Technology ID",
-                        "name": "id",
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncDatabasesInputDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncDatabasesResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-instance/test-connection": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Test connection with the selected database instances, if instances ids are not provided, test connection with all enabled instances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database Instance"
+                ],
+                "summary": "Test connection with the selected database instances, if instances ids are not provided, test connection with all enabled instances",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TestConnectionInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.TestConnectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-instances": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing database instances",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database Instance"
+                ],
+                "summary": "List all existing database instances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "ecosystemId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database Technology ID",
+                        "name": "technologyId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only Enabled Database Instances",
+                        "name": "onlyEnabled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListDatabaseInstancesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-role": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an existing database role with its privileges",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database Role"
+                ],
+                "summary": "Get a database role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database Role ID",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
@@ -1688,7 +1985,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.GetTechnologyResponse"
+                            "$ref": "#/definitions/handler.GetDatabaseRoleResponse"
                         }
                     },
                     "400": {
@@ -1717,7 +2014,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing technology",
+                "description": "Update the display name, description and privileges of a database role. The name can't be changed.\nThe new privileges apply to each database the next time the grants are set up in it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1725,13 +2022,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Technology"
+                    "Database Role"
                 ],
-                "summary": "Update a technology",
+                "summary": "Update a database role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Technology ID",
+                        "description": "Database Role ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -1742,7 +2039,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TechnologyInputDTO"
+                            "$ref": "#/definitions/dto.UpdateDatabaseRoleInputDTO"
                         }
                     }
                 ],
@@ -1750,7 +2047,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateTechnologyResponse"
+                            "$ref": "#/definitions/handler.UpdateDatabaseRoleResponse"
                         }
                     },
                     "400": {
@@ -1765,12 +2062,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1785,7 +2076,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new technology",
+                "description": "Create a new database role with its privileges, in the vocabulary of PostgreSQL, which the connectors translate to each technology.\nThe role must be propagated to the instances and the grants set up in their databases before being given to users.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1793,9 +2084,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Technology"
+                    "Database Role"
                 ],
-                "summary": "Create a technology",
+                "summary": "Create a database role",
                 "parameters": [
                     {
                         "description": "Request body",
@@ -1803,7 +2094,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TechnologyInputDTO"
+                            "$ref": "#/definitions/dto.DatabaseRoleInputDTO"
                         }
                     }
                 ],
@@ -1811,7 +2102,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateTechnologyResponse"
+                            "$ref": "#/definitions/handler.CreateDatabaseRoleResponse"
                         }
                     },
                     "400": {
@@ -1840,7 +2131,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing technology",
+                "description": "Delete a database role that no database user or access permission uses. The predefined roles can't be deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1848,13 +2139,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Technology"
+                    "Database Role"
                 ],
-                "summary": "Delete a technology",
+                "summary": "Delete a database role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Technology ID",
+                        "description": "Database Role ID",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -1864,7 +2155,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.DeleteTechnologyResponse"
+                            "$ref": "#/definitions/handler.DeleteDatabaseRoleResponse"
                         }
                     },
                     "400": {
@@ -1879,6 +2170,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1887,188 +2184,3367 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AccessPermissionLogOutputDTO": {
-            "type": "object",
-            "properties": {
-                "databaseId": {
-                    "type": "string"
-                },
-                "databaseInstanceId": {
-                    "type": "string"
-                },
-                "databaseInstanceName": {
-                    "type": "string"
-                },
-                "databaseName": {
-                    "type": "string"
-                },
-                "databaseUserEmail": {
-                    "type": "string"
-                },
-                "databaseUserId": {
-                    "type": "string"
-                },
-                "databaseUserName": {
-                    "type": "string"
-                },
-                "date": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "operationUserId": {
-                    "type": "string"
-                },
-                "operationUserName": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                }
-            }
+        },
+        "/database-roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing database roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database Role"
+                ],
+                "summary": "List all existing database roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListDatabaseRolesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-user": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an existing database user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Get a database user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetDatabaseUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing database user\nThe role of a user with access permissions can't be changed here (409), use /database-user/migrate-role instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Update a database user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateDatabaseUserInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateDatabaseUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new database user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Create a database user",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DatabaseUserInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateDatabaseUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-user/change-status": {
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the status of a database user, enabling or disabling it. If disabled, it revokes access from all instances accessible by the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Change the status of a database user, enabling or disabling it. If disabled, it revokes access from all instances accessible by the user.",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangeStatusInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChangeStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-user/credentials": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get credentials of an existing database user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Get credentials of a specific database user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetDatabaseUserCredentialsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-user/migrate-role": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Grant the new role and revoke the old one on every instance where the user exists, logging the outcome of each instance. The databases with a role of their own keep it.\nThe user keeps the old role until every instance is migrated (hasErrors); the migration can be run again to finish it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "Migrate the role of a database user with access permissions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Database User ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MigrateDatabaseUserRoleInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.MigrateDatabaseUserRoleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database-users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing database users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database User"
+                ],
+                "summary": "List all existing database users",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only Enabled Users",
+                        "name": "onlyEnabled",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListDatabaseUsersResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/database/setup-roles": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances\nWith dryRun, each result has the statements that would be executed in the database. Nothing is executed nor persisted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database"
+                ],
+                "summary": "Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetupRolesInputDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Process the operation in background as a job, returning the job right away",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SetupRolesResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.SubmitJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/databases": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing databases",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database"
+                ],
+                "summary": "List all existing databases",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "ecosystemId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database Instance ID",
+                        "name": "databaseInstanceId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListDatabasesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/databases/outdated-roles": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the enabled databases whose roles weren't set up with the current roles template, i.e. never set up or set up before a change in the roles or in the grants generated by the connectors.\nSetup roles with onlyOutdated to re-apply the grants only in these databases.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database"
+                ],
+                "summary": "List the databases with outdated roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "ecosystemId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Database Instance ID",
+                        "name": "databaseInstanceId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListOutdatedRolesDatabasesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/desired-state/apply": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Plans the desired state, as the plan endpoint does, and applies each change in order through the same operations of the API. A change that fails doesn't stop the others: each change is returned with its result, and hasErrors is true when any failed.\nWith prune, what the desired state doesn't mention is removed as well.",
+                "consumes": [
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Desired State"
+                ],
+                "summary": "Apply a desired state file to the stored ecosystems, instances, database users, access groups and access permissions",
+                "parameters": [
+                    {
+                        "description": "Desired state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DesiredStateInputDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also remove what the desired state doesn't mention",
+                        "name": "prune",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DesiredStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/desired-state/plan": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Receives the desired state as YAML (or JSON) and returns the changes needed to reach it, in the order they are applied: creates, updates, group changes and grants. Nothing is changed.\nWith prune, the changes also remove what the file doesn't mention: members removed from the groups, access to instances and databases revoked, users and instances disabled and ecosystems deleted. Differences that can't be changed are returned as warnings.",
+                "consumes": [
+                    "application/x-yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Desired State"
+                ],
+                "summary": "Compare a desired state file with the stored ecosystems, instances, database users, access groups and access permissions",
+                "parameters": [
+                    {
+                        "description": "Desired state",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DesiredStateInputDTO"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also remove what the desired state doesn't mention",
+                        "name": "prune",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DesiredStateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ecosystem": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an existing ecosystem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ecosystem"
+                ],
+                "summary": "Get an ecosystem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetEcosystemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing ecosystem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ecosystem"
+                ],
+                "summary": "Update an ecosystem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EcosystemInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateEcosystemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new ecosystem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ecosystem"
+                ],
+                "summary": "Create an ecosystem",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EcosystemInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateEcosystemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing ecosystem",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ecosystem"
+                ],
+                "summary": "Delete an ecosystem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ecosystem ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteEcosystemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ecosystems": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing ecosystems",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ecosystem"
+                ],
+                "summary": "List all existing ecosystems",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListEcosystemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/job": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a job with its status, progress counters, the result of each processed item and the result of the operation once finished",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job"
+                ],
+                "summary": "Get a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the jobs from the most recent, with their status and progress counters. The items and the result of each job are returned by the get job endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job"
+                ],
+                "summary": "List the jobs",
+                "parameters": [
+                    {
+                        "enum": [
+                            "GRANT_ACCESS",
+                            "SYNC_DATABASES",
+                            "SETUP_ROLES",
+                            "PROPAGATE_ROLES",
+                            "DISCOVER_ROLES",
+                            "RECONCILE_ACCESS"
+                        ],
+                        "type": "string",
+                        "description": "Job type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "RUNNING",
+                            "SUCCEEDED",
+                            "FAILED"
+                        ],
+                        "type": "string",
+                        "description": "Job status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListJobsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaign": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a recertification campaign with the count of its items by decision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "Get an access recertification campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recertification campaign ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecertificationCampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Take a snapshot of the current access permissions, all of them or those of an ecosystem and/or of the database users of a team, and assign them to the reviewers.\nAll permissions of a database user are assigned to the same reviewer, the users are distributed among the reviewers in turn.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "Create an access recertification campaign",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecertificationCampaignInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecertificationCampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaign/close": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Revoke the access of the items decided as REVOKE and close the campaign, so no more decisions are accepted. Items still pending are kept.\nOnly the database of each item is revoked, the other permissions of the user in the instance are kept. The user is removed from the instance only when no permission is left there. A permission removed since the review isn't revoked, so an access granted again afterward is kept.\nWhen a revocation fails, it's returned with hasErrors and the campaign is kept open: closing it again retries the revocations still pending.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "Close an access recertification campaign, revoking the permissions decided to be revoked",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recertification campaign ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CloseRecertificationCampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaign/decide": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record whether each permission should be kept or revoked. Only the reviewer assigned to an item can decide it, and the decision may be changed while the campaign is open.\nNo decision is recorded when any of the items is invalid. The revocations run when the campaign is closed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "Record the decisions of a reviewer in an access recertification campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recertification campaign ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DecideRecertificationItemsInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecertificationCampaignResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaign/items": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the permissions under review in a campaign, ordered by database user, instance and database, with the decision of their reviewer.\nFilter by reviewerId and decision PENDING to get the work left to a reviewer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "List the items of an access recertification campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recertification campaign ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the application user assigned as reviewer",
+                        "name": "reviewerId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "PENDING",
+                            "KEEP",
+                            "REVOKE"
+                        ],
+                        "type": "string",
+                        "description": "Decision of the reviewer",
+                        "name": "decision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListRecertificationItemsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaign/report": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Export every permission reviewed in the campaign, as it was when the campaign was created, with the reviewer, the decision and its comment and the result of the revocation.\nWith format csv the report is downloaded as a CSV file, one line per permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "Export the evidence report of an access recertification campaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recertification campaign ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Format of the report, json by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.RecertificationReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recertification-campaigns": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the recertification campaigns from the most recent, with the count of their items by decision",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recertification"
+                ],
+                "summary": "List the access recertification campaigns",
+                "parameters": [
+                    {
+                        "enum": [
+                            "OPEN",
+                            "CLOSED"
+                        ],
+                        "type": "string",
+                        "description": "Recertification campaign status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListRecertificationCampaignsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/role-findings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the logins found by the last role discovery of each database instance, from the most dangerous class (SUPERUSER) to the least (MANAGED), with the reasons of their class and when they were discovered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Database Instance"
+                ],
+                "summary": "List the logins found by the last role discovery of each database instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Database instance ID",
+                        "name": "databaseInstanceId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "MANAGED",
+                            "MISMATCHED",
+                            "UNKNOWN",
+                            "PRIVILEGED",
+                            "SUPERUSER"
+                        ],
+                        "type": "string",
+                        "description": "Class of the login",
+                        "name": "class",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListRoleFindingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/technologies": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all existing technologies",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Technology"
+                ],
+                "summary": "List all existing technologies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListTechnologiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/technology": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an existing technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Technology"
+                ],
+                "summary": "Get a technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GetTechnologyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Technology"
+                ],
+                "summary": "Update a technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TechnologyInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateTechnologyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Technology"
+                ],
+                "summary": "Create a technology",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TechnologyInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTechnologyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing technology",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Technology"
+                ],
+                "summary": "Delete a technology",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Technology ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.DeleteTechnologyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AccessDriftDTO": {
+            "type": "object",
+            "properties": {
+                "corrected": {
+                    "type": "boolean"
+                },
+                "correction": {
+                    "type": "string"
+                },
+                "database": {
+                    "type": "string"
+                },
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessGroupChangeOutputDTO": {
+            "type": "object",
+            "properties": {
+                "grant": {
+                    "$ref": "#/definitions/dto.GrantAccessOutputDTO"
+                },
+                "group": {
+                    "$ref": "#/definitions/dto.AccessGroupOutputDTO"
+                },
+                "hasErrors": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "revocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResultDTO"
+                    }
+                }
+            }
+        },
+        "dto.AccessGroupInputDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "instancesData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstanceDataDTO"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Team B analysts"
+                }
+            }
+        },
+        "dto.AccessGroupMemberOutputDTO": {
+            "type": "object",
+            "properties": {
+                "addedAt": {
+                    "type": "string"
+                },
+                "addedByUser": {
+                    "type": "string"
+                },
+                "addedByUserId": {
+                    "type": "string"
+                },
+                "databaseUserEmail": {
+                    "type": "string"
+                },
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "databaseUserName": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessGroupMembersInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseUsersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AccessGroupOutputDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUser": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccessGroupMemberOutputDTO"
+                    }
+                },
+                "membersQty": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccessGroupTargetOutputDTO"
+                    }
+                },
+                "targetsQty": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessGroupTargetOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseId": {
+                    "type": "string"
+                },
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databaseInstanceName": {
+                    "type": "string"
+                },
+                "databaseName": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessGroupTargetsInputDTO": {
+            "type": "object",
+            "properties": {
+                "instancesData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstanceDataDTO"
+                    }
+                }
+            }
+        },
+        "dto.AccessPermissionLogOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseId": {
+                    "type": "string"
+                },
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databaseInstanceName": {
+                    "type": "string"
+                },
+                "databaseName": {
+                    "type": "string"
+                },
+                "databaseUserEmail": {
+                    "type": "string"
+                },
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "databaseUserName": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "operationUserId": {
+                    "type": "string"
+                },
+                "operationUserName": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
         },
         "dto.AccessPermissionOutputDTO": {
             "type": "object",
             "properties": {
-                "databaseId": {
+                "databaseId": {
+                    "type": "string"
+                },
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databaseInstanceName": {
+                    "type": "string"
+                },
+                "databaseName": {
+                    "type": "string"
+                },
+                "databaseRoleId": {
+                    "type": "string"
+                },
+                "databaseRoleName": {
+                    "type": "string"
+                },
+                "databaseUserEmail": {
+                    "type": "string"
+                },
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "databaseUserName": {
+                    "type": "string"
+                },
+                "databaseUserTeam": {
+                    "type": "string"
+                },
+                "ecosystemId": {
+                    "type": "string"
+                },
+                "ecosystemName": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "grantedAt": {
+                    "type": "string"
+                },
+                "grantedByAccessGroupId": {
+                    "description": "GrantedByAccessGroupID is the access group that granted the permission, when not granted directly",
+                    "type": "string"
+                },
+                "grantedByUserId": {
+                    "type": "string"
+                },
+                "grantedByUserName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "Scope is the allowlist of schemas and tables the user can access, when not the whole database",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.AccessScopeDTO"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.AccessRequestInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseUsersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "description": "DryRun validates the operation and returns the statements it would execute in the instances, without executing them",
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the permissions granted are revoked automatically. They never expire when it's not informed.\nThe permissions the users already have in the databases are extended to it when they expire sooner.",
+                    "type": "string",
+                    "example": "2024-06-01T18:00:00Z"
+                },
+                "instancesData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstanceDataDTO"
+                    }
+                },
+                "justification": {
+                    "type": "string",
+                    "example": "On-call investigation of incident INC-42"
+                }
+            }
+        },
+        "dto.AccessRequestOutputDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "executedAt": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "input": {
+                    "type": "object"
+                },
+                "justification": {
+                    "type": "string"
+                },
+                "requestedByUser": {
+                    "type": "string"
+                },
+                "requestedByUserId": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "reviewComment": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "reviewedByUser": {
+                    "type": "string"
+                },
+                "reviewedByUserId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessScopeDTO": {
+            "type": "object",
+            "properties": {
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "reporting"
+                    ]
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
+                }
+            }
+        },
+        "dto.BreakGlassAccessInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "incidentReference": {
+                    "type": "string",
+                    "example": "INC-1234 orders database down"
+                },
+                "instancesData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstanceDataDTO"
+                    }
+                }
+            }
+        },
+        "dto.ChangeAccessPermissionRoleInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseRoleId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangeStatusInputDTO": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangeStatusOutputDTO": {
+            "type": "object",
+            "properties": {
+                "disabledAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CloseRecertificationCampaignOutputDTO": {
+            "type": "object",
+            "properties": {
+                "campaign": {
+                    "$ref": "#/definitions/dto.RecertificationCampaignOutputDTO"
+                },
+                "hasErrors": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "revocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResultDTO"
+                    }
+                }
+            }
+        },
+        "dto.DatabaseInstanceCredentialsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseInstanceInputDTO": {
+            "type": "object",
+            "properties": {
+                "adminPassword": {
+                    "type": "string"
+                },
+                "adminUser": {
+                    "type": "string"
+                },
+                "bastionHost": {
+                    "type": "string"
+                },
+                "bastionHostKey": {
+                    "type": "string"
+                },
+                "bastionPassword": {
+                    "type": "string"
+                },
+                "bastionPort": {
+                    "type": "string"
+                },
+                "bastionPrivateKey": {
+                    "type": "string"
+                },
+                "bastionUser": {
+                    "type": "string"
+                },
+                "caCertificate": {
+                    "type": "string"
+                },
+                "clearCertificates": {
+                    "description": "ClearCertificates removes the current certificates of the instance on update, before storing the informed ones",
+                    "type": "boolean"
+                },
+                "clientCertificate": {
+                    "type": "string"
+                },
+                "clientKey": {
+                    "type": "string"
+                },
+                "databaseTechnologyId": {
+                    "type": "string"
+                },
+                "ecosystemId": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "hostConnection": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "portConnection": {
+                    "type": "string"
+                },
+                "sslMode": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseInstanceOutputDTO": {
+            "type": "object",
+            "properties": {
+                "adminPassword": {
+                    "type": "string"
+                },
+                "adminUser": {
+                    "type": "string"
+                },
+                "bastionHost": {
+                    "type": "string"
+                },
+                "bastionHostKey": {
+                    "type": "string"
+                },
+                "bastionPort": {
+                    "type": "string"
+                },
+                "bastionUser": {
+                    "type": "string"
+                },
+                "connectionStatus": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUser": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "databaseTechnologyId": {
+                    "type": "string"
+                },
+                "databaseTechnologyName": {
+                    "type": "string"
+                },
+                "databaseTechnologyVersion": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "ecosystemId": {
+                    "type": "string"
+                },
+                "ecosystemName": {
+                    "type": "string"
+                },
+                "ecosystemRequiresApproval": {
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "hostConnection": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastConnectionResult": {
+                    "type": "string"
+                },
+                "lastConnectionTest": {
+                    "type": "string"
+                },
+                "lastDatabaseSync": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "portConnection": {
+                    "type": "string"
+                },
+                "rolesCreated": {
+                    "type": "boolean"
+                },
+                "sslMode": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseOutputDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUser": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "currentSize": {
+                    "type": "string"
+                },
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databaseInstanceName": {
+                    "type": "string"
+                },
+                "databaseTechnologyId": {
+                    "type": "string"
+                },
+                "databaseTechnologyName": {
+                    "type": "string"
+                },
+                "databaseTechnologyVersion": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "ecosystemId": {
+                    "type": "string"
+                },
+                "ecosystemName": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastDatabaseSync": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rolesConfigured": {
+                    "type": "boolean"
+                },
+                "rolesConfiguredAt": {
+                    "type": "string"
+                },
+                "rolesTemplateChecksum": {
+                    "type": "string"
+                },
+                "rolesTemplateVersion": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseRoleInputDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "privileges": {
+                    "$ref": "#/definitions/dto.RolePrivilegesDTO"
+                }
+            }
+        },
+        "dto.DatabaseRoleOutputDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "privileges": {
+                    "$ref": "#/definitions/dto.RolePrivilegesDTO"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseUserCredentialsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseUserInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseRoleId": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DatabaseUserOutputDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUser": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "databaseRoleDisplayName": {
+                    "type": "string"
+                },
+                "databaseRoleId": {
+                    "type": "string"
+                },
+                "databaseRoleName": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DecideRecertificationItemsInputDTO": {
+            "type": "object",
+            "properties": {
+                "decisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RecertificationDecisionInputDTO"
+                    }
+                }
+            }
+        },
+        "dto.DefaultPrivilegesDTO": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sequence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "table": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DesiredEcosystemDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "requiresAccessApproval": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.DesiredGrantDTO": {
+            "type": "object",
+            "properties": {
+                "databases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DesiredGroupDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "targets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredTargetDTO"
+                    }
+                }
+            }
+        },
+        "dto.DesiredInstanceDTO": {
+            "type": "object",
+            "properties": {
+                "adminPassword": {
                     "type": "string"
                 },
-                "databaseInstanceId": {
+                "adminUser": {
                     "type": "string"
                 },
-                "databaseInstanceName": {
+                "bastionHost": {
                     "type": "string"
                 },
-                "databaseName": {
+                "bastionHostKey": {
                     "type": "string"
                 },
-                "databaseRoleId": {
+                "bastionPort": {
                     "type": "string"
                 },
-                "databaseRoleName": {
+                "bastionUser": {
                     "type": "string"
                 },
-                "databaseUserEmail": {
+                "ecosystem": {
                     "type": "string"
                 },
-                "databaseUserId": {
+                "host": {
                     "type": "string"
                 },
-                "databaseUserName": {
+                "hostConnection": {
                     "type": "string"
                 },
-                "ecosystemId": {
+                "name": {
                     "type": "string"
                 },
-                "ecosystemName": {
+                "note": {
                     "type": "string"
                 },
-                "grantedAt": {
+                "port": {
                     "type": "string"
                 },
-                "grantedByUserId": {
+                "portConnection": {
                     "type": "string"
                 },
-                "grantedByUserName": {
+                "sslMode": {
                     "type": "string"
                 },
-                "id": {
+                "technology": {
+                    "type": "string"
+                },
+                "technologyVersion": {
                     "type": "string"
                 }
             }
         },
-        "dto.ChangeStatusInputDTO": {
+        "dto.DesiredStateChangeDTO": {
             "type": "object",
             "properties": {
-                "enabled": {
+                "action": {
+                    "type": "string",
+                    "example": "UPDATE"
+                },
+                "details": {
+                    "type": "string",
+                    "example": "team: Team A -\u003e Team B"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "USER"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "johndoe@email.com"
+                },
+                "success": {
                     "type": "boolean"
+                }
+            }
+        },
+        "dto.DesiredStateInputDTO": {
+            "type": "object",
+            "properties": {
+                "ecosystems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredEcosystemDTO"
+                    }
                 },
-                "id": {
+                "grants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredGrantDTO"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredGroupDTO"
+                    }
+                },
+                "instances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredInstanceDTO"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredUserDTO"
+                    }
+                }
+            }
+        },
+        "dto.DesiredStateOutputDTO": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DesiredStateChangeDTO"
+                    }
+                },
+                "hasErrors": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Warnings are the differences from the desired state that can't be changed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DesiredTargetDTO": {
+            "type": "object",
+            "properties": {
+                "databases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
                     "type": "string"
                 }
             }
         },
-        "dto.ChangeStatusOutputDTO": {
+        "dto.DesiredUserDTO": {
             "type": "object",
             "properties": {
-                "disabledAt": {
+                "email": {
                     "type": "string"
                 },
-                "enabled": {
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DiscoverRolesInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstancesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DiscoverRolesOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "ecosystem": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "loginsByClass": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "queueTimeMs": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "technology": {
+                    "type": "string"
+                },
+                "totalLogins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DryRunDatabaseOutputDTO": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "type": "string"
+                },
+                "statements": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.DryRunInstanceOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DryRunDatabaseOutputDTO"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EcosystemInputDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "requiresAccessApproval": {
                     "type": "boolean"
+                }
+            }
+        },
+        "dto.EcosystemOutputDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdByUser": {
+                    "type": "string"
+                },
+                "createdByUserId": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "requiresAccessApproval": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExecutionOutputDTO": {
+            "type": "object",
+            "properties": {
+                "maxConcurrency": {
+                    "type": "integer"
+                },
+                "maxConcurrencyPerInstance": {
+                    "type": "integer"
+                },
+                "maxQueueTimeMs": {
+                    "type": "integer"
+                },
+                "queuedTasks": {
+                    "type": "integer"
+                },
+                "saturated": {
+                    "type": "boolean"
+                },
+                "tasks": {
+                    "type": "integer"
+                },
+                "totalQueueTimeMs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GrantAccessInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseUsersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "dryRun": {
+                    "description": "DryRun validates the operation and returns the statements it would execute in the instances, without executing them",
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is when the permissions granted are revoked automatically. They never expire when it's not informed.\nThe permissions the users already have in the databases are extended to it when they expire sooner.",
+                    "type": "string",
+                    "example": "2024-06-01T18:00:00Z"
+                },
+                "instancesData": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InstanceDataDTO"
+                    }
+                }
+            }
+        },
+        "dto.GrantAccessOutputDTO": {
+            "type": "object",
+            "properties": {
+                "execution": {
+                    "$ref": "#/definitions/dto.ExecutionOutputDTO"
+                },
+                "hasErrors": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "plan": {
+                    "description": "Plan has the statements the grant would execute in each instance, in dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DryRunInstanceOutputDTO"
+                    }
                 }
             }
         },
-        "dto.DatabaseInstanceCredentialsOutputDTO": {
+        "dto.GrantBreakGlassAccessOutputDTO": {
             "type": "object",
             "properties": {
-                "password": {
+                "databases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResultDTO"
+                    }
+                },
+                "expiresAt": {
                     "type": "string"
                 },
-                "user": {
+                "hasErrors": {
+                    "type": "boolean"
+                },
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "dto.DatabaseInstanceInputDTO": {
+        "dto.InstanceDataDTO": {
             "type": "object",
             "properties": {
-                "adminPassword": {
-                    "type": "string"
-                },
-                "adminUser": {
-                    "type": "string"
-                },
-                "databaseTechnologyId": {
-                    "type": "string"
-                },
-                "ecosystemId": {
-                    "type": "string"
-                },
-                "host": {
-                    "type": "string"
-                },
-                "hostConnection": {
-                    "type": "string"
-                },
-                "name": {
+                "databaseInstanceId": {
                     "type": "string"
                 },
-                "note": {
-                    "type": "string"
+                "databasesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "port": {
-                    "type": "string"
+                "databasesRolesIds": {
+                    "description": "DatabasesRolesIDs is the role of the users in some of the databases informed, by database id, instead of their own role",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "portConnection": {
-                    "type": "string"
+                "databasesScopes": {
+                    "description": "DatabasesScopes restricts the access to some schemas and tables of some of the databases informed, by database id",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.AccessScopeDTO"
+                    }
                 }
             }
         },
-        "dto.DatabaseInstanceOutputDTO": {
+        "dto.ItemResultDTO": {
             "type": "object",
             "properties": {
-                "adminPassword": {
+                "item": {
                     "type": "string"
                 },
-                "adminUser": {
+                "message": {
                     "type": "string"
                 },
-                "connectionStatus": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.JobOutputDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
                 },
                 "createdAt": {
                     "type": "string"
@@ -2079,319 +5555,296 @@ const docTemplate = `{
                 "createdByUserId": {
                     "type": "string"
                 },
-                "databaseTechnologyId": {
-                    "type": "string"
-                },
-                "databaseTechnologyName": {
-                    "type": "string"
-                },
-                "databaseTechnologyVersion": {
-                    "type": "string"
-                },
-                "disabledAt": {
-                    "type": "string"
-                },
-                "ecosystemId": {
-                    "type": "string"
-                },
-                "ecosystemName": {
+                "error": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
-                },
-                "host": {
-                    "type": "string"
+                "failedItems": {
+                    "type": "integer"
                 },
-                "hostConnection": {
+                "finishedAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "lastConnectionResult": {
-                    "type": "string"
+                "input": {
+                    "type": "object"
                 },
-                "lastConnectionTest": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResultDTO"
+                    }
                 },
-                "lastDatabaseSync": {
-                    "type": "string"
+                "processedItems": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "result": {
+                    "type": "object"
                 },
-                "note": {
+                "startedAt": {
                     "type": "string"
                 },
-                "port": {
+                "status": {
                     "type": "string"
                 },
-                "portConnection": {
-                    "type": "string"
+                "totalItems": {
+                    "type": "integer"
                 },
-                "rolesCreated": {
-                    "type": "boolean"
+                "type": {
+                    "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "dto.DatabaseOutputDTO": {
+        "dto.MigrateDatabaseUserRoleInputDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "createdByUser": {
-                    "type": "string"
-                },
-                "createdByUserId": {
-                    "type": "string"
-                },
-                "currentSize": {
-                    "type": "string"
-                },
-                "databaseInstanceId": {
-                    "type": "string"
-                },
-                "databaseInstanceName": {
-                    "type": "string"
-                },
-                "databaseTechnologyId": {
-                    "type": "string"
-                },
-                "databaseTechnologyName": {
-                    "type": "string"
-                },
-                "databaseTechnologyVersion": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "disabledAt": {
-                    "type": "string"
-                },
-                "ecosystemId": {
+                "databaseRoleId": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.MigrateDatabaseUserRoleOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseUser": {
+                    "$ref": "#/definitions/dto.DatabaseUserOutputDTO"
                 },
-                "ecosystemName": {
-                    "type": "string"
+                "execution": {
+                    "$ref": "#/definitions/dto.ExecutionOutputDTO"
                 },
-                "enabled": {
+                "hasErrors": {
                     "type": "boolean"
                 },
-                "id": {
-                    "type": "string"
+                "instances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ItemResultDTO"
+                    }
                 },
-                "lastDatabaseSync": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.OutdatedRolesDatabasesOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DatabaseOutputDTO"
+                    }
                 },
-                "name": {
+                "rolesTemplateChecksum": {
                     "type": "string"
                 },
-                "rolesConfigured": {
-                    "type": "boolean"
+                "rolesTemplateVersion": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PropagateRolesInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstancesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updatedAt": {
-                    "type": "string"
+                "dryRun": {
+                    "description": "DryRun validates the operation and returns the statements it would execute in the instances, without executing them",
+                    "type": "boolean"
                 }
             }
         },
-        "dto.DatabaseRoleOutputDTO": {
+        "dto.PropagateRolesOutputDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "databaseInstanceId": {
                     "type": "string"
                 },
-                "createdByUserId": {
-                    "type": "string"
+                "databases": {
+                    "description": "Databases are the statements the propagation would execute, in dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DryRunDatabaseOutputDTO"
+                    }
                 },
-                "description": {
+                "ecosystem": {
                     "type": "string"
                 },
-                "displayName": {
+                "instance": {
                     "type": "string"
                 },
-                "id": {
+                "message": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "queueTimeMs": {
+                    "type": "integer"
                 },
-                "readOnly": {
+                "success": {
                     "type": "boolean"
-                }
-            }
-        },
-        "dto.DatabaseUserCredentialsOutputDTO": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
                 },
-                "username": {
+                "technology": {
                     "type": "string"
                 }
             }
         },
-        "dto.DatabaseUserInputDTO": {
+        "dto.RecertificationCampaignInputDTO": {
             "type": "object",
             "properties": {
-                "databaseRoleId": {
-                    "type": "string"
-                },
-                "email": {
+                "ecosystemId": {
                     "type": "string"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "Q3 2024 recertification"
                 },
-                "position": {
-                    "type": "string"
+                "reviewersIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "team": {
                     "type": "string"
                 }
             }
         },
-        "dto.DatabaseUserOutputDTO": {
+        "dto.RecertificationCampaignOutputDTO": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "closedAt": {
                     "type": "string"
                 },
-                "createdByUser": {
+                "closedByUser": {
                     "type": "string"
                 },
-                "createdByUserId": {
+                "closedByUserId": {
                     "type": "string"
                 },
-                "databaseRoleDisplayName": {
+                "createdAt": {
                     "type": "string"
                 },
-                "databaseRoleId": {
+                "createdByUser": {
                     "type": "string"
                 },
-                "databaseRoleName": {
+                "createdByUserId": {
                     "type": "string"
                 },
-                "disabledAt": {
+                "ecosystemId": {
                     "type": "string"
                 },
-                "email": {
+                "ecosystemName": {
                     "type": "string"
                 },
-                "enabled": {
-                    "type": "boolean"
-                },
                 "id": {
                     "type": "string"
                 },
+                "keptItems": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "pendingItems": {
+                    "type": "integer"
+                },
+                "revokedItems": {
+                    "type": "integer"
                 },
-                "position": {
+                "status": {
                     "type": "string"
                 },
                 "team": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "totalItems": {
+                    "type": "integer"
                 },
-                "username": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "dto.EcosystemInputDTO": {
+        "dto.RecertificationDecisionInputDTO": {
             "type": "object",
             "properties": {
-                "code": {
+                "comment": {
                     "type": "string"
                 },
-                "displayName": {
+                "decision": {
+                    "type": "string",
+                    "enum": [
+                        "KEEP",
+                        "REVOKE"
+                    ]
+                },
+                "itemId": {
                     "type": "string"
                 }
             }
         },
-        "dto.EcosystemOutputDTO": {
+        "dto.RecertificationItemOutputDTO": {
             "type": "object",
             "properties": {
-                "code": {
+                "campaignId": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
-                },
-                "createdByUser": {
+                "comment": {
                     "type": "string"
                 },
-                "createdByUserId": {
+                "decidedAt": {
                     "type": "string"
                 },
-                "displayName": {
+                "decision": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "permission": {
+                    "$ref": "#/definitions/dto.AccessPermissionOutputDTO"
+                },
+                "reviewer": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.GrantAccessInputDTO": {
-            "type": "object",
-            "properties": {
-                "databaseUsersIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
                 },
-                "instancesData": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.InstanceDataDTO"
-                    }
-                }
-            }
-        },
-        "dto.GrantAccessOutputDTO": {
-            "type": "object",
-            "properties": {
-                "hasErrors": {
-                    "type": "boolean"
+                "reviewerId": {
+                    "type": "string"
                 },
-                "message": {
+                "revocationResult": {
                     "type": "string"
                 }
             }
         },
-        "dto.InstanceDataDTO": {
+        "dto.RecertificationReportOutputDTO": {
             "type": "object",
             "properties": {
-                "databaseInstanceId": {
+                "campaign": {
+                    "$ref": "#/definitions/dto.RecertificationCampaignOutputDTO"
+                },
+                "generatedAt": {
                     "type": "string"
                 },
-                "databasesIds": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.RecertificationItemOutputDTO"
                     }
                 }
             }
         },
-        "dto.PropagateRolesInputDTO": {
+        "dto.ReconcileAccessInputDTO": {
             "type": "object",
             "properties": {
+                "apply": {
+                    "type": "boolean"
+                },
                 "databaseInstancesIds": {
                     "type": "array",
                     "items": {
@@ -2400,12 +5853,18 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.PropagateRolesOutputDTO": {
+        "dto.ReconcileAccessOutputDTO": {
             "type": "object",
             "properties": {
                 "databaseInstanceId": {
                     "type": "string"
                 },
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccessDriftDTO"
+                    }
+                },
                 "ecosystem": {
                     "type": "string"
                 },
@@ -2415,6 +5874,9 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "queueTimeMs": {
+                    "type": "integer"
+                },
                 "success": {
                     "type": "boolean"
                 },
@@ -2423,6 +5885,14 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.ReviewAccessRequestInputDTO": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.RevokeAccessInputDTO": {
             "type": "object",
             "properties": {
@@ -2434,17 +5904,116 @@ const docTemplate = `{
                 },
                 "databaseUserId": {
                     "type": "string"
+                },
+                "dryRun": {
+                    "description": "DryRun validates the operation and returns the statements it would execute in the instances, without executing them",
+                    "type": "boolean"
                 }
             }
         },
         "dto.RevokeAccessOutputDTO": {
             "type": "object",
             "properties": {
+                "execution": {
+                    "$ref": "#/definitions/dto.ExecutionOutputDTO"
+                },
                 "hasErrors": {
                     "type": "boolean"
                 },
                 "message": {
                     "type": "string"
+                },
+                "plan": {
+                    "description": "Plan has the statements the revoke would execute in each instance, in dry run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DryRunInstanceOutputDTO"
+                    }
+                }
+            }
+        },
+        "dto.RoleFindingOutputDTO": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string"
+                },
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "databaseInstanceName": {
+                    "type": "string"
+                },
+                "databaseUserId": {
+                    "type": "string"
+                },
+                "databaseUserName": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "discoveredAt": {
+                    "type": "string"
+                },
+                "discoveredByUserId": {
+                    "type": "string"
+                },
+                "discoveredByUserName": {
+                    "type": "string"
+                },
+                "ecosystemName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loginName": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RolePrivilegesDTO": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "default": {
+                    "$ref": "#/definitions/dto.DefaultPrivilegesDTO"
+                },
+                "function": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schema": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sequence": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "table": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -2459,6 +6028,14 @@ const docTemplate = `{
                     "items": {
                         "type": "string"
                     }
+                },
+                "dryRun": {
+                    "description": "DryRun validates the operation and returns the statements it would execute in the instances, without executing them",
+                    "type": "boolean"
+                },
+                "onlyOutdated": {
+                    "description": "OnlyOutdated limits the setup to the databases whose roles weren't set up with the current grants",
+                    "type": "boolean"
                 }
             }
         },
@@ -2483,6 +6060,16 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "queueTimeMs": {
+                    "type": "integer"
+                },
+                "statements": {
+                    "description": "Statements are the statements the setup would execute, in dry run",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "success": {
                     "type": "boolean"
                 },
@@ -2517,6 +6104,9 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
+                "queueTimeMs": {
+                    "type": "integer"
+                },
                 "success": {
                     "type": "boolean"
                 },
@@ -2565,73 +6155,167 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.TestConnectionInputDTO": {
+        "dto.TestConnectionInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstancesIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.TestConnectionOutputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseInstanceId": {
+                    "type": "string"
+                },
+                "ecosystem": {
+                    "type": "string"
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "queueTimeMs": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "technology": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateDatabaseRoleInputDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "privileges": {
+                    "$ref": "#/definitions/dto.RolePrivilegesDTO"
+                }
+            }
+        },
+        "dto.UpdateDatabaseUserInputDTO": {
+            "type": "object",
+            "properties": {
+                "databaseRoleId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "team": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccessGroupChangeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AccessGroupChangeOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccessGroupResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AccessGroupOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccessPermissionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AccessPermissionOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccessRequestResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.AccessRequestOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BreakGlassAccessResponse": {
             "type": "object",
             "properties": {
-                "databaseInstancesIds": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "data": {
+                    "$ref": "#/definitions/dto.GrantBreakGlassAccessOutputDTO"
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "dto.TestConnectionOutputDTO": {
+        "handler.ChangeStatusResponse": {
             "type": "object",
             "properties": {
-                "databaseInstanceId": {
-                    "type": "string"
-                },
-                "ecosystem": {
-                    "type": "string"
-                },
-                "instance": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/dto.ChangeStatusOutputDTO"
                 },
                 "message": {
                     "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                },
-                "technology": {
-                    "type": "string"
                 }
             }
         },
-        "dto.UpdateDatabaseUserInputDTO": {
+        "handler.CloseRecertificationCampaignResponse": {
             "type": "object",
             "properties": {
-                "databaseRoleId": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "position": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/dto.CloseRecertificationCampaignOutputDTO"
                 },
-                "team": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.ChangeStatusResponse": {
+        "handler.CreateDatabaseInstanceResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.ChangeStatusOutputDTO"
+                    "$ref": "#/definitions/dto.DatabaseInstanceOutputDTO"
                 },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "handler.CreateDatabaseInstanceResponse": {
+        "handler.CreateDatabaseRoleResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/dto.DatabaseInstanceOutputDTO"
+                    "$ref": "#/definitions/dto.DatabaseRoleOutputDTO"
                 },
                 "message": {
                     "type": "string"
@@ -2671,6 +6355,14 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.DeleteDatabaseRoleResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.DeleteEcosystemResponse": {
             "type": "object",
             "properties": {
@@ -2687,6 +6379,34 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.DesiredStateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.DesiredStateOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.DiscoverRolesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DiscoverRolesOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -2731,6 +6451,17 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.GetDatabaseRoleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.DatabaseRoleOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.GetDatabaseUserCredentialsResponse": {
             "type": "object",
             "properties": {
@@ -2764,6 +6495,17 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.GetJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.JobOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.GetTechnologyResponse": {
             "type": "object",
             "properties": {
@@ -2786,6 +6528,23 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ListAccessGroupsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccessGroupOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.ListAccessPermissionLogsResponse": {
             "type": "object",
             "properties": {
@@ -2820,6 +6579,23 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ListAccessRequestsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AccessRequestOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.ListDatabaseInstancesResponse": {
             "type": "object",
             "properties": {
@@ -2911,6 +6687,85 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.ListJobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ListOutdatedRolesDatabasesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.OutdatedRolesDatabasesOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListRecertificationCampaignsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RecertificationCampaignOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ListRecertificationItemsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RecertificationItemOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ListRoleFindingsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RoleFindingOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.ListTechnologiesResponse": {
             "type": "object",
             "properties": {
@@ -2934,6 +6789,17 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.MigrateDatabaseUserRoleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.MigrateDatabaseUserRoleOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.PropagateRolesResponse": {
             "type": "object",
             "properties": {
@@ -2951,6 +6817,45 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.RecertificationCampaignResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.RecertificationCampaignOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RecertificationReportResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.RecertificationReportOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReconcileAccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReconcileAccessOutputDTO"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
         "handler.RevokeAccessResponse": {
             "type": "object",
             "properties": {
@@ -2979,6 +6884,17 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.SubmitJobResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.JobOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.SyncDatabasesResponse": {
             "type": "object",
             "properties": {
@@ -3013,6 +6929,17 @@ const docTemplate = `{
                 }
             }
         },
+        "handler.UpdateDatabaseRoleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/dto.DatabaseRoleOutputDTO"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.UpdateEcosystemResponse": {
             "type": "object",
             "properties": {
@@ -3071,6 +6998,10 @@ const docTemplate = `{
         {
             "description": "It represents the permission that can be granted to a user to connect in a specific database. e.g. foo.bar (user) can connect in zg-data-guard (database) with developer role.",
             "name": "Access Permission"
+        },
+        {
+            "description": "It represents an operation processed in background, requested with async=true. e.g. a grant of access to many users in many instances.",
+            "name": "Job"
         }
     ]
 }`
@@ -0,0 +1,422 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitUsage   = 2
+
+	defaultTimeout = 5 * time.Minute
+	internalAuth   = "/auth/internal"
+	checkTokenPath = "/ecosystems"
+)
+
+var ErrOperationFailed = errors.New("the operation finished with errors, see the output")
+
+// options godoc
+// Flags accepted by every command, before or after the name of the command
+type options struct {
+	url     string
+	output  string
+	timeout time.Duration
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.url, "url", o.url, "URL of the application, e.g. http://localhost:8081 (default: the URL of the login)")
+	fs.StringVar(&o.output, "output", o.output, "Output format: table or json")
+	fs.StringVar(&o.output, "o", o.output, "Shorthand for --output")
+	fs.DurationVar(&o.timeout, "timeout", o.timeout, "Timeout of the requests")
+}
+
+// app godoc
+// The streams of the process and the clock, replaced in the tests
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	now    func() time.Time
+}
+
+// Run godoc
+// Runs the command line client with the args, without the name of the program, and returns the exit code: 1 when
+// the API fails or the operation finishes with errors and 2 when the command is misused
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	a := &app{stdin: stdin, stdout: stdout, stderr: stderr, now: time.Now}
+	return a.run(args)
+}
+
+func (a *app) run(args []string) int {
+	opts := &options{output: outputTable, timeout: defaultTimeout}
+	global := flag.NewFlagSet("zg-data-guard-cli", flag.ContinueOnError)
+	global.SetOutput(a.stderr)
+	global.Usage = func() { a.printUsage() }
+	opts.register(global)
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitSuccess
+		}
+		return exitUsage
+	}
+	args = global.Args()
+	if len(args) == 0 {
+		a.printUsage()
+		return exitUsage
+	}
+	if args[0] == "help" {
+		return a.help(args[1:])
+	}
+
+	var err error
+	switch args[0] {
+	case "login":
+		err = a.login(args[1:], opts)
+	case "logout":
+		err = a.logout()
+	case "status":
+		err = a.status(opts)
+	default:
+		err = a.runCommand(args, opts)
+	}
+	return a.exitCode(err)
+}
+
+func (a *app) exitCode(err error) int {
+	switch {
+	case err == nil || errors.Is(err, flag.ErrHelp):
+		return exitSuccess
+	case errors.Is(err, ErrUsage):
+		fmt.Fprintf(a.stderr, "Error: %s\n", strings.TrimPrefix(err.Error(), ErrUsage.Error()+": "))
+		return exitUsage
+	default:
+		fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+}
+
+func (a *app) runCommand(args []string, opts *options) error {
+	res := findResource(args[0])
+	if res == nil {
+		return fmt.Errorf("%w: unknown command '%s', see 'zg-data-guard-cli help'", ErrUsage, args[0])
+	}
+	cmd, name, rest := res.find(args[1:])
+	if cmd == nil {
+		a.printResourceUsage(res)
+		if len(args) > 1 && !strings.HasPrefix(args[1], "-") {
+			return fmt.Errorf("%w: unknown action '%s' of %s", ErrUsage, args[1], res.name)
+		}
+		return fmt.Errorf("%w: the action of %s is required", ErrUsage, res.name)
+	}
+	inv, err := cmd.parse(name, rest, opts, a.stderr)
+	if err != nil {
+		return err
+	}
+	out, err := newPrinter(a.stdout, opts.output)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	request, err := cmd.request(inv, a.stdin)
+	if err != nil {
+		return err
+	}
+	client, err := a.client(opts, cmd.public)
+	if err != nil {
+		return err
+	}
+
+	var response *Response
+	if inv.stream {
+		response, err = client.Stream(request, func(event string, data json.RawMessage) {
+			fmt.Fprintln(a.stderr, describeProgress(event, data))
+		})
+	} else {
+		response, err = client.Do(request)
+	}
+	if err != nil {
+		return err
+	}
+	if cmd.render != nil && opts.output == outputTable && !inv.async {
+		err = cmd.render(out, response)
+	} else {
+		err = out.print(response, cmd.columns)
+	}
+	if err != nil {
+		return err
+	}
+	if operationFailed(response.Data) {
+		return ErrOperationFailed
+	}
+	return nil
+}
+
+// client godoc
+// Creates the client with the stored credentials. Public routes don't need them, only the URL.
+func (a *app) client(opts *options, public bool) (*Client, error) {
+	credentials, err := loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if opts.url != "" {
+		credentials.URL = opts.url
+	}
+	if credentials.URL == "" {
+		return nil, ErrNotLoggedIn
+	}
+	if public {
+		return NewClient(credentials.URL, "", opts.timeout), nil
+	}
+	if credentials.AccessToken == "" {
+		return nil, ErrNotLoggedIn
+	}
+	if credentials.Expired(a.now()) {
+		return nil, ErrTokenExpired
+	}
+	return NewClient(credentials.URL, credentials.AccessToken, opts.timeout), nil
+}
+
+// login godoc
+// Stores the token informed, or the one of the internal user of the development environment, after checking it in
+// the API
+func (a *app) login(args []string, opts *options) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	opts.register(fs)
+	token := fs.String("token", "", "JWT token to call the API, - to read it from stdin")
+	internal := fs.Bool("internal", false, "Authenticate as the internal user, only available in the development environment")
+	fs.Usage = func() {
+		fmt.Fprint(a.stderr, "Authenticate and store the token to call the API\n\nUsage: zg-data-guard-cli login --url URL (--token TOKEN | --internal)\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	if opts.url != "" {
+		credentials.URL = opts.url
+	}
+	if credentials.URL == "" {
+		return fmt.Errorf("%w: the flag --url is required", ErrUsage)
+	}
+	if _, err = url.ParseRequestURI(credentials.URL); err != nil {
+		return fmt.Errorf("%w: invalid URL '%s'", ErrUsage, credentials.URL)
+	}
+
+	accessToken, err := a.readToken(*token, *internal, credentials.URL, opts.timeout)
+	if err != nil {
+		return err
+	}
+	query := url.Values{"limit": []string{"1"}}
+	if _, err = NewClient(credentials.URL, accessToken, opts.timeout).Do(Request{Method: http.MethodGet, Path: checkTokenPath, Query: query}); err != nil {
+		return fmt.Errorf("the token was refused, nothing was stored. Cause: %w", err)
+	}
+
+	credentials.AccessToken = accessToken
+	credentials.ExpiresAt = tokenExpiration(accessToken)
+	path, err := saveCredentials(credentials)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Logged in to %s%s. The token was stored in %s", credentials.URL, describeUser(accessToken), path)
+	if credentials.ExpiresAt > 0 {
+		fmt.Fprintf(a.stdout, " and expires at %s", time.Unix(credentials.ExpiresAt, 0).Format(time.RFC3339))
+	}
+	fmt.Fprintln(a.stdout, ".")
+	return nil
+}
+
+func (a *app) readToken(token string, internal bool, baseURL string, timeout time.Duration) (string, error) {
+	if internal {
+		if token != "" {
+			return "", fmt.Errorf("%w: --token and --internal can't be used together", ErrUsage)
+		}
+		response, err := NewClient(baseURL, "", timeout).Do(Request{Method: http.MethodGet, Path: internalAuth, Absolute: true})
+		if err != nil {
+			return "", fmt.Errorf("error authenticating as the internal user: %w", err)
+		}
+		var jwtToken struct {
+			AccessToken string `json:"accessToken"`
+		}
+		if err = json.Unmarshal(response.Data, &jwtToken); err != nil || jwtToken.AccessToken == "" {
+			return "", fmt.Errorf("unexpected response of the authentication: %s", response.Raw)
+		}
+		return jwtToken.AccessToken, nil
+	}
+	if token == "" {
+		return "", fmt.Errorf("%w: inform the token with --token, or use --internal in the development environment", ErrUsage)
+	}
+	if token == "-" {
+		content, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return "", fmt.Errorf("error reading the token from stdin: %w", err)
+		}
+		token = string(content)
+	}
+	if token = normalizeToken(token); token == "" {
+		return "", fmt.Errorf("%w: the token is empty", ErrUsage)
+	}
+	return token, nil
+}
+
+func (a *app) logout() error {
+	path, err := removeCredentials()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Logged out, the credentials in %s were removed.\n", path)
+	return nil
+}
+
+// status godoc
+// Shows the URL and the user of the token, without calling the API
+func (a *app) status(opts *options) error {
+	credentials, err := loadCredentials()
+	if err != nil {
+		return err
+	}
+	if opts.url != "" {
+		credentials.URL = opts.url
+	}
+	if credentials.URL == "" || credentials.AccessToken == "" {
+		return ErrNotLoggedIn
+	}
+	fmt.Fprintf(a.stdout, "Logged in to %s%s.\n", credentials.URL, describeUser(credentials.AccessToken))
+	if credentials.ExpiresAt == 0 {
+		return nil
+	}
+	expiresAt := time.Unix(credentials.ExpiresAt, 0).Format(time.RFC3339)
+	if credentials.Expired(a.now()) {
+		return fmt.Errorf("the token expired at %s, run 'zg-data-guard-cli login' again", expiresAt)
+	}
+	fmt.Fprintf(a.stdout, "The token expires at %s.\n", expiresAt)
+	return nil
+}
+
+func describeUser(token string) string {
+	if name, ok := tokenClaims(token)["name"].(string); ok && name != "" {
+		return " as " + name
+	}
+	return ""
+}
+
+// operationFailed godoc
+// The operations on many items answer successfully even when some of them fail, flagging it with hasErrors or with
+// the success of each item
+func operationFailed(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return false
+	}
+	var result struct {
+		HasErrors bool `json:"hasErrors"`
+	}
+	if data[0] == '{' {
+		return json.Unmarshal(data, &result) == nil && result.HasErrors
+	}
+	var items []struct {
+		Success *bool `json:"success"`
+	}
+	if data[0] != '[' || json.Unmarshal(data, &items) != nil {
+		return false
+	}
+	for _, item := range items {
+		if item.Success != nil && !*item.Success {
+			return true
+		}
+	}
+	return false
+}
+
+func findResource(name string) *resource {
+	for _, res := range resources() {
+		if res.name == name {
+			return res
+		}
+		for _, alias := range res.aliases {
+			if alias == name {
+				return res
+			}
+		}
+	}
+	return nil
+}
+
+// find godoc
+// Finds the action of the args, returning the full name of the command and the args left. Resources with a single
+// command without action, like health, run it directly.
+func (r *resource) find(args []string) (*command, string, []string) {
+	for _, cmd := range r.commands {
+		if cmd.action == "" {
+			return cmd, r.name, args
+		}
+		if len(args) > 0 && args[0] == cmd.action {
+			return cmd, r.name + " " + cmd.action, args[1:]
+		}
+	}
+	return nil, "", nil
+}
+
+// help godoc
+// Prints the usage of the client, or of the command given
+func (a *app) help(args []string) int {
+	if len(args) == 0 {
+		a.printUsage()
+		return exitSuccess
+	}
+	res := findResource(args[0])
+	if res == nil {
+		return a.exitCode(fmt.Errorf("%w: unknown command '%s', see 'zg-data-guard-cli help'", ErrUsage, args[0]))
+	}
+	a.printResourceUsage(res)
+	return exitSuccess
+}
+
+func (a *app) printUsage() {
+	fmt.Fprint(a.stderr, `Command line client of the ZG Data Guard API
+
+Usage: zg-data-guard-cli [--url URL] [--output table|json] <command> [action] [flags]
+
+Commands:
+`)
+	writer := tabwriter.NewWriter(a.stderr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "  login\tAuthenticate and store the token to call the API")
+	fmt.Fprintln(writer, "  logout\tRemove the stored token")
+	fmt.Fprintln(writer, "  status\tShow the URL and the user of the stored token")
+	for _, res := range resources() {
+		fmt.Fprintf(writer, "  %s\t%s\n", res.name, res.summary)
+	}
+	_ = writer.Flush()
+	fmt.Fprint(a.stderr, `
+Run 'zg-data-guard-cli <command>' to list its actions and 'zg-data-guard-cli <command> <action> --help' for their flags.
+The URL and the token can also be set in ZG_DATA_GUARD_URL and ZG_DATA_GUARD_TOKEN, e.g. in pipelines.
+`)
+}
+
+func (a *app) printResourceUsage(res *resource) {
+	fmt.Fprintf(a.stderr, "%s\n\nUsage: zg-data-guard-cli %s <action> [flags]\n\nActions:\n", res.summary, res.name)
+	writer := tabwriter.NewWriter(a.stderr, 0, 0, 2, ' ', 0)
+	for _, cmd := range res.commands {
+		fmt.Fprintf(writer, "  %s\t%s\n", cmd.action, cmd.summary)
+	}
+	_ = writer.Flush()
+	if len(res.aliases) > 0 {
+		fmt.Fprintf(a.stderr, "\nAliases: %s\n", strings.Join(res.aliases, ", "))
+	}
+}
@@ -0,0 +1,434 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "Bearer header.payload.signature"
+
+type recordedRequest struct {
+	method      string
+	path        string
+	query       string
+	contentType string
+	auth        string
+	body        string
+}
+
+// fakeAPI godoc
+// Answers every request with the status and the body given, recording the last request received
+func fakeAPI(t *testing.T, status int, contentType, body string) (*httptest.Server, *recordedRequest) {
+	recorded := &recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		*recorded = recordedRequest{
+			method:      r.Method,
+			path:        r.URL.Path,
+			query:       r.URL.RawQuery,
+			contentType: r.Header.Get("Content-Type"),
+			auth:        r.Header.Get("Authorization"),
+			body:        string(content),
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, recorded
+}
+
+func loggedIn(t *testing.T, url string) {
+	t.Setenv(envCredentials, filepath.Join(t.TempDir(), credentialsFile))
+	t.Setenv(envURL, url)
+	t.Setenv(envToken, testToken)
+}
+
+func runCLI(stdin string, args ...string) (string, string, int) {
+	var stdout, stderr bytes.Buffer
+	code := Run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), code
+}
+
+func buildJWT(claims map[string]any) string {
+	payload, _ := json.Marshal(claims)
+	return "Bearer header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestGivenAPageOfEcosystems_WhenRunEcosystemList_ThenShouldPrintThemInATableWithThePaging(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","total":3,"limit":2,"page":1,"data":[
+		{"id":"1","code":"aws","displayName":"AWS","requiresAccessApproval":true,"createdAt":"2024-01-01T00:00:00Z"},
+		{"id":"2","code":"azure","displayName":"Azure","requiresAccessApproval":false,"createdAt":"2024-01-02T00:00:00Z"}]}`)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("", "ecosystem", "list", "--page", "1", "--limit", "2")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.Equal(t, http.MethodGet, request.method)
+	assert.Equal(t, "/api/v1/ecosystems", request.path)
+	assert.Equal(t, "limit=2&page=1", request.query)
+	assert.Equal(t, testToken, request.auth)
+	assert.Equal(t, `ID  CODE   DISPLAY_NAME  REQUIRES_ACCESS_APPROVAL  CREATED_AT
+1   aws    AWS           true                      2024-01-01T00:00:00Z
+2   azure  Azure         false                     2024-01-02T00:00:00Z
+
+Showing 2 of 3 (page 1, limit 2)
+`, stdout)
+}
+
+func TestGivenTheJSONOutput_WhenRunACommand_ThenShouldPrintOnlyTheDataIndented(t *testing.T) {
+	server, _ := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":{"id":"1","name":"PostgreSQL","version":"16"}}`)
+	loggedIn(t, server.URL)
+
+	stdout, _, code := runCLI("", "-o", "json", "technology", "get", "--id", "1")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.Equal(t, "{\n  \"id\": \"1\",\n  \"name\": \"PostgreSQL\",\n  \"version\": \"16\"\n}\n", stdout)
+}
+
+func TestGivenAnErrorOfTheAPI_WhenRunACommand_ThenShouldPrintItsMessageAndCode(t *testing.T) {
+	server, _ := fakeAPI(t, http.StatusNotFound, contentTypeJSON, `{"message":"ecosystem not found","errorCode":404}`)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("", "ecosystem", "get", "--id", "1")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "Error: the API returned 404 Not Found: ecosystem not found\n", stderr)
+}
+
+func TestGivenATokenRefusedByTheAPI_WhenRunACommand_ThenShouldAskToLogInAgain(t *testing.T) {
+	server, _ := fakeAPI(t, http.StatusUnauthorized, "text/plain", "Unauthorized\n")
+	loggedIn(t, server.URL)
+
+	_, stderr, code := runCLI("", "user", "list")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "the API returned 401 Unauthorized: Unauthorized. The token is invalid or has expired, run 'zg-data-guard-cli login' again")
+}
+
+func TestGivenNoCredentials_WhenRunACommand_ThenShouldAskToLogIn(t *testing.T) {
+	t.Setenv(envCredentials, filepath.Join(t.TempDir(), credentialsFile))
+	t.Setenv(envURL, "")
+	t.Setenv(envToken, "")
+
+	_, stderr, code := runCLI("", "user", "list")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Equal(t, "Error: "+ErrNotLoggedIn.Error()+"\n", stderr)
+}
+
+func TestGivenAnExpiredToken_WhenRunACommand_ThenShouldAskToLogInAgainWithoutCallingTheAPI(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":[]}`)
+	loggedIn(t, server.URL)
+	t.Setenv(envToken, buildJWT(map[string]any{"exp": time.Now().Add(-time.Minute).Unix()}))
+
+	_, stderr, code := runCLI("", "user", "list")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, ErrTokenExpired.Error())
+	assert.Empty(t, request.path)
+}
+
+func TestGivenARequiredFlagMissing_WhenRunACommand_ThenShouldReturnUsageError(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{}`)
+	loggedIn(t, server.URL)
+
+	_, stderr, code := runCLI("", "ecosystem", "get")
+
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "the flag --id is required")
+	assert.Empty(t, request.path)
+}
+
+func TestGivenGrantFlagsAndDryRun_WhenRunAccessGrant_ThenShouldSendTheBodyAndPrintThePlan(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":{"hasErrors":false,"message":"Dry run","plan":[
+		{"databaseInstanceId":"i1","instance":"PostgreSQL - Azure","databases":[{"database":"orders","statements":["GRANT CONNECT ON DATABASE \"orders\" TO \"jane\""]}]}]}}`)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("", "access", "grant", "--user-ids", "u1, u2", "--instance-id", "i1", "--database-ids", "d1,d2", "--dry-run")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.Equal(t, "/api/v1/access-permission/grant", request.path)
+	assert.Equal(t, contentTypeJSON, request.contentType)
+	assert.JSONEq(t, `{"databaseUsersIds":["u1","u2"],"instancesData":[{"databaseInstanceId":"i1","databasesIds":["d1","d2"]}],"dryRun":true}`, request.body)
+	assert.Equal(t, `HAS_ERRORS:  false
+MESSAGE:     Dry run
+
+STATEMENTS:
+INSTANCE            DATABASE  STATEMENT
+PostgreSQL - Azure  orders    GRANT CONNECT ON DATABASE "orders" TO "jane"
+`, stdout)
+}
+
+func TestGivenAFileAndFlags_WhenRunAccessGrant_ThenTheFlagsShouldOverrideTheFieldsOfTheFile(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":{"hasErrors":false,"message":"ok"}}`)
+	loggedIn(t, server.URL)
+	file := filepath.Join(t.TempDir(), "grant.json")
+	content := `{"databaseUsersIds":["u1"],"instancesData":[{"databaseInstanceId":"i1","databasesIds":["d1"]},{"databaseInstanceId":"i2","databasesIds":["d3"]}]}`
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	_, stderr, code := runCLI("", "access", "grant", "--file", file, "--database-ids", "d1,d2")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.JSONEq(t, `{"databaseUsersIds":["u1"],"instancesData":[{"databaseInstanceId":"i1","databasesIds":["d1","d2"]},{"databaseInstanceId":"i2","databasesIds":["d3"]}]}`, request.body)
+}
+
+func TestGivenAnOperationWithErrors_WhenRunIt_ThenShouldPrintTheOutputAndExitWithFailure(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","total":2,"data":[
+		{"databaseInstanceId":"i1","instance":"PostgreSQL","success":true,"message":"ok"},
+		{"databaseInstanceId":"i2","instance":"MySQL","success":false,"message":"connection refused"}]}`)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("", "instance", "test-connection", "--instance-ids", "i1,i2")
+
+	assert.Equal(t, exitFailure, code)
+	assert.JSONEq(t, `{"databaseInstancesIds":["i1","i2"]}`, request.body)
+	assert.Contains(t, stdout, "connection refused")
+	assert.Equal(t, "Error: "+ErrOperationFailed.Error()+"\n", stderr)
+}
+
+func TestGivenTheAsyncFlag_WhenRunAnOperation_ThenShouldSubmitItAsAJob(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusAccepted, contentTypeJSON, `{"message":"ok","data":{"id":"j1","type":"SYNC_DATABASES","status":"PENDING"}}`)
+	loggedIn(t, server.URL)
+
+	stdout, _, code := runCLI("", "instance", "sync-databases", "--async")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.Equal(t, "async=true", request.query)
+	assert.Equal(t, "{}", request.body, "an empty body selects all the instances")
+	assert.Contains(t, stdout, "SYNC_DATABASES")
+}
+
+func TestGivenTheStreamFlag_WhenRunAccessRevoke_ThenShouldPrintTheProgressAndTheResult(t *testing.T) {
+	events := "event: progress\ndata: {\"instance\":\"PostgreSQL\",\"instanceIndex\":1,\"instancesQty\":1,\"message\":\"revoking access\"}\n\n" +
+		": keep-alive\n\n" +
+		"event: item\ndata: {\"item\":\"PostgreSQL\",\"success\":true,\"message\":\"revoked\",\"totalItems\":1,\"processedItems\":1,\"failedItems\":0}\n\n" +
+		"event: result\ndata: {\"hasErrors\":false,\"message\":\"Successfully revoked\"}\n\n"
+	server, request := fakeAPI(t, http.StatusOK, "text/event-stream", events)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("", "access", "revoke", "--user-id", "u1", "--stream")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.Equal(t, "/api/v1/access-permission/revoke/stream", request.path)
+	assert.Equal(t, "[PostgreSQL] revoking access\n[1/1] PostgreSQL: ok - revoked\n", stderr)
+	assert.Equal(t, "HAS_ERRORS:  false\nMESSAGE:     Successfully revoked\n", stdout)
+}
+
+func TestGivenAnErrorEventInTheStream_WhenRunAccessGrant_ThenShouldPrintTheErrorOfTheOperation(t *testing.T) {
+	events := "event: error\ndata: {\"message\":\"error in operation grant-access! Cause: no instances found\",\"errorCode\":404}\n\n"
+	server, _ := fakeAPI(t, http.StatusOK, "text/event-stream", events)
+	loggedIn(t, server.URL)
+
+	_, stderr, code := runCLI("", "access", "grant", "--user-ids", "u1", "--stream")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Equal(t, "Error: the API returned 404 Not Found: error in operation grant-access! Cause: no instances found\n", stderr)
+}
+
+func TestGivenADesiredStateFile_WhenRunDesiredStatePlan_ThenShouldSendItAsYAMLAndPrintTheChanges(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":{"hasErrors":false,"message":"1 change",
+		"changes":[{"action":"CREATE","kind":"ECOSYSTEM","name":"azure"}],"warnings":["database reports not synced"]}}`)
+	loggedIn(t, server.URL)
+
+	stdout, stderr, code := runCLI("ecosystems:\n  - code: azure\n", "desired-state", "plan", "--file", "-", "--prune")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.Equal(t, "/api/v1/desired-state/plan", request.path)
+	assert.Equal(t, "prune=true", request.query)
+	assert.Equal(t, contentTypeYAML, request.contentType)
+	assert.Equal(t, "ecosystems:\n  - code: azure\n", request.body)
+	assert.Equal(t, `HAS_ERRORS:  false
+MESSAGE:     1 change
+WARNINGS:    database reports not synced
+
+CHANGES:
+ACTION  KIND       NAME
+CREATE  ECOSYSTEM  azure
+`, stdout)
+}
+
+func TestGivenTheCSVReport_WhenRunRecertificationReport_ThenShouldPrintItAsIs(t *testing.T) {
+	csv := "user,database,decision\njane,orders,KEEP\n"
+	server, request := fakeAPI(t, http.StatusOK, "text/csv", csv)
+	loggedIn(t, server.URL)
+
+	stdout, _, code := runCLI("", "recertification", "report", "--id", "c1", "--format", "csv")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.Equal(t, "format=csv&id=c1", request.query)
+	assert.Equal(t, csv, stdout)
+}
+
+func TestGivenAValidToken_WhenLogin_ThenShouldStoreItOnlyReadableByTheUser(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":[]}`)
+	path := filepath.Join(t.TempDir(), "config", credentialsFile)
+	t.Setenv(envCredentials, path)
+	t.Setenv(envURL, "")
+	t.Setenv(envToken, "")
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	token := buildJWT(map[string]any{"name": "Jane Doe", "exp": expiresAt})
+
+	stdout, stderr, code := runCLI(strings.TrimPrefix(token, bearerPrefix)+"\n", "login", "--url", server.URL, "--token", "-")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	assert.Equal(t, "/api/v1/ecosystems", request.path, "the token should be checked in the API")
+	assert.Equal(t, token, request.auth, "the Bearer prefix should be added")
+	assert.Contains(t, stdout, fmt.Sprintf("Logged in to %s as Jane Doe", server.URL))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	content, _ := os.ReadFile(path)
+	assert.JSONEq(t, fmt.Sprintf(`{"url":%q,"accessToken":%q,"expiresAt":%d}`, server.URL, token, expiresAt), string(content))
+
+	stdout, _, code = runCLI("", "status")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.Contains(t, stdout, "as Jane Doe")
+	assert.Contains(t, stdout, "The token expires at")
+}
+
+func TestGivenCredentialsReadableByOthers_WhenLogin_ThenShouldStoreTheTokenOnlyReadableByTheUser(t *testing.T) {
+	server, _ := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"message":"ok","data":[]}`)
+	path := filepath.Join(t.TempDir(), credentialsFile)
+	require.NoError(t, os.WriteFile(path, []byte(`{"url":"http://old","accessToken":"Bearer old"}`), 0o644))
+	require.NoError(t, os.Chmod(path, 0o644))
+	t.Setenv(envCredentials, path)
+	t.Setenv(envURL, "")
+	t.Setenv(envToken, "")
+
+	_, stderr, code := runCLI("", "login", "--url", server.URL, "--token", buildJWT(map[string]any{"name": "Jane Doe"}))
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	content, _ := os.ReadFile(path)
+	assert.Contains(t, string(content), server.URL)
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1, "no temp file should be left behind")
+}
+
+func TestGivenATokenRefused_WhenLogin_ThenShouldNotStoreIt(t *testing.T) {
+	server, _ := fakeAPI(t, http.StatusUnauthorized, "text/plain", "Unauthorized")
+	path := filepath.Join(t.TempDir(), credentialsFile)
+	t.Setenv(envCredentials, path)
+	t.Setenv(envURL, "")
+	t.Setenv(envToken, "")
+
+	_, stderr, code := runCLI("", "login", "--url", server.URL, "--token", "invalid")
+
+	assert.Equal(t, exitFailure, code)
+	assert.Contains(t, stderr, "the token was refused, nothing was stored")
+	assert.NoFileExists(t, path)
+}
+
+func TestGivenTheDevelopmentEnvironment_WhenLoginAsTheInternalUser_ThenShouldStoreItsToken(t *testing.T) {
+	token := buildJWT(map[string]any{"name": "ZG Services"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		if r.URL.Path == internalAuth {
+			_, _ = fmt.Fprintf(w, `{"message":"ok","data":{"accessToken":%q,"expiresAt":0}}`, token)
+			return
+		}
+		if r.Header.Get("Authorization") != token {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		_, _ = io.WriteString(w, `{"message":"ok","data":[]}`)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), credentialsFile)
+	t.Setenv(envCredentials, path)
+	t.Setenv(envURL, "")
+	t.Setenv(envToken, "")
+
+	_, stderr, code := runCLI("", "--url", server.URL, "login", "--internal")
+
+	assert.Equal(t, exitSuccess, code, stderr)
+	credentials, err := loadCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, token, credentials.AccessToken)
+
+	_, _, code = runCLI("", "logout")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.NoFileExists(t, path)
+}
+
+func TestGivenTheHealthCommand_WhenRunIt_ThenShouldCallTheHealthCheckWithoutToken(t *testing.T) {
+	server, request := fakeAPI(t, http.StatusOK, contentTypeJSON, `{"serviceName":"zg-data-guard","serviceVersion":"1.0"}`)
+	t.Setenv(envCredentials, filepath.Join(t.TempDir(), credentialsFile))
+	t.Setenv(envToken, "")
+	t.Setenv(envURL, server.URL)
+
+	stdout, _, code := runCLI("", "health")
+
+	assert.Equal(t, exitSuccess, code)
+	assert.Equal(t, "/healthcheck/info", request.path)
+	assert.Empty(t, request.auth)
+	assert.Equal(t, "SERVICE_NAME:     zg-data-guard\nSERVICE_VERSION:  1.0\n", stdout)
+}
+
+// TestGivenTheRoutesOfTheAPI_WhenListTheCommands_ThenEveryRouteShouldHaveACommand godoc
+// Reads the routes registered in router/routes.go, so a new route without a command fails the test
+func TestGivenTheRoutesOfTheAPI_WhenListTheCommands_ThenEveryRouteShouldHaveACommand(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "webserver", "router", "routes.go"))
+	require.NoError(t, err)
+	commands := map[string]bool{}
+	for _, res := range resources() {
+		for _, cmd := range res.commands {
+			commands[cmd.method+" "+cmd.path] = true
+			if cmd.stream != "" {
+				commands[cmd.method+" "+cmd.stream] = true
+			}
+		}
+	}
+	function := regexp.MustCompile(`^func (\w+)\(`)
+	group := regexp.MustCompile(`r\.Route\("([^"]+)"`)
+	route := regexp.MustCompile(`r\.(Get|Post|Put|Patch|Delete)\("([^"]+)"`)
+
+	var routes []string
+	var current, prefix string
+	for _, line := range strings.Split(string(content), "\n") {
+		if match := function.FindStringSubmatch(line); match != nil {
+			current, prefix = match[1], ""
+		}
+		if !strings.HasPrefix(current, "create") {
+			continue
+		}
+		if match := group.FindStringSubmatch(line); match != nil {
+			prefix = match[1]
+			continue
+		}
+		if strings.TrimSpace(line) == "})" {
+			prefix = ""
+		}
+		if match := route.FindStringSubmatch(line); match != nil {
+			path := prefix + strings.TrimSuffix(match[2], "/")
+			if path == "" {
+				path = prefix
+			}
+			routes = append(routes, strings.ToUpper(match[1])+" "+path)
+		}
+	}
+
+	assert.Greater(t, len(routes), 60)
+	for _, r := range routes {
+		assert.True(t, commands[r], "the route %s has no command", r)
+	}
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+const (
+	apiBasePath        = "/api/v1"
+	contentTypeJSON    = "application/json"
+	contentTypeYAML    = "application/x-yaml"
+	sseEventProgress   = "progress"
+	sseEventItem       = "item"
+	sseEventResult     = "result"
+	sseEventError      = "error"
+	maxErrorBodyLength = 300
+)
+
+// APIError godoc
+// Error returned by the API, with the message and the code of its error responses. The code is the HTTP status when
+// the response isn't one of them, e.g. when the token is refused by the authentication of the API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	ErrorCode  int
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("the API returned %d %s: %s", e.ErrorCode, http.StatusText(e.ErrorCode), e.Message)
+	if e.StatusCode == http.StatusUnauthorized {
+		message += ". The token is invalid or has expired, run 'zg-data-guard-cli login' again"
+	}
+	return message
+}
+
+// Response godoc
+// Body of the successful responses of the API. Data is the whole body when the response isn't wrapped by the API.
+type Response struct {
+	Message     string          `json:"message"`
+	Data        json.RawMessage `json:"data"`
+	Total       int             `json:"total"`
+	Limit       int             `json:"limit"`
+	Page        int             `json:"page"`
+	ContentType string          `json:"-"`
+	Raw         []byte          `json:"-"`
+}
+
+// Request godoc
+// Request to a route of the API, relative to its base path unless Absolute is set
+type Request struct {
+	Method      string
+	Path        string
+	Query       url.Values
+	Body        []byte
+	ContentType string
+	Absolute    bool
+}
+
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewClient(baseURL, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Do godoc
+// Sends the request and decodes the response, returning an APIError when the API doesn't succeed
+func (c *Client) Do(request Request) (*Response, error) {
+	httpResponse, err := c.send(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the response of %s: %w", request.Path, err)
+	}
+	if httpResponse.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(httpResponse.StatusCode, body)
+	}
+	return decodeResponse(httpResponse.Header.Get("Content-Type"), body)
+}
+
+// Stream godoc
+// Sends the request to a route that streams the progress of the operation as Server-Sent Events. Each progress and
+// item event is given to onProgress and the result event is returned as the data of the response.
+func (c *Client) Stream(request Request, onProgress func(event string, data json.RawMessage)) (*Response, error) {
+	// The operation may take longer than the timeout of the requests, the stream ends when the operation does
+	client := *c.httpClient
+	client.Timeout = 0
+	streamClient := &Client{baseURL: c.baseURL, token: c.token, httpClient: &client}
+	httpResponse, err := streamClient.send(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(httpResponse.Body)
+		return nil, newAPIError(httpResponse.StatusCode, body)
+	}
+	return readEvents(httpResponse.Body, onProgress)
+}
+
+func (c *Client) send(request Request) (*http.Response, error) {
+	endpoint := c.baseURL + request.Path
+	if !request.Absolute {
+		endpoint = c.baseURL + apiBasePath + request.Path
+	}
+	if len(request.Query) > 0 {
+		endpoint += "?" + request.Query.Encode()
+	}
+	var body io.Reader
+	if request.Body != nil {
+		body = bytes.NewReader(request.Body)
+	}
+	httpRequest, err := http.NewRequest(request.Method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("error building the request to %s: %w", endpoint, err)
+	}
+	if request.Body != nil {
+		contentType := request.ContentType
+		if contentType == "" {
+			contentType = contentTypeJSON
+		}
+		httpRequest.Header.Set("Content-Type", contentType)
+	}
+	httpRequest.Header.Set("Accept", contentTypeJSON)
+	if c.token != "" {
+		httpRequest.Header.Set("Authorization", c.token)
+	}
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s %s, check the URL and if the API is running: %w", request.Method, endpoint, err)
+	}
+	return httpResponse, nil
+}
+
+func decodeResponse(contentType string, body []byte) (*Response, error) {
+	response := &Response{ContentType: contentType, Raw: body}
+	if !strings.HasPrefix(contentType, contentTypeJSON) {
+		return response, nil
+	}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	if response.Data == nil && response.Message == "" {
+		response.Data = body
+	}
+	return response, nil
+}
+
+// newAPIError godoc
+// Builds the error from the message and errorCode of the error responses of the API, or from the status and the body
+// of the response when it isn't one of them
+func newAPIError(statusCode int, body []byte) *APIError {
+	var errorResponse struct {
+		Message   string          `json:"message"`
+		ErrorCode json.RawMessage `json:"errorCode"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err == nil && errorResponse.Message != "" {
+		// The code is a number, but documented as a string in the swagger of the API
+		errorCode, err := strconv.Atoi(strings.Trim(string(errorResponse.ErrorCode), `"`))
+		if err != nil || errorCode == 0 {
+			errorCode = statusCode
+		}
+		return &APIError{StatusCode: statusCode, Message: errorResponse.Message, ErrorCode: errorCode}
+	}
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorBodyLength {
+		message = message[:maxErrorBodyLength] + "..."
+	}
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	return &APIError{StatusCode: statusCode, Message: message, ErrorCode: statusCode}
+}
+
+// readEvents godoc
+// Reads the Server-Sent Events until the result or the error event, which end the stream
+func readEvents(body io.Reader, onProgress func(event string, data json.RawMessage)) (*Response, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var event string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		case line == "" && event != "":
+			payload := json.RawMessage(strings.Join(data, "\n"))
+			switch event {
+			case sseEventResult:
+				return &Response{Data: payload, ContentType: contentTypeJSON, Raw: payload}, nil
+			case sseEventError:
+				return nil, newAPIError(http.StatusOK, payload)
+			default:
+				onProgress(event, payload)
+			}
+			event, data = "", nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading the progress of the operation: %w", err)
+	}
+	return nil, fmt.Errorf("the stream ended before the result of the operation")
+}
+
+// describeProgress godoc
+// Describes the progress and item events of the streams in a line
+func describeProgress(event string, data json.RawMessage) string {
+	switch event {
+	case sseEventProgress:
+		var progress dto.ProgressEventDTO
+		if err := json.Unmarshal(data, &progress); err == nil {
+			return describeProgressEvent(progress)
+		}
+	case sseEventItem:
+		var item dto.ItemProgressEventDTO
+		if err := json.Unmarshal(data, &item); err == nil {
+			status := "ok"
+			if !item.Success {
+				status = "failed"
+			}
+			return fmt.Sprintf("[%d/%d] %s: %s - %s", item.ProcessedItems, item.TotalItems, item.Item, status, item.Message)
+		}
+	}
+	return fmt.Sprintf("%s: %s", event, data)
+}
+
+func describeProgressEvent(progress dto.ProgressEventDTO) string {
+	contexts := []string{progress.Instance}
+	if progress.User != "" {
+		contexts = append(contexts, progress.User)
+	}
+	if progress.Database != "" {
+		contexts = append(contexts, progress.Database)
+	}
+	return fmt.Sprintf("[%s] %s", strings.Join(contexts, " # "), progress.Message)
+}
@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type paramKind int
+
+const (
+	kindString paramKind = iota
+	kindBool
+	kindInt
+	kindList
+)
+
+type paramLocation int
+
+const (
+	inQuery paramLocation = iota
+	inBody
+)
+
+type bodyKind int
+
+const (
+	bodyNone bodyKind = iota
+	bodyJSON
+	bodyYAML
+)
+
+var ErrUsage = errors.New("invalid usage")
+
+// param godoc
+// Flag of a command, sent as a query param or as a field of the JSON body. The name of the fields of the body are
+// separated by dots in nested objects and lists, e.g. instancesData.0.databasesIds.
+type param struct {
+	flag     string
+	name     string
+	kind     paramKind
+	in       paramLocation
+	usage    string
+	required bool
+}
+
+// command godoc
+// Action on a resource, wrapping a route of the API. Commands with body read it from --file, whose fields are
+// overridden by the flags of the body. Commands with stream can follow the progress of the operation with --stream.
+type command struct {
+	action   string
+	summary  string
+	method   string
+	path     string
+	params   []param
+	body     bodyKind
+	paging   bool
+	async    bool
+	stream   string
+	columns  []string
+	render   func(p *printer, response *Response) error
+	public   bool
+	absolute bool
+}
+
+type resource struct {
+	name     string
+	aliases  []string
+	summary  string
+	commands []*command
+}
+
+// invocation godoc
+// Values of the flags of a command, after parsed
+type invocation struct {
+	strings map[string]*string
+	bools   map[string]*bool
+	ints    map[string]*int
+	visited map[string]bool
+	file    string
+	async   bool
+	stream  bool
+}
+
+func (c *command) flagSet(name string, opts *options, stderr io.Writer) (*flag.FlagSet, *invocation) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts.register(fs)
+	inv := &invocation{
+		strings: make(map[string]*string),
+		bools:   make(map[string]*bool),
+		ints:    make(map[string]*int),
+		visited: make(map[string]bool),
+	}
+	for _, p := range c.params {
+		switch p.kind {
+		case kindBool:
+			inv.bools[p.flag] = fs.Bool(p.flag, false, p.usage)
+		case kindInt:
+			inv.ints[p.flag] = fs.Int(p.flag, 0, p.usage)
+		case kindList:
+			inv.strings[p.flag] = fs.String(p.flag, "", p.usage+" (comma separated)")
+		default:
+			inv.strings[p.flag] = fs.String(p.flag, "", p.usage)
+		}
+	}
+	if c.paging {
+		inv.ints["page"] = fs.Int("page", 0, "Page number")
+		inv.ints["limit"] = fs.Int("limit", 0, "Limit per page")
+	}
+	switch c.body {
+	case bodyJSON:
+		fs.StringVar(&inv.file, "file", "", "JSON file with the request body, - to read it from stdin. The flags override its fields")
+	case bodyYAML:
+		fs.StringVar(&inv.file, "file", "", "YAML (or JSON) file with the desired state, - to read it from stdin")
+	}
+	if c.async {
+		fs.BoolVar(&inv.async, "async", false, "Process the operation in background as a job, returning the job right away")
+	}
+	if c.stream != "" {
+		fs.BoolVar(&inv.stream, "stream", false, "Follow the progress of the operation until it finishes")
+	}
+	return fs, inv
+}
+
+func (c *command) parse(name string, args []string, opts *options, stderr io.Writer) (*invocation, error) {
+	fs, inv := c.flagSet(name, opts, stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "%s\n\nUsage: zg-data-guard-cli %s [flags]\n\nFlags:\n", c.summary, name)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("%w: unexpected argument '%s', see 'zg-data-guard-cli %s --help'", ErrUsage, fs.Arg(0), name)
+	}
+	fs.Visit(func(f *flag.Flag) { inv.visited[f.Name] = true })
+	for _, p := range c.params {
+		if p.required && !inv.visited[p.flag] {
+			return nil, fmt.Errorf("%w: the flag --%s is required, see 'zg-data-guard-cli %s --help'", ErrUsage, p.flag, name)
+		}
+	}
+	if c.body == bodyYAML && inv.file == "" {
+		return nil, fmt.Errorf("%w: the flag --file is required, see 'zg-data-guard-cli %s --help'", ErrUsage, name)
+	}
+	if inv.async && inv.stream {
+		return nil, fmt.Errorf("%w: --async and --stream can't be used together", ErrUsage)
+	}
+	return inv, nil
+}
+
+// request godoc
+// Builds the request to the route of the command with the flags informed
+func (c *command) request(inv *invocation, stdin io.Reader) (Request, error) {
+	request := Request{Method: c.method, Path: c.path, Query: url.Values{}, Absolute: c.absolute}
+	body := map[string]any{}
+	for _, p := range c.params {
+		if !inv.visited[p.flag] {
+			continue
+		}
+		value := inv.value(p)
+		if p.in == inQuery {
+			request.Query.Set(p.name, queryValue(value))
+			continue
+		}
+		if err := setField(body, strings.Split(p.name, "."), value); err != nil {
+			return request, err
+		}
+	}
+	if c.paging {
+		for _, name := range []string{"page", "limit"} {
+			if inv.visited[name] {
+				request.Query.Set(name, strconv.Itoa(*inv.ints[name]))
+			}
+		}
+	}
+	if inv.async {
+		request.Query.Set("async", "true")
+	}
+	if inv.stream {
+		request.Path = c.stream
+	}
+	switch c.body {
+	case bodyYAML:
+		content, err := readFile(inv.file, stdin)
+		if err != nil {
+			return request, err
+		}
+		request.Body, request.ContentType = content, contentTypeYAML
+	case bodyJSON:
+		content, err := c.jsonBody(inv, body, stdin)
+		if err != nil {
+			return request, err
+		}
+		request.Body = content
+	}
+	return request, nil
+}
+
+func (c *command) jsonBody(inv *invocation, fields map[string]any, stdin io.Reader) ([]byte, error) {
+	body := map[string]any{}
+	if inv.file != "" {
+		content, err := readFile(inv.file, stdin)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(content, &body); err != nil {
+			return nil, fmt.Errorf("%w: the file %s isn't a JSON object: %v", ErrUsage, inv.file, err)
+		}
+	}
+	if err := mergeFields(body, fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(body)
+}
+
+func (inv *invocation) value(p param) any {
+	switch p.kind {
+	case kindBool:
+		return *inv.bools[p.flag]
+	case kindInt:
+		return *inv.ints[p.flag]
+	case kindList:
+		var items []string
+		for _, item := range strings.Split(*inv.strings[p.flag], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	default:
+		return *inv.strings[p.flag]
+	}
+}
+
+func queryValue(value any) string {
+	if items, ok := value.([]string); ok {
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// setField godoc
+// Sets the value in the path of the body, creating the nested objects and lists, where the numeric keys are the
+// indexes of the lists
+func setField(body map[string]any, path []string, value any) error {
+	if len(path) == 1 {
+		body[path[0]] = value
+		return nil
+	}
+	if index, err := strconv.Atoi(path[1]); err == nil {
+		list, _ := body[path[0]].([]any)
+		for len(list) <= index {
+			list = append(list, map[string]any{})
+		}
+		item, ok := list[index].(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: the field %s of the body isn't a list of objects", ErrUsage, path[0])
+		}
+		body[path[0]] = list
+		return setField(item, path[2:], value)
+	}
+	nested, ok := body[path[0]].(map[string]any)
+	if !ok {
+		nested = map[string]any{}
+		body[path[0]] = nested
+	}
+	return setField(nested, path[1:], value)
+}
+
+// mergeFields godoc
+// Merges the fields of the flags into the body read from the file, overriding its values
+func mergeFields(body, fields map[string]any) error {
+	for key, value := range fields {
+		if list, ok := value.([]any); ok {
+			current, _ := body[key].([]any)
+			for i, item := range list {
+				if i >= len(current) {
+					current = append(current, item)
+					continue
+				}
+				currentItem, isObject := current[i].(map[string]any)
+				if !isObject {
+					return fmt.Errorf("%w: the field %s of the body isn't a list of objects", ErrUsage, key)
+				}
+				if err := mergeFields(currentItem, item.(map[string]any)); err != nil {
+					return err
+				}
+			}
+			body[key] = current
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			current, isObject := body[key].(map[string]any)
+			if !isObject {
+				current = map[string]any{}
+			}
+			if err := mergeFields(current, nested); err != nil {
+				return err
+			}
+			body[key] = current
+			continue
+		}
+		body[key] = value
+	}
+	return nil
+}
+
+func readFile(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the body from stdin: %w", err)
+		}
+		return content, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the file %s: %w", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,495 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+const (
+	flagInstanceIDs = "instance-ids"
+	flagDryRun      = "dry-run"
+	usageDryRun     = "Validate the operation and show the statements it would execute, without executing them"
+)
+
+// resources godoc
+// The commands of the client, one for each route of the API registered in router/routes.go
+func resources() []*resource {
+	return []*resource{
+		{
+			name:    "health",
+			summary: "Show the health check of the application",
+			commands: []*command{
+				{summary: "Show the health check of the application", method: http.MethodGet, path: "/healthcheck/info", public: true, absolute: true},
+			},
+		},
+		{
+			name:    "ecosystem",
+			summary: "Manage the ecosystems where the instances run",
+			commands: []*command{
+				{action: "list", summary: "List the ecosystems", method: http.MethodGet, path: "/ecosystems", paging: true,
+					columns: []string{"id", "code", "displayName", "requiresAccessApproval", "createdAt"}},
+				{action: "get", summary: "Show an ecosystem", method: http.MethodGet, path: "/ecosystem", params: []param{idParam("Ecosystem ID")}},
+				{action: "create", summary: "Create an ecosystem", method: http.MethodPost, path: "/ecosystem", body: bodyJSON, params: ecosystemParams()},
+				{action: "update", summary: "Update an ecosystem", method: http.MethodPut, path: "/ecosystem", body: bodyJSON,
+					params: append([]param{idParam("Ecosystem ID")}, ecosystemParams()...)},
+				{action: "delete", summary: "Delete an ecosystem", method: http.MethodDelete, path: "/ecosystem", params: []param{idParam("Ecosystem ID")}},
+			},
+		},
+		{
+			name:    "technology",
+			summary: "Manage the database technologies",
+			commands: []*command{
+				{action: "list", summary: "List the technologies", method: http.MethodGet, path: "/technologies", paging: true,
+					columns: []string{"id", "name", "version", "createdAt"}},
+				{action: "get", summary: "Show a technology", method: http.MethodGet, path: "/technology", params: []param{idParam("Technology ID")}},
+				{action: "create", summary: "Create a technology", method: http.MethodPost, path: "/technology", body: bodyJSON, params: technologyParams()},
+				{action: "update", summary: "Update a technology", method: http.MethodPut, path: "/technology", body: bodyJSON,
+					params: append([]param{idParam("Technology ID")}, technologyParams()...)},
+				{action: "delete", summary: "Delete a technology", method: http.MethodDelete, path: "/technology", params: []param{idParam("Technology ID")}},
+			},
+		},
+		{
+			name:    "instance",
+			aliases: []string{"database-instance"},
+			summary: "Manage the database instances (clusters)",
+			commands: []*command{
+				{action: "list", summary: "List the instances", method: http.MethodGet, path: "/database-instances",
+					params: []param{
+						queryParam("ecosystem-id", "ecosystemId", "Ecosystem ID"),
+						queryParam("technology-id", "technologyId", "Database Technology ID"),
+						{flag: "only-enabled", name: "onlyEnabled", kind: kindBool, usage: "Only enabled instances"},
+					},
+					columns: []string{"id", "name", "ecosystemName", "databaseTechnologyName", "databaseTechnologyVersion", "host", "port", "enabled", "connectionStatus"}},
+				{action: "get", summary: "Show an instance", method: http.MethodGet, path: "/database-instance", params: []param{idParam("Database Instance ID")}},
+				{action: "credentials", summary: "Show the admin credentials of an instance", method: http.MethodGet, path: "/database-instance/credentials",
+					params: []param{idParam("Database Instance ID")}},
+				{action: "create", summary: "Create an instance, the certificates and the bastion are only informed in the file", method: http.MethodPost,
+					path: "/database-instance", body: bodyJSON, params: instanceParams()},
+				{action: "update", summary: "Update an instance, the certificates and the bastion are only informed in the file", method: http.MethodPut,
+					path: "/database-instance", body: bodyJSON, params: append([]param{idParam("Database Instance ID")}, instanceParams()...)},
+				{action: "change-status", summary: "Enable or disable an instance", method: http.MethodPatch, path: "/database-instance/change-status",
+					body: bodyJSON, params: changeStatusParams("Database Instance ID")},
+				{action: "test-connection", summary: "Test the connection with the instances", method: http.MethodPost, path: "/database-instance/test-connection",
+					body: bodyJSON, params: []param{instanceIDsParam()}},
+				{action: "sync-databases", summary: "Sync the databases of the instances", method: http.MethodPost, path: "/database-instance/sync-databases",
+					body: bodyJSON, async: true, params: []param{instanceIDsParam()}},
+				{action: "propagate-roles", summary: "Create the roles in the instances", method: http.MethodPost, path: "/database-instance/propagate-roles",
+					body: bodyJSON, async: true, render: renderPropagateRoles,
+					params: []param{instanceIDsParam(), bodyParam(flagDryRun, "dryRun", kindBool, usageDryRun)}},
+				{action: "discover-roles", summary: "Discover the logins of the instances", method: http.MethodPost, path: "/database-instance/discover-roles",
+					body: bodyJSON, async: true, params: []param{instanceIDsParam()}},
+				{action: "role-findings", summary: "List the logins discovered in the instances", method: http.MethodGet, path: "/role-findings", paging: true,
+					params: []param{
+						queryParam("instance-id", "databaseInstanceId", "Database Instance ID"),
+						queryParam("class", "class", "Class of the login: MANAGED, MISMATCHED, UNKNOWN, PRIVILEGED or SUPERUSER"),
+					},
+					columns: []string{"databaseInstanceName", "loginName", "class", "databaseUserName", "details", "discoveredAt"}},
+			},
+		},
+		{
+			name:    "database",
+			summary: "Manage the databases of the instances",
+			commands: []*command{
+				{action: "list", summary: "List the databases", method: http.MethodGet, path: "/databases",
+					params: []param{
+						queryParam("ecosystem-id", "ecosystemId", "Ecosystem ID"),
+						queryParam("instance-id", "databaseInstanceId", "Database Instance ID"),
+					},
+					columns: []string{"id", "name", "databaseInstanceName", "ecosystemName", "currentSize", "enabled", "rolesConfigured", "rolesTemplateVersion"}},
+				{action: "get", summary: "Show a database", method: http.MethodGet, path: "/database", params: []param{idParam("Database ID")}},
+				{action: "outdated-roles", summary: "List the databases whose roles weren't set up with the current grants", method: http.MethodGet,
+					path: "/databases/outdated-roles",
+					params: []param{
+						queryParam("ecosystem-id", "ecosystemId", "Ecosystem ID"),
+						queryParam("instance-id", "databaseInstanceId", "Database Instance ID"),
+					}},
+				{action: "setup-roles", summary: "Set up the grants of the roles in the databases", method: http.MethodPost, path: "/database/setup-roles",
+					body: bodyJSON, async: true, render: renderSetupRoles,
+					params: []param{
+						bodyParam("instance-id", "databaseInstanceId", kindString, "Database Instance ID"),
+						bodyParam("database-ids", "databasesIds", kindList, "Database IDs"),
+						bodyParam("only-outdated", "onlyOutdated", kindBool, "Only the databases whose roles weren't set up with the current grants"),
+						bodyParam(flagDryRun, "dryRun", kindBool, usageDryRun),
+					}},
+			},
+		},
+		{
+			name:    "role",
+			aliases: []string{"database-role"},
+			summary: "Manage the roles of the database users",
+			commands: []*command{
+				{action: "list", summary: "List the roles", method: http.MethodGet, path: "/database-roles",
+					columns: []string{"id", "name", "displayName", "readOnly", "description"}},
+				{action: "get", summary: "Show a role", method: http.MethodGet, path: "/database-role", params: []param{idParam("Database Role ID")}},
+				{action: "create", summary: "Create a role, its privileges are informed in the file", method: http.MethodPost, path: "/database-role",
+					body: bodyJSON, params: append([]param{bodyParam("name", "name", kindString, "Name")}, roleParams()...)},
+				{action: "update", summary: "Update a role, its privileges are informed in the file", method: http.MethodPut, path: "/database-role",
+					body: bodyJSON, params: append([]param{idParam("Database Role ID")}, roleParams()...)},
+				{action: "delete", summary: "Delete a role", method: http.MethodDelete, path: "/database-role", params: []param{idParam("Database Role ID")}},
+			},
+		},
+		{
+			name:    "user",
+			aliases: []string{"database-user"},
+			summary: "Manage the database users",
+			commands: []*command{
+				{action: "list", summary: "List the users", method: http.MethodGet, path: "/database-users",
+					params:  []param{{flag: "only-enabled", name: "onlyEnabled", kind: kindBool, usage: "Only enabled users"}},
+					columns: []string{"id", "name", "email", "username", "databaseRoleName", "team", "enabled"}},
+				{action: "get", summary: "Show a user", method: http.MethodGet, path: "/database-user", params: []param{idParam("Database User ID")}},
+				{action: "credentials", summary: "Show the credentials of a user", method: http.MethodGet, path: "/database-user/credentials",
+					params: []param{idParam("Database User ID")}},
+				{action: "create", summary: "Create a user", method: http.MethodPost, path: "/database-user", body: bodyJSON,
+					params: append([]param{bodyParam("email", "email", kindString, "Email")}, userParams()...)},
+				{action: "update", summary: "Update a user", method: http.MethodPut, path: "/database-user", body: bodyJSON,
+					params: append([]param{idParam("Database User ID")}, userParams()...)},
+				{action: "change-status", summary: "Enable or disable a user", method: http.MethodPatch, path: "/database-user/change-status",
+					body: bodyJSON, params: changeStatusParams("Database User ID")},
+				{action: "migrate-role", summary: "Change the role of a user and of its access permissions", method: http.MethodPost,
+					path: "/database-user/migrate-role", body: bodyJSON,
+					params: []param{idParam("Database User ID"), bodyParam("role-id", "databaseRoleId", kindString, "Database Role ID")}},
+			},
+		},
+		{
+			name:    "access",
+			aliases: []string{"access-permission"},
+			summary: "Grant and revoke the access of the users to the databases",
+			commands: []*command{
+				{action: "list", summary: "List the access permissions", method: http.MethodGet, path: "/access-permissions",
+					params: []param{
+						queryParam("database-id", "databaseId", "Database ID"),
+						queryParam("user-id", "databaseUserId", "Database User ID"),
+						queryParam("instance-id", "databaseInstanceId", "Database Instance ID"),
+					},
+					columns: []string{"id", "databaseUserName", "databaseRoleName", "databaseInstanceName", "databaseName", "grantedAt", "expiresAt"}},
+				{action: "grant", summary: "Grant the access of users to databases", method: http.MethodPost, path: "/access-permission/grant",
+					stream: "/access-permission/grant/stream", body: bodyJSON, async: true, render: renderAccessOutput,
+					params: append(grantParams(), bodyParam(flagDryRun, "dryRun", kindBool, usageDryRun))},
+				{action: "revoke", summary: "Revoke the access of a user to instances, removing the user from them", method: http.MethodPost,
+					path: "/access-permission/revoke", stream: "/access-permission/revoke/stream", body: bodyJSON, render: renderAccessOutput,
+					params: []param{
+						bodyParam("user-id", "databaseUserId", kindString, "Database User ID"),
+						instanceIDsParam(),
+						bodyParam(flagDryRun, "dryRun", kindBool, usageDryRun),
+					}},
+				{action: "break-glass", summary: "Grant an emergency access to a user, revoked automatically", method: http.MethodPost,
+					path: "/access-permission/break-glass", body: bodyJSON,
+					params: []param{
+						bodyParam("user-id", "databaseUserId", kindString, "Database User ID"),
+						bodyParam("instance-id", "instancesData.0.databaseInstanceId", kindString, "Database Instance ID, use the file for many instances"),
+						bodyParam("database-ids", "instancesData.0.databasesIds", kindList, "Database IDs of the instance"),
+						bodyParam("incident", "incidentReference", kindString, "Reference of the incident"),
+					}},
+				{action: "change-role", summary: "Change the role of an access permission", method: http.MethodPost, path: "/access-permission/change-role",
+					body: bodyJSON, params: []param{idParam("Access Permission ID"), bodyParam("role-id", "databaseRoleId", kindString, "Database Role ID")}},
+				{action: "reconcile", summary: "Compare the access permissions with the effective access in the instances", method: http.MethodPost,
+					path: "/access-permission/reconcile", body: bodyJSON, async: true,
+					params: []param{instanceIDsParam(), bodyParam("apply", "apply", kindBool, "Fix the drifts found, instead of only reporting them")}},
+				{action: "logs", summary: "List the logs of the access permissions", method: http.MethodGet, path: "/access-permission/logs", paging: true,
+					params:  []param{queryParam("type", "type", "Log type: ACCESS, BREAK_GLASS or RECONCILE")},
+					columns: []string{"date", "type", "databaseInstanceName", "databaseName", "databaseUserName", "success", "message", "operationUserName"}},
+			},
+		},
+		{
+			name:    "access-request",
+			summary: "Request access to the ecosystems that require approval",
+			commands: []*command{
+				{action: "list", summary: "List the access requests", method: http.MethodGet, path: "/access-requests", paging: true,
+					params: []param{
+						queryParam("status", "status", "Status: REQUESTED, APPROVED, REJECTED, EXECUTED, FAILED or EXPIRED"),
+						queryParam("requested-by", "requestedByUserId", "ID of the application user who requested the access"),
+					},
+					columns: []string{"id", "status", "requestedByUser", "justification", "createdAt", "expiresAt"}},
+				{action: "get", summary: "Show an access request", method: http.MethodGet, path: "/access-request", params: []param{idParam("Access Request ID")}},
+				{action: "create", summary: "Request the access of users to databases", method: http.MethodPost, path: "/access-request", body: bodyJSON,
+					params: append(grantParams(), bodyParam("justification", "justification", kindString, "Justification of the request"))},
+				{action: "approve", summary: "Approve an access request, granting the access", method: http.MethodPost, path: "/access-request/approve",
+					body: bodyJSON, params: reviewParams()},
+				{action: "reject", summary: "Reject an access request", method: http.MethodPost, path: "/access-request/reject",
+					body: bodyJSON, params: reviewParams()},
+			},
+		},
+		{
+			name:    "recertification",
+			aliases: []string{"recertification-campaign"},
+			summary: "Review periodically the access permissions",
+			commands: []*command{
+				{action: "list", summary: "List the recertification campaigns", method: http.MethodGet, path: "/recertification-campaigns", paging: true,
+					params:  []param{queryParam("status", "status", "Status: OPEN or CLOSED")},
+					columns: []string{"id", "name", "status", "ecosystemName", "team", "totalItems", "pendingItems", "keptItems", "revokedItems"}},
+				{action: "get", summary: "Show a recertification campaign", method: http.MethodGet, path: "/recertification-campaign",
+					params: []param{idParam("Recertification Campaign ID")}},
+				{action: "create", summary: "Create a recertification campaign", method: http.MethodPost, path: "/recertification-campaign", body: bodyJSON,
+					params: []param{
+						bodyParam("name", "name", kindString, "Name"),
+						bodyParam("ecosystem-id", "ecosystemId", kindString, "Ecosystem ID"),
+						bodyParam("team", "team", kindString, "Team"),
+						bodyParam("reviewer-ids", "reviewersIds", kindList, "IDs of the application users assigned as reviewers"),
+					}},
+				{action: "items", summary: "List the items of a recertification campaign", method: http.MethodGet, path: "/recertification-campaign/items",
+					paging: true,
+					params: []param{
+						idParam("Recertification Campaign ID"),
+						queryParam("reviewer-id", "reviewerId", "ID of the application user assigned as reviewer"),
+						queryParam("decision", "decision", "Decision: PENDING, KEEP or REVOKE"),
+					},
+					columns: []string{"id", "permission.databaseUserName", "permission.databaseInstanceName", "permission.databaseName", "reviewer", "decision", "decidedAt"}},
+				{action: "decide", summary: "Decide the items of a recertification campaign, the decisions are informed in the file", method: http.MethodPost,
+					path: "/recertification-campaign/decide", body: bodyJSON, params: []param{idParam("Recertification Campaign ID")}},
+				{action: "close", summary: "Close a recertification campaign", method: http.MethodPost, path: "/recertification-campaign/close",
+					params: []param{idParam("Recertification Campaign ID")}},
+				{action: "report", summary: "Show the report of a recertification campaign", method: http.MethodGet, path: "/recertification-campaign/report",
+					params: []param{idParam("Recertification Campaign ID"), queryParam("format", "format", "Format of the report: json or csv")}},
+			},
+		},
+		{
+			name:    "group",
+			aliases: []string{"access-group"},
+			summary: "Manage the access groups, whose members are granted the access to their targets",
+			commands: []*command{
+				{action: "list", summary: "List the access groups", method: http.MethodGet, path: "/access-groups", paging: true,
+					columns: []string{"id", "name", "description", "targetsQty", "membersQty", "createdAt"}},
+				{action: "get", summary: "Show an access group with its targets and members", method: http.MethodGet, path: "/access-group",
+					params: []param{idParam("Access Group ID")}},
+				{action: "create", summary: "Create an access group", method: http.MethodPost, path: "/access-group", body: bodyJSON,
+					params: append([]param{
+						bodyParam("name", "name", kindString, "Name"),
+						bodyParam("description", "description", kindString, "Description"),
+					}, targetParams()...)},
+				{action: "targets", summary: "Replace the targets of an access group", method: http.MethodPut, path: "/access-group/targets", body: bodyJSON,
+					params: append([]param{idParam("Access Group ID")}, targetParams()...)},
+				{action: "add-members", summary: "Add members to an access group, granting its targets to them", method: http.MethodPost,
+					path: "/access-group/members", body: bodyJSON,
+					params: []param{idParam("Access Group ID"), bodyParam("user-ids", "databaseUsersIds", kindList, "Database User IDs")}},
+				{action: "remove-member", summary: "Remove a member from an access group", method: http.MethodDelete, path: "/access-group/member",
+					params: []param{idParam("Access Group ID"), {flag: "user-id", name: "databaseUserId", usage: "Database User ID", required: true}}},
+			},
+		},
+		{
+			name:    "desired-state",
+			summary: "Plan and apply a desired state file",
+			commands: []*command{
+				{action: "plan", summary: "Show the changes needed to reach the desired state", method: http.MethodPost, path: "/desired-state/plan",
+					body: bodyYAML, params: []param{pruneParam()}},
+				{action: "apply", summary: "Apply the changes needed to reach the desired state", method: http.MethodPost, path: "/desired-state/apply",
+					body: bodyYAML, params: []param{pruneParam()}},
+			},
+		},
+		{
+			name:    "job",
+			summary: "Follow the operations processed in background",
+			commands: []*command{
+				{action: "list", summary: "List the jobs", method: http.MethodGet, path: "/jobs", paging: true,
+					params: []param{
+						queryParam("type", "type", "Job type: GRANT_ACCESS, SYNC_DATABASES, SETUP_ROLES, PROPAGATE_ROLES, DISCOVER_ROLES or RECONCILE_ACCESS"),
+						queryParam("status", "status", "Job status: PENDING, RUNNING, SUCCEEDED or FAILED"),
+					},
+					columns: []string{"id", "type", "status", "totalItems", "processedItems", "failedItems", "createdByUser", "createdAt", "finishedAt"}},
+				{action: "get", summary: "Show a job with its items and result", method: http.MethodGet, path: "/job", params: []param{idParam("Job ID")}},
+			},
+		},
+	}
+}
+
+func idParam(usage string) param {
+	return param{flag: "id", name: "id", usage: usage, required: true}
+}
+
+func queryParam(flag, name, usage string) param {
+	return param{flag: flag, name: name, usage: usage}
+}
+
+func bodyParam(flag, name string, kind paramKind, usage string) param {
+	return param{flag: flag, name: name, kind: kind, in: inBody, usage: usage}
+}
+
+func instanceIDsParam() param {
+	return bodyParam(flagInstanceIDs, "databaseInstancesIds", kindList, "Database Instance IDs, all the enabled ones when not informed")
+}
+
+func pruneParam() param {
+	return param{flag: "prune", name: "prune", kind: kindBool, usage: "Also remove what the desired state doesn't mention"}
+}
+
+func ecosystemParams() []param {
+	return []param{
+		bodyParam("code", "code", kindString, "Code"),
+		bodyParam("display-name", "displayName", kindString, "Display name"),
+		bodyParam("requires-approval", "requiresAccessApproval", kindBool, "The access to the instances of the ecosystem requires approval"),
+	}
+}
+
+func technologyParams() []param {
+	return []param{
+		bodyParam("name", "name", kindString, "Name"),
+		bodyParam("version", "version", kindString, "Version"),
+	}
+}
+
+func instanceParams() []param {
+	return []param{
+		bodyParam("name", "name", kindString, "Name"),
+		bodyParam("host", "host", kindString, "Host"),
+		bodyParam("port", "port", kindString, "Port"),
+		bodyParam("host-connection", "hostConnection", kindString, "Host used to connect, when different from the host"),
+		bodyParam("port-connection", "portConnection", kindString, "Port used to connect, when different from the port"),
+		bodyParam("admin-user", "adminUser", kindString, "Admin user"),
+		bodyParam("admin-password", "adminPassword", kindString, "Admin password, prefer informing it in the file"),
+		bodyParam("ssl-mode", "sslMode", kindString, "SSL mode"),
+		bodyParam("ecosystem-id", "ecosystemId", kindString, "Ecosystem ID"),
+		bodyParam("technology-id", "databaseTechnologyId", kindString, "Database Technology ID"),
+		bodyParam("note", "note", kindString, "Note"),
+	}
+}
+
+func changeStatusParams(usage string) []param {
+	return []param{
+		{flag: "id", name: "id", in: inBody, usage: usage, required: true},
+		{flag: "enabled", name: "enabled", kind: kindBool, in: inBody, usage: "Enable (--enabled) or disable (--enabled=false)", required: true},
+	}
+}
+
+func roleParams() []param {
+	return []param{
+		bodyParam("display-name", "displayName", kindString, "Display name"),
+		bodyParam("description", "description", kindString, "Description"),
+	}
+}
+
+func userParams() []param {
+	return []param{
+		bodyParam("name", "name", kindString, "Name"),
+		bodyParam("team", "team", kindString, "Team"),
+		bodyParam("position", "position", kindString, "Position"),
+		bodyParam("role-id", "databaseRoleId", kindString, "Database Role ID"),
+	}
+}
+
+// grantParams godoc
+// The access to the databases of a single instance can be informed by flags, many instances are informed in the file
+func grantParams() []param {
+	return append([]param{
+		bodyParam("user-ids", "databaseUsersIds", kindList, "Database User IDs"),
+		bodyParam("expires-at", "expiresAt", kindString, "When the access is revoked automatically, e.g. 2024-06-01T18:00:00Z"),
+	}, targetParams()...)
+}
+
+func targetParams() []param {
+	return []param{
+		bodyParam("instance-id", "instancesData.0.databaseInstanceId", kindString, "Database Instance ID, use the file for many instances"),
+		bodyParam("database-ids", "instancesData.0.databasesIds", kindList, "Database IDs of the instance"),
+	}
+}
+
+func reviewParams() []param {
+	return []param{idParam("Access Request ID"), bodyParam("comment", "comment", kindString, "Comment of the review")}
+}
+
+// renderAccessOutput godoc
+// Prints the output of the grant and of the revoke, followed by the statements of the plan in dry run
+func renderAccessOutput(p *printer, response *Response) error {
+	var output struct {
+		Plan []dto.DryRunInstanceOutputDTO `json:"plan"`
+	}
+	if err := json.Unmarshal(response.Data, &output); err != nil {
+		return fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	withoutPlan, err := withoutField(response, "plan")
+	if err != nil {
+		return err
+	}
+	if err = p.print(withoutPlan, nil); err != nil {
+		return err
+	}
+	var statements [][]string
+	for _, instance := range output.Plan {
+		statements = append(statements, databasesStatements(instance.Instance, instance.Databases)...)
+	}
+	return p.printStatements(statements)
+}
+
+// renderSetupRoles godoc
+// Prints the databases set up, followed by their statements in dry run
+func renderSetupRoles(p *printer, response *Response) error {
+	var outputs []dto.SetupRolesOutputDTO
+	if err := json.Unmarshal(response.Data, &outputs); err != nil {
+		return fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	columns := []string{"databaseId", "databaseName", "instance", "success", "message"}
+	if err := p.print(response, columns); err != nil {
+		return err
+	}
+	var statements [][]string
+	for _, output := range outputs {
+		for _, statement := range output.Statements {
+			statements = append(statements, []string{output.Instance, output.DatabaseName, statement})
+		}
+	}
+	return p.printStatements(statements)
+}
+
+// renderPropagateRoles godoc
+// Prints the instances where the roles were propagated, followed by their statements in dry run
+func renderPropagateRoles(p *printer, response *Response) error {
+	var outputs []dto.PropagateRolesOutputDTO
+	if err := json.Unmarshal(response.Data, &outputs); err != nil {
+		return fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	columns := []string{"databaseInstanceId", "instance", "technology", "success", "message"}
+	if err := p.print(response, columns); err != nil {
+		return err
+	}
+	var statements [][]string
+	for _, output := range outputs {
+		statements = append(statements, databasesStatements(output.Instance, output.Databases)...)
+	}
+	return p.printStatements(statements)
+}
+
+func databasesStatements(instance string, databases []dto.DryRunDatabaseOutputDTO) [][]string {
+	var statements [][]string
+	for _, database := range databases {
+		for _, statement := range database.Statements {
+			statements = append(statements, []string{instance, database.Database, statement})
+		}
+	}
+	return statements
+}
+
+func (p *printer) printStatements(statements [][]string) error {
+	if len(statements) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(p.out, "\nSTATEMENTS:"); err != nil {
+		return err
+	}
+	return p.printTable([]string{"INSTANCE", "DATABASE", "STATEMENT"}, statements)
+}
+
+// withoutField godoc
+// Returns the response without the field of its data, which is printed apart
+func withoutField(response *Response, key string) (*Response, error) {
+	fields, err := objectFields(response.Data)
+	if err != nil {
+		return nil, err
+	}
+	var buffer []byte
+	buffer = append(buffer, '{')
+	for _, f := range fields {
+		if f.key == key {
+			continue
+		}
+		if len(buffer) > 1 {
+			buffer = append(buffer, ',')
+		}
+		name, _ := json.Marshal(f.key)
+		buffer = append(append(append(buffer, name...), ':'), f.value...)
+	}
+	buffer = append(buffer, '}')
+	result := *response
+	result.Data = buffer
+	return &result, nil
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	envCredentials     = "ZG_DATA_GUARD_CREDENTIALS"
+	envURL             = "ZG_DATA_GUARD_URL"
+	envToken           = "ZG_DATA_GUARD_TOKEN"
+	credentialsDirName = "zg-data-guard"
+	credentialsFile    = "credentials.json"
+	bearerPrefix       = "Bearer "
+)
+
+var (
+	ErrNotLoggedIn  = errors.New("not logged in, run 'zg-data-guard-cli login' first")
+	ErrTokenExpired = errors.New("the token has expired, run 'zg-data-guard-cli login' again")
+)
+
+// Credentials godoc
+// The URL of the application and the JWT token used to call its API, stored by the login
+type Credentials struct {
+	URL         string `json:"url"`
+	AccessToken string `json:"accessToken"`
+	ExpiresAt   int64  `json:"expiresAt,omitempty"`
+}
+
+// Expired godoc
+// A token without expiration never expires
+func (c *Credentials) Expired(now time.Time) bool {
+	return c.ExpiresAt > 0 && now.Unix() >= c.ExpiresAt
+}
+
+// credentialsPath godoc
+// The credentials are stored in the user config dir (e.g. ~/.config/zg-data-guard/credentials.json on Linux), unless
+// another file is set in ZG_DATA_GUARD_CREDENTIALS
+func credentialsPath() (string, error) {
+	if path := os.Getenv(envCredentials); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding the config dir of the user: %w", err)
+	}
+	return filepath.Join(dir, credentialsDirName, credentialsFile), nil
+}
+
+// loadCredentials godoc
+// Loads the stored credentials. ZG_DATA_GUARD_URL and ZG_DATA_GUARD_TOKEN take precedence over them, so pipelines
+// don't need to log in.
+func loadCredentials() (*Credentials, error) {
+	credentials := &Credentials{}
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading the credentials in %s: %w", path, err)
+	}
+	if err == nil {
+		if err = json.Unmarshal(content, credentials); err != nil {
+			return nil, fmt.Errorf("error decoding the credentials in %s, run 'zg-data-guard-cli login' again: %w", path, err)
+		}
+	}
+	if url := os.Getenv(envURL); url != "" {
+		credentials.URL = url
+	}
+	if token := os.Getenv(envToken); token != "" {
+		credentials.AccessToken = normalizeToken(token)
+		credentials.ExpiresAt = tokenExpiration(credentials.AccessToken)
+	}
+	return credentials, nil
+}
+
+// saveCredentials godoc
+// Only the user can read the file, since the token gives access to the API. The credentials are written to a new file,
+// created readable only by the user, which then replaces the previous one, so a file left with a wider mode (e.g. by an
+// older version) doesn't keep it.
+func saveCredentials(credentials *Credentials) (string, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("error creating the dir of the credentials: %w", err)
+	}
+	content, err := json.MarshalIndent(credentials, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err = writePrivateFile(path, content); err != nil {
+		return "", fmt.Errorf("error writing the credentials in %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// writePrivateFile godoc
+// Writes the content to a temp file in the same dir, which is created with mode 0600, and renames it to the path
+func writePrivateFile(path string, content []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(content); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func removeCredentials() (string, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return "", err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("error removing the credentials in %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// normalizeToken godoc
+// The API expects the token with the Bearer prefix, as returned by the authentication
+func normalizeToken(token string) string {
+	token = strings.TrimSpace(token)
+	if token == "" || strings.HasPrefix(token, bearerPrefix) {
+		return token
+	}
+	return bearerPrefix + token
+}
+
+// tokenClaims godoc
+// Reads the claims of the JWT token without verifying it, which is up to the API. It's empty when the token isn't a JWT.
+func tokenClaims(token string) map[string]any {
+	parts := strings.Split(strings.TrimPrefix(token, bearerPrefix), ".")
+	if len(parts) != 3 {
+		return map[string]any{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return map[string]any{}
+	}
+	claims := map[string]any{}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return map[string]any{}
+	}
+	return claims
+}
+
+func tokenExpiration(token string) int64 {
+	if exp, ok := tokenClaims(token)["exp"].(float64); ok {
+		return int64(exp)
+	}
+	return 0
+}
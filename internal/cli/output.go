@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printer godoc
+// Writes the responses of the API as tables or as the JSON of their data
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// field godoc
+// Field of a JSON object, in the order of the object
+type field struct {
+	key   string
+	value json.RawMessage
+}
+
+func newPrinter(out io.Writer, format string) (*printer, error) {
+	if format != outputTable && format != outputJSON {
+		return nil, fmt.Errorf("invalid output '%s', use %s or %s", format, outputTable, outputJSON)
+	}
+	return &printer{out: out, format: format}, nil
+}
+
+// print godoc
+// Prints the response with the columns given for lists, or with every field of the data when they aren't given. What
+// isn't JSON, like the CSV reports, is printed as is.
+func (p *printer) print(response *Response, columns []string) error {
+	if !strings.HasPrefix(response.ContentType, contentTypeJSON) {
+		_, err := p.out.Write(response.Raw)
+		return err
+	}
+	if p.format == outputJSON {
+		return p.printJSON(response)
+	}
+	data := bytes.TrimSpace(response.Data)
+	switch {
+	case len(data) == 0 || string(data) == "null":
+		_, err := fmt.Fprintln(p.out, response.Message)
+		return err
+	case data[0] == '[':
+		if err := p.printList(data, columns); err != nil {
+			return err
+		}
+		return p.printPaging(response, data)
+	case data[0] == '{':
+		return p.printObject(data)
+	default:
+		_, err := fmt.Fprintln(p.out, formatValue(data))
+		return err
+	}
+}
+
+func (p *printer) printJSON(response *Response) error {
+	data := response.Data
+	if len(bytes.TrimSpace(data)) == 0 {
+		content, err := json.Marshal(map[string]string{"message": response.Message})
+		if err != nil {
+			return err
+		}
+		data = content
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return fmt.Errorf("error formatting the response of the API: %w", err)
+	}
+	_, err := fmt.Fprintln(p.out, indented.String())
+	return err
+}
+
+func (p *printer) printList(data json.RawMessage, columns []string) error {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(p.out, "No results found.")
+		return err
+	}
+	if len(columns) == 0 {
+		columns = scalarKeys(rows[0])
+	}
+	table := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = formatValue(lookup(row, column))
+		}
+		table = append(table, cells)
+	}
+	return p.printTable(headers(columns), table)
+}
+
+func (p *printer) printPaging(response *Response, data json.RawMessage) error {
+	if response.Total == 0 {
+		return nil
+	}
+	var rows []json.RawMessage
+	_ = json.Unmarshal(data, &rows)
+	summary := fmt.Sprintf("\nShowing %d of %d", len(rows), response.Total)
+	if response.Page > 0 && response.Limit > 0 {
+		summary += fmt.Sprintf(" (page %d, limit %d)", response.Page, response.Limit)
+	}
+	_, err := fmt.Fprintln(p.out, summary)
+	return err
+}
+
+// printObject godoc
+// Prints the fields of the object as a list of keys and values, followed by a table for each list of objects in it
+func (p *printer) printObject(data json.RawMessage) error {
+	fields, err := objectFields(data)
+	if err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	var lists []field
+	for _, f := range fields {
+		if isListOfObjects(f.value) {
+			lists = append(lists, f)
+			continue
+		}
+		fmt.Fprintf(writer, "%s:\t%s\n", header(f.key), formatValue(f.value))
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	for _, list := range lists {
+		if _, err = fmt.Fprintf(p.out, "\n%s:\n", header(list.key)); err != nil {
+			return err
+		}
+		if err = p.printList(list.value, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) printTable(headers []string, rows [][]string) error {
+	writer := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+	return writer.Flush()
+}
+
+// objectFields godoc
+// Decodes the fields of the object keeping their order, which a map would lose
+func objectFields(data json.RawMessage) ([]field, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("error decoding the response of the API: %w", err)
+	}
+	var fields []field
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding the response of the API: %w", err)
+		}
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("error decoding the response of the API: %w", err)
+		}
+		fields = append(fields, field{key: fmt.Sprint(token), value: value})
+	}
+	return fields, nil
+}
+
+// scalarKeys godoc
+// The keys of the object whose values fit in a cell of a table
+func scalarKeys(data json.RawMessage) []string {
+	fields, err := objectFields(data)
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, f := range fields {
+		if value := bytes.TrimSpace(f.value); len(value) > 0 && value[0] != '{' && value[0] != '[' {
+			keys = append(keys, f.key)
+		}
+	}
+	return keys
+}
+
+// lookup godoc
+// Returns the value of the path in the object, with the keys of the nested objects separated by dots
+func lookup(data json.RawMessage, path string) json.RawMessage {
+	value := data
+	for _, key := range strings.Split(path, ".") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(value, &object); err != nil {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func isListOfObjects(value json.RawMessage) bool {
+	var list []json.RawMessage
+	if err := json.Unmarshal(value, &list); err != nil || len(list) == 0 {
+		return false
+	}
+	item := bytes.TrimSpace(list[0])
+	return len(item) > 0 && item[0] == '{'
+}
+
+// formatValue godoc
+// Formats a JSON value to fit in a cell: strings unquoted, lists of scalars joined and other values as compact JSON
+func formatValue(value json.RawMessage) string {
+	value = bytes.TrimSpace(value)
+	if len(value) == 0 || string(value) == "null" {
+		return ""
+	}
+	switch value[0] {
+	case '"':
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			return strings.ReplaceAll(text, "\n", " ")
+		}
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err == nil && !isListOfObjects(value) {
+			formatted := make([]string, len(items))
+			for i, item := range items {
+				formatted[i] = formatValue(item)
+			}
+			return strings.Join(formatted, ", ")
+		}
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, value); err != nil {
+		return string(value)
+	}
+	return compact.String()
+}
+
+func headers(columns []string) []string {
+	result := make([]string, len(columns))
+	for i, column := range columns {
+		result[i] = header(column)
+	}
+	return result
+}
+
+// header godoc
+// The header of the column of a key in camel case, e.g. DATABASE_NAME for permission.databaseName
+func header(key string) string {
+	if index := strings.LastIndex(key, "."); index >= 0 {
+		key = key[index+1:]
+	}
+	var builder strings.Builder
+	for i, r := range key {
+		if i > 0 && unicode.IsUpper(r) {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
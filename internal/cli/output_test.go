@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGivenKeysInCamelCase_WhenHeader_ThenShouldReturnThemInUpperSnakeCase(t *testing.T) {
+	assert.Equal(t, "ID", header("id"))
+	assert.Equal(t, "DISPLAY_NAME", header("displayName"))
+	assert.Equal(t, "DATABASE_NAME", header("permission.databaseName"))
+}
+
+func TestGivenJSONValues_WhenFormatValue_ThenShouldFitThemInACell(t *testing.T) {
+	assert.Equal(t, "", formatValue(json.RawMessage(`null`)))
+	assert.Equal(t, "multi line", formatValue(json.RawMessage(`"multi\nline"`)))
+	assert.Equal(t, "10", formatValue(json.RawMessage(`10`)))
+	assert.Equal(t, "a, b", formatValue(json.RawMessage(`["a", "b"]`)))
+	assert.Equal(t, `{"a":1}`, formatValue(json.RawMessage(`{ "a": 1 }`)))
+	assert.Equal(t, `[{"a":1}]`, formatValue(json.RawMessage(`[{ "a": 1 }]`)))
+}
+
+func TestGivenAnObject_WhenObjectFields_ThenShouldKeepTheOrderOfTheKeys(t *testing.T) {
+	fields, err := objectFields(json.RawMessage(`{"zeta":1,"alpha":"a","middle":[1]}`))
+
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+	assert.Equal(t, "zeta", fields[0].key)
+	assert.Equal(t, "alpha", fields[1].key)
+	assert.Equal(t, "middle", fields[2].key)
+	assert.Equal(t, []string{"zeta", "alpha"}, scalarKeys(json.RawMessage(`{"zeta":1,"alpha":"a","middle":[1]}`)))
+}
+
+func TestGivenANestedPath_WhenLookup_ThenShouldReturnItsValue(t *testing.T) {
+	data := json.RawMessage(`{"permission":{"databaseName":"orders"}}`)
+
+	assert.Equal(t, `"orders"`, string(lookup(data, "permission.databaseName")))
+	assert.Nil(t, lookup(data, "permission.databaseName.other"))
+}
+
+func TestGivenAPathWithIndexes_WhenSetField_ThenShouldCreateTheNestedListsAndObjects(t *testing.T) {
+	body := map[string]any{}
+
+	require.NoError(t, setField(body, []string{"instancesData", "0", "databaseInstanceId"}, "i1"))
+	require.NoError(t, setField(body, []string{"instancesData", "0", "databasesIds"}, []string{"d1"}))
+	require.NoError(t, setField(body, []string{"filter", "team"}, "dba"))
+
+	content, _ := json.Marshal(body)
+	assert.JSONEq(t, `{"instancesData":[{"databaseInstanceId":"i1","databasesIds":["d1"]}],"filter":{"team":"dba"}}`, string(content))
+}
+
+func TestGivenAFieldThatIsNotAListOfObjects_WhenMergeFields_ThenShouldReturnUsageError(t *testing.T) {
+	body := map[string]any{"instancesData": []any{"i1"}}
+
+	err := mergeFields(body, map[string]any{"instancesData": []any{map[string]any{"databasesIds": []string{"d1"}}}})
+
+	assert.ErrorIs(t, err, ErrUsage)
+}
+
+func TestGivenAnInvalidOutput_WhenNewPrinter_ThenShouldReturnError(t *testing.T) {
+	_, err := newPrinter(nil, "yaml")
+
+	assert.EqualError(t, err, "invalid output 'yaml', use table or json")
+}
@@ -0,0 +1,314 @@
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+const maxIdleConnectionsPerPool = 2
+
+var (
+	connectionsOnce    sync.Once
+	defaultConnections *connectionManager
+)
+
+// connectionManager godoc
+// Keeps a *sql.DB per (instance, database) reused by every operation of the SQL connectors, instead of opening and
+// closing a pool on each statement. All the pools of an instance share a limit of open connections, so concurrent
+// operations on many databases of the same cluster can't exhaust its connection slots. Pools unused for longer than the
+// idle timeout are closed, and pools whose connection settings changed are replaced.
+type connectionManager struct {
+	maxConnections int
+	idleTimeout    time.Duration
+
+	mu    sync.Mutex
+	pools map[poolKey]*connectionPool
+	slots map[string]chan struct{}
+}
+
+type poolKey struct {
+	instance string
+	database string
+}
+
+type connectionPool struct {
+	db          *sql.DB
+	fingerprint string
+	inUse       int
+	lastUsed    time.Time
+	retired     bool
+}
+
+// connections godoc
+// Returns the manager shared by the connectors, created on first use with the limits read from the environment
+func connections() *connectionManager {
+	connectionsOnce.Do(func() {
+		defaultConnections = newConnectionManager(config.GetTargetMaxConnectionsPerInstance(), config.GetTargetPoolIdleTimeout())
+		go defaultConnections.evictIdlePools()
+	})
+	return defaultConnections
+}
+
+func newConnectionManager(maxConnections int, idleTimeout time.Duration) *connectionManager {
+	return &connectionManager{
+		maxConnections: maxConnections,
+		idleTimeout:    idleTimeout,
+		pools:          make(map[poolKey]*connectionPool),
+		slots:          make(map[string]chan struct{}),
+	}
+}
+
+// InvalidateConnections godoc
+// Closes the pools, the HTTP transport and the SSH tunnel of the instance, so the next operations connect with its
+// current settings (credentials, TLS, bastion). Pools in use are closed as soon as their operations finish.
+func InvalidateConnections(instanceID string) {
+	connections().invalidate(func(key poolKey) bool { return key.instance == instanceID })
+	closeHTTPTransports(func(instance string) bool { return instance == instanceID })
+	closeSSHTunnelOfInstance(instanceID)
+}
+
+// CloseConnections godoc
+// Closes the pools and the HTTP transports of every instance
+func CloseConnections() {
+	connections().invalidate(func(poolKey) bool { return true })
+	closeHTTPTransports(func(string) bool { return true })
+}
+
+// acquire godoc
+// Returns the pool of the database, opening it with newConnector when it doesn't exist or when the DSN of the
+// connection changed. The returned function must be called when the operation finishes.
+func (cm *connectionManager) acquire(connectionData dto.ConnectionInputDTO, database, dsn string, newConnector func() (driver.Connector, error)) (*sql.DB, func(), error) {
+	key := poolKey{instance: instanceKey(connectionData), database: database}
+	fingerprint := hashKey("", dsn, sshTunnelKey(connectionData))
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	pool, exists := cm.pools[key]
+	if exists && pool.fingerprint != fingerprint {
+		log.Printf("Connection settings of instance %s changed, replacing the connection pool of database %s", key.instance, key.database)
+		cm.retire(key, pool)
+		exists = false
+	}
+	if !exists {
+		driverConnector, err := newConnector()
+		if err != nil {
+			return nil, nil, err
+		}
+		db := sql.OpenDB(&limitedConnector{
+			Connector: driverConnector,
+			instance:  key.instance,
+			slots:     cm.instanceSlots(key.instance),
+			reclaim:   func() { cm.reclaimIdleConnections(key.instance) },
+		})
+		db.SetMaxOpenConns(cm.maxConnections)
+		db.SetMaxIdleConns(min(maxIdleConnectionsPerPool, cm.maxConnections))
+		db.SetConnMaxIdleTime(cm.idleTimeout)
+		pool = &connectionPool{db: db, fingerprint: fingerprint}
+		cm.pools[key] = pool
+	}
+	pool.inUse++
+
+	var once sync.Once
+	return pool.db, func() { once.Do(func() { cm.release(pool) }) }, nil
+}
+
+func (cm *connectionManager) release(pool *connectionPool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	pool.inUse--
+	pool.lastUsed = time.Now()
+	if pool.retired && pool.inUse == 0 {
+		_ = pool.db.Close()
+	}
+}
+
+// retire godoc
+// Removes the pool from the manager, closing it right away when it's not in use. Must be called with the lock held.
+func (cm *connectionManager) retire(key poolKey, pool *connectionPool) {
+	delete(cm.pools, key)
+	pool.retired = true
+	if pool.inUse == 0 {
+		_ = pool.db.Close()
+	}
+}
+
+func (cm *connectionManager) invalidate(match func(poolKey) bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for key, pool := range cm.pools {
+		if match(key) {
+			log.Printf("Closing the connection pool of database %s on instance %s", key.database, key.instance)
+			cm.retire(key, pool)
+		}
+	}
+}
+
+// closeIdlePools godoc
+// Closes the pools that are not in use since before the idle timeout
+func (cm *connectionManager) closeIdlePools(now time.Time) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for key, pool := range cm.pools {
+		if pool.inUse == 0 && now.Sub(pool.lastUsed) >= cm.idleTimeout {
+			log.Printf("Closing the idle connection pool of database %s on instance %s", key.database, key.instance)
+			cm.retire(key, pool)
+		}
+	}
+}
+
+func (cm *connectionManager) evictIdlePools() {
+	ticker := time.NewTicker(cm.idleTimeout / 2)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		cm.closeIdlePools(now)
+	}
+}
+
+func (cm *connectionManager) instanceSlots(instance string) chan struct{} {
+	slots, exists := cm.slots[instance]
+	if !exists {
+		slots = make(chan struct{}, cm.maxConnections)
+		cm.slots[instance] = slots
+	}
+	return slots
+}
+
+// reclaimIdleConnections godoc
+// Idle connections kept by the pools of the other databases also hold slots of the instance, so they are closed when
+// a pool needs a connection and the limit is reached
+func (cm *connectionManager) reclaimIdleConnections(instance string) {
+	cm.mu.Lock()
+	var dbs []*sql.DB
+	for key, pool := range cm.pools {
+		if key.instance == instance {
+			dbs = append(dbs, pool.db)
+		}
+	}
+	cm.mu.Unlock()
+	for _, db := range dbs {
+		db.SetMaxIdleConns(0)
+		db.SetMaxIdleConns(min(maxIdleConnectionsPerPool, cm.maxConnections))
+	}
+}
+
+// instanceKey godoc
+// Pools are grouped by the instance ID, connections built without one (e.g. connection tests) use the address instead
+func instanceKey(connectionData dto.ConnectionInputDTO) string {
+	if connectionData.ID != "" {
+		return connectionData.ID
+	}
+	return net.JoinHostPort(connectionData.Host, connectionData.Port)
+}
+
+// limitedConnector godoc
+// Wraps the driver connector to take a slot of the instance for each open connection, waiting for a free slot when the
+// limit of the instance is reached
+type limitedConnector struct {
+	driver.Connector
+	instance string
+	slots    chan struct{}
+	reclaim  func()
+}
+
+func (lc *limitedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	select {
+	case lc.slots <- struct{}{}:
+	default:
+		lc.reclaim()
+		select {
+		case lc.slots <- struct{}{}:
+		default:
+			log.Printf("Limit of %d connections reached on instance %s, waiting for a free connection", cap(lc.slots), lc.instance)
+			select {
+			case lc.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+	conn, err := lc.Connector.Connect(ctx)
+	if err != nil {
+		<-lc.slots
+		return nil, err
+	}
+	return &limitedConn{Conn: conn, slots: lc.slots}, nil
+}
+
+// limitedConn godoc
+// Gives back the slot of the instance when the connection is closed. The optional interfaces of the driver connection
+// are forwarded, so database/sql keeps using the context aware and session reset implementations of the driver.
+type limitedConn struct {
+	driver.Conn
+	slots     chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *limitedConn) Close() error {
+	err := c.Conn.Close()
+	c.closeOnce.Do(func() { <-c.slots })
+	return err
+}
+
+func (c *limitedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *limitedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // drivers without ConnBeginTx
+}
+
+func (c *limitedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *limitedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *limitedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *limitedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *limitedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *limitedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
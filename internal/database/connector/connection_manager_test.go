@@ -0,0 +1,180 @@
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+// fakeDriverConnector counts the connections opened and still open, standing in for the connectors of lib/pq and
+// go-sql-driver/mysql
+type fakeDriverConnector struct {
+	mu     sync.Mutex
+	opened int
+	open   int
+}
+
+type fakeDriverConn struct {
+	connector *fakeDriverConnector
+}
+
+func (c *fakeDriverConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opened++
+	c.open++
+	return &fakeDriverConn{connector: c}, nil
+}
+
+func (c *fakeDriverConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c *fakeDriverConnector) stats() (int, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened, c.open
+}
+
+func (c *fakeDriverConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeDriverConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeDriverConn) Close() error {
+	c.connector.mu.Lock()
+	defer c.connector.mu.Unlock()
+	c.connector.open--
+	return nil
+}
+
+func acquireFake(t *testing.T, cm *connectionManager, connectionData dto.ConnectionInputDTO, database string, fake *fakeDriverConnector) (*sql.DB, func()) {
+	db, release, err := cm.acquire(connectionData, database, connectionData.User+":"+connectionData.Password, func() (driver.Connector, error) {
+		return fake, nil
+	})
+	assert.NoError(t, err)
+	return db, release
+}
+
+func TestGivenSameInstanceAndDatabase_WhenAcquire_ThenShouldReuseThePool(t *testing.T) {
+	cm := newConnectionManager(5, time.Minute)
+	fake := &fakeDriverConnector{}
+	connectionData := dto.ConnectionInputDTO{ID: "instance-1", User: "admin", Password: "pwd"}
+
+	db, release := acquireFake(t, cm, connectionData, "orders", fake)
+	assert.NoError(t, db.Ping())
+	release()
+	sameDB, release := acquireFake(t, cm, connectionData, "orders", fake)
+	assert.NoError(t, sameDB.Ping())
+	release()
+	otherDB, release := acquireFake(t, cm, connectionData, "billing", fake)
+	release()
+
+	assert.Same(t, db, sameDB)
+	assert.NotSame(t, db, otherDB)
+	opened, _ := fake.stats()
+	assert.Equal(t, 1, opened, "the idle connection should be reused by the second operation")
+}
+
+func TestGivenCredentialsChanged_WhenAcquire_ThenShouldReplaceThePoolAfterItsOperationsFinish(t *testing.T) {
+	cm := newConnectionManager(5, time.Minute)
+	fake := &fakeDriverConnector{}
+	connectionData := dto.ConnectionInputDTO{ID: "instance-1", User: "admin", Password: "pwd"}
+	oldDB, releaseOld := acquireFake(t, cm, connectionData, "orders", fake)
+
+	connectionData.Password = "rotated"
+	newDB, releaseNew := acquireFake(t, cm, connectionData, "orders", fake)
+	defer releaseNew()
+
+	assert.NotSame(t, oldDB, newDB)
+	assert.NoError(t, oldDB.Ping(), "the replaced pool should stay open while in use")
+	releaseOld()
+	assert.Error(t, oldDB.Ping())
+	assert.NoError(t, newDB.Ping())
+}
+
+func TestGivenLimitReached_WhenConnectToAnotherDatabase_ThenShouldWaitAndReclaimIdleConnections(t *testing.T) {
+	cm := newConnectionManager(2, time.Minute)
+	fake := &fakeDriverConnector{}
+	connectionData := dto.ConnectionInputDTO{ID: "instance-1"}
+	ordersDB, releaseOrders := acquireFake(t, cm, connectionData, "orders", fake)
+	defer releaseOrders()
+	billingDB, releaseBilling := acquireFake(t, cm, connectionData, "billing", fake)
+	defer releaseBilling()
+	otherInstanceDB, releaseOther := acquireFake(t, cm, dto.ConnectionInputDTO{ID: "instance-2"}, "orders", fake)
+	defer releaseOther()
+
+	ordersConn, err := ordersDB.Conn(context.Background())
+	assert.NoError(t, err)
+	billingConn, err := billingDB.Conn(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, otherInstanceDB.Ping(), "the limit should be applied per instance")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = billingDB.Conn(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "a third connection to the instance should wait for a free slot")
+
+	// Back to the idle connections of the orders pool, still holding a slot of the instance
+	assert.NoError(t, ordersConn.Close())
+	extraConn, err := billingDB.Conn(context.Background())
+	assert.NoError(t, err, "the idle connection of the other database should be closed to free its slot")
+	_, open := fake.stats()
+	assert.Equal(t, 3, open)
+	assert.NoError(t, extraConn.Close())
+	assert.NoError(t, billingConn.Close())
+}
+
+func TestGivenIdlePools_WhenCloseIdlePools_ThenShouldCloseOnlyPoolsNotInUseSinceTheTimeout(t *testing.T) {
+	cm := newConnectionManager(5, time.Minute)
+	fake := &fakeDriverConnector{}
+	connectionData := dto.ConnectionInputDTO{ID: "instance-1"}
+	idleDB, release := acquireFake(t, cm, connectionData, "orders", fake)
+	assert.NoError(t, idleDB.Ping())
+	release()
+	busyDB, releaseBusy := acquireFake(t, cm, connectionData, "billing", fake)
+	defer releaseBusy()
+
+	cm.closeIdlePools(time.Now())
+	assert.NoError(t, idleDB.Ping(), "the pool should be kept before the idle timeout")
+
+	cm.closeIdlePools(time.Now().Add(2 * time.Minute))
+	assert.Error(t, idleDB.Ping())
+	assert.NoError(t, busyDB.Ping())
+	assert.Len(t, cm.pools, 1)
+}
+
+func TestGivenPoolsOfSeveralInstances_WhenInvalidate_ThenShouldCloseOnlyThePoolsOfTheInstance(t *testing.T) {
+	cm := newConnectionManager(5, time.Minute)
+	fake := &fakeDriverConnector{}
+	db, release := acquireFake(t, cm, dto.ConnectionInputDTO{ID: "instance-1"}, "orders", fake)
+	otherDB, releaseOther := acquireFake(t, cm, dto.ConnectionInputDTO{ID: "instance-2"}, "orders", fake)
+	releaseOther()
+
+	cm.invalidate(func(key poolKey) bool { return key.instance == "instance-1" })
+
+	assert.NoError(t, db.Ping(), "the pool in use should be closed only when released")
+	release()
+	release()
+	assert.Error(t, db.Ping())
+	assert.NoError(t, otherDB.Ping())
+	newDB, release := acquireFake(t, cm, dto.ConnectionInputDTO{ID: "instance-1"}, "orders", fake)
+	defer release()
+	assert.NotSame(t, db, newDB)
+}
+
+func TestGivenConnectionWithoutID_WhenInstanceKey_ThenShouldUseTheAddress(t *testing.T) {
+	assert.Equal(t, "instance-1", instanceKey(dto.ConnectionInputDTO{ID: "instance-1", Host: "db.internal", Port: "5432"}))
+	assert.Equal(t, "db.internal:5432", instanceKey(dto.ConnectionInputDTO{Host: "db.internal", Port: "5432"}))
+}
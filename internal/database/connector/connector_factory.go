@@ -8,6 +8,7 @@ import (
 
 	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 )
 
 const (
@@ -16,6 +17,7 @@ const (
 
 var (
 	ErrEmptyPasswordAfterDecrypt = errors.New("unexpected empty password for instance after decrypt")
+	ErrScopeNotSupported         = errors.New("the database technology doesn't support access scoped to schemas or tables")
 )
 
 type DatabaseTCPConnectorInterface interface {
@@ -25,12 +27,21 @@ type DatabaseTCPConnectorInterface interface {
 	Database() string
 	DefaultDatabase() string
 	ListDatabases() ([]*Database, error)
+	ListLogins() ([]*Login, error)
+	FindUserAccess(username string) (*UserAccess, error)
 	CreateRoles([]*DatabaseRole) error
-	SetupGrantsToRoles() error
+	SetupGrantsToRoles([]*DatabaseRole) error
 	UserExists(string) (bool, error)
 	CreateUser(*DatabaseUser) error
 	RevokeUserPrivilegesAndRemove(string) error
 	GrantConnect(string) error
+	GrantConnectWithRole(username, role string) error
+	GrantConnectWithScope(username string, role *DatabaseRole, scope entity.AccessScope) error
+	RevokeScope(username string) error
+	RevokeConnect(username string) error
+	GrantRole(username, role string) error
+	RevokeRole(username, role string) error
+	ChangeUserRole(username, oldRole, newRole string, databases []string) error
 }
 
 func NewDatabaseConnector(instanceData *dto.DatabaseInstanceOutputDTO, databaseName string) (DatabaseTCPConnectorInterface, error) {
@@ -45,9 +56,28 @@ func NewDatabaseConnector(instanceData *dto.DatabaseInstanceOutputDTO, databaseN
 		return nil, ErrEmptyPasswordAfterDecrypt
 	}
 	connectionData := buildConnectionData(instanceData, databaseName, plainTextPasswd)
+	if err := decryptSecrets(instanceData, &connectionData); err != nil {
+		log.Printf("Error decrypting certificates and bastion credentials for database instance %s - %s. Cause: %v", instanceData.ID, instanceData.Name, err)
+		return nil, err
+	}
+	tunnel, err := openSSHTunnel(connectionData)
+	if err != nil {
+		log.Printf("Error opening SSH tunnel for database instance %s - %s. Cause: %v", instanceData.ID, instanceData.Name, err)
+		return nil, err
+	}
 	switch {
 	case strings.Contains(technologyName, postgres):
-		return newPostgresConnector(connectionData), nil
+		return newPostgresConnector(connectionData, tunnel), nil
+	case strings.Contains(technologyName, mysqlTechnology):
+		return newMySQLConnector(connectionData, false, tunnel), nil
+	case strings.Contains(technologyName, mariadbTechnology):
+		return newMySQLConnector(connectionData, true, tunnel), nil
+	case strings.Contains(technologyName, elasticsearchTechnology):
+		return newElasticsearchConnector(connectionData, false, tunnel)
+	case strings.Contains(technologyName, opensearchTechnology):
+		return newElasticsearchConnector(connectionData, true, tunnel)
+	case strings.Contains(technologyName, mongodbTechnology):
+		return newMongoDBConnector(connectionData, tunnel), nil
 	case strings.Contains(technologyName, DummyTest):
 		return newDummyTestConnector(connectionData), nil
 	default:
@@ -55,16 +85,60 @@ func NewDatabaseConnector(instanceData *dto.DatabaseInstanceOutputDTO, databaseN
 	}
 }
 
+// NewDryRunDatabaseConnector godoc
+// Same as NewDatabaseConnector, but the statements that would change the instance are recorded instead of executed.
+// The queries are still executed, so the instance must be reachable.
+func NewDryRunDatabaseConnector(instanceData *dto.DatabaseInstanceOutputDTO, databaseName string, recorder *StatementRecorder) (DatabaseTCPConnectorInterface, error) {
+	dbConnector, err := NewDatabaseConnector(instanceData, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	dryRunDBConnector, ok := dbConnector.(dryRunConnector)
+	if !ok {
+		return nil, fmt.Errorf("the connector of the database technology '%s' doesn't support dry run", instanceData.DatabaseTechnologyName)
+	}
+	dryRunDBConnector.setRecorder(recorder)
+	return dbConnector, nil
+}
+
 func buildConnectionData(instanceData *dto.DatabaseInstanceOutputDTO, databaseName string, plainTextPasswd string) dto.ConnectionInputDTO {
 	return dto.ConnectionInputDTO{
-		ID:         instanceData.ID,
-		Host:       instanceData.HostConnection,
-		Port:       instanceData.PortConnection,
-		User:       instanceData.AdminUser,
-		Password:   plainTextPasswd,
-		Database:   databaseName,
-		Instance:   instanceData.Name,
-		Ecosystem:  instanceData.EcosystemName,
-		Technology: fmt.Sprintf("%s %s", instanceData.DatabaseTechnologyName, instanceData.DatabaseTechnologyVersion),
+		ID:             instanceData.ID,
+		Host:           instanceData.HostConnection,
+		Port:           instanceData.PortConnection,
+		User:           instanceData.AdminUser,
+		Password:       plainTextPasswd,
+		Database:       databaseName,
+		Instance:       instanceData.Name,
+		Ecosystem:      instanceData.EcosystemName,
+		Technology:     fmt.Sprintf("%s %s", instanceData.DatabaseTechnologyName, instanceData.DatabaseTechnologyVersion),
+		SSLMode:        instanceData.SSLMode,
+		BastionHost:    instanceData.BastionHost,
+		BastionPort:    instanceData.BastionPort,
+		BastionUser:    instanceData.BastionUser,
+		BastionHostKey: instanceData.BastionHostKey,
+	}
+}
+
+func decryptSecrets(instanceData *dto.DatabaseInstanceOutputDTO, connectionData *dto.ConnectionInputDTO) error {
+	for _, field := range []struct {
+		cipherHex string
+		target    *string
+	}{
+		{instanceData.CACertificate, &connectionData.CACertificate},
+		{instanceData.ClientCertificate, &connectionData.ClientCertificate},
+		{instanceData.ClientKey, &connectionData.ClientKey},
+		{instanceData.BastionPrivateKey, &connectionData.BastionPrivateKey},
+		{instanceData.BastionPassword, &connectionData.BastionPassword},
+	} {
+		if field.cipherHex == "" {
+			continue
+		}
+		plainText, err := config.GetCryptoHelper().Decrypt(field.cipherHex)
+		if err != nil {
+			return err
+		}
+		*field.target = plainText
 	}
+	return nil
 }
@@ -8,7 +8,18 @@ type Database struct {
 }
 
 type DatabaseRole struct {
-	Name entity.RoleName
+	Name       entity.RoleName
+	Privileges entity.RolePrivileges
+}
+
+// NewDatabaseRoles godoc
+// Builds the roles to be created and granted by the connectors from the roles managed by Data Guard
+func NewDatabaseRoles(roles []*entity.DatabaseRole) []*DatabaseRole {
+	databaseRoles := make([]*DatabaseRole, 0, len(roles))
+	for _, role := range roles {
+		databaseRoles = append(databaseRoles, &DatabaseRole{Name: role.Name, Privileges: role.Privileges})
+	}
+	return databaseRoles
 }
 
 type DatabaseUser struct {
@@ -16,3 +27,25 @@ type DatabaseUser struct {
 	Password string
 	Role     string
 }
+
+// Login godoc
+// An account of the instance able to log in, found by the role discovery. CreateRole tells if it can create or manage
+// other accounts and UnmanagedRoles are the roles granted to it that weren't created by Data Guard.
+type Login struct {
+	Name           string
+	Superuser      bool
+	CreateRole     bool
+	BypassRLS      bool
+	UnmanagedRoles []string
+}
+
+// UserAccess godoc
+// The access a user has in the instance, read from the instance itself by the reconciliation of the access permissions.
+// RolesByDatabase has the databases the user can connect to, with the Data Guard roles it has in each one. The roles
+// are nil when the technology doesn't keep the roles of the users by database, like MySQL, which copies the privileges
+// of the roles to the users. PublicDatabases are the databases any login can connect to, so a user connecting to them
+// needs no grant.
+type UserAccess struct {
+	RolesByDatabase map[string][]string
+	PublicDatabases []string
+}
@@ -0,0 +1,87 @@
+package connector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+// scopeRolePrefix is the prefix of the roles that hold the privileges of a scoped access, see postgresScopeRoleName
+const scopeRolePrefix = "dg_scope_"
+
+// formatSize godoc
+// Formats a size in bytes the same way PostgreSQL pg_size_pretty does (e.g. 512 bytes, 8192 kB, 25 MB)
+func formatSize(sizeInBytes int64) string {
+	const threshold = 10 * 1024
+	units := []string{"bytes", "kB", "MB", "GB", "TB"}
+	size := sizeInBytes
+	unit := 0
+	for unit < len(units)-1 && size >= threshold {
+		size = (size + 512) / 1024
+		unit++
+	}
+	return fmt.Sprintf("%d %s", size, units[unit])
+}
+
+// hashKey godoc
+// Builds a short deterministic name from the values, used to register driver settings (TLS configs, dialers) that
+// can only be referenced by name
+func hashKey(prefix string, values ...string) string {
+	hash := sha256.New()
+	for _, value := range values {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return prefix + hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// databaseRoleName godoc
+// Returns the name of the role scoped to a database of a Data Guard role (e.g. developer_orders). Names longer than
+// the limit of the technology are shortened with a hash of the database name.
+func databaseRoleName(role, databaseName string, maxSize int) string {
+	name := fmt.Sprintf("%s_%s", role, databaseName)
+	if len(name) <= maxSize {
+		return name
+	}
+	hash := sha256.Sum256([]byte(databaseName))
+	return fmt.Sprintf("%s_%s", role, hex.EncodeToString(hash[:])[:maxSize-len(role)-1])
+}
+
+// managedRoleNames godoc
+// Names of the roles Data Guard creates in an instance: the known roles and, when the technology copies them to each
+// database or index, their copies scoped to the databases of the instance (e.g. developer_orders). Roles that only
+// look like them, e.g. devops_admin, are not managed.
+type managedRoleNames map[string]bool
+
+// newManagedRoleNames godoc
+// Builds the names of the managed roles of an instance with its databases. The databaseRoleName is nil when the
+// technology has no roles scoped to a database.
+func newManagedRoleNames(databases []*Database, databaseRoleName func(role, databaseName string) string) managedRoleNames {
+	names := make(managedRoleNames)
+	for _, role := range entity.KnownRoleNames() {
+		names[string(role)] = true
+		if databaseRoleName == nil {
+			continue
+		}
+		for _, database := range databases {
+			names[databaseRoleName(string(role), database.Name)] = true
+		}
+	}
+	return names
+}
+
+// unmanagedRoles godoc
+// Returns the roles that weren't created by Data Guard, keeping their order. The other managed roles are the ones
+// created for the login itself, e.g. its scope roles (see postgresScopeRoleName).
+func (m managedRoleNames) unmanagedRoles(roles []string, otherManaged ...string) []string {
+	var unmanaged []string
+	for _, role := range roles {
+		if !m[role] && !slices.Contains(otherManaged, role) {
+			unmanaged = append(unmanaged, role)
+		}
+	}
+	return unmanaged
+}
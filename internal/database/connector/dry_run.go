@@ -0,0 +1,124 @@
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+// dryRunPassword replaces the password of the users created in dry run, so the plans never have it
+const dryRunPassword = "********"
+
+// StatementRecorder godoc
+// Collects the statements that the connectors would execute in dry run, grouped by the database of the connector.
+// The connectors of an instance share the same recorder, which also keeps the users created in dry run: they don't
+// exist in the instance when the following operations look up their role.
+type StatementRecorder struct {
+	mu           sync.Mutex
+	databases    []string
+	statements   map[string][]string
+	createdUsers map[string]string
+}
+
+// dryRunConnector godoc
+// Implemented by every connector, so NewDryRunDatabaseConnector can make it record its statements
+type dryRunConnector interface {
+	setRecorder(recorder *StatementRecorder)
+}
+
+// sqlExecutor godoc
+// The statements of the SQL connectors that change the instance are executed through it, see recordingExecutor
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// recordingExecutor godoc
+// Records the statements instead of executing them. The args are rendered into the statement by render, when given.
+type recordingExecutor struct {
+	recorder *StatementRecorder
+	database string
+	render   func(query string, args []any) string
+}
+
+// NewStatementRecorder godoc
+// Creates an empty recorder, to be shared by the connectors of an instance in dry run
+func NewStatementRecorder() *StatementRecorder {
+	return &StatementRecorder{statements: make(map[string][]string), createdUsers: make(map[string]string)}
+}
+
+// Plan godoc
+// Returns the statements recorded for each database, in the order the databases were first used
+func (r *StatementRecorder) Plan() []dto.DryRunDatabaseOutputDTO {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	plan := make([]dto.DryRunDatabaseOutputDTO, 0, len(r.databases))
+	for _, database := range r.databases {
+		plan = append(plan, dto.DryRunDatabaseOutputDTO{Database: database, Statements: r.statements[database]})
+	}
+	return plan
+}
+
+// Statements godoc
+// Returns the statements recorded for the database
+func (r *StatementRecorder) Statements(database string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statements[database]
+}
+
+func (r *StatementRecorder) record(database string, statements ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.statements[database]; !found {
+		r.databases = append(r.databases, database)
+	}
+	r.statements[database] = append(r.statements[database], statements...)
+}
+
+// createUser godoc
+// Keeps the role of the user created in dry run and returns a copy of it with the password hidden. A nil recorder
+// returns the user as is.
+func (r *StatementRecorder) createUser(user *DatabaseUser) *DatabaseUser {
+	if r == nil {
+		return user
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.createdUsers[user.Username] = user.Role
+	return &DatabaseUser{Username: user.Username, Password: dryRunPassword, Role: user.Role}
+}
+
+// createdUserRole godoc
+// Returns the role of the user when it was created in dry run
+func (r *StatementRecorder) createdUserRole(username string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	role, found := r.createdUsers[username]
+	return role, found
+}
+
+func (r *StatementRecorder) executor(database string, render func(query string, args []any) string) sqlExecutor {
+	return recordingExecutor{recorder: r, database: database, render: render}
+}
+
+func (e recordingExecutor) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	if e.render != nil && len(args) > 0 {
+		query = e.render(query, args)
+	}
+	e.recorder.record(e.database, query)
+	return driver.RowsAffected(0), nil
+}
+
+var (
+	_ dryRunConnector = (*PostgresConnector)(nil)
+	_ dryRunConnector = (*MySQLConnector)(nil)
+	_ dryRunConnector = (*MongoDBConnector)(nil)
+	_ dryRunConnector = (*ElasticsearchConnector)(nil)
+	_ dryRunConnector = (*DummyTestConnector)(nil)
+)
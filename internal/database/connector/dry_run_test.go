@@ -0,0 +1,73 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+func TestGivenStatementsOfSomeDatabases_WhenBuildThePlan_ThenShouldGroupThemInTheOrderTheDatabasesWereFirstUsed(t *testing.T) {
+	recorder := NewStatementRecorder()
+
+	recorder.record("postgres", "CREATE ROLE a")
+	recorder.record("orders", "GRANT CONNECT ON orders TO a")
+	recorder.record("postgres", "CREATE ROLE b")
+
+	assert.Equal(t, []dto.DryRunDatabaseOutputDTO{
+		{Database: "postgres", Statements: []string{"CREATE ROLE a", "CREATE ROLE b"}},
+		{Database: "orders", Statements: []string{"GRANT CONNECT ON orders TO a"}},
+	}, recorder.Plan())
+	assert.Equal(t, []string{"GRANT CONNECT ON orders TO a"}, recorder.Statements("orders"))
+	assert.Empty(t, recorder.Statements("billing"))
+}
+
+func TestGivenAUserCreatedInDryRun_WhenCreateUser_ThenShouldHideThePasswordAndKeepItsRole(t *testing.T) {
+	recorder := NewStatementRecorder()
+	user := &DatabaseUser{Username: "johndoe", Password: "654321", Role: "developer"}
+
+	created := recorder.createUser(user)
+	role, found := recorder.createdUserRole("johndoe")
+	_, otherFound := recorder.createdUserRole("janedoe")
+
+	assert.Equal(t, dryRunPassword, created.Password)
+	assert.Equal(t, "654321", user.Password, "the user given should not be changed")
+	assert.True(t, found)
+	assert.Equal(t, "developer", role)
+	assert.False(t, otherFound)
+}
+
+func TestGivenANilRecorder_WhenCreateUser_ThenShouldReturnTheUserAsIs(t *testing.T) {
+	var recorder *StatementRecorder
+	user := &DatabaseUser{Username: "johndoe", Password: "654321", Role: "developer"}
+
+	created := recorder.createUser(user)
+	_, found := recorder.createdUserRole("johndoe")
+
+	assert.Same(t, user, created)
+	assert.False(t, found)
+}
+
+func TestGivenAPostgresStatementWithParams_WhenExecuteInDryRun_ThenShouldRecordItWithTheParamsQuoted(t *testing.T) {
+	recorder := NewStatementRecorder()
+	exec := recorder.executor("orders", renderPostgresStatement)
+	args := []any{"o'brien", 1, 2, 3, 4, 5, 6, 7, 8, 9, "tenth"}
+
+	_, err := exec.ExecContext(context.Background(), `SELECT set_config('zg_data_guard.username', $1, true), $10, $11`, args...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`SELECT set_config('zg_data_guard.username', 'o''brien', true), '9', 'tenth'`}, recorder.Statements("orders"))
+}
+
+func TestGivenADummyConnectorInDryRun_WhenGrantConnectWithRole_ThenShouldRecordTheStatements(t *testing.T) {
+	recorder := NewStatementRecorder()
+	dummy := newDummyTestConnector(dto.ConnectionInputDTO{Instance: DummyTest, Database: "orders"})
+	dummy.setRecorder(recorder)
+
+	err := dummy.GrantConnectWithRole("johndoe", "developer")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GRANT CONNECT ON orders TO johndoe", "GRANT developer TO johndoe"}, recorder.Statements(dummy.Database()))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 )
 
 const (
@@ -19,12 +20,16 @@ const (
 var (
 	ErrCreateUser      = errors.New("error creating user")
 	ErrGrantConnect    = errors.New("error granting connect")
+	ErrRevokeRole      = errors.New("error revoking role")
+	ErrRevokeConnect   = errors.New("error revoking connect")
+	ErrChangeUserRole  = errors.New("error changing user role")
 	ErrorRemoveUser    = errors.New("error revoking permissions and removing user")
 	ErrorCreatingRoles = errors.New("error creating roles")
 )
 
 type DummyTestConnector struct {
 	ConnectionData dto.ConnectionInputDTO
+	recorder       *StatementRecorder
 }
 
 func newDummyTestConnector(connectionData dto.ConnectionInputDTO) *DummyTestConnector {
@@ -59,6 +64,34 @@ func (d *DummyTestConnector) ListDatabases() ([]*Database, error) {
 	return databases, nil
 }
 
+// ListLogins godoc
+// Returns a login of each class of the role discovery, johndoe being the only one created by Data Guard
+func (d *DummyTestConnector) ListLogins() ([]*Login, error) {
+	if d.ConnectionData.Instance == InstanceDummyTestError {
+		return nil, fmt.Errorf("error listing logins from %s", d.ConnectionData.Instance)
+	}
+	logins := []*Login{
+		{Name: "johndoe"},
+		{Name: "foobar", UnmanagedRoles: []string{"reporting_admin"}},
+		{Name: "legacy_app"},
+		{Name: "etl", CreateRole: true, BypassRLS: true},
+		{Name: "postgres", Superuser: true},
+	}
+	return logins, nil
+}
+
+// FindUserAccess godoc
+// Returns the access to dummy-db-1 and dummy-db-2 for every user, except DummyTestUser, which doesn't exist
+func (d *DummyTestConnector) FindUserAccess(username string) (*UserAccess, error) {
+	if d.ConnectionData.Instance == InstanceDummyTestError {
+		return nil, fmt.Errorf("error reading the access of %s from %s", username, d.ConnectionData.Instance)
+	}
+	if username == DummyTestUser {
+		return nil, nil
+	}
+	return &UserAccess{RolesByDatabase: map[string][]string{"dummy-db-1": nil, "dummy-db-2": nil}}, nil
+}
+
 func (d *DummyTestConnector) Driver() string {
 	return "dummy"
 }
@@ -85,17 +118,23 @@ func (d *DummyTestConnector) DefaultDatabase() string {
 	return "dummy-test-db"
 }
 
-func (d *DummyTestConnector) CreateRoles(_ []*DatabaseRole) error {
+func (d *DummyTestConnector) CreateRoles(roles []*DatabaseRole) error {
 	if d.ConnectionData.Instance == InstanceDummyTestError {
 		return fmt.Errorf("%w: Instance(%s)", ErrorCreatingRoles, d.ConnectionData.Instance)
 	}
+	for _, role := range roles {
+		d.record("CREATE ROLE " + string(role.Name))
+	}
 	return nil
 }
 
-func (d *DummyTestConnector) SetupGrantsToRoles() error {
+func (d *DummyTestConnector) SetupGrantsToRoles(roles []*DatabaseRole) error {
 	if d.ConnectionData.Instance == InstanceDummyTestError {
 		return fmt.Errorf("%w: Instance(%s)", ErrGrantConnect, d.ConnectionData.Instance)
 	}
+	for _, role := range roles {
+		d.record(fmt.Sprintf("GRANT PRIVILEGES ON %s TO %s", d.Database(), role.Name))
+	}
 	return nil
 }
 
@@ -113,6 +152,8 @@ func (d *DummyTestConnector) CreateUser(user *DatabaseUser) error {
 	if d.ConnectionData.Instance == InstanceDummyTestError || user.Username == DummyTestUserErrorOnCreate {
 		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrCreateUser, d.ConnectionData.Instance, user.Username)
 	}
+	user = d.recorder.createUser(user)
+	d.record(fmt.Sprintf("CREATE USER %s PASSWORD %s IN ROLE %s", user.Username, user.Password, user.Role))
 	return nil
 }
 
@@ -120,6 +161,52 @@ func (d *DummyTestConnector) GrantConnect(username string) error {
 	if d.ConnectionData.Instance == InstanceDummyTestError || username == DummyTestUserErrorOnGrant {
 		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrGrantConnect, d.ConnectionData.Instance, username)
 	}
+	d.record(fmt.Sprintf("GRANT CONNECT ON %s TO %s", d.Database(), username))
+	return nil
+}
+
+func (d *DummyTestConnector) GrantConnectWithRole(username, role string) error {
+	if err := d.GrantConnect(username); err != nil {
+		return err
+	}
+	d.record(fmt.Sprintf("GRANT %s TO %s", role, username))
+	return nil
+}
+
+func (d *DummyTestConnector) GrantConnectWithScope(username string, role *DatabaseRole, _ entity.AccessScope) error {
+	return d.GrantConnectWithRole(username, string(role.Name))
+}
+
+func (d *DummyTestConnector) RevokeScope(username string) error {
+	d.record("DROP SCOPE OF " + username)
+	return nil
+}
+
+func (d *DummyTestConnector) RevokeConnect(username string) error {
+	if d.ConnectionData.Instance == InstanceDummyTestError || username == DummyTestUserErrorOnRemove {
+		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrRevokeConnect, d.ConnectionData.Instance, username)
+	}
+	d.record(fmt.Sprintf("REVOKE CONNECT ON %s FROM %s", d.Database(), username))
+	return nil
+}
+
+func (d *DummyTestConnector) GrantRole(username, role string) error {
+	return d.GrantConnectWithRole(username, role)
+}
+
+func (d *DummyTestConnector) RevokeRole(username, role string) error {
+	if d.ConnectionData.Instance == InstanceDummyTestError || username == DummyTestUserErrorOnRemove {
+		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrRevokeRole, d.ConnectionData.Instance, username)
+	}
+	d.record(fmt.Sprintf("REVOKE %s FROM %s", role, username))
+	return nil
+}
+
+func (d *DummyTestConnector) ChangeUserRole(username, oldRole, newRole string, _ []string) error {
+	if d.ConnectionData.Instance == InstanceDummyTestError {
+		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrChangeUserRole, d.ConnectionData.Instance, username)
+	}
+	d.record(fmt.Sprintf("GRANT %s TO %s", newRole, username), fmt.Sprintf("REVOKE %s FROM %s", oldRole, username))
 	return nil
 }
 
@@ -127,5 +214,18 @@ func (d *DummyTestConnector) RevokeUserPrivilegesAndRemove(username string) erro
 	if d.ConnectionData.Instance == InstanceDummyTestError || username == DummyTestUserErrorOnRemove {
 		return fmt.Errorf("%w: Instance(%s) - User(%s)", ErrorRemoveUser, d.ConnectionData.Instance, username)
 	}
+	d.record("DROP USER " + username)
 	return nil
 }
+
+func (d *DummyTestConnector) setRecorder(recorder *StatementRecorder) {
+	d.recorder = recorder
+}
+
+// record godoc
+// Records the statements in dry run, as the connectors of the real technologies do
+func (d *DummyTestConnector) record(statements ...string) {
+	if d.recorder != nil {
+		d.recorder.record(d.Database(), statements...)
+	}
+}
@@ -0,0 +1,668 @@
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	elasticsearchTechnology  = "elasticsearch"
+	opensearchTechnology     = "opensearch"
+	elasticsearchAllIndices  = "_all"
+	elasticsearchRoleMetaKey = "zg_data_guard_role"
+	// elasticsearchSuperuserRole and opensearchSuperuserRole are the built-in roles with every privilege of the cluster
+	elasticsearchSuperuserRole = "superuser"
+	opensearchSuperuserRole    = "all_access"
+	// opensearchSecurityManagerRole is the built-in role able to manage users and roles
+	opensearchSecurityManagerRole = "security_manager"
+)
+
+var (
+	ErrWhileExecutingRequestElasticsearch = errors.New("error occurred while attempting to execute the request on the target Elasticsearch instance")
+	ErrUserWithoutRoleElasticsearch       = errors.New("the user is not a member of any Data Guard role")
+
+	httpTransportsMu sync.Mutex
+	// httpTransports godoc
+	// Transport of each instance, see sharedHTTPTransport
+	httpTransports = make(map[string]*httpTransport)
+)
+
+// ElasticsearchConnector godoc
+// HTTP connector for the Elasticsearch security API (_security) and the OpenSearch security plugin (_plugins/_security).
+// Indices play the role of databases. The Data Guard roles are created without privileges and only record the role of
+// a user, while the index privileges live in roles scoped to each index (e.g. developer_orders).
+// Granting access to an index adds its scoped role to the user.
+type ElasticsearchConnector struct {
+	ConnectionData dto.ConnectionInputDTO
+	openSearch     bool
+	client         *http.Client
+	recorder       *StatementRecorder
+}
+
+type elasticsearchIndex struct {
+	Index     string `json:"index"`
+	StoreSize string `json:"store.size"`
+}
+
+type elasticsearchUser struct {
+	Roles    []string       `json:"roles"`
+	FullName string         `json:"full_name,omitempty"`
+	Email    string         `json:"email,omitempty"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+	Enabled  bool           `json:"enabled"`
+	Password string         `json:"password,omitempty"`
+}
+
+type opensearchUser struct {
+	Roles      []string          `json:"opendistro_security_roles"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Password   string            `json:"password,omitempty"`
+}
+
+func newElasticsearchConnector(connectionData dto.ConnectionInputDTO, openSearch bool, tunnel *sshTunnel) (*ElasticsearchConnector, error) {
+	tlsConfig, err := buildTLSConfig(connectionData)
+	if err != nil {
+		return nil, err
+	}
+	transport := sharedHTTPTransport(connectionData, func() *http.Transport {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		if tunnel != nil {
+			transport.DialContext = tunnel.DialContext
+		}
+		return transport
+	})
+	return &ElasticsearchConnector{
+		ConnectionData: connectionData,
+		openSearch:     openSearch,
+		client:         &http.Client{Timeout: connectionTimeout, Transport: transport},
+	}, nil
+}
+
+type httpTransport struct {
+	transport   *http.Transport
+	fingerprint string
+}
+
+// sharedHTTPTransport godoc
+// Returns the transport of the instance, built with newTransport on first use, so the connectors of an instance reuse
+// its keep-alive connections, like the pools of the SQL connectors, instead of leaving them open in a transport per
+// connector. The transport is replaced when the TLS or bastion settings of the instance change, and closed by
+// InvalidateConnections.
+func sharedHTTPTransport(connectionData dto.ConnectionInputDTO, newTransport func() *http.Transport) *http.Transport {
+	instance := instanceKey(connectionData)
+	fingerprint := hashKey("", tlsConfigKey(connectionData), sshTunnelKey(connectionData))
+
+	httpTransportsMu.Lock()
+	defer httpTransportsMu.Unlock()
+	shared, exists := httpTransports[instance]
+	if exists && shared.fingerprint == fingerprint {
+		return shared.transport
+	}
+	if exists {
+		log.Printf("Connection settings of instance %s changed, replacing its HTTP transport", instance)
+		shared.transport.CloseIdleConnections()
+	}
+	shared = &httpTransport{transport: newTransport(), fingerprint: fingerprint}
+	httpTransports[instance] = shared
+	return shared.transport
+}
+
+// closeHTTPTransports godoc
+// Closes the idle connections of the transports of the instances matched and removes them, so the next connectors
+// build new ones. Requests still running keep their connections until they finish.
+func closeHTTPTransports(match func(instance string) bool) {
+	httpTransportsMu.Lock()
+	defer httpTransportsMu.Unlock()
+	for instance, shared := range httpTransports {
+		if match(instance) {
+			shared.transport.CloseIdleConnections()
+			delete(httpTransports, instance)
+		}
+	}
+}
+
+func (ec *ElasticsearchConnector) TestConnection() error {
+	path := "/_security/_authenticate"
+	if ec.openSearch {
+		path = "/_plugins/_security/authinfo"
+	}
+	_, err := ec.request(http.MethodGet, path, nil, nil)
+	return err
+}
+
+func (ec *ElasticsearchConnector) ListDatabases() ([]*Database, error) {
+	var indices []elasticsearchIndex
+	if _, err := ec.request(http.MethodGet, "/_cat/indices?format=json&bytes=b&h=index,store.size", nil, &indices); err != nil {
+		return nil, err
+	}
+	var databases []*Database
+	for _, index := range indices {
+		// Hidden and system indices start with a dot
+		if strings.HasPrefix(index.Index, ".") {
+			continue
+		}
+		sizeInBytes, _ := strconv.ParseInt(index.StoreSize, 10, 64)
+		databases = append(databases, &Database{Name: index.Index, CurrentSize: formatSize(sizeInBytes)})
+	}
+	return databases, nil
+}
+
+// CreateRoles godoc
+// Create Data Guard roles in the cluster. They have no index privileges, see SetupGrantsToRoles.
+func (ec *ElasticsearchConnector) CreateRoles(roles []*DatabaseRole) error {
+	for _, role := range roles {
+		if err := ec.putRole(string(role.Name), nil, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupGrantsToRoles godoc
+// Creates the roles scoped to the current index with the index privileges of each Data Guard role, see
+// buildElasticsearchIndexPrivileges
+func (ec *ElasticsearchConnector) SetupGrantsToRoles(roles []*DatabaseRole) error {
+	for _, role := range roles {
+		indexRole := elasticsearchIndexRoleName(string(role.Name), ec.Database())
+		if err := ec.putRole(indexRole, []string{ec.Database()}, buildElasticsearchIndexPrivileges(role.Privileges, ec.openSearch)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ec *ElasticsearchConnector) UserExists(username string) (bool, error) {
+	status, err := ec.request(http.MethodGet, ec.userPath(username), nil, nil, http.StatusNotFound)
+	if err != nil {
+		return false, err
+	}
+	return status != http.StatusNotFound, nil
+}
+
+// ListLogins godoc
+// Lists the native users (Elasticsearch) or internal users (OpenSearch) with their roles, except the disabled ones and
+// the admin user of the instance. There is no row level security to bypass, so BypassRLS is never set.
+func (ec *ElasticsearchConnector) ListLogins() ([]*Login, error) {
+	indices, err := ec.ListDatabases()
+	if err != nil {
+		return nil, err
+	}
+	managedRoles := newManagedRoleNames(indices, elasticsearchIndexRoleName)
+	rolesByUser := make(map[string][]string)
+	if ec.openSearch {
+		var users map[string]opensearchUser
+		if _, err := ec.request(http.MethodGet, ec.usersPath(), nil, &users); err != nil {
+			return nil, err
+		}
+		for username, user := range users {
+			rolesByUser[username] = user.Roles
+		}
+	} else {
+		var users map[string]elasticsearchUser
+		if _, err := ec.request(http.MethodGet, ec.usersPath(), nil, &users); err != nil {
+			return nil, err
+		}
+		for username, user := range users {
+			if user.Enabled {
+				rolesByUser[username] = user.Roles
+			}
+		}
+	}
+
+	superuserRole := elasticsearchSuperuserRole
+	if ec.openSearch {
+		superuserRole = opensearchSuperuserRole
+	}
+	logins := make([]*Login, 0, len(rolesByUser))
+	for username, roles := range rolesByUser {
+		if username == ec.ConnectionData.User {
+			continue
+		}
+		logins = append(logins, &Login{
+			Name:           username,
+			Superuser:      slices.Contains(roles, superuserRole),
+			CreateRole:     ec.openSearch && slices.Contains(roles, opensearchSecurityManagerRole),
+			UnmanagedRoles: managedRoles.unmanagedRoles(roles),
+		})
+	}
+	slices.SortFunc(logins, func(a, b *Login) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return logins, nil
+}
+
+// FindUserAccess godoc
+// Reads the indexes where the user has a role scoped to the index (see elasticsearchIndexRoleName), with the Data Guard
+// roles they belong to. Returns nil when the user doesn't exist.
+func (ec *ElasticsearchConnector) FindUserAccess(username string) (*UserAccess, error) {
+	exists, err := ec.UserExists(username)
+	if err != nil || !exists {
+		return nil, err
+	}
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return nil, err
+	}
+	return &UserAccess{RolesByDatabase: elasticsearchRolesByIndex(roles)}, nil
+}
+
+func (ec *ElasticsearchConnector) CreateUser(user *DatabaseUser) error {
+	user = ec.recorder.createUser(user)
+	var body any = elasticsearchUser{
+		Roles:    []string{user.Role},
+		Metadata: map[string]any{elasticsearchRoleMetaKey: user.Role},
+		Enabled:  true,
+		Password: user.Password,
+	}
+	if ec.openSearch {
+		body = opensearchUser{
+			Roles:      []string{user.Role},
+			Attributes: map[string]string{elasticsearchRoleMetaKey: user.Role},
+			Password:   user.Password,
+		}
+	}
+	_, err := ec.request(http.MethodPut, ec.userPath(user.Username), body, nil)
+	return err
+}
+
+// GrantConnect godoc
+// Adds the role scoped to the current index that matches the Data Guard role of the user
+func (ec *ElasticsearchConnector) GrantConnect(username string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	roleIdx := slices.IndexFunc(roles, entity.ValidateRoleName)
+	if roleIdx < 0 {
+		return ErrUserWithoutRoleElasticsearch
+	}
+	indexRole := elasticsearchIndexRoleName(roles[roleIdx], ec.Database())
+	if slices.Contains(roles, indexRole) {
+		return nil
+	}
+	return ec.updateUserRoles(username, append(roles, indexRole))
+}
+
+// GrantConnectWithRole godoc
+// Same as GrantConnect, adding the role scoped to the current index of the given Data Guard role instead of the one of
+// the user's own role, and removing the roles of the other Data Guard roles scoped to the index
+func (ec *ElasticsearchConnector) GrantConnectWithRole(username, role string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	indexRole := elasticsearchIndexRoleName(role, ec.Database())
+	newRoles := slices.DeleteFunc(slices.Clone(roles), func(r string) bool {
+		return r != indexRole && ec.isIndexRole(r)
+	})
+	if !slices.Contains(newRoles, indexRole) {
+		newRoles = append(newRoles, indexRole)
+	}
+	if slices.Equal(roles, newRoles) {
+		return nil
+	}
+	return ec.updateUserRoles(username, newRoles)
+}
+
+// GrantConnectWithScope godoc
+// Indices have no schemas nor tables, so the access can't be restricted inside an index
+func (ec *ElasticsearchConnector) GrantConnectWithScope(_ string, _ *DatabaseRole, _ entity.AccessScope) error {
+	return ErrScopeNotSupported
+}
+
+// RevokeScope godoc
+// No scope is ever granted, see GrantConnectWithScope
+func (ec *ElasticsearchConnector) RevokeScope(_ string) error {
+	return nil
+}
+
+// RevokeConnect godoc
+// Removes the roles scoped to the current index from the user, keeping the user and its roles of the other indexes
+func (ec *ElasticsearchConnector) RevokeConnect(username string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	remainingRoles := slices.DeleteFunc(slices.Clone(roles), ec.isIndexRole)
+	if len(remainingRoles) == len(roles) {
+		return nil
+	}
+	return ec.updateUserRoles(username, remainingRoles)
+}
+
+// GrantRole godoc
+// Adds the role scoped to the current index of the Data Guard role, in addition to the ones of the user's own role
+func (ec *ElasticsearchConnector) GrantRole(username, role string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	indexRole := elasticsearchIndexRoleName(role, ec.Database())
+	if slices.Contains(roles, indexRole) {
+		return nil
+	}
+	return ec.updateUserRoles(username, append(roles, indexRole))
+}
+
+// RevokeRole godoc
+// Removes the role scoped to the current index of the Data Guard role, unless it's the one of the user's own role
+func (ec *ElasticsearchConnector) RevokeRole(username, role string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(roles, role) {
+		return nil
+	}
+	indexRole := elasticsearchIndexRoleName(role, ec.Database())
+	remainingRoles := slices.DeleteFunc(slices.Clone(roles), func(r string) bool { return r == indexRole })
+	if len(remainingRoles) == len(roles) {
+		return nil
+	}
+	return ec.updateUserRoles(username, remainingRoles)
+}
+
+// ChangeUserRole godoc
+// Replaces the old Data Guard role of the user by the new one, and the roles of the old role scoped to the given
+// indexes by the ones of the new role. The role kept in the metadata of the user is also updated.
+func (ec *ElasticsearchConnector) ChangeUserRole(username, oldRole, newRole string, indexes []string) error {
+	roles, err := ec.findUserRoles(username)
+	if err != nil {
+		return err
+	}
+	replacedRoles := map[string]string{oldRole: newRole}
+	for _, index := range indexes {
+		replacedRoles[elasticsearchIndexRoleName(oldRole, index)] = elasticsearchIndexRoleName(newRole, index)
+	}
+	newRoles := make([]string, 0, len(roles)+len(indexes)+1)
+	for _, role := range append(roles, newRole) {
+		if replacement, ok := replacedRoles[role]; ok {
+			role = replacement
+		}
+		if !slices.Contains(newRoles, role) {
+			newRoles = append(newRoles, role)
+		}
+	}
+	for _, index := range indexes {
+		if indexRole := elasticsearchIndexRoleName(newRole, index); !slices.Contains(newRoles, indexRole) {
+			newRoles = append(newRoles, indexRole)
+		}
+	}
+	return ec.updateUserOwnRole(username, newRoles, newRole)
+}
+
+// RevokeUserPrivilegesAndRemove godoc
+// Removes the user from the cluster, which also removes all the roles assigned to it
+func (ec *ElasticsearchConnector) RevokeUserPrivilegesAndRemove(username string) error {
+	_, err := ec.request(http.MethodDelete, ec.userPath(username), nil, nil, http.StatusNotFound)
+	if err != nil || ec.recorder != nil {
+		return err
+	}
+	userStillExists, err := ec.UserExists(username)
+	if err != nil {
+		return err
+	}
+	if userStillExists {
+		return fmt.Errorf("user '%s' still exists after removal attempt", username)
+	}
+	return nil
+}
+
+func (ec *ElasticsearchConnector) Driver() string {
+	if ec.openSearch {
+		return opensearchTechnology
+	}
+	return elasticsearchTechnology
+}
+
+func (ec *ElasticsearchConnector) DefaultDatabase() string {
+	return elasticsearchAllIndices
+}
+
+func (ec *ElasticsearchConnector) Database() string {
+	databaseName := ec.ConnectionData.Database
+	if databaseName == "" {
+		databaseName = ec.DefaultDatabase()
+	}
+	return databaseName
+}
+
+func (ec *ElasticsearchConnector) URL() string {
+	scheme := "http"
+	if tlsEnabled(ec.ConnectionData) {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(ec.ConnectionData.Host, ec.ConnectionData.Port))
+}
+
+func (ec *ElasticsearchConnector) putRole(name string, indices, privileges []string) error {
+	var body map[string]any
+	switch {
+	case ec.openSearch && len(indices) > 0:
+		body = map[string]any{"index_permissions": []map[string]any{{"index_patterns": indices, "allowed_actions": privileges}}}
+	case ec.openSearch:
+		body = map[string]any{}
+	case len(indices) > 0:
+		body = map[string]any{"indices": []map[string]any{{"names": indices, "privileges": privileges}}}
+	default:
+		body = map[string]any{"cluster": []string{}, "indices": []any{}}
+	}
+	_, err := ec.request(http.MethodPut, ec.rolePath(name), body, nil)
+	return err
+}
+
+func (ec *ElasticsearchConnector) findUserRoles(username string) ([]string, error) {
+	if role, created := ec.recorder.createdUserRole(username); created {
+		return []string{role}, nil
+	}
+	if ec.openSearch {
+		var users map[string]opensearchUser
+		if _, err := ec.request(http.MethodGet, ec.userPath(username), nil, &users); err != nil {
+			return nil, err
+		}
+		return users[username].Roles, nil
+	}
+	var users map[string]elasticsearchUser
+	if _, err := ec.request(http.MethodGet, ec.userPath(username), nil, &users); err != nil {
+		return nil, err
+	}
+	return users[username].Roles, nil
+}
+
+func (ec *ElasticsearchConnector) updateUserRoles(username string, roles []string) error {
+	if ec.openSearch {
+		patch := []map[string]any{{"op": "replace", "path": "/opendistro_security_roles", "value": roles}}
+		_, err := ec.request(http.MethodPatch, ec.userPath(username), patch, nil)
+		return err
+	}
+	user, err := ec.findElasticsearchUser(username)
+	if err != nil {
+		return err
+	}
+	// Updating a user without a password keeps the current one
+	user.Roles = roles
+	_, err = ec.request(http.MethodPut, ec.userPath(username), user, nil)
+	return err
+}
+
+// updateUserOwnRole godoc
+// Same as updateUserRoles, also updating the Data Guard role kept in the metadata of the user
+func (ec *ElasticsearchConnector) updateUserOwnRole(username string, roles []string, ownRole string) error {
+	if ec.openSearch {
+		patch := []map[string]any{
+			{"op": "replace", "path": "/opendistro_security_roles", "value": roles},
+			{"op": "add", "path": "/attributes/" + elasticsearchRoleMetaKey, "value": ownRole},
+		}
+		_, err := ec.request(http.MethodPatch, ec.userPath(username), patch, nil)
+		return err
+	}
+	user, err := ec.findElasticsearchUser(username)
+	if err != nil {
+		return err
+	}
+	user.Roles = roles
+	if user.Metadata == nil {
+		user.Metadata = map[string]any{}
+	}
+	user.Metadata[elasticsearchRoleMetaKey] = ownRole
+	_, err = ec.request(http.MethodPut, ec.userPath(username), user, nil)
+	return err
+}
+
+// findElasticsearchUser godoc
+// Returns the user of Elasticsearch to be updated, also finding the users created in dry run with their role
+func (ec *ElasticsearchConnector) findElasticsearchUser(username string) (elasticsearchUser, error) {
+	if role, created := ec.recorder.createdUserRole(username); created {
+		return elasticsearchUser{Roles: []string{role}, Metadata: map[string]any{elasticsearchRoleMetaKey: role}, Enabled: true}, nil
+	}
+	var users map[string]elasticsearchUser
+	if _, err := ec.request(http.MethodGet, ec.userPath(username), nil, &users); err != nil {
+		return elasticsearchUser{}, err
+	}
+	return users[username], nil
+}
+
+func (ec *ElasticsearchConnector) isIndexRole(name string) bool {
+	for _, role := range entity.KnownRoleNames() {
+		if name == elasticsearchIndexRoleName(string(role), ec.Database()) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildElasticsearchIndexPrivileges godoc
+// Maps the privileges of a role to index privileges: SELECT on tables to read, writing on tables to write and CREATE on
+// schemas to manage. Elasticsearch also needs view_index_metadata to read the mappings, which OpenSearch includes in
+// the read action group.
+func buildElasticsearchIndexPrivileges(privileges entity.RolePrivileges, openSearch bool) []string {
+	indexPrivileges := make([]string, 0)
+	if privileges.HasAny("table", "SELECT") {
+		indexPrivileges = append(indexPrivileges, "read")
+	}
+	if privileges.HasAny("table", "INSERT", "UPDATE", "DELETE", "TRUNCATE") {
+		indexPrivileges = append(indexPrivileges, "write")
+	}
+	if !openSearch && len(indexPrivileges) > 0 {
+		indexPrivileges = append(indexPrivileges, "view_index_metadata")
+	}
+	if privileges.HasAny("schema", "CREATE") {
+		indexPrivileges = append(indexPrivileges, "manage")
+	}
+	return indexPrivileges
+}
+
+func (ec *ElasticsearchConnector) userPath(username string) string {
+	if ec.openSearch {
+		return "/_plugins/_security/api/internalusers/" + url.PathEscape(username)
+	}
+	return "/_security/user/" + url.PathEscape(username)
+}
+
+func (ec *ElasticsearchConnector) usersPath() string {
+	if ec.openSearch {
+		return "/_plugins/_security/api/internalusers/"
+	}
+	return "/_security/user"
+}
+
+func (ec *ElasticsearchConnector) rolePath(name string) string {
+	if ec.openSearch {
+		return "/_plugins/_security/api/roles/" + url.PathEscape(name)
+	}
+	return "/_security/role/" + url.PathEscape(name)
+}
+
+func (ec *ElasticsearchConnector) setRecorder(recorder *StatementRecorder) {
+	ec.recorder = recorder
+}
+
+// request godoc
+// Executes a request against the cluster using the admin credentials and decodes the JSON response into out.
+// Status codes above 299 are returned as errors, except the ones listed in allowedStatus.
+// In dry run, only the GET requests are executed: the others are recorded as "METHOD path body".
+func (ec *ElasticsearchConnector) request(method, path string, body, out any, allowedStatus ...int) (int, error) {
+	var payload []byte
+	var reqBody io.Reader
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+	if ec.recorder != nil && method != http.MethodGet {
+		ec.recorder.record(ec.Database(), strings.TrimSpace(fmt.Sprintf("%s %s %s", method, path, payload)))
+		return http.StatusOK, nil
+	}
+	req, err := http.NewRequest(method, ec.URL()+path, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	req.SetBasicAuth(ec.ConnectionData.User, ec.ConnectionData.Password)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ec.client.Do(req)
+	if err != nil {
+		log.Printf("Error while executing request on %s. Cause: %v", ec.ConnectionData.Instance, err)
+		return 0, fmt.Errorf("%w! Index: %s. Cause: %w", ErrWhileExecutingRequestElasticsearch, ec.Database(), err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if slices.Contains(allowedStatus, resp.StatusCode) {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode > 299 {
+		log.Printf("Error while executing request %s %s on %s. Status: %d", method, path, ec.ConnectionData.Instance, resp.StatusCode)
+		return resp.StatusCode, fmt.Errorf("%w! Index: %s. Status: %d. Response: %s", ErrWhileExecutingRequestElasticsearch, ec.Database(), resp.StatusCode, respBody)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// elasticsearchRolesByIndex godoc
+// Returns the Data Guard roles of each index from the roles scoped to the indexes. The longest Data Guard role is
+// taken, since the name of a role may be the prefix of another one (e.g. user_ro and user_ro_admin).
+func elasticsearchRolesByIndex(roles []string) map[string][]string {
+	rolesByIndex := make(map[string][]string)
+	for _, role := range roles {
+		dataGuardRole := ""
+		for _, knownRole := range entity.KnownRoleNames() {
+			if strings.HasPrefix(role, string(knownRole)+"_") && len(knownRole) > len(dataGuardRole) {
+				dataGuardRole = string(knownRole)
+			}
+		}
+		if dataGuardRole != "" {
+			index := strings.TrimPrefix(role, dataGuardRole+"_")
+			rolesByIndex[index] = append(rolesByIndex[index], dataGuardRole)
+		}
+	}
+	return rolesByIndex
+}
+
+func elasticsearchIndexRoleName(role, index string) string {
+	return fmt.Sprintf("%s_%s", role, index)
+}
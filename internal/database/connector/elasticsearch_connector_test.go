@@ -0,0 +1,343 @@
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	esAdminUser     = "elastic"
+	esAdminPassword = "changeme"
+)
+
+// fakeSecurityAPI is an in-memory stand-in of the Elasticsearch and OpenSearch security APIs
+type fakeSecurityAPI struct {
+	mu        sync.Mutex
+	roles     map[string]map[string]any
+	users     map[string]map[string]any
+	passwords map[string]any
+	indices   []elasticsearchIndex
+}
+
+func newFakeSecurityAPI(t *testing.T) (*fakeSecurityAPI, *httptest.Server) {
+	return startFakeSecurityAPI(t, httptest.NewServer)
+}
+
+func startFakeSecurityAPI(t *testing.T, newServer func(http.Handler) *httptest.Server) (*fakeSecurityAPI, *httptest.Server) {
+	api := &fakeSecurityAPI{
+		roles:     map[string]map[string]any{},
+		users:     map[string]map[string]any{},
+		passwords: map[string]any{},
+		indices: []elasticsearchIndex{
+			{Index: "orders", StoreSize: "20480"},
+			{Index: ".security-7", StoreSize: "1024"},
+			{Index: "logs-2024", StoreSize: "512"},
+		},
+	}
+	server := newServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func (api *fakeSecurityAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if user, passwd, ok := r.BasicAuth(); !ok || user != esAdminUser || passwd != esAdminPassword {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/_plugins/_security/api/internalusers/")
+	path = strings.TrimPrefix(path, "/_plugins/_security/api/roles/")
+	var body any
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	switch {
+	case r.URL.Path == "/_security/_authenticate" || r.URL.Path == "/_plugins/_security/authinfo":
+		writeJSON(w, http.StatusOK, map[string]any{"username": esAdminUser})
+	case r.URL.Path == "/_cat/indices":
+		writeJSON(w, http.StatusOK, api.indices)
+	case strings.HasPrefix(r.URL.Path, "/_security/role/") || strings.HasPrefix(r.URL.Path, "/_plugins/_security/api/roles/"):
+		name := strings.TrimPrefix(path, "/_security/role/")
+		api.roles[name] = body.(map[string]any)
+		writeJSON(w, http.StatusOK, map[string]any{"role": map[string]any{"created": true}})
+	case strings.HasPrefix(r.URL.Path, "/_security/user/") || strings.HasPrefix(r.URL.Path, "/_plugins/_security/api/internalusers/"):
+		api.serveUser(w, r, strings.TrimPrefix(path, "/_security/user/"), body)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (api *fakeSecurityAPI) serveUser(w http.ResponseWriter, r *http.Request, username string, body any) {
+	user, exists := api.users[username]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			writeJSON(w, http.StatusNotFound, map[string]any{})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{username: user})
+	case http.MethodPut:
+		newUser := body.(map[string]any)
+		if password, hasPassword := newUser["password"]; hasPassword {
+			api.passwords[username] = password
+			delete(newUser, "password")
+		} else if !exists {
+			writeJSON(w, http.StatusBadRequest, map[string]any{"error": "password required"})
+			return
+		}
+		api.users[username] = newUser
+		writeJSON(w, http.StatusOK, map[string]any{"created": !exists})
+	case http.MethodPatch:
+		for _, op := range body.([]any) {
+			patch := op.(map[string]any)
+			user[strings.TrimPrefix(patch["path"].(string), "/")] = patch["value"]
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"status": "OK"})
+	case http.MethodDelete:
+		if !exists {
+			writeJSON(w, http.StatusNotFound, map[string]any{"found": false})
+			return
+		}
+		delete(api.users, username)
+		writeJSON(w, http.StatusOK, map[string]any{"found": true})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func buildElasticsearchConnector(t *testing.T, server *httptest.Server, index string, openSearch bool) *ElasticsearchConnector {
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	ec, err := newElasticsearchConnector(dto.ConnectionInputDTO{
+		Host:     serverURL.Hostname(),
+		Port:     serverURL.Port(),
+		User:     esAdminUser,
+		Password: esAdminPassword,
+		Database: index,
+		Instance: "elasticsearch-test",
+	}, openSearch, nil)
+	assert.NoError(t, err)
+	return ec
+}
+
+// buildDataGuardRoles godoc
+// The predefined roles with the privileges given to them by the migrations
+func buildDataGuardRoles() []*DatabaseRole {
+	readOnly := entity.RolePrivileges{
+		Schema:   []string{"USAGE"},
+		Table:    []string{"SELECT"},
+		Sequence: []string{"SELECT"},
+		Function: []string{"EXECUTE"},
+		Default:  entity.DefaultPrivileges{Table: []string{"SELECT"}, Sequence: []string{"SELECT"}, Function: []string{"EXECUTE"}, Type: []string{"USAGE"}},
+	}
+	developer := entity.RolePrivileges{
+		Schema:   []string{"USAGE"},
+		Table:    []string{"SELECT", "INSERT", "UPDATE", "DELETE"},
+		Sequence: []string{"USAGE", "SELECT"},
+		Function: []string{"EXECUTE"},
+		Default: entity.DefaultPrivileges{Table: []string{"SELECT", "INSERT", "UPDATE", "DELETE"}, Sequence: []string{"USAGE", "SELECT"},
+			Function: []string{"EXECUTE"}, Type: []string{"USAGE"}},
+	}
+	allTablePrivileges := []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+	devOps := entity.RolePrivileges{
+		Schema:   []string{"USAGE", "CREATE"},
+		Table:    allTablePrivileges,
+		Sequence: []string{"USAGE", "SELECT", "UPDATE"},
+		Function: []string{"EXECUTE"},
+		Default: entity.DefaultPrivileges{Table: allTablePrivileges, Sequence: []string{"USAGE", "SELECT", "UPDATE"},
+			Function: []string{"EXECUTE"}, Type: []string{"USAGE"}},
+	}
+	application := devOps
+	application.Database = []string{"CREATE", "TEMP"}
+	return []*DatabaseRole{
+		{Name: entity.UserRO, Privileges: readOnly},
+		{Name: entity.Developer, Privileges: developer},
+		{Name: entity.DevOps, Privileges: devOps},
+		{Name: entity.Application, Privileges: application},
+	}
+}
+
+func TestGivenValidCredentials_WhenTestConnectionElasticsearch_ThenShouldSucceed(t *testing.T) {
+	_, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "", false)
+
+	assert.NoError(t, ec.TestConnection())
+	assert.Equal(t, elasticsearchAllIndices, ec.Database())
+}
+
+func TestGivenInvalidCredentials_WhenTestConnectionElasticsearch_ThenShouldReturnError(t *testing.T) {
+	_, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "", false)
+	ec.ConnectionData.Password = "wrong"
+
+	err := ec.TestConnection()
+
+	assert.ErrorIs(t, err, ErrWhileExecutingRequestElasticsearch)
+	assert.Contains(t, err.Error(), "Status: 401")
+}
+
+func TestGivenConnectorsOfTheSameInstance_WhenNewElasticsearchConnector_ThenShouldShareTheTransportUntilItsSettingsChange(t *testing.T) {
+	_, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "", false)
+	otherIndex := buildElasticsearchConnector(t, server, "orders", false)
+	assert.Same(t, ec.client.Transport, otherIndex.client.Transport)
+
+	connectionData := ec.ConnectionData
+	connectionData.SSLMode = string(entity.SSLModeRequire)
+	withTLS, err := newElasticsearchConnector(connectionData, false, nil)
+	assert.NoError(t, err)
+	assert.NotSame(t, ec.client.Transport, withTLS.client.Transport, "the transport should be replaced when the TLS settings change")
+
+	InvalidateConnections(instanceKey(connectionData))
+	afterInvalidate, err := newElasticsearchConnector(connectionData, false, nil)
+	assert.NoError(t, err)
+	assert.NotSame(t, withTLS.client.Transport, afterInvalidate.client.Transport, "the transport should be closed by the invalidation")
+}
+
+func TestGivenIndices_WhenListDatabasesElasticsearch_ThenShouldIgnoreSystemIndices(t *testing.T) {
+	_, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "", false)
+
+	databases, err := ec.ListDatabases()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Database{{Name: "orders", CurrentSize: "20 kB"}, {Name: "logs-2024", CurrentSize: "512 bytes"}}, databases)
+}
+
+func TestGivenDataGuardRoles_WhenCreateRolesAndSetupGrantsElasticsearch_ThenShouldCreateIndexScopedRoles(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "orders", false)
+
+	assert.NoError(t, ec.CreateRoles(buildDataGuardRoles()))
+	assert.NoError(t, ec.SetupGrantsToRoles(buildDataGuardRoles()))
+
+	assert.Len(t, api.roles, 8)
+	assert.Equal(t, []any{}, api.roles["developer"]["indices"])
+	indices := api.roles["developer_orders"]["indices"].([]any)
+	assert.Len(t, indices, 1)
+	assert.Equal(t, []any{"orders"}, indices[0].(map[string]any)["names"])
+	assert.Equal(t, []any{"read", "write", "view_index_metadata"}, indices[0].(map[string]any)["privileges"])
+}
+
+func TestGivenNewUser_WhenCreateUserAndGrantConnectElasticsearch_ThenShouldAddIndexScopedRole(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "orders", false)
+
+	exists, err := ec.UserExists("john.doe")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.NoError(t, ec.CreateUser(&DatabaseUser{Username: "john.doe", Password: "s3cr3t", Role: "user_ro"}))
+	assert.NoError(t, ec.GrantConnect("john.doe"))
+	assert.NoError(t, ec.GrantConnect("john.doe"), "granting twice should be idempotent")
+
+	exists, err = ec.UserExists("john.doe")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []any{"user_ro", "user_ro_orders"}, api.users["john.doe"]["roles"])
+	assert.Equal(t, map[string]any{elasticsearchRoleMetaKey: "user_ro"}, api.users["john.doe"]["metadata"])
+	assert.Equal(t, "s3cr3t", api.passwords["john.doe"], "password should be kept when updating roles")
+}
+
+func TestGivenUserWithoutDataGuardRole_WhenGrantConnectElasticsearch_ThenShouldReturnError(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["kibana"] = map[string]any{"roles": []any{"kibana_admin"}}
+	ec := buildElasticsearchConnector(t, server, "orders", false)
+
+	assert.ErrorIs(t, ec.GrantConnect("kibana"), ErrUserWithoutRoleElasticsearch)
+}
+
+func TestGivenUserWithAccess_WhenGrantAndRevokeRoleElasticsearch_ThenShouldOnlyChangeTheIndexScopedRoleOfTheRole(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["john.doe"] = map[string]any{"roles": []any{"developer", "developer_orders"}}
+	ec := buildElasticsearchConnector(t, server, "orders", false)
+
+	assert.NoError(t, ec.GrantRole("john.doe", "devops"))
+	assert.NoError(t, ec.GrantRole("john.doe", "devops"), "granting twice should be idempotent")
+	assert.Equal(t, []any{"developer", "developer_orders", "devops_orders"}, api.users["john.doe"]["roles"])
+
+	assert.NoError(t, ec.RevokeRole("john.doe", "devops"))
+	assert.Equal(t, []any{"developer", "developer_orders"}, api.users["john.doe"]["roles"])
+
+	assert.NoError(t, ec.RevokeRole("john.doe", "developer"), "the index scoped role of the user's own role should be kept")
+	assert.Equal(t, []any{"developer", "developer_orders"}, api.users["john.doe"]["roles"])
+}
+
+func TestGivenUserWithAccess_WhenGrantConnectWithRoleElasticsearch_ThenShouldReplaceTheIndexScopedRoleOfTheIndex(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["john.doe"] = map[string]any{"roles": []any{"developer", "developer_orders", "developer_logs-2024"}}
+	ec := buildElasticsearchConnector(t, server, "orders", false)
+
+	assert.NoError(t, ec.GrantConnectWithRole("john.doe", "user_ro"))
+	assert.NoError(t, ec.GrantConnectWithRole("john.doe", "user_ro"), "granting twice should be idempotent")
+	assert.Equal(t, []any{"developer", "developer_logs-2024", "user_ro_orders"}, api.users["john.doe"]["roles"])
+}
+
+func TestGivenUserWithAccess_WhenFindUserAccessElasticsearch_ThenShouldReturnTheRolesOfEachIndex(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["john.doe"] = map[string]any{"roles": []any{"developer", "developer_orders", "user_ro_logs-2024", "kibana_admin"}}
+	ec := buildElasticsearchConnector(t, server, "", false)
+
+	access, err := ec.FindUserAccess("john.doe")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"orders": {"developer"}, "logs-2024": {"user_ro"}}, access.RolesByDatabase)
+
+	access, err = ec.FindUserAccess("mary.jane")
+	assert.NoError(t, err)
+	assert.Nil(t, access, "a user that doesn't exist should have no access")
+}
+
+func TestGivenUserWithAccess_WhenChangeUserRoleElasticsearch_ThenShouldReplaceTheRolesOfTheOldRoleOnly(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["john.doe"] = map[string]any{
+		"roles":    []any{"developer", "developer_orders", "user_ro_logs-2024"},
+		"metadata": map[string]any{elasticsearchRoleMetaKey: "developer"},
+	}
+	ec := buildElasticsearchConnector(t, server, "", false)
+
+	assert.NoError(t, ec.ChangeUserRole("john.doe", "developer", "devops", []string{"orders"}))
+	assert.NoError(t, ec.ChangeUserRole("john.doe", "developer", "devops", []string{"orders"}), "changing twice should be idempotent")
+	assert.Equal(t, []any{"devops", "devops_orders", "user_ro_logs-2024"}, api.users["john.doe"]["roles"])
+	assert.Equal(t, "devops", api.users["john.doe"]["metadata"].(map[string]any)[elasticsearchRoleMetaKey])
+}
+
+func TestGivenExistingUser_WhenRevokeUserPrivilegesAndRemoveElasticsearch_ThenShouldDeleteUser(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	api.users["john.doe"] = map[string]any{"roles": []any{"developer", "developer_orders"}}
+	ec := buildElasticsearchConnector(t, server, "", false)
+
+	assert.NoError(t, ec.RevokeUserPrivilegesAndRemove("john.doe"))
+	assert.NotContains(t, api.users, "john.doe")
+	assert.NoError(t, ec.RevokeUserPrivilegesAndRemove("john.doe"), "removing a missing user should not fail")
+}
+
+func TestGivenOpenSearchCluster_WhenCreateUserAndGrantConnect_ThenShouldUseSecurityPluginAPI(t *testing.T) {
+	api, server := newFakeSecurityAPI(t)
+	ec := buildElasticsearchConnector(t, server, "orders", true)
+
+	assert.NoError(t, ec.TestConnection())
+	assert.NoError(t, ec.SetupGrantsToRoles(buildDataGuardRoles()))
+	assert.NoError(t, ec.CreateUser(&DatabaseUser{Username: "john.doe", Password: "s3cr3t", Role: "devops"}))
+	assert.NoError(t, ec.GrantConnect("john.doe"))
+
+	permissions := api.roles["devops_orders"]["index_permissions"].([]any)
+	assert.Equal(t, []any{"read", "write", "manage"}, permissions[0].(map[string]any)["allowed_actions"])
+	assert.Equal(t, []any{"devops", "devops_orders"}, api.users["john.doe"]["opendistro_security_roles"])
+	assert.Equal(t, opensearchTechnology, ec.Driver())
+}
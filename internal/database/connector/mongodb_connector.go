@@ -0,0 +1,631 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	mongodbTechnology           = "mongo"
+	mongodbAdminDatabase        = "admin"
+	mongodbErrRoleAlreadyExists = 51002
+	mongodbErrUserNotFound      = 11
+)
+
+var (
+	ErrWhileExecutingCommandMongoDB = errors.New("error occurred while attempting to execute the command on the target MongoDB instance")
+	ErrUserWithoutRoleMongoDB       = errors.New("the user is not a member of any Data Guard role")
+	mongodbSystemDatabases          = []string{"admin", "local", "config"}
+	// mongodbSuperuserRoles godoc
+	// Built-in roles with every privilege of the cluster
+	mongodbSuperuserRoles = []string{"root", "__system"}
+	// mongodbUserAdminRoles godoc
+	// Built-in roles able to create users and roles and grant any role, including to themselves
+	mongodbUserAdminRoles = []string{"userAdminAnyDatabase", "userAdmin", "dbOwner"}
+)
+
+// MongoDBConnector godoc
+// Connector for MongoDB replica sets. Users and the Data Guard roles are created in the admin database, where the
+// roles have no privileges and only record the role of a user. Each database gets its own custom roles with the same
+// names inheriting the built-in roles (read, readWrite, dbAdmin), and granting access to a database adds the role of
+// that database to the user.
+type MongoDBConnector struct {
+	ConnectionData dto.ConnectionInputDTO
+	tunnel         *sshTunnel
+	recorder       *StatementRecorder
+}
+
+type mongodbRoleRef struct {
+	Role string `bson:"role"`
+	DB   string `bson:"db"`
+}
+
+type mongodbUsersInfo struct {
+	Users []mongodbUserInfo `bson:"users"`
+}
+
+type mongodbUserInfo struct {
+	User  string           `bson:"user"`
+	DB    string           `bson:"db"`
+	Roles []mongodbRoleRef `bson:"roles"`
+}
+
+func newMongoDBConnector(connectionData dto.ConnectionInputDTO, tunnel *sshTunnel) *MongoDBConnector {
+	return &MongoDBConnector{ConnectionData: connectionData, tunnel: tunnel}
+}
+
+func (mc *MongoDBConnector) TestConnection() error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		return client.Ping(ctx, nil)
+	})
+}
+
+func (mc *MongoDBConnector) ListDatabases() ([]*Database, error) {
+	var databases []*Database
+	err := mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		result, err := client.ListDatabases(ctx, bson.D{})
+		if err != nil {
+			return err
+		}
+		for _, database := range result.Databases {
+			if slices.Contains(mongodbSystemDatabases, database.Name) {
+				continue
+			}
+			databases = append(databases, &Database{Name: database.Name, CurrentSize: formatSize(database.SizeOnDisk)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return databases, nil
+}
+
+// CreateRoles godoc
+// Create Data Guard roles in the admin database. They have no privileges, see SetupGrantsToRoles.
+func (mc *MongoDBConnector) CreateRoles(roles []*DatabaseRole) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		admin := client.Database(mongodbAdminDatabase)
+		for _, role := range roles {
+			err := mc.runCommand(ctx, admin, buildMongoDBCreateRoleCommand(string(role.Name), bson.A{}))
+			if err != nil && !hasMongoDBErrorCode(err, mongodbErrRoleAlreadyExists) {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetupGrantsToRoles godoc
+// Creates or updates the Data Guard roles of the current database, inheriting the built-in roles equivalent to their
+// privileges, see buildMongoDBInheritedRoles
+func (mc *MongoDBConnector) SetupGrantsToRoles(roles []*DatabaseRole) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		database := client.Database(mc.Database())
+		for _, role := range roles {
+			inheritedRoles := buildMongoDBInheritedRoles(role.Privileges, mc.Database())
+			updateRoleCommand := buildMongoDBUpdateRoleCommand(string(role.Name), inheritedRoles)
+			// In dry run the creation doesn't fail when the role exists, so the update is recorded in its place
+			if mc.recorder != nil {
+				exists, err := mongoDBRoleExists(ctx, database, string(role.Name))
+				if err != nil {
+					return err
+				}
+				if exists {
+					mc.recordCommand(database, updateRoleCommand)
+					continue
+				}
+			}
+			err := mc.runCommand(ctx, database, buildMongoDBCreateRoleCommand(string(role.Name), inheritedRoles))
+			if hasMongoDBErrorCode(err, mongodbErrRoleAlreadyExists) {
+				err = mc.runCommand(ctx, database, updateRoleCommand)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (mc *MongoDBConnector) UserExists(username string) (bool, error) {
+	var exists bool
+	err := mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		exists = len(usersInfo.Users) > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// ListLogins godoc
+// Lists the users of all databases with their roles, except the admin user of the instance. The users created outside
+// the admin database, where Data Guard creates its users, are named user@db. MongoDB has no row level security, so
+// BypassRLS is never set.
+func (mc *MongoDBConnector) ListLogins() ([]*Login, error) {
+	var logins []*Login
+	err := mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		var usersInfo mongodbUsersInfo
+		err := client.Database(mongodbAdminDatabase).RunCommand(ctx, bson.D{
+			{Key: "usersInfo", Value: bson.D{{Key: "forAllDBs", Value: true}}},
+		}).Decode(&usersInfo)
+		if err != nil {
+			return err
+		}
+		for _, user := range usersInfo.Users {
+			if user.User == mc.ConnectionData.User && user.DB == mongodbAdminDatabase {
+				continue
+			}
+			logins = append(logins, buildMongoDBLogin(user.User, user.DB, user.Roles))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logins, nil
+}
+
+// FindUserAccess godoc
+// Reads the databases where the user has a Data Guard role, granted by GrantConnect. The roles of the admin database
+// only record the role of the user, so they give no access. Returns nil when the user doesn't exist.
+func (mc *MongoDBConnector) FindUserAccess(username string) (*UserAccess, error) {
+	var access *UserAccess
+	err := mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil || len(usersInfo.Users) == 0 {
+			return err
+		}
+		access = &UserAccess{RolesByDatabase: make(map[string][]string)}
+		for _, user := range usersInfo.Users {
+			for _, roleRef := range user.Roles {
+				if roleRef.DB != mongodbAdminDatabase && entity.ValidateRoleName(roleRef.Role) {
+					access.RolesByDatabase[roleRef.DB] = append(access.RolesByDatabase[roleRef.DB], roleRef.Role)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return access, nil
+}
+
+func (mc *MongoDBConnector) CreateUser(user *DatabaseUser) error {
+	user = mc.recorder.createUser(user)
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		return mc.runCommand(ctx, client.Database(mongodbAdminDatabase), buildMongoDBCreateUserCommand(user))
+	})
+}
+
+// GrantConnect godoc
+// Grants to the user the Data Guard role of the current database that matches its role in the admin database
+func (mc *MongoDBConnector) GrantConnect(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		command, err := buildMongoDBGrantConnectCommand(usersInfo, username, mc.Database())
+		if err != nil {
+			return err
+		}
+		return mc.runCommand(ctx, client.Database(mongodbAdminDatabase), command)
+	})
+}
+
+// GrantConnectWithRole godoc
+// Same as GrantConnect, granting the given Data Guard role of the current database instead of the one matching the
+// user's own role, and revoking the other Data Guard roles the user has in the database
+func (mc *MongoDBConnector) GrantConnectWithRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		admin := client.Database(mongodbAdminDatabase)
+		for _, command := range buildMongoDBGrantConnectWithRoleCommands(usersInfo, username, role, mc.Database()) {
+			if err = mc.runCommand(ctx, admin, command); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GrantConnectWithScope godoc
+// MongoDB roles are granted by database, so the access can't be restricted to some collections
+func (mc *MongoDBConnector) GrantConnectWithScope(_ string, _ *DatabaseRole, _ entity.AccessScope) error {
+	return ErrScopeNotSupported
+}
+
+// RevokeScope godoc
+// No scope is ever granted, see GrantConnectWithScope
+func (mc *MongoDBConnector) RevokeScope(_ string) error {
+	return nil
+}
+
+// RevokeConnect godoc
+// Revokes from the user the Data Guard roles of the current database, keeping the user and its roles of the other
+// databases
+func (mc *MongoDBConnector) RevokeConnect(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		command := buildMongoDBRevokeConnectCommand(usersInfo, username, mc.Database())
+		if command == nil {
+			return nil
+		}
+		return mc.runCommand(ctx, client.Database(mongodbAdminDatabase), command)
+	})
+}
+
+// GrantRole godoc
+// Grants to the user the Data Guard role of the current database, in addition to the one of its own role
+func (mc *MongoDBConnector) GrantRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		command := buildMongoDBGrantRolesCommand(username, bson.A{mongodbRoleRef{Role: role, DB: mc.Database()}})
+		return mc.runCommand(ctx, client.Database(mongodbAdminDatabase), command)
+	})
+}
+
+// RevokeRole godoc
+// Revokes from the user the Data Guard role of the current database, unless it matches its own role
+func (mc *MongoDBConnector) RevokeRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		command := buildMongoDBRevokeRoleCommand(usersInfo, username, role, mc.Database())
+		if command == nil {
+			return nil
+		}
+		return mc.runCommand(ctx, client.Database(mongodbAdminDatabase), command)
+	})
+}
+
+// ChangeUserRole godoc
+// Replaces the old Data Guard role of the user by the new one in the admin database and in the given databases, where
+// the role matching the user's own role was granted by GrantConnect
+func (mc *MongoDBConnector) ChangeUserRole(username, oldRole, newRole string, databases []string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		if len(usersInfo.Users) == 0 {
+			return ErrUserWithoutRoleMongoDB
+		}
+		admin := client.Database(mongodbAdminDatabase)
+		for _, command := range buildMongoDBChangeUserRoleCommands(usersInfo.Users[0], oldRole, newRole, databases) {
+			if err = mc.runCommand(ctx, admin, command); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RevokeUserPrivilegesAndRemove godoc
+// Drops the user from the admin database, which also removes all the roles granted to it
+func (mc *MongoDBConnector) RevokeUserPrivilegesAndRemove(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, client *mongo.Client) error {
+		err := mc.runCommand(ctx, client.Database(mongodbAdminDatabase), buildMongoDBDropUserCommand(username))
+		if err != nil && !hasMongoDBErrorCode(err, mongodbErrUserNotFound) {
+			return err
+		}
+		if mc.recorder != nil {
+			return nil
+		}
+		usersInfo, err := mc.findUser(ctx, client, username)
+		if err != nil {
+			return err
+		}
+		if len(usersInfo.Users) > 0 {
+			return fmt.Errorf("user '%s' still exists after removal attempt", username)
+		}
+		return nil
+	})
+}
+
+func (mc *MongoDBConnector) Driver() string {
+	return "mongodb"
+}
+
+func (mc *MongoDBConnector) DefaultDatabase() string {
+	return mongodbAdminDatabase
+}
+
+func (mc *MongoDBConnector) Database() string {
+	databaseName := mc.ConnectionData.Database
+	if databaseName == "" {
+		databaseName = mc.DefaultDatabase()
+	}
+	return databaseName
+}
+
+func (mc *MongoDBConnector) URL() string {
+	return fmt.Sprintf("mongodb://%s:%s@%s/?authSource=%s",
+		url.QueryEscape(mc.ConnectionData.User),
+		url.QueryEscape(mc.ConnectionData.Password),
+		net.JoinHostPort(mc.ConnectionData.Host, mc.ConnectionData.Port),
+		mongodbAdminDatabase,
+	)
+}
+
+func (mc *MongoDBConnector) executeWithTimeout(ctx context.Context, operation func(context.Context, *mongo.Client) error) error {
+	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
+	defer cancel()
+
+	tlsConfig, err := buildTLSConfig(mc.ConnectionData)
+	if err != nil {
+		return err
+	}
+	clientOptions := options.Client().ApplyURI(mc.URL()).SetTimeout(connectionTimeout)
+	if tlsConfig != nil {
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+	if mc.tunnel != nil {
+		clientOptions.SetDialer(mc.tunnel)
+	}
+	client, err := mongo.Connect(clientOptions)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	if err := operation(ctx, client); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Printf("Connection failed! Cause: connection timed out after %s", connectionTimeout)
+			return fmt.Errorf("connection failed! Cause: connection timed out after %s", connectionTimeout)
+		}
+		log.Printf("Error while executing command on database %s. Cause: %v", mc.Database(), err)
+		return fmt.Errorf("%w! Database: %s. Cause: %w", ErrWhileExecutingCommandMongoDB, mc.Database(), err)
+	}
+	return nil
+}
+
+func (mc *MongoDBConnector) setRecorder(recorder *StatementRecorder) {
+	mc.recorder = recorder
+}
+
+// runCommand godoc
+// Runs a command that changes the instance, or records it in dry run
+func (mc *MongoDBConnector) runCommand(ctx context.Context, database *mongo.Database, command bson.D) error {
+	if mc.recorder != nil {
+		mc.recordCommand(database, command)
+		return nil
+	}
+	return database.RunCommand(ctx, command).Err()
+}
+
+// recordCommand godoc
+// Records the command as a mongosh statement run on its database, under the database of the connector
+func (mc *MongoDBConnector) recordCommand(database *mongo.Database, command bson.D) {
+	commandJSON, err := bson.MarshalExtJSON(command, false, false)
+	if err != nil {
+		commandJSON = []byte(fmt.Sprint(command))
+	}
+	mc.recorder.record(mc.Database(), fmt.Sprintf("db.getSiblingDB(%q).runCommand(%s)", database.Name(), commandJSON))
+}
+
+// findUser godoc
+// Same as findMongoDBUser, also finding the users created in dry run with their role
+func (mc *MongoDBConnector) findUser(ctx context.Context, client *mongo.Client, username string) (*mongodbUsersInfo, error) {
+	role, created := mc.recorder.createdUserRole(username)
+	if !created {
+		return findMongoDBUser(ctx, client, username)
+	}
+	user := mongodbUserInfo{User: username, DB: mongodbAdminDatabase, Roles: []mongodbRoleRef{{Role: role, DB: mongodbAdminDatabase}}}
+	return &mongodbUsersInfo{Users: []mongodbUserInfo{user}}, nil
+}
+
+func mongoDBRoleExists(ctx context.Context, database *mongo.Database, role string) (bool, error) {
+	var rolesInfo struct {
+		Roles []bson.Raw `bson:"roles"`
+	}
+	if err := database.RunCommand(ctx, bson.D{{Key: "rolesInfo", Value: role}}).Decode(&rolesInfo); err != nil {
+		return false, err
+	}
+	return len(rolesInfo.Roles) > 0, nil
+}
+
+func findMongoDBUser(ctx context.Context, client *mongo.Client, username string) (*mongodbUsersInfo, error) {
+	var usersInfo mongodbUsersInfo
+	err := client.Database(mongodbAdminDatabase).RunCommand(ctx, bson.D{{Key: "usersInfo", Value: username}}).Decode(&usersInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &usersInfo, nil
+}
+
+// buildMongoDBLogin godoc
+// Builds the login of the user of a database. The Data Guard roles are created with their own names in each database,
+// so there are no roles scoped to a database to tell apart.
+func buildMongoDBLogin(username, databaseName string, roles []mongodbRoleRef) *Login {
+	managedRoles := newManagedRoleNames(nil, nil)
+	login := &Login{Name: username}
+	if databaseName != mongodbAdminDatabase {
+		login.Name = fmt.Sprintf("%s@%s", username, databaseName)
+	}
+	for _, roleRef := range roles {
+		switch {
+		case slices.Contains(mongodbSuperuserRoles, roleRef.Role):
+			login.Superuser = true
+		case slices.Contains(mongodbUserAdminRoles, roleRef.Role):
+			login.CreateRole = true
+		}
+		if !managedRoles[roleRef.Role] {
+			login.UnmanagedRoles = append(login.UnmanagedRoles, fmt.Sprintf("%s@%s", roleRef.Role, roleRef.DB))
+		}
+	}
+	return login
+}
+
+func buildMongoDBCreateRoleCommand(role string, inheritedRoles bson.A) bson.D {
+	return bson.D{
+		{Key: "createRole", Value: role},
+		{Key: "privileges", Value: bson.A{}},
+		{Key: "roles", Value: inheritedRoles},
+	}
+}
+
+func buildMongoDBUpdateRoleCommand(role string, inheritedRoles bson.A) bson.D {
+	return bson.D{
+		{Key: "updateRole", Value: role},
+		{Key: "privileges", Value: bson.A{}},
+		{Key: "roles", Value: inheritedRoles},
+	}
+}
+
+// buildMongoDBCreateUserCommand godoc
+// Creates the user in the admin database with the Data Guard role of the admin database, which only records its role
+func buildMongoDBCreateUserCommand(user *DatabaseUser) bson.D {
+	return bson.D{
+		{Key: "createUser", Value: user.Username},
+		{Key: "pwd", Value: user.Password},
+		{Key: "roles", Value: bson.A{mongodbRoleRef{Role: user.Role, DB: mongodbAdminDatabase}}},
+	}
+}
+
+func buildMongoDBGrantRolesCommand(username string, roles bson.A) bson.D {
+	return bson.D{
+		{Key: "grantRolesToUser", Value: username},
+		{Key: "roles", Value: roles},
+	}
+}
+
+func buildMongoDBRevokeRolesCommand(username string, roles bson.A) bson.D {
+	return bson.D{
+		{Key: "revokeRolesFromUser", Value: username},
+		{Key: "roles", Value: roles},
+	}
+}
+
+func buildMongoDBDropUserCommand(username string) bson.D {
+	return bson.D{{Key: "dropUser", Value: username}}
+}
+
+// buildMongoDBGrantConnectCommand godoc
+// Grants the Data Guard role of the database matching the role the user has in the admin database
+func buildMongoDBGrantConnectCommand(usersInfo *mongodbUsersInfo, username, databaseName string) (bson.D, error) {
+	role := ""
+	for _, user := range usersInfo.Users {
+		for _, roleRef := range user.Roles {
+			if roleRef.DB == mongodbAdminDatabase && entity.ValidateRoleName(roleRef.Role) {
+				role = roleRef.Role
+			}
+		}
+	}
+	if role == "" {
+		return nil, ErrUserWithoutRoleMongoDB
+	}
+	return buildMongoDBGrantRolesCommand(username, bson.A{mongodbRoleRef{Role: role, DB: databaseName}}), nil
+}
+
+// buildMongoDBGrantConnectWithRoleCommands godoc
+// Grants the given Data Guard role of the database, revoking the other Data Guard roles the user has in it
+func buildMongoDBGrantConnectWithRoleCommands(usersInfo *mongodbUsersInfo, username, role, databaseName string) []bson.D {
+	var otherRoles bson.A
+	for _, user := range usersInfo.Users {
+		for _, roleRef := range user.Roles {
+			if roleRef.DB == databaseName && roleRef.Role != role && entity.ValidateRoleName(roleRef.Role) {
+				otherRoles = append(otherRoles, roleRef)
+			}
+		}
+	}
+	commands := []bson.D{buildMongoDBGrantRolesCommand(username, bson.A{mongodbRoleRef{Role: role, DB: databaseName}})}
+	if len(otherRoles) > 0 {
+		commands = append(commands, buildMongoDBRevokeRolesCommand(username, otherRoles))
+	}
+	return commands
+}
+
+// buildMongoDBRevokeRoleCommand godoc
+// Revokes the Data Guard role of the database, returning nil when it matches the user's own role
+func buildMongoDBRevokeRoleCommand(usersInfo *mongodbUsersInfo, username, role, databaseName string) bson.D {
+	for _, user := range usersInfo.Users {
+		if slices.Contains(user.Roles, mongodbRoleRef{Role: role, DB: mongodbAdminDatabase}) {
+			return nil
+		}
+	}
+	return buildMongoDBRevokeRolesCommand(username, bson.A{mongodbRoleRef{Role: role, DB: databaseName}})
+}
+
+// buildMongoDBRevokeConnectCommand godoc
+// Revokes the Data Guard roles the user has in the database, returning nil when it has none
+func buildMongoDBRevokeConnectCommand(usersInfo *mongodbUsersInfo, username, databaseName string) bson.D {
+	var databaseRoles bson.A
+	for _, user := range usersInfo.Users {
+		for _, roleRef := range user.Roles {
+			if roleRef.DB == databaseName && entity.ValidateRoleName(roleRef.Role) {
+				databaseRoles = append(databaseRoles, roleRef)
+			}
+		}
+	}
+	if len(databaseRoles) == 0 {
+		return nil
+	}
+	return buildMongoDBRevokeRolesCommand(username, databaseRoles)
+}
+
+// buildMongoDBChangeUserRoleCommands godoc
+// Grants the new role in the admin database and in the given databases, revoking the old role where the user has it
+func buildMongoDBChangeUserRoleCommands(user mongodbUserInfo, oldRole, newRole string, databases []string) []bson.D {
+	var grantedRoles, revokedRoles bson.A
+	for _, databaseName := range append([]string{mongodbAdminDatabase}, databases...) {
+		grantedRoles = append(grantedRoles, mongodbRoleRef{Role: newRole, DB: databaseName})
+		oldRoleRef := mongodbRoleRef{Role: oldRole, DB: databaseName}
+		if oldRole != newRole && slices.Contains(user.Roles, oldRoleRef) {
+			revokedRoles = append(revokedRoles, oldRoleRef)
+		}
+	}
+	commands := []bson.D{buildMongoDBGrantRolesCommand(user.User, grantedRoles)}
+	if len(revokedRoles) > 0 {
+		commands = append(commands, buildMongoDBRevokeRolesCommand(user.User, revokedRoles))
+	}
+	return commands
+}
+
+// buildMongoDBInheritedRoles godoc
+// Maps the privileges of a role to the built-in roles of the database: SELECT on tables to read, writing on tables to
+// readWrite and CREATE on schemas to dbAdmin
+func buildMongoDBInheritedRoles(privileges entity.RolePrivileges, databaseName string) bson.A {
+	inheritedRoles := bson.A{}
+	if privileges.HasAny("table", "INSERT", "UPDATE", "DELETE", "TRUNCATE") {
+		inheritedRoles = append(inheritedRoles, mongodbRoleRef{Role: "readWrite", DB: databaseName})
+	} else if privileges.HasAny("table", "SELECT") {
+		inheritedRoles = append(inheritedRoles, mongodbRoleRef{Role: "read", DB: databaseName})
+	}
+	if privileges.HasAny("schema", "CREATE") {
+		inheritedRoles = append(inheritedRoles, mongodbRoleRef{Role: "dbAdmin", DB: databaseName})
+	}
+	return inheritedRoles
+}
+
+func hasMongoDBErrorCode(err error, code int) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.HasErrorCode(code)
+}
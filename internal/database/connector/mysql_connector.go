@@ -0,0 +1,843 @@
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	mysqlTechnology      = "mysql"
+	mariadbTechnology    = "mariadb"
+	mysqlSqlFilePath     = "internal/database/connector/scripts/mysql"
+	mysqlAnyHost         = "%"
+	mysqlMaxRoleNameSize = 32
+)
+
+var (
+	ErrWhileExecutingStatementMySQL   = errors.New("error occurred while attempting to execute the statement on the target MySQL instance")
+	ErrRolesNotConfiguredMySQL        = errors.New("the Data Guard roles are not configured in the database, setup the roles before granting access")
+	ErrUserWithoutRoleMySQL           = errors.New("the user is not a member of any Data Guard role")
+	ErrScopeSchemasNotSupportedMySQL  = errors.New("MySQL has no schemas inside a database, the scope must only have tables")
+	ErrScopeTableOfOtherDatabaseMySQL = errors.New("the tables of the scope must belong to the database granted")
+	mysqlSystemDatabases              = []string{"mysql", "information_schema", "performance_schema", "sys"}
+	// mysqlPrivileges godoc
+	// Privileges given to the database scoped roles, in the order they are granted
+	mysqlPrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "ALTER", "DROP", "INDEX", "REFERENCES", "TRIGGER",
+		"CREATE VIEW", "SHOW VIEW", "CREATE ROUTINE", "ALTER ROUTINE", "EXECUTE", "EVENT", "LOCK TABLES", "CREATE TEMPORARY TABLES"}
+	// mysqlTablePrivileges godoc
+	// Privileges of mysqlPrivileges that can be granted on a single table
+	mysqlTablePrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "ALTER", "DROP", "INDEX", "REFERENCES", "TRIGGER",
+		"CREATE VIEW", "SHOW VIEW"}
+)
+
+// MySQLConnector godoc
+// Connector for MySQL 8 and MariaDB instances.
+// MySQL has no CONNECT privilege, so the Data Guard roles are created as plain marker roles that only record which role
+// a user has, while the privileges live in roles scoped to each database (see SetupGrantsToRoles).
+// Granting access to a database copies the privileges of the scoped role to the user.
+type MySQLConnector struct {
+	ConnectionData dto.ConnectionInputDTO
+	mariaDB        bool
+	tunnel         *sshTunnel
+	recorder       *StatementRecorder
+}
+
+func newMySQLConnector(connectionData dto.ConnectionInputDTO, mariaDB bool, tunnel *sshTunnel) *MySQLConnector {
+	return &MySQLConnector{ConnectionData: connectionData, mariaDB: mariaDB, tunnel: tunnel}
+}
+
+func (mc *MySQLConnector) TestConnection() error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return db.PingContext(ctx)
+	})
+}
+
+// CreateRoles godoc
+// Create Data Guard roles in the database instance
+func (mc *MySQLConnector) CreateRoles(roles []*DatabaseRole) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		sqlFilePath := filepath.Join(mysqlSqlFilePath, "create_role_if_not_exists.sql")
+		createRoleTemplate, err := storage.ReadSQLFile(sqlFilePath)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if _, err := mc.writer(db).ExecContext(ctx, fmt.Sprintf(createRoleTemplate, mc.quoteRole(string(role.Name)))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetupGrantsToRoles godoc
+// Creates the database scoped roles and grants them the privileges of each Data Guard role in the current database.
+// The privileges a scoped role holds beyond the ones of its Data Guard role are revoked, so changes to a role apply.
+func (mc *MySQLConnector) SetupGrantsToRoles(roles []*DatabaseRole) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		sqlFilePath := filepath.Join(mysqlSqlFilePath, "create_role_if_not_exists.sql")
+		createRoleTemplate, err := storage.ReadSQLFile(sqlFilePath)
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			databaseRole := mysqlDatabaseRoleName(string(role.Name), mc.Database())
+			if _, err = mc.writer(db).ExecContext(ctx, fmt.Sprintf(createRoleTemplate, mc.quoteRole(databaseRole))); err != nil {
+				return err
+			}
+			currentPrivileges, err := mc.findDatabaseRolePrivileges(ctx, db, mc.Database(), databaseRole)
+			if err != nil {
+				return err
+			}
+			privileges := buildMySQLRolePrivileges(role.Privileges)
+			for _, stmt := range buildMySQLSetupGrantsStatements(mc.Database(), mc.quoteRole(databaseRole), currentPrivileges, privileges) {
+				if _, err = mc.writer(db).ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (mc *MySQLConnector) ListDatabases() ([]*Database, error) {
+	result, err := mc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		query := `SELECT s.schema_name, COALESCE(SUM(t.data_length + t.index_length), 0)
+			FROM information_schema.schemata s
+			LEFT JOIN information_schema.tables t ON t.table_schema = s.schema_name
+			WHERE s.schema_name NOT IN (?, ?, ?, ?)
+			GROUP BY s.schema_name`
+		args := make([]any, len(mysqlSystemDatabases))
+		for i, name := range mysqlSystemDatabases {
+			args[i] = name
+		}
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var databases []*Database
+		for rows.Next() {
+			var name string
+			var sizeInBytes int64
+			if err := rows.Scan(&name, &sizeInBytes); err != nil {
+				return nil, err
+			}
+			databases = append(databases, &Database{Name: name, CurrentSize: formatSize(sizeInBytes)})
+		}
+		return databases, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*Database), nil
+}
+
+// ListLogins godoc
+// Lists the accounts with the roles granted to them, except the system accounts (mysql.*), the accounts of the
+// Data Guard roles and the admin user of the instance. Accounts of a host other than '%' are named user@host, so
+// they are never taken as a Data Guard user. MySQL has no row level security, so BypassRLS is never set.
+func (mc *MySQLConnector) ListLogins() ([]*Login, error) {
+	databases, err := mc.ListDatabases()
+	if err != nil {
+		return nil, err
+	}
+	managedRoles := newManagedRoleNames(databases, mysqlDatabaseRoleName)
+	result, err := mc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		rolesByAccount, err := mc.findRolesByAccount(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		query := `SELECT User, Host, Super_priv = 'Y', Create_user_priv = 'Y' OR Create_role_priv = 'Y', account_locked = 'Y'
+			FROM mysql.user
+			WHERE User != '' AND User NOT LIKE 'mysql.%' AND User != ?`
+		if mc.mariaDB {
+			query = `SELECT User, Host, Super_priv = 'Y', Create_user_priv = 'Y', FALSE
+			FROM mysql.user
+			WHERE User != '' AND User NOT LIKE 'mysql.%' AND User != ? AND is_role = 'N'`
+		}
+		rows, err := db.QueryContext(ctx, query, mc.ConnectionData.User)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var logins []*Login
+		for rows.Next() {
+			var user, host string
+			var login Login
+			var locked bool
+			if err := rows.Scan(&user, &host, &login.Superuser, &login.CreateRole, &locked); err != nil {
+				return nil, err
+			}
+			// MySQL roles are locked accounts
+			if locked && managedRoles[user] {
+				continue
+			}
+			login.Name = mysqlLoginName(user, host)
+			login.UnmanagedRoles = managedRoles.unmanagedRoles(rolesByAccount[login.Name])
+			logins = append(logins, &login)
+		}
+		return logins, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*Login), nil
+}
+
+// FindUserAccess godoc
+// Reads the databases where the user has any privilege, on the whole database or on some of its tables. MySQL has no
+// CONNECT privilege and the privileges of the roles are copied to the users, so the roles of each database are not
+// known. Returns nil when the user doesn't exist.
+func (mc *MySQLConnector) FindUserAccess(username string) (*UserAccess, error) {
+	result, err := mc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		exists, err := mysqlUserExists(ctx, db, username)
+		if err != nil || !exists {
+			return (*UserAccess)(nil), err
+		}
+		query := `SELECT table_schema FROM information_schema.schema_privileges WHERE grantee = ?
+			UNION
+			SELECT table_schema FROM information_schema.table_privileges WHERE grantee = ?`
+		grantee := fmt.Sprintf("'%s'@'%s'", username, mysqlAnyHost)
+		rows, err := db.QueryContext(ctx, query, grantee, grantee)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		access := &UserAccess{RolesByDatabase: make(map[string][]string)}
+		for rows.Next() {
+			var databaseName string
+			if err := rows.Scan(&databaseName); err != nil {
+				return nil, err
+			}
+			access.RolesByDatabase[databaseName] = nil
+		}
+		return access, rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*UserAccess), nil
+}
+
+func (mc *MySQLConnector) UserExists(username string) (bool, error) {
+	result, err := mc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		return mysqlUserExists(ctx, db, username)
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.(bool), nil
+}
+
+// CreateUser godoc
+// Creates the user and makes it a member of its Data Guard role. The role has no privileges, it is used to know
+// which database scoped role must be applied when the user receives access to a database.
+func (mc *MySQLConnector) CreateUser(user *DatabaseUser) error {
+	user = mc.recorder.createUser(user)
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		account := quoteMySQLAccount(user.Username)
+		stmt := fmt.Sprintf(`CREATE USER %s IDENTIFIED BY %s`, account, quoteMySQLLiteral(user.Password))
+		if _, err := mc.writer(db).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		_, err := mc.writer(db).ExecContext(ctx, fmt.Sprintf(`GRANT %s TO %s`, mc.quoteRole(user.Role), account))
+		return err
+	})
+}
+
+// GrantConnect godoc
+// Grants USAGE to the user and copies to it the privileges that its database scoped role holds in the current database
+func (mc *MySQLConnector) GrantConnect(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		role, err := mc.findUserRole(ctx, db, username)
+		if err != nil {
+			return err
+		}
+		return mc.grantDatabaseRolePrivileges(ctx, db, username, role)
+	})
+}
+
+// GrantConnectWithRole godoc
+// Same as GrantConnect, copying the privileges of the given Data Guard role instead of the ones of the user's own role.
+// The privileges the user had in the current database are revoked first, so it also changes the role of a database
+// the user already has access to.
+func (mc *MySQLConnector) GrantConnectWithRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return mc.replaceDatabasePrivileges(ctx, db, username, mc.Database(), role)
+	})
+}
+
+// GrantConnectWithScope godoc
+// Same as GrantConnect, granting the privileges of the Data Guard role only on the tables of the scope. The privileges
+// are already copied to each user, so they are granted to the user itself on each table, replacing the ones it had in
+// the current database. MySQL has no schemas inside a database, so the scope can only have tables.
+func (mc *MySQLConnector) GrantConnectWithScope(username string, role *DatabaseRole, scope entity.AccessScope) error {
+	stmts, err := buildMySQLScopeGrantsStatements(username, mc.Database(), buildMySQLRolePrivileges(role.Privileges), scope)
+	if err != nil {
+		return err
+	}
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		if err := mc.revokeDatabasePrivileges(ctx, db, username); err != nil {
+			return err
+		}
+		for _, stmt := range stmts {
+			if _, err := mc.writer(db).ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RevokeScope godoc
+// Revokes the privileges the user has on the tables of the current database
+func (mc *MySQLConnector) RevokeScope(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return mc.revokeTablePrivileges(ctx, db, username)
+	})
+}
+
+// RevokeConnect godoc
+// Revokes the privileges the user has in the current database, on the whole database and on its tables. The user and
+// its privileges in the other databases are kept.
+func (mc *MySQLConnector) RevokeConnect(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return mc.revokeDatabasePrivileges(ctx, db, username)
+	})
+}
+
+// GrantRole godoc
+// Copies to the user the privileges that the database scoped role of the Data Guard role holds in the current
+// database, in addition to the ones of its own role
+func (mc *MySQLConnector) GrantRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return mc.grantDatabaseRolePrivileges(ctx, db, username, role)
+	})
+}
+
+// RevokeRole godoc
+// Revokes from the user the privileges of the Data Guard role in the current database. The privileges are not
+// tracked by role, so the ones of the user's own role are copied again afterward.
+func (mc *MySQLConnector) RevokeRole(username, role string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		ownRole, err := mc.findUserRole(ctx, db, username)
+		if err != nil {
+			return err
+		}
+		privileges, err := mc.findDatabaseRolePrivileges(ctx, db, mc.Database(), mysqlDatabaseRoleName(role, mc.Database()))
+		if err != nil {
+			return err
+		}
+		if len(privileges) > 0 {
+			if _, err = mc.writer(db).ExecContext(ctx, buildMySQLRevokePrivilegesStatement(username, mc.Database(), privileges)); err != nil {
+				return err
+			}
+		}
+		return mc.grantDatabaseRolePrivileges(ctx, db, username, ownRole)
+	})
+}
+
+// ChangeUserRole godoc
+// Grants the new Data Guard role to the user and revokes the old one. The privileges of the roles are copied to the
+// user by database, so the ones of the given databases are replaced by the privileges of the new role.
+// Each step is skipped when already done, so running it again completes a change that failed midway.
+func (mc *MySQLConnector) ChangeUserRole(username, oldRole, newRole string, databases []string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		roles, err := mc.findUserRoles(ctx, db, username)
+		if err != nil {
+			return err
+		}
+		account := quoteMySQLAccount(username)
+		if !slices.Contains(roles, newRole) {
+			if _, err = mc.writer(db).ExecContext(ctx, fmt.Sprintf(`GRANT %s TO %s`, mc.quoteRole(newRole), account)); err != nil {
+				return err
+			}
+		}
+		for _, databaseName := range databases {
+			if err = mc.replaceDatabasePrivileges(ctx, db, username, databaseName, newRole); err != nil {
+				return fmt.Errorf("database '%s': %w", databaseName, err)
+			}
+		}
+		if oldRole != newRole && slices.Contains(roles, oldRole) {
+			_, err = mc.writer(db).ExecContext(ctx, fmt.Sprintf(`REVOKE %s FROM %s`, mc.quoteRole(oldRole), account))
+		}
+		return err
+	})
+}
+
+// RevokeUserPrivilegesAndRemove godoc
+// Revokes all privileges from a user and removes it from the database instance
+func (mc *MySQLConnector) RevokeUserPrivilegesAndRemove(username string) error {
+	return mc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		sqlFilePath := filepath.Join(mysqlSqlFilePath, "revoke_user_privileges_and_exclude.sql")
+		removeUserTemplate, err := storage.ReadSQLFile(sqlFilePath)
+		if err != nil {
+			return err
+		}
+		if _, err = mc.writer(db).ExecContext(ctx, fmt.Sprintf(removeUserTemplate, quoteMySQLAccount(username))); err != nil {
+			return err
+		}
+		if mc.recorder != nil {
+			return nil
+		}
+
+		userStillExists, err := mysqlUserExists(ctx, db, username)
+		if err != nil {
+			return err
+		}
+		if userStillExists {
+			return fmt.Errorf("user '%s' still exists after removal attempt", username)
+		}
+		return nil
+	})
+}
+
+func (mc *MySQLConnector) Driver() string {
+	return "mysql"
+}
+
+func (mc *MySQLConnector) DefaultDatabase() string {
+	return "mysql"
+}
+
+func (mc *MySQLConnector) Database() string {
+	databaseName := mc.ConnectionData.Database
+	if databaseName == "" {
+		databaseName = mc.DefaultDatabase()
+	}
+	return databaseName
+}
+
+// URL godoc
+// Returns the DSN in the go-sql-driver format. Multiple statements are allowed to run the setup scripts.
+func (mc *MySQLConnector) URL() string {
+	cfg := mysql.NewConfig()
+	cfg.User = mc.ConnectionData.User
+	cfg.Passwd = mc.ConnectionData.Password
+	cfg.Net = "tcp"
+	if mc.tunnel != nil {
+		// Dial function registered by openSSHTunnel
+		cfg.Net = mc.tunnel.key
+	}
+	cfg.Addr = net.JoinHostPort(mc.ConnectionData.Host, mc.ConnectionData.Port)
+	cfg.DBName = mc.Database()
+	cfg.Timeout = connectionTimeout
+	cfg.MultiStatements = true
+	if tlsEnabled(mc.ConnectionData) {
+		// Registered by newDriverConnector, see buildTLSConfig
+		cfg.TLSConfig = tlsConfigKey(mc.ConnectionData)
+	}
+	return cfg.FormatDSN()
+}
+
+// setRecorder godoc
+// Records the statements that change the instance instead of executing them, for the dry run
+func (mc *MySQLConnector) setRecorder(recorder *StatementRecorder) {
+	mc.recorder = recorder
+}
+
+// writer godoc
+// Returns where the statements that change the instance are executed: db itself, or the recorder in dry run
+func (mc *MySQLConnector) writer(db *sql.DB) sqlExecutor {
+	if mc.recorder != nil {
+		return mc.recorder.executor(mc.Database(), nil)
+	}
+	return db
+}
+
+// acquireDB godoc
+// Returns the pool of the database kept by the connection manager and the function that must be called after using it
+func (mc *MySQLConnector) acquireDB() (*sql.DB, func(), error) {
+	return connections().acquire(mc.ConnectionData, mc.Database(), mc.URL(), mc.newDriverConnector)
+}
+
+func (mc *MySQLConnector) newDriverConnector() (driver.Connector, error) {
+	if _, err := registerTLSConfig(mc.ConnectionData, mysql.RegisterTLSConfig); err != nil {
+		return nil, err
+	}
+	cfg, err := mysql.ParseDSN(mc.URL())
+	if err != nil {
+		return nil, err
+	}
+	return mysql.NewConnector(cfg)
+}
+
+// quoteRole godoc
+// MySQL roles are accounts with the host part set to '%', while MariaDB roles have no host
+func (mc *MySQLConnector) quoteRole(name string) string {
+	if mc.mariaDB {
+		return quoteMySQLIdentifier(name)
+	}
+	return quoteMySQLAccount(name)
+}
+
+func (mc *MySQLConnector) findUserRole(ctx context.Context, db *sql.DB, username string) (string, error) {
+	roles, err := mc.findUserRoles(ctx, db, username)
+	if err != nil {
+		return "", err
+	}
+	if len(roles) == 0 {
+		return "", ErrUserWithoutRoleMySQL
+	}
+	return roles[0], nil
+}
+
+func (mc *MySQLConnector) findUserRoles(ctx context.Context, db *sql.DB, username string) ([]string, error) {
+	if role, created := mc.recorder.createdUserRole(username); created {
+		return []string{role}, nil
+	}
+	query := `SELECT from_user FROM mysql.role_edges WHERE to_user = ? AND to_host = ?`
+	if mc.mariaDB {
+		query = `SELECT role FROM mysql.roles_mapping WHERE user = ? AND host = ?`
+	}
+	rows, err := db.QueryContext(ctx, query, username, mysqlAnyHost)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		if entity.ValidateRoleName(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles, rows.Err()
+}
+
+// findRolesByAccount godoc
+// Returns the roles granted to each account, by the name of the account as in mysqlLoginName
+func (mc *MySQLConnector) findRolesByAccount(ctx context.Context, db *sql.DB) (map[string][]string, error) {
+	query := `SELECT to_user, to_host, from_user FROM mysql.role_edges ORDER BY from_user`
+	if mc.mariaDB {
+		query = `SELECT user, host, role FROM mysql.roles_mapping ORDER BY role`
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rolesByAccount := make(map[string][]string)
+	for rows.Next() {
+		var user, host, role string
+		if err := rows.Scan(&user, &host, &role); err != nil {
+			return nil, err
+		}
+		name := mysqlLoginName(user, host)
+		rolesByAccount[name] = append(rolesByAccount[name], role)
+	}
+	return rolesByAccount, rows.Err()
+}
+
+func (mc *MySQLConnector) grantDatabaseRolePrivileges(ctx context.Context, db *sql.DB, username, role string) error {
+	privileges, err := mc.findDatabaseRolePrivileges(ctx, db, mc.Database(), mysqlDatabaseRoleName(role, mc.Database()))
+	if err != nil {
+		return err
+	}
+	if len(privileges) == 0 {
+		return ErrRolesNotConfiguredMySQL
+	}
+	for _, stmt := range buildMySQLGrantConnectStatements(username, mc.Database(), privileges) {
+		if _, err := mc.writer(db).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceDatabasePrivileges godoc
+// Revokes the privileges the user has in the database and copies to it the ones of the database scoped role of the
+// Data Guard role. The privileges of the role are checked first, so the user keeps its access when they are missing.
+func (mc *MySQLConnector) replaceDatabasePrivileges(ctx context.Context, db *sql.DB, username, databaseName, role string) error {
+	rolePrivileges, err := mc.findDatabaseRolePrivileges(ctx, db, databaseName, mysqlDatabaseRoleName(role, databaseName))
+	if err != nil {
+		return err
+	}
+	if len(rolePrivileges) == 0 {
+		return ErrRolesNotConfiguredMySQL
+	}
+	userPrivileges, err := mc.findDatabaseRolePrivileges(ctx, db, databaseName, username)
+	if err != nil {
+		return err
+	}
+	if len(userPrivileges) > 0 {
+		if _, err = mc.writer(db).ExecContext(ctx, buildMySQLRevokePrivilegesStatement(username, databaseName, userPrivileges)); err != nil {
+			return err
+		}
+	}
+	for _, stmt := range buildMySQLGrantConnectStatements(username, databaseName, rolePrivileges) {
+		if _, err = mc.writer(db).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// revokeDatabasePrivileges godoc
+// Revokes the privileges the user has in the current database, on the whole database and on its tables
+func (mc *MySQLConnector) revokeDatabasePrivileges(ctx context.Context, db *sql.DB, username string) error {
+	userPrivileges, err := mc.findDatabaseRolePrivileges(ctx, db, mc.Database(), username)
+	if err != nil {
+		return err
+	}
+	if len(userPrivileges) > 0 {
+		if _, err = mc.writer(db).ExecContext(ctx, buildMySQLRevokePrivilegesStatement(username, mc.Database(), userPrivileges)); err != nil {
+			return err
+		}
+	}
+	return mc.revokeTablePrivileges(ctx, db, username)
+}
+
+func (mc *MySQLConnector) revokeTablePrivileges(ctx context.Context, db *sql.DB, username string) error {
+	query := `SELECT table_name, privilege_type FROM information_schema.table_privileges WHERE table_schema = ? AND grantee = ? ORDER BY table_name`
+	rows, err := db.QueryContext(ctx, query, mc.Database(), fmt.Sprintf("'%s'@'%s'", username, mysqlAnyHost))
+	if err != nil {
+		return err
+	}
+	var tables []string
+	privilegesByTable := make(map[string][]string)
+	for rows.Next() {
+		var table, privilege string
+		if err = rows.Scan(&table, &privilege); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if _, found := privilegesByTable[table]; !found {
+			tables = append(tables, table)
+		}
+		privilegesByTable[table] = append(privilegesByTable[table], privilege)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		stmt := fmt.Sprintf(`REVOKE %s ON %s.%s FROM %s`, strings.Join(privilegesByTable[table], ", "),
+			quoteMySQLIdentifier(mc.Database()), quoteMySQLIdentifier(table), quoteMySQLAccount(username))
+		if _, err = mc.writer(db).ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (mc *MySQLConnector) findDatabaseRolePrivileges(ctx context.Context, db *sql.DB, databaseName, roleName string) ([]string, error) {
+	// MySQL reports role grantees as 'role'@'%' while MariaDB omits the host
+	query := `SELECT privilege_type FROM information_schema.schema_privileges WHERE table_schema = ? AND grantee IN (?, ?)`
+	rows, err := db.QueryContext(ctx, query, databaseName, fmt.Sprintf("'%s'@'%s'", roleName, mysqlAnyHost), fmt.Sprintf("'%s'", roleName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []string
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, privilege)
+	}
+	return privileges, rows.Err()
+}
+
+func (mc *MySQLConnector) executeWithTimeout(ctx context.Context, operation func(context.Context, *sql.DB) error) error {
+	dbConn, release, err := mc.acquireDB()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		defer release()
+		result <- operation(ctx, dbConn)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Printf("Connection failed! Cause: connection timed out after %s", connectionTimeout)
+		return fmt.Errorf("connection failed! Cause: connection timed out after %s", connectionTimeout)
+	case err := <-result:
+		if err != nil {
+			log.Printf("Error while executing statement on database %s. Cause: %v", mc.Database(), err)
+			return fmt.Errorf("%w! Database: %s. Cause: %w", ErrWhileExecutingStatementMySQL, mc.Database(), err)
+		}
+		return nil
+	}
+}
+
+func (mc *MySQLConnector) queryWithTimeout(ctx context.Context, operation func(context.Context, *sql.DB) (any, error)) (any, error) {
+	dbConn, release, err := mc.acquireDB()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
+	defer cancel()
+
+	result := make(chan any, 1)
+	errChan := make(chan error, 1)
+	go func() {
+		defer release()
+		res, err := operation(ctx, dbConn)
+		if err != nil {
+			errChan <- err
+		} else {
+			result <- res
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("connection failed! Cause: connection timed out after %s", connectionTimeout)
+	case err := <-errChan:
+		return nil, err
+	case res := <-result:
+		return res, nil
+	}
+}
+
+func mysqlUserExists(ctx context.Context, db *sql.DB, username string) (bool, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM mysql.user WHERE user = ? AND host = ?)`, username, mysqlAnyHost).Scan(&exists)
+	return exists, err
+}
+
+// buildMySQLRolePrivileges godoc
+// Maps the privileges of a role to the privileges of a MySQL database, in the order of mysqlPrivileges. CREATE on
+// schemas gives the privileges to manage the structure of the database, since MySQL has no schemas inside a database.
+func buildMySQLRolePrivileges(privileges entity.RolePrivileges) []string {
+	var granted []string
+	grant := func(mysqlPrivileges ...string) {
+		granted = append(granted, mysqlPrivileges...)
+	}
+	if privileges.HasAny("table", "SELECT") {
+		grant("SELECT", "SHOW VIEW")
+	}
+	for _, privilege := range []string{"INSERT", "UPDATE", "DELETE", "REFERENCES", "TRIGGER"} {
+		if privileges.HasAny("table", privilege) {
+			grant(privilege)
+		}
+	}
+	if privileges.HasAny("table", "TRUNCATE") {
+		grant("DROP")
+	}
+	if privileges.HasAny("function", "EXECUTE") {
+		grant("EXECUTE")
+	}
+	if privileges.HasAny("schema", "CREATE") {
+		grant("CREATE", "ALTER", "DROP", "INDEX", "CREATE VIEW", "CREATE ROUTINE", "ALTER ROUTINE", "EVENT", "LOCK TABLES", "CREATE TEMPORARY TABLES")
+	}
+	if privileges.HasAny("database", "TEMP") {
+		grant("CREATE TEMPORARY TABLES")
+	}
+	ordered := make([]string, 0, len(granted))
+	for _, privilege := range mysqlPrivileges {
+		if slices.Contains(granted, privilege) {
+			ordered = append(ordered, privilege)
+		}
+	}
+	return ordered
+}
+
+// buildMySQLSetupGrantsStatements godoc
+// Builds the statements that leave the scoped role with exactly the given privileges on the database
+func buildMySQLSetupGrantsStatements(databaseName, quotedRole string, currentPrivileges, privileges []string) []string {
+	var stmts []string
+	var extraPrivileges []string
+	for _, privilege := range currentPrivileges {
+		if !slices.Contains(privileges, privilege) {
+			extraPrivileges = append(extraPrivileges, privilege)
+		}
+	}
+	if len(extraPrivileges) > 0 {
+		stmts = append(stmts, fmt.Sprintf(`REVOKE %s ON %s.* FROM %s`, strings.Join(extraPrivileges, ", "), quoteMySQLIdentifier(databaseName), quotedRole))
+	}
+	if len(privileges) > 0 {
+		stmts = append(stmts, fmt.Sprintf(`GRANT %s ON %s.* TO %s`, strings.Join(privileges, ", "), quoteMySQLIdentifier(databaseName), quotedRole))
+	}
+	return stmts
+}
+
+func buildMySQLGrantConnectStatements(username, databaseName string, privileges []string) []string {
+	account := quoteMySQLAccount(username)
+	return []string{
+		fmt.Sprintf(`GRANT USAGE ON *.* TO %s`, account),
+		fmt.Sprintf(`GRANT %s ON %s.* TO %s`, strings.Join(privileges, ", "), quoteMySQLIdentifier(databaseName), account),
+	}
+}
+
+// buildMySQLScopeGrantsStatements godoc
+// Builds the statements that grant to the user the privileges of the role that apply to a single table, on each
+// table of the scope. The tables may be qualified with the name of the database.
+func buildMySQLScopeGrantsStatements(username, databaseName string, privileges []string, scope entity.AccessScope) ([]string, error) {
+	if len(scope.Schemas) > 0 {
+		return nil, ErrScopeSchemasNotSupportedMySQL
+	}
+	var tablePrivileges []string
+	for _, privilege := range privileges {
+		if slices.Contains(mysqlTablePrivileges, privilege) {
+			tablePrivileges = append(tablePrivileges, privilege)
+		}
+	}
+	account := quoteMySQLAccount(username)
+	stmts := []string{fmt.Sprintf(`GRANT USAGE ON *.* TO %s`, account)}
+	for _, table := range scope.Tables {
+		if qualifier, tableName, found := strings.Cut(table, "."); found {
+			if qualifier != databaseName {
+				return nil, fmt.Errorf("%w: %s", ErrScopeTableOfOtherDatabaseMySQL, table)
+			}
+			table = tableName
+		}
+		stmts = append(stmts, fmt.Sprintf(`GRANT %s ON %s.%s TO %s`, strings.Join(tablePrivileges, ", "),
+			quoteMySQLIdentifier(databaseName), quoteMySQLIdentifier(table), account))
+	}
+	return stmts, nil
+}
+
+func buildMySQLRevokePrivilegesStatement(username, databaseName string, privileges []string) string {
+	return fmt.Sprintf(`REVOKE %s ON %s.* FROM %s`, strings.Join(privileges, ", "), quoteMySQLIdentifier(databaseName), quoteMySQLAccount(username))
+}
+
+// mysqlLoginName godoc
+// Data Guard creates the users for any host ('%'), so only the accounts of other hosts carry the host in the name
+func mysqlLoginName(user, host string) string {
+	if host == mysqlAnyHost {
+		return user
+	}
+	return fmt.Sprintf("%s@%s", user, host)
+}
+
+// mysqlDatabaseRoleName godoc
+// Returns the name of the role that holds the privileges of a Data Guard role in a database.
+// MySQL limits account names to 32 characters, so long names are shortened with a hash of the database name.
+func mysqlDatabaseRoleName(role, databaseName string) string {
+	return databaseRoleName(role, databaseName, mysqlMaxRoleNameSize)
+}
@@ -0,0 +1,109 @@
+package connector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+func TestGivenShortDatabaseName_WhenBuildMySQLDatabaseRoleName_ThenShouldConcatenateRoleAndDatabase(t *testing.T) {
+	assert.Equal(t, "developer_orders", mysqlDatabaseRoleName("developer", "orders"))
+}
+
+func TestGivenLongDatabaseName_WhenBuildMySQLDatabaseRoleName_ThenShouldRespectMaxSizeAndBeDeterministic(t *testing.T) {
+	databaseName := "a_very_long_database_name_used_by_the_billing_team"
+
+	roleName := mysqlDatabaseRoleName("application", databaseName)
+
+	assert.Len(t, roleName, mysqlMaxRoleNameSize)
+	assert.True(t, strings.HasPrefix(roleName, "application_"))
+	assert.Equal(t, roleName, mysqlDatabaseRoleName("application", databaseName))
+	assert.NotEqual(t, roleName, mysqlDatabaseRoleName("application", databaseName+"_v2"))
+}
+
+func TestGivenNamesWithQuotes_WhenQuoteMySQL_ThenShouldEscapeThem(t *testing.T) {
+	assert.Equal(t, "`my``db`", quoteMySQLIdentifier("my`db"))
+	assert.Equal(t, `'it''s\\secret'`, quoteMySQLLiteral(`it's\secret`))
+	assert.Equal(t, `'john''s'@'%'`, quoteMySQLAccount("john's"))
+}
+
+func TestGivenDataGuardRoles_WhenBuildMySQLRolePrivileges_ThenShouldMapToDatabasePrivileges(t *testing.T) {
+	roles := buildDataGuardRoles()
+	allPrivileges := []string{"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "ALTER", "DROP", "INDEX", "REFERENCES", "TRIGGER",
+		"CREATE VIEW", "SHOW VIEW", "CREATE ROUTINE", "ALTER ROUTINE", "EXECUTE", "EVENT", "LOCK TABLES", "CREATE TEMPORARY TABLES"}
+
+	assert.Equal(t, []string{"SELECT", "SHOW VIEW", "EXECUTE"}, buildMySQLRolePrivileges(roles[0].Privileges))
+	assert.Equal(t, []string{"SELECT", "INSERT", "UPDATE", "DELETE", "SHOW VIEW", "EXECUTE"}, buildMySQLRolePrivileges(roles[1].Privileges))
+	assert.Equal(t, allPrivileges, buildMySQLRolePrivileges(roles[2].Privileges))
+	assert.Equal(t, allPrivileges, buildMySQLRolePrivileges(roles[3].Privileges))
+}
+
+func TestGivenScopedRoleWithExtraPrivileges_WhenBuildMySQLSetupGrantsStatements_ThenShouldRevokeThemAndGrantTheRoleOnes(t *testing.T) {
+	stmts := buildMySQLSetupGrantsStatements("orders", "`developer_orders`", []string{"SELECT", "INSERT", "DROP"}, []string{"SELECT", "SHOW VIEW"})
+
+	assert.Equal(t, []string{
+		"REVOKE INSERT, DROP ON `orders`.* FROM `developer_orders`",
+		"GRANT SELECT, SHOW VIEW ON `orders`.* TO `developer_orders`",
+	}, stmts)
+}
+
+func TestGivenPrivileges_WhenBuildMySQLGrantConnectStatements_ThenShouldGrantUsageAndSchemaPrivileges(t *testing.T) {
+	stmts := buildMySQLGrantConnectStatements("john.doe", "orders", []string{"SELECT", "SHOW VIEW"})
+
+	assert.Equal(t, []string{
+		"GRANT USAGE ON *.* TO 'john.doe'@'%'",
+		"GRANT SELECT, SHOW VIEW ON `orders`.* TO 'john.doe'@'%'",
+	}, stmts)
+}
+
+func TestGivenPrivileges_WhenBuildMySQLRevokePrivilegesStatement_ThenShouldRevokeSchemaPrivileges(t *testing.T) {
+	stmt := buildMySQLRevokePrivilegesStatement("john.doe", "orders", []string{"SELECT", "INSERT"})
+
+	assert.Equal(t, "REVOKE SELECT, INSERT ON `orders`.* FROM 'john.doe'@'%'", stmt)
+}
+
+func TestGivenConnectionData_WhenBuildMySQLURL_ThenShouldUseDefaultDatabaseAndMultiStatements(t *testing.T) {
+	mc := newMySQLConnector(dto.ConnectionInputDTO{Host: "localhost", Port: "3306", User: "admin", Password: "p@ss:word"}, false, nil)
+
+	assert.Equal(t, "mysql", mc.Database())
+	assert.Equal(t, "admin:p@ss:word@tcp(localhost:3306)/mysql?multiStatements=true&timeout=30s", mc.URL())
+}
+
+func TestGivenSizesInBytes_WhenFormatSize_ThenShouldFormatLikePgSizePretty(t *testing.T) {
+	assert.Equal(t, "0 bytes", formatSize(0))
+	assert.Equal(t, "10239 bytes", formatSize(10239))
+	assert.Equal(t, "10 kB", formatSize(10240))
+	assert.Equal(t, "25 MB", formatSize(25*1024*1024))
+	assert.Equal(t, "3072 MB", formatSize(3*1024*1024*1024))
+	assert.Equal(t, "30 GB", formatSize(30*1024*1024*1024))
+}
+
+func TestGivenScopeWithTables_WhenBuildMySQLScopeGrantsStatements_ThenShouldGrantTheTablePrivilegesOnEachTable(t *testing.T) {
+	privileges := []string{"SELECT", "INSERT", "SHOW VIEW", "EXECUTE"}
+
+	stmts, err := buildMySQLScopeGrantsStatements("john.doe", "orders", privileges, entity.AccessScope{Tables: []string{"customers", "orders.sales"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GRANT USAGE ON *.* TO 'john.doe'@'%'",
+		"GRANT SELECT, INSERT, SHOW VIEW ON `orders`.`customers` TO 'john.doe'@'%'",
+		"GRANT SELECT, INSERT, SHOW VIEW ON `orders`.`sales` TO 'john.doe'@'%'",
+	}, stmts)
+}
+
+func TestGivenScopeWithSchemasOrTablesOfOtherDatabase_WhenBuildMySQLScopeGrantsStatements_ThenShouldReturnAnError(t *testing.T) {
+	_, err := buildMySQLScopeGrantsStatements("john.doe", "orders", []string{"SELECT"}, entity.AccessScope{Schemas: []string{"reporting"}})
+	assert.ErrorIs(t, err, ErrScopeSchemasNotSupportedMySQL)
+
+	_, err = buildMySQLScopeGrantsStatements("john.doe", "orders", []string{"SELECT"}, entity.AccessScope{Tables: []string{"billing.invoices"}})
+	assert.ErrorIs(t, err, ErrScopeTableOfOtherDatabaseMySQL)
+}
+
+func TestGivenAccountsOfSeveralHosts_WhenBuildMySQLLoginName_ThenShouldOnlyNameTheHostWhenNotAnyHost(t *testing.T) {
+	assert.Equal(t, "johndoe", mysqlLoginName("johndoe", "%"))
+	assert.Equal(t, "johndoe@10.0.0.1", mysqlLoginName("johndoe", "10.0.0.1"))
+}
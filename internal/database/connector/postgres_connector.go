@@ -3,15 +3,18 @@ package connector
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log"
 	"net/url"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 
@@ -20,11 +23,14 @@ import (
 )
 
 const (
-	postgres          = "postgres"
-	connectionTimeout = 30 * time.Second
-	maxRetries        = 3
-	retryInterval     = 500 * time.Millisecond
-	sqlFilePath       = "internal/database/connector/scripts/postgres"
+	postgres            = "postgres"
+	connectionTimeout   = 30 * time.Second
+	maxRetries          = 3
+	retryInterval       = 500 * time.Millisecond
+	sqlFilePath         = "internal/database/connector/scripts/postgres"
+	postgresParamPrefix = "zg_data_guard."
+	// postgresMaxRoleNameSize is the NAMEDATALEN limit of identifiers minus the terminator
+	postgresMaxRoleNameSize = 63
 )
 
 var (
@@ -33,10 +39,19 @@ var (
 
 type PostgresConnector struct {
 	ConnectionData dto.ConnectionInputDTO
+	tunnel         *sshTunnel
+	recorder       *StatementRecorder
 }
 
-func newPostgresConnector(connectionData dto.ConnectionInputDTO) *PostgresConnector {
-	return &PostgresConnector{ConnectionData: connectionData}
+// postgresType godoc
+// A user defined type, which has no ALL TYPES IN SCHEMA form to grant privileges on
+type postgresType struct {
+	Schema string
+	Name   string
+}
+
+func newPostgresConnector(connectionData dto.ConnectionInputDTO, tunnel *sshTunnel) *PostgresConnector {
+	return &PostgresConnector{ConnectionData: connectionData, tunnel: tunnel}
 }
 
 func (pc *PostgresConnector) TestConnection() error {
@@ -58,13 +73,12 @@ func (pc *PostgresConnector) CreateRoles(roles []*DatabaseRole) error {
 
 func (pc *PostgresConnector) createRolesInDB(ctx context.Context, db *sql.DB, roles []*DatabaseRole) error {
 	sqlFilePath := filepath.Join(sqlFilePath, "create_role_if_not_exists.sql")
-	createRoleTemplate, err := storage.ReadSQLFile(sqlFilePath)
+	createRoleFunction, err := storage.ReadSQLFile(sqlFilePath)
 	if err != nil {
 		return err
 	}
 	for _, role := range roles {
-		createRoleFunction := fmt.Sprintf(createRoleTemplate, role.Name)
-		if _, err := db.ExecContext(ctx, createRoleFunction); err != nil {
+		if err := pc.executeDoBlock(ctx, db, createRoleFunction, map[string]string{"role_name": string(role.Name)}); err != nil {
 			return err
 		}
 	}
@@ -72,57 +86,51 @@ func (pc *PostgresConnector) createRolesInDB(ctx context.Context, db *sql.DB, ro
 }
 
 // SetupGrantsToRoles godoc
-// Execute a procedure that grants privileges to roles in the all schemas of the database
-// It's necessary to execute this procedure after creating the roles to grant the necessary privileges to them
-// The database user will have one of this roles
-func (pc *PostgresConnector) SetupGrantsToRoles() error {
+// Grants to each Data Guard role its privileges in all the schemas of the current database, revoking the ones it
+// no longer has, in a single transaction. See buildPostgresSetupGrantsStatements.
+// It's necessary to execute it after creating the roles, and again for the schemas created afterward.
+func (pc *PostgresConnector) SetupGrantsToRoles(roles []*DatabaseRole) error {
 	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
-		sqlFilePath := filepath.Join(sqlFilePath, "setup_grants_roles_database.sql")
-		setupFunction, err := storage.ReadSQLFile(sqlFilePath)
+		schemas, err := listPostgresSchemas(ctx, db)
 		if err != nil {
 			return err
 		}
-		_, err = db.ExecContext(ctx, setupFunction)
+		var types []postgresType
+		for _, role := range roles {
+			if len(role.Privileges.Type) > 0 {
+				if types, err = listPostgresTypes(ctx, db); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		tx, err := db.BeginTx(ctx, nil)
 		if err != nil {
 			return err
 		}
-		return nil
+		defer func() { _ = tx.Rollback() }()
+		for _, stmt := range buildPostgresSetupGrantsStatements(pc.Database(), schemas, types, roles) {
+			if _, err = pc.writer(tx).ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return tx.Commit()
 	})
 }
 
 func (pc *PostgresConnector) ListDatabases() ([]*Database, error) {
-	dbConn, err := sql.Open(pc.Driver(), pc.URL())
+	result, err := pc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		return listDatabases(ctx, db)
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer dbConn.Close()
-
-	ctx, cancel := context.WithTimeout(context.Background(), connectionTimeout)
-	defer cancel()
-	errorChan := make(chan error, 1)
-	databasesChan := make(chan []*Database, 1)
-	go listDatabasesWithTimeout(ctx, dbConn, errorChan, databasesChan)
-
-	select {
-	case <-ctx.Done():
-		return nil, fmt.Errorf("connection failed! Cause: connection timed out after %s", connectionTimeout.String())
-	case err := <-errorChan:
-		return nil, err
-	case databases := <-databasesChan:
-		return databases, nil
-	}
+	return result.([]*Database), nil
 }
 
 func (pc *PostgresConnector) UserExists(username string) (bool, error) {
 	result, err := pc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
-		query := `SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname=$1)`
-
-		var exists bool
-		err := db.QueryRowContext(ctx, query, username).Scan(&exists)
-		if err != nil {
-			return nil, err
-		}
-		return exists, nil
+		return postgresUserExists(ctx, db, username)
 	})
 
 	if err != nil {
@@ -133,36 +141,201 @@ func (pc *PostgresConnector) UserExists(username string) (bool, error) {
 }
 
 func (pc *PostgresConnector) CreateUser(user *DatabaseUser) error {
+	user = pc.recorder.createUser(user)
 	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
-		var err error
+		// The connections are reused, so settings are changed only within the transaction
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
 		if entity.CheckRoleApplication(user.Role) {
 			// Application role requires password encryption to be set to 'md5' for compatibility purposes
-			if _, err = db.ExecContext(ctx, `SET password_encryption = 'md5'`); err != nil {
+			if _, err = pc.writer(tx).ExecContext(ctx, `SET LOCAL password_encryption = 'md5'`); err != nil {
 				return err
 			}
 		}
-		_, err = db.ExecContext(ctx, fmt.Sprintf(`CREATE USER "%s" WITH LOGIN PASSWORD '%s' IN ROLE "%s"`, user.Username, user.Password, user.Role))
-		return err
+		if _, err = pc.writer(tx).ExecContext(ctx, buildPostgresCreateUserStatement(user)); err != nil {
+			return err
+		}
+		return tx.Commit()
 	})
 }
 
 func (pc *PostgresConnector) GrantConnect(username string) error {
 	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
-		stmt := fmt.Sprintf(`GRANT CONNECT ON DATABASE "%s" TO "%s"`, pc.Database(), username)
-		var err error
-		for i := 0; i < maxRetries; i++ {
-			_, err = db.ExecContext(ctx, stmt)
-			if err != nil && isConcurrentError(err) {
-				log.Printf("Concurrent error detected on grant connect to '%s' in '%s', retrying... [%d/%d]", username, pc.Database(), i+1, maxRetries)
-				time.Sleep(retryInterval)
-				continue
+		return grantPostgresConnect(ctx, pc.writer(db), pc.Database(), username)
+	})
+}
+
+// GrantConnectWithRole godoc
+// Same as GrantConnect, also granting the privileges of the Data Guard role in the current database only, through its
+// database scoped role (e.g. developer_orders) set up from the privileges the role holds in the database. The scoped
+// roles of the other Data Guard roles are revoked from the user, so it also changes the role of a database the user
+// already has access to. The own role of the user is a membership of the whole instance, so it still applies.
+func (pc *PostgresConnector) GrantConnectWithRole(username, role string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		databaseRole, err := pc.setupDatabaseRole(ctx, db, role)
+		if err != nil {
+			return err
+		}
+		if err = grantPostgresConnect(ctx, pc.writer(db), pc.Database(), username); err != nil {
+			return err
+		}
+		if _, err = pc.writer(db).ExecContext(ctx, buildPostgresGrantRoleStatement(databaseRole, username)); err != nil {
+			return err
+		}
+		return revokeOtherPostgresDatabaseRoles(ctx, db, pc.writer(db), pc.Database(), username, role)
+	})
+}
+
+// GrantConnectWithScope godoc
+// Same as GrantConnect, granting the privileges of the Data Guard role only on the schemas and tables of the scope,
+// through a role of the user in the current database (see postgresScopeRoleName). The privileges of the scope role are
+// replaced in a single transaction, so it also changes the scope of a database the user already has access to.
+// The memberships of the user in the Data Guard roles apply to the whole instance, so they are revoked, as are the
+// database scoped roles of the current database: the databases of the instance without scope must be granted with
+// GrantConnectWithRole.
+func (pc *PostgresConnector) GrantConnectWithScope(username string, role *DatabaseRole, scope entity.AccessScope) error {
+	scopeRole := postgresScopeRoleName(username, pc.Database())
+	stmts := buildPostgresScopeGrantsStatements(pc.Database(), scopeRole, role, scope)
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		if err := pc.createRolesInDB(ctx, db, []*DatabaseRole{{Name: entity.RoleName(scopeRole)}}); err != nil {
+			return err
+		}
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		for _, stmt := range stmts {
+			if _, err = pc.writer(tx).ExecContext(ctx, stmt); err != nil {
+				return err
 			}
-			break
 		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+		if err = grantPostgresConnect(ctx, pc.writer(db), pc.Database(), username); err != nil {
+			return err
+		}
+		if _, err = pc.writer(db).ExecContext(ctx, buildPostgresGrantRoleStatement(scopeRole, username)); err != nil {
+			return err
+		}
+		var rolesToRevoke []string
+		for _, dataGuardRole := range entity.KnownRoleNames() {
+			rolesToRevoke = append(rolesToRevoke, string(dataGuardRole), postgresDatabaseRoleName(string(dataGuardRole), pc.Database()))
+		}
+		return revokePostgresMemberships(ctx, db, pc.writer(db), username, rolesToRevoke)
+	})
+}
+
+// RevokeScope godoc
+// Removes the scope role of the user in the current database, with all its privileges. Nothing is done when the user
+// has no scope in the database.
+func (pc *PostgresConnector) RevokeScope(username string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		return pc.dropScopeRole(ctx, db, username)
+	})
+}
+
+// RevokeConnect godoc
+// Removes the access of the user to the current database only, keeping the user and its access to the other databases
+// of the instance: the CONNECT privilege is revoked, as are the database scoped roles and the scope role of the user in
+// the database. The own role of the user is a membership of the whole instance, so it is kept.
+func (pc *PostgresConnector) RevokeConnect(username string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		if _, err := pc.writer(db).ExecContext(ctx, buildPostgresRevokeConnectStatement(pc.Database(), username)); err != nil {
+			return err
+		}
+		var databaseRoles []string
+		for _, dataGuardRole := range entity.KnownRoleNames() {
+			databaseRoles = append(databaseRoles, postgresDatabaseRoleName(string(dataGuardRole), pc.Database()))
+		}
+		if err := revokePostgresMemberships(ctx, db, pc.writer(db), username, databaseRoles); err != nil {
+			return err
+		}
+		return pc.dropScopeRole(ctx, db, username)
+	})
+}
+
+// dropScopeRole godoc
+// Drops the scope role of the user in the current database, if it exists
+func (pc *PostgresConnector) dropScopeRole(ctx context.Context, db *sql.DB, username string) error {
+	scopeRole := postgresScopeRoleName(username, pc.Database())
+	exists, err := postgresUserExists(ctx, db, scopeRole)
+	if err != nil || !exists {
+		return err
+	}
+	// DROP OWNED revokes the privileges of the role in the current database, which is the only one it has any
+	if _, err = pc.writer(db).ExecContext(ctx, fmt.Sprintf(`DROP OWNED BY %s`, quotePostgresIdentifier(scopeRole))); err != nil {
+		return err
+	}
+	_, err = pc.writer(db).ExecContext(ctx, fmt.Sprintf(`DROP ROLE IF EXISTS %s`, quotePostgresIdentifier(scopeRole)))
+	return err
+}
+
+// setupDatabaseRole godoc
+// Sets up the database scoped role of the Data Guard role in the current database (e.g. developer_orders) from the
+// privileges the role holds in the database, returning its name
+func (pc *PostgresConnector) setupDatabaseRole(ctx context.Context, db *sql.DB, role string) (string, error) {
+	setupFunction, err := storage.ReadSQLFile(filepath.Join(sqlFilePath, "setup_grants_database_role.sql"))
+	if err != nil {
+		return "", err
+	}
+	databaseRole := postgresDatabaseRoleName(role, pc.Database())
+	params := map[string]string{"role_name": role, "database_role_name": databaseRole}
+	err = retryOnConcurrentError(fmt.Sprintf("setup of role '%s' in '%s'", databaseRole, pc.Database()), func() error {
+		return pc.executeDoBlock(ctx, db, setupFunction, params)
+	})
+	return databaseRole, err
+}
+
+// GrantRole godoc
+// Grants to the user the database scoped role of the Data Guard role in the current database, in addition to its own
+// role, so the privileges of the role apply to the current database only
+func (pc *PostgresConnector) GrantRole(username, role string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		databaseRole, err := pc.setupDatabaseRole(ctx, db, role)
+		if err != nil {
+			return err
+		}
+		_, err = pc.writer(db).ExecContext(ctx, buildPostgresGrantRoleStatement(databaseRole, username))
+		return err
+	})
+}
+
+// RevokeRole godoc
+// Revokes from the user the database scoped role of the Data Guard role in the current database granted by GrantRole.
+// The own role of the user is a membership of the whole instance, so it is kept.
+func (pc *PostgresConnector) RevokeRole(username, role string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		_, err := pc.writer(db).ExecContext(ctx, buildPostgresRevokeRoleStatement(postgresDatabaseRoleName(role, pc.Database()), username))
 		return err
 	})
 }
 
+// ChangeUserRole godoc
+// Grants the new Data Guard role to the user and revokes the old one in a single transaction. The membership is of the
+// instance, so the databases are not needed: the privileges of the new role apply to all of them at once.
+func (pc *PostgresConnector) ChangeUserRole(username, oldRole, newRole string, _ []string) error {
+	return pc.executeWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) error {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		if _, err = pc.writer(tx).ExecContext(ctx, buildPostgresGrantRoleStatement(newRole, username)); err != nil {
+			return err
+		}
+		// Revoking a role the user is not a member of only raises a warning, so a change run again doesn't fail
+		if _, err = pc.writer(tx).ExecContext(ctx, buildPostgresRevokeRoleStatement(oldRole, username)); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
 // RevokeUserPrivilegesAndRemove godoc
 // Revokes all privileges from a user and removes it from the database
 // It's necessary to revoke all privileges before removing the user, but if the user owns objects, it's necessary to transfer ownership to another user before removing it.
@@ -174,13 +347,14 @@ func (pc *PostgresConnector) RevokeUserPrivilegesAndRemove(username string) erro
 		if err != nil {
 			return err
 		}
-		stmt := fmt.Sprintf(removeUserFunc, username)
-		_, err = db.ExecContext(ctx, stmt)
-		if err != nil {
+		if err = pc.executeDoBlock(ctx, db, removeUserFunc, map[string]string{"user_to_drop": username}); err != nil {
 			return err
 		}
+		if pc.recorder != nil {
+			return nil
+		}
 
-		userStillExists, err := pc.UserExists(username)
+		userStillExists, err := postgresUserExists(ctx, db, username)
 		if err != nil {
 			return err
 		}
@@ -191,6 +365,33 @@ func (pc *PostgresConnector) RevokeUserPrivilegesAndRemove(username string) erro
 	})
 }
 
+// ListLogins godoc
+// Lists the roles able to log in with the roles granted to them, except the built-in pg_* roles and the admin user of
+// the instance, which is the one Data Guard connects with
+func (pc *PostgresConnector) ListLogins() ([]*Login, error) {
+	result, err := pc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		return listPostgresLogins(ctx, db, pc.ConnectionData.User)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*Login), nil
+}
+
+// FindUserAccess godoc
+// Reads the databases the user can connect to with has_database_privilege, which also counts the grants to PUBLIC and
+// to the roles of the user, and the Data Guard roles the user is a member of, in the whole instance or through the
+// database scoped roles. Returns nil when the user doesn't exist.
+func (pc *PostgresConnector) FindUserAccess(username string) (*UserAccess, error) {
+	result, err := pc.queryWithTimeout(context.Background(), func(ctx context.Context, db *sql.DB) (any, error) {
+		return findPostgresUserAccess(ctx, db, username)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*UserAccess), nil
+}
+
 func (pc *PostgresConnector) Driver() string {
 	return "postgres"
 }
@@ -208,27 +409,74 @@ func (pc *PostgresConnector) Database() string {
 }
 
 func (pc *PostgresConnector) URL() string {
-	return fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=disable",
+	return fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
 		url.QueryEscape(pc.ConnectionData.User),
 		url.QueryEscape(pc.ConnectionData.Password),
 		pc.ConnectionData.Host,
 		pc.ConnectionData.Port,
 		pc.Database(),
+		pc.sslMode(),
 	)
 }
 
+// sslMode godoc
+// Any mode other than disable uses the TLS configuration registered in lib/pq by newDriverConnector, see buildTLSConfig
+func (pc *PostgresConnector) sslMode() string {
+	if !tlsEnabled(pc.ConnectionData) {
+		return string(entity.SSLModeDisable)
+	}
+	return "pqgo-" + tlsConfigKey(pc.ConnectionData)
+}
+
+// setRecorder godoc
+// Records the statements that change the instance instead of executing them, for the dry run
+func (pc *PostgresConnector) setRecorder(recorder *StatementRecorder) {
+	pc.recorder = recorder
+}
+
+// writer godoc
+// Returns where the statements that change the instance are executed: exec itself, or the recorder in dry run
+func (pc *PostgresConnector) writer(exec sqlExecutor) sqlExecutor {
+	if pc.recorder != nil {
+		return pc.recorder.executor(pc.Database(), renderPostgresStatement)
+	}
+	return exec
+}
+
+// acquireDB godoc
+// Returns the pool of the database kept by the connection manager and the function that must be called after using it
+func (pc *PostgresConnector) acquireDB() (*sql.DB, func(), error) {
+	return connections().acquire(pc.ConnectionData, pc.Database(), pc.URL(), pc.newDriverConnector)
+}
+
+func (pc *PostgresConnector) newDriverConnector() (driver.Connector, error) {
+	if _, err := registerTLSConfig(pc.ConnectionData, pq.RegisterTLSConfig); err != nil {
+		return nil, err
+	}
+	pqConnector, err := pq.NewConnector(pc.URL())
+	if err != nil {
+		return nil, err
+	}
+	if pc.tunnel != nil {
+		pqConnector.Dialer(pc.tunnel)
+	}
+	return pqConnector, nil
+}
+
 func (pc *PostgresConnector) executeWithTimeout(ctx context.Context, operation func(context.Context, *sql.DB) error) error {
-	dbConn, err := sql.Open(pc.Driver(), pc.URL())
+	dbConn, release, err := pc.acquireDB()
 	if err != nil {
 		return err
 	}
-	defer dbConn.Close()
 
 	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
 	defer cancel()
 
 	result := make(chan error, 1)
-	go func() { result <- operation(ctx, dbConn) }()
+	go func() {
+		defer release()
+		result <- operation(ctx, dbConn)
+	}()
 
 	select {
 	case <-ctx.Done():
@@ -244,11 +492,10 @@ func (pc *PostgresConnector) executeWithTimeout(ctx context.Context, operation f
 }
 
 func (pc *PostgresConnector) queryWithTimeout(ctx context.Context, operation func(context.Context, *sql.DB) (any, error)) (any, error) {
-	dbConn, err := sql.Open(pc.Driver(), pc.URL())
+	dbConn, release, err := pc.acquireDB()
 	if err != nil {
 		return nil, err
 	}
-	defer dbConn.Close()
 
 	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
 	defer cancel()
@@ -256,6 +503,7 @@ func (pc *PostgresConnector) queryWithTimeout(ctx context.Context, operation fun
 	result := make(chan any, 1)
 	errChan := make(chan error, 1)
 	go func() {
+		defer release()
 		res, err := operation(ctx, dbConn)
 		if err != nil {
 			errChan <- err
@@ -274,27 +522,445 @@ func (pc *PostgresConnector) queryWithTimeout(ctx context.Context, operation fun
 	}
 }
 
-func listDatabasesWithTimeout(ctx context.Context, db *sql.DB, errorChan chan<- error, databasesChan chan<- []*Database) {
+func listDatabases(ctx context.Context, db *sql.DB) ([]*Database, error) {
 	rows, err := db.QueryContext(ctx, "SELECT datname AS name, PG_SIZE_PRETTY(PG_DATABASE_SIZE(datname)) AS current_size FROM pg_database WHERE datistemplate = FALSE AND datname != 'postgres'")
 	if err != nil {
-		errorChan <- err
-		return
+		return nil, err
 	}
 	defer rows.Close()
 
 	var databases []*Database
 	for rows.Next() {
 		var database Database
-		err := rows.Scan(&database.Name, &database.CurrentSize)
-		if err != nil {
-			errorChan <- err
-			return
+		if err := rows.Scan(&database.Name, &database.CurrentSize); err != nil {
+			return nil, err
 		}
 		databases = append(databases, &database)
 	}
-	databasesChan <- databases
+	return databases, rows.Err()
+}
+
+func listPostgresLogins(ctx context.Context, db *sql.DB, adminUser string) ([]*Login, error) {
+	databases, err := listDatabases(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	managedRoles := newManagedRoleNames(databases, postgresDatabaseRoleName)
+	query := `
+SELECT r.rolname,
+	r.rolsuper,
+	r.rolcreaterole,
+	r.rolbypassrls,
+	ARRAY(SELECT g.rolname FROM pg_auth_members m JOIN pg_roles g ON g.oid = m.roleid WHERE m.member = r.oid ORDER BY g.rolname)
+FROM pg_roles r
+WHERE r.rolcanlogin
+	AND r.rolname NOT LIKE 'pg\_%'
+	AND r.rolname != $1
+ORDER BY r.rolname`
+	rows, err := db.QueryContext(ctx, query, adminUser)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logins []*Login
+	for rows.Next() {
+		var login Login
+		var roles []string
+		if err := rows.Scan(&login.Name, &login.Superuser, &login.CreateRole, &login.BypassRLS, pq.Array(&roles)); err != nil {
+			return nil, err
+		}
+		login.UnmanagedRoles = managedRoles.unmanagedRoles(roles, postgresScopeRoleNames(login.Name, databases)...)
+		logins = append(logins, &login)
+	}
+	return logins, rows.Err()
+}
+
+func findPostgresUserAccess(ctx context.Context, db *sql.DB, username string) (*UserAccess, error) {
+	var memberships []string
+	err := db.QueryRowContext(ctx, `
+SELECT ARRAY(SELECT g.rolname FROM pg_auth_members m JOIN pg_roles g ON g.oid = m.roleid WHERE m.member = r.oid)
+FROM pg_roles r
+WHERE r.rolname = $1`, username).Scan(pq.Array(&memberships))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The default privileges of a database (NULL datacl) grant CONNECT to PUBLIC
+	query := `
+SELECT d.datname,
+	has_database_privilege($1, d.datname, 'CONNECT'),
+	EXISTS(SELECT 1 FROM aclexplode(COALESCE(d.datacl, acldefault('d', d.datdba))) a WHERE a.grantee = 0 AND a.privilege_type = 'CONNECT')
+FROM pg_database d
+WHERE d.datallowconn
+	AND NOT d.datistemplate
+ORDER BY d.datname`
+	rows, err := db.QueryContext(ctx, query, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var databases []string
+	access := &UserAccess{}
+	for rows.Next() {
+		var databaseName string
+		var canConnect, public bool
+		if err := rows.Scan(&databaseName, &canConnect, &public); err != nil {
+			return nil, err
+		}
+		if canConnect {
+			databases = append(databases, databaseName)
+		}
+		if public {
+			access.PublicDatabases = append(access.PublicDatabases, databaseName)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	access.RolesByDatabase = postgresRolesByDatabase(memberships, databases)
+	return access, nil
+}
+
+// postgresRolesByDatabase godoc
+// Returns the Data Guard roles the user has in each database, by its memberships: a Data Guard role applies to every
+// database, while a database scoped role (see postgresDatabaseRoleName) applies to its database only
+func postgresRolesByDatabase(memberships, databases []string) map[string][]string {
+	rolesByDatabase := make(map[string][]string, len(databases))
+	for _, databaseName := range databases {
+		roles := make([]string, 0)
+		for _, role := range entity.KnownRoleNames() {
+			if slices.Contains(memberships, string(role)) || slices.Contains(memberships, postgresDatabaseRoleName(string(role), databaseName)) {
+				roles = append(roles, string(role))
+			}
+		}
+		rolesByDatabase[databaseName] = roles
+	}
+	return rolesByDatabase
+}
+
+// listPostgresSchemas godoc
+// Lists the schemas of the current database, except the system ones (pg_catalog, pg_toast, information_schema...)
+func listPostgresSchemas(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT nspname FROM pg_namespace WHERE nspname != 'information_schema' AND nspname NOT LIKE 'pg\_%' ORDER BY nspname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, rows.Err()
+}
+
+// listPostgresTypes godoc
+// Lists the domains, enums and ranges of the current database. Composite types belong to tables and base types to
+// extensions, so they are left out.
+func listPostgresTypes(ctx context.Context, db *sql.DB) ([]postgresType, error) {
+	query := `
+SELECT n.nspname, t.typname
+FROM pg_type t
+	JOIN pg_namespace n
+		ON n.oid = t.typnamespace
+WHERE t.typtype IN ('d', 'e', 'r')
+  AND n.nspname != 'information_schema'
+  AND n.nspname NOT LIKE 'pg\_%'
+ORDER BY n.nspname, t.typname`
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []postgresType
+	for rows.Next() {
+		var pgType postgresType
+		if err := rows.Scan(&pgType.Schema, &pgType.Name); err != nil {
+			return nil, err
+		}
+		types = append(types, pgType)
+	}
+	return types, rows.Err()
+}
+
+func grantPostgresConnect(ctx context.Context, exec sqlExecutor, databaseName, username string) error {
+	stmt := buildPostgresGrantConnectStatement(databaseName, username)
+	return retryOnConcurrentError(fmt.Sprintf("grant connect to '%s' in '%s'", username, databaseName), func() error {
+		_, err := exec.ExecContext(ctx, stmt)
+		return err
+	})
+}
+
+// revokeOtherPostgresDatabaseRoles godoc
+// Revokes from the user the database scoped roles of the current database other than the one of the given role
+func revokeOtherPostgresDatabaseRoles(ctx context.Context, db *sql.DB, exec sqlExecutor, databaseName, username, role string) error {
+	var otherRoles []string
+	for _, dataGuardRole := range entity.KnownRoleNames() {
+		if string(dataGuardRole) != role {
+			otherRoles = append(otherRoles, postgresDatabaseRoleName(string(dataGuardRole), databaseName))
+		}
+	}
+	return revokePostgresMemberships(ctx, db, exec, username, otherRoles)
+}
+
+// revokePostgresMemberships godoc
+// Revokes from the user the given roles it is a member of. The memberships are read from db and revoked through exec.
+func revokePostgresMemberships(ctx context.Context, db *sql.DB, exec sqlExecutor, username string, roles []string) error {
+	query := `
+SELECT r.rolname
+FROM pg_auth_members m
+	JOIN pg_roles r
+		ON m.roleid = r.oid
+	JOIN pg_roles u
+		ON m.member = u.oid
+WHERE u.rolname = $1
+  AND r.rolname = ANY ($2)`
+	rows, err := db.QueryContext(ctx, query, username, pq.Array(roles))
+	if err != nil {
+		return err
+	}
+	var memberships []string
+	for rows.Next() {
+		var roleName string
+		if err = rows.Scan(&roleName); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		memberships = append(memberships, roleName)
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, roleName := range memberships {
+		if _, err = exec.ExecContext(ctx, buildPostgresRevokeRoleStatement(roleName, username)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderPostgresStatement godoc
+// Replaces the placeholders of the statement by its args quoted as literals, so a recorded statement can be run as is
+func renderPostgresStatement(query string, args []any) string {
+	for i := len(args); i > 0; i-- {
+		query = strings.ReplaceAll(query, fmt.Sprintf("$%d", i), pq.QuoteLiteral(fmt.Sprint(args[i-1])))
+	}
+	return query
+}
+
+func postgresUserExists(ctx context.Context, db *sql.DB, username string) (bool, error) {
+	var exists bool
+	err := db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM pg_roles WHERE rolname=$1)`, username).Scan(&exists)
+	return exists, err
+}
+
+// executeDoBlock godoc
+// DO blocks can't receive parameters, so each param is set as a transaction scoped setting named zg_data_guard.<param>
+// and read by the block with current_setting. This way no value is ever interpolated into the block.
+func (pc *PostgresConnector) executeDoBlock(ctx context.Context, db *sql.DB, block string, params map[string]string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := pc.writer(tx).ExecContext(ctx, `SELECT set_config($1, $2, true)`, postgresParamPrefix+name, params[name]); err != nil {
+			return err
+		}
+	}
+	if _, err := pc.writer(tx).ExecContext(ctx, block); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// buildPostgresSetupGrantsStatements godoc
+// Builds the statements that leave each role with exactly its privileges on the database and on the existing and
+// future objects of the schemas. PUBLIC loses CONNECT on the database and CREATE on the schemas, so only the users with
+// access can connect. The privileges on existing types are only granted, since PUBLIC has USAGE on them anyway.
+func buildPostgresSetupGrantsStatements(databaseName string, schemas []string, types []postgresType, roles []*DatabaseRole) []string {
+	database := quotePostgresIdentifier(databaseName)
+	stmts := []string{fmt.Sprintf(`REVOKE CONNECT ON DATABASE %s FROM PUBLIC`, database)}
+	for _, role := range roles {
+		stmts = append(stmts, buildPostgresPrivilegesStatements("", "DATABASE "+database, role, "database", role.Privileges.Database)...)
+	}
+	for _, schemaName := range schemas {
+		schema := quotePostgresIdentifier(schemaName)
+		stmts = append(stmts, fmt.Sprintf(`REVOKE CREATE ON SCHEMA %s FROM PUBLIC`, schema))
+		defaultPrefix := fmt.Sprintf(`ALTER DEFAULT PRIVILEGES IN SCHEMA %s `, schema)
+		for _, role := range roles {
+			privileges := role.Privileges
+			stmts = append(stmts, buildPostgresPrivilegesStatements("", "SCHEMA "+schema, role, "schema", privileges.Schema)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements("", "ALL TABLES IN SCHEMA "+schema, role, "table", privileges.Table)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements("", "ALL SEQUENCES IN SCHEMA "+schema, role, "sequence", privileges.Sequence)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements("", "ALL FUNCTIONS IN SCHEMA "+schema, role, "function", privileges.Function)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements(defaultPrefix, "TABLES", role, "table", privileges.Default.Table)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements(defaultPrefix, "SEQUENCES", role, "sequence", privileges.Default.Sequence)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements(defaultPrefix, "FUNCTIONS", role, "function", privileges.Default.Function)...)
+			stmts = append(stmts, buildPostgresPrivilegesStatements(defaultPrefix, "TYPES", role, "type", privileges.Default.Type)...)
+		}
+	}
+	for _, role := range roles {
+		if len(role.Privileges.Type) == 0 {
+			continue
+		}
+		for _, pgType := range types {
+			stmts = append(stmts, fmt.Sprintf(`GRANT %s ON TYPE %s.%s TO %s`, strings.Join(role.Privileges.Type, ", "),
+				quotePostgresIdentifier(pgType.Schema), quotePostgresIdentifier(pgType.Name), quotePostgresIdentifier(string(role.Name))))
+		}
+	}
+	return stmts
+}
+
+// buildPostgresPrivilegesStatements godoc
+// Revokes from the role the privileges allowed on the objects that it doesn't have and grants the ones it has
+func buildPostgresPrivilegesStatements(prefix, objects string, role *DatabaseRole, objectType string, privileges []string) []string {
+	roleName := quotePostgresIdentifier(string(role.Name))
+	var stmts []string
+	var revoked []string
+	for _, privilege := range entity.AllowedPrivileges(objectType) {
+		if !slices.Contains(privileges, privilege) {
+			revoked = append(revoked, privilege)
+		}
+	}
+	if len(revoked) > 0 {
+		stmts = append(stmts, fmt.Sprintf(`%sREVOKE %s ON %s FROM %s`, prefix, strings.Join(revoked, ", "), objects, roleName))
+	}
+	if len(privileges) > 0 {
+		stmts = append(stmts, fmt.Sprintf(`%sGRANT %s ON %s TO %s`, prefix, strings.Join(privileges, ", "), objects, roleName))
+	}
+	return stmts
+}
+
+// buildPostgresScopeGrantsStatements godoc
+// Builds the statements that leave the scope role with the privileges of the Data Guard role on the database, on the
+// existing and future objects of the schemas of the scope and on its tables. The privileges the scope role had in the
+// database are dropped first. Tables without schema are the ones of the public schema, as in the default search path.
+func buildPostgresScopeGrantsStatements(databaseName, scopeRole string, role *DatabaseRole, scope entity.AccessScope) []string {
+	privileges := role.Privileges
+	grant := func(prefix string, rolePrivileges []string, objects string) []string {
+		if len(rolePrivileges) == 0 {
+			return nil
+		}
+		return []string{fmt.Sprintf(`%sGRANT %s ON %s TO %s`, prefix, strings.Join(rolePrivileges, ", "), objects, quotePostgresIdentifier(scopeRole))}
+	}
+
+	stmts := []string{fmt.Sprintf(`DROP OWNED BY %s`, quotePostgresIdentifier(scopeRole))}
+	stmts = append(stmts, grant("", privileges.Database, "DATABASE "+quotePostgresIdentifier(databaseName))...)
+	for _, schemaName := range scope.Schemas {
+		schema := quotePostgresIdentifier(schemaName)
+		defaultPrefix := fmt.Sprintf(`ALTER DEFAULT PRIVILEGES IN SCHEMA %s `, schema)
+		stmts = append(stmts, grant("", privileges.Schema, "SCHEMA "+schema)...)
+		stmts = append(stmts, grant("", privileges.Table, "ALL TABLES IN SCHEMA "+schema)...)
+		stmts = append(stmts, grant("", privileges.Sequence, "ALL SEQUENCES IN SCHEMA "+schema)...)
+		stmts = append(stmts, grant("", privileges.Function, "ALL FUNCTIONS IN SCHEMA "+schema)...)
+		stmts = append(stmts, grant(defaultPrefix, privileges.Default.Table, "TABLES")...)
+		stmts = append(stmts, grant(defaultPrefix, privileges.Default.Sequence, "SEQUENCES")...)
+		stmts = append(stmts, grant(defaultPrefix, privileges.Default.Function, "FUNCTIONS")...)
+		stmts = append(stmts, grant(defaultPrefix, privileges.Default.Type, "TYPES")...)
+	}
+	var tablesSchemas []string
+	for _, table := range scope.Tables {
+		schemaName, tableName, found := strings.Cut(table, ".")
+		if !found {
+			schemaName, tableName = "public", table
+		}
+		// The tables can only be reached with USAGE on their schema, which gives no access to its other objects
+		if !slices.Contains(scope.Schemas, schemaName) && !slices.Contains(tablesSchemas, schemaName) {
+			tablesSchemas = append(tablesSchemas, schemaName)
+			stmts = append(stmts, grant("", []string{"USAGE"}, "SCHEMA "+quotePostgresIdentifier(schemaName))...)
+		}
+		stmts = append(stmts, grant("", privileges.Table, fmt.Sprintf("TABLE %s.%s", quotePostgresIdentifier(schemaName), quotePostgresIdentifier(tableName)))...)
+	}
+	return stmts
+}
+
+func buildPostgresCreateUserStatement(user *DatabaseUser) string {
+	return fmt.Sprintf(`CREATE USER %s WITH LOGIN PASSWORD %s IN ROLE %s`,
+		quotePostgresIdentifier(user.Username),
+		quotePostgresLiteral(user.Password),
+		quotePostgresIdentifier(user.Role),
+	)
+}
+
+func buildPostgresGrantConnectStatement(databaseName, username string) string {
+	return fmt.Sprintf(`GRANT CONNECT ON DATABASE %s TO %s`, quotePostgresIdentifier(databaseName), quotePostgresIdentifier(username))
+}
+
+func buildPostgresRevokeConnectStatement(databaseName, username string) string {
+	return fmt.Sprintf(`REVOKE CONNECT ON DATABASE %s FROM %s`, quotePostgresIdentifier(databaseName), quotePostgresIdentifier(username))
+}
+
+func buildPostgresGrantRoleStatement(role, username string) string {
+	return fmt.Sprintf(`GRANT %s TO %s`, quotePostgresIdentifier(role), quotePostgresIdentifier(username))
+}
+
+func buildPostgresRevokeRoleStatement(role, username string) string {
+	return fmt.Sprintf(`REVOKE %s FROM %s`, quotePostgresIdentifier(role), quotePostgresIdentifier(username))
+}
+
+// postgresDatabaseRoleName godoc
+// Returns the name of the role that holds the privileges of a Data Guard role in a database only.
+// PostgreSQL limits identifiers to 63 bytes, so long names are shortened with a hash of the database name.
+func postgresDatabaseRoleName(role, databaseName string) string {
+	return databaseRoleName(role, databaseName, postgresMaxRoleNameSize)
+}
+
+// postgresScopeRoleName godoc
+// Returns the name of the role that holds the privileges of the scope of a user in a database, e.g.
+// dg_scope_1a2b3c4d5e6f7a8b. The name is a hash, since the user and database names together may exceed the limit.
+func postgresScopeRoleName(username, databaseName string) string {
+	return hashKey(scopeRolePrefix, username, databaseName)
+}
+
+// postgresScopeRoleNames godoc
+// Returns the names of the scope roles the user may have, one for each database
+func postgresScopeRoleNames(username string, databases []*Database) []string {
+	names := make([]string, 0, len(databases))
+	for _, database := range databases {
+		names = append(names, postgresScopeRoleName(username, database.Name))
+	}
+	return names
+}
+
+// retryOnConcurrentError godoc
+// Runs the operation again when it fails because another session changed the same catalog rows at the same time,
+// which happens when the same database or role is granted to many users in parallel
+func retryOnConcurrentError(operation string, fn func() error) error {
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		err = fn()
+		if err != nil && isConcurrentError(err) {
+			log.Printf("Concurrent error detected on %s, retrying... [%d/%d]", operation, i+1, maxRetries)
+			time.Sleep(retryInterval)
+			continue
+		}
+		break
+	}
+	return err
 }
 
+// isConcurrentError godoc
+// Checks for concurrent updates of a catalog row, or the creation of the same role by another session
 func isConcurrentError(err error) bool {
-	return strings.Contains(err.Error(), "tuple concurrently updated")
+	return strings.Contains(err.Error(), "tuple concurrently updated") ||
+		strings.Contains(err.Error(), `duplicate key value violates unique constraint "pg_authid_rolname_index"`)
 }
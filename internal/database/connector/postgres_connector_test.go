@@ -0,0 +1,114 @@
+package connector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+func TestGivenLongDatabaseName_WhenBuildPostgresDatabaseRoleName_ThenShouldRespectIdentifierMaxSize(t *testing.T) {
+	databaseName := strings.Repeat("billing_", 10)
+
+	roleName := postgresDatabaseRoleName("developer", databaseName)
+
+	assert.Len(t, roleName, postgresMaxRoleNameSize)
+	assert.True(t, strings.HasPrefix(roleName, "developer_"))
+	assert.Equal(t, "developer_orders", postgresDatabaseRoleName("developer", "orders"))
+}
+
+func TestGivenDeveloperRole_WhenBuildPostgresSetupGrantsStatements_ThenShouldGrantItsPrivilegesAndRevokeTheOthers(t *testing.T) {
+	developer := buildDataGuardRoles()[1]
+
+	stmts := buildPostgresSetupGrantsStatements("orders", []string{"public"}, nil, []*DatabaseRole{developer})
+
+	assert.Equal(t, []string{
+		`REVOKE CONNECT ON DATABASE "orders" FROM PUBLIC`,
+		`REVOKE CREATE, TEMP ON DATABASE "orders" FROM "developer"`,
+		`REVOKE CREATE ON SCHEMA "public" FROM PUBLIC`,
+		`REVOKE CREATE ON SCHEMA "public" FROM "developer"`,
+		`GRANT USAGE ON SCHEMA "public" TO "developer"`,
+		`REVOKE TRUNCATE, REFERENCES, TRIGGER ON ALL TABLES IN SCHEMA "public" FROM "developer"`,
+		`GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA "public" TO "developer"`,
+		`REVOKE UPDATE ON ALL SEQUENCES IN SCHEMA "public" FROM "developer"`,
+		`GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA "public" TO "developer"`,
+		`GRANT EXECUTE ON ALL FUNCTIONS IN SCHEMA "public" TO "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" REVOKE TRUNCATE, REFERENCES, TRIGGER ON TABLES FROM "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" REVOKE UPDATE ON SEQUENCES FROM "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT USAGE, SELECT ON SEQUENCES TO "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT EXECUTE ON FUNCTIONS TO "developer"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT USAGE ON TYPES TO "developer"`,
+	}, stmts)
+}
+
+func TestGivenRoleWithTypePrivileges_WhenBuildPostgresSetupGrantsStatements_ThenShouldGrantThemOnEachType(t *testing.T) {
+	role := &DatabaseRole{Name: "reporting", Privileges: entity.RolePrivileges{Table: []string{"SELECT"}, Type: []string{"USAGE"}}}
+
+	stmts := buildPostgresSetupGrantsStatements("orders", nil, []postgresType{{Schema: "public", Name: "status"}}, []*DatabaseRole{role})
+
+	assert.Contains(t, stmts, `GRANT USAGE ON TYPE "public"."status" TO "reporting"`)
+}
+
+func TestGivenScopeWithSchemasAndTables_WhenBuildPostgresScopeGrantsStatements_ThenShouldGrantTheRoleOnlyOnThem(t *testing.T) {
+	userRO := buildDataGuardRoles()[0]
+	scope := entity.AccessScope{Schemas: []string{"reporting"}, Tables: []string{"sales.orders", "customers", "reporting.daily"}}
+
+	stmts := buildPostgresScopeGrantsStatements("orders", "dg_scope_1", userRO, scope)
+
+	assert.Equal(t, []string{
+		`DROP OWNED BY "dg_scope_1"`,
+		`GRANT USAGE ON SCHEMA "reporting" TO "dg_scope_1"`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "reporting" TO "dg_scope_1"`,
+		`GRANT SELECT ON ALL SEQUENCES IN SCHEMA "reporting" TO "dg_scope_1"`,
+		`GRANT EXECUTE ON ALL FUNCTIONS IN SCHEMA "reporting" TO "dg_scope_1"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "reporting" GRANT SELECT ON TABLES TO "dg_scope_1"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "reporting" GRANT SELECT ON SEQUENCES TO "dg_scope_1"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "reporting" GRANT EXECUTE ON FUNCTIONS TO "dg_scope_1"`,
+		`ALTER DEFAULT PRIVILEGES IN SCHEMA "reporting" GRANT USAGE ON TYPES TO "dg_scope_1"`,
+		`GRANT USAGE ON SCHEMA "sales" TO "dg_scope_1"`,
+		`GRANT SELECT ON TABLE "sales"."orders" TO "dg_scope_1"`,
+		`GRANT USAGE ON SCHEMA "public" TO "dg_scope_1"`,
+		`GRANT SELECT ON TABLE "public"."customers" TO "dg_scope_1"`,
+		`GRANT SELECT ON TABLE "reporting"."daily" TO "dg_scope_1"`,
+	}, stmts)
+}
+
+func TestGivenUserAndDatabase_WhenBuildPostgresScopeRoleName_ThenShouldBeDeterministicAndShort(t *testing.T) {
+	roleName := postgresScopeRoleName(strings.Repeat("john.doe", 10), "orders")
+
+	assert.True(t, strings.HasPrefix(roleName, "dg_scope_"))
+	assert.LessOrEqual(t, len(roleName), postgresMaxRoleNameSize)
+	assert.Equal(t, roleName, postgresScopeRoleName(strings.Repeat("john.doe", 10), "orders"))
+	assert.NotEqual(t, roleName, postgresScopeRoleName(strings.Repeat("john.doe", 10), "billing"))
+}
+
+func TestGivenRolesOfTheInstance_WhenFilterUnmanagedRoles_ThenShouldKeepOnlyTheOnesNotCreatedByDataGuard(t *testing.T) {
+	databases := []*Database{{Name: "orders"}}
+	managedRoles := newManagedRoleNames(databases, postgresDatabaseRoleName)
+	roles := []string{"developer", "user_ro_orders", postgresScopeRoleName("johndoe", "orders"), "pg_read_all_data", "reporting_admin"}
+
+	assert.Equal(t, []string{"pg_read_all_data", "reporting_admin"}, managedRoles.unmanagedRoles(roles, postgresScopeRoleNames("johndoe", databases)...))
+	assert.Nil(t, managedRoles.unmanagedRoles([]string{"devops"}))
+}
+
+func TestGivenRolesThatOnlyLookLikeTheOnesOfDataGuard_WhenFilterUnmanagedRoles_ThenShouldKeepThem(t *testing.T) {
+	databases := []*Database{{Name: "orders"}}
+	managedRoles := newManagedRoleNames(databases, postgresDatabaseRoleName)
+	roles := []string{"devops_admin", "application_owner", "developer_billing", "devops_orders", postgresScopeRoleName("janedoe", "orders")}
+
+	unmanaged := managedRoles.unmanagedRoles(roles, postgresScopeRoleNames("johndoe", databases)...)
+
+	assert.Equal(t, []string{"devops_admin", "application_owner", "developer_billing", postgresScopeRoleName("janedoe", "orders")}, unmanaged)
+}
+
+func TestGivenMembershipsOfTheUser_WhenBuildPostgresRolesByDatabase_ThenShouldApplyTheInstanceRolesToEveryDatabase(t *testing.T) {
+	memberships := []string{"developer", postgresDatabaseRoleName("user_ro", "orders"), "reporting_admin"}
+
+	rolesByDatabase := postgresRolesByDatabase(memberships, []string{"orders", "logs"})
+
+	assert.ElementsMatch(t, []string{"developer", "user_ro"}, rolesByDatabase["orders"])
+	assert.Equal(t, []string{"developer"}, rolesByDatabase["logs"])
+}
@@ -0,0 +1,33 @@
+package connector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Every value coming from users, databases or roles must be quoted with these functions before being part of a
+// statement sent to a target instance. Values used inside PostgreSQL DO blocks are passed as parameters instead,
+// see PostgresConnector.executeDoBlock.
+
+func quotePostgresIdentifier(name string) string {
+	return pq.QuoteIdentifier(name)
+}
+
+func quotePostgresLiteral(value string) string {
+	return strings.TrimSpace(pq.QuoteLiteral(value))
+}
+
+func quoteMySQLIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteMySQLLiteral(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `''`, "\x00", `\0`, "\n", `\n`, "\r", `\r`, "\x1a", `\Z`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+func quoteMySQLAccount(name string) string {
+	return fmt.Sprintf("%s@%s", quoteMySQLLiteral(name), quoteMySQLLiteral(mysqlAnyHost))
+}
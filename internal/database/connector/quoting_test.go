@@ -0,0 +1,198 @@
+package connector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const benignValue = "john_doe"
+
+var hostileValues = []string{
+	`john"doe`,
+	`john'doe`,
+	`x'; DROP TABLE application_users; --`,
+	`x"; DROP ROLE postgres; --`,
+	`$$; DROP DATABASE orders; $$`,
+	`back\slash\'`,
+	`multi
+line`,
+	"tab\tand`backtick`",
+	`''""''`,
+	`%s %d %%I`,
+	`ação-ñandú-数据库`,
+	`*/ /* -- #`,
+}
+
+// token is a quoted identifier or literal found in a statement, with its value already unescaped
+type token struct {
+	kind  string
+	value string
+}
+
+// tokenizePostgres replaces every quoted identifier and literal of a statement with a placeholder, returning the
+// skeleton of the statement and the unescaped values found. Two statements with the same skeleton have the same
+// structure, no matter the values quoted inside them.
+func tokenizePostgres(t *testing.T, stmt string) (string, []token) {
+	var skeleton strings.Builder
+	var tokens []token
+	for i := 0; i < len(stmt); i++ {
+		switch {
+		case stmt[i] == '"':
+			value, end := readQuoted(t, stmt, i, '"', false)
+			tokens = append(tokens, token{kind: "identifier", value: value})
+			skeleton.WriteString("<identifier>")
+			i = end
+		case stmt[i] == 'E' && i+1 < len(stmt) && stmt[i+1] == '\'':
+			value, end := readQuoted(t, stmt, i+1, '\'', true)
+			tokens = append(tokens, token{kind: "literal", value: value})
+			skeleton.WriteString("<literal>")
+			i = end
+		case stmt[i] == '\'':
+			value, end := readQuoted(t, stmt, i, '\'', false)
+			tokens = append(tokens, token{kind: "literal", value: value})
+			skeleton.WriteString("<literal>")
+			i = end
+		default:
+			skeleton.WriteByte(stmt[i])
+		}
+	}
+	return skeleton.String(), tokens
+}
+
+// tokenizeMySQL does the same as tokenizePostgres for MySQL, where identifiers use backticks and literals always
+// accept backslash escapes
+func tokenizeMySQL(t *testing.T, stmt string) (string, []token) {
+	var skeleton strings.Builder
+	var tokens []token
+	for i := 0; i < len(stmt); i++ {
+		switch stmt[i] {
+		case '`':
+			value, end := readQuoted(t, stmt, i, '`', false)
+			tokens = append(tokens, token{kind: "identifier", value: value})
+			skeleton.WriteString("<identifier>")
+			i = end
+		case '\'':
+			value, end := readQuoted(t, stmt, i, '\'', true)
+			tokens = append(tokens, token{kind: "literal", value: value})
+			skeleton.WriteString("<literal>")
+			i = end
+		default:
+			skeleton.WriteByte(stmt[i])
+		}
+	}
+	return skeleton.String(), tokens
+}
+
+func readQuoted(t *testing.T, stmt string, start int, quote byte, backslashEscapes bool) (string, int) {
+	unescape := map[byte]string{'0': "\x00", 'n': "\n", 'r': "\r", 'Z': "\x1a", 't': "\t"}
+	var value strings.Builder
+	for i := start + 1; i < len(stmt); i++ {
+		switch {
+		case backslashEscapes && stmt[i] == '\\' && i+1 < len(stmt):
+			i++
+			if replacement, ok := unescape[stmt[i]]; ok {
+				value.WriteString(replacement)
+			} else {
+				value.WriteByte(stmt[i])
+			}
+		case stmt[i] == quote && i+1 < len(stmt) && stmt[i+1] == quote:
+			value.WriteByte(quote)
+			i++
+		case stmt[i] == quote:
+			return value.String(), i
+		default:
+			value.WriteByte(stmt[i])
+		}
+	}
+	t.Fatalf("unterminated quoted value starting at %d in statement: %s", start, stmt)
+	return "", len(stmt)
+}
+
+func assertSameStructure(t *testing.T, tokenize func(*testing.T, string) (string, []token), build func(string) string, hostile string) []token {
+	expectedSkeleton, _ := tokenize(t, build(benignValue))
+	stmt := build(hostile)
+	skeleton, tokens := tokenize(t, stmt)
+	assert.Equal(t, expectedSkeleton, skeleton, "statement structure changed with value %q: %s", hostile, stmt)
+	return tokens
+}
+
+func TestGivenHostileUsernameAndPassword_WhenBuildPostgresCreateUser_ThenStatementShouldStayWellFormed(t *testing.T) {
+	for _, hostile := range hostileValues {
+		tokens := assertSameStructure(t, tokenizePostgres, func(value string) string {
+			return buildPostgresCreateUserStatement(&DatabaseUser{Username: value, Password: value, Role: "developer"})
+		}, hostile)
+		assert.Equal(t, []token{{"identifier", hostile}, {"literal", hostile}, {"identifier", "developer"}}, tokens)
+	}
+}
+
+func TestGivenHostileDatabaseAndUsername_WhenBuildPostgresGrantAndRevokeConnect_ThenStatementsShouldStayWellFormed(t *testing.T) {
+	for _, build := range []func(string, string) string{buildPostgresGrantConnectStatement, buildPostgresRevokeConnectStatement} {
+		for _, hostile := range hostileValues {
+			tokens := assertSameStructure(t, tokenizePostgres, func(value string) string {
+				return build(value, value)
+			}, hostile)
+			assert.Equal(t, []token{{"identifier", hostile}, {"identifier", hostile}}, tokens)
+		}
+	}
+}
+
+func TestGivenHostileRoleAndUsername_WhenBuildPostgresGrantAndRevokeRole_ThenStatementsShouldStayWellFormed(t *testing.T) {
+	for _, build := range []func(string, string) string{buildPostgresGrantRoleStatement, buildPostgresRevokeRoleStatement} {
+		for _, hostile := range hostileValues {
+			tokens := assertSameStructure(t, tokenizePostgres, func(value string) string {
+				return build(value, value)
+			}, hostile)
+			assert.Equal(t, []token{{"identifier", hostile}, {"identifier", hostile}}, tokens)
+		}
+	}
+}
+
+func TestGivenPostgresDoBlocks_WhenReadTemplates_ThenNoValueShouldBeInterpolated(t *testing.T) {
+	files, err := filepath.Glob("scripts/postgres/*.sql")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(content), "%s", "template %s should read its values with current_setting", file)
+		assert.NotContains(t, string(content), "%%", "template %s is not formatted, %% must not be escaped", file)
+	}
+}
+
+func TestGivenHostileValues_WhenBuildMySQLStatements_ThenStatementsShouldStayWellFormed(t *testing.T) {
+	for _, hostile := range hostileValues {
+		tokens := assertSameStructure(t, tokenizeMySQL, func(value string) string {
+			return "CREATE USER " + quoteMySQLAccount(value) + " IDENTIFIED BY " + quoteMySQLLiteral(value)
+		}, hostile)
+		assert.Equal(t, []token{{"literal", hostile}, {"literal", "%"}, {"literal", hostile}}, tokens)
+
+		tokens = assertSameStructure(t, tokenizeMySQL, func(value string) string {
+			return strings.Join(buildMySQLGrantConnectStatements(value, value, []string{"SELECT"}), ";")
+		}, hostile)
+		assert.Equal(t, []token{{"literal", hostile}, {"literal", "%"}, {"identifier", hostile}, {"literal", hostile}, {"literal", "%"}}, tokens)
+	}
+}
+
+func TestGivenHostileDatabase_WhenBuildMySQLSetupGrants_ThenStatementShouldStayWellFormed(t *testing.T) {
+	for _, hostile := range hostileValues {
+		assertSameStructure(t, tokenizeMySQL, func(value string) string {
+			return strings.Join(buildMySQLSetupGrantsStatements(value, quoteMySQLAccount(value), []string{"DROP"}, []string{"SELECT"}), ";")
+		}, hostile)
+	}
+}
+
+func TestGivenHostileDatabaseAndSchema_WhenBuildPostgresSetupGrants_ThenStatementsShouldStayWellFormed(t *testing.T) {
+	for _, hostile := range hostileValues {
+		assertSameStructure(t, tokenizePostgres, func(value string) string {
+			roles := append(buildDataGuardRoles(), &DatabaseRole{Name: "reporting", Privileges: entity.RolePrivileges{Table: []string{"SELECT"}, Type: []string{"USAGE"}}})
+			types := []postgresType{{Schema: value, Name: value}}
+			return strings.Join(buildPostgresSetupGrantsStatements(value, []string{value}, types, roles), ";")
+		}, hostile)
+	}
+}
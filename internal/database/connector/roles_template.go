@@ -0,0 +1,38 @@
+package connector
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RolesTemplateVersion godoc
+// Version of the grants generated by the connectors from the privileges of the roles. Increase it whenever the grants
+// generated for any technology change, so the databases set up with the previous version are reported as outdated.
+const RolesTemplateVersion = 2
+
+// RolesTemplate godoc
+// Identifies the grants applied to the roles of a database: the version of the connectors and a checksum of the roles
+// and their privileges. A database is outdated when its roles were set up with another checksum.
+type RolesTemplate struct {
+	Version  int
+	Checksum string
+}
+
+func NewRolesTemplate(roles []*DatabaseRole) RolesTemplate {
+	sortedRoles := slices.Clone(roles)
+	slices.SortFunc(sortedRoles, func(a, b *DatabaseRole) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "version:%d\n", RolesTemplateVersion)
+	for _, role := range sortedRoles {
+		privileges, _ := json.Marshal(role.Privileges)
+		_, _ = fmt.Fprintf(hash, "%s:%s\n", role.Name, privileges)
+	}
+	return RolesTemplate{Version: RolesTemplateVersion, Checksum: hex.EncodeToString(hash.Sum(nil))}
+}
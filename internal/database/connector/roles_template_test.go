@@ -0,0 +1,32 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+func TestGivenTheSameRolesInAnotherOrder_WhenNewRolesTemplate_ThenShouldHaveTheSameChecksum(t *testing.T) {
+	roles := buildDataGuardRoles()
+	reversedRoles := []*DatabaseRole{roles[3], roles[2], roles[1], roles[0]}
+
+	template := NewRolesTemplate(roles)
+
+	assert.Equal(t, RolesTemplateVersion, template.Version)
+	assert.Len(t, template.Checksum, 64)
+	assert.Equal(t, template, NewRolesTemplate(reversedRoles))
+}
+
+func TestGivenRolesWithChangedPrivileges_WhenNewRolesTemplate_ThenShouldChangeTheChecksum(t *testing.T) {
+	roles := buildDataGuardRoles()
+	template := NewRolesTemplate(roles)
+
+	roles[0].Privileges.Table = append(roles[0].Privileges.Table, "REFERENCES")
+	changedTemplate := NewRolesTemplate(roles)
+	rolesWithNewRole := append(buildDataGuardRoles(), &DatabaseRole{Name: "analyst", Privileges: entity.RolePrivileges{Table: []string{"SELECT"}}})
+
+	assert.NotEqual(t, template.Checksum, changedTemplate.Checksum)
+	assert.NotEqual(t, template.Checksum, NewRolesTemplate(rolesWithNewRole).Checksum)
+}
@@ -0,0 +1 @@
+CREATE ROLE IF NOT EXISTS %s;
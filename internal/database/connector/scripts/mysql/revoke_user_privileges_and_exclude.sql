@@ -0,0 +1,2 @@
+-- DROP USER revokes every privilege and role held by the account before removing it
+DROP USER IF EXISTS %s;
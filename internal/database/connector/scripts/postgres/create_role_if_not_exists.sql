@@ -1,10 +1,10 @@
 DO
 $$
 	DECLARE
-		role_name text := '%s';
+		role_name text := current_setting('zg_data_guard.role_name');
 	BEGIN
 		IF NOT EXISTS (SELECT FROM pg_catalog.pg_roles WHERE rolname = role_name) THEN
-			EXECUTE FORMAT('CREATE ROLE %%I', role_name);
+			EXECUTE FORMAT('CREATE ROLE %I', role_name);
 		END IF;
 	END
 $$;
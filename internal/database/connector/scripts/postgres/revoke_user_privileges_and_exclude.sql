@@ -1,7 +1,7 @@
 DO
 $$
 	DECLARE
-		user_to_drop text := '%s';
+		user_to_drop text := current_setting('zg_data_guard.user_to_drop');
 		database_rec record;
 	BEGIN
 
@@ -12,9 +12,9 @@ $$
 							   AND pg_catalog.has_database_privilege((SELECT oid FROM pg_roles WHERE rolname = user_to_drop), oid, 'CONNECT')
 							 ORDER BY datname)
 			LOOP
-				EXECUTE FORMAT('REVOKE ALL PRIVILEGES ON DATABASE %%I FROM %%I', database_rec.name, user_to_drop);
+				EXECUTE FORMAT('REVOKE ALL PRIVILEGES ON DATABASE %I FROM %I', database_rec.name, user_to_drop);
 			END LOOP;
 
-		EXECUTE FORMAT('DROP USER IF EXISTS %%I', user_to_drop);
+		EXECUTE FORMAT('DROP USER IF EXISTS %I', user_to_drop);
 	END
 $$;
@@ -0,0 +1,74 @@
+-- Description: Script to set up the database scoped role of a Data Guard role (e.g. developer_orders) in the current
+-- database. The scoped role receives the privileges the Data Guard role holds in this database only, copied from their
+-- ACLs, so a user can have a role in a single database while role memberships apply to the whole instance.
+-- For further information, please check the README.
+DO
+$$
+	DECLARE
+		role_name          text := current_setting('zg_data_guard.role_name');
+		database_role_name text := current_setting('zg_data_guard.database_role_name');
+		role_oid           oid;
+		privilege          record;
+
+	BEGIN
+		SELECT oid INTO role_oid FROM pg_catalog.pg_roles WHERE rolname = role_name;
+		IF role_oid IS NULL THEN
+			RAISE EXCEPTION 'role "%" does not exist', role_name;
+		END IF;
+		IF NOT EXISTS (SELECT FROM pg_catalog.pg_roles WHERE rolname = database_role_name) THEN
+			EXECUTE FORMAT('CREATE ROLE %I', database_role_name);
+		END IF;
+
+		FOR privilege IN (SELECT acl.privilege_type
+						  FROM pg_catalog.pg_database d,
+							   ACLEXPLODE(d.datacl) acl
+						  WHERE d.datname = CURRENT_DATABASE()
+							AND acl.grantee = role_oid)
+			LOOP
+				EXECUTE FORMAT('GRANT ' || privilege.privilege_type || ' ON DATABASE %I TO %I', CURRENT_DATABASE(), database_role_name);
+			END LOOP;
+
+		FOR privilege IN (SELECT n.nspname, acl.privilege_type
+						  FROM pg_catalog.pg_namespace n,
+							   ACLEXPLODE(n.nspacl) acl
+						  WHERE acl.grantee = role_oid)
+			LOOP
+				EXECUTE FORMAT('GRANT ' || privilege.privilege_type || ' ON SCHEMA %I TO %I', privilege.nspname, database_role_name);
+			END LOOP;
+
+		FOR privilege IN (SELECT n.nspname, c.relname, c.relkind, acl.privilege_type
+						  FROM pg_catalog.pg_class c
+								   JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace,
+							   ACLEXPLODE(c.relacl) acl
+						  WHERE acl.grantee = role_oid)
+			LOOP
+				EXECUTE FORMAT('GRANT ' || privilege.privilege_type || ' ON ' ||
+							   CASE WHEN privilege.relkind = 'S' THEN 'SEQUENCE' ELSE 'TABLE' END || ' %I.%I TO %I',
+							   privilege.nspname, privilege.relname, database_role_name);
+			END LOOP;
+
+		-- The text of regprocedure is already quoted and qualified with the schema when needed
+		FOR privilege IN (SELECT p.oid::regprocedure AS routine, acl.privilege_type
+						  FROM pg_catalog.pg_proc p,
+							   ACLEXPLODE(p.proacl) acl
+						  WHERE acl.grantee = role_oid)
+			LOOP
+				EXECUTE 'GRANT ' || privilege.privilege_type || ' ON ROUTINE ' || privilege.routine || ' TO ' || QUOTE_IDENT(database_role_name);
+			END LOOP;
+
+		FOR privilege IN (SELECT PG_GET_USERBYID(da.defaclrole) AS owner_name, n.nspname, da.defaclobjtype, acl.privilege_type
+						  FROM pg_catalog.pg_default_acl da
+								   JOIN pg_catalog.pg_namespace n ON n.oid = da.defaclnamespace,
+							   ACLEXPLODE(da.defaclacl) acl
+						  WHERE acl.grantee = role_oid)
+			LOOP
+				EXECUTE FORMAT('ALTER DEFAULT PRIVILEGES FOR ROLE %I IN SCHEMA %I GRANT ' || privilege.privilege_type || ' ON ' ||
+							   CASE privilege.defaclobjtype
+								   WHEN 'r' THEN 'TABLES'
+								   WHEN 'S' THEN 'SEQUENCES'
+								   WHEN 'f' THEN 'FUNCTIONS'
+								   ELSE 'TYPES' END || ' TO %I',
+							   privilege.owner_name, privilege.nspname, database_role_name);
+			END LOOP;
+	END
+$$;
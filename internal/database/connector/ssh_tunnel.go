@@ -0,0 +1,218 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+const sshTunnelKeyPrefix = "zgdg-ssh-"
+
+var (
+	ErrWhileOpeningSSHTunnel   = errors.New("error occurred while attempting to open the SSH tunnel through the bastion")
+	ErrBastionHostKeyNotPinned = errors.New("the host key of the bastion is not pinned")
+
+	sshTunnelsMu sync.Mutex
+	sshTunnels   = make(map[string]*sshTunnel)
+	// instanceTunnels godoc
+	// Key of the tunnel used by each instance, to close the tunnels no instance uses anymore
+	instanceTunnels = make(map[string]string)
+)
+
+// sshTunnel godoc
+// In-process SSH tunnel through a bastion host. Every connection to the target is a direct-tcpip channel of a single
+// SSH connection, shared by all the connectors that use the same bastion, so the target address is resolved and dialed
+// by the bastion. When the SSH connection drops, it's dialed again on the next use. The tunnel is closed and removed
+// when the bastion settings of the instances using it change, see releaseSSHTunnel.
+type sshTunnel struct {
+	key       string
+	address   string
+	config    *ssh.ClientConfig
+	instances map[string]bool
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// openSSHTunnel godoc
+// Returns the tunnel through the bastion of the connection, opening the SSH connection if it's not open yet.
+// Returns nil when the connection has no bastion.
+func openSSHTunnel(connectionData dto.ConnectionInputDTO) (*sshTunnel, error) {
+	if connectionData.BastionHost == "" {
+		return nil, nil
+	}
+	key := sshTunnelKey(connectionData)
+
+	sshTunnelsMu.Lock()
+	tunnel, exists := sshTunnels[key]
+	if !exists {
+		config, err := buildSSHClientConfig(connectionData)
+		if err != nil {
+			sshTunnelsMu.Unlock()
+			return nil, fmt.Errorf("%w! Bastion: %s. Cause: %w", ErrWhileOpeningSSHTunnel, connectionData.BastionHost, err)
+		}
+		tunnel = &sshTunnel{
+			key:       key,
+			address:   net.JoinHostPort(connectionData.BastionHost, connectionData.BastionPort),
+			config:    config,
+			instances: make(map[string]bool),
+		}
+		sshTunnels[key] = tunnel
+		mysql.RegisterDialContext(key, func(ctx context.Context, address string) (net.Conn, error) {
+			return tunnel.DialContext(ctx, "tcp", address)
+		})
+	}
+	instance := instanceKey(connectionData)
+	if previousKey, used := instanceTunnels[instance]; used && previousKey != key {
+		log.Printf("Bastion settings of instance %s changed, releasing its previous SSH tunnel", instance)
+		releaseSSHTunnel(instance)
+	}
+	instanceTunnels[instance] = key
+	tunnel.instances[instance] = true
+	sshTunnelsMu.Unlock()
+
+	if _, err := tunnel.sshClient(); err != nil {
+		return nil, err
+	}
+	return tunnel, nil
+}
+
+// releaseSSHTunnel godoc
+// Stops tracking the tunnel of the instance, closing and removing it when no other instance uses it. Operations still
+// running through it fail, like the ones using the pools closed by InvalidateConnections. Must be called with the lock
+// held.
+func releaseSSHTunnel(instance string) {
+	key, used := instanceTunnels[instance]
+	if !used {
+		return
+	}
+	delete(instanceTunnels, instance)
+	tunnel, exists := sshTunnels[key]
+	if !exists {
+		return
+	}
+	delete(tunnel.instances, instance)
+	if len(tunnel.instances) == 0 {
+		delete(sshTunnels, key)
+		_ = tunnel.Close()
+	}
+}
+
+// closeSSHTunnelOfInstance godoc
+// Closes the tunnel of the instance when no other instance uses it, so the next operation opens a new one with the
+// current settings of the bastion
+func closeSSHTunnelOfInstance(instance string) {
+	sshTunnelsMu.Lock()
+	defer sshTunnelsMu.Unlock()
+	releaseSSHTunnel(instance)
+}
+
+func (t *sshTunnel) sshClient() (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client != nil {
+		return t.client, nil
+	}
+	client, err := ssh.Dial("tcp", t.address, t.config)
+	if err != nil {
+		log.Printf("Error while opening SSH tunnel through bastion %s. Cause: %v", t.address, err)
+		return nil, fmt.Errorf("%w! Bastion: %s. Cause: %w", ErrWhileOpeningSSHTunnel, t.address, err)
+	}
+	log.Printf("SSH tunnel opened through bastion %s", t.address)
+	t.client = client
+	go func() {
+		_ = client.Wait()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.client == client {
+			log.Printf("SSH tunnel through bastion %s closed", t.address)
+			t.client = nil
+		}
+	}()
+	return client, nil
+}
+
+// DialContext godoc
+// Opens a connection to the address through the bastion. Implements pq.DialerContext and the dialers of the other
+// drivers.
+func (t *sshTunnel) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	client, err := t.sshClient()
+	if err != nil {
+		return nil, err
+	}
+	return client.DialContext(ctx, network, address)
+}
+
+func (t *sshTunnel) Dial(network, address string) (net.Conn, error) {
+	return t.DialContext(context.Background(), network, address)
+}
+
+func (t *sshTunnel) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return t.DialContext(ctx, network, address)
+}
+
+// Close godoc
+// Closes the SSH connection. The tunnel stays usable and opens a new connection on the next dial.
+func (t *sshTunnel) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client == nil {
+		return nil
+	}
+	err := t.client.Close()
+	t.client = nil
+	return err
+}
+
+func buildSSHClientConfig(connectionData dto.ConnectionInputDTO) (*ssh.ClientConfig, error) {
+	var authMethods []ssh.AuthMethod
+	if connectionData.BastionPrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(connectionData.BastionPrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if connectionData.BastionPassword != "" {
+		authMethods = append(authMethods, ssh.Password(connectionData.BastionPassword))
+	}
+	if len(authMethods) == 0 {
+		return nil, errors.New("the bastion has no private key or password")
+	}
+
+	if connectionData.BastionHostKey == "" {
+		return nil, ErrBastionHostKeyNotPinned
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(connectionData.BastionHostKey))
+	if err != nil {
+		return nil, err
+	}
+	return &ssh.ClientConfig{
+		User:            connectionData.BastionUser,
+		Auth:            authMethods,
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         connectionTimeout,
+	}, nil
+}
+
+func sshTunnelKey(connectionData dto.ConnectionInputDTO) string {
+	return hashKey(sshTunnelKeyPrefix,
+		connectionData.BastionHost,
+		connectionData.BastionPort,
+		connectionData.BastionUser,
+		connectionData.BastionPrivateKey,
+		connectionData.BastionPassword,
+		connectionData.BastionHostKey,
+	)
+}
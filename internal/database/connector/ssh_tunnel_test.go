@@ -0,0 +1,289 @@
+package connector
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+const (
+	bastionUser     = "jump"
+	bastionPassword = "jump-pwd"
+)
+
+// fakeBastion is an in-process SSH server that only forwards direct-tcpip channels. The addresses requested by the
+// clients are translated by routes, simulating names that can only be resolved by the bastion.
+type fakeBastion struct {
+	listener net.Listener
+	hostKey  ssh.Signer
+	routes   map[string]string
+
+	mu             sync.Mutex
+	sshConnections int
+	requested      []string
+}
+
+func newFakeBastion(t *testing.T, routes map[string]string, authorizedKey ssh.PublicKey) *fakeBastion {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(hostPrivateKey)
+	assert.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	bastion := &fakeBastion{listener: listener, hostKey: hostKey, routes: routes}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == bastionUser && string(password) == bastionPassword {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorizedKey != nil && conn.User() == bastionUser && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(hostKey)
+	go bastion.serve(config)
+	return bastion
+}
+
+func (b *fakeBastion) serve(config *ssh.ServerConfig) {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, channels, requests, err := ssh.NewServerConn(conn, config)
+			if err != nil {
+				return
+			}
+			b.mu.Lock()
+			b.sshConnections++
+			b.mu.Unlock()
+			go ssh.DiscardRequests(requests)
+			for newChannel := range channels {
+				go b.forward(newChannel)
+			}
+		}()
+	}
+}
+
+func (b *fakeBastion) forward(newChannel ssh.NewChannel) {
+	var payload struct {
+		Host     string
+		Port     uint32
+		OrigHost string
+		OrigPort uint32
+	}
+	if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &payload) != nil {
+		_ = newChannel.Reject(ssh.UnknownChannelType, "only direct-tcpip is supported")
+		return
+	}
+	address := net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port)))
+	b.mu.Lock()
+	b.requested = append(b.requested, address)
+	b.mu.Unlock()
+	if route, exists := b.routes[address]; exists {
+		address = route
+	}
+	target, err := net.Dial("tcp", address)
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		_ = target.Close()
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	go func() {
+		_, _ = io.Copy(channel, target)
+		_ = channel.CloseWrite()
+	}()
+	_, _ = io.Copy(target, channel)
+	_ = target.Close()
+}
+
+func (b *fakeBastion) stats() (int, []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sshConnections, append([]string(nil), b.requested...)
+}
+
+func (b *fakeBastion) connectionData(host, port string) dto.ConnectionInputDTO {
+	bastionHost, bastionPort, _ := net.SplitHostPort(b.listener.Addr().String())
+	return dto.ConnectionInputDTO{
+		Host:            host,
+		Port:            port,
+		User:            esAdminUser,
+		Password:        esAdminPassword,
+		Instance:        "behind-bastion",
+		BastionHost:     bastionHost,
+		BastionPort:     bastionPort,
+		BastionUser:     bastionUser,
+		BastionPassword: bastionPassword,
+		BastionHostKey:  string(ssh.MarshalAuthorizedKey(b.hostKey.PublicKey())),
+	}
+}
+
+// newClosingListener accepts connections and closes them right away, counting how many reached it
+func newClosingListener(t *testing.T) (string, func() int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	var mu sync.Mutex
+	accepted := 0
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			accepted++
+			mu.Unlock()
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().String(), func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return accepted
+	}
+}
+
+func TestGivenNoBastion_WhenOpenSSHTunnel_ThenShouldConnectDirectly(t *testing.T) {
+	tunnel, err := openSSHTunnel(dto.ConnectionInputDTO{Host: "localhost", Port: "5432"})
+
+	assert.NoError(t, err)
+	assert.Nil(t, tunnel)
+}
+
+func TestGivenBastionWithPassword_WhenRequestElasticsearchThroughTunnel_ThenTargetShouldBeResolvedByBastion(t *testing.T) {
+	_, server := newFakeSecurityAPI(t)
+	serverURL, _ := url.Parse(server.URL)
+	bastion := newFakeBastion(t, map[string]string{"es.internal:9200": serverURL.Host}, nil)
+	connectionData := bastion.connectionData("es.internal", "9200")
+
+	tunnel, err := openSSHTunnel(connectionData)
+	assert.NoError(t, err)
+	ec, err := newElasticsearchConnector(connectionData, false, tunnel)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ec.TestConnection())
+	databases, err := ec.ListDatabases()
+	assert.NoError(t, err)
+	assert.Len(t, databases, 2)
+	sshConnections, requested := bastion.stats()
+	assert.Equal(t, 1, sshConnections)
+	assert.Contains(t, requested, "es.internal:9200")
+}
+
+func TestGivenBastion_WhenSeveralPostgresOperations_ThenShouldShareASingleSSHConnection(t *testing.T) {
+	targetAddress, accepted := newClosingListener(t)
+	bastion := newFakeBastion(t, map[string]string{"pg.internal:5432": targetAddress}, nil)
+	connectionData := bastion.connectionData("pg.internal", "5432")
+
+	tunnel, err := openSSHTunnel(connectionData)
+	assert.NoError(t, err)
+	pc := newPostgresConnector(connectionData, tunnel)
+
+	// The stand-in target closes every connection, so the operations fail after going through the tunnel
+	assert.Error(t, pc.TestConnection())
+	_, err = pc.UserExists("john.doe")
+	assert.Error(t, err)
+	sameTunnel, err := openSSHTunnel(connectionData)
+	assert.NoError(t, err)
+	_, err = newPostgresConnector(connectionData, sameTunnel).ListDatabases()
+	assert.Error(t, err)
+
+	sshConnections, requested := bastion.stats()
+	assert.Same(t, tunnel, sameTunnel)
+	assert.Equal(t, 1, sshConnections, "every operation should share the same SSH connection")
+	assert.GreaterOrEqual(t, len(requested), 3)
+	assert.GreaterOrEqual(t, accepted(), 3)
+	for _, address := range requested {
+		assert.Equal(t, "pg.internal:5432", address)
+	}
+	assert.Contains(t, newMySQLConnector(connectionData, false, tunnel).URL(), "@"+tunnel.key+"(pg.internal:5432)")
+}
+
+func TestGivenBastionWithPrivateKeyAndPinnedHostKey_WhenOpenSSHTunnel_ThenShouldVerifyHostKeyAndReconnect(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	assert.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	assert.NoError(t, err)
+	privateKeyPEM, err := ssh.MarshalPrivateKey(privateKey, "")
+	assert.NoError(t, err)
+	bastion := newFakeBastion(t, nil, sshPublicKey)
+
+	connectionData := bastion.connectionData("db.internal", "5432")
+	connectionData.BastionPassword = ""
+	connectionData.BastionPrivateKey = string(pem.EncodeToMemory(privateKeyPEM))
+	tunnel, err := openSSHTunnel(connectionData)
+	assert.NoError(t, err)
+
+	assert.NoError(t, tunnel.Close())
+	_, err = tunnel.sshClient()
+	assert.NoError(t, err, "the tunnel should reconnect after being closed")
+	sshConnections, _ := bastion.stats()
+	assert.Equal(t, 2, sshConnections)
+
+	connectionData.BastionHostKey = string(ssh.MarshalAuthorizedKey(signer.PublicKey()))
+	_, err = openSSHTunnel(connectionData)
+	assert.ErrorIs(t, err, ErrWhileOpeningSSHTunnel, "a host key different from the pinned one should be rejected")
+}
+
+func TestGivenBastionWithoutHostKey_WhenOpenSSHTunnel_ThenShouldRefuseToConnect(t *testing.T) {
+	bastion := newFakeBastion(t, nil, nil)
+	connectionData := bastion.connectionData("db.internal", "5432")
+	connectionData.BastionHostKey = ""
+
+	tunnel, err := openSSHTunnel(connectionData)
+
+	assert.ErrorIs(t, err, ErrBastionHostKeyNotPinned)
+	assert.Nil(t, tunnel)
+	sshConnections, _ := bastion.stats()
+	assert.Equal(t, 0, sshConnections, "the credentials should never be sent to an unverified bastion")
+}
+
+func TestGivenBastionSettingsChanged_WhenOpenSSHTunnel_ThenShouldCloseAndRemoveThePreviousTunnel(t *testing.T) {
+	bastion := newFakeBastion(t, nil, nil)
+	connectionData := bastion.connectionData("db.internal", "5432")
+	connectionData.ID = "changed-bastion-instance"
+	tunnel, err := openSSHTunnel(connectionData)
+	assert.NoError(t, err)
+	otherInstanceData := connectionData
+	otherInstanceData.ID = "same-bastion-instance"
+	_, err = openSSHTunnel(otherInstanceData)
+	assert.NoError(t, err)
+
+	connectionData.BastionUser = "other-jump"
+	_, err = openSSHTunnel(connectionData)
+	assert.ErrorIs(t, err, ErrWhileOpeningSSHTunnel)
+	assert.Contains(t, sshTunnels, tunnel.key, "the tunnel should be kept while another instance uses it")
+
+	InvalidateConnections(otherInstanceData.ID)
+	assert.NotContains(t, sshTunnels, tunnel.key)
+	assert.Nil(t, tunnel.client, "the tunnel no instance uses should be closed")
+}
@@ -0,0 +1,107 @@
+package connector
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const tlsConfigKeyPrefix = "zgdg-"
+
+// buildTLSConfig godoc
+// Builds the TLS configuration of a connection following the libpq sslmode semantics for every technology:
+//   - disable: no TLS, the returned config is nil
+//   - require: encrypts the connection, the server certificate is only verified against the CA when one is informed
+//   - verify-ca: verifies that the server certificate was signed by the CA, without checking the host name
+//   - verify-full: verifies the server certificate and that it was issued to the host
+//
+// Without a CA certificate the server certificate is verified against the system pool.
+func buildTLSConfig(connectionData dto.ConnectionInputDTO) (*tls.Config, error) {
+	if !tlsEnabled(connectionData) {
+		return nil, nil
+	}
+	mode := entity.SSLMode(connectionData.SSLMode)
+	tlsConfig := &tls.Config{
+		ServerName: connectionData.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if connectionData.CACertificate != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(connectionData.CACertificate)) {
+			return nil, entity.ErrInvalidCACertificate
+		}
+	}
+	if connectionData.ClientCertificate != "" || connectionData.ClientKey != "" {
+		clientCertificate, err := tls.X509KeyPair([]byte(connectionData.ClientCertificate), []byte(connectionData.ClientKey))
+		if err != nil {
+			return nil, errors.Join(entity.ErrInvalidClientCertificate, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCertificate}
+	}
+
+	switch mode {
+	case entity.SSLModeRequire:
+		tlsConfig.InsecureSkipVerify = true
+		if tlsConfig.RootCAs != nil {
+			tlsConfig.VerifyConnection = verifyCertificateAuthority(tlsConfig.RootCAs)
+		}
+	case entity.SSLModeVerifyCA:
+		// The default verification always checks the host name, so it's skipped and the chain is verified by hand
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = verifyCertificateAuthority(tlsConfig.RootCAs)
+	case entity.SSLModeVerifyFull:
+	default:
+		return nil, fmt.Errorf("%w: %s", entity.ErrInvalidSSLMode, mode)
+	}
+	return tlsConfig, nil
+}
+
+func tlsEnabled(connectionData dto.ConnectionInputDTO) bool {
+	return connectionData.SSLMode != "" && connectionData.SSLMode != string(entity.SSLModeDisable)
+}
+
+func verifyCertificateAuthority(roots *x509.CertPool) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("the server did not present a certificate")
+		}
+		opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+		for _, cert := range state.PeerCertificates[1:] {
+			opts.Intermediates.AddCert(cert)
+		}
+		_, err := state.PeerCertificates[0].Verify(opts)
+		return err
+	}
+}
+
+// registerTLSConfig godoc
+// Drivers configured through a DSN (lib/pq and go-sql-driver/mysql) only accept a custom TLS configuration registered
+// under a name. The name is derived from the host and TLS settings of the connection, so connections sharing the same
+// settings reuse the same entry and any change of certificate registers a new one.
+// Returns an empty name when TLS is disabled.
+func registerTLSConfig(connectionData dto.ConnectionInputDTO, register func(string, *tls.Config) error) (string, error) {
+	tlsConfig, err := buildTLSConfig(connectionData)
+	if err != nil || tlsConfig == nil {
+		return "", err
+	}
+	key := tlsConfigKey(connectionData)
+	if err := register(key, tlsConfig); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func tlsConfigKey(connectionData dto.ConnectionInputDTO) string {
+	return hashKey(tlsConfigKeyPrefix,
+		connectionData.Host,
+		connectionData.Port,
+		connectionData.SSLMode,
+		connectionData.CACertificate,
+		connectionData.ClientCertificate,
+		connectionData.ClientKey,
+	)
+}
@@ -0,0 +1,150 @@
+package connector
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+func generateClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zg-data-guard"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func serverCertificatePEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func TestGivenSSLModeDisabled_WhenBuildTLSConfig_ThenShouldNotUseTLS(t *testing.T) {
+	for _, mode := range []string{"", string(entity.SSLModeDisable)} {
+		tlsConfig, err := buildTLSConfig(dto.ConnectionInputDTO{Host: "localhost", SSLMode: mode})
+		assert.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	}
+}
+
+func TestGivenEachSSLMode_WhenBuildTLSConfig_ThenShouldFollowLibpqSemantics(t *testing.T) {
+	clientCert, clientKey := generateClientCertificate(t)
+	connectionData := dto.ConnectionInputDTO{Host: "db.internal", CACertificate: clientCert, ClientCertificate: clientCert, ClientKey: clientKey}
+
+	connectionData.SSLMode = string(entity.SSLModeRequire)
+	tlsConfig, err := buildTLSConfig(connectionData)
+	assert.NoError(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+	assert.NotNil(t, tlsConfig.VerifyConnection, "require should verify the CA when one is informed")
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	connectionData.SSLMode = string(entity.SSLModeVerifyCA)
+	tlsConfig, err = buildTLSConfig(connectionData)
+	assert.NoError(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+	assert.NotNil(t, tlsConfig.VerifyConnection)
+
+	connectionData.SSLMode = string(entity.SSLModeVerifyFull)
+	tlsConfig, err = buildTLSConfig(connectionData)
+	assert.NoError(t, err)
+	assert.False(t, tlsConfig.InsecureSkipVerify)
+	assert.Nil(t, tlsConfig.VerifyConnection)
+	assert.Equal(t, "db.internal", tlsConfig.ServerName)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+
+	connectionData.SSLMode = string(entity.SSLModeRequire)
+	connectionData.CACertificate = ""
+	tlsConfig, err = buildTLSConfig(connectionData)
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig.VerifyConnection, "require without CA should only encrypt the connection")
+}
+
+func TestGivenInvalidCertificates_WhenBuildTLSConfig_ThenShouldReturnError(t *testing.T) {
+	_, err := buildTLSConfig(dto.ConnectionInputDTO{SSLMode: string(entity.SSLModeVerifyFull), CACertificate: "not a pem"})
+	assert.ErrorIs(t, err, entity.ErrInvalidCACertificate)
+
+	clientCert, _ := generateClientCertificate(t)
+	_, err = buildTLSConfig(dto.ConnectionInputDTO{SSLMode: string(entity.SSLModeRequire), ClientCertificate: clientCert})
+	assert.ErrorIs(t, err, entity.ErrInvalidClientCertificate)
+
+	_, err = buildTLSConfig(dto.ConnectionInputDTO{SSLMode: "prefer"})
+	assert.ErrorIs(t, err, entity.ErrInvalidSSLMode)
+}
+
+func TestGivenSameTLSSettings_WhenTLSConfigKey_ThenShouldBeStableAndChangeWithCertificates(t *testing.T) {
+	connectionData := dto.ConnectionInputDTO{Host: "db.internal", Port: "5432", SSLMode: string(entity.SSLModeVerifyFull), CACertificate: "ca"}
+	key := tlsConfigKey(connectionData)
+
+	assert.True(t, strings.HasPrefix(key, tlsConfigKeyPrefix))
+	assert.Equal(t, key, tlsConfigKey(connectionData))
+	connectionData.CACertificate = "rotated ca"
+	assert.NotEqual(t, key, tlsConfigKey(connectionData))
+}
+
+func TestGivenTLSServer_WhenTestConnectionElasticsearchWithEachSSLMode_ThenShouldVerifyAsConfigured(t *testing.T) {
+	_, server := startFakeSecurityAPI(t, httptest.NewTLSServer)
+	ec := buildElasticsearchConnector(t, server, "", false)
+	assert.Error(t, ec.TestConnection(), "plain HTTP against a TLS server should fail")
+
+	// The test certificate is valid for 127.0.0.1 but not for localhost
+	for _, tc := range []struct {
+		mode, host, ca string
+		success        bool
+	}{
+		{string(entity.SSLModeRequire), "localhost", "", true},
+		{string(entity.SSLModeVerifyCA), "localhost", serverCertificatePEM(server), true},
+		{string(entity.SSLModeVerifyCA), "localhost", "", false},
+		{string(entity.SSLModeVerifyFull), "127.0.0.1", serverCertificatePEM(server), true},
+		{string(entity.SSLModeVerifyFull), "localhost", serverCertificatePEM(server), false},
+	} {
+		connectionData := ec.ConnectionData
+		connectionData.SSLMode = tc.mode
+		connectionData.Host = tc.host
+		connectionData.CACertificate = tc.ca
+		tlsConnector, err := newElasticsearchConnector(connectionData, false, nil)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(tlsConnector.URL(), "https://"))
+
+		err = tlsConnector.TestConnection()
+		if tc.success {
+			assert.NoError(t, err, "mode %s with host %s should connect", tc.mode, tc.host)
+		} else {
+			assert.Error(t, err, "mode %s with host %s should fail the certificate verification", tc.mode, tc.host)
+		}
+	}
+}
+
+func TestGivenSSLMode_WhenBuildPostgresAndMySQLURL_ThenShouldReferenceRegisteredTLSConfig(t *testing.T) {
+	connectionData := dto.ConnectionInputDTO{Host: "db.internal", Port: "5432", User: "admin", Password: "pwd"}
+	assert.Contains(t, newPostgresConnector(connectionData, nil).URL(), "sslmode=disable")
+	assert.NotContains(t, newMySQLConnector(connectionData, false, nil).URL(), "tls=")
+
+	connectionData.SSLMode = string(entity.SSLModeVerifyFull)
+	key := tlsConfigKey(connectionData)
+	assert.Contains(t, newPostgresConnector(connectionData, nil).URL(), "sslmode=pqgo-"+key)
+	assert.Contains(t, newMySQLConnector(connectionData, false, nil).URL(), "tls="+key)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE host_connection_info
+	DROP COLUMN IF EXISTS ssl_mode,
+	DROP COLUMN IF EXISTS ca_certificate,
+	DROP COLUMN IF EXISTS client_certificate,
+	DROP COLUMN IF EXISTS client_key;
@@ -0,0 +1,5 @@
+ALTER TABLE host_connection_info
+	ADD COLUMN IF NOT EXISTS ssl_mode           TEXT NOT NULL DEFAULT 'disable',
+	ADD COLUMN IF NOT EXISTS ca_certificate     TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS client_certificate TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS client_key         TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+ALTER TABLE host_connection_info
+	DROP COLUMN IF EXISTS bastion_host,
+	DROP COLUMN IF EXISTS bastion_port,
+	DROP COLUMN IF EXISTS bastion_user,
+	DROP COLUMN IF EXISTS bastion_private_key,
+	DROP COLUMN IF EXISTS bastion_password,
+	DROP COLUMN IF EXISTS bastion_host_key;
@@ -0,0 +1,7 @@
+ALTER TABLE host_connection_info
+	ADD COLUMN IF NOT EXISTS bastion_host        TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS bastion_port        TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS bastion_user        TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS bastion_private_key TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS bastion_password    TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS bastion_host_key    TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_created_by_user_id;
+DROP INDEX IF EXISTS idx_jobs_status;
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS jobs
+(
+	id                 uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	type               TEXT      NOT NULL,
+	status             TEXT      NOT NULL,
+	input              JSONB     NOT NULL,
+	total_items        INTEGER   NOT NULL DEFAULT 0,
+	processed_items    INTEGER   NOT NULL DEFAULT 0,
+	failed_items       INTEGER   NOT NULL DEFAULT 0,
+	items              JSONB     NOT NULL DEFAULT '[]',
+	result             JSONB,
+	error              TEXT      NOT NULL DEFAULT '',
+	attempts           INTEGER   NOT NULL DEFAULT 0,
+	created_by_user_id uuid      NOT NULL,
+	created_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	started_at         TIMESTAMP,
+	finished_at        TIMESTAMP,
+	FOREIGN KEY (created_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status
+	ON jobs (status);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_created_by_user_id
+	ON jobs (created_by_user_id);
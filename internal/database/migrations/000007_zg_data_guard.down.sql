@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_access_permissions_expires_at;
+
+ALTER TABLE access_permissions
+	DROP COLUMN IF EXISTS expires_at;
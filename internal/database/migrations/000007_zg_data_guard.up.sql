@@ -0,0 +1,6 @@
+ALTER TABLE access_permissions
+	ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_access_permissions_expires_at
+	ON access_permissions (expires_at)
+	WHERE expires_at IS NOT NULL;
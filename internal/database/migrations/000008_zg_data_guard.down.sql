@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_access_requests_requested_by_user_id;
+DROP INDEX IF EXISTS idx_access_requests_status;
+DROP TABLE IF EXISTS access_requests;
+
+ALTER TABLE ecosystems
+	DROP COLUMN IF EXISTS requires_access_approval;
@@ -0,0 +1,28 @@
+ALTER TABLE ecosystems
+	ADD COLUMN IF NOT EXISTS requires_access_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS access_requests
+(
+	id                   uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	status               TEXT      NOT NULL,
+	input                JSONB     NOT NULL,
+	justification        TEXT      NOT NULL,
+	requested_by_user_id uuid      NOT NULL,
+	reviewed_by_user_id  uuid,
+	review_comment       TEXT      NOT NULL DEFAULT '',
+	result               JSONB,
+	error                TEXT      NOT NULL DEFAULT '',
+	created_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at           TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at           TIMESTAMP NOT NULL,
+	reviewed_at          TIMESTAMP,
+	executed_at          TIMESTAMP,
+	FOREIGN KEY (requested_by_user_id) REFERENCES application_users (id),
+	FOREIGN KEY (reviewed_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_requests_status
+	ON access_requests (status);
+
+CREATE INDEX IF NOT EXISTS idx_access_requests_requested_by_user_id
+	ON access_requests (requested_by_user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_break_glass_access_expires_at;
+DROP TABLE IF EXISTS break_glass_access;
+
+DROP INDEX IF EXISTS idx_access_permission_log_type;
+ALTER TABLE access_permission_log
+	DROP COLUMN IF EXISTS type;
@@ -0,0 +1,25 @@
+ALTER TABLE access_permission_log
+	ADD COLUMN IF NOT EXISTS type TEXT NOT NULL DEFAULT 'ACCESS';
+
+CREATE INDEX IF NOT EXISTS idx_access_permission_log_type
+	ON access_permission_log (type);
+
+CREATE TABLE IF NOT EXISTS break_glass_access
+(
+	id                 uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	database_id        uuid      NOT NULL,
+	database_user_id   uuid      NOT NULL,
+	incident_reference TEXT      NOT NULL,
+	role_granted       BOOLEAN   NOT NULL,
+	granted_by_user_id uuid      NOT NULL,
+	granted_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expires_at         TIMESTAMP NOT NULL,
+	revoked_at         TIMESTAMP,
+	FOREIGN KEY (database_id) REFERENCES databases (id),
+	FOREIGN KEY (database_user_id) REFERENCES database_users (id),
+	FOREIGN KEY (granted_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_access_expires_at
+	ON break_glass_access (expires_at)
+	WHERE revoked_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_recertification_items_campaign_id_reviewer_id;
+DROP TABLE IF EXISTS recertification_items;
+
+DROP INDEX IF EXISTS idx_recertification_campaigns_status;
+DROP TABLE IF EXISTS recertification_campaigns;
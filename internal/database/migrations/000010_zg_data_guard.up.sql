@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS recertification_campaigns
+(
+	id                 uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name               TEXT      NOT NULL,
+	status             TEXT      NOT NULL,
+	ecosystem_id       uuid,
+	team               TEXT      NOT NULL DEFAULT '',
+	created_by_user_id uuid      NOT NULL,
+	closed_by_user_id  uuid,
+	created_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	closed_at          TIMESTAMP,
+	FOREIGN KEY (ecosystem_id) REFERENCES ecosystems (id),
+	FOREIGN KEY (created_by_user_id) REFERENCES application_users (id),
+	FOREIGN KEY (closed_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recertification_campaigns_status
+	ON recertification_campaigns (status);
+
+-- The permission of each item is a snapshot taken when the campaign was created. It has no foreign key to
+-- access_permissions, since the permissions revoked are deleted while the evidence of their review must remain.
+CREATE TABLE IF NOT EXISTS recertification_items
+(
+	id                   uuid          DEFAULT uuid_generate_v4() PRIMARY KEY,
+	campaign_id          uuid NOT NULL,
+	access_permission_id uuid NOT NULL,
+	database_user_id     uuid NOT NULL,
+	database_instance_id uuid NOT NULL,
+	permission           JSONB NOT NULL,
+	reviewer_id          uuid NOT NULL,
+	decision             TEXT NOT NULL,
+	comment              TEXT NOT NULL DEFAULT '',
+	decided_at           TIMESTAMP,
+	revocation_result    TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY (campaign_id) REFERENCES recertification_campaigns (id),
+	FOREIGN KEY (reviewer_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recertification_items_campaign_id_reviewer_id
+	ON recertification_items (campaign_id, reviewer_id);
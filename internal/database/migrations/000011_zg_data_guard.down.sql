@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_access_group_members_database_user_id;
+DROP TABLE IF EXISTS access_group_members;
+
+DROP INDEX IF EXISTS idx_access_group_targets_access_group_id;
+DROP TABLE IF EXISTS access_group_targets;
+
+DROP TABLE IF EXISTS access_groups;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS access_groups
+(
+	id                 uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	name               TEXT      NOT NULL UNIQUE,
+	description        TEXT      NOT NULL DEFAULT '',
+	created_by_user_id uuid      NOT NULL,
+	created_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (created_by_user_id) REFERENCES application_users (id)
+);
+
+-- A target without database_id covers all the enabled databases of the instance, like a grant without databases
+CREATE TABLE IF NOT EXISTS access_group_targets
+(
+	id                   uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+	access_group_id      uuid NOT NULL,
+	database_instance_id uuid NOT NULL,
+	database_id          uuid,
+	FOREIGN KEY (access_group_id) REFERENCES access_groups (id),
+	FOREIGN KEY (database_instance_id) REFERENCES database_instances (id),
+	FOREIGN KEY (database_id) REFERENCES databases (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_group_targets_access_group_id
+	ON access_group_targets (access_group_id);
+
+CREATE TABLE IF NOT EXISTS access_group_members
+(
+	access_group_id  uuid      NOT NULL,
+	database_user_id uuid      NOT NULL,
+	added_by_user_id uuid      NOT NULL,
+	added_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (access_group_id, database_user_id),
+	FOREIGN KEY (access_group_id) REFERENCES access_groups (id),
+	FOREIGN KEY (database_user_id) REFERENCES database_users (id),
+	FOREIGN KEY (added_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_access_group_members_database_user_id
+	ON access_group_members (database_user_id);
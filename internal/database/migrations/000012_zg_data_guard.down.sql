@@ -0,0 +1,3 @@
+ALTER TABLE database_roles
+	DROP COLUMN IF EXISTS updated_at,
+	DROP COLUMN IF EXISTS privileges;
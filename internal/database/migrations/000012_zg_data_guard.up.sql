@@ -0,0 +1,46 @@
+-- Privileges of the role on the objects of each database, in the vocabulary of PostgreSQL. The connectors generate the
+-- grants of the role from them.
+ALTER TABLE database_roles
+	ADD COLUMN IF NOT EXISTS privileges JSONB     NOT NULL DEFAULT '{}',
+	ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE database_roles
+SET privileges = '{
+  "schema": ["USAGE"],
+  "table": ["SELECT"],
+  "sequence": ["SELECT"],
+  "function": ["EXECUTE"],
+  "default": {"table": ["SELECT"], "sequence": ["SELECT"], "function": ["EXECUTE"], "type": ["USAGE"]}
+}'
+WHERE name = 'user_ro';
+
+UPDATE database_roles
+SET privileges = '{
+  "schema": ["USAGE"],
+  "table": ["SELECT", "INSERT", "UPDATE", "DELETE"],
+  "sequence": ["USAGE", "SELECT"],
+  "function": ["EXECUTE"],
+  "default": {"table": ["SELECT", "INSERT", "UPDATE", "DELETE"], "sequence": ["USAGE", "SELECT"], "function": ["EXECUTE"], "type": ["USAGE"]}
+}'
+WHERE name = 'developer';
+
+UPDATE database_roles
+SET privileges = '{
+  "schema": ["USAGE", "CREATE"],
+  "table": ["SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"],
+  "sequence": ["USAGE", "SELECT", "UPDATE"],
+  "function": ["EXECUTE"],
+  "default": {"table": ["SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"], "sequence": ["USAGE", "SELECT", "UPDATE"], "function": ["EXECUTE"], "type": ["USAGE"]}
+}'
+WHERE name = 'devops';
+
+UPDATE database_roles
+SET privileges = '{
+  "database": ["CREATE", "TEMP"],
+  "schema": ["USAGE", "CREATE"],
+  "table": ["SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"],
+  "sequence": ["USAGE", "SELECT", "UPDATE"],
+  "function": ["EXECUTE"],
+  "default": {"table": ["SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"], "sequence": ["USAGE", "SELECT", "UPDATE"], "function": ["EXECUTE"], "type": ["USAGE"]}
+}'
+WHERE name = 'application';
@@ -0,0 +1,4 @@
+ALTER TABLE databases
+	DROP COLUMN IF EXISTS roles_configured_at,
+	DROP COLUMN IF EXISTS roles_template_checksum,
+	DROP COLUMN IF EXISTS roles_template_version;
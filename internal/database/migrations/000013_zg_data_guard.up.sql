@@ -0,0 +1,5 @@
+-- The databases configured before have no record of the grants applied, so they're reported as outdated
+ALTER TABLE databases
+	ADD COLUMN roles_template_version  INTEGER     NOT NULL DEFAULT 0,
+	ADD COLUMN roles_template_checksum VARCHAR(64) NOT NULL DEFAULT '',
+	ADD COLUMN roles_configured_at     TIMESTAMP;
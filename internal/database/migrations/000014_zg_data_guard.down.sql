@@ -0,0 +1,3 @@
+ALTER TABLE access_permissions
+	DROP COLUMN IF EXISTS scope_schemas,
+	DROP COLUMN IF EXISTS scope_tables;
@@ -0,0 +1,4 @@
+-- Allowlists of schemas and tables of the database, an empty scope means the whole database
+ALTER TABLE access_permissions
+	ADD COLUMN scope_schemas TEXT[] NOT NULL DEFAULT '{}',
+	ADD COLUMN scope_tables  TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_role_findings_database_instance_id_class;
+DROP TABLE IF EXISTS role_findings;
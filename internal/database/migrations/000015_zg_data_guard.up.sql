@@ -0,0 +1,18 @@
+-- Logins found in the instances by the last role discovery of each instance, which replaces the previous findings.
+-- The user is kept without foreign key, since a login may outlive its Data Guard user.
+CREATE TABLE IF NOT EXISTS role_findings
+(
+	id                    uuid               DEFAULT uuid_generate_v4() PRIMARY KEY,
+	database_instance_id  uuid      NOT NULL,
+	login_name            TEXT      NOT NULL,
+	database_user_id      uuid,
+	class                 TEXT      NOT NULL,
+	details               TEXT      NOT NULL DEFAULT '',
+	discovered_by_user_id uuid      NOT NULL,
+	discovered_at         TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (database_instance_id) REFERENCES database_instances (id),
+	FOREIGN KEY (discovered_by_user_id) REFERENCES application_users (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_findings_database_instance_id_class
+	ON role_findings (database_instance_id, class);
@@ -0,0 +1,2 @@
+ALTER TABLE access_permissions
+	DROP COLUMN IF EXISTS granted_by_access_group_id;
@@ -0,0 +1,4 @@
+-- The access group whose grant created the permission, so a member losing a target of the group is revoked only the
+-- access given by groups. It's null for the permissions granted directly.
+ALTER TABLE access_permissions
+	ADD COLUMN IF NOT EXISTS granted_by_access_group_id uuid REFERENCES access_groups (id);
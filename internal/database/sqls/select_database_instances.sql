@@ -6,8 +6,19 @@ SELECT di.id,
 	   hci.port_connection,
 	   hci.admin_username,
 	   hci.admin_password,
+	   hci.ssl_mode,
+	   hci.ca_certificate,
+	   hci.client_certificate,
+	   hci.client_key,
+	   hci.bastion_host,
+	   hci.bastion_port,
+	   hci.bastion_user,
+	   hci.bastion_private_key,
+	   hci.bastion_password,
+	   hci.bastion_host_key,
 	   di.ecosystem_id,
 	   e.display_name,
+	   e.requires_access_approval,
 	   di.database_technology_id,
 	   dt.name,
 	   dt.version,
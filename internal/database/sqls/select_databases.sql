@@ -16,7 +16,10 @@ SELECT db.id,
 	   db.created_at,
 	   db.updated_at,
 	   di.last_database_sync,
-	   db.disabled_at
+	   db.disabled_at,
+	   db.roles_template_version,
+	   db.roles_template_checksum,
+	   db.roles_configured_at
 FROM databases db
 	 JOIN database_instances di
 		ON db.database_instance_id = di.id
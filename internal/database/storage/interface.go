@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
@@ -46,6 +47,11 @@ type DatabaseInstanceStorage interface {
 }
 
 type DatabaseRoleStorage interface {
+	Save(role *entity.DatabaseRole) error
+	Update(role *entity.DatabaseRole) error
+	Delete(id string) error
+	CheckNameExists(name string) (bool, error)
+	IsInUse(id string) (bool, error)
 	FindAll() ([]*entity.DatabaseRole, error)
 	FindByID(id string) (*entity.DatabaseRole, error)
 }
@@ -73,17 +79,87 @@ type DatabaseUserStorage interface {
 
 type AccessPermissionStorage interface {
 	Save(d *entity.AccessPermission) error
+	UpdateDatabaseRole(id string, databaseRoleID sql.NullString) error
+	UpdateExpiresAt(databaseID, databaseUserID string, expiresAt *time.Time) error
+	UpdateAccessGroup(databaseID, databaseUserID string, accessGroupID sql.NullString) error
 	Exists(databaseID, databaseUserID string) (bool, error)
+	Delete(id string) error
 	DeleteAllByInstance(instanceID string) error
 	DeleteAllByUserAndInstance(databaseUserID, instanceID string) error
+	FindDTOByID(id string) (*dto.AccessPermissionOutputDTO, error)
 	FindAllDTOs(databaseID, databaseUserID, databaseInstanceID string) ([]*dto.AccessPermissionOutputDTO, error)
+	FindAllDTOsByScope(ecosystemID, team string) ([]*dto.AccessPermissionOutputDTO, error)
 	SaveLog(log *entity.AccessPermissionLog) error
 	FindAllAccessibleInstancesIDsByUser(userID string) ([]string, error)
-	FindAllLogsDTOs(page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error)
+	FindAllExpiredDTOs(now time.Time) ([]*dto.AccessPermissionOutputDTO, error)
+	FindAllScopedInstancesIDsByUsers(userIDs []string) (map[string][]string, error)
+	FindAllLogsDTOs(logType string, page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error)
 	CheckIfUserHasAccessPermission(databaseUserID string) (bool, error)
-	LogCount() (int, error)
+	LogCount(logType string) (int, error)
 }
 
 type ForbiddenObjectsStorage interface {
 	FindAllDatabases() ([]*entity.ForbiddenDatabase, error)
 }
+
+type AccessRequestStorage interface {
+	Save(r *entity.AccessRequest) error
+	Update(r *entity.AccessRequest, previousStatus entity.AccessRequestStatus) (bool, error)
+	FindByID(id string) (*entity.AccessRequest, error)
+	FindDTOByID(id string) (*dto.AccessRequestOutputDTO, error)
+	FindAllDTOs(status, requestedByUserID string, page, limit int) ([]*dto.AccessRequestOutputDTO, error)
+	Count(status, requestedByUserID string) (int, error)
+	ExpireAllPending(now time.Time) (int64, error)
+}
+
+type JobStorage interface {
+	Save(job *entity.Job) error
+	Update(job *entity.Job) error
+	FindByID(id string) (*entity.Job, error)
+	FindDTOByID(id string) (*dto.JobOutputDTO, error)
+	FindAllDTOs(jobType, status string, page, limit int) ([]*dto.JobOutputDTO, error)
+	Count(jobType, status string) (int, error)
+	FindAllUnfinished() ([]*entity.Job, error)
+}
+
+type BreakGlassAccessStorage interface {
+	Save(b *entity.BreakGlassAccess) error
+	FindAllExpiredDTOs(now time.Time) ([]*dto.BreakGlassAccessOutputDTO, error)
+	UpdateRevokedAt(id string, revokedAt time.Time) error
+	UpdateRevokedAtByUserAndInstance(databaseUserID, databaseInstanceID string, revokedAt time.Time) error
+}
+
+type RecertificationCampaignStorage interface {
+	Save(c *entity.RecertificationCampaign, items []*entity.RecertificationItem) error
+	Update(c *entity.RecertificationCampaign, previousStatus entity.RecertificationCampaignStatus) (bool, error)
+	FindByID(id string) (*entity.RecertificationCampaign, error)
+	FindDTOByID(id string) (*dto.RecertificationCampaignOutputDTO, error)
+	FindAllDTOs(status string, page, limit int) ([]*dto.RecertificationCampaignOutputDTO, error)
+	Count(status string) (int, error)
+	FindAllItems(campaignID string, ids []string) ([]*entity.RecertificationItem, error)
+	UpdateItems(items []*entity.RecertificationItem) error
+	FindAllItemDTOs(campaignID, reviewerID, decision string, page, limit int) ([]*dto.RecertificationItemOutputDTO, error)
+	CountItems(campaignID, reviewerID, decision string) (int, error)
+}
+
+type AccessGroupStorage interface {
+	Save(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error
+	UpdateTargets(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error
+	CheckNameExists(name string) (bool, error)
+	FindByID(id string) (*entity.AccessGroup, error)
+	FindDTOByID(id string) (*dto.AccessGroupOutputDTO, error)
+	FindAllDTOs(page, limit int) ([]*dto.AccessGroupOutputDTO, error)
+	Count() (int, error)
+	FindAllTargets(accessGroupID string) ([]*entity.AccessGroupTarget, error)
+	FindAllTargetsByMember(databaseUserID, exceptAccessGroupID string) ([]*entity.AccessGroupTarget, error)
+	FindAllTargetDTOs(accessGroupID string) ([]dto.AccessGroupTargetOutputDTO, error)
+	SaveMembers(members []*entity.AccessGroupMember) error
+	DeleteMember(accessGroupID, databaseUserID string) (bool, error)
+	FindAllMemberDTOs(accessGroupID string) ([]dto.AccessGroupMemberOutputDTO, error)
+}
+
+type RoleFindingStorage interface {
+	ReplaceAllByInstance(databaseInstanceID string, findings []*entity.RoleFinding) error
+	FindAllDTOs(databaseInstanceID, class string, page, limit int) ([]*dto.RoleFindingOutputDTO, error)
+	Count(databaseInstanceID, class string) (int, error)
+}
@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresAccessGroupStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresAccessGroupStorage(db *sql.DB) *PostgresAccessGroupStorage {
+	return &PostgresAccessGroupStorage{DB: db}
+}
+
+// Save godoc
+// Saves the group with its targets in a single transaction
+func (ags *PostgresAccessGroupStorage) Save(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error {
+	return inTransaction(ags.DB, func(tx *sql.Tx) error {
+		query := `
+INSERT INTO access_groups (id, name, description, created_by_user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)`
+		_, err := tx.Exec(query, g.ID, g.Name, g.Description, g.CreatedByUserID, g.CreatedAt, g.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return insertAccessGroupTargets(tx, targets)
+	})
+}
+
+// UpdateTargets godoc
+// Replaces all the targets of the group in a single transaction
+func (ags *PostgresAccessGroupStorage) UpdateTargets(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error {
+	return inTransaction(ags.DB, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`UPDATE access_groups SET updated_at = $1 WHERE id = $2`, g.UpdatedAt, g.ID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM access_group_targets WHERE access_group_id = $1`, g.ID)
+		if err != nil {
+			return err
+		}
+		return insertAccessGroupTargets(tx, targets)
+	})
+}
+
+func insertAccessGroupTargets(tx *sql.Tx, targets []*entity.AccessGroupTarget) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(`
+INSERT INTO access_group_targets (id, access_group_id, database_instance_id, database_id)
+VALUES ($1, $2, $3, $4)`)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+	for _, t := range targets {
+		if _, err = stmt.Exec(t.ID, t.AccessGroupID, t.DatabaseInstanceID, t.DatabaseID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ags *PostgresAccessGroupStorage) CheckNameExists(name string) (bool, error) {
+	var exists bool
+	err := ags.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM access_groups WHERE name = $1)`, name).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (ags *PostgresAccessGroupStorage) FindByID(id string) (*entity.AccessGroup, error) {
+	query := `
+SELECT id, name, description, created_by_user_id, created_at, updated_at
+FROM access_groups
+WHERE id = $1`
+	var g entity.AccessGroup
+	err := ags.DB.QueryRow(query, id).Scan(
+		&g.ID,
+		&g.Name,
+		&g.Description,
+		&g.CreatedByUserID,
+		&g.CreatedAt,
+		&g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (ags *PostgresAccessGroupStorage) FindDTOByID(id string) (*dto.AccessGroupOutputDTO, error) {
+	query := ags.baseQueryDTO() + ` WHERE ag.id = $1`
+	return ags.scanDTO(ags.DB.QueryRow(query, id))
+}
+
+func (ags *PostgresAccessGroupStorage) FindAllDTOs(page, limit int) ([]*dto.AccessGroupOutputDTO, error) {
+	query := ags.baseQueryDTO() + ` ORDER BY ag.name`
+	query, args := appendPagination(query, nil, page, limit)
+	rows, err := ags.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var groups []*dto.AccessGroupOutputDTO
+	for rows.Next() {
+		d, err := ags.scanDTO(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, d)
+	}
+	return groups, nil
+}
+
+func (ags *PostgresAccessGroupStorage) Count() (int, error) {
+	var count int
+	err := ags.DB.QueryRow(`SELECT COUNT(*) FROM access_groups`).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (ags *PostgresAccessGroupStorage) FindAllTargets(accessGroupID string) ([]*entity.AccessGroupTarget, error) {
+	query := `
+SELECT t.id, t.access_group_id, t.database_instance_id, t.database_id
+FROM access_group_targets t
+WHERE t.access_group_id = $1`
+	return ags.queryTargets(query, accessGroupID)
+}
+
+// FindAllTargetsByMember godoc
+// Finds the targets of all the groups the database user is a member of, except the informed group
+func (ags *PostgresAccessGroupStorage) FindAllTargetsByMember(databaseUserID, exceptAccessGroupID string) ([]*entity.AccessGroupTarget, error) {
+	query := `
+SELECT t.id, t.access_group_id, t.database_instance_id, t.database_id
+FROM access_group_targets t
+	JOIN access_group_members m
+		ON t.access_group_id = m.access_group_id
+WHERE m.database_user_id = $1
+	AND t.access_group_id <> $2`
+	return ags.queryTargets(query, databaseUserID, exceptAccessGroupID)
+}
+
+func (ags *PostgresAccessGroupStorage) queryTargets(query string, args ...any) ([]*entity.AccessGroupTarget, error) {
+	rows, err := ags.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var targets []*entity.AccessGroupTarget
+	for rows.Next() {
+		var t entity.AccessGroupTarget
+		if err := rows.Scan(&t.ID, &t.AccessGroupID, &t.DatabaseInstanceID, &t.DatabaseID); err != nil {
+			return nil, err
+		}
+		targets = append(targets, &t)
+	}
+	return targets, nil
+}
+
+func (ags *PostgresAccessGroupStorage) FindAllTargetDTOs(accessGroupID string) ([]dto.AccessGroupTargetOutputDTO, error) {
+	query := `
+SELECT t.database_instance_id, di.name, t.database_id, db.name
+FROM access_group_targets t
+	JOIN database_instances di
+		ON t.database_instance_id = di.id
+	LEFT JOIN databases db
+		ON t.database_id = db.id
+WHERE t.access_group_id = $1
+ORDER BY di.name, db.name NULLS FIRST`
+	rows, err := ags.DB.Query(query, accessGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var targets []dto.AccessGroupTargetOutputDTO
+	for rows.Next() {
+		var t dto.AccessGroupTargetOutputDTO
+		if err := rows.Scan(&t.DatabaseInstanceID, &t.DatabaseInstanceName, &t.DatabaseID, &t.DatabaseName); err != nil {
+			return nil, err
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// SaveMembers godoc
+// Adds the members to their groups in a single transaction. A user that is already a member of the group is kept as is.
+func (ags *PostgresAccessGroupStorage) SaveMembers(members []*entity.AccessGroupMember) error {
+	return inTransaction(ags.DB, func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`
+INSERT INTO access_group_members (access_group_id, database_user_id, added_by_user_id, added_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (access_group_id, database_user_id) DO NOTHING`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stmt.Close() }()
+		for _, m := range members {
+			if _, err = stmt.Exec(m.AccessGroupID, m.DatabaseUserID, m.AddedByUserID, m.AddedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMember godoc
+// Removes the member from the group, returning false when the user was not a member of the group
+func (ags *PostgresAccessGroupStorage) DeleteMember(accessGroupID, databaseUserID string) (bool, error) {
+	result, err := ags.DB.Exec(`DELETE FROM access_group_members WHERE access_group_id = $1 AND database_user_id = $2`, accessGroupID, databaseUserID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (ags *PostgresAccessGroupStorage) FindAllMemberDTOs(accessGroupID string) ([]dto.AccessGroupMemberOutputDTO, error) {
+	query := `
+SELECT m.database_user_id, db_user.name, db_user.email, db_user.username, m.added_by_user_id, au.name, m.added_at
+FROM access_group_members m
+	JOIN database_users db_user
+		ON m.database_user_id = db_user.id
+	JOIN application_users au
+		ON m.added_by_user_id = au.id
+WHERE m.access_group_id = $1
+ORDER BY db_user.name`
+	rows, err := ags.DB.Query(query, accessGroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var members []dto.AccessGroupMemberOutputDTO
+	for rows.Next() {
+		var m dto.AccessGroupMemberOutputDTO
+		err := rows.Scan(
+			&m.DatabaseUserID,
+			&m.DatabaseUserName,
+			&m.DatabaseUserEmail,
+			&m.Username,
+			&m.AddedByUserID,
+			&m.AddedByUser,
+			&m.AddedAt)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func (ags *PostgresAccessGroupStorage) baseQueryDTO() string {
+	return `
+SELECT
+	ag.id,
+	ag.name,
+	ag.description,
+	ag.created_by_user_id,
+	au.name,
+	ag.created_at,
+	ag.updated_at,
+	(SELECT COUNT(*) FROM access_group_targets t WHERE t.access_group_id = ag.id),
+	(SELECT COUNT(*) FROM access_group_members m WHERE m.access_group_id = ag.id)
+FROM access_groups ag
+	JOIN application_users au
+		ON ag.created_by_user_id = au.id`
+}
+
+func (ags *PostgresAccessGroupStorage) scanDTO(row interface{ Scan(dest ...any) error }) (*dto.AccessGroupOutputDTO, error) {
+	var d dto.AccessGroupOutputDTO
+	err := row.Scan(
+		&d.ID,
+		&d.Name,
+		&d.Description,
+		&d.CreatedByUserID,
+		&d.CreatedByUser,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.TargetsQty,
+		&d.MembersQty)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
@@ -16,17 +19,53 @@ func NewPostgresAccessPermissionStorage(db *sql.DB) *PostgresAccessPermissionSto
 }
 
 func (ar *PostgresAccessPermissionStorage) Save(d *entity.AccessPermission) error {
-	query := `INSERT INTO access_permissions (id, database_id, database_user_id, granted_by_user_id, granted_at) VALUES ($1, $2, $3, $4, $5)`
+	query := `INSERT INTO access_permissions (id, database_id, database_user_id, granted_by_user_id, granted_at, expires_at, database_role_id, scope_schemas, scope_tables, granted_by_access_group_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 	_, err := ar.db.Exec(
 		query,
 		d.ID,
 		d.DatabaseID,
 		d.DatabaseUserID,
 		d.GrantedByUserID,
-		d.GrantedAt)
+		d.GrantedAt,
+		d.ExpiresAt,
+		d.DatabaseRoleID,
+		pq.Array(nonNilStrings(d.Scope.Schemas)),
+		pq.Array(nonNilStrings(d.Scope.Tables)),
+		d.GrantedByAccessGroupID)
+	return err
+}
+
+// UpdateDatabaseRole godoc
+// Changes the role the user has in the database of the permission. An invalid role id means the user's own role.
+func (ar *PostgresAccessPermissionStorage) UpdateDatabaseRole(id string, databaseRoleID sql.NullString) error {
+	query := `UPDATE access_permissions SET database_role_id = $1 WHERE id = $2`
+	_, err := ar.db.Exec(query, databaseRoleID, id)
+	return err
+}
+
+// UpdateExpiresAt godoc
+// Changes when the permission of the user in the database expires. A nil expiration means it never expires.
+func (ar *PostgresAccessPermissionStorage) UpdateExpiresAt(databaseID, databaseUserID string, expiresAt *time.Time) error {
+	query := `UPDATE access_permissions SET expires_at = $1 WHERE database_id = $2 AND database_user_id = $3`
+	_, err := ar.db.Exec(query, expiresAt, databaseID, databaseUserID)
 	return err
 }
 
+// UpdateAccessGroup godoc
+// Changes the access group that granted the permission of the user in the database. An invalid id means the permission
+// is granted directly.
+func (ar *PostgresAccessPermissionStorage) UpdateAccessGroup(databaseID, databaseUserID string, accessGroupID sql.NullString) error {
+	query := `UPDATE access_permissions SET granted_by_access_group_id = $1 WHERE database_id = $2 AND database_user_id = $3`
+	_, err := ar.db.Exec(query, accessGroupID, databaseID, databaseUserID)
+	return err
+}
+
+func (ar *PostgresAccessPermissionStorage) FindDTOByID(id string) (*dto.AccessPermissionOutputDTO, error) {
+	query := ar.baseQueryDTO() + ` AND ap.id = $1`
+	return ar.scanDTO(ar.db.QueryRow(query, id))
+}
+
 func (ar *PostgresAccessPermissionStorage) Exists(databaseID, databaseUserID string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM access_permissions WHERE database_id = $1 AND database_user_id = $2)`
 
@@ -45,7 +84,23 @@ func (ar *PostgresAccessPermissionStorage) FindAllDTOs(databaseID, databaseUserI
 	baseQuery, args = addFilterCondition(baseQuery, args, "ap.database_user_id", databaseUserID)
 	baseQuery, args = addFilterCondition(baseQuery, args, "di.id", databaseInstanceID)
 	baseQuery += " ORDER BY db_user.name, ap.granted_at"
-	rows, err := ar.db.Query(baseQuery, args...)
+	return ar.queryDTOs(baseQuery, args)
+}
+
+// FindAllDTOsByScope godoc
+// Finds the permissions of the instances of an ecosystem and/or of the users of a team, ordered by user, or all
+// permissions when none is informed
+func (ar *PostgresAccessPermissionStorage) FindAllDTOsByScope(ecosystemID, team string) ([]*dto.AccessPermissionOutputDTO, error) {
+	baseQuery := ar.baseQueryDTO()
+	var args []any
+	baseQuery, args = addFilterCondition(baseQuery, args, "e.id", ecosystemID)
+	baseQuery, args = addFilterCondition(baseQuery, args, "db_user.team", team)
+	baseQuery += " ORDER BY db_user.name, ap.database_user_id, di.name, db.name"
+	return ar.queryDTOs(baseQuery, args)
+}
+
+func (ar *PostgresAccessPermissionStorage) queryDTOs(query string, args []any) ([]*dto.AccessPermissionOutputDTO, error) {
+	rows, err := ar.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -53,32 +108,93 @@ func (ar *PostgresAccessPermissionStorage) FindAllDTOs(databaseID, databaseUserI
 
 	var accessDTOs []*dto.AccessPermissionOutputDTO
 	for rows.Next() {
-		var d dto.AccessPermissionOutputDTO
-		err := rows.Scan(
-			&d.ID,
-			&d.DatabaseUserID,
-			&d.DatabaseUserName,
-			&d.DatabaseUserEmail,
-			&d.DatabaseRoleID,
-			&d.DatabaseRoleName,
-			&d.EcosystemID,
-			&d.EcosystemName,
-			&d.DatabaseInstanceID,
-			&d.DatabaseInstanceName,
-			&d.DatabaseID,
-			&d.DatabaseName,
-			&d.GrantedByUserID,
-			&d.GrantedByUserName,
-			&d.GrantedAt)
+		d, err := ar.scanDTO(rows)
 		if err != nil {
 			return nil, err
 		}
-		accessDTOs = append(accessDTOs, &d)
+		accessDTOs = append(accessDTOs, d)
 	}
 
 	return accessDTOs, nil
 }
 
+func (ar *PostgresAccessPermissionStorage) scanDTO(row interface{ Scan(dest ...any) error }) (*dto.AccessPermissionOutputDTO, error) {
+	var d dto.AccessPermissionOutputDTO
+	var scope dto.AccessScopeDTO
+	err := row.Scan(
+		&d.ID,
+		&d.DatabaseUserID,
+		&d.DatabaseUserName,
+		&d.DatabaseUserEmail,
+		&d.DatabaseUserTeam,
+		&d.DatabaseRoleID,
+		&d.DatabaseRoleName,
+		&d.EcosystemID,
+		&d.EcosystemName,
+		&d.DatabaseInstanceID,
+		&d.DatabaseInstanceName,
+		&d.DatabaseID,
+		&d.DatabaseName,
+		&d.GrantedByUserID,
+		&d.GrantedByUserName,
+		&d.GrantedAt,
+		&d.ExpiresAt,
+		pq.Array(&scope.Schemas),
+		pq.Array(&scope.Tables),
+		&d.GrantedByAccessGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(scope.Schemas) > 0 || len(scope.Tables) > 0 {
+		d.Scope = &scope
+	}
+	return &d, nil
+}
+
+// FindAllExpiredDTOs godoc
+// Finds the permissions that have expired at the given time, ordered by user and instance
+func (ar *PostgresAccessPermissionStorage) FindAllExpiredDTOs(now time.Time) ([]*dto.AccessPermissionOutputDTO, error) {
+	query := ar.baseQueryDTO() + ` AND ap.expires_at <= $1 ORDER BY ap.database_user_id, di.id, db.name`
+	return ar.queryDTOs(query, []any{now})
+}
+
+// FindAllScopedInstancesIDsByUsers godoc
+// Finds the instances where each of the users has any permission scoped to schemas or tables
+func (ar *PostgresAccessPermissionStorage) FindAllScopedInstancesIDsByUsers(userIDs []string) (map[string][]string, error) {
+	query := `
+SELECT DISTINCT ap.database_user_id, db.database_instance_id
+FROM access_permissions ap
+	JOIN databases db
+		ON ap.database_id = db.id
+WHERE ap.database_user_id = ANY ($1)
+  AND (CARDINALITY(ap.scope_schemas) > 0 OR CARDINALITY(ap.scope_tables) > 0)
+ORDER BY ap.database_user_id`
+
+	rows, err := ar.db.Query(query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	instancesIDsByUser := make(map[string][]string)
+	for rows.Next() {
+		var userID, instanceID string
+		if err := rows.Scan(&userID, &instanceID); err != nil {
+			return nil, err
+		}
+		instancesIDsByUser[userID] = append(instancesIDsByUser[userID], instanceID)
+	}
+	return instancesIDsByUser, nil
+}
+
+func (ar *PostgresAccessPermissionStorage) Delete(id string) error {
+	query := `DELETE FROM access_permissions WHERE id = $1`
+	_, err := ar.db.Exec(query, id)
+	return err
+}
+
 func (ar *PostgresAccessPermissionStorage) DeleteAllByUserAndInstance(databaseUserID, instanceID string) error {
 	query := `DELETE FROM access_permissions WHERE database_user_id = $1 AND database_id IN (SELECT id FROM databases WHERE database_instance_id = $2)`
 	_, err := ar.db.Exec(query, databaseUserID, instanceID)
@@ -92,11 +208,12 @@ func (ar *PostgresAccessPermissionStorage) DeleteAllByInstance(instanceID string
 }
 
 func (ar *PostgresAccessPermissionStorage) SaveLog(log *entity.AccessPermissionLog) error {
-	query := `INSERT INTO access_permission_log (id, database_instance_id, database_user_id, database_id, message, success, date, user_id) 
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	query := `INSERT INTO access_permission_log (id, type, database_instance_id, database_user_id, database_id, message, success, date, user_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 	_, err := ar.db.Exec(
 		query,
 		log.ID,
+		log.Type,
 		log.DatabaseInstanceID,
 		log.DatabaseUserID,
 		log.DatabaseID,
@@ -139,10 +256,14 @@ WHERE ap.database_user_id = $1`
 	return instanceIDs, nil
 }
 
-func (ar *PostgresAccessPermissionStorage) FindAllLogsDTOs(page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error) {
+func (ar *PostgresAccessPermissionStorage) FindAllLogsDTOs(logType string, page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error) {
 	var logs []*dto.AccessPermissionLogOutputDTO
-	query := ar.baseQueryLogDTO()
-	rows, err := ar.db.Query(query, (page-1)*limit, limit)
+	query := ar.baseQueryLogDTO() + ` WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "log.type", logType)
+	query += " ORDER BY log.date DESC"
+	query, args = appendPagination(query, args, page, limit)
+	rows, err := ar.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +276,7 @@ func (ar *PostgresAccessPermissionStorage) FindAllLogsDTOs(page, limit int) ([]*
 		var log dto.AccessPermissionLogOutputDTO
 		err := rows.Scan(
 			&log.ID,
+			&log.Type,
 			&log.DatabaseUserID,
 			&log.DatabaseUserName,
 			&log.DatabaseUserEmail,
@@ -186,10 +308,12 @@ func (ar *PostgresAccessPermissionStorage) CheckIfUserHasAccessPermission(databa
 	return exists, nil
 }
 
-func (ar *PostgresAccessPermissionStorage) LogCount() (int, error) {
-	query := `SELECT COUNT(*) FROM access_permission_log`
+func (ar *PostgresAccessPermissionStorage) LogCount(logType string) (int, error) {
+	query := `SELECT COUNT(*) FROM access_permission_log log WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "log.type", logType)
 	var count int
-	err := ar.db.QueryRow(query).Scan(&count)
+	err := ar.db.QueryRow(query, args...).Scan(&count)
 	if err != nil {
 		return 0, err
 	}
@@ -203,6 +327,7 @@ SELECT
        ap.database_user_id,
        db_user.name,
        db_user.email,
+       COALESCE(db_user.team, ''),
        COALESCE(ap.database_role_id, db_user.database_role_id),
        COALESCE(role_from_access.display_name, role.display_name),
        e.id,
@@ -213,7 +338,11 @@ SELECT
        db.name,
        ap.granted_by_user_id,
        op_user.name,
-       ap.granted_at
+       ap.granted_at,
+       ap.expires_at,
+       ap.scope_schemas,
+       ap.scope_tables,
+       COALESCE(ap.granted_by_access_group_id::text, '')
 FROM access_permissions ap
 	JOIN databases db
 		ON ap.database_id = db.id
@@ -236,6 +365,7 @@ func (ar *PostgresAccessPermissionStorage) baseQueryLogDTO() string {
 	return `
 SELECT
 	log.id,
+	log.type,
 	log.database_user_id,
 	db_user.name,
 	db_user.email,
@@ -256,6 +386,14 @@ FROM access_permission_log log
 	LEFT JOIN database_users db_user
 		ON log.database_user_id = db_user.id
 	LEFT JOIN databases db
-		ON log.database_id = db.id
-ORDER BY log.date DESC OFFSET $1 LIMIT $2`
+		ON log.database_id = db.id`
+}
+
+// nonNilStrings godoc
+// pq sends nil slices as NULL, so the empty arrays of the NOT NULL columns are sent as empty slices
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
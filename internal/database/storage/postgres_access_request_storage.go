@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresAccessRequestStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresAccessRequestStorage(db *sql.DB) *PostgresAccessRequestStorage {
+	return &PostgresAccessRequestStorage{DB: db}
+}
+
+func (ars *PostgresAccessRequestStorage) Save(r *entity.AccessRequest) error {
+	query := `
+INSERT INTO access_requests (id, status, input, justification, requested_by_user_id, created_at, updated_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := ars.DB.Exec(
+		query,
+		r.ID,
+		r.Status,
+		r.Input,
+		r.Justification,
+		r.RequestedByUserID,
+		r.CreatedAt,
+		r.UpdatedAt,
+		r.ExpiresAt)
+	return err
+}
+
+// Update godoc
+// Saves the request only if it's still in the status it was loaded with, returning false when it was changed by another
+// operation in the meantime, e.g. when two users review the same request at the same time
+func (ars *PostgresAccessRequestStorage) Update(r *entity.AccessRequest, previousStatus entity.AccessRequestStatus) (bool, error) {
+	query := `
+UPDATE access_requests
+SET status              = $1,
+	reviewed_by_user_id = $2,
+	review_comment      = $3,
+	result              = $4,
+	error               = $5,
+	updated_at          = $6,
+	reviewed_at         = $7,
+	executed_at         = $8
+WHERE id = $9
+	AND status = $10`
+	result, err := ars.DB.Exec(
+		query,
+		r.Status,
+		r.ReviewedByUserID,
+		r.ReviewComment,
+		r.Result,
+		r.Error,
+		r.UpdatedAt,
+		r.ReviewedAt,
+		r.ExecutedAt,
+		r.ID,
+		previousStatus)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (ars *PostgresAccessRequestStorage) FindByID(id string) (*entity.AccessRequest, error) {
+	query := `
+SELECT id, status, input, justification, requested_by_user_id, reviewed_by_user_id, review_comment, result, error,
+	created_at, updated_at, expires_at, reviewed_at, executed_at
+FROM access_requests
+WHERE id = $1`
+	var r entity.AccessRequest
+	err := ars.DB.QueryRow(query, id).Scan(
+		&r.ID,
+		&r.Status,
+		&r.Input,
+		&r.Justification,
+		&r.RequestedByUserID,
+		&r.ReviewedByUserID,
+		&r.ReviewComment,
+		&r.Result,
+		&r.Error,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.ExpiresAt,
+		&r.ReviewedAt,
+		&r.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (ars *PostgresAccessRequestStorage) FindDTOByID(id string) (*dto.AccessRequestOutputDTO, error) {
+	query := ars.baseQueryDTO(true) + ` WHERE ar.id = $1`
+	d, err := ars.scanDTO(ars.DB.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// FindAllDTOs godoc
+// Lists the requests from the most recent, without the results of their execution, that are only loaded by FindDTOByID
+func (ars *PostgresAccessRequestStorage) FindAllDTOs(status, requestedByUserID string, page, limit int) ([]*dto.AccessRequestOutputDTO, error) {
+	query := ars.baseQueryDTO(false) + ` WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "ar.status", status)
+	query, args = addFilterCondition(query, args, "ar.requested_by_user_id", requestedByUserID)
+	query += " ORDER BY ar.created_at DESC"
+	query, args = appendPagination(query, args, page, limit)
+	rows, err := ars.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var accessRequests []*dto.AccessRequestOutputDTO
+	for rows.Next() {
+		d, err := ars.scanDTO(rows)
+		if err != nil {
+			return nil, err
+		}
+		accessRequests = append(accessRequests, d)
+	}
+	return accessRequests, nil
+}
+
+func (ars *PostgresAccessRequestStorage) Count(status, requestedByUserID string) (int, error) {
+	query := `SELECT COUNT(*) FROM access_requests ar WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "ar.status", status)
+	query, args = addFilterCondition(query, args, "ar.requested_by_user_id", requestedByUserID)
+	var count int
+	err := ars.DB.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ExpireAllPending godoc
+// Marks as expired the requests still waiting for review whose expiration is due, returning how many were expired
+func (ars *PostgresAccessRequestStorage) ExpireAllPending(now time.Time) (int64, error) {
+	query := `
+UPDATE access_requests
+SET status     = $1,
+	updated_at = $2
+WHERE status = $3
+	AND expires_at <= $2`
+	result, err := ars.DB.Exec(query, entity.AccessRequestStatusExpired, now, entity.AccessRequestStatusRequested)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (ars *PostgresAccessRequestStorage) baseQueryDTO(withResult bool) string {
+	resultColumn := "NULL"
+	if withResult {
+		resultColumn = "ar.result"
+	}
+	return `
+SELECT
+	ar.id,
+	ar.status,
+	ar.input,
+	ar.justification,
+	ar.requested_by_user_id,
+	ru.name,
+	ar.reviewed_by_user_id,
+	vu.name,
+	ar.review_comment,
+	` + resultColumn + `,
+	ar.error,
+	ar.created_at,
+	ar.updated_at,
+	ar.expires_at,
+	ar.reviewed_at,
+	ar.executed_at
+FROM access_requests ar
+	JOIN application_users ru
+		ON ar.requested_by_user_id = ru.id
+	LEFT JOIN application_users vu
+		ON ar.reviewed_by_user_id = vu.id`
+}
+
+func (ars *PostgresAccessRequestStorage) scanDTO(row interface{ Scan(dest ...any) error }) (*dto.AccessRequestOutputDTO, error) {
+	var d dto.AccessRequestOutputDTO
+	var input string
+	var result sql.NullString
+	err := row.Scan(
+		&d.ID,
+		&d.Status,
+		&input,
+		&d.Justification,
+		&d.RequestedByUserID,
+		&d.RequestedByUser,
+		&d.ReviewedByUserID,
+		&d.ReviewedByUser,
+		&d.ReviewComment,
+		&result,
+		&d.Error,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.ExpiresAt,
+		&d.ReviewedAt,
+		&d.ExecutedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Input = json.RawMessage(input)
+	if result.Valid {
+		d.Result = json.RawMessage(result.String)
+	}
+	return &d, nil
+}
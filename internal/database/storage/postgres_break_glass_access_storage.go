@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresBreakGlassAccessStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresBreakGlassAccessStorage(db *sql.DB) *PostgresBreakGlassAccessStorage {
+	return &PostgresBreakGlassAccessStorage{DB: db}
+}
+
+func (bgs *PostgresBreakGlassAccessStorage) Save(b *entity.BreakGlassAccess) error {
+	query := `
+INSERT INTO break_glass_access (id, database_id, database_user_id, incident_reference, role_granted, granted_by_user_id, granted_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := bgs.DB.Exec(
+		query,
+		b.ID,
+		b.DatabaseID,
+		b.DatabaseUserID,
+		b.IncidentReference,
+		b.RoleGranted,
+		b.GrantedByUserID,
+		b.GrantedAt,
+		b.ExpiresAt)
+	return err
+}
+
+// FindAllExpiredDTOs godoc
+// Lists the accesses not revoked yet whose window closed, only of the users with no access still open to the same
+// database, since the role granted to the user in the database is the same
+func (bgs *PostgresBreakGlassAccessStorage) FindAllExpiredDTOs(now time.Time) ([]*dto.BreakGlassAccessOutputDTO, error) {
+	query := `
+SELECT
+	bga.id,
+	bga.database_user_id,
+	db_user.username,
+	db.database_instance_id,
+	bga.database_id,
+	db.name,
+	bga.incident_reference,
+	bga.role_granted,
+	bga.granted_by_user_id,
+	bga.granted_at,
+	bga.expires_at,
+	bga.revoked_at
+FROM break_glass_access bga
+	JOIN databases db
+		ON bga.database_id = db.id
+	JOIN database_users db_user
+		ON bga.database_user_id = db_user.id
+WHERE bga.revoked_at IS NULL
+	AND bga.expires_at <= $1
+	AND NOT EXISTS (SELECT 1
+					FROM break_glass_access open_bga
+					WHERE open_bga.database_user_id = bga.database_user_id
+						AND open_bga.database_id = bga.database_id
+						AND open_bga.revoked_at IS NULL
+						AND open_bga.expires_at > $1)
+ORDER BY bga.expires_at`
+	rows, err := bgs.DB.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var accesses []*dto.BreakGlassAccessOutputDTO
+	for rows.Next() {
+		var b dto.BreakGlassAccessOutputDTO
+		var revokedAt sql.NullTime
+		err := rows.Scan(
+			&b.ID,
+			&b.DatabaseUserID,
+			&b.DatabaseUsername,
+			&b.DatabaseInstanceID,
+			&b.DatabaseID,
+			&b.DatabaseName,
+			&b.IncidentReference,
+			&b.RoleGranted,
+			&b.GrantedByUserID,
+			&b.GrantedAt,
+			&b.ExpiresAt,
+			&revokedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if revokedAt.Valid {
+			b.RevokedAt = &revokedAt.Time
+		}
+		accesses = append(accesses, &b)
+	}
+	return accesses, rows.Err()
+}
+
+func (bgs *PostgresBreakGlassAccessStorage) UpdateRevokedAt(id string, revokedAt time.Time) error {
+	_, err := bgs.DB.Exec(`UPDATE break_glass_access SET revoked_at = $1 WHERE id = $2`, revokedAt, id)
+	return err
+}
+
+// UpdateRevokedAtByUserAndInstance godoc
+// Closes all the accesses still open of the user in the databases of the instance
+func (bgs *PostgresBreakGlassAccessStorage) UpdateRevokedAtByUserAndInstance(databaseUserID, databaseInstanceID string, revokedAt time.Time) error {
+	query := `
+UPDATE break_glass_access
+SET revoked_at = $1
+WHERE database_user_id = $2
+	AND revoked_at IS NULL
+	AND database_id IN (SELECT id FROM databases WHERE database_instance_id = $3)`
+	_, err := bgs.DB.Exec(query, revokedAt, databaseUserID, databaseInstanceID)
+	return err
+}
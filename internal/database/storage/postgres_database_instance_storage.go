@@ -110,6 +110,16 @@ SELECT di.id,
        hci.port_connection,
        hci.admin_username,
        hci.admin_password,
+       hci.ssl_mode,
+       hci.ca_certificate,
+       hci.client_certificate,
+       hci.client_key,
+       hci.bastion_host,
+       hci.bastion_port,
+       hci.bastion_user,
+       hci.bastion_private_key,
+       hci.bastion_password,
+       hci.bastion_host_key,
        ecosystem_id, 
        database_technology_id, 
        enabled,
@@ -135,6 +145,16 @@ WHERE di.id = $1`, id).
 			&hostConnection.PortConnection,
 			&hostConnection.AdminUser,
 			&hostConnection.AdminPassword,
+			&hostConnection.SSLMode,
+			&hostConnection.CACertificate,
+			&hostConnection.ClientCertificate,
+			&hostConnection.ClientKey,
+			&hostConnection.BastionHost,
+			&hostConnection.BastionPort,
+			&hostConnection.BastionUser,
+			&hostConnection.BastionPrivateKey,
+			&hostConnection.BastionPassword,
+			&hostConnection.BastionHostKey,
 			&databaseInstance.EcosystemID,
 			&databaseInstance.DatabaseTechnologyID,
 			&databaseInstance.Enabled,
@@ -170,6 +190,16 @@ func (dir *PostgresDatabaseInstanceStorage) FindDTOByID(id string) (*dto.Databas
 			&output.PortConnection,
 			&output.AdminUser,
 			&output.AdminPassword,
+			&output.SSLMode,
+			&output.CACertificate,
+			&output.ClientCertificate,
+			&output.ClientKey,
+			&output.BastionHost,
+			&output.BastionPort,
+			&output.BastionUser,
+			&output.BastionPrivateKey,
+			&output.BastionPassword,
+			&output.BastionHostKey,
 			&output.EcosystemID,
 			&output.EcosystemName,
 			&output.DatabaseTechnologyID,
@@ -233,11 +263,11 @@ func (dir *PostgresDatabaseInstanceStorage) FindAllDTOsEnabled(ecosystemID, tech
 }
 
 func (dir *PostgresDatabaseInstanceStorage) insertHostConnectionInfo(h *entity.HostConnectionInfo) error {
-	stmt, err := dir.Uow.transaction.Prepare("INSERT INTO host_connection_info (id, host, port, host_connection, port_connection, admin_username, admin_password) VALUES ($1, $2, $3, $4, $5, $6, $7)")
+	stmt, err := dir.Uow.transaction.Prepare("INSERT INTO host_connection_info (id, host, port, host_connection, port_connection, admin_username, admin_password, ssl_mode, ca_certificate, client_certificate, client_key, bastion_host, bastion_port, bastion_user, bastion_private_key, bastion_password, bastion_host_key) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(h.ID, h.Host, h.Port, h.HostConnection, h.PortConnection, h.AdminUser, h.AdminPassword)
+	_, err = stmt.Exec(h.ID, h.Host, h.Port, h.HostConnection, h.PortConnection, h.AdminUser, h.AdminPassword, h.SSLMode, h.CACertificate, h.ClientCertificate, h.ClientKey, h.BastionHost, h.BastionPort, h.BastionUser, h.BastionPrivateKey, h.BastionPassword, h.BastionHostKey)
 	if err != nil {
 		return err
 	}
@@ -257,11 +287,11 @@ func (dir *PostgresDatabaseInstanceStorage) insertDatabaseInstance(di *entity.Da
 }
 
 func (dir *PostgresDatabaseInstanceStorage) updateHostConnectionInfo(h *entity.HostConnectionInfo) error {
-	stmt, err := dir.Uow.transaction.Prepare("UPDATE host_connection_info SET host = $2, port = $3, host_connection = $4, port_connection = $5, admin_username = $6, admin_password = $7 WHERE id = $1")
+	stmt, err := dir.Uow.transaction.Prepare("UPDATE host_connection_info SET host = $2, port = $3, host_connection = $4, port_connection = $5, admin_username = $6, admin_password = $7, ssl_mode = $8, ca_certificate = $9, client_certificate = $10, client_key = $11, bastion_host = $12, bastion_port = $13, bastion_user = $14, bastion_private_key = $15, bastion_password = $16, bastion_host_key = $17 WHERE id = $1")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(h.ID, h.Host, h.Port, h.HostConnection, h.PortConnection, h.AdminUser, h.AdminPassword)
+	_, err = stmt.Exec(h.ID, h.Host, h.Port, h.HostConnection, h.PortConnection, h.AdminUser, h.AdminPassword, h.SSLMode, h.CACertificate, h.ClientCertificate, h.ClientKey, h.BastionHost, h.BastionPort, h.BastionUser, h.BastionPrivateKey, h.BastionPassword, h.BastionHostKey)
 	if err != nil {
 		return err
 	}
@@ -313,8 +343,19 @@ func scanDatabaseInstance(rows *sql.Rows) (dto.DatabaseInstanceOutputDTO, error)
 		&dbInstance.PortConnection,
 		&dbInstance.AdminUser,
 		&dbInstance.AdminPassword,
+		&dbInstance.SSLMode,
+		&dbInstance.CACertificate,
+		&dbInstance.ClientCertificate,
+		&dbInstance.ClientKey,
+		&dbInstance.BastionHost,
+		&dbInstance.BastionPort,
+		&dbInstance.BastionUser,
+		&dbInstance.BastionPrivateKey,
+		&dbInstance.BastionPassword,
+		&dbInstance.BastionHostKey,
 		&dbInstance.EcosystemID,
 		&dbInstance.EcosystemName,
+		&dbInstance.EcosystemRequiresApproval,
 		&dbInstance.DatabaseTechnologyID,
 		&dbInstance.DatabaseTechnologyName,
 		&dbInstance.DatabaseTechnologyVersion,
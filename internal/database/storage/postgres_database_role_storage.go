@@ -2,10 +2,13 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 )
 
+const databaseRoleColumns = `id, name, display_name, description, read_only, privileges, created_at, updated_at, created_by_user_id`
+
 type PostgresDatabaseRoleStorage struct {
 	db *sql.DB
 }
@@ -14,8 +17,70 @@ func NewPostgresDatabaseRoleStorage(db *sql.DB) *PostgresDatabaseRoleStorage {
 	return &PostgresDatabaseRoleStorage{db: db}
 }
 
+func (r *PostgresDatabaseRoleStorage) Save(role *entity.DatabaseRole) error {
+	privileges, err := json.Marshal(role.Privileges)
+	if err != nil {
+		return err
+	}
+	stmt, err := r.db.Prepare(`INSERT INTO database_roles (id, name, display_name, description, read_only, privileges, created_at, updated_at, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(role.ID, role.Name, role.DisplayName, role.Description, role.ReadOnly, string(privileges), role.CreatedAt, role.UpdatedAt, role.CreatedByUserID)
+	return err
+}
+
+func (r *PostgresDatabaseRoleStorage) Update(role *entity.DatabaseRole) error {
+	privileges, err := json.Marshal(role.Privileges)
+	if err != nil {
+		return err
+	}
+	stmt, err := r.db.Prepare("UPDATE database_roles SET display_name = $2, description = $3, read_only = $4, privileges = $5, updated_at = $6 WHERE id = $1")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(role.ID, role.DisplayName, role.Description, role.ReadOnly, string(privileges), role.UpdatedAt)
+	return err
+}
+
+func (r *PostgresDatabaseRoleStorage) Delete(id string) error {
+	_, err := r.FindByID(id)
+	if err != nil {
+		return err
+	}
+	stmt, err := r.db.Prepare("DELETE FROM database_roles WHERE id = $1")
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(id)
+	return err
+}
+
+func (r *PostgresDatabaseRoleStorage) CheckNameExists(name string) (bool, error) {
+	var nameExists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM database_roles WHERE name = $1) AS exists", name).Scan(&nameExists)
+	if err != nil {
+		return false, err
+	}
+	return nameExists, nil
+}
+
+// IsInUse godoc
+// Checks if any database user has the role as its own role or any access permission has it as a role of its own
+func (r *PostgresDatabaseRoleStorage) IsInUse(id string) (bool, error) {
+	query := `
+SELECT EXISTS(SELECT 1 FROM database_users WHERE database_role_id = $1)
+	OR EXISTS(SELECT 1 FROM access_permissions WHERE database_role_id = $1)`
+	var inUse bool
+	if err := r.db.QueryRow(query, id).Scan(&inUse); err != nil {
+		return false, err
+	}
+	return inUse, nil
+}
+
 func (r *PostgresDatabaseRoleStorage) FindAll() ([]*entity.DatabaseRole, error) {
-	query := `SELECT id, name, display_name, description, read_only, created_at, created_by_user_id FROM database_roles`
+	query := `SELECT ` + databaseRoleColumns + ` FROM database_roles ORDER BY created_at, name`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -25,25 +90,31 @@ func (r *PostgresDatabaseRoleStorage) FindAll() ([]*entity.DatabaseRole, error)
 
 	var roles []*entity.DatabaseRole
 	for rows.Next() {
-		var role entity.DatabaseRole
-		err := rows.Scan(&role.ID, &role.Name, &role.DisplayName, &role.Description, &role.ReadOnly, &role.CreatedAt, &role.CreatedByUserID)
+		role, err := scanDatabaseRole(rows)
 		if err != nil {
 			return nil, err
 		}
-		roles = append(roles, &role)
+		roles = append(roles, role)
 	}
 
-	return roles, nil
+	return roles, rows.Err()
 }
 
 func (r *PostgresDatabaseRoleStorage) FindByID(id string) (*entity.DatabaseRole, error) {
-	query := `SELECT id, name, display_name, description, read_only, created_at, created_by_user_id FROM database_roles WHERE id = $1`
+	query := `SELECT ` + databaseRoleColumns + ` FROM database_roles WHERE id = $1`
+
+	return scanDatabaseRole(r.db.QueryRow(query, id))
+}
 
+func scanDatabaseRole(row interface{ Scan(...any) error }) (*entity.DatabaseRole, error) {
 	var role entity.DatabaseRole
-	err := r.db.QueryRow(query, id).Scan(&role.ID, &role.Name, &role.DisplayName, &role.Description, &role.ReadOnly, &role.CreatedAt, &role.CreatedByUserID)
+	var privileges string
+	err := row.Scan(&role.ID, &role.Name, &role.DisplayName, &role.Description, &role.ReadOnly, &privileges, &role.CreatedAt, &role.UpdatedAt, &role.CreatedByUserID)
 	if err != nil {
 		return nil, err
 	}
-
+	if err = json.Unmarshal([]byte(privileges), &role.Privileges); err != nil {
+		return nil, err
+	}
 	return &role, nil
 }
@@ -17,8 +17,9 @@ func NewPostgresDatabaseStorage(db *sql.DB) *PostgresDatabaseStorage {
 }
 
 func (r *PostgresDatabaseStorage) Save(database *entity.Database) error {
-	query := `INSERT INTO databases (id, name, description, current_size, enabled, roles_configured, database_instance_id, created_at, created_by_user_id, updated_at)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	query := `INSERT INTO databases (id, name, description, current_size, enabled, roles_configured, database_instance_id, created_at, created_by_user_id, updated_at,
+                       roles_template_version, roles_template_checksum, roles_configured_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 	_, err := r.db.Exec(
 		query,
 		database.ID,
@@ -30,7 +31,10 @@ func (r *PostgresDatabaseStorage) Save(database *entity.Database) error {
 		database.DatabaseInstanceID,
 		database.CreatedAt,
 		database.CreatedByUserID,
-		database.UpdatedAt)
+		database.UpdatedAt,
+		database.RolesTemplateVersion,
+		database.RolesTemplateChecksum,
+		database.RolesConfiguredAt)
 	return err
 }
 
@@ -45,8 +49,11 @@ SET name = $1,
     database_instance_id = $5,
     updated_at = $6,
     disabled_at = $7,
-    roles_configured = $8 
-WHERE id = $9`
+    roles_configured = $8,
+    roles_template_version = $9,
+    roles_template_checksum = $10,
+    roles_configured_at = $11
+WHERE id = $12`
 	_, err := r.db.Exec(
 		query,
 		database.Name,
@@ -57,6 +64,9 @@ WHERE id = $9`
 		database.UpdatedAt,
 		database.DisabledAt,
 		database.RolesConfigured,
+		database.RolesTemplateVersion,
+		database.RolesTemplateChecksum,
+		database.RolesConfiguredAt,
 		database.ID)
 	return err
 }
@@ -111,7 +121,10 @@ func (r *PostgresDatabaseStorage) FindDTOByID(id string) (*dto.DatabaseOutputDTO
 		&output.CreatedAt,
 		&output.UpdatedAt,
 		&output.LastDatabaseSync,
-		&output.DisabledAt)
+		&output.DisabledAt,
+		&output.RolesTemplateVersion,
+		&output.RolesTemplateChecksum,
+		&output.RolesConfiguredAt)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +143,10 @@ SELECT id,
        created_at,
        created_by_user_id,
        updated_at,
-       disabled_at
+       disabled_at,
+       roles_template_version,
+       roles_template_checksum,
+       roles_configured_at
 FROM databases db
 WHERE 1 = 1`
 
@@ -158,7 +174,10 @@ WHERE 1 = 1`
 			&database.CreatedAt,
 			&database.CreatedByUserID,
 			&database.UpdatedAt,
-			&database.DisabledAt)
+			&database.DisabledAt,
+			&database.RolesTemplateVersion,
+			&database.RolesTemplateChecksum,
+			&database.RolesConfiguredAt)
 		if err != nil {
 			return nil, err
 		}
@@ -244,6 +263,9 @@ func scanDatabase(rows *sql.Rows) (dto.DatabaseOutputDTO, error) {
 		&output.CreatedAt,
 		&output.UpdatedAt,
 		&output.LastDatabaseSync,
-		&output.DisabledAt)
+		&output.DisabledAt,
+		&output.RolesTemplateVersion,
+		&output.RolesTemplateChecksum,
+		&output.RolesConfiguredAt)
 	return output, err
 }
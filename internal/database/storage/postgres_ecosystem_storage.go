@@ -25,11 +25,11 @@ func (er *PostgresEcosystemStorage) CheckCodeExists(code string) (bool, error) {
 }
 
 func (er *PostgresEcosystemStorage) Save(e *entity.Ecosystem) error {
-	stmt, err := er.DB.Prepare("INSERT INTO ecosystems (id, code, display_name, created_at, updated_at, created_by_user_id) VALUES ($1, $2, $3, $4, $5, $6)")
+	stmt, err := er.DB.Prepare("INSERT INTO ecosystems (id, code, display_name, requires_access_approval, created_at, updated_at, created_by_user_id) VALUES ($1, $2, $3, $4, $5, $6, $7)")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(e.ID, e.Code, e.DisplayName, e.CreatedAt, e.UpdatedAt, e.CreatedByUserID)
+	_, err = stmt.Exec(e.ID, e.Code, e.DisplayName, e.RequiresAccessApproval, e.CreatedAt, e.UpdatedAt, e.CreatedByUserID)
 	if err != nil {
 		return err
 	}
@@ -37,11 +37,11 @@ func (er *PostgresEcosystemStorage) Save(e *entity.Ecosystem) error {
 }
 
 func (er *PostgresEcosystemStorage) Update(e *entity.Ecosystem) error {
-	stmt, err := er.DB.Prepare("UPDATE ecosystems SET code = $2, display_name = $3, updated_at = $4 WHERE id = $1")
+	stmt, err := er.DB.Prepare("UPDATE ecosystems SET code = $2, display_name = $3, requires_access_approval = $4, updated_at = $5 WHERE id = $1")
 	if err != nil {
 		return err
 	}
-	_, err = stmt.Exec(e.ID, e.Code, e.DisplayName, e.UpdatedAt)
+	_, err = stmt.Exec(e.ID, e.Code, e.DisplayName, e.RequiresAccessApproval, e.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -50,8 +50,8 @@ func (er *PostgresEcosystemStorage) Update(e *entity.Ecosystem) error {
 
 func (er *PostgresEcosystemStorage) FindByID(id string) (*entity.Ecosystem, error) {
 	var e entity.Ecosystem
-	err := er.DB.QueryRow("SELECT id, code, display_name, created_at, updated_at, created_by_user_id FROM ecosystems WHERE id = $1", id).
-		Scan(&e.ID, &e.Code, &e.DisplayName, &e.CreatedAt, &e.UpdatedAt, &e.CreatedByUserID)
+	err := er.DB.QueryRow("SELECT id, code, display_name, requires_access_approval, created_at, updated_at, created_by_user_id FROM ecosystems WHERE id = $1", id).
+		Scan(&e.ID, &e.Code, &e.DisplayName, &e.RequiresAccessApproval, &e.CreatedAt, &e.UpdatedAt, &e.CreatedByUserID)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +80,7 @@ func (er *PostgresEcosystemStorage) FindAll(page, limit int) ([]*dto.EcosystemOu
 SELECT e.id,
        code,
        display_name,
+       requires_access_approval,
        e.created_at,
        e.updated_at,
        u.name,
@@ -92,7 +93,7 @@ ORDER BY e.display_name OFFSET $1 LIMIT $2`, (page-1)*limit, limit)
 	}
 	for rows.Next() {
 		var e dto.EcosystemOutputDTO
-		err := rows.Scan(&e.ID, &e.Code, &e.DisplayName, &e.CreatedAt, &e.UpdatedAt, &e.CreatedByUser, &e.CreatedByUserID)
+		err := rows.Scan(&e.ID, &e.Code, &e.DisplayName, &e.RequiresAccessApproval, &e.CreatedAt, &e.UpdatedAt, &e.CreatedByUser, &e.CreatedByUserID)
 		if err != nil {
 			return nil, err
 		}
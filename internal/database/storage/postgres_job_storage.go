@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresJobStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresJobStorage(db *sql.DB) *PostgresJobStorage {
+	return &PostgresJobStorage{DB: db}
+}
+
+func (js *PostgresJobStorage) Save(job *entity.Job) error {
+	query := `INSERT INTO jobs (id, type, status, input, items, created_by_user_id, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := js.DB.Exec(
+		query,
+		job.ID,
+		job.Type,
+		job.Status,
+		job.Input,
+		job.Items,
+		job.CreatedByUserID,
+		job.CreatedAt,
+		job.UpdatedAt)
+	return err
+}
+
+func (js *PostgresJobStorage) Update(job *entity.Job) error {
+	query := `
+UPDATE jobs
+SET status          = $1,
+	total_items     = $2,
+	processed_items = $3,
+	failed_items    = $4,
+	items           = $5,
+	result          = $6,
+	error           = $7,
+	attempts        = $8,
+	updated_at      = $9,
+	started_at      = $10,
+	finished_at     = $11
+WHERE id = $12`
+	_, err := js.DB.Exec(
+		query,
+		job.Status,
+		job.TotalItems,
+		job.ProcessedItems,
+		job.FailedItems,
+		job.Items,
+		job.Result,
+		job.Error,
+		job.Attempts,
+		job.UpdatedAt,
+		job.StartedAt,
+		job.FinishedAt,
+		job.ID)
+	return err
+}
+
+func (js *PostgresJobStorage) FindByID(id string) (*entity.Job, error) {
+	query := js.baseQueryEntity() + ` WHERE id = $1`
+	return js.scanEntity(js.DB.QueryRow(query, id))
+}
+
+func (js *PostgresJobStorage) FindAllUnfinished() ([]*entity.Job, error) {
+	query := js.baseQueryEntity() + ` WHERE status IN ($1, $2) ORDER BY created_at`
+	rows, err := js.DB.Query(query, entity.JobStatusPending, entity.JobStatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var jobs []*entity.Job
+	for rows.Next() {
+		job, err := js.scanEntity(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (js *PostgresJobStorage) FindDTOByID(id string) (*dto.JobOutputDTO, error) {
+	query := `
+SELECT
+	j.id,
+	j.type,
+	j.status,
+	j.input,
+	j.total_items,
+	j.processed_items,
+	j.failed_items,
+	j.items,
+	j.result,
+	j.error,
+	j.attempts,
+	j.created_by_user_id,
+	u.name,
+	j.created_at,
+	j.updated_at,
+	j.started_at,
+	j.finished_at
+FROM jobs j
+	JOIN application_users u
+		ON j.created_by_user_id = u.id
+WHERE j.id = $1`
+
+	var d dto.JobOutputDTO
+	var input, items string
+	var result sql.NullString
+	err := js.DB.QueryRow(query, id).Scan(
+		&d.ID,
+		&d.Type,
+		&d.Status,
+		&input,
+		&d.TotalItems,
+		&d.ProcessedItems,
+		&d.FailedItems,
+		&items,
+		&result,
+		&d.Error,
+		&d.Attempts,
+		&d.CreatedByUserID,
+		&d.CreatedByUser,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.StartedAt,
+		&d.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Input = json.RawMessage(input)
+	if result.Valid {
+		d.Result = json.RawMessage(result.String)
+	}
+	if err = json.Unmarshal([]byte(items), &d.Items); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// FindAllDTOs godoc
+// Lists the jobs from the most recent, without their items and results, that are only loaded by FindDTOByID
+func (js *PostgresJobStorage) FindAllDTOs(jobType, status string, page, limit int) ([]*dto.JobOutputDTO, error) {
+	query := `
+SELECT
+	j.id,
+	j.type,
+	j.status,
+	j.total_items,
+	j.processed_items,
+	j.failed_items,
+	j.error,
+	j.attempts,
+	j.created_by_user_id,
+	u.name,
+	j.created_at,
+	j.updated_at,
+	j.started_at,
+	j.finished_at
+FROM jobs j
+	JOIN application_users u
+		ON j.created_by_user_id = u.id
+WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "j.type", jobType)
+	query, args = addFilterCondition(query, args, "j.status", status)
+	query += " ORDER BY j.created_at DESC"
+	query, args = appendPagination(query, args, page, limit)
+	rows, err := js.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var jobs []*dto.JobOutputDTO
+	for rows.Next() {
+		var d dto.JobOutputDTO
+		err := rows.Scan(
+			&d.ID,
+			&d.Type,
+			&d.Status,
+			&d.TotalItems,
+			&d.ProcessedItems,
+			&d.FailedItems,
+			&d.Error,
+			&d.Attempts,
+			&d.CreatedByUserID,
+			&d.CreatedByUser,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+			&d.StartedAt,
+			&d.FinishedAt)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &d)
+	}
+	return jobs, nil
+}
+
+func (js *PostgresJobStorage) Count(jobType, status string) (int, error) {
+	query := `SELECT COUNT(*) FROM jobs j WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "j.type", jobType)
+	query, args = addFilterCondition(query, args, "j.status", status)
+	var count int
+	err := js.DB.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (js *PostgresJobStorage) baseQueryEntity() string {
+	return `
+SELECT id, type, status, input, total_items, processed_items, failed_items, items, result, error, attempts,
+	created_by_user_id, created_at, updated_at, started_at, finished_at
+FROM jobs`
+}
+
+func (js *PostgresJobStorage) scanEntity(row interface{ Scan(dest ...any) error }) (*entity.Job, error) {
+	var j entity.Job
+	err := row.Scan(
+		&j.ID,
+		&j.Type,
+		&j.Status,
+		&j.Input,
+		&j.TotalItems,
+		&j.ProcessedItems,
+		&j.FailedItems,
+		&j.Items,
+		&j.Result,
+		&j.Error,
+		&j.Attempts,
+		&j.CreatedByUserID,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+		&j.StartedAt,
+		&j.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresRecertificationCampaignStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresRecertificationCampaignStorage(db *sql.DB) *PostgresRecertificationCampaignStorage {
+	return &PostgresRecertificationCampaignStorage{DB: db}
+}
+
+// Save godoc
+// Saves the campaign with its items in a single transaction, so a campaign is never left with part of its snapshot
+func (rcs *PostgresRecertificationCampaignStorage) Save(c *entity.RecertificationCampaign, items []*entity.RecertificationItem) error {
+	return inTransaction(rcs.DB, func(tx *sql.Tx) error {
+		query := `
+INSERT INTO recertification_campaigns (id, name, status, ecosystem_id, team, created_by_user_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		_, err := tx.Exec(
+			query,
+			c.ID,
+			c.Name,
+			c.Status,
+			c.EcosystemID,
+			c.Team,
+			c.CreatedByUserID,
+			c.CreatedAt,
+			c.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		stmt, err := tx.Prepare(`
+INSERT INTO recertification_items (id, campaign_id, access_permission_id, database_user_id, database_instance_id, permission,
+	reviewer_id, decision)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stmt.Close() }()
+		for _, i := range items {
+			_, err = stmt.Exec(i.ID, i.CampaignID, i.AccessPermissionID, i.DatabaseUserID, i.DatabaseInstanceID, i.Permission,
+				i.ReviewerID, i.Decision)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Update godoc
+// Saves the campaign only if it's still in the status it was loaded with, returning false when it was changed by another
+// operation in the meantime, e.g. when two users close the same campaign at the same time
+func (rcs *PostgresRecertificationCampaignStorage) Update(c *entity.RecertificationCampaign, previousStatus entity.RecertificationCampaignStatus) (bool, error) {
+	query := `
+UPDATE recertification_campaigns
+SET status            = $1,
+	closed_by_user_id = $2,
+	updated_at        = $3,
+	closed_at         = $4
+WHERE id = $5
+	AND status = $6`
+	result, err := rcs.DB.Exec(query, c.Status, c.ClosedByUserID, c.UpdatedAt, c.ClosedAt, c.ID, previousStatus)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) FindByID(id string) (*entity.RecertificationCampaign, error) {
+	query := `
+SELECT id, name, status, ecosystem_id, team, created_by_user_id, closed_by_user_id, created_at, updated_at, closed_at
+FROM recertification_campaigns
+WHERE id = $1`
+	var c entity.RecertificationCampaign
+	err := rcs.DB.QueryRow(query, id).Scan(
+		&c.ID,
+		&c.Name,
+		&c.Status,
+		&c.EcosystemID,
+		&c.Team,
+		&c.CreatedByUserID,
+		&c.ClosedByUserID,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) FindDTOByID(id string) (*dto.RecertificationCampaignOutputDTO, error) {
+	query := rcs.baseQueryDTO() + ` WHERE rc.id = $1`
+	return rcs.scanDTO(rcs.DB.QueryRow(query, id))
+}
+
+// FindAllDTOs godoc
+// Lists the campaigns from the most recent, with the count of their items by decision
+func (rcs *PostgresRecertificationCampaignStorage) FindAllDTOs(status string, page, limit int) ([]*dto.RecertificationCampaignOutputDTO, error) {
+	query := rcs.baseQueryDTO() + ` WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "rc.status", status)
+	query += " ORDER BY rc.created_at DESC"
+	query, args = appendPagination(query, args, page, limit)
+	rows, err := rcs.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var campaigns []*dto.RecertificationCampaignOutputDTO
+	for rows.Next() {
+		d, err := rcs.scanDTO(rows)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, d)
+	}
+	return campaigns, nil
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) Count(status string) (int, error) {
+	query := `SELECT COUNT(*) FROM recertification_campaigns rc WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "rc.status", status)
+	var count int
+	err := rcs.DB.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindAllItems godoc
+// Finds the items of the campaign with the given ids, or all of its items when no id is informed
+func (rcs *PostgresRecertificationCampaignStorage) FindAllItems(campaignID string, ids []string) ([]*entity.RecertificationItem, error) {
+	query := `
+SELECT ri.id, ri.campaign_id, ri.access_permission_id, ri.database_user_id, ri.database_instance_id, ri.permission,
+	ri.reviewer_id, ri.decision, ri.comment, ri.decided_at, ri.revocation_result
+FROM recertification_items ri
+WHERE ri.campaign_id = $1`
+	args := []any{campaignID}
+	query, args = appendFilterIdsInQuery(query, "ri", ids, args)
+	rows, err := rcs.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var items []*entity.RecertificationItem
+	for rows.Next() {
+		var i entity.RecertificationItem
+		err := rows.Scan(
+			&i.ID,
+			&i.CampaignID,
+			&i.AccessPermissionID,
+			&i.DatabaseUserID,
+			&i.DatabaseInstanceID,
+			&i.Permission,
+			&i.ReviewerID,
+			&i.Decision,
+			&i.Comment,
+			&i.DecidedAt,
+			&i.RevocationResult)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	return items, nil
+}
+
+// UpdateItems godoc
+// Saves the decision and the revocation result of the items in a single transaction
+func (rcs *PostgresRecertificationCampaignStorage) UpdateItems(items []*entity.RecertificationItem) error {
+	return inTransaction(rcs.DB, func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`
+UPDATE recertification_items
+SET decision          = $1,
+	comment           = $2,
+	decided_at        = $3,
+	revocation_result = $4
+WHERE id = $5`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stmt.Close() }()
+		for _, i := range items {
+			if _, err = stmt.Exec(i.Decision, i.Comment, i.DecidedAt, i.RevocationResult, i.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindAllItemDTOs godoc
+// Lists the items of a campaign ordered by user, instance and database of their permission. A limit of zero lists all.
+func (rcs *PostgresRecertificationCampaignStorage) FindAllItemDTOs(campaignID, reviewerID, decision string, page, limit int) ([]*dto.RecertificationItemOutputDTO, error) {
+	query := `
+SELECT
+	ri.id,
+	ri.campaign_id,
+	ri.permission,
+	ri.reviewer_id,
+	au.name,
+	ri.decision,
+	ri.comment,
+	ri.decided_at,
+	ri.revocation_result
+FROM recertification_items ri
+	JOIN application_users au
+		ON ri.reviewer_id = au.id
+WHERE ri.campaign_id = $1`
+	args := []any{campaignID}
+	query, args = addFilterCondition(query, args, "ri.reviewer_id", reviewerID)
+	query, args = addFilterCondition(query, args, "ri.decision", decision)
+	query += " ORDER BY ri.permission ->> 'databaseUserName', ri.permission ->> 'databaseInstanceName', ri.permission ->> 'databaseName'"
+	if limit > 0 {
+		query, args = appendPagination(query, args, page, limit)
+	}
+	rows, err := rcs.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var items []*dto.RecertificationItemOutputDTO
+	for rows.Next() {
+		var d dto.RecertificationItemOutputDTO
+		var permission string
+		err := rows.Scan(
+			&d.ID,
+			&d.CampaignID,
+			&permission,
+			&d.ReviewerID,
+			&d.Reviewer,
+			&d.Decision,
+			&d.Comment,
+			&d.DecidedAt,
+			&d.RevocationResult)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(permission), &d.Permission); err != nil {
+			return nil, err
+		}
+		items = append(items, &d)
+	}
+	return items, nil
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) CountItems(campaignID, reviewerID, decision string) (int, error) {
+	query := `SELECT COUNT(*) FROM recertification_items ri WHERE ri.campaign_id = $1`
+	args := []any{campaignID}
+	query, args = addFilterCondition(query, args, "ri.reviewer_id", reviewerID)
+	query, args = addFilterCondition(query, args, "ri.decision", decision)
+	var count int
+	err := rcs.DB.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) baseQueryDTO() string {
+	return `
+SELECT
+	rc.id,
+	rc.name,
+	rc.status,
+	rc.ecosystem_id,
+	e.display_name,
+	rc.team,
+	rc.created_by_user_id,
+	cu.name,
+	rc.closed_by_user_id,
+	lu.name,
+	rc.created_at,
+	rc.updated_at,
+	rc.closed_at,
+	(SELECT COUNT(*) FROM recertification_items ri WHERE ri.campaign_id = rc.id),
+	(SELECT COUNT(*) FROM recertification_items ri WHERE ri.campaign_id = rc.id AND ri.decision = '` + string(entity.RecertificationDecisionPending) + `'),
+	(SELECT COUNT(*) FROM recertification_items ri WHERE ri.campaign_id = rc.id AND ri.decision = '` + string(entity.RecertificationDecisionKeep) + `'),
+	(SELECT COUNT(*) FROM recertification_items ri WHERE ri.campaign_id = rc.id AND ri.decision = '` + string(entity.RecertificationDecisionRevoke) + `')
+FROM recertification_campaigns rc
+	JOIN application_users cu
+		ON rc.created_by_user_id = cu.id
+	LEFT JOIN application_users lu
+		ON rc.closed_by_user_id = lu.id
+	LEFT JOIN ecosystems e
+		ON rc.ecosystem_id = e.id`
+}
+
+func (rcs *PostgresRecertificationCampaignStorage) scanDTO(row interface{ Scan(dest ...any) error }) (*dto.RecertificationCampaignOutputDTO, error) {
+	var d dto.RecertificationCampaignOutputDTO
+	err := row.Scan(
+		&d.ID,
+		&d.Name,
+		&d.Status,
+		&d.EcosystemID,
+		&d.EcosystemName,
+		&d.Team,
+		&d.CreatedByUserID,
+		&d.CreatedByUser,
+		&d.ClosedByUserID,
+		&d.ClosedByUser,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.ClosedAt,
+		&d.TotalItems,
+		&d.PendingItems,
+		&d.KeptItems,
+		&d.RevokedItems)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
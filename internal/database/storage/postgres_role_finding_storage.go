@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"database/sql"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type PostgresRoleFindingStorage struct {
+	DB *sql.DB
+}
+
+func NewPostgresRoleFindingStorage(db *sql.DB) *PostgresRoleFindingStorage {
+	return &PostgresRoleFindingStorage{DB: db}
+}
+
+// ReplaceAllByInstance godoc
+// Replaces the findings of the instance by the ones of the last discovery in a single transaction, so the list never
+// mixes logins of two discoveries
+func (rfs *PostgresRoleFindingStorage) ReplaceAllByInstance(databaseInstanceID string, findings []*entity.RoleFinding) error {
+	return inTransaction(rfs.DB, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM role_findings WHERE database_instance_id = $1`, databaseInstanceID); err != nil {
+			return err
+		}
+		stmt, err := tx.Prepare(`
+INSERT INTO role_findings (id, database_instance_id, login_name, database_user_id, class, details, discovered_by_user_id, discovered_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = stmt.Close() }()
+		for _, f := range findings {
+			_, err = stmt.Exec(f.ID, f.DatabaseInstanceID, f.LoginName, sql.NullString{String: f.DatabaseUserID, Valid: f.DatabaseUserID != ""},
+				f.Class, f.Details, f.DiscoveredByUserID, f.DiscoveredAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindAllDTOs godoc
+// Lists the findings from the most dangerous class, by instance and login
+func (rfs *PostgresRoleFindingStorage) FindAllDTOs(databaseInstanceID, class string, page, limit int) ([]*dto.RoleFindingOutputDTO, error) {
+	query := `
+SELECT
+	rf.id,
+	rf.database_instance_id,
+	di.name,
+	e.display_name,
+	rf.login_name,
+	COALESCE(rf.database_user_id::TEXT, ''),
+	COALESCE(du.name, ''),
+	rf.class,
+	rf.details,
+	rf.discovered_by_user_id,
+	au.name,
+	rf.discovered_at
+FROM role_findings rf
+	JOIN database_instances di
+		ON rf.database_instance_id = di.id
+	JOIN ecosystems e
+		ON di.ecosystem_id = e.id
+	JOIN application_users au
+		ON rf.discovered_by_user_id = au.id
+	LEFT JOIN database_users du
+		ON rf.database_user_id = du.id
+WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "rf.database_instance_id", databaseInstanceID)
+	query, args = addFilterCondition(query, args, "rf.class", class)
+	query += ` ORDER BY CASE rf.class
+		WHEN 'SUPERUSER' THEN 1
+		WHEN 'PRIVILEGED' THEN 2
+		WHEN 'UNKNOWN' THEN 3
+		WHEN 'MISMATCHED' THEN 4
+		ELSE 5 END, di.name, rf.login_name`
+	query, args = appendPagination(query, args, page, limit)
+	rows, err := rfs.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	var findings []*dto.RoleFindingOutputDTO
+	for rows.Next() {
+		var d dto.RoleFindingOutputDTO
+		err := rows.Scan(
+			&d.ID,
+			&d.DatabaseInstanceID,
+			&d.DatabaseInstanceName,
+			&d.EcosystemName,
+			&d.LoginName,
+			&d.DatabaseUserID,
+			&d.DatabaseUserName,
+			&d.Class,
+			&d.Details,
+			&d.DiscoveredByUserID,
+			&d.DiscoveredByUserName,
+			&d.DiscoveredAt)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, &d)
+	}
+	return findings, rows.Err()
+}
+
+func (rfs *PostgresRoleFindingStorage) Count(databaseInstanceID, class string) (int, error) {
+	query := `SELECT COUNT(*) FROM role_findings rf WHERE 1 = 1`
+	var args []any
+	query, args = addFilterCondition(query, args, "rf.database_instance_id", databaseInstanceID)
+	query, args = addFilterCondition(query, args, "rf.class", class)
+	var count int
+	err := rfs.DB.QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
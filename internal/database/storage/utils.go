@@ -45,3 +45,23 @@ func appendFilterIdsInQuery(baseQuery, entityAlias string, ids []string, args []
 	baseQuery += ")"
 	return baseQuery, args
 }
+
+func appendPagination(query string, args []any, page, limit int) (string, []any) {
+	query += fmt.Sprintf(" OFFSET $%d LIMIT $%d", len(args)+1, len(args)+2)
+	args = append(args, (page-1)*limit, limit)
+	return query, args
+}
+
+// inTransaction godoc
+// Runs the operation in a transaction, committed when the operation succeeds and rolled back otherwise
+func inTransaction(db *sql.DB, operation func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = operation(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
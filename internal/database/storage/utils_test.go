@@ -101,3 +101,12 @@ func TestAppendFilterIdsInQuery(t *testing.T) {
 		assert.Equal(t, args, newArgs)
 	})
 }
+
+func TestAppendPagination(t *testing.T) {
+	query, args := addFilterCondition("SELECT * FROM test WHERE 1=1", nil, "field", "value")
+
+	newQuery, newArgs := appendPagination(query, args, 3, 50)
+
+	assert.Equal(t, "SELECT * FROM test WHERE 1=1 AND field = $1 OFFSET $2 LIMIT $3", newQuery)
+	assert.Equal(t, []any{"value", 100, 50}, newArgs)
+}
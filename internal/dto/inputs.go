@@ -3,6 +3,9 @@ package dto
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -19,6 +22,19 @@ const (
 var (
 	ErrArrayDatabaseUsersIdsEmpty = errors.New("param: databaseUsersIds (type: []string) cannot be empty")
 	ErrArrayInstancesDataEmpty    = errors.New("param: instancesData (type: []InstanceDataDTO) cannot be empty")
+	ErrArrayDatabasesIdsEmpty     = errors.New("param: instancesData.databasesIds (type: []string) cannot be empty")
+	ErrClientCertificateAndKey    = errors.New("params: clientCertificate and clientKey (type: string) must be informed together")
+	ErrExpiresAtNotInTheFuture    = errors.New("param: expiresAt (type: datetime) must be in the future")
+	ErrArrayReviewersIdsEmpty     = errors.New("param: reviewersIds (type: []string) cannot be empty")
+	ErrArrayDecisionsEmpty        = errors.New("param: decisions (type: []RecertificationDecisionInputDTO) cannot be empty")
+	ErrDatabasesRolesIdsNotInList = errors.New("param: instancesData.databasesRolesIds (type: map[string]string) must only have databases informed in databasesIds")
+	ErrDatabasesRolesIdsNotUsed   = errors.New("param: instancesData.databasesRolesIds (type: map[string]string) is not supported in this operation")
+	ErrDatabasesScopesNotInList   = errors.New("param: instancesData.databasesScopes (type: map[string]AccessScopeDTO) must only have databases informed in databasesIds")
+	ErrDatabasesScopesNotUsed     = errors.New("param: instancesData.databasesScopes (type: map[string]AccessScopeDTO) is not supported in this operation")
+	ErrDryRunNotUsed              = errors.New("param: dryRun (type: bool) is not supported in this operation")
+	ErrAccessScopeEmpty           = errors.New("param: instancesData.databasesScopes (type: map[string]AccessScopeDTO) must have schemas or tables in each scope")
+	ErrArrayTablePrivilegesEmpty  = errors.New("param: privileges.table (type: []string) cannot be empty")
+	validSSLModes                 = []string{"disable", "require", "verify-ca", "verify-full"}
 )
 
 type InputValidator interface {
@@ -26,8 +42,9 @@ type InputValidator interface {
 }
 
 type EcosystemInputDTO struct {
-	Code        string `json:"code"`
-	DisplayName string `json:"displayName"`
+	Code                   string `json:"code"`
+	DisplayName            string `json:"displayName"`
+	RequiresAccessApproval bool   `json:"requiresAccessApproval"`
 }
 
 func (e *EcosystemInputDTO) Validate() error {
@@ -56,13 +73,25 @@ func (t *TechnologyInputDTO) Validate() error {
 }
 
 type DatabaseInstanceInputDTO struct {
-	Name                 string `json:"name"`
-	Host                 string `json:"host"`
-	Port                 string `json:"port"`
-	HostConnection       string `json:"hostConnection"`
-	PortConnection       string `json:"portConnection"`
-	AdminUser            string `json:"adminUser"`
-	AdminPassword        string `json:"adminPassword"`
+	Name              string `json:"name"`
+	Host              string `json:"host"`
+	Port              string `json:"port"`
+	HostConnection    string `json:"hostConnection"`
+	PortConnection    string `json:"portConnection"`
+	AdminUser         string `json:"adminUser"`
+	AdminPassword     string `json:"adminPassword"`
+	SSLMode           string `json:"sslMode"`
+	CACertificate     string `json:"caCertificate"`
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+	// ClearCertificates removes the current certificates of the instance on update, before storing the informed ones
+	ClearCertificates    bool   `json:"clearCertificates,omitempty"`
+	BastionHost          string `json:"bastionHost"`
+	BastionPort          string `json:"bastionPort"`
+	BastionUser          string `json:"bastionUser"`
+	BastionPrivateKey    string `json:"bastionPrivateKey"`
+	BastionPassword      string `json:"bastionPassword"`
+	BastionHostKey       string `json:"bastionHostKey"`
 	EcosystemID          string `json:"ecosystemId"`
 	DatabaseTechnologyID string `json:"databaseTechnologyId"`
 	Note                 string `json:"note"`
@@ -90,6 +119,18 @@ func (d *DatabaseInstanceInputDTO) Validate() error {
 	if d.AdminPassword == emptyString {
 		return errParamIsRequired("adminPassword", typeString)
 	}
+	if d.SSLMode != emptyString && !slices.Contains(validSSLModes, d.SSLMode) {
+		return errParamIsInvalid("sslMode", typeString)
+	}
+	if (d.ClientCertificate == emptyString) != (d.ClientKey == emptyString) {
+		return ErrClientCertificateAndKey
+	}
+	if d.BastionHost != emptyString && d.BastionUser == emptyString {
+		return errParamIsRequired("bastionUser", typeString)
+	}
+	if d.BastionHost != emptyString && d.BastionHostKey == emptyString {
+		return errParamIsRequired("bastionHostKey", typeString)
+	}
 	if d.EcosystemID == emptyString {
 		return errParamIsRequired("ecosystemId", typeUUID)
 	}
@@ -111,19 +152,31 @@ func validUUID(id string) bool {
 }
 
 type ConnectionInputDTO struct {
-	ID         string `json:"id"`
-	Host       string `json:"host"`
-	Port       string `json:"port"`
-	User       string `json:"user"`
-	Password   string `json:"password"`
-	Database   string `json:"database"`
-	Instance   string `json:"instance"`
-	Ecosystem  string `json:"ecosystem"`
-	Technology string `json:"technology"`
+	ID                string `json:"id"`
+	Host              string `json:"host"`
+	Port              string `json:"port"`
+	User              string `json:"user"`
+	Password          string `json:"password"`
+	Database          string `json:"database"`
+	Instance          string `json:"instance"`
+	Ecosystem         string `json:"ecosystem"`
+	Technology        string `json:"technology"`
+	SSLMode           string `json:"sslMode"`
+	CACertificate     string `json:"caCertificate"`
+	ClientCertificate string `json:"clientCertificate"`
+	ClientKey         string `json:"clientKey"`
+	BastionHost       string `json:"bastionHost"`
+	BastionPort       string `json:"bastionPort"`
+	BastionUser       string `json:"bastionUser"`
+	BastionPrivateKey string `json:"bastionPrivateKey"`
+	BastionPassword   string `json:"bastionPassword"`
+	BastionHostKey    string `json:"bastionHostKey"`
 }
 
 type PropagateRolesInputDTO struct {
 	DatabaseInstancesIDs []string `json:"databaseInstancesIds"`
+	// DryRun validates the operation and returns the statements it would execute in the instances, without executing them
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type TestConnectionInputDTO struct {
@@ -134,9 +187,81 @@ type SyncDatabasesInputDTO struct {
 	DatabaseInstancesIDs []string `json:"databaseInstancesIds"`
 }
 
+type DiscoverRolesInputDTO struct {
+	DatabaseInstancesIDs []string `json:"databaseInstancesIds"`
+}
+
+// ReconcileAccessInputDTO godoc
+// Apply fixes the drifts found in the instances, instead of only reporting them
+type ReconcileAccessInputDTO struct {
+	DatabaseInstancesIDs []string `json:"databaseInstancesIds"`
+	Apply                bool     `json:"apply"`
+}
+
+// RolePrivilegesDTO godoc
+// Privileges of a database role on each type of object, in the vocabulary of PostgreSQL (e.g. "SELECT" on tables)
+type RolePrivilegesDTO struct {
+	Database []string             `json:"database,omitempty"`
+	Schema   []string             `json:"schema,omitempty"`
+	Table    []string             `json:"table,omitempty"`
+	Sequence []string             `json:"sequence,omitempty"`
+	Function []string             `json:"function,omitempty"`
+	Type     []string             `json:"type,omitempty"`
+	Default  DefaultPrivilegesDTO `json:"default"`
+}
+
+type DefaultPrivilegesDTO struct {
+	Table    []string `json:"table,omitempty"`
+	Sequence []string `json:"sequence,omitempty"`
+	Function []string `json:"function,omitempty"`
+	Type     []string `json:"type,omitempty"`
+}
+
+type DatabaseRoleInputDTO struct {
+	Name        string            `json:"name"`
+	DisplayName string            `json:"displayName"`
+	Description string            `json:"description"`
+	Privileges  RolePrivilegesDTO `json:"privileges"`
+}
+
+func (d *DatabaseRoleInputDTO) Validate() error {
+	if d.Name == emptyString {
+		return errParamIsRequired("name", typeString)
+	}
+	if d.DisplayName == emptyString {
+		return errParamIsRequired("displayName", typeString)
+	}
+	if len(d.Privileges.Table) == 0 {
+		return ErrArrayTablePrivilegesEmpty
+	}
+	return nil
+}
+
+// UpdateDatabaseRoleInputDTO godoc
+// The name of a role can't be changed, since it identifies the role in the database instances
+type UpdateDatabaseRoleInputDTO struct {
+	DisplayName string            `json:"displayName"`
+	Description string            `json:"description"`
+	Privileges  RolePrivilegesDTO `json:"privileges"`
+}
+
+func (d *UpdateDatabaseRoleInputDTO) Validate() error {
+	if d.DisplayName == emptyString {
+		return errParamIsRequired("displayName", typeString)
+	}
+	if len(d.Privileges.Table) == 0 {
+		return ErrArrayTablePrivilegesEmpty
+	}
+	return nil
+}
+
 type SetupRolesInputDTO struct {
 	DatabaseInstanceID string   `json:"databaseInstanceId"`
 	DatabasesIDs       []string `json:"databasesIds"`
+	// OnlyOutdated limits the setup to the databases whose roles weren't set up with the current grants
+	OnlyOutdated bool `json:"onlyOutdated"`
+	// DryRun validates the operation and returns the statements it would execute in the instances, without executing them
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 type DatabaseUserInputDTO struct {
@@ -189,11 +314,30 @@ func (d *UpdateDatabaseUserInputDTO) Validate() error {
 type InstanceDataDTO struct {
 	DatabaseInstanceID string   `json:"databaseInstanceId"`
 	DatabasesIDs       []string `json:"databasesIds"`
+	// DatabasesRolesIDs is the role of the users in some of the databases informed, by database id, instead of their own role
+	DatabasesRolesIDs map[string]string `json:"databasesRolesIds,omitempty"`
+	// DatabasesScopes restricts the access to some schemas and tables of some of the databases informed, by database id
+	DatabasesScopes map[string]AccessScopeDTO `json:"databasesScopes,omitempty"`
+}
+
+// AccessScopeDTO godoc
+// Allowlist of schemas and tables of a database. PostgreSQL tables are informed as schema.table, or only by name for
+// the ones of the public schema. MySQL has no schemas, so only tables are accepted.
+type AccessScopeDTO struct {
+	Schemas []string `json:"schemas,omitempty" example:"reporting"`
+	Tables  []string `json:"tables,omitempty" example:"public.orders"`
 }
 
 type GrantAccessInputDTO struct {
 	DatabaseUsersIDs []string          `json:"databaseUsersIds"`
 	InstancesData    []InstanceDataDTO `json:"instancesData"`
+	// ExpiresAt is when the permissions granted are revoked automatically. They never expire when it's not informed.
+	// The permissions the users already have in the databases are extended to it when they expire sooner.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty" example:"2024-06-01T18:00:00Z"`
+	// DryRun validates the operation and returns the statements it would execute in the instances, without executing them
+	DryRun bool `json:"dryRun,omitempty"`
+	// AccessGroupID is the access group granting its targets to its members. It's empty for a direct grant.
+	AccessGroupID string `json:"-"`
 }
 
 func (g *GrantAccessInputDTO) Validate() error {
@@ -208,7 +352,14 @@ func (g *GrantAccessInputDTO) Validate() error {
 			return errParamIsInvalid("databaseUsersIds", typeUUID)
 		}
 	}
-	for _, instanceData := range g.InstancesData {
+	if err := validateInstancesData(g.InstancesData); err != nil {
+		return err
+	}
+	return g.ValidateExpiresAt()
+}
+
+func validateInstancesData(instancesData []InstanceDataDTO) error {
+	for _, instanceData := range instancesData {
 		if !validUUID(instanceData.DatabaseInstanceID) {
 			return errParamIsInvalid("instancesData", typeUUID)
 		}
@@ -217,6 +368,81 @@ func (g *GrantAccessInputDTO) Validate() error {
 				return errParamIsInvalid("instancesData", typeUUID)
 			}
 		}
+		for databaseID, roleID := range instanceData.DatabasesRolesIDs {
+			if !validUUID(databaseID) || !validUUID(roleID) {
+				return errParamIsInvalid("instancesData.databasesRolesIds", typeUUID)
+			}
+			if !slices.Contains(instanceData.DatabasesIDs, databaseID) {
+				return ErrDatabasesRolesIdsNotInList
+			}
+		}
+		for databaseID, scope := range instanceData.DatabasesScopes {
+			if !validUUID(databaseID) {
+				return errParamIsInvalid("instancesData.databasesScopes", typeUUID)
+			}
+			if !slices.Contains(instanceData.DatabasesIDs, databaseID) {
+				return ErrDatabasesScopesNotInList
+			}
+			if len(scope.Schemas) == 0 && len(scope.Tables) == 0 {
+				return ErrAccessScopeEmpty
+			}
+		}
+	}
+	return nil
+}
+
+// validateInstancesDataWithoutRoles godoc
+// Same as validateInstancesData for the operations where the users always have their own role in the whole databases
+func validateInstancesDataWithoutRoles(instancesData []InstanceDataDTO) error {
+	for _, instanceData := range instancesData {
+		if len(instanceData.DatabasesRolesIDs) > 0 {
+			return ErrDatabasesRolesIdsNotUsed
+		}
+		if len(instanceData.DatabasesScopes) > 0 {
+			return ErrDatabasesScopesNotUsed
+		}
+	}
+	return validateInstancesData(instancesData)
+}
+
+// ChangeAccessPermissionRoleInputDTO godoc
+// The role the user has in the database of the permission. The user's own role makes the permission follow it again.
+type ChangeAccessPermissionRoleInputDTO struct {
+	DatabaseRoleID string `json:"databaseRoleId"`
+}
+
+func (c *ChangeAccessPermissionRoleInputDTO) Validate() error {
+	if c.DatabaseRoleID == emptyString {
+		return errParamIsRequired("databaseRoleId", typeUUID)
+	}
+	if !validUUID(c.DatabaseRoleID) {
+		return errParamIsInvalid("databaseRoleId", typeUUID)
+	}
+	return nil
+}
+
+// MigrateDatabaseUserRoleInputDTO godoc
+// The new own role of the user, applied on every instance where the user exists
+type MigrateDatabaseUserRoleInputDTO struct {
+	DatabaseRoleID string `json:"databaseRoleId"`
+}
+
+func (m *MigrateDatabaseUserRoleInputDTO) Validate() error {
+	if m.DatabaseRoleID == emptyString {
+		return errParamIsRequired("databaseRoleId", typeUUID)
+	}
+	if !validUUID(m.DatabaseRoleID) {
+		return errParamIsInvalid("databaseRoleId", typeUUID)
+	}
+	return nil
+}
+
+// ValidateExpiresAt godoc
+// Checks that the grant doesn't expire before it's processed. Validated again when the grant is processed, since it may
+// run later as a job.
+func (g *GrantAccessInputDTO) ValidateExpiresAt() error {
+	if g.ExpiresAt != nil && !g.ExpiresAt.After(time.Now()) {
+		return ErrExpiresAtNotInTheFuture
 	}
 	return nil
 }
@@ -224,6 +450,8 @@ func (g *GrantAccessInputDTO) Validate() error {
 type RevokeAccessInputDTO struct {
 	DatabaseUserID       string   `json:"databaseUserId"`
 	DatabaseInstancesIDs []string `json:"databaseInstancesIds"`
+	// DryRun validates the operation and returns the statements it would execute in the instances, without executing them
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 func (r *RevokeAccessInputDTO) Validate() error {
@@ -241,6 +469,166 @@ func (r *RevokeAccessInputDTO) Validate() error {
 	return nil
 }
 
+// AccessRequestInputDTO godoc
+// Same payload of the grant of access, plus the reason why the access is needed
+type AccessRequestInputDTO struct {
+	GrantAccessInputDTO
+	Justification string `json:"justification" example:"On-call investigation of incident INC-42"`
+}
+
+func (a *AccessRequestInputDTO) Validate() error {
+	if err := a.GrantAccessInputDTO.Validate(); err != nil {
+		return err
+	}
+	if a.DryRun {
+		return ErrDryRunNotUsed
+	}
+	if strings.TrimSpace(a.Justification) == emptyString {
+		return errParamIsRequired("justification", typeString)
+	}
+	return nil
+}
+
+type ReviewAccessRequestInputDTO struct {
+	Comment string `json:"comment"`
+}
+
+// BreakGlassAccessInputDTO godoc
+// Emergency access of a user to the selected databases, that must be informed in each instance
+type BreakGlassAccessInputDTO struct {
+	DatabaseUserID    string            `json:"databaseUserId"`
+	InstancesData     []InstanceDataDTO `json:"instancesData"`
+	IncidentReference string            `json:"incidentReference" example:"INC-1234 orders database down"`
+}
+
+func (b *BreakGlassAccessInputDTO) Validate() error {
+	if b.DatabaseUserID == emptyString {
+		return errParamIsRequired("databaseUserId", typeUUID)
+	}
+	if !validUUID(b.DatabaseUserID) {
+		return errParamIsInvalid("databaseUserId", typeUUID)
+	}
+	if len(b.InstancesData) == 0 {
+		return ErrArrayInstancesDataEmpty
+	}
+	for _, instanceData := range b.InstancesData {
+		if len(instanceData.DatabasesRolesIDs) > 0 {
+			return ErrDatabasesRolesIdsNotUsed
+		}
+		if len(instanceData.DatabasesScopes) > 0 {
+			return ErrDatabasesScopesNotUsed
+		}
+		if !validUUID(instanceData.DatabaseInstanceID) {
+			return errParamIsInvalid("instancesData", typeUUID)
+		}
+		if len(instanceData.DatabasesIDs) == 0 {
+			return ErrArrayDatabasesIdsEmpty
+		}
+		for _, id := range instanceData.DatabasesIDs {
+			if !validUUID(id) {
+				return errParamIsInvalid("instancesData", typeUUID)
+			}
+		}
+	}
+	if strings.TrimSpace(b.IncidentReference) == emptyString {
+		return errParamIsRequired("incidentReference", typeString)
+	}
+	return nil
+}
+
+// RecertificationCampaignInputDTO godoc
+// The campaign reviews all the access permissions, or only those of the ecosystem and/or the team informed
+type RecertificationCampaignInputDTO struct {
+	Name         string   `json:"name" example:"Q3 2024 recertification"`
+	EcosystemID  string   `json:"ecosystemId"`
+	Team         string   `json:"team"`
+	ReviewersIDs []string `json:"reviewersIds"`
+}
+
+func (r *RecertificationCampaignInputDTO) Validate() error {
+	if strings.TrimSpace(r.Name) == emptyString {
+		return errParamIsRequired("name", typeString)
+	}
+	if r.EcosystemID != emptyString && !validUUID(r.EcosystemID) {
+		return errParamIsInvalid("ecosystemId", typeUUID)
+	}
+	if len(r.ReviewersIDs) == 0 {
+		return ErrArrayReviewersIdsEmpty
+	}
+	for _, id := range r.ReviewersIDs {
+		if !validUUID(id) {
+			return errParamIsInvalid("reviewersIds", typeUUID)
+		}
+	}
+	return nil
+}
+
+type RecertificationDecisionInputDTO struct {
+	ItemID   string `json:"itemId"`
+	Decision string `json:"decision" enums:"KEEP,REVOKE"`
+	Comment  string `json:"comment"`
+}
+
+type DecideRecertificationItemsInputDTO struct {
+	Decisions []RecertificationDecisionInputDTO `json:"decisions"`
+}
+
+func (d *DecideRecertificationItemsInputDTO) Validate() error {
+	if len(d.Decisions) == 0 {
+		return ErrArrayDecisionsEmpty
+	}
+	for _, decision := range d.Decisions {
+		if !validUUID(decision.ItemID) {
+			return errParamIsInvalid("decisions.itemId", typeUUID)
+		}
+		if decision.Decision != "KEEP" && decision.Decision != "REVOKE" {
+			return errParamIsInvalid("decisions.decision", typeString)
+		}
+	}
+	return nil
+}
+
+// AccessGroupInputDTO godoc
+// The targets of the group are informed as in a grant: an instance without databases targets all its enabled databases
+type AccessGroupInputDTO struct {
+	Name          string            `json:"name" example:"Team B analysts"`
+	Description   string            `json:"description"`
+	InstancesData []InstanceDataDTO `json:"instancesData"`
+}
+
+func (a *AccessGroupInputDTO) Validate() error {
+	if strings.TrimSpace(a.Name) == emptyString {
+		return errParamIsRequired("name", typeString)
+	}
+	return validateInstancesDataWithoutRoles(a.InstancesData)
+}
+
+// AccessGroupTargetsInputDTO godoc
+// Replaces all the targets of the group, that may be left with no targets
+type AccessGroupTargetsInputDTO struct {
+	InstancesData []InstanceDataDTO `json:"instancesData"`
+}
+
+func (a *AccessGroupTargetsInputDTO) Validate() error {
+	return validateInstancesDataWithoutRoles(a.InstancesData)
+}
+
+type AccessGroupMembersInputDTO struct {
+	DatabaseUsersIDs []string `json:"databaseUsersIds"`
+}
+
+func (a *AccessGroupMembersInputDTO) Validate() error {
+	if len(a.DatabaseUsersIDs) == 0 {
+		return ErrArrayDatabaseUsersIdsEmpty
+	}
+	for _, id := range a.DatabaseUsersIDs {
+		if !validUUID(id) {
+			return errParamIsInvalid("databaseUsersIds", typeUUID)
+		}
+	}
+	return nil
+}
+
 type ChangeStatusInputDTO struct {
 	ID      string `json:"id"`
 	Enabled *bool  `json:"enabled"`
@@ -259,6 +647,193 @@ func (cs *ChangeStatusInputDTO) Validate() error {
 	return nil
 }
 
+// DesiredStateInputDTO godoc
+// Desired state of the ecosystems, instances, database users, access groups and grants, usually kept as a YAML file.
+// The entities reference each other by their natural keys: ecosystems by code, instances and groups by name, users by
+// email and databases by name inside their instance.
+type DesiredStateInputDTO struct {
+	Ecosystems []DesiredEcosystemDTO `json:"ecosystems" yaml:"ecosystems"`
+	Instances  []DesiredInstanceDTO  `json:"instances" yaml:"instances"`
+	Users      []DesiredUserDTO      `json:"users" yaml:"users"`
+	Groups     []DesiredGroupDTO     `json:"groups" yaml:"groups"`
+	Grants     []DesiredGrantDTO     `json:"grants" yaml:"grants"`
+}
+
+type DesiredEcosystemDTO struct {
+	Code                   string `json:"code" yaml:"code"`
+	DisplayName            string `json:"displayName" yaml:"displayName"`
+	RequiresAccessApproval bool   `json:"requiresAccessApproval" yaml:"requiresAccessApproval"`
+}
+
+// DesiredInstanceDTO godoc
+// The connection host and port default to the host and port. The admin user and password are only required to create
+// the instance, the current ones are kept when not informed. Certificates and the secrets of the bastion are not
+// managed by the desired state.
+type DesiredInstanceDTO struct {
+	Name              string `json:"name" yaml:"name"`
+	Ecosystem         string `json:"ecosystem" yaml:"ecosystem"`
+	Technology        string `json:"technology" yaml:"technology"`
+	TechnologyVersion string `json:"technologyVersion" yaml:"technologyVersion"`
+	Host              string `json:"host" yaml:"host"`
+	Port              string `json:"port" yaml:"port"`
+	HostConnection    string `json:"hostConnection,omitempty" yaml:"hostConnection"`
+	PortConnection    string `json:"portConnection,omitempty" yaml:"portConnection"`
+	AdminUser         string `json:"adminUser,omitempty" yaml:"adminUser"`
+	AdminPassword     string `json:"adminPassword,omitempty" yaml:"adminPassword"`
+	SSLMode           string `json:"sslMode,omitempty" yaml:"sslMode"`
+	BastionHost       string `json:"bastionHost,omitempty" yaml:"bastionHost"`
+	BastionPort       string `json:"bastionPort,omitempty" yaml:"bastionPort"`
+	BastionUser       string `json:"bastionUser,omitempty" yaml:"bastionUser"`
+	BastionHostKey    string `json:"bastionHostKey,omitempty" yaml:"bastionHostKey"`
+	Note              string `json:"note,omitempty" yaml:"note"`
+}
+
+// DesiredUserDTO godoc
+// Role is the name of the own role of the user
+type DesiredUserDTO struct {
+	Email    string `json:"email" yaml:"email"`
+	Name     string `json:"name" yaml:"name"`
+	Team     string `json:"team,omitempty" yaml:"team"`
+	Position string `json:"position,omitempty" yaml:"position"`
+	Role     string `json:"role" yaml:"role"`
+}
+
+// DesiredGroupDTO godoc
+// Members are the emails of the users. The description is only used to create the group.
+type DesiredGroupDTO struct {
+	Name        string             `json:"name" yaml:"name"`
+	Description string             `json:"description,omitempty" yaml:"description"`
+	Targets     []DesiredTargetDTO `json:"targets" yaml:"targets"`
+	Members     []string           `json:"members" yaml:"members"`
+}
+
+// DesiredTargetDTO godoc
+// A target without databases covers all the enabled databases of the instance
+type DesiredTargetDTO struct {
+	Instance  string   `json:"instance" yaml:"instance"`
+	Databases []string `json:"databases,omitempty" yaml:"databases"`
+}
+
+// DesiredGrantDTO godoc
+// Direct access of a user, by email, to databases of an instance, with the own role of the user
+type DesiredGrantDTO struct {
+	User      string   `json:"user" yaml:"user"`
+	Instance  string   `json:"instance" yaml:"instance"`
+	Databases []string `json:"databases" yaml:"databases"`
+}
+
+// Validate godoc
+// Checks the required fields and the duplicated keys. The references between the entities are checked by the plan,
+// since they may point to entities already stored.
+func (d *DesiredStateInputDTO) Validate() error {
+	codes := make(map[string]bool)
+	for _, e := range d.Ecosystems {
+		if e.Code == emptyString {
+			return errParamIsRequired("ecosystems.code", typeString)
+		}
+		if e.DisplayName == emptyString {
+			return errParamIsRequired("ecosystems.displayName", typeString)
+		}
+		if codes[e.Code] {
+			return errParamIsDuplicated("ecosystems.code", e.Code)
+		}
+		codes[e.Code] = true
+	}
+	if err := d.validateInstances(); err != nil {
+		return err
+	}
+	emails := make(map[string]bool)
+	for _, u := range d.Users {
+		if !utils.ValidEmail(u.Email) {
+			return errParamIsInvalid("users.email", typeString)
+		}
+		if u.Name == emptyString {
+			return errParamIsRequired("users.name", typeString)
+		}
+		if u.Role == emptyString {
+			return errParamIsRequired("users.role", typeString)
+		}
+		if emails[u.Email] {
+			return errParamIsDuplicated("users.email", u.Email)
+		}
+		emails[u.Email] = true
+	}
+	if err := d.validateGroups(); err != nil {
+		return err
+	}
+	for _, g := range d.Grants {
+		if g.User == emptyString {
+			return errParamIsRequired("grants.user", typeString)
+		}
+		if g.Instance == emptyString {
+			return errParamIsRequired("grants.instance", typeString)
+		}
+		if len(g.Databases) == 0 {
+			return errParamIsRequired("grants.databases", "[]string")
+		}
+	}
+	return nil
+}
+
+func (d *DesiredStateInputDTO) validateInstances() error {
+	names := make(map[string]bool)
+	for _, i := range d.Instances {
+		if i.Name == emptyString {
+			return errParamIsRequired("instances.name", typeString)
+		}
+		if i.Ecosystem == emptyString {
+			return errParamIsRequired("instances.ecosystem", typeString)
+		}
+		if i.Technology == emptyString {
+			return errParamIsRequired("instances.technology", typeString)
+		}
+		if i.TechnologyVersion == emptyString {
+			return errParamIsRequired("instances.technologyVersion", typeString)
+		}
+		if i.Host == emptyString {
+			return errParamIsRequired("instances.host", typeString)
+		}
+		if i.Port == emptyString {
+			return errParamIsRequired("instances.port", typeString)
+		}
+		if i.SSLMode != emptyString && !slices.Contains(validSSLModes, i.SSLMode) {
+			return errParamIsInvalid("instances.sslMode", typeString)
+		}
+		if i.BastionHost != emptyString && i.BastionUser == emptyString {
+			return errParamIsRequired("instances.bastionUser", typeString)
+		}
+		if names[i.Name] {
+			return errParamIsDuplicated("instances.name", i.Name)
+		}
+		names[i.Name] = true
+	}
+	return nil
+}
+
+func (d *DesiredStateInputDTO) validateGroups() error {
+	names := make(map[string]bool)
+	for _, g := range d.Groups {
+		if strings.TrimSpace(g.Name) == emptyString {
+			return errParamIsRequired("groups.name", typeString)
+		}
+		if names[g.Name] {
+			return errParamIsDuplicated("groups.name", g.Name)
+		}
+		names[g.Name] = true
+		for _, t := range g.Targets {
+			if t.Instance == emptyString {
+				return errParamIsRequired("groups.targets.instance", typeString)
+			}
+		}
+		for _, member := range g.Members {
+			if !utils.ValidEmail(member) {
+				return errParamIsInvalid("groups.members", typeString)
+			}
+		}
+	}
+	return nil
+}
+
 func errParamIsRequired(name, typ string) error {
 	return fmt.Errorf("param: %s (type: %s) is required", name, typ)
 }
@@ -266,3 +841,7 @@ func errParamIsRequired(name, typ string) error {
 func errParamIsInvalid(name, typ string) error {
 	return fmt.Errorf("param: %s (type: %s) is invalid", name, typ)
 }
+
+func errParamIsDuplicated(name, value string) error {
+	return fmt.Errorf("param: %s has the duplicated value %s", name, value)
+}
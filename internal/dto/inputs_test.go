@@ -2,6 +2,7 @@ package dto
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -56,6 +57,18 @@ func TestValidateDatabaseInstanceInputDTO(t *testing.T) {
 	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", EcosystemID: "123"}
 	assertValidate(t, i, errParamIsInvalid("ecosystemId", typeUUID))
 
+	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", SSLMode: "prefer"}
+	assertValidate(t, i, errParamIsInvalid("sslMode", typeString))
+
+	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", SSLMode: "verify-full", ClientCertificate: "cert"}
+	assertValidate(t, i, ErrClientCertificateAndKey)
+
+	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", BastionHost: "bastion.example.com"}
+	assertValidate(t, i, errParamIsRequired("bastionUser", typeString))
+
+	i.BastionUser = "jump"
+	assertValidate(t, i, errParamIsRequired("bastionHostKey", typeString))
+
 	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", EcosystemID: "dd42cf0c-8a91-42d7-a906-cb9313494e7d"}
 	assertValidate(t, i, errParamIsRequired("databaseTechnologyId", typeUUID))
 
@@ -64,6 +77,9 @@ func TestValidateDatabaseInstanceInputDTO(t *testing.T) {
 
 	i = &DatabaseInstanceInputDTO{Name: "PostgreSQL", Host: "127.0.0.1", Port: "5432", HostConnection: "host.conn.ip", PortConnection: "5433", AdminUser: "admin", AdminPassword: "pwd", EcosystemID: "dd42cf0c-8a91-42d7-a906-cb9313494e7d", DatabaseTechnologyID: "1eb93da6-e739-4396-902f-19f79aa74e39"}
 	assert.NoError(t, i.Validate())
+
+	i.SSLMode = "verify-ca"
+	assert.NoError(t, i.Validate())
 }
 
 func TestValidateDatabaseUserInputDTO(t *testing.T) {
@@ -138,6 +154,82 @@ func TestValidateGrantAccessInputDTO(t *testing.T) {
 	i = &GrantAccessInputDTO{DatabaseUsersIDs: []string{"1eb93da6-e739-4396-902f-19f79aa74e39"},
 		InstancesData: []InstanceDataDTO{{DatabaseInstanceID: "1eb93da6-e739-4396-902f-19f79aa74e39", DatabasesIDs: []string{"1eb93da6-e739-4396-902f-19f79aa74e39"}}}}
 	assert.NoError(t, i.Validate())
+
+	expired := time.Now().Add(-time.Minute)
+	i.ExpiresAt = &expired
+	assertValidate(t, i, ErrExpiresAtNotInTheFuture)
+
+	expiresAt := time.Now().Add(4 * time.Hour)
+	i.ExpiresAt = &expiresAt
+	assert.NoError(t, i.Validate())
+
+	i.InstancesData[0].DatabasesRolesIDs = map[string]string{"1eb93da6-e739-4396-902f-19f79aa74e39": "1"}
+	assertValidate(t, i, errParamIsInvalid("instancesData.databasesRolesIds", typeUUID))
+
+	i.InstancesData[0].DatabasesRolesIDs = map[string]string{"cd7f93a4-a2ff-41db-9ad2-6dd67dd285c7": "1eb93da6-e739-4396-902f-19f79aa74e39"}
+	assertValidate(t, i, ErrDatabasesRolesIdsNotInList)
+
+	i.InstancesData[0].DatabasesRolesIDs = map[string]string{"1eb93da6-e739-4396-902f-19f79aa74e39": "cd7f93a4-a2ff-41db-9ad2-6dd67dd285c7"}
+	assert.NoError(t, i.Validate())
+
+	i.InstancesData[0].DatabasesScopes = map[string]AccessScopeDTO{"1": {Schemas: []string{"reporting"}}}
+	assertValidate(t, i, errParamIsInvalid("instancesData.databasesScopes", typeUUID))
+
+	i.InstancesData[0].DatabasesScopes = map[string]AccessScopeDTO{"cd7f93a4-a2ff-41db-9ad2-6dd67dd285c7": {Schemas: []string{"reporting"}}}
+	assertValidate(t, i, ErrDatabasesScopesNotInList)
+
+	i.InstancesData[0].DatabasesScopes = map[string]AccessScopeDTO{"1eb93da6-e739-4396-902f-19f79aa74e39": {}}
+	assertValidate(t, i, ErrAccessScopeEmpty)
+
+	i.InstancesData[0].DatabasesScopes = map[string]AccessScopeDTO{"1eb93da6-e739-4396-902f-19f79aa74e39": {Tables: []string{"public.orders"}}}
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateChangeAccessPermissionRoleInputDTO(t *testing.T) {
+	i := &ChangeAccessPermissionRoleInputDTO{}
+	assertValidate(t, i, errParamIsRequired("databaseRoleId", typeUUID))
+
+	i.DatabaseRoleID = "1"
+	assertValidate(t, i, errParamIsInvalid("databaseRoleId", typeUUID))
+
+	i.DatabaseRoleID = "1eb93da6-e739-4396-902f-19f79aa74e39"
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateMigrateDatabaseUserRoleInputDTO(t *testing.T) {
+	i := &MigrateDatabaseUserRoleInputDTO{}
+	assertValidate(t, i, errParamIsRequired("databaseRoleId", typeUUID))
+
+	i.DatabaseRoleID = "1"
+	assertValidate(t, i, errParamIsInvalid("databaseRoleId", typeUUID))
+
+	i.DatabaseRoleID = "1eb93da6-e739-4396-902f-19f79aa74e39"
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateDatabaseRoleInputDTO(t *testing.T) {
+	i := &DatabaseRoleInputDTO{}
+	assertValidate(t, i, errParamIsRequired("name", typeString))
+
+	i.Name = "reporting"
+	assertValidate(t, i, errParamIsRequired("displayName", typeString))
+
+	i.DisplayName = "Reporting"
+	assertValidate(t, i, ErrArrayTablePrivilegesEmpty)
+
+	i.Privileges.Table = []string{"SELECT"}
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateUpdateDatabaseRoleInputDTO(t *testing.T) {
+	i := &UpdateDatabaseRoleInputDTO{}
+	assertValidate(t, i, errParamIsRequired("displayName", typeString))
+
+	i.DisplayName = "Reporting"
+	assertValidate(t, i, ErrArrayTablePrivilegesEmpty)
+
+	i.Privileges.Table = []string{"SELECT"}
+	assert.NoError(t, i.Validate())
 }
 
 func TestValidateRevokeAccessInputDTO(t *testing.T) {
@@ -157,6 +249,112 @@ func TestValidateRevokeAccessInputDTO(t *testing.T) {
 	assert.NoError(t, i.Validate())
 }
 
+func TestValidateAccessRequestInputDTO(t *testing.T) {
+	i := &AccessRequestInputDTO{Justification: "INC-42"}
+	assertValidate(t, i, ErrArrayDatabaseUsersIdsEmpty)
+
+	grant := GrantAccessInputDTO{DatabaseUsersIDs: []string{"1eb93da6-e739-4396-902f-19f79aa74e39"},
+		InstancesData: []InstanceDataDTO{{DatabaseInstanceID: "96cfa8f2-2c91-4630-b556-f7a2eab84e29"}}}
+	i = &AccessRequestInputDTO{GrantAccessInputDTO: grant, Justification: "  "}
+	assertValidate(t, i, errParamIsRequired("justification", typeString))
+
+	i = &AccessRequestInputDTO{GrantAccessInputDTO: grant, Justification: "INC-42"}
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateBreakGlassAccessInputDTO(t *testing.T) {
+	i := &BreakGlassAccessInputDTO{}
+	assertValidate(t, i, errParamIsRequired("databaseUserId", typeUUID))
+
+	i = &BreakGlassAccessInputDTO{DatabaseUserID: "1"}
+	assertValidate(t, i, errParamIsInvalid("databaseUserId", typeUUID))
+
+	i = &BreakGlassAccessInputDTO{DatabaseUserID: "1eb93da6-e739-4396-902f-19f79aa74e39"}
+	assertValidate(t, i, ErrArrayInstancesDataEmpty)
+
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "1", DatabasesIDs: []string{"96cfa8f2-2c91-4630-b556-f7a2eab84e29"}}}
+	assertValidate(t, i, errParamIsInvalid("instancesData", typeUUID))
+
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "96cfa8f2-2c91-4630-b556-f7a2eab84e29"}}
+	assertValidate(t, i, ErrArrayDatabasesIdsEmpty)
+
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "96cfa8f2-2c91-4630-b556-f7a2eab84e29", DatabasesIDs: []string{"1"}}}
+	assertValidate(t, i, errParamIsInvalid("instancesData", typeUUID))
+
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "96cfa8f2-2c91-4630-b556-f7a2eab84e29", DatabasesIDs: []string{"63862219-f1c3-41c4-9938-31346773b697"}}}
+	i.IncidentReference = "  "
+	assertValidate(t, i, errParamIsRequired("incidentReference", typeString))
+
+	i.IncidentReference = "INC-1234"
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateRecertificationCampaignInputDTO(t *testing.T) {
+	i := &RecertificationCampaignInputDTO{Name: "  "}
+	assertValidate(t, i, errParamIsRequired("name", typeString))
+
+	i = &RecertificationCampaignInputDTO{Name: "Q3 2024 recertification", EcosystemID: "1"}
+	assertValidate(t, i, errParamIsInvalid("ecosystemId", typeUUID))
+
+	i.EcosystemID = "96cfa8f2-2c91-4630-b556-f7a2eab84e29"
+	assertValidate(t, i, ErrArrayReviewersIdsEmpty)
+
+	i.ReviewersIDs = []string{"1"}
+	assertValidate(t, i, errParamIsInvalid("reviewersIds", typeUUID))
+
+	i.ReviewersIDs = []string{"1eb93da6-e739-4396-902f-19f79aa74e39"}
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateDecideRecertificationItemsInputDTO(t *testing.T) {
+	i := &DecideRecertificationItemsInputDTO{}
+	assertValidate(t, i, ErrArrayDecisionsEmpty)
+
+	i.Decisions = []RecertificationDecisionInputDTO{{ItemID: "1", Decision: "KEEP"}}
+	assertValidate(t, i, errParamIsInvalid("decisions.itemId", typeUUID))
+
+	i.Decisions = []RecertificationDecisionInputDTO{{ItemID: "1eb93da6-e739-4396-902f-19f79aa74e39", Decision: "PENDING"}}
+	assertValidate(t, i, errParamIsInvalid("decisions.decision", typeString))
+
+	i.Decisions = []RecertificationDecisionInputDTO{{ItemID: "1eb93da6-e739-4396-902f-19f79aa74e39", Decision: "REVOKE", Comment: "left the team"}}
+	assert.NoError(t, i.Validate())
+}
+
+func TestValidateAccessGroupInputDTO(t *testing.T) {
+	i := &AccessGroupInputDTO{Name: "  "}
+	assertValidate(t, i, errParamIsRequired("name", typeString))
+
+	i.Name = "Team B analysts"
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "1eb93da6-e739-4396-902f-19f79aa74e39", DatabasesIDs: []string{"1"}}}
+	assertValidate(t, i, errParamIsInvalid("instancesData", typeUUID))
+
+	i.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "1eb93da6-e739-4396-902f-19f79aa74e39"}}
+	assert.NoError(t, i.Validate())
+
+	targets := &AccessGroupTargetsInputDTO{}
+	assert.NoError(t, targets.Validate())
+
+	targets.InstancesData = []InstanceDataDTO{{DatabaseInstanceID: "1eb93da6-e739-4396-902f-19f79aa74e39",
+		DatabasesIDs:      []string{"1eb93da6-e739-4396-902f-19f79aa74e39"},
+		DatabasesRolesIDs: map[string]string{"1eb93da6-e739-4396-902f-19f79aa74e39": "cd7f93a4-a2ff-41db-9ad2-6dd67dd285c7"}}}
+	assertValidate(t, targets, ErrDatabasesRolesIdsNotUsed)
+
+	targets.InstancesData[0].DatabasesRolesIDs = nil
+	targets.InstancesData[0].DatabasesScopes = map[string]AccessScopeDTO{"1eb93da6-e739-4396-902f-19f79aa74e39": {Schemas: []string{"reporting"}}}
+	assertValidate(t, targets, ErrDatabasesScopesNotUsed)
+}
+
+func TestValidateAccessGroupMembersInputDTO(t *testing.T) {
+	i := &AccessGroupMembersInputDTO{}
+	assertValidate(t, i, ErrArrayDatabaseUsersIdsEmpty)
+
+	i.DatabaseUsersIDs = []string{"1eb93da6-e739-4396-902f-19f79aa74e39", "1"}
+	assertValidate(t, i, errParamIsInvalid("databaseUsersIds", typeUUID))
+
+	i.DatabaseUsersIDs = []string{"1eb93da6-e739-4396-902f-19f79aa74e39"}
+	assert.NoError(t, i.Validate())
+}
+
 func TestValidateChangeStatusDBUserInputDTO(t *testing.T) {
 	i := &ChangeStatusInputDTO{}
 	assertValidate(t, i, errParamIsRequired("id", typeUUID))
@@ -180,3 +378,27 @@ func assertValidate(t *testing.T, dto InputValidator, expectedError error) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, expectedError.Error())
 }
+
+func TestValidateDesiredStateInputDTO(t *testing.T) {
+	i := &DesiredStateInputDTO{}
+	assert.NoError(t, i.Validate())
+
+	i.Ecosystems = []DesiredEcosystemDTO{{Code: "azure", DisplayName: "Azure"}, {Code: "azure", DisplayName: "Azure 2"}}
+	assertValidate(t, i, errParamIsDuplicated("ecosystems.code", "azure"))
+
+	i.Ecosystems = i.Ecosystems[:1]
+	i.Instances = []DesiredInstanceDTO{{Name: "PostgreSQL - Azure", Ecosystem: "azure", Technology: "PostgreSQL", TechnologyVersion: "16"}}
+	assertValidate(t, i, errParamIsRequired("instances.host", typeString))
+
+	i.Instances[0].Host = "10.1.1.1"
+	i.Instances[0].Port = "5432"
+	i.Users = []DesiredUserDTO{{Email: "john", Name: "John Doe", Role: "developer"}}
+	assertValidate(t, i, errParamIsInvalid("users.email", typeString))
+
+	i.Users[0].Email = "johndoe@email.com"
+	i.Grants = []DesiredGrantDTO{{User: "johndoe@email.com", Instance: "PostgreSQL - Azure"}}
+	assertValidate(t, i, errParamIsRequired("grants.databases", "[]string"))
+
+	i.Grants[0].Databases = []string{"settings"}
+	assert.NoError(t, i.Validate())
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ApplicationUserOutputDTO struct {
 	ID      string `json:"id"`
@@ -10,13 +13,14 @@ type ApplicationUserOutputDTO struct {
 }
 
 type EcosystemOutputDTO struct {
-	ID              string     `json:"id"`
-	Code            string     `json:"code"`
-	DisplayName     string     `json:"displayName"`
-	CreatedByUserID string     `json:"createdByUserId,omitempty"`
-	CreatedByUser   string     `json:"createdByUser,omitempty"`
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+	ID                     string     `json:"id"`
+	Code                   string     `json:"code"`
+	DisplayName            string     `json:"displayName"`
+	RequiresAccessApproval bool       `json:"requiresAccessApproval"`
+	CreatedByUserID        string     `json:"createdByUserId,omitempty"`
+	CreatedByUser          string     `json:"createdByUser,omitempty"`
+	CreatedAt              time.Time  `json:"createdAt"`
+	UpdatedAt              *time.Time `json:"updatedAt,omitempty"`
 }
 
 type TechnologyOutputDTO struct {
@@ -38,8 +42,19 @@ type DatabaseInstanceOutputDTO struct {
 	PortConnection            string     `json:"portConnection"`
 	AdminUser                 string     `json:"adminUser,omitempty"`
 	AdminPassword             string     `json:"adminPassword,omitempty"`
+	SSLMode                   string     `json:"sslMode"`
+	CACertificate             string     `json:"-"`
+	ClientCertificate         string     `json:"-"`
+	ClientKey                 string     `json:"-"`
+	BastionHost               string     `json:"bastionHost,omitempty"`
+	BastionPort               string     `json:"bastionPort,omitempty"`
+	BastionUser               string     `json:"bastionUser,omitempty"`
+	BastionPrivateKey         string     `json:"-"`
+	BastionPassword           string     `json:"-"`
+	BastionHostKey            string     `json:"bastionHostKey,omitempty"`
 	EcosystemID               string     `json:"ecosystemId"`
 	EcosystemName             string     `json:"ecosystemName,omitempty"`
+	EcosystemRequiresApproval bool       `json:"ecosystemRequiresApproval"`
 	DatabaseTechnologyID      string     `json:"databaseTechnologyId"`
 	DatabaseTechnologyName    string     `json:"databaseTechnologyName,omitempty"`
 	DatabaseTechnologyVersion string     `json:"databaseTechnologyVersion,omitempty"`
@@ -70,6 +85,9 @@ type DatabaseOutputDTO struct {
 	DatabaseTechnologyVersion string     `json:"databaseTechnologyVersion"`
 	Enabled                   bool       `json:"enabled"`
 	RolesConfigured           bool       `json:"rolesConfigured"`
+	RolesTemplateVersion      int        `json:"rolesTemplateVersion,omitempty"`
+	RolesTemplateChecksum     string     `json:"rolesTemplateChecksum,omitempty"`
+	RolesConfiguredAt         *time.Time `json:"rolesConfiguredAt,omitempty"`
 	Description               string     `json:"description"`
 	CreatedByUserID           string     `json:"createdByUserId"`
 	CreatedByUser             string     `json:"createdByUser"`
@@ -91,6 +109,7 @@ type TestConnectionOutputDTO struct {
 	Technology         string `json:"technology,omitempty"`
 	Success            bool   `json:"success"`
 	Message            string `json:"message"`
+	QueueTimeMs        int64  `json:"queueTimeMs,omitempty"`
 }
 
 type SyncDatabasesOutputDTO struct {
@@ -101,6 +120,63 @@ type SyncDatabasesOutputDTO struct {
 	Success            bool   `json:"success"`
 	Message            string `json:"message"`
 	TotalDatabases     int    `json:"totalDatabases,omitempty"`
+	QueueTimeMs        int64  `json:"queueTimeMs,omitempty"`
+}
+
+// DiscoverRolesOutputDTO godoc
+// Result of the role discovery of an instance, with the number of logins found by class (e.g. "UNKNOWN": 2)
+type DiscoverRolesOutputDTO struct {
+	DatabaseInstanceID string         `json:"databaseInstanceId"`
+	Ecosystem          string         `json:"ecosystem,omitempty"`
+	Instance           string         `json:"instance,omitempty"`
+	Technology         string         `json:"technology,omitempty"`
+	Success            bool           `json:"success"`
+	Message            string         `json:"message"`
+	TotalLogins        int            `json:"totalLogins,omitempty"`
+	LoginsByClass      map[string]int `json:"loginsByClass,omitempty"`
+	QueueTimeMs        int64          `json:"queueTimeMs,omitempty"`
+}
+
+// ReconcileAccessOutputDTO godoc
+// Result of the reconciliation of an instance, with the drifts found between the access permissions and the instance
+type ReconcileAccessOutputDTO struct {
+	DatabaseInstanceID string           `json:"databaseInstanceId"`
+	Ecosystem          string           `json:"ecosystem,omitempty"`
+	Instance           string           `json:"instance,omitempty"`
+	Technology         string           `json:"technology,omitempty"`
+	Success            bool             `json:"success"`
+	Message            string           `json:"message"`
+	Drifts             []AccessDriftDTO `json:"drifts,omitempty"`
+	QueueTimeMs        int64            `json:"queueTimeMs,omitempty"`
+}
+
+// AccessDriftDTO godoc
+// A difference between the access permissions of a user and its access in the instance: MISSING_USER, MISSING_GRANT
+// or EXTRA_GRANT. Database is empty when the drift is of the whole instance. Corrected is set when the apply mode
+// fixed the drift, and Correction tells how it was fixed or why it wasn't.
+type AccessDriftDTO struct {
+	Type           string `json:"type"`
+	DatabaseUserID string `json:"databaseUserId"`
+	Username       string `json:"username"`
+	Database       string `json:"database,omitempty"`
+	Details        string `json:"details"`
+	Corrected      bool   `json:"corrected"`
+	Correction     string `json:"correction,omitempty"`
+}
+
+type RoleFindingOutputDTO struct {
+	ID                   string    `json:"id"`
+	DatabaseInstanceID   string    `json:"databaseInstanceId"`
+	DatabaseInstanceName string    `json:"databaseInstanceName"`
+	EcosystemName        string    `json:"ecosystemName"`
+	LoginName            string    `json:"loginName"`
+	DatabaseUserID       string    `json:"databaseUserId,omitempty"`
+	DatabaseUserName     string    `json:"databaseUserName,omitempty"`
+	Class                string    `json:"class"`
+	Details              string    `json:"details"`
+	DiscoveredByUserID   string    `json:"discoveredByUserId"`
+	DiscoveredByUserName string    `json:"discoveredByUserName"`
+	DiscoveredAt         time.Time `json:"discoveredAt"`
 }
 
 type PropagateRolesOutputDTO struct {
@@ -110,16 +186,21 @@ type PropagateRolesOutputDTO struct {
 	Technology         string `json:"technology,omitempty"`
 	Success            bool   `json:"success"`
 	Message            string `json:"message"`
+	QueueTimeMs        int64  `json:"queueTimeMs,omitempty"`
+	// Databases are the statements the propagation would execute, in dry run
+	Databases []DryRunDatabaseOutputDTO `json:"databases,omitempty"`
 }
 
 type DatabaseRoleOutputDTO struct {
-	ID              string    `json:"id"`
-	Name            string    `json:"name"`
-	DisplayName     string    `json:"displayName"`
-	Description     string    `json:"description"`
-	ReadOnly        bool      `json:"readOnly"`
-	CreatedAt       time.Time `json:"createdAt"`
-	CreatedByUserID string    `json:"createdByUserId"`
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	DisplayName     string            `json:"displayName"`
+	Description     string            `json:"description"`
+	ReadOnly        bool              `json:"readOnly"`
+	Privileges      RolePrivilegesDTO `json:"privileges"`
+	CreatedAt       time.Time         `json:"createdAt"`
+	UpdatedAt       *time.Time        `json:"updatedAt,omitempty"`
+	CreatedByUserID string            `json:"createdByUserId"`
 }
 
 type SetupRolesOutputDTO struct {
@@ -131,6 +212,15 @@ type SetupRolesOutputDTO struct {
 	Instance           string `json:"instance,omitempty"`
 	Success            bool   `json:"success"`
 	Message            string `json:"message"`
+	QueueTimeMs        int64  `json:"queueTimeMs,omitempty"`
+	// Statements are the statements the setup would execute, in dry run
+	Statements []string `json:"statements,omitempty"`
+}
+
+type OutdatedRolesDatabasesOutputDTO struct {
+	RolesTemplateVersion  int                  `json:"rolesTemplateVersion"`
+	RolesTemplateChecksum string               `json:"rolesTemplateChecksum"`
+	Databases             []*DatabaseOutputDTO `json:"databases"`
 }
 
 type DatabaseUserOutputDTO struct {
@@ -165,35 +255,192 @@ type DatabaseUserCredentialsOutputDTO struct {
 }
 
 type AccessPermissionOutputDTO struct {
-	ID                   string    `json:"id"`
-	DatabaseUserID       string    `json:"databaseUserId"`
-	DatabaseUserName     string    `json:"databaseUserName"`
-	DatabaseUserEmail    string    `json:"databaseUserEmail"`
-	DatabaseRoleID       string    `json:"databaseRoleId"`
-	DatabaseRoleName     string    `json:"databaseRoleName"`
-	EcosystemID          string    `json:"ecosystemId"`
-	EcosystemName        string    `json:"ecosystemName"`
-	DatabaseInstanceID   string    `json:"databaseInstanceId"`
-	DatabaseInstanceName string    `json:"databaseInstanceName"`
-	DatabaseID           string    `json:"databaseId"`
-	DatabaseName         string    `json:"databaseName"`
-	GrantedByUserID      string    `json:"grantedByUserId"`
-	GrantedByUserName    string    `json:"grantedByUserName"`
-	GrantedAt            time.Time `json:"grantedAt"`
+	ID                   string     `json:"id"`
+	DatabaseUserID       string     `json:"databaseUserId"`
+	DatabaseUserName     string     `json:"databaseUserName"`
+	DatabaseUserEmail    string     `json:"databaseUserEmail"`
+	DatabaseUserTeam     string     `json:"databaseUserTeam"`
+	DatabaseRoleID       string     `json:"databaseRoleId"`
+	DatabaseRoleName     string     `json:"databaseRoleName"`
+	EcosystemID          string     `json:"ecosystemId"`
+	EcosystemName        string     `json:"ecosystemName"`
+	DatabaseInstanceID   string     `json:"databaseInstanceId"`
+	DatabaseInstanceName string     `json:"databaseInstanceName"`
+	DatabaseID           string     `json:"databaseId"`
+	DatabaseName         string     `json:"databaseName"`
+	GrantedByUserID      string     `json:"grantedByUserId"`
+	GrantedByUserName    string     `json:"grantedByUserName"`
+	GrantedAt            time.Time  `json:"grantedAt"`
+	ExpiresAt            *time.Time `json:"expiresAt,omitempty"`
+	// Scope is the allowlist of schemas and tables the user can access, when not the whole database
+	Scope *AccessScopeDTO `json:"scope,omitempty"`
+	// GrantedByAccessGroupID is the access group that granted the permission, when not granted directly
+	GrantedByAccessGroupID string `json:"grantedByAccessGroupId,omitempty"`
 }
 
 type GrantAccessOutputDTO struct {
-	HasErrors bool   `json:"hasErrors"`
-	Message   string `json:"message"`
+	HasErrors bool                `json:"hasErrors"`
+	Message   string              `json:"message"`
+	Execution *ExecutionOutputDTO `json:"execution,omitempty"`
+	// Plan has the statements the grant would execute in each instance, in dry run
+	Plan []DryRunInstanceOutputDTO `json:"plan,omitempty"`
+}
+
+// GrantBreakGlassAccessOutputDTO godoc
+// Result of the break-glass access, with the result of each database of the form "instance # database"
+type GrantBreakGlassAccessOutputDTO struct {
+	HasErrors bool            `json:"hasErrors"`
+	Message   string          `json:"message"`
+	ExpiresAt time.Time       `json:"expiresAt"`
+	Databases []ItemResultDTO `json:"databases"`
+}
+
+type BreakGlassAccessOutputDTO struct {
+	ID                 string     `json:"id"`
+	DatabaseUserID     string     `json:"databaseUserId"`
+	DatabaseUsername   string     `json:"databaseUsername"`
+	DatabaseInstanceID string     `json:"databaseInstanceId"`
+	DatabaseID         string     `json:"databaseId"`
+	DatabaseName       string     `json:"databaseName"`
+	IncidentReference  string     `json:"incidentReference"`
+	RoleGranted        bool       `json:"roleGranted"`
+	GrantedByUserID    string     `json:"grantedByUserId"`
+	GrantedAt          time.Time  `json:"grantedAt"`
+	ExpiresAt          time.Time  `json:"expiresAt"`
+	RevokedAt          *time.Time `json:"revokedAt,omitempty"`
+}
+
+// MigrateDatabaseUserRoleOutputDTO godoc
+// Result of the role migration, with the result of each instance where the user exists. The user keeps the old role
+// until the migration succeeds on every instance.
+type MigrateDatabaseUserRoleOutputDTO struct {
+	HasErrors    bool                   `json:"hasErrors"`
+	Message      string                 `json:"message"`
+	DatabaseUser *DatabaseUserOutputDTO `json:"databaseUser"`
+	Instances    []ItemResultDTO        `json:"instances"`
+	Execution    *ExecutionOutputDTO    `json:"execution,omitempty"`
 }
 
 type RevokeAccessOutputDTO struct {
-	HasErrors bool   `json:"hasErrors"`
-	Message   string `json:"message"`
+	HasErrors bool                `json:"hasErrors"`
+	Message   string              `json:"message"`
+	Execution *ExecutionOutputDTO `json:"execution,omitempty"`
+	// Plan has the statements the revoke would execute in each instance, in dry run
+	Plan []DryRunInstanceOutputDTO `json:"plan,omitempty"`
+}
+
+// DryRunInstanceOutputDTO godoc
+// The statements an operation in dry run would execute in an instance, by database
+type DryRunInstanceOutputDTO struct {
+	DatabaseInstanceID string                    `json:"databaseInstanceId"`
+	Instance           string                    `json:"instance"`
+	Databases          []DryRunDatabaseOutputDTO `json:"databases"`
+}
+
+// DryRunDatabaseOutputDTO godoc
+// The statements an operation in dry run would execute in a database, in the order they would be executed
+type DryRunDatabaseOutputDTO struct {
+	Database   string   `json:"database"`
+	Statements []string `json:"statements"`
+}
+
+// ExecutionOutputDTO godoc
+// Shows how the tasks of an operation were scheduled by the executor. Queued tasks waited for a free slot because
+// the concurrency limits were reached.
+type ExecutionOutputDTO struct {
+	Tasks                     int   `json:"tasks"`
+	QueuedTasks               int   `json:"queuedTasks"`
+	MaxQueueTimeMs            int64 `json:"maxQueueTimeMs"`
+	TotalQueueTimeMs          int64 `json:"totalQueueTimeMs"`
+	MaxConcurrency            int   `json:"maxConcurrency"`
+	MaxConcurrencyPerInstance int   `json:"maxConcurrencyPerInstance"`
+	Saturated                 bool  `json:"saturated"`
+}
+
+// ItemResultDTO godoc
+// Result of an item processed by an operation, e.g. the grant of access to a user in an instance
+type ItemResultDTO struct {
+	Item    string `json:"item"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ProgressEventDTO godoc
+// Message of an operation about one of its contexts: an instance, a user in an instance or a database of a user in an
+// instance. The indexes start at 1 and identify the position of the context in the progress tree of the operation.
+type ProgressEventDTO struct {
+	Instance      string    `json:"instance"`
+	InstanceIndex int       `json:"instanceIndex"`
+	InstancesQty  int       `json:"instancesQty"`
+	User          string    `json:"user,omitempty"`
+	UserIndex     int       `json:"userIndex,omitempty"`
+	UsersQty      int       `json:"usersQty,omitempty"`
+	Database      string    `json:"database,omitempty"`
+	DatabaseIndex int       `json:"databaseIndex,omitempty"`
+	DatabasesQty  int       `json:"databasesQty,omitempty"`
+	Message       string    `json:"message"`
+	Error         bool      `json:"error"`
+	Time          time.Time `json:"time"`
+}
+
+// ItemProgressEventDTO godoc
+// Item processed by an operation, with the counters of the operation up to it
+type ItemProgressEventDTO struct {
+	ItemResultDTO
+	TotalItems     int `json:"totalItems"`
+	ProcessedItems int `json:"processedItems"`
+	FailedItems    int `json:"failedItems"`
+}
+
+// JobOutputDTO godoc
+// Operation processed in background. Input and Result are the input and the output DTOs of the operation, the items
+// and the result are only loaded when the job is fetched by its ID.
+type JobOutputDTO struct {
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Input           json.RawMessage `json:"input,omitempty" swaggertype:"object"`
+	TotalItems      int             `json:"totalItems"`
+	ProcessedItems  int             `json:"processedItems"`
+	FailedItems     int             `json:"failedItems"`
+	Items           []ItemResultDTO `json:"items,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error           string          `json:"error,omitempty"`
+	Attempts        int             `json:"attempts"`
+	CreatedByUserID string          `json:"createdByUserId"`
+	CreatedByUser   string          `json:"createdByUser,omitempty"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+	StartedAt       *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time      `json:"finishedAt,omitempty"`
+}
+
+// AccessRequestOutputDTO godoc
+// Request of access with its trail: who requested it and why, who reviewed it and the result of the grant once approved.
+// Input is the GrantAccessInputDTO requested and Result the GrantAccessOutputDTO of its execution, only loaded when the
+// request is fetched by its ID.
+type AccessRequestOutputDTO struct {
+	ID                string          `json:"id"`
+	Status            string          `json:"status"`
+	Input             json.RawMessage `json:"input" swaggertype:"object"`
+	Justification     string          `json:"justification"`
+	RequestedByUserID string          `json:"requestedByUserId"`
+	RequestedByUser   string          `json:"requestedByUser,omitempty"`
+	ReviewedByUserID  *string         `json:"reviewedByUserId,omitempty"`
+	ReviewedByUser    *string         `json:"reviewedByUser,omitempty"`
+	ReviewComment     string          `json:"reviewComment,omitempty"`
+	Result            json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	Error             string          `json:"error,omitempty"`
+	CreatedAt         time.Time       `json:"createdAt"`
+	UpdatedAt         time.Time       `json:"updatedAt"`
+	ExpiresAt         time.Time       `json:"expiresAt"`
+	ReviewedAt        *time.Time      `json:"reviewedAt,omitempty"`
+	ExecutedAt        *time.Time      `json:"executedAt,omitempty"`
 }
 
 type AccessPermissionLogOutputDTO struct {
 	ID                   string    `json:"id"`
+	Type                 string    `json:"type"`
 	DatabaseUserID       *string   `json:"databaseUserId,omitempty"`
 	DatabaseUserName     *string   `json:"databaseUserName,omitempty"`
 	DatabaseUserEmail    *string   `json:"databaseUserEmail,omitempty"`
@@ -208,9 +455,124 @@ type AccessPermissionLogOutputDTO struct {
 	Date                 time.Time `json:"date"`
 }
 
+type RecertificationCampaignOutputDTO struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	EcosystemID     *string    `json:"ecosystemId,omitempty"`
+	EcosystemName   *string    `json:"ecosystemName,omitempty"`
+	Team            string     `json:"team,omitempty"`
+	CreatedByUserID string     `json:"createdByUserId"`
+	CreatedByUser   string     `json:"createdByUser"`
+	ClosedByUserID  *string    `json:"closedByUserId,omitempty"`
+	ClosedByUser    *string    `json:"closedByUser,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	ClosedAt        *time.Time `json:"closedAt,omitempty"`
+	TotalItems      int        `json:"totalItems"`
+	PendingItems    int        `json:"pendingItems"`
+	KeptItems       int        `json:"keptItems"`
+	RevokedItems    int        `json:"revokedItems"`
+}
+
+// RecertificationItemOutputDTO godoc
+// Permission is the snapshot of the access permission taken when the campaign was created
+type RecertificationItemOutputDTO struct {
+	ID               string                    `json:"id"`
+	CampaignID       string                    `json:"campaignId"`
+	Permission       AccessPermissionOutputDTO `json:"permission"`
+	ReviewerID       string                    `json:"reviewerId"`
+	Reviewer         string                    `json:"reviewer"`
+	Decision         string                    `json:"decision"`
+	Comment          string                    `json:"comment,omitempty"`
+	DecidedAt        *time.Time                `json:"decidedAt,omitempty"`
+	RevocationResult string                    `json:"revocationResult,omitempty"`
+}
+
+type CloseRecertificationCampaignOutputDTO struct {
+	HasErrors   bool                              `json:"hasErrors"`
+	Message     string                            `json:"message"`
+	Campaign    *RecertificationCampaignOutputDTO `json:"campaign"`
+	Revocations []ItemResultDTO                   `json:"revocations"`
+}
+
+// RecertificationReportOutputDTO godoc
+// Evidence of a campaign: every permission reviewed, with the decision of its reviewer and the result of its revocation
+type RecertificationReportOutputDTO struct {
+	Campaign    *RecertificationCampaignOutputDTO `json:"campaign"`
+	Items       []*RecertificationItemOutputDTO   `json:"items"`
+	GeneratedAt time.Time                         `json:"generatedAt"`
+}
+
+// AccessGroupOutputDTO godoc
+// Targets and Members are only returned when a single group is fetched
+type AccessGroupOutputDTO struct {
+	ID              string                       `json:"id"`
+	Name            string                       `json:"name"`
+	Description     string                       `json:"description,omitempty"`
+	CreatedByUserID string                       `json:"createdByUserId"`
+	CreatedByUser   string                       `json:"createdByUser"`
+	CreatedAt       time.Time                    `json:"createdAt"`
+	UpdatedAt       time.Time                    `json:"updatedAt"`
+	TargetsQty      int                          `json:"targetsQty"`
+	MembersQty      int                          `json:"membersQty"`
+	Targets         []AccessGroupTargetOutputDTO `json:"targets,omitempty"`
+	Members         []AccessGroupMemberOutputDTO `json:"members,omitempty"`
+}
+
+// AccessGroupTargetOutputDTO godoc
+// A target without database covers all the enabled databases of the instance
+type AccessGroupTargetOutputDTO struct {
+	DatabaseInstanceID   string  `json:"databaseInstanceId"`
+	DatabaseInstanceName string  `json:"databaseInstanceName"`
+	DatabaseID           *string `json:"databaseId,omitempty"`
+	DatabaseName         *string `json:"databaseName,omitempty"`
+}
+
+type AccessGroupMemberOutputDTO struct {
+	DatabaseUserID    string    `json:"databaseUserId"`
+	DatabaseUserName  string    `json:"databaseUserName"`
+	DatabaseUserEmail string    `json:"databaseUserEmail"`
+	Username          string    `json:"username"`
+	AddedByUserID     string    `json:"addedByUserId"`
+	AddedByUser       string    `json:"addedByUser"`
+	AddedAt           time.Time `json:"addedAt"`
+}
+
+// AccessGroupChangeOutputDTO godoc
+// Result of a change of the members or the targets of a group: the grant of the access now covered by the group and the
+// revocation of the access no longer covered, with one item per member of the form "username # instance"
+type AccessGroupChangeOutputDTO struct {
+	HasErrors   bool                  `json:"hasErrors"`
+	Message     string                `json:"message"`
+	Group       *AccessGroupOutputDTO `json:"group"`
+	Grant       *GrantAccessOutputDTO `json:"grant,omitempty"`
+	Revocations []ItemResultDTO       `json:"revocations,omitempty"`
+}
+
 type ChangeStatusOutputDTO struct {
 	ID         string     `json:"id"`
 	Enabled    bool       `json:"enabled"`
 	UpdatedAt  time.Time  `json:"updatedAt"`
 	DisabledAt *time.Time `json:"disabledAt,omitempty"`
 }
+
+// DesiredStateOutputDTO godoc
+// Changes needed to reach the desired state, in the order they are applied. HasErrors and the success and message of
+// each change are only filled when the changes are applied.
+type DesiredStateOutputDTO struct {
+	HasErrors bool                    `json:"hasErrors"`
+	Message   string                  `json:"message"`
+	Changes   []DesiredStateChangeDTO `json:"changes"`
+	// Warnings are the differences from the desired state that can't be changed
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type DesiredStateChangeDTO struct {
+	Action  string `json:"action" example:"UPDATE"`
+	Kind    string `json:"kind" example:"USER"`
+	Name    string `json:"name" example:"johndoe@email.com"`
+	Details string `json:"details,omitempty" example:"team: Team A -> Team B"`
+	Success *bool  `json:"success,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const allDatabasesTargetKey = "*"
+
+var (
+	ErrAccessGroupIDNotInformed = errors.New("access group id not informed")
+	ErrAddedByUserIDNotInformed = errors.New("added by user id not informed")
+)
+
+// AccessGroup godoc
+// Set of database users (members) that receive the same access, given by the targets of the group: instances and their
+// databases. The access of the members is granted and revoked as the members and the targets of the group change.
+type AccessGroup struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	CreatedByUserID string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func NewAccessGroup(name, description, createdByUserID string) (*AccessGroup, error) {
+	currentTime := time.Now()
+	g := &AccessGroup{
+		ID:              uuid.New(),
+		Name:            strings.TrimSpace(name),
+		Description:     strings.TrimSpace(description),
+		CreatedByUserID: createdByUserID,
+		CreatedAt:       currentTime,
+		UpdatedAt:       currentTime,
+	}
+	err := g.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *AccessGroup) Validate() error {
+	if g.Name == "" {
+		return ErrInvalidName
+	}
+	if g.CreatedByUserID == "" {
+		return ErrCreatedByUserNotInformed
+	}
+	return nil
+}
+
+// AccessGroupTarget godoc
+// Database of an instance granted to the members of a group. A target without DatabaseID covers all the enabled
+// databases of the instance, like a grant without databases.
+type AccessGroupTarget struct {
+	ID                 uuid.UUID
+	AccessGroupID      string
+	DatabaseInstanceID string
+	DatabaseID         sql.NullString
+}
+
+func NewAccessGroupTarget(accessGroupID, databaseInstanceID, databaseID string) (*AccessGroupTarget, error) {
+	t := &AccessGroupTarget{
+		ID:                 uuid.New(),
+		AccessGroupID:      accessGroupID,
+		DatabaseInstanceID: databaseInstanceID,
+		DatabaseID:         sql.NullString{String: databaseID, Valid: databaseID != ""},
+	}
+	err := t.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Covers godoc
+// Checks if the target gives access to the database of the instance
+func (t *AccessGroupTarget) Covers(databaseInstanceID, databaseID string) bool {
+	return t.DatabaseInstanceID == databaseInstanceID && (!t.DatabaseID.Valid || t.DatabaseID.String == databaseID)
+}
+
+// Key godoc
+// Identifies the instance and database of the target, regardless of its group, to compare the targets of groups
+func (t *AccessGroupTarget) Key() string {
+	if !t.DatabaseID.Valid {
+		return t.DatabaseInstanceID + "/" + allDatabasesTargetKey
+	}
+	return t.DatabaseInstanceID + "/" + t.DatabaseID.String
+}
+
+func (t *AccessGroupTarget) Validate() error {
+	if t.AccessGroupID == "" {
+		return ErrAccessGroupIDNotInformed
+	}
+	if t.DatabaseInstanceID == "" {
+		return ErrDatabaseInstanceIDNotInformed
+	}
+	return nil
+}
+
+type AccessGroupMember struct {
+	AccessGroupID  string
+	DatabaseUserID string
+	AddedByUserID  string
+	AddedAt        time.Time
+}
+
+func NewAccessGroupMember(accessGroupID, databaseUserID, addedByUserID string) (*AccessGroupMember, error) {
+	m := &AccessGroupMember{
+		AccessGroupID:  accessGroupID,
+		DatabaseUserID: databaseUserID,
+		AddedByUserID:  addedByUserID,
+		AddedAt:        time.Now(),
+	}
+	err := m.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *AccessGroupMember) Validate() error {
+	if m.AccessGroupID == "" {
+		return ErrAccessGroupIDNotInformed
+	}
+	if m.DatabaseUserID == "" {
+		return ErrDatabaseUserIDNotInformed
+	}
+	if m.AddedByUserID == "" {
+		return ErrAddedByUserIDNotInformed
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const accessGroupName = "Team B analysts"
+
+func TestGivenAnEmptyRequiredParam_WhenValidateAccessGroup_ThenShouldReceiveAnError(t *testing.T) {
+	g := &AccessGroup{}
+	assertValidate(t, g, ErrInvalidName)
+
+	g = &AccessGroup{Name: accessGroupName}
+	assertValidate(t, g, ErrCreatedByUserNotInformed)
+}
+
+func TestGivenAValidParams_WhenCreateNewAccessGroup_ThenShouldReturnTheGroup(t *testing.T) {
+	g, err := NewAccessGroup(" "+accessGroupName+" ", " Read access to the reports ", userID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, g.ID)
+	assert.Equal(t, accessGroupName, g.Name)
+	assert.Equal(t, "Read access to the reports", g.Description)
+	assert.Equal(t, g.CreatedAt, g.UpdatedAt)
+}
+
+func TestGivenAnEmptyRequiredParam_WhenValidateAccessGroupTarget_ThenShouldReceiveAnError(t *testing.T) {
+	target := &AccessGroupTarget{}
+	assertValidate(t, target, ErrAccessGroupIDNotInformed)
+
+	target = &AccessGroupTarget{AccessGroupID: uuid.New().String()}
+	assertValidate(t, target, ErrDatabaseInstanceIDNotInformed)
+}
+
+func TestGivenATargetOfAllDatabases_WhenCheckCoverage_ThenShouldCoverAnyDatabaseOfTheInstance(t *testing.T) {
+	instanceID, databaseID := uuid.New().String(), uuid.New().String()
+	target, err := NewAccessGroupTarget(uuid.New().String(), instanceID, "")
+	assert.NoError(t, err)
+
+	assert.False(t, target.DatabaseID.Valid)
+	assert.True(t, target.Covers(instanceID, databaseID))
+	assert.False(t, target.Covers(uuid.New().String(), databaseID))
+	assert.Equal(t, instanceID+"/*", target.Key())
+}
+
+func TestGivenATargetOfADatabase_WhenCheckCoverage_ThenShouldCoverOnlyThatDatabase(t *testing.T) {
+	instanceID, databaseID := uuid.New().String(), uuid.New().String()
+	target, err := NewAccessGroupTarget(uuid.New().String(), instanceID, databaseID)
+	assert.NoError(t, err)
+
+	assert.True(t, target.Covers(instanceID, databaseID))
+	assert.False(t, target.Covers(instanceID, uuid.New().String()))
+	assert.Equal(t, instanceID+"/"+databaseID, target.Key())
+}
+
+func TestGivenAnEmptyRequiredParam_WhenValidateAccessGroupMember_ThenShouldReceiveAnError(t *testing.T) {
+	m := &AccessGroupMember{}
+	assertValidate(t, m, ErrAccessGroupIDNotInformed)
+
+	m = &AccessGroupMember{AccessGroupID: uuid.New().String()}
+	assertValidate(t, m, ErrDatabaseUserIDNotInformed)
+
+	m.DatabaseUserID = uuid.New().String()
+	assertValidate(t, m, ErrAddedByUserIDNotInformed)
+
+	m.AddedByUserID = userID
+	assert.NoError(t, m.Validate())
+}
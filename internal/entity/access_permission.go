@@ -1,7 +1,11 @@
 package entity
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +15,7 @@ var (
 	ErrDatabaseIDNotInformed      = errors.New("database id not informed")
 	ErrDatabaseUserIDNotInformed  = errors.New("database user id not informed")
 	ErrGrantedByUserIDNotInformed = errors.New("granted by user id not informed")
+	ErrInvalidScopeObject         = errors.New("invalid object in scope, schemas must be informed by name and tables by name or schema and name (e.g. reporting.sales)")
 )
 
 type AccessPermission struct {
@@ -19,9 +24,77 @@ type AccessPermission struct {
 	DatabaseUserID  string
 	GrantedByUserID string
 	GrantedAt       time.Time
+	ExpiresAt       sql.NullTime
+	// DatabaseRoleID is the role the user has in the database instead of its own role, when informed
+	DatabaseRoleID sql.NullString
+	// Scope restricts the privileges of the role to some schemas and tables of the database, when informed
+	Scope AccessScope
+	// GrantedByAccessGroupID is the access group whose grant created the permission, when not granted directly
+	GrantedByAccessGroupID sql.NullString
 }
 
-func NewAccessPermission(databaseID, databaseUserID, grantedByUserID string) (*AccessPermission, error) {
+// AccessScope godoc
+// Allowlist of the schemas and tables of a database where the user has the privileges of its role. The user has them
+// in all the database when it's empty.
+type AccessScope struct {
+	Schemas []string
+	Tables  []string
+}
+
+func NewAccessScope(schemas, tables []string) (AccessScope, error) {
+	s := AccessScope{Schemas: normalizeScopeObjects(schemas), Tables: normalizeScopeObjects(tables)}
+	if err := s.Validate(); err != nil {
+		return AccessScope{}, err
+	}
+	return s, nil
+}
+
+func (s AccessScope) Validate() error {
+	for _, schema := range s.Schemas {
+		if schema == "" || strings.Contains(schema, ".") {
+			return fmt.Errorf("%w: schema '%s'", ErrInvalidScopeObject, schema)
+		}
+	}
+	for _, table := range s.Tables {
+		if parts := strings.Split(table, "."); len(parts) > 2 || slices.Contains(parts, "") {
+			return fmt.Errorf("%w: table '%s'", ErrInvalidScopeObject, table)
+		}
+	}
+	return nil
+}
+
+func (s AccessScope) IsEmpty() bool {
+	return len(s.Schemas) == 0 && len(s.Tables) == 0
+}
+
+// String godoc
+// Describes the scope for the logs, e.g. "schemas reporting; tables public.orders"
+func (s AccessScope) String() string {
+	var parts []string
+	if len(s.Schemas) > 0 {
+		parts = append(parts, "schemas "+strings.Join(s.Schemas, ", "))
+	}
+	if len(s.Tables) > 0 {
+		parts = append(parts, "tables "+strings.Join(s.Tables, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func normalizeScopeObjects(objects []string) []string {
+	var normalized []string
+	for _, object := range objects {
+		object = strings.TrimSpace(object)
+		if !slices.Contains(normalized, object) {
+			normalized = append(normalized, object)
+		}
+	}
+	return normalized
+}
+
+// NewAccessPermission godoc
+// Creates the permission of a user to a database, that expires at the given time or never when it's nil.
+// An expiration already passed is kept as is, so the permission is revoked by the next check of expired permissions.
+func NewAccessPermission(databaseID, databaseUserID, grantedByUserID string, expiresAt *time.Time) (*AccessPermission, error) {
 	a := &AccessPermission{
 		ID:              uuid.New(),
 		DatabaseID:      databaseID,
@@ -29,6 +102,9 @@ func NewAccessPermission(databaseID, databaseUserID, grantedByUserID string) (*A
 		GrantedByUserID: grantedByUserID,
 		GrantedAt:       time.Now(),
 	}
+	if expiresAt != nil {
+		a.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
 	if err := a.Validate(); err != nil {
 		return nil, err
 	}
@@ -47,3 +123,20 @@ func (a *AccessPermission) Validate() error {
 	}
 	return nil
 }
+
+func (a *AccessPermission) IsScoped() bool {
+	return !a.Scope.IsEmpty()
+}
+
+// SetDatabaseRole godoc
+// Sets the role the user has in the database, see PermissionDatabaseRoleID
+func (a *AccessPermission) SetDatabaseRole(databaseRoleID, ownRoleID string) {
+	a.DatabaseRoleID = PermissionDatabaseRoleID(databaseRoleID, ownRoleID)
+}
+
+// PermissionDatabaseRoleID godoc
+// Returns the role kept in a permission for the user in the database. No role is kept when it's empty or the user's own
+// role, so the permission follows the own role of the user.
+func PermissionDatabaseRoleID(databaseRoleID, ownRoleID string) sql.NullString {
+	return sql.NullString{String: databaseRoleID, Valid: databaseRoleID != "" && databaseRoleID != ownRoleID}
+}
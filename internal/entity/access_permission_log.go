@@ -8,6 +8,18 @@ import (
 	"github.com/google/uuid"
 )
 
+type AccessPermissionLogType string
+
+const (
+	// AccessPermissionLogTypeAccess is the type of the logs of the regular grants and revocations
+	AccessPermissionLogTypeAccess AccessPermissionLogType = "ACCESS"
+	// AccessPermissionLogTypeBreakGlass is the type of the logs of the emergency access, see BreakGlassAccess
+	AccessPermissionLogTypeBreakGlass AccessPermissionLogType = "BREAK_GLASS"
+	// AccessPermissionLogTypeReconcile is the type of the logs of the corrections made by the reconciliation of the
+	// access permissions with the instances
+	AccessPermissionLogTypeReconcile AccessPermissionLogType = "RECONCILE"
+)
+
 var (
 	ErrUserIDNotInformed  = errors.New("user id not informed")
 	ErrMessageNotInformed = errors.New("message not informed")
@@ -15,6 +27,7 @@ var (
 
 type AccessPermissionLog struct {
 	ID                 uuid.UUID
+	Type               AccessPermissionLogType
 	DatabaseUserID     sql.NullString
 	DatabaseInstanceID string
 	DatabaseID         sql.NullString
@@ -25,6 +38,10 @@ type AccessPermissionLog struct {
 }
 
 func NewAccessPermissionLog(databaseInstanceID, databaseUserID, databaseID, message, operationUserID string, success bool) (*AccessPermissionLog, error) {
+	return NewAccessPermissionLogOfType(AccessPermissionLogTypeAccess, databaseInstanceID, databaseUserID, databaseID, message, operationUserID, success)
+}
+
+func NewAccessPermissionLogOfType(logType AccessPermissionLogType, databaseInstanceID, databaseUserID, databaseID, message, operationUserID string, success bool) (*AccessPermissionLog, error) {
 	databaseUserIDFormatted := sql.NullString{
 		String: databaseUserID,
 		Valid:  databaseUserID != "",
@@ -35,6 +52,7 @@ func NewAccessPermissionLog(databaseInstanceID, databaseUserID, databaseID, mess
 	}
 	g := &AccessPermissionLog{
 		ID:                 uuid.New(),
+		Type:               logType,
 		DatabaseUserID:     databaseUserIDFormatted,
 		DatabaseInstanceID: databaseInstanceID,
 		DatabaseID:         databaseIDFormatted,
@@ -62,3 +80,12 @@ func (g *AccessPermissionLog) Validate() error {
 	}
 	return nil
 }
+
+func ValidateAccessPermissionLogType(logType string) bool {
+	switch AccessPermissionLogType(logType) {
+	case AccessPermissionLogTypeAccess, AccessPermissionLogTypeBreakGlass, AccessPermissionLogTypeReconcile:
+		return true
+	default:
+		return false
+	}
+}
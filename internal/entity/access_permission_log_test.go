@@ -48,3 +48,20 @@ func TestGivenAValidParams_WhenCreateNewAccessPermissionLog_ThenShouldReturnAnAc
 	assert.True(t, g.Success)
 	assert.NotEmpty(t, g.Date)
 }
+
+func TestGivenAValidParams_WhenCreateNewAccessPermissionLog_ThenShouldHaveTheAccessType(t *testing.T) {
+	g, err := NewAccessPermissionLog(instanceID, userID, databaseID, message, userID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessPermissionLogTypeAccess, g.Type)
+
+	g, err = NewAccessPermissionLogOfType(AccessPermissionLogTypeBreakGlass, instanceID, userID, databaseID, message, userID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, AccessPermissionLogTypeBreakGlass, g.Type)
+}
+
+func TestGivenALogType_WhenValidateAccessPermissionLogType_ThenShouldAcceptOnlyTheKnownTypes(t *testing.T) {
+	assert.True(t, ValidateAccessPermissionLogType("ACCESS"))
+	assert.True(t, ValidateAccessPermissionLogType("BREAK_GLASS"))
+	assert.False(t, ValidateAccessPermissionLogType("access"))
+	assert.False(t, ValidateAccessPermissionLogType(""))
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestGivenValidParams_WhenValidateAccessPermission_ThenShouldNotReceiveAnErr
 }
 
 func TestGivenAnInvalidParams_WhenCreateNewAccessPermission_ThenShouldReturnAnError(t *testing.T) {
-	a, err := NewAccessPermission(databaseID, "", "")
+	a, err := NewAccessPermission(databaseID, "", "", nil)
 	assert.Error(t, err)
 	assert.EqualError(t, err, ErrDatabaseUserIDNotInformed.Error())
 	assert.Nil(t, a, "AccessPermission should be nil")
@@ -38,7 +39,7 @@ func TestGivenAnInvalidParams_WhenCreateNewAccessPermission_ThenShouldReturnAnEr
 
 func TestGivenAValidParams_WhenCreateNewAccessPermission_ThenShouldReturnAccessPermission(t *testing.T) {
 	grantedBy := uuid.New().String()
-	a, err := NewAccessPermission(databaseID, databaseUserID, grantedBy)
+	a, err := NewAccessPermission(databaseID, databaseUserID, grantedBy, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, a, "AccessPermission should not be nil")
 	assert.NotEmpty(t, a.ID, "AccessPermission id should not be empty")
@@ -46,6 +47,59 @@ func TestGivenAValidParams_WhenCreateNewAccessPermission_ThenShouldReturnAccessP
 	assert.Equal(t, a.DatabaseUserID, databaseUserID)
 	assert.Equal(t, a.GrantedByUserID, grantedBy)
 	assert.NotEmpty(t, a.GrantedAt)
+	assert.False(t, a.ExpiresAt.Valid, "AccessPermission should not expire")
+}
+
+func TestGivenAnExpiration_WhenCreateNewAccessPermission_ThenShouldReturnAccessPermissionThatExpires(t *testing.T) {
+	expiresAt := time.Now().Add(4 * time.Hour)
+	a, err := NewAccessPermission(databaseID, databaseUserID, grantedByUserID, &expiresAt)
+	assert.NoError(t, err)
+	assert.True(t, a.ExpiresAt.Valid)
+	assert.Equal(t, expiresAt, a.ExpiresAt.Time)
+}
+
+func TestGivenARole_WhenSetDatabaseRoleOfAccessPermission_ThenShouldKeepItOnlyWhenNotTheOwnRole(t *testing.T) {
+	a, err := NewAccessPermission(databaseID, databaseUserID, grantedByUserID, nil)
+	assert.NoError(t, err)
+	assert.False(t, a.DatabaseRoleID.Valid, "AccessPermission should follow the own role of the user by default")
+
+	ownRoleID, otherRoleID := uuid.NewString(), uuid.NewString()
+	a.SetDatabaseRole(otherRoleID, ownRoleID)
+	assert.True(t, a.DatabaseRoleID.Valid)
+	assert.Equal(t, otherRoleID, a.DatabaseRoleID.String)
+
+	a.SetDatabaseRole(ownRoleID, ownRoleID)
+	assert.False(t, a.DatabaseRoleID.Valid)
+
+	a.SetDatabaseRole("", ownRoleID)
+	assert.False(t, a.DatabaseRoleID.Valid)
+}
+
+func TestGivenSchemasAndTables_WhenCreateNewAccessScope_ThenShouldNormalizeThem(t *testing.T) {
+	s, err := NewAccessScope([]string{" reporting ", "reporting"}, []string{"public.orders", "customers", "public.orders"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reporting"}, s.Schemas)
+	assert.Equal(t, []string{"public.orders", "customers"}, s.Tables)
+	assert.False(t, s.IsEmpty())
+	assert.Equal(t, "schemas reporting; tables public.orders, customers", s.String())
+
+	s, err = NewAccessScope(nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, s.IsEmpty())
+}
+
+func TestGivenAnInvalidObject_WhenCreateNewAccessScope_ThenShouldReturnAnError(t *testing.T) {
+	_, err := NewAccessScope([]string{"public.orders"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidScopeObject)
+
+	_, err = NewAccessScope([]string{" "}, nil)
+	assert.ErrorIs(t, err, ErrInvalidScopeObject)
+
+	_, err = NewAccessScope(nil, []string{"db.public.orders"})
+	assert.ErrorIs(t, err, ErrInvalidScopeObject)
+
+	_, err = NewAccessScope(nil, []string{"public."})
+	assert.ErrorIs(t, err, ErrInvalidScopeObject)
 }
 
 func assertValidate(t *testing.T, entity Validator, expectedError error) {
@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccessRequestStatus string
+
+const (
+	AccessRequestStatusRequested AccessRequestStatus = "REQUESTED"
+	AccessRequestStatusApproved  AccessRequestStatus = "APPROVED"
+	AccessRequestStatusRejected  AccessRequestStatus = "REJECTED"
+	AccessRequestStatusExecuted  AccessRequestStatus = "EXECUTED"
+	AccessRequestStatusFailed    AccessRequestStatus = "FAILED"
+	AccessRequestStatusExpired   AccessRequestStatus = "EXPIRED"
+)
+
+var (
+	ErrInvalidAccessRequestInput        = errors.New("invalid access request input")
+	ErrInvalidJustification             = errors.New("invalid justification")
+	ErrInvalidAccessRequestExpiration   = errors.New("access request expiration must be after its creation")
+	ErrAccessRequestNotPending          = errors.New("access request is not pending review")
+	ErrAccessRequestNotApproved         = errors.New("access request is not approved")
+	ErrAccessRequestExpired             = errors.New("access request has expired")
+	ErrAccessRequestReviewedByRequester = errors.New("access request must be reviewed by a user other than the requester")
+)
+
+// AccessRequest godoc
+// Request of access to be granted once approved by an application user other than the requester. Input and Result hold
+// JSON documents: the GrantAccessInputDTO requested and the GrantAccessOutputDTO of its execution.
+// The lifecycle is REQUESTED, then APPROVED or REJECTED by the reviewer or EXPIRED when not reviewed in time. An
+// approved request is executed right away, ending as EXECUTED or FAILED when the grant could not run.
+type AccessRequest struct {
+	ID                uuid.UUID
+	Status            AccessRequestStatus
+	Input             string
+	Justification     string
+	RequestedByUserID string
+	ReviewedByUserID  sql.NullString
+	ReviewComment     string
+	Result            sql.NullString
+	Error             string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	ExpiresAt         time.Time
+	ReviewedAt        sql.NullTime
+	ExecutedAt        sql.NullTime
+}
+
+func NewAccessRequest(input, justification, requestedByUserID string, expiresAt time.Time) (*AccessRequest, error) {
+	currentTime := time.Now()
+	r := &AccessRequest{
+		ID:                uuid.New(),
+		Status:            AccessRequestStatusRequested,
+		Input:             input,
+		Justification:     justification,
+		RequestedByUserID: requestedByUserID,
+		CreatedAt:         currentTime,
+		UpdatedAt:         currentTime,
+		ExpiresAt:         expiresAt,
+	}
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *AccessRequest) Approve(reviewedByUserID, comment string) error {
+	return r.review(AccessRequestStatusApproved, reviewedByUserID, comment)
+}
+
+func (r *AccessRequest) Reject(reviewedByUserID, comment string) error {
+	return r.review(AccessRequestStatusRejected, reviewedByUserID, comment)
+}
+
+// review godoc
+// A pending request found expired is marked as expired, to be persisted by the caller along with the error
+func (r *AccessRequest) review(status AccessRequestStatus, reviewedByUserID, comment string) error {
+	if r.Status != AccessRequestStatusRequested {
+		return ErrAccessRequestNotPending
+	}
+	if reviewedByUserID == r.RequestedByUserID {
+		return ErrAccessRequestReviewedByRequester
+	}
+	currentTime := time.Now()
+	if !currentTime.Before(r.ExpiresAt) {
+		r.Expire()
+		return ErrAccessRequestExpired
+	}
+	r.Status = status
+	r.ReviewedByUserID = sql.NullString{String: reviewedByUserID, Valid: true}
+	r.ReviewComment = comment
+	r.ReviewedAt = sql.NullTime{Time: currentTime, Valid: true}
+	r.UpdatedAt = currentTime
+	return nil
+}
+
+func (r *AccessRequest) Expire() {
+	r.Status = AccessRequestStatusExpired
+	r.UpdatedAt = time.Now()
+}
+
+func (r *AccessRequest) Execute(result string) error {
+	if r.Status != AccessRequestStatusApproved {
+		return ErrAccessRequestNotApproved
+	}
+	r.finishExecution(AccessRequestStatusExecuted)
+	r.Result = sql.NullString{String: result, Valid: true}
+	return nil
+}
+
+func (r *AccessRequest) Fail(cause error) error {
+	if r.Status != AccessRequestStatusApproved {
+		return ErrAccessRequestNotApproved
+	}
+	r.finishExecution(AccessRequestStatusFailed)
+	r.Error = cause.Error()
+	return nil
+}
+
+func (r *AccessRequest) finishExecution(status AccessRequestStatus) {
+	currentTime := time.Now()
+	r.Status = status
+	r.ExecutedAt = sql.NullTime{Time: currentTime, Valid: true}
+	r.UpdatedAt = currentTime
+}
+
+func (r *AccessRequest) Validate() error {
+	if r.Input == "" {
+		return ErrInvalidAccessRequestInput
+	}
+	if r.Justification == "" {
+		return ErrInvalidJustification
+	}
+	if r.RequestedByUserID == "" {
+		return ErrCreatedByUserNotInformed
+	}
+	if !r.ExpiresAt.After(r.CreatedAt) {
+		return ErrInvalidAccessRequestExpiration
+	}
+	return nil
+}
+
+func ValidateAccessRequestStatus(status string) bool {
+	switch AccessRequestStatus(status) {
+	case AccessRequestStatusRequested, AccessRequestStatusApproved, AccessRequestStatusRejected,
+		AccessRequestStatusExecuted, AccessRequestStatusFailed, AccessRequestStatusExpired:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	accessRequestInput = `{"databaseUsersIds":[],"instancesData":[]}`
+	justification      = "Incident INC-42 investigation"
+)
+
+func TestGivenAnEmptyRequiredParam_WhenValidateAccessRequest_ThenShouldReceiveAnError(t *testing.T) {
+	r := &AccessRequest{}
+	assertValidate(t, r, ErrInvalidAccessRequestInput)
+
+	r = &AccessRequest{Input: accessRequestInput}
+	assertValidate(t, r, ErrInvalidJustification)
+
+	r = &AccessRequest{Input: accessRequestInput, Justification: justification}
+	assertValidate(t, r, ErrCreatedByUserNotInformed)
+
+	now := time.Now()
+	r = &AccessRequest{Input: accessRequestInput, Justification: justification, RequestedByUserID: userID, CreatedAt: now, ExpiresAt: now}
+	assertValidate(t, r, ErrInvalidAccessRequestExpiration)
+}
+
+func TestGivenAValidParams_WhenCreateNewAccessRequest_ThenShouldReturnARequestedAccessRequest(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	r, err := NewAccessRequest(accessRequestInput, justification, userID, expiresAt)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, r.ID)
+	assert.Equal(t, AccessRequestStatusRequested, r.Status)
+	assert.Equal(t, accessRequestInput, r.Input)
+	assert.Equal(t, justification, r.Justification)
+	assert.Equal(t, userID, r.RequestedByUserID)
+	assert.Equal(t, expiresAt, r.ExpiresAt)
+	assert.False(t, r.ReviewedByUserID.Valid)
+	assert.False(t, r.ReviewedAt.Valid)
+}
+
+func TestGivenARequestedAccess_WhenApprovedByAnotherUserAndExecuted_ThenShouldKeepTheTrail(t *testing.T) {
+	reviewerID := uuid.New().String()
+	r, _ := NewAccessRequest(accessRequestInput, justification, userID, time.Now().Add(time.Hour))
+
+	assert.NoError(t, r.Approve(reviewerID, "ok"))
+	assert.Equal(t, AccessRequestStatusApproved, r.Status)
+	assert.Equal(t, reviewerID, r.ReviewedByUserID.String)
+	assert.Equal(t, "ok", r.ReviewComment)
+	assert.True(t, r.ReviewedAt.Valid)
+
+	assert.NoError(t, r.Execute(`{"hasErrors":false}`))
+	assert.Equal(t, AccessRequestStatusExecuted, r.Status)
+	assert.Equal(t, `{"hasErrors":false}`, r.Result.String)
+	assert.True(t, r.ExecutedAt.Valid)
+	assert.ErrorIs(t, r.Reject(reviewerID, ""), ErrAccessRequestNotPending)
+}
+
+func TestGivenAnApprovedAccess_WhenTheExecutionFails_ThenShouldKeepTheError(t *testing.T) {
+	r, _ := NewAccessRequest(accessRequestInput, justification, userID, time.Now().Add(time.Hour))
+	assert.ErrorIs(t, r.Fail(errors.New("connection refused")), ErrAccessRequestNotApproved)
+
+	_ = r.Approve(uuid.New().String(), "")
+	assert.NoError(t, r.Fail(errors.New("connection refused")))
+
+	assert.Equal(t, AccessRequestStatusFailed, r.Status)
+	assert.Equal(t, "connection refused", r.Error)
+	assert.True(t, r.ExecutedAt.Valid)
+}
+
+func TestGivenARequestedAccess_WhenReviewedByTheRequester_ThenShouldReceiveAnError(t *testing.T) {
+	r, _ := NewAccessRequest(accessRequestInput, justification, userID, time.Now().Add(time.Hour))
+
+	assert.ErrorIs(t, r.Approve(userID, ""), ErrAccessRequestReviewedByRequester)
+	assert.ErrorIs(t, r.Reject(userID, ""), ErrAccessRequestReviewedByRequester)
+	assert.Equal(t, AccessRequestStatusRequested, r.Status)
+}
+
+func TestGivenAnAccessRequestNotReviewedInTime_WhenReview_ThenShouldExpire(t *testing.T) {
+	r, _ := NewAccessRequest(accessRequestInput, justification, userID, time.Now().Add(time.Hour))
+	r.ExpiresAt = time.Now().Add(-time.Minute)
+
+	assert.ErrorIs(t, r.Approve(uuid.New().String(), ""), ErrAccessRequestExpired)
+	assert.Equal(t, AccessRequestStatusExpired, r.Status)
+	assert.False(t, r.ReviewedAt.Valid)
+}
+
+func TestGivenAStatus_WhenValidateAccessRequestStatus_ThenShouldAcceptOnlyTheKnownOnes(t *testing.T) {
+	assert.True(t, ValidateAccessRequestStatus("REQUESTED"))
+	assert.True(t, ValidateAccessRequestStatus("EXPIRED"))
+	assert.False(t, ValidateAccessRequestStatus("PENDING"))
+}
@@ -13,6 +13,11 @@ var (
 	ErrInvalidEmail = errors.New("invalid e-mail")
 )
 
+// SystemUserEmail godoc
+// E-mail of the application user created by the migrations, that is the operator of the operations started by the
+// application itself, e.g. the revocation of expired access permissions
+const SystemUserEmail = "zg-service@email.com"
+
 type ApplicationUser struct {
 	ID         uuid.UUID
 	Name       string
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BreakGlassRole is the role granted by the emergency access, on top of the role of the user
+const BreakGlassRole = DevOps
+
+var (
+	ErrInvalidIncidentReference    = errors.New("invalid incident reference")
+	ErrInvalidBreakGlassExpiration = errors.New("break-glass access expiration must be after its grant")
+)
+
+// BreakGlassAccess godoc
+// Emergency access of a user to a database, granted without approval during an incident for a fixed window.
+// RoleGranted tells if the BreakGlassRole was granted to the user in the database, which is not the case when the user
+// already has it, so only a granted role is revoked when the window closes.
+type BreakGlassAccess struct {
+	ID                uuid.UUID
+	DatabaseID        string
+	DatabaseUserID    string
+	IncidentReference string
+	RoleGranted       bool
+	GrantedByUserID   string
+	GrantedAt         time.Time
+	ExpiresAt         time.Time
+	RevokedAt         sql.NullTime
+}
+
+func NewBreakGlassAccess(databaseID, databaseUserID, incidentReference string, roleGranted bool, grantedByUserID string, expiresAt time.Time) (*BreakGlassAccess, error) {
+	b := &BreakGlassAccess{
+		ID:                uuid.New(),
+		DatabaseID:        databaseID,
+		DatabaseUserID:    databaseUserID,
+		IncidentReference: strings.TrimSpace(incidentReference),
+		RoleGranted:       roleGranted,
+		GrantedByUserID:   grantedByUserID,
+		GrantedAt:         time.Now(),
+		ExpiresAt:         expiresAt,
+	}
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *BreakGlassAccess) Validate() error {
+	if b.DatabaseID == "" {
+		return ErrDatabaseIDNotInformed
+	}
+	if b.DatabaseUserID == "" {
+		return ErrDatabaseUserIDNotInformed
+	}
+	if b.IncidentReference == "" {
+		return ErrInvalidIncidentReference
+	}
+	if b.GrantedByUserID == "" {
+		return ErrGrantedByUserIDNotInformed
+	}
+	if !b.ExpiresAt.After(b.GrantedAt) {
+		return ErrInvalidBreakGlassExpiration
+	}
+	return nil
+}
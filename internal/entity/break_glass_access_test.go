@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const incidentReference = "INC-1234"
+
+func TestGivenAnEmptyRequiredParam_WhenValidateBreakGlassAccess_ThenShouldReceiveAnError(t *testing.T) {
+	b := &BreakGlassAccess{}
+	assertValidate(t, b, ErrDatabaseIDNotInformed)
+
+	b = &BreakGlassAccess{DatabaseID: databaseID}
+	assertValidate(t, b, ErrDatabaseUserIDNotInformed)
+
+	b = &BreakGlassAccess{DatabaseID: databaseID, DatabaseUserID: userID}
+	assertValidate(t, b, ErrInvalidIncidentReference)
+
+	b = &BreakGlassAccess{DatabaseID: databaseID, DatabaseUserID: userID, IncidentReference: incidentReference}
+	assertValidate(t, b, ErrGrantedByUserIDNotInformed)
+
+	now := time.Now()
+	b = &BreakGlassAccess{DatabaseID: databaseID, DatabaseUserID: userID, IncidentReference: incidentReference, GrantedByUserID: userID, GrantedAt: now, ExpiresAt: now}
+	assertValidate(t, b, ErrInvalidBreakGlassExpiration)
+}
+
+func TestGivenABlankIncidentReference_WhenCreateNewBreakGlassAccess_ThenShouldReturnAnError(t *testing.T) {
+	b, err := NewBreakGlassAccess(databaseID, userID, "   ", true, userID, time.Now().Add(time.Hour))
+	assert.EqualError(t, err, ErrInvalidIncidentReference.Error())
+	assert.Nil(t, b)
+}
+
+func TestGivenAValidParams_WhenCreateNewBreakGlassAccess_ThenShouldReturnANotRevokedAccess(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	b, err := NewBreakGlassAccess(databaseID, userID, " "+incidentReference+" ", true, userID, expiresAt)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, b.ID)
+	assert.Equal(t, databaseID, b.DatabaseID)
+	assert.Equal(t, userID, b.DatabaseUserID)
+	assert.Equal(t, incidentReference, b.IncidentReference)
+	assert.True(t, b.RoleGranted)
+	assert.Equal(t, userID, b.GrantedByUserID)
+	assert.Equal(t, expiresAt, b.ExpiresAt)
+	assert.False(t, b.RevokedAt.Valid)
+}
@@ -11,17 +11,21 @@ import (
 var ErrDatabaseInstanceIDNotInformed = errors.New("database instance id not informed")
 
 type Database struct {
-	ID                 uuid.UUID
-	Name               string
-	Description        string
-	CurrentSize        string
-	Enabled            bool
-	RolesConfigured    bool
-	DatabaseInstanceID string
-	CreatedAt          time.Time
-	CreatedByUserID    string
-	UpdatedAt          time.Time
-	DisabledAt         sql.NullTime
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	CurrentSize     string
+	Enabled         bool
+	RolesConfigured bool
+	// RolesTemplateVersion and RolesTemplateChecksum identify the grants applied to the roles of the database
+	RolesTemplateVersion  int
+	RolesTemplateChecksum string
+	RolesConfiguredAt     sql.NullTime
+	DatabaseInstanceID    string
+	CreatedAt             time.Time
+	CreatedByUserID       string
+	UpdatedAt             time.Time
+	DisabledAt            sql.NullTime
 }
 
 func NewDatabase(name, description, databaseInstanceID, currentSize, createdByUserID string) (*Database, error) {
@@ -76,7 +80,17 @@ func (d *Database) Update(currentSize string) {
 	d.UpdatedAt = time.Now()
 }
 
-func (d *Database) ConfigureRoles() {
+func (d *Database) ConfigureRoles(templateVersion int, templateChecksum string) {
+	currentTime := time.Now()
 	d.RolesConfigured = true
-	d.UpdatedAt = time.Now()
+	d.RolesTemplateVersion = templateVersion
+	d.RolesTemplateChecksum = templateChecksum
+	d.RolesConfiguredAt = sql.NullTime{Time: currentTime, Valid: true}
+	d.UpdatedAt = currentTime
+}
+
+// IsRolesTemplateOutdated godoc
+// Checks if the roles of the database weren't set up yet or were set up with grants other than the current ones
+func (d *Database) IsRolesTemplateOutdated(templateChecksum string) bool {
+	return !d.RolesConfigured || d.RolesTemplateChecksum != templateChecksum
 }
@@ -1,11 +1,14 @@
 package entity
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
@@ -13,6 +16,8 @@ import (
 
 type ConnectionStatus string
 
+type SSLMode string
+
 const (
 	StatusOnline          ConnectionStatus = "ONLINE"
 	StatusOffline         ConnectionStatus = "OFFLINE"
@@ -21,6 +26,13 @@ const (
 	connectionEstablished                  = "connection established successfully!"
 )
 
+const (
+	SSLModeDisable    SSLMode = "disable"
+	SSLModeRequire    SSLMode = "require"
+	SSLModeVerifyCA   SSLMode = "verify-ca"
+	SSLModeVerifyFull SSLMode = "verify-full"
+)
+
 var (
 	ErrInvalidHost               = errors.New("invalid host")
 	ErrInvalidPort               = errors.New("invalid port")
@@ -31,8 +43,17 @@ var (
 	ErrInvalidEcosystem          = errors.New("invalid ecosystem")
 	ErrInvalidDatabaseTechnology = errors.New("invalid database technology")
 	ErrInvalidConnectionStatus   = errors.New("invalid connection status")
+	ErrInvalidSSLMode            = errors.New("invalid ssl mode")
+	ErrInvalidCACertificate      = errors.New("invalid CA certificate, expected one or more PEM encoded certificates")
+	ErrInvalidClientCertificate  = errors.New("invalid client certificate, expected a PEM encoded certificate and its private key")
+	ErrInvalidBastionUser        = errors.New("invalid bastion user")
+	ErrInvalidBastionCredentials = errors.New("invalid bastion credentials, a private key or a password is required")
+	ErrInvalidBastionPrivateKey  = errors.New("invalid bastion private key, expected an unencrypted PEM encoded private key")
+	ErrInvalidBastionHostKey     = errors.New("invalid bastion host key, expected a public key in the authorized_keys format")
 )
 
+const defaultBastionPort = "22"
+
 type HostConnectionInfo struct {
 	ID             uuid.UUID
 	Host           string
@@ -41,6 +62,21 @@ type HostConnectionInfo struct {
 	PortConnection string
 	AdminUser      string
 	AdminPassword  string
+	// SSLMode follows the libpq sslmode semantics for every technology.
+	// CACertificate, ClientCertificate and ClientKey are PEM encoded and stored encrypted, like AdminPassword.
+	SSLMode           SSLMode
+	CACertificate     string
+	ClientCertificate string
+	ClientKey         string
+	// Optional SSH bastion used to reach HostConnection. BastionPrivateKey and BastionPassword are stored encrypted.
+	// BastionHostKey pins the public key of the bastion and is required, so the credentials are never sent to a host
+	// impersonating it.
+	BastionHost       string
+	BastionPort       string
+	BastionUser       string
+	BastionPrivateKey string
+	BastionPassword   string
+	BastionHostKey    string
 }
 
 type DatabaseInstance struct {
@@ -64,6 +100,10 @@ type DatabaseInstance struct {
 
 func NewDatabaseInstance(input dto.DatabaseInstanceInputDTO, createdByUserID string) (*DatabaseInstance, error) {
 	currentTime := time.Now()
+	sslMode := SSLMode(input.SSLMode)
+	if sslMode == "" {
+		sslMode = SSLModeDisable
+	}
 
 	e := &DatabaseInstance{
 		ID:   uuid.New(),
@@ -76,6 +116,7 @@ func NewDatabaseInstance(input dto.DatabaseInstanceInputDTO, createdByUserID str
 			PortConnection: input.PortConnection,
 			AdminUser:      input.AdminUser,
 			AdminPassword:  input.AdminPassword,
+			SSLMode:        sslMode,
 		},
 		EcosystemID:          input.EcosystemID,
 		DatabaseTechnologyID: input.DatabaseTechnologyID,
@@ -87,6 +128,12 @@ func NewDatabaseInstance(input dto.DatabaseInstanceInputDTO, createdByUserID str
 		CreatedByUserID:      createdByUserID,
 		UpdatedAt:            currentTime,
 	}
+	if err := e.HostConnection.setCertificates(input.CACertificate, input.ClientCertificate, input.ClientKey); err != nil {
+		return nil, err
+	}
+	if err := e.HostConnection.setBastion(input); err != nil {
+		return nil, err
+	}
 	err := e.Validate()
 	if err != nil {
 		return nil, err
@@ -121,6 +168,18 @@ func (dbi *DatabaseInstance) Validate() error {
 	if dbi.HostConnection.AdminPassword == "" {
 		return ErrInvalidAdminPassword
 	}
+	if dbi.HostConnection.SSLMode != "" && !ValidateSSLMode(string(dbi.HostConnection.SSLMode)) {
+		return ErrInvalidSSLMode
+	}
+	if dbi.HostConnection.BastionHost != "" && dbi.HostConnection.BastionUser == "" {
+		return ErrInvalidBastionUser
+	}
+	if dbi.HostConnection.BastionHost != "" && dbi.HostConnection.BastionPrivateKey == "" && dbi.HostConnection.BastionPassword == "" {
+		return ErrInvalidBastionCredentials
+	}
+	if dbi.HostConnection.BastionHost != "" && dbi.HostConnection.BastionHostKey == "" {
+		return ErrInvalidBastionHostKey
+	}
 	if dbi.EcosystemID == "" {
 		return ErrInvalidEcosystem
 	}
@@ -157,10 +216,103 @@ func (dbi *DatabaseInstance) Update(updatedData dto.DatabaseInstanceInputDTO) er
 		}
 		dbi.HostConnection.AdminPassword = cipherPasswordHex
 	}
+	if updatedData.SSLMode != "" {
+		dbi.HostConnection.SSLMode = SSLMode(updatedData.SSLMode)
+	}
+	if updatedData.ClearCertificates {
+		dbi.HostConnection.CACertificate, dbi.HostConnection.ClientCertificate, dbi.HostConnection.ClientKey = "", "", ""
+	}
+	if err := dbi.HostConnection.setCertificates(updatedData.CACertificate, updatedData.ClientCertificate, updatedData.ClientKey); err != nil {
+		return err
+	}
+	if err := dbi.HostConnection.setBastion(updatedData); err != nil {
+		return err
+	}
 
 	return dbi.Validate()
 }
 
+// setCertificates godoc
+// Validates and stores encrypted the PEM encoded certificates informed, keeping the current ones when empty, see
+// ClearCertificates to remove them. The client certificate and key must be informed together.
+func (hci *HostConnectionInfo) setCertificates(caCertificate, clientCertificate, clientKey string) error {
+	if caCertificate != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCertificate)) {
+		return ErrInvalidCACertificate
+	}
+	if clientCertificate != "" || clientKey != "" {
+		if _, err := tls.X509KeyPair([]byte(clientCertificate), []byte(clientKey)); err != nil {
+			return ErrInvalidClientCertificate
+		}
+	}
+	return encryptSecrets(
+		secretField{caCertificate, &hci.CACertificate},
+		secretField{clientCertificate, &hci.ClientCertificate},
+		secretField{clientKey, &hci.ClientKey},
+	)
+}
+
+// setBastion godoc
+// Sets the SSH bastion used to reach the instance. The private key and password are kept when not informed, unless the
+// bastion is removed by informing an empty host.
+func (hci *HostConnectionInfo) setBastion(input dto.DatabaseInstanceInputDTO) error {
+	if input.BastionHost == "" {
+		hci.BastionHost, hci.BastionPort, hci.BastionUser = "", "", ""
+		hci.BastionPrivateKey, hci.BastionPassword, hci.BastionHostKey = "", "", ""
+		return nil
+	}
+	if input.BastionPrivateKey != "" {
+		if _, err := ssh.ParsePrivateKey([]byte(input.BastionPrivateKey)); err != nil {
+			return ErrInvalidBastionPrivateKey
+		}
+	}
+	if input.BastionHostKey != "" {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(input.BastionHostKey)); err != nil {
+			return ErrInvalidBastionHostKey
+		}
+	}
+	hci.BastionHost = input.BastionHost
+	hci.BastionPort = input.BastionPort
+	if hci.BastionPort == "" {
+		hci.BastionPort = defaultBastionPort
+	}
+	hci.BastionUser = input.BastionUser
+	hci.BastionHostKey = input.BastionHostKey
+	return encryptSecrets(
+		secretField{input.BastionPrivateKey, &hci.BastionPrivateKey},
+		secretField{input.BastionPassword, &hci.BastionPassword},
+	)
+}
+
+type secretField struct {
+	plainText string
+	target    *string
+}
+
+// encryptSecrets godoc
+// Stores encrypted each informed secret into its target, keeping the current value of the empty ones
+func encryptSecrets(fields ...secretField) error {
+	cryptoHelper := config.GetCryptoHelper()
+	for _, field := range fields {
+		if field.plainText == "" {
+			continue
+		}
+		cipherHex, err := cryptoHelper.Encrypt(field.plainText)
+		if err != nil {
+			return err
+		}
+		*field.target = cipherHex
+	}
+	return nil
+}
+
+func ValidateSSLMode(mode string) bool {
+	switch SSLMode(mode) {
+	case SSLModeDisable, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull:
+		return true
+	}
+	return false
+}
+
 func (dbi *DatabaseInstance) Enable() {
 	dbi.Enabled = true
 	dbi.UpdatedAt = time.Now()
@@ -1,11 +1,21 @@
 package entity
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 )
 
@@ -219,3 +229,165 @@ func buildDatabaseInstance(name, host, port, hostConn, portConn, admUser, admPwd
 		ConnectionStatus:     status,
 	}
 }
+
+func TestGivenAnInvalidSSLMode_WhenCreateNewDatabaseInstance_ThenShouldReturnAnError(t *testing.T) {
+	input := validInput
+	input.SSLMode = "prefer"
+
+	dbInstance, err := NewDatabaseInstance(input, userID)
+
+	assert.ErrorIs(t, err, ErrInvalidSSLMode)
+	assert.Nil(t, dbInstance)
+}
+
+func TestGivenNoSSLMode_WhenCreateNewDatabaseInstance_ThenShouldDisableSSL(t *testing.T) {
+	dbInstance, err := NewDatabaseInstance(validInput, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, SSLModeDisable, dbInstance.HostConnection.SSLMode)
+	assert.Empty(t, dbInstance.HostConnection.CACertificate)
+	assert.Empty(t, dbInstance.HostConnection.ClientCertificate)
+	assert.Empty(t, dbInstance.HostConnection.ClientKey)
+}
+
+func TestGivenInvalidCertificates_WhenCreateNewDatabaseInstance_ThenShouldReturnAnError(t *testing.T) {
+	input := validInput
+	input.SSLMode = string(SSLModeVerifyFull)
+	input.CACertificate = "not a certificate"
+	_, err := NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidCACertificate)
+
+	input.CACertificate = ""
+	input.ClientCertificate = "not a certificate"
+	input.ClientKey = "not a key"
+	_, err = NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidClientCertificate)
+}
+
+func TestGivenCertificates_WhenCreateAndUpdateDatabaseInstance_ThenShouldStoreThemEncrypted(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+	input := validInput
+	input.SSLMode = string(SSLModeVerifyCA)
+	input.CACertificate = certPEM
+	input.ClientCertificate = certPEM
+	input.ClientKey = keyPEM
+
+	dbInstance, err := NewDatabaseInstance(input, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, SSLModeVerifyCA, dbInstance.HostConnection.SSLMode)
+	for _, stored := range []string{dbInstance.HostConnection.CACertificate, dbInstance.HostConnection.ClientCertificate, dbInstance.HostConnection.ClientKey} {
+		assert.NotEmpty(t, stored)
+		assert.NotContains(t, stored, "BEGIN")
+	}
+	plainTextKey, err := config.GetCryptoHelper().Decrypt(dbInstance.HostConnection.ClientKey)
+	assert.NoError(t, err)
+	assert.Equal(t, keyPEM, plainTextKey)
+
+	storedCA := dbInstance.HostConnection.CACertificate
+	updatedInput := validInput
+	updatedInput.SSLMode = string(SSLModeVerifyFull)
+	assert.NoError(t, dbInstance.Update(updatedInput))
+	assert.Equal(t, SSLModeVerifyFull, dbInstance.HostConnection.SSLMode)
+	assert.Equal(t, storedCA, dbInstance.HostConnection.CACertificate, "certificates not informed should be kept")
+
+	updatedInput.SSLMode = "allow"
+	assert.ErrorIs(t, dbInstance.Update(updatedInput), ErrInvalidSSLMode)
+}
+
+func TestGivenClearCertificates_WhenUpdateDatabaseInstance_ThenShouldRemoveTheCurrentCertificates(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+	input := validInput
+	input.SSLMode = string(SSLModeVerifyCA)
+	input.CACertificate = certPEM
+	input.ClientCertificate = certPEM
+	input.ClientKey = keyPEM
+	dbInstance, err := NewDatabaseInstance(input, userID)
+	assert.NoError(t, err)
+
+	updatedInput := validInput
+	updatedInput.SSLMode = string(SSLModeRequire)
+	updatedInput.ClearCertificates = true
+	assert.NoError(t, dbInstance.Update(updatedInput))
+
+	assert.Empty(t, dbInstance.HostConnection.CACertificate)
+	assert.Empty(t, dbInstance.HostConnection.ClientCertificate)
+	assert.Empty(t, dbInstance.HostConnection.ClientKey)
+
+	updatedInput.CACertificate = certPEM
+	assert.NoError(t, dbInstance.Update(updatedInput))
+
+	assert.NotEmpty(t, dbInstance.HostConnection.CACertificate, "the informed certificates should replace the cleared ones")
+	assert.Empty(t, dbInstance.HostConnection.ClientKey)
+}
+
+func generateHostKey(t *testing.T) string {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	assert.NoError(t, err)
+	return string(ssh.MarshalAuthorizedKey(sshPublicKey))
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "zg-data-guard"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestGivenABastion_WhenCreateNewDatabaseInstance_ThenShouldValidateAndEncryptItsCredentials(t *testing.T) {
+	input := validInput
+	input.BastionHost = "bastion.example.com"
+	_, err := NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidBastionUser)
+
+	input.BastionUser = "jump"
+	_, err = NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidBastionCredentials)
+
+	input.BastionPrivateKey = "not a key"
+	_, err = NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidBastionPrivateKey)
+
+	input.BastionPrivateKey = ""
+	input.BastionPassword = "jump-pwd"
+	input.BastionHostKey = "not a key"
+	_, err = NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidBastionHostKey)
+
+	input.BastionHostKey = ""
+	_, err = NewDatabaseInstance(input, userID)
+	assert.ErrorIs(t, err, ErrInvalidBastionHostKey, "the host key of the bastion should be required")
+
+	input.BastionHostKey = generateHostKey(t)
+	dbInstance, err := NewDatabaseInstance(input, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "bastion.example.com", dbInstance.HostConnection.BastionHost)
+	assert.Equal(t, "22", dbInstance.HostConnection.BastionPort)
+	assert.Equal(t, "jump", dbInstance.HostConnection.BastionUser)
+	assert.Equal(t, input.BastionHostKey, dbInstance.HostConnection.BastionHostKey)
+	assert.NotEmpty(t, dbInstance.HostConnection.BastionPassword)
+	assert.NotEqual(t, "jump-pwd", dbInstance.HostConnection.BastionPassword)
+
+	updatedInput := input
+	updatedInput.BastionPassword = ""
+	assert.NoError(t, dbInstance.Update(updatedInput))
+	plainTextPassword, err := config.GetCryptoHelper().Decrypt(dbInstance.HostConnection.BastionPassword)
+	assert.NoError(t, err)
+	assert.Equal(t, "jump-pwd", plainTextPassword, "bastion password should be kept when not informed")
+
+	updatedInput.BastionHost = ""
+	assert.NoError(t, dbInstance.Update(updatedInput))
+	assert.Empty(t, dbInstance.HostConnection.BastionUser)
+	assert.Empty(t, dbInstance.HostConnection.BastionPassword, "removing the bastion should remove its credentials")
+}
@@ -1,6 +1,12 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,8 +14,8 @@ import (
 
 type RoleName string
 
-// Any new role should be added here and the corresponding role should be added to the grant script below
-// internal/database/connector/scripts/postgres/setup_grants_roles_database.sql
+// Predefined roles, created by the migrations. The application role is the only one allowed in forbidden databases and
+// can't be given per database. Any other role is managed through the API, see DatabaseRole.
 const (
 	UserRO      RoleName = "user_ro"
 	Developer   RoleName = "developer"
@@ -17,16 +23,100 @@ const (
 	Application RoleName = "application"
 )
 
+const roleNameMaxSize = 30
+
+var (
+	ErrInvalidRoleName       = fmt.Errorf("invalid role name, it must start with a letter and have only lowercase letters, digits and underscores, up to %d characters", roleNameMaxSize)
+	ErrInvalidRolePrivilege  = errors.New("invalid role privilege")
+	ErrRoleWithoutPrivileges = errors.New("the role must have at least one privilege on tables")
+
+	roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	// allowedPrivileges godoc
+	// Privileges accepted by object type, in the vocabulary of PostgreSQL
+	allowedPrivileges = map[string][]string{
+		"database": {"CREATE", "TEMP"},
+		"schema":   {"USAGE", "CREATE"},
+		"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+		"sequence": {"USAGE", "SELECT", "UPDATE"},
+		"function": {"EXECUTE"},
+		"type":     {"USAGE"},
+	}
+	readPrivileges = []string{"USAGE", "SELECT", "EXECUTE"}
+)
+
+// RolePrivileges godoc
+// Privileges of a role on the objects of each database, in the vocabulary of PostgreSQL (e.g. SELECT on tables, USAGE
+// on schemas). The connectors of the other technologies translate them to their own privileges.
+// Default privileges apply to the objects created later in the schemas.
+type RolePrivileges struct {
+	Database []string          `json:"database,omitempty"`
+	Schema   []string          `json:"schema,omitempty"`
+	Table    []string          `json:"table,omitempty"`
+	Sequence []string          `json:"sequence,omitempty"`
+	Function []string          `json:"function,omitempty"`
+	Type     []string          `json:"type,omitempty"`
+	Default  DefaultPrivileges `json:"default"`
+}
+
+type DefaultPrivileges struct {
+	Table    []string `json:"table,omitempty"`
+	Sequence []string `json:"sequence,omitempty"`
+	Function []string `json:"function,omitempty"`
+	Type     []string `json:"type,omitempty"`
+}
+
 type DatabaseRole struct {
 	ID              uuid.UUID
 	Name            RoleName
 	DisplayName     string
 	Description     string
 	ReadOnly        bool
+	Privileges      RolePrivileges
 	CreatedAt       time.Time
+	UpdatedAt       time.Time
 	CreatedByUserID string
 }
 
+func NewDatabaseRole(name, displayName, description string, privileges RolePrivileges, createdByUserID string) (*DatabaseRole, error) {
+	currentTime := time.Now()
+	r := &DatabaseRole{
+		ID:              uuid.New(),
+		Name:            RoleName(name),
+		CreatedAt:       currentTime,
+		CreatedByUserID: createdByUserID,
+	}
+	r.Update(displayName, description, privileges)
+	r.UpdatedAt = currentTime
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update godoc
+// The name can't be changed, since it identifies the role in the database instances
+func (d *DatabaseRole) Update(displayName, description string, privileges RolePrivileges) {
+	d.DisplayName = displayName
+	d.Description = description
+	d.Privileges = privileges.normalize()
+	d.ReadOnly = d.Privileges.IsReadOnly()
+	d.UpdatedAt = time.Now()
+}
+
+func (d *DatabaseRole) Validate() error {
+	if len(d.Name) > roleNameMaxSize || !roleNamePattern.MatchString(string(d.Name)) {
+		return ErrInvalidRoleName
+	}
+	if d.DisplayName == "" {
+		return ErrInvalidDisplayName
+	}
+	if d.CreatedByUserID == "" {
+		return ErrCreatedByUserNotInformed
+	}
+	return d.Privileges.Validate()
+}
+
 func (d *DatabaseRole) IsUserRO() bool {
 	return d.Name == UserRO
 }
@@ -43,13 +133,152 @@ func (d *DatabaseRole) IsApplication() bool {
 	return d.Name == Application
 }
 
-func ValidateRoleName(currentRole string) bool {
-	switch RoleName(currentRole) {
-	case UserRO, Developer, DevOps, Application:
-		return true
-	default:
-		return false
+// IsCoveredBy godoc
+// Checks if the role is another one with no privilege beyond the ones of the other role. Given along with the other
+// role, whose membership applies to the whole instance in PostgreSQL, it wouldn't change anything.
+func (d *DatabaseRole) IsCoveredBy(other *DatabaseRole) bool {
+	return d.ID != other.ID && other.Privileges.Includes(d.Privileges)
+}
+
+// IsPredefined godoc
+// The predefined roles are created by the migrations and can't be deleted
+func (d *DatabaseRole) IsPredefined() bool {
+	return slices.Contains(predefinedRoleNames, d.Name)
+}
+
+func (p RolePrivileges) Validate() error {
+	privilegesByType := p.byObjectType()
+	for objectType, privileges := range privilegesByType {
+		for _, privilege := range privileges {
+			if !slices.Contains(allowedPrivileges[strings.TrimPrefix(objectType, "default ")], privilege) {
+				return fmt.Errorf("%w: %s on %s", ErrInvalidRolePrivilege, privilege, objectType)
+			}
+		}
+	}
+	if len(p.Table) == 0 {
+		return ErrRoleWithoutPrivileges
+	}
+	return nil
+}
+
+// IsReadOnly godoc
+// A role is read only when it can't change data nor structure: only USAGE, SELECT and EXECUTE
+func (p RolePrivileges) IsReadOnly() bool {
+	for _, privileges := range p.byObjectType() {
+		for _, privilege := range privileges {
+			if !slices.Contains(readPrivileges, privilege) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Includes godoc
+// Checks if every privilege of the other role is given by this one, on the same type of object
+func (p RolePrivileges) Includes(other RolePrivileges) bool {
+	privilegesByType := p.byObjectType()
+	for objectType, privileges := range other.byObjectType() {
+		for _, privilege := range privileges {
+			if !slices.Contains(privilegesByType[objectType], privilege) {
+				return false
+			}
+		}
 	}
+	return true
+}
+
+// AllowedPrivileges godoc
+// Returns the privileges accepted on the objects of the type (e.g. "table")
+func AllowedPrivileges(objectType string) []string {
+	return slices.Clone(allowedPrivileges[objectType])
+}
+
+// HasAny godoc
+// Checks if any of the privileges is given on the objects of the type (e.g. "table")
+func (p RolePrivileges) HasAny(objectType string, privileges ...string) bool {
+	for _, privilege := range p.byObjectType()[objectType] {
+		if slices.Contains(privileges, privilege) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p RolePrivileges) byObjectType() map[string][]string {
+	return map[string][]string{
+		"database":         p.Database,
+		"schema":           p.Schema,
+		"table":            p.Table,
+		"sequence":         p.Sequence,
+		"function":         p.Function,
+		"type":             p.Type,
+		"default table":    p.Default.Table,
+		"default sequence": p.Default.Sequence,
+		"default function": p.Default.Function,
+		"default type":     p.Default.Type,
+	}
+}
+
+func (p RolePrivileges) normalize() RolePrivileges {
+	return RolePrivileges{
+		Database: normalizePrivileges(p.Database),
+		Schema:   normalizePrivileges(p.Schema),
+		Table:    normalizePrivileges(p.Table),
+		Sequence: normalizePrivileges(p.Sequence),
+		Function: normalizePrivileges(p.Function),
+		Type:     normalizePrivileges(p.Type),
+		Default: DefaultPrivileges{
+			Table:    normalizePrivileges(p.Default.Table),
+			Sequence: normalizePrivileges(p.Default.Sequence),
+			Function: normalizePrivileges(p.Default.Function),
+			Type:     normalizePrivileges(p.Default.Type),
+		},
+	}
+}
+
+// normalizePrivileges godoc
+// Uppercases and removes the duplicated privileges, keeping their order. TEMPORARY is accepted as an alias of TEMP.
+func normalizePrivileges(privileges []string) []string {
+	normalized := make([]string, 0, len(privileges))
+	for _, privilege := range privileges {
+		privilege = strings.ToUpper(strings.TrimSpace(privilege))
+		if privilege == "TEMPORARY" {
+			privilege = "TEMP"
+		}
+		if !slices.Contains(normalized, privilege) {
+			normalized = append(normalized, privilege)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+var (
+	predefinedRoleNames = []RoleName{UserRO, Developer, DevOps, Application}
+	knownRoleNames      = slices.Clone(predefinedRoleNames)
+	knownRoleNamesMu    sync.RWMutex
+)
+
+// SetKnownRoleNames godoc
+// Replaces the names of the roles managed by Data Guard, loaded from the storage. The connectors rely on them to tell
+// the Data Guard roles apart from the other roles of the instances. Until loaded, only the predefined roles are known.
+func SetKnownRoleNames(names []RoleName) {
+	knownRoleNamesMu.Lock()
+	defer knownRoleNamesMu.Unlock()
+	knownRoleNames = slices.Clone(names)
+}
+
+func KnownRoleNames() []RoleName {
+	knownRoleNamesMu.RLock()
+	defer knownRoleNamesMu.RUnlock()
+	return slices.Clone(knownRoleNames)
+}
+
+func ValidateRoleName(currentRole string) bool {
+	return slices.Contains(KnownRoleNames(), RoleName(currentRole))
 }
 
 func CheckRoleApplication(currentRole string) bool {
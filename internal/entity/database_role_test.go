@@ -3,6 +3,7 @@ package entity
 import (
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,3 +43,65 @@ func TestCheckRole(t *testing.T) {
 	assert.False(t, CheckRole("invalid", UserRO))
 	assert.False(t, CheckRole(string(UserRO), Developer))
 }
+
+func TestGivenKnownRoleNamesLoaded_WhenValidateRoleName_ThenShouldAcceptTheManagedRoles(t *testing.T) {
+	defer SetKnownRoleNames(predefinedRoleNames)
+
+	SetKnownRoleNames([]RoleName{UserRO, "reporting"})
+
+	assert.True(t, ValidateRoleName("reporting"))
+	assert.True(t, ValidateRoleName("user_ro"))
+	assert.False(t, ValidateRoleName("developer"))
+}
+
+func TestNewDatabaseRole(t *testing.T) {
+	privileges := RolePrivileges{Schema: []string{"usage"}, Table: []string{"select", "SELECT"}, Database: []string{"temporary"}}
+
+	role, err := NewDatabaseRole("reporting", "Reporting", "", privileges, "user-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, RoleName("reporting"), role.Name)
+	assert.Equal(t, []string{"SELECT"}, role.Privileges.Table)
+	assert.Equal(t, []string{"TEMP"}, role.Privileges.Database)
+	assert.False(t, role.ReadOnly, "TEMP on the database allows to create objects")
+	assert.False(t, role.IsPredefined())
+}
+
+func TestNewDatabaseRoleWithInvalidData(t *testing.T) {
+	privileges := RolePrivileges{Table: []string{"SELECT"}}
+
+	_, err := NewDatabaseRole("Reporting", "Reporting", "", privileges, "user-id")
+	assert.ErrorIs(t, err, ErrInvalidRoleName)
+
+	_, err = NewDatabaseRole("a_role_name_longer_than_thirty_chars", "Reporting", "", privileges, "user-id")
+	assert.ErrorIs(t, err, ErrInvalidRoleName)
+
+	_, err = NewDatabaseRole("reporting", "", "", privileges, "user-id")
+	assert.ErrorIs(t, err, ErrInvalidDisplayName)
+
+	_, err = NewDatabaseRole("reporting", "Reporting", "", privileges, "")
+	assert.ErrorIs(t, err, ErrCreatedByUserNotInformed)
+
+	_, err = NewDatabaseRole("reporting", "Reporting", "", RolePrivileges{Schema: []string{"USAGE"}}, "user-id")
+	assert.ErrorIs(t, err, ErrRoleWithoutPrivileges)
+
+	_, err = NewDatabaseRole("reporting", "Reporting", "", RolePrivileges{Table: []string{"SELECT"}, Function: []string{"SELECT"}}, "user-id")
+	assert.ErrorIs(t, err, ErrInvalidRolePrivilege)
+}
+
+func TestRolePrivilegesIsReadOnly(t *testing.T) {
+	assert.True(t, RolePrivileges{Schema: []string{"USAGE"}, Table: []string{"SELECT"}, Function: []string{"EXECUTE"}}.IsReadOnly())
+	assert.False(t, RolePrivileges{Table: []string{"SELECT", "UPDATE"}}.IsReadOnly())
+	assert.False(t, RolePrivileges{Table: []string{"SELECT"}, Default: DefaultPrivileges{Table: []string{"DELETE"}}}.IsReadOnly())
+}
+
+func TestRoleIsCoveredBy(t *testing.T) {
+	developer := &DatabaseRole{ID: uuid.New(), Privileges: RolePrivileges{Schema: []string{"USAGE"}, Table: []string{"SELECT", "INSERT"}}}
+	readOnly := &DatabaseRole{ID: uuid.New(), Privileges: RolePrivileges{Schema: []string{"USAGE"}, Table: []string{"SELECT"}}}
+	temp := &DatabaseRole{ID: uuid.New(), Privileges: RolePrivileges{Database: []string{"TEMP"}, Table: []string{"SELECT"}}}
+
+	assert.True(t, readOnly.IsCoveredBy(developer))
+	assert.False(t, developer.IsCoveredBy(readOnly))
+	assert.False(t, temp.IsCoveredBy(developer))
+	assert.False(t, developer.IsCoveredBy(developer), "the role itself is not another role")
+}
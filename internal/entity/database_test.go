@@ -95,10 +95,23 @@ func TestGivenEnabledDatabase_WhenDisableDatabase_ThenShouldDisableDatabase(t *t
 func TestGivenValidParams_WhenConfigureRoles_ThenShouldConfigureRoles(t *testing.T) {
 	db, _ := NewDatabase(databaseName, dbDesc, dbDatabaseInstanceId, dbCurrentSize, userID)
 
-	db.ConfigureRoles()
+	db.ConfigureRoles(2, "checksum")
 
 	assert.True(t, db.RolesConfigured)
-	assert.NotEmpty(t, db.UpdatedAt)
+	assert.Equal(t, 2, db.RolesTemplateVersion)
+	assert.Equal(t, "checksum", db.RolesTemplateChecksum)
+	assert.True(t, db.RolesConfiguredAt.Valid)
+	assert.Equal(t, db.UpdatedAt, db.RolesConfiguredAt.Time)
+}
+
+func TestGivenDatabasesWithRolesConfiguredOrNot_WhenIsRolesTemplateOutdated_ThenShouldCompareTheChecksum(t *testing.T) {
+	db, _ := NewDatabase(databaseName, dbDesc, dbDatabaseInstanceId, dbCurrentSize, userID)
+	assert.True(t, db.IsRolesTemplateOutdated(""), "databases without roles configured are outdated")
+
+	db.ConfigureRoles(2, "checksum")
+
+	assert.False(t, db.IsRolesTemplateOutdated("checksum"))
+	assert.True(t, db.IsRolesTemplateOutdated("other-checksum"))
 }
 
 func buildDatabase(name, databaseInstanceId, userId string) *Database {
@@ -13,24 +13,29 @@ var (
 	ErrCreatedByUserNotInformed = errors.New("created by user not informed")
 )
 
+// Ecosystem godoc
+// When RequiresAccessApproval is set, access to the instances of the ecosystem can't be granted directly, only through
+// an access request approved by another application user
 type Ecosystem struct {
-	ID              uuid.UUID
-	Code            string
-	DisplayName     string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	CreatedByUserID string
+	ID                     uuid.UUID
+	Code                   string
+	DisplayName            string
+	RequiresAccessApproval bool
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	CreatedByUserID        string
 }
 
-func NewEcosystem(code, displayName string, createdByID string) (*Ecosystem, error) {
+func NewEcosystem(code, displayName string, requiresAccessApproval bool, createdByID string) (*Ecosystem, error) {
 	currentTime := time.Now()
 	e := &Ecosystem{
-		ID:              uuid.New(),
-		Code:            code,
-		DisplayName:     displayName,
-		CreatedAt:       currentTime,
-		UpdatedAt:       currentTime,
-		CreatedByUserID: createdByID,
+		ID:                     uuid.New(),
+		Code:                   code,
+		DisplayName:            displayName,
+		RequiresAccessApproval: requiresAccessApproval,
+		CreatedAt:              currentTime,
+		UpdatedAt:              currentTime,
+		CreatedByUserID:        createdByID,
 	}
 	err := e.Validate()
 	if err != nil {
@@ -39,9 +44,10 @@ func NewEcosystem(code, displayName string, createdByID string) (*Ecosystem, err
 	return e, nil
 }
 
-func (e *Ecosystem) Update(code string, displayName string) {
+func (e *Ecosystem) Update(code string, displayName string, requiresAccessApproval bool) {
 	e.Code = code
 	e.DisplayName = displayName
+	e.RequiresAccessApproval = requiresAccessApproval
 	e.UpdatedAt = time.Now()
 }
 
@@ -45,7 +45,7 @@ func TestGivenAValidParams_WhenValidateEcosystem_ThenShouldNotReceiveAnError(t *
 
 func TestGivenAnInvalidParams_WhenCreateNewEcosystem_ThenShouldReturnAnError(t *testing.T) {
 	createdBy := uuid.New().String()
-	e, err := NewEcosystem("", fooDisplayName, createdBy)
+	e, err := NewEcosystem("", fooDisplayName, false, createdBy)
 	assert.Error(t, err)
 	assert.EqualError(t, err, ErrInvalidCode.Error())
 	assert.Nil(t, e, "Ecosystem should be nil")
@@ -53,12 +53,13 @@ func TestGivenAnInvalidParams_WhenCreateNewEcosystem_ThenShouldReturnAnError(t *
 
 func TestGivenAValidParams_WhenCreateNewEcosystem_ThenShouldReturnAEcosystem(t *testing.T) {
 	createdBy := uuid.New().String()
-	e, err := NewEcosystem(fooCode, fooDisplayName, createdBy)
+	e, err := NewEcosystem(fooCode, fooDisplayName, true, createdBy)
 	assert.NoError(t, err)
 	assert.NotNil(t, e, "Ecosystem should not be nil")
 	assert.NotEmpty(t, e.ID, "Ecosystem id should not be empty")
 	assert.Equal(t, e.Code, fooCode)
 	assert.Equal(t, e.DisplayName, fooDisplayName)
+	assert.True(t, e.RequiresAccessApproval)
 	assert.Equal(t, e.CreatedByUserID, createdBy)
 	assert.NotEmpty(t, e.CreatedAt)
 	assert.NotEmpty(t, e.UpdatedAt)
@@ -66,15 +67,16 @@ func TestGivenAValidParams_WhenCreateNewEcosystem_ThenShouldReturnAEcosystem(t *
 }
 
 func TestGivenAnEcosystem_WhenUpdate_ThenShouldBeUpdated(t *testing.T) {
-	e, err := NewEcosystem(fooCode, fooDisplayName, uuid.New().String())
+	e, err := NewEcosystem(fooCode, fooDisplayName, false, uuid.New().String())
 	assert.NoError(t, err)
 
 	newCode := "bar foo"
 	newDisplayName := "Bar Foo"
-	e.Update(newCode, newDisplayName)
+	e.Update(newCode, newDisplayName, true)
 
 	assert.Equal(t, newCode, e.Code)
 	assert.Equal(t, newDisplayName, e.DisplayName)
+	assert.True(t, e.RequiresAccessApproval)
 	assert.NotEmpty(t, e.UpdatedAt)
 	assert.NotEqual(t, e.CreatedAt, e.UpdatedAt)
 }
@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "PENDING"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusFailed    JobStatus = "FAILED"
+)
+
+const (
+	JobTypeGrantAccess     = "GRANT_ACCESS"
+	JobTypeSyncDatabases   = "SYNC_DATABASES"
+	JobTypeSetupRoles      = "SETUP_ROLES"
+	JobTypePropagateRoles  = "PROPAGATE_ROLES"
+	JobTypeDiscoverRoles   = "DISCOVER_ROLES"
+	JobTypeReconcileAccess = "RECONCILE_ACCESS"
+)
+
+const emptyJobItems = "[]"
+
+var (
+	ErrInvalidJobType  = errors.New("invalid job type")
+	ErrInvalidJobInput = errors.New("invalid job input")
+)
+
+// Job godoc
+// Operation processed in background. Input, Items and Result hold JSON documents: the input DTO of the operation, the
+// result of each processed item and the output DTO of the operation.
+type Job struct {
+	ID              uuid.UUID
+	Type            string
+	Status          JobStatus
+	Input           string
+	TotalItems      int
+	ProcessedItems  int
+	FailedItems     int
+	Items           string
+	Result          sql.NullString
+	Error           string
+	Attempts        int
+	CreatedByUserID string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	StartedAt       sql.NullTime
+	FinishedAt      sql.NullTime
+}
+
+func NewJob(jobType, input, createdByUserID string) (*Job, error) {
+	currentTime := time.Now()
+	j := &Job{
+		ID:              uuid.New(),
+		Type:            jobType,
+		Status:          JobStatusPending,
+		Input:           input,
+		Items:           emptyJobItems,
+		CreatedByUserID: createdByUserID,
+		CreatedAt:       currentTime,
+		UpdatedAt:       currentTime,
+	}
+	err := j.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Start godoc
+// Marks the job as running from scratch, discarding the progress of a previous attempt interrupted by a shutdown
+func (j *Job) Start() {
+	currentTime := time.Now()
+	j.Status = JobStatusRunning
+	j.TotalItems = 0
+	j.ProcessedItems = 0
+	j.FailedItems = 0
+	j.Items = emptyJobItems
+	j.Result = sql.NullString{}
+	j.Error = ""
+	j.Attempts++
+	j.StartedAt = sql.NullTime{Time: currentTime, Valid: true}
+	j.FinishedAt = sql.NullTime{}
+	j.UpdatedAt = currentTime
+}
+
+func (j *Job) UpdateProgress(totalItems, processedItems, failedItems int, items string) {
+	j.TotalItems = totalItems
+	j.ProcessedItems = processedItems
+	j.FailedItems = failedItems
+	j.Items = items
+	j.UpdatedAt = time.Now()
+}
+
+func (j *Job) Succeed(result string) {
+	j.finish(JobStatusSucceeded)
+	j.Result = sql.NullString{String: result, Valid: true}
+}
+
+func (j *Job) Fail(cause error) {
+	j.finish(JobStatusFailed)
+	j.Error = cause.Error()
+}
+
+func (j *Job) finish(status JobStatus) {
+	currentTime := time.Now()
+	j.Status = status
+	j.FinishedAt = sql.NullTime{Time: currentTime, Valid: true}
+	j.UpdatedAt = currentTime
+}
+
+func (j *Job) Finished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
+
+func (j *Job) Validate() error {
+	if j.Type == "" {
+		return ErrInvalidJobType
+	}
+	if j.Input == "" {
+		return ErrInvalidJobInput
+	}
+	if j.CreatedByUserID == "" {
+		return ErrCreatedByUserNotInformed
+	}
+	return nil
+}
+
+func ValidateJobStatus(status string) bool {
+	switch JobStatus(status) {
+	case JobStatusPending, JobStatusRunning, JobStatusSucceeded, JobStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
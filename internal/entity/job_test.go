@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jobInput = `{"databaseInstancesIds":[]}`
+
+func TestGivenAnEmptyRequiredParam_WhenValidateJob_ThenShouldReceiveAnError(t *testing.T) {
+	j := &Job{}
+	assertValidate(t, j, ErrInvalidJobType)
+
+	j = &Job{Type: JobTypeSyncDatabases}
+	assertValidate(t, j, ErrInvalidJobInput)
+
+	j = &Job{Type: JobTypeSyncDatabases, Input: jobInput}
+	assertValidate(t, j, ErrCreatedByUserNotInformed)
+}
+
+func TestGivenAValidParams_WhenCreateNewJob_ThenShouldReturnAPendingJob(t *testing.T) {
+	j, err := NewJob(JobTypeSyncDatabases, jobInput, userID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, j.ID)
+	assert.Equal(t, JobStatusPending, j.Status)
+	assert.Equal(t, jobInput, j.Input)
+	assert.Equal(t, "[]", j.Items)
+	assert.Equal(t, userID, j.CreatedByUserID)
+	assert.False(t, j.StartedAt.Valid)
+	assert.False(t, j.Finished())
+}
+
+func TestGivenAnInterruptedJob_WhenStartAgain_ThenShouldDiscardThePreviousProgress(t *testing.T) {
+	j, _ := NewJob(JobTypeGrantAccess, jobInput, userID)
+	j.Start()
+	j.UpdateProgress(10, 4, 1, `[{"item":"instance # user"}]`)
+
+	j.Start()
+
+	assert.Equal(t, JobStatusRunning, j.Status)
+	assert.Equal(t, 2, j.Attempts)
+	assert.Equal(t, 0, j.TotalItems)
+	assert.Equal(t, 0, j.ProcessedItems)
+	assert.Equal(t, 0, j.FailedItems)
+	assert.Equal(t, "[]", j.Items)
+	assert.True(t, j.StartedAt.Valid)
+}
+
+func TestGivenARunningJob_WhenFinish_ThenShouldKeepTheResultOrTheError(t *testing.T) {
+	j, _ := NewJob(JobTypeSetupRoles, jobInput, userID)
+	j.Start()
+	j.Succeed(`[]`)
+	assert.Equal(t, JobStatusSucceeded, j.Status)
+	assert.Equal(t, `[]`, j.Result.String)
+	assert.True(t, j.FinishedAt.Valid)
+	assert.True(t, j.Finished())
+
+	j.Start()
+	j.Fail(errors.New("no databases found"))
+	assert.Equal(t, JobStatusFailed, j.Status)
+	assert.Equal(t, "no databases found", j.Error)
+	assert.False(t, j.Result.Valid)
+	assert.True(t, j.Finished())
+}
+
+func TestGivenAStatus_WhenValidateJobStatus_ThenShouldAcceptOnlyKnownStatuses(t *testing.T) {
+	assert.True(t, ValidateJobStatus(string(JobStatusRunning)))
+	assert.False(t, ValidateJobStatus("DONE"))
+}
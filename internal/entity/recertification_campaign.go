@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecertificationCampaignStatus string
+
+const (
+	RecertificationCampaignStatusOpen   RecertificationCampaignStatus = "OPEN"
+	RecertificationCampaignStatusClosed RecertificationCampaignStatus = "CLOSED"
+)
+
+type RecertificationDecision string
+
+const (
+	RecertificationDecisionPending RecertificationDecision = "PENDING"
+	RecertificationDecisionKeep    RecertificationDecision = "KEEP"
+	RecertificationDecisionRevoke  RecertificationDecision = "REVOKE"
+)
+
+var (
+	ErrRecertificationCampaignClosed      = errors.New("recertification campaign is closed")
+	ErrCampaignIDNotInformed              = errors.New("campaign id not informed")
+	ErrAccessPermissionIDNotInformed      = errors.New("access permission id not informed")
+	ErrInvalidRecertificationPermission   = errors.New("invalid recertification permission snapshot")
+	ErrReviewerIDNotInformed              = errors.New("reviewer id not informed")
+	ErrInvalidRecertificationDecision     = errors.New("invalid recertification decision")
+	ErrRecertificationItemOfOtherReviewer = errors.New("recertification item is assigned to another reviewer")
+)
+
+// RecertificationCampaign godoc
+// Periodic review of the access permissions, e.g. a quarterly audit. The permissions in the scope of the campaign, all of
+// them or those of an ecosystem and/or a team, are taken as items assigned to reviewers, who decide to keep or revoke
+// each one. The lifecycle is OPEN while the items are reviewed, then CLOSED, when the revocations decided are executed.
+type RecertificationCampaign struct {
+	ID              uuid.UUID
+	Name            string
+	Status          RecertificationCampaignStatus
+	EcosystemID     sql.NullString
+	Team            string
+	CreatedByUserID string
+	ClosedByUserID  sql.NullString
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	ClosedAt        sql.NullTime
+}
+
+func NewRecertificationCampaign(name, ecosystemID, team, createdByUserID string) (*RecertificationCampaign, error) {
+	currentTime := time.Now()
+	c := &RecertificationCampaign{
+		ID:              uuid.New(),
+		Name:            strings.TrimSpace(name),
+		Status:          RecertificationCampaignStatusOpen,
+		EcosystemID:     sql.NullString{String: ecosystemID, Valid: ecosystemID != ""},
+		Team:            strings.TrimSpace(team),
+		CreatedByUserID: createdByUserID,
+		CreatedAt:       currentTime,
+		UpdatedAt:       currentTime,
+	}
+	err := c.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *RecertificationCampaign) IsOpen() bool {
+	return c.Status == RecertificationCampaignStatusOpen
+}
+
+func (c *RecertificationCampaign) Close(closedByUserID string) error {
+	if !c.IsOpen() {
+		return ErrRecertificationCampaignClosed
+	}
+	currentTime := time.Now()
+	c.Status = RecertificationCampaignStatusClosed
+	c.ClosedByUserID = sql.NullString{String: closedByUserID, Valid: true}
+	c.ClosedAt = sql.NullTime{Time: currentTime, Valid: true}
+	c.UpdatedAt = currentTime
+	return nil
+}
+
+func (c *RecertificationCampaign) Validate() error {
+	if c.Name == "" {
+		return ErrInvalidName
+	}
+	if c.CreatedByUserID == "" {
+		return ErrCreatedByUserNotInformed
+	}
+	return nil
+}
+
+func ValidateRecertificationCampaignStatus(status string) bool {
+	switch RecertificationCampaignStatus(status) {
+	case RecertificationCampaignStatusOpen, RecertificationCampaignStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecertificationItem godoc
+// Access permission under review in a campaign. Permission holds the JSON snapshot of the permission (an
+// AccessPermissionOutputDTO) taken when the campaign was created, so the evidence remains after the permission is revoked.
+// RevocationResult holds the result of the revocation executed when the campaign closed.
+type RecertificationItem struct {
+	ID                 uuid.UUID
+	CampaignID         string
+	AccessPermissionID string
+	DatabaseUserID     string
+	DatabaseInstanceID string
+	Permission         string
+	ReviewerID         string
+	Decision           RecertificationDecision
+	Comment            string
+	DecidedAt          sql.NullTime
+	RevocationResult   string
+}
+
+func NewRecertificationItem(campaignID, accessPermissionID, databaseUserID, databaseInstanceID, permission, reviewerID string) (*RecertificationItem, error) {
+	i := &RecertificationItem{
+		ID:                 uuid.New(),
+		CampaignID:         campaignID,
+		AccessPermissionID: accessPermissionID,
+		DatabaseUserID:     databaseUserID,
+		DatabaseInstanceID: databaseInstanceID,
+		Permission:         permission,
+		ReviewerID:         reviewerID,
+		Decision:           RecertificationDecisionPending,
+	}
+	err := i.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// Decide godoc
+// Records the decision of the reviewer the item is assigned to. The decision may be changed while the campaign is open.
+func (i *RecertificationItem) Decide(reviewerID string, decision RecertificationDecision, comment string) error {
+	if reviewerID != i.ReviewerID {
+		return ErrRecertificationItemOfOtherReviewer
+	}
+	if decision != RecertificationDecisionKeep && decision != RecertificationDecisionRevoke {
+		return ErrInvalidRecertificationDecision
+	}
+	i.Decision = decision
+	i.Comment = comment
+	i.DecidedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	return nil
+}
+
+func (i *RecertificationItem) Validate() error {
+	if i.CampaignID == "" {
+		return ErrCampaignIDNotInformed
+	}
+	if i.AccessPermissionID == "" {
+		return ErrAccessPermissionIDNotInformed
+	}
+	if i.DatabaseUserID == "" {
+		return ErrDatabaseUserIDNotInformed
+	}
+	if i.DatabaseInstanceID == "" {
+		return ErrDatabaseInstanceIDNotInformed
+	}
+	if i.Permission == "" {
+		return ErrInvalidRecertificationPermission
+	}
+	if i.ReviewerID == "" {
+		return ErrReviewerIDNotInformed
+	}
+	return nil
+}
+
+func ValidateRecertificationDecision(decision string) bool {
+	switch RecertificationDecision(decision) {
+	case RecertificationDecisionPending, RecertificationDecisionKeep, RecertificationDecisionRevoke:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	campaignName        = "Q3 2024 recertification"
+	permissionSnapshot  = `{"id":"9c7a0d5e-1b2f-4c3d-8e9f-0a1b2c3d4e5f"}`
+	accessPermissionID  = "9c7a0d5e-1b2f-4c3d-8e9f-0a1b2c3d4e5f"
+	recertificationTeam = "Team B"
+)
+
+func TestGivenAnEmptyRequiredParam_WhenValidateRecertificationCampaign_ThenShouldReceiveAnError(t *testing.T) {
+	c := &RecertificationCampaign{}
+	assertValidate(t, c, ErrInvalidName)
+
+	c = &RecertificationCampaign{Name: campaignName}
+	assertValidate(t, c, ErrCreatedByUserNotInformed)
+}
+
+func TestGivenAValidParams_WhenCreateNewRecertificationCampaign_ThenShouldReturnAnOpenCampaign(t *testing.T) {
+	c, err := NewRecertificationCampaign(" "+campaignName+" ", "", " "+recertificationTeam, userID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, c.ID)
+	assert.Equal(t, campaignName, c.Name)
+	assert.Equal(t, RecertificationCampaignStatusOpen, c.Status)
+	assert.False(t, c.EcosystemID.Valid)
+	assert.Equal(t, recertificationTeam, c.Team)
+	assert.True(t, c.IsOpen())
+	assert.False(t, c.ClosedAt.Valid)
+}
+
+func TestGivenAnOpenCampaign_WhenClose_ThenShouldNotBeClosedAgain(t *testing.T) {
+	c, _ := NewRecertificationCampaign(campaignName, uuid.New().String(), "", userID)
+
+	assert.NoError(t, c.Close(userID))
+	assert.Equal(t, RecertificationCampaignStatusClosed, c.Status)
+	assert.Equal(t, userID, c.ClosedByUserID.String)
+	assert.True(t, c.ClosedAt.Valid)
+	assert.ErrorIs(t, c.Close(userID), ErrRecertificationCampaignClosed)
+}
+
+func TestGivenAnEmptyRequiredParam_WhenValidateRecertificationItem_ThenShouldReceiveAnError(t *testing.T) {
+	i := &RecertificationItem{}
+	assertValidate(t, i, ErrCampaignIDNotInformed)
+
+	i = &RecertificationItem{CampaignID: uuid.New().String()}
+	assertValidate(t, i, ErrAccessPermissionIDNotInformed)
+
+	i.AccessPermissionID = accessPermissionID
+	assertValidate(t, i, ErrDatabaseUserIDNotInformed)
+
+	i.DatabaseUserID = uuid.New().String()
+	assertValidate(t, i, ErrDatabaseInstanceIDNotInformed)
+
+	i.DatabaseInstanceID = uuid.New().String()
+	assertValidate(t, i, ErrInvalidRecertificationPermission)
+
+	i.Permission = permissionSnapshot
+	assertValidate(t, i, ErrReviewerIDNotInformed)
+}
+
+func TestGivenAPendingItem_WhenDecide_ThenShouldOnlyAcceptTheDecisionOfItsReviewer(t *testing.T) {
+	i, err := NewRecertificationItem(uuid.New().String(), accessPermissionID, uuid.New().String(), uuid.New().String(), permissionSnapshot, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, RecertificationDecisionPending, i.Decision)
+
+	assert.ErrorIs(t, i.Decide(uuid.New().String(), RecertificationDecisionKeep, ""), ErrRecertificationItemOfOtherReviewer)
+	assert.ErrorIs(t, i.Decide(userID, RecertificationDecisionPending, ""), ErrInvalidRecertificationDecision)
+	assert.False(t, i.DecidedAt.Valid)
+
+	assert.NoError(t, i.Decide(userID, RecertificationDecisionRevoke, "left the team"))
+	assert.Equal(t, RecertificationDecisionRevoke, i.Decision)
+	assert.Equal(t, "left the team", i.Comment)
+	assert.True(t, i.DecidedAt.Valid)
+}
+
+func TestGivenADecision_WhenValidateRecertificationDecision_ThenShouldAcceptOnlyKnownDecisions(t *testing.T) {
+	assert.True(t, ValidateRecertificationDecision("KEEP"))
+	assert.True(t, ValidateRecertificationDecision("PENDING"))
+	assert.False(t, ValidateRecertificationDecision("keep"))
+	assert.True(t, ValidateRecertificationCampaignStatus("CLOSED"))
+	assert.False(t, ValidateRecertificationCampaignStatus("DONE"))
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RoleFindingClass string
+
+// Classes of the logins found in the instances, from the least to the most dangerous
+const (
+	// RoleFindingManaged is a login of a Data Guard user with access to the instance and only Data Guard roles
+	RoleFindingManaged RoleFindingClass = "MANAGED"
+	// RoleFindingMismatched is a login of a Data Guard user whose permissions don't match the ones in Data Guard
+	RoleFindingMismatched RoleFindingClass = "MISMATCHED"
+	// RoleFindingUnknown is a login created outside Data Guard
+	RoleFindingUnknown RoleFindingClass = "UNKNOWN"
+	// RoleFindingPrivileged is a login able to create roles (CREATEROLE) or to bypass the row level security (BYPASSRLS)
+	RoleFindingPrivileged RoleFindingClass = "PRIVILEGED"
+	// RoleFindingSuperuser is a login with every privilege of the instance
+	RoleFindingSuperuser RoleFindingClass = "SUPERUSER"
+)
+
+var (
+	ErrLoginNameNotInformed    = errors.New("login name not informed")
+	ErrInvalidRoleFindingClass = errors.New("invalid role finding class")
+)
+
+// RoleFinding godoc
+// A login found in a database instance by the role discovery. DatabaseUserID is set when the login belongs to a Data
+// Guard user and Details tells why the login was classified as it was.
+type RoleFinding struct {
+	ID                 uuid.UUID
+	DatabaseInstanceID string
+	LoginName          string
+	DatabaseUserID     string
+	Class              RoleFindingClass
+	Details            string
+	DiscoveredByUserID string
+	DiscoveredAt       time.Time
+}
+
+func NewRoleFinding(databaseInstanceID, loginName, databaseUserID string, class RoleFindingClass, details, discoveredByUserID string, discoveredAt time.Time) (*RoleFinding, error) {
+	f := &RoleFinding{
+		ID:                 uuid.New(),
+		DatabaseInstanceID: databaseInstanceID,
+		LoginName:          loginName,
+		DatabaseUserID:     databaseUserID,
+		Class:              class,
+		Details:            details,
+		DiscoveredByUserID: discoveredByUserID,
+		DiscoveredAt:       discoveredAt,
+	}
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RoleFinding) Validate() error {
+	if f.DatabaseInstanceID == "" {
+		return ErrDatabaseInstanceIDNotInformed
+	}
+	if f.LoginName == "" {
+		return ErrLoginNameNotInformed
+	}
+	if !ValidateRoleFindingClass(string(f.Class)) {
+		return ErrInvalidRoleFindingClass
+	}
+	if f.DiscoveredByUserID == "" {
+		return ErrUserIDNotInformed
+	}
+	return nil
+}
+
+func ValidateRoleFindingClass(class string) bool {
+	switch RoleFindingClass(class) {
+	case RoleFindingManaged, RoleFindingMismatched, RoleFindingUnknown, RoleFindingPrivileged, RoleFindingSuperuser:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGivenAnEmptyRequiredParam_WhenValidateRoleFinding_ThenShouldReceiveAnError(t *testing.T) {
+	f := &RoleFinding{}
+	assertValidate(t, f, ErrDatabaseInstanceIDNotInformed)
+
+	f = &RoleFinding{DatabaseInstanceID: instanceID}
+	assertValidate(t, f, ErrLoginNameNotInformed)
+
+	f = &RoleFinding{DatabaseInstanceID: instanceID, LoginName: "johndoe", Class: "ROGUE"}
+	assertValidate(t, f, ErrInvalidRoleFindingClass)
+
+	f = &RoleFinding{DatabaseInstanceID: instanceID, LoginName: "johndoe", Class: RoleFindingUnknown}
+	assertValidate(t, f, ErrUserIDNotInformed)
+}
+
+func TestGivenAValidParams_WhenCreateNewRoleFinding_ThenShouldReturnTheFinding(t *testing.T) {
+	discoveredAt := time.Now()
+	f, err := NewRoleFinding(instanceID, "johndoe", userID, RoleFindingMismatched, "no access permission in the instance", userID, discoveredAt)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, f.ID)
+	assert.Equal(t, instanceID, f.DatabaseInstanceID)
+	assert.Equal(t, "johndoe", f.LoginName)
+	assert.Equal(t, userID, f.DatabaseUserID)
+	assert.Equal(t, RoleFindingMismatched, f.Class)
+	assert.Equal(t, "no access permission in the instance", f.Details)
+	assert.Equal(t, discoveredAt, f.DiscoveredAt)
+}
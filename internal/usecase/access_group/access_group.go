@@ -0,0 +1,173 @@
+package accessgroup
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+var (
+	ErrAccessGroupNotFound      = errors.New("access group not found")
+	ErrAccessGroupAlreadyExists = errors.New("an access group with this name already exists")
+	ErrNotAccessGroupMember     = errors.New("database user is not a member of the access group")
+	ErrTargetInstanceNotFound   = errors.New("database instance of the target not found")
+	ErrTargetDatabaseNotFound   = errors.New("database of the target not found in its instance")
+	ErrTargetRequiresApproval   = errors.New("access to the instance requires approval, so it can't be a target of an access group")
+	ErrInstanceInformedTwice    = errors.New("the same instance is informed more than once in instancesData")
+)
+
+func findGroup(groupStorage storage.AccessGroupStorage, groupID string) (*entity.AccessGroup, error) {
+	group, err := groupStorage.FindByID(groupID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Access group with id %s not found in database!", groupID)
+		return nil, ErrAccessGroupNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching access group with id %s. Cause: %v", groupID, err)
+		return nil, err
+	}
+	return group, nil
+}
+
+// findGroupDTO godoc
+// Fetches the group with its targets and members
+func findGroupDTO(groupStorage storage.AccessGroupStorage, groupID string) (*dto.AccessGroupOutputDTO, error) {
+	groupDTO, err := groupStorage.FindDTOByID(groupID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAccessGroupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if groupDTO.Targets, err = groupStorage.FindAllTargetDTOs(groupID); err != nil {
+		return nil, fmt.Errorf("error fetching the targets of access group %s. Cause: %w", groupID, err)
+	}
+	if groupDTO.Members, err = groupStorage.FindAllMemberDTOs(groupID); err != nil {
+		return nil, fmt.Errorf("error fetching the members of access group %s. Cause: %w", groupID, err)
+	}
+	return groupDTO, nil
+}
+
+// buildTargets godoc
+// Builds the targets of the group from the instances and databases informed, checking that the instances exist and
+// don't require approval, since the access of the members is granted without it, and that the databases belong to them
+func buildTargets(
+	instanceStorage storage.DatabaseInstanceStorage,
+	databaseStorage storage.DatabaseStorage,
+	groupID string,
+	instancesData []dto.InstanceDataDTO,
+) ([]*entity.AccessGroupTarget, error) {
+	if len(instancesData) == 0 {
+		return nil, nil
+	}
+	instancesIDs := make([]string, 0, len(instancesData))
+	seen := make(map[string]bool, len(instancesData))
+	for _, instanceData := range instancesData {
+		if seen[instanceData.DatabaseInstanceID] {
+			return nil, fmt.Errorf("%w: %s", ErrInstanceInformedTwice, instanceData.DatabaseInstanceID)
+		}
+		seen[instanceData.DatabaseInstanceID] = true
+		instancesIDs = append(instancesIDs, instanceData.DatabaseInstanceID)
+	}
+	instances, err := instanceStorage.FindAllDTOs("", "", instancesIDs)
+	if err != nil {
+		return nil, err
+	}
+	instancesByID := make(map[string]*dto.DatabaseInstanceOutputDTO, len(instances))
+	for _, instance := range instances {
+		instancesByID[instance.ID] = instance
+	}
+
+	var targets []*entity.AccessGroupTarget
+	for _, instanceData := range instancesData {
+		instance, found := instancesByID[instanceData.DatabaseInstanceID]
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrTargetInstanceNotFound, instanceData.DatabaseInstanceID)
+		}
+		if instance.EcosystemRequiresApproval {
+			return nil, fmt.Errorf("%w: %s", ErrTargetRequiresApproval, instance.Name)
+		}
+		instanceTargets, err := buildInstanceTargets(databaseStorage, groupID, instanceData)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, instanceTargets...)
+	}
+	return targets, nil
+}
+
+func buildInstanceTargets(databaseStorage storage.DatabaseStorage, groupID string, instanceData dto.InstanceDataDTO) ([]*entity.AccessGroupTarget, error) {
+	if len(instanceData.DatabasesIDs) == 0 {
+		target, err := entity.NewAccessGroupTarget(groupID, instanceData.DatabaseInstanceID, "")
+		if err != nil {
+			return nil, err
+		}
+		return []*entity.AccessGroupTarget{target}, nil
+	}
+	databases, err := databaseStorage.FindAll(instanceData.DatabaseInstanceID, instanceData.DatabasesIDs)
+	if err != nil {
+		return nil, err
+	}
+	databasesFound := make(map[string]bool, len(databases))
+	for _, database := range databases {
+		databasesFound[database.ID.String()] = true
+	}
+	targets := make([]*entity.AccessGroupTarget, 0, len(instanceData.DatabasesIDs))
+	added := make(map[string]bool, len(instanceData.DatabasesIDs))
+	for _, databaseID := range instanceData.DatabasesIDs {
+		if !databasesFound[databaseID] {
+			return nil, fmt.Errorf("%w: %s", ErrTargetDatabaseNotFound, databaseID)
+		}
+		// Prevents the same database informed twice from becoming two targets
+		if added[databaseID] {
+			continue
+		}
+		added[databaseID] = true
+		target, err := entity.NewAccessGroupTarget(groupID, instanceData.DatabaseInstanceID, databaseID)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// toInstancesData godoc
+// Converts the targets to the instances data of a grant. A target of all databases of an instance prevails over the
+// targets of its databases, since the grant of an instance without databases grants all of them.
+func toInstancesData(targets []*entity.AccessGroupTarget) []dto.InstanceDataDTO {
+	var instancesData []dto.InstanceDataDTO
+	idxByInstance := make(map[string]int)
+	allDatabases := make(map[string]bool)
+	for _, target := range targets {
+		idx, found := idxByInstance[target.DatabaseInstanceID]
+		if !found {
+			idx = len(instancesData)
+			idxByInstance[target.DatabaseInstanceID] = idx
+			instancesData = append(instancesData, dto.InstanceDataDTO{DatabaseInstanceID: target.DatabaseInstanceID})
+		}
+		if !target.DatabaseID.Valid {
+			allDatabases[target.DatabaseInstanceID] = true
+			instancesData[idx].DatabasesIDs = nil
+			continue
+		}
+		if !allDatabases[target.DatabaseInstanceID] {
+			instancesData[idx].DatabasesIDs = append(instancesData[idx].DatabasesIDs, target.DatabaseID.String)
+		}
+	}
+	return instancesData
+}
+
+func coversDatabase(targets []*entity.AccessGroupTarget, instanceID, databaseID string) bool {
+	for _, target := range targets {
+		if target.Covers(instanceID, databaseID) {
+			return true
+		}
+	}
+	return false
+}
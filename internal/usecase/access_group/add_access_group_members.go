@@ -0,0 +1,95 @@
+package accessgroup
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type AddAccessGroupMembersUseCase struct {
+	AccessGroupStorage  storage.AccessGroupStorage
+	DatabaseUserStorage storage.DatabaseUserStorage
+	GrantUseCase        common.GrantAccessPermissionUseCaseInterface
+}
+
+func NewAddAccessGroupMembersUseCase(
+	accessGroupStorage storage.AccessGroupStorage,
+	databaseUserStorage storage.DatabaseUserStorage,
+	grantUseCase common.GrantAccessPermissionUseCaseInterface,
+) *AddAccessGroupMembersUseCase {
+	return &AddAccessGroupMembersUseCase{
+		AccessGroupStorage:  accessGroupStorage,
+		DatabaseUserStorage: databaseUserStorage,
+		GrantUseCase:        grantUseCase,
+	}
+}
+
+// Execute godoc
+/** Adds the database users to the group and grants them the access of its targets through the grant access use case.
+Users that are already members are kept, and the access of the group is granted to them again, so adding a member again
+grants the access that couldn't be granted before. */
+func (uc *AddAccessGroupMembersUseCase) Execute(groupID string, input dto.AccessGroupMembersInputDTO, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error) {
+	group, err := findGroup(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	membersIDs, err := uc.validateUsers(input.DatabaseUsersIDs)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*entity.AccessGroupMember, 0, len(membersIDs))
+	for _, memberID := range membersIDs {
+		member, err := entity.NewAccessGroupMember(groupID, memberID, operationUserID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	targets, err := uc.AccessGroupStorage.FindAllTargets(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.AccessGroupStorage.SaveMembers(members); err != nil {
+		log.Printf("Error saving the members of access group %s. Cause: %v", groupID, err)
+		return nil, err
+	}
+
+	log.Printf("%d members added to access group '%s' by user %s, granting %d targets", len(members), group.Name, operationUserID, len(targets))
+	grantOutput := grant(uc.GrantUseCase, groupID, membersIDs, targets, operationUserID)
+	groupDTO, err := findGroupDTO(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return buildChangeOutput(groupDTO, grantOutput, nil), nil
+}
+
+// validateUsers godoc
+// Returns the users informed without duplicates, failing when any of them is not a database user
+func (uc *AddAccessGroupMembersUseCase) validateUsers(databaseUsersIDs []string) ([]string, error) {
+	var uniqueIDs []string
+	seen := make(map[string]bool, len(databaseUsersIDs))
+	for _, id := range databaseUsersIDs {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	users, err := uc.DatabaseUserStorage.FindAll(uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(users))
+	for _, user := range users {
+		found[user.ID.String()] = true
+	}
+	for _, id := range uniqueIDs {
+		if !found[id] {
+			return nil, fmt.Errorf("%w: %s", common.ErrDatabaseUserNotFound, id)
+		}
+	}
+	return uniqueIDs, nil
+}
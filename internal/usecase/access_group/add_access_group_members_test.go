@@ -0,0 +1,93 @@
+package accessgroup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func mockGroupDTOFetch(groupStorage *mocks.AccessGroupStorageMock) {
+	groupStorage.On("FindDTOByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroupDTO(), nil).Once()
+	groupStorage.On("FindAllTargetDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupTargetOutputDTO{}, nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{}, nil).Once()
+}
+
+func TestGivenAnUnknownDatabaseUser_WhenExecuteAddAccessGroupMembers_ThenShouldReturnError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string{mocks.DbUserID}).Return([]*entity.DatabaseUser{}, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+
+	uc := NewAddAccessGroupMembersUseCase(groupStorage, dbUserStorage, grantUC)
+	output, err := uc.Execute(mocks.AccessGroupID, dto.AccessGroupMembersInputDTO{DatabaseUsersIDs: []string{mocks.DbUserID}}, mocks.UserID)
+
+	assert.ErrorIs(t, err, common.ErrDatabaseUserNotFound)
+	assert.Nil(t, output)
+	groupStorage.AssertNotCalled(t, "SaveMembers", mock.Anything)
+	grantUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGivenValidUsers_WhenExecuteAddAccessGroupMembers_ThenShouldGrantTheTargetsToThem(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, ""),
+		mocks.BuildAccessGroupTarget(mocks.DatabaseInstanceId, mocks.DatabaseID),
+	}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(targets, nil).Once()
+	groupStorage.On("SaveMembers", mock.Anything).Return(nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string{mocks.DbUserID}).Return([]*entity.DatabaseUser{{ID: uuid.MustParse(mocks.DbUserID)}}, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	expectedGrant := dto.GrantAccessInputDTO{
+		DatabaseUsersIDs: []string{mocks.DbUserID},
+		InstancesData: []dto.InstanceDataDTO{
+			{DatabaseInstanceID: mocks.QAInstanceId},
+			{DatabaseInstanceID: mocks.DatabaseInstanceId, DatabasesIDs: []string{mocks.DatabaseID}},
+		},
+		AccessGroupID: mocks.AccessGroupID,
+	}
+	grantUC.On("Execute", expectedGrant, mocks.UserID).Return(&dto.GrantAccessOutputDTO{Message: "granted"}, nil).Once()
+
+	uc := NewAddAccessGroupMembersUseCase(groupStorage, dbUserStorage, grantUC)
+	input := dto.AccessGroupMembersInputDTO{DatabaseUsersIDs: []string{mocks.DbUserID, mocks.DbUserID}}
+	output, err := uc.Execute(mocks.AccessGroupID, input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, AccessGroupChangedMsg, output.Message)
+	assert.Equal(t, "granted", output.Grant.Message)
+	groupStorage.AssertExpectations(t)
+	grantUC.AssertExpectations(t)
+}
+
+func TestGivenAGrantError_WhenExecuteAddAccessGroupMembers_ThenShouldKeepTheMembersAndReportTheError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return([]*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, "")}, nil).Once()
+	groupStorage.On("SaveMembers", mock.Anything).Return(nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string{mocks.DbUserID}).Return([]*entity.DatabaseUser{{ID: uuid.MustParse(mocks.DbUserID)}}, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	grantUC.On("Execute", mock.Anything, mocks.UserID).Return(&dto.GrantAccessOutputDTO{}, errors.New("connection refused")).Once()
+
+	uc := NewAddAccessGroupMembersUseCase(groupStorage, dbUserStorage, grantUC)
+	output, err := uc.Execute(mocks.AccessGroupID, dto.AccessGroupMembersInputDTO{DatabaseUsersIDs: []string{mocks.DbUserID}}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, AccessGroupChangedWithErrorMsg, output.Message)
+	assert.Contains(t, output.Grant.Message, "connection refused")
+	groupStorage.AssertExpectations(t)
+}
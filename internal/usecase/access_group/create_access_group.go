@@ -0,0 +1,54 @@
+package accessgroup
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type CreateAccessGroupUseCase struct {
+	AccessGroupStorage      storage.AccessGroupStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseStorage         storage.DatabaseStorage
+}
+
+func NewCreateAccessGroupUseCase(
+	accessGroupStorage storage.AccessGroupStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	databaseStorage storage.DatabaseStorage,
+) *CreateAccessGroupUseCase {
+	return &CreateAccessGroupUseCase{
+		AccessGroupStorage:      accessGroupStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		DatabaseStorage:         databaseStorage,
+	}
+}
+
+// Execute godoc
+// Creates the group with its targets and no members, so nothing is granted until members are added
+func (uc *CreateAccessGroupUseCase) Execute(input dto.AccessGroupInputDTO, operationUserID string) (*dto.AccessGroupOutputDTO, error) {
+	group, err := entity.NewAccessGroup(input.Name, input.Description, operationUserID)
+	if err != nil {
+		return nil, err
+	}
+	exists, err := uc.AccessGroupStorage.CheckNameExists(group.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAccessGroupAlreadyExists
+	}
+	targets, err := buildTargets(uc.DatabaseInstanceStorage, uc.DatabaseStorage, group.ID.String(), input.InstancesData)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.AccessGroupStorage.Save(group, targets); err != nil {
+		log.Printf("Error saving access group. Cause: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Access group %s created by user %s with %d targets", group.ID, operationUserID, len(targets))
+	return findGroupDTO(uc.AccessGroupStorage, group.ID.String())
+}
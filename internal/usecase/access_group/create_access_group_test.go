@@ -0,0 +1,108 @@
+package accessgroup
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const otherDatabaseID = "0b6e2f3a-9c4d-4e5f-8a7b-6c5d4e3f2a1b"
+
+func buildGroupInput(instancesData ...dto.InstanceDataDTO) dto.AccessGroupInputDTO {
+	return dto.AccessGroupInputDTO{Name: "Team B analysts", Description: "Read access of the analysts", InstancesData: instancesData}
+}
+
+func TestGivenAnExistingName_WhenExecuteCreateAccessGroup_ThenShouldReturnError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("CheckNameExists", "Team B analysts").Return(true, nil).Once()
+
+	uc := NewCreateAccessGroupUseCase(groupStorage, nil, nil)
+	output, err := uc.Execute(buildGroupInput(dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId}), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrAccessGroupAlreadyExists)
+	assert.Nil(t, output)
+	groupStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGivenAnInstanceRequiringApproval_WhenExecuteCreateAccessGroup_ThenShouldReturnError(t *testing.T) {
+	instance := mocks.BuildQAInstanceEnabled()
+	instance.EcosystemRequiresApproval = true
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("CheckNameExists", "Team B analysts").Return(false, nil).Once()
+	instanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	instanceStorage.On("FindAllDTOs", "", "", []string{mocks.QAInstanceId}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+
+	uc := NewCreateAccessGroupUseCase(groupStorage, instanceStorage, nil)
+	output, err := uc.Execute(buildGroupInput(dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId}), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrTargetRequiresApproval)
+	assert.Nil(t, output)
+	groupStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGivenADatabaseOfOtherInstance_WhenExecuteCreateAccessGroup_ThenShouldReturnError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("CheckNameExists", "Team B analysts").Return(false, nil).Once()
+	instanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	instanceStorage.On("FindAllDTOs", "", "", []string{mocks.QAInstanceId}).Return([]*dto.DatabaseInstanceOutputDTO{mocks.BuildQAInstanceEnabled()}, nil).Once()
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAll", mocks.QAInstanceId, []string{otherDatabaseID}).Return([]*entity.Database{}, nil).Once()
+
+	uc := NewCreateAccessGroupUseCase(groupStorage, instanceStorage, databaseStorage)
+	input := buildGroupInput(dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId, DatabasesIDs: []string{otherDatabaseID}})
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrTargetDatabaseNotFound)
+	assert.Nil(t, output)
+	groupStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGivenTheSameInstanceTwice_WhenExecuteCreateAccessGroup_ThenShouldReturnError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("CheckNameExists", "Team B analysts").Return(false, nil).Once()
+
+	uc := NewCreateAccessGroupUseCase(groupStorage, nil, nil)
+	input := buildGroupInput(dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId}, dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId})
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrInstanceInformedTwice)
+	assert.Nil(t, output)
+}
+
+func TestGivenValidTargets_WhenExecuteCreateAccessGroup_ThenShouldSaveTheGroupWithItsTargets(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("CheckNameExists", "Team B analysts").Return(false, nil).Once()
+	var savedTargets []*entity.AccessGroupTarget
+	groupStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		savedTargets = args.Get(1).([]*entity.AccessGroupTarget)
+	}).Return(nil).Once()
+	groupStorage.On("FindDTOByID", mock.Anything).Return(mocks.BuildAccessGroupDTO(), nil).Once()
+	groupStorage.On("FindAllTargetDTOs", mock.Anything).Return([]dto.AccessGroupTargetOutputDTO{}, nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mock.Anything).Return([]dto.AccessGroupMemberOutputDTO{}, nil).Once()
+	instanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	instances := []*dto.DatabaseInstanceOutputDTO{mocks.BuildQAInstanceEnabled(), {ID: mocks.DatabaseInstanceId, Name: "Production"}}
+	instanceStorage.On("FindAllDTOs", "", "", []string{mocks.QAInstanceId, mocks.DatabaseInstanceId}).Return(instances, nil).Once()
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	database := &entity.Database{ID: uuid.MustParse(mocks.DatabaseID), DatabaseInstanceID: mocks.DatabaseInstanceId}
+	databaseStorage.On("FindAll", mocks.DatabaseInstanceId, []string{mocks.DatabaseID, mocks.DatabaseID}).Return([]*entity.Database{database}, nil).Once()
+
+	uc := NewCreateAccessGroupUseCase(groupStorage, instanceStorage, databaseStorage)
+	input := buildGroupInput(
+		dto.InstanceDataDTO{DatabaseInstanceID: mocks.QAInstanceId},
+		dto.InstanceDataDTO{DatabaseInstanceID: mocks.DatabaseInstanceId, DatabasesIDs: []string{mocks.DatabaseID, mocks.DatabaseID}},
+	)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	assert.Len(t, savedTargets, 2)
+	assert.Equal(t, mocks.QAInstanceId+"/*", savedTargets[0].Key())
+	assert.Equal(t, mocks.DatabaseInstanceId+"/"+mocks.DatabaseID, savedTargets[1].Key())
+	groupStorage.AssertExpectations(t)
+}
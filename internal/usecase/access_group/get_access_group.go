@@ -0,0 +1,26 @@
+package accessgroup
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type GetAccessGroupUseCase struct {
+	AccessGroupStorage storage.AccessGroupStorage
+}
+
+func NewGetAccessGroupUseCase(accessGroupStorage storage.AccessGroupStorage) *GetAccessGroupUseCase {
+	return &GetAccessGroupUseCase{AccessGroupStorage: accessGroupStorage}
+}
+
+func (uc *GetAccessGroupUseCase) Execute(groupID string) (*dto.AccessGroupOutputDTO, error) {
+	groupDTO, err := findGroupDTO(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		log.Printf("Error fetching access group with id %s. Cause: %v", groupID, err)
+		return nil, err
+	}
+	log.Printf("Access group with id %s loaded successfully!", groupID)
+	return groupDTO, nil
+}
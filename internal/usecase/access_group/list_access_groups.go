@@ -0,0 +1,30 @@
+package accessgroup
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListAccessGroupsUseCase struct {
+	AccessGroupStorage storage.AccessGroupStorage
+}
+
+func NewListAccessGroupsUseCase(accessGroupStorage storage.AccessGroupStorage) *ListAccessGroupsUseCase {
+	return &ListAccessGroupsUseCase{AccessGroupStorage: accessGroupStorage}
+}
+
+func (uc *ListAccessGroupsUseCase) Execute(page, limit int) ([]*dto.AccessGroupOutputDTO, int, error) {
+	groupDTOs, err := uc.AccessGroupStorage.FindAllDTOs(page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching access groups! Cause: %w", err)
+	}
+	totalCount, err := uc.AccessGroupStorage.Count()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching access groups count! Cause: %w", err)
+	}
+	log.Printf("List of access groups loaded successfully!")
+	return groupDTOs, totalCount, nil
+}
@@ -0,0 +1,159 @@
+package accessgroup
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const (
+	AccessGroupChangedMsg          = "Access group changed and the access of its members reconciled."
+	AccessGroupChangedWithErrorMsg = "Access group changed, but some errors occurred reconciling the access of its members. Check the grant and the revocations for details."
+	AccessKeptCoveredByGroupMsg    = "Access kept, the databases are still covered by an access group of the user"
+	AccessKeptDirectGrantMsg       = "Access kept, the access of the user to the databases of the instance was granted directly, not by an access group"
+	AccessRevokedMsg               = "Access revoked to %d databases no longer covered by an access group of the user"
+	AccessAlreadyRevokedMsg        = "The access was already revoked"
+	ErrRevokingAccessMsg           = "Error revoking the access: %s"
+	ErrGrantingAccessMsg           = "Error granting the access: %s"
+)
+
+// accessReconciler godoc
+// Revokes the access of a member no longer covered by a group. Only the permissions granted by access groups to
+// databases no longer covered are revoked, keeping the ones still covered by a target of an access group of the user
+// and the direct grants, even to databases the group covers; the user is removed from an instance only when no
+// permission is left there.
+type accessReconciler struct {
+	GroupStorage            storage.AccessGroupStorage
+	AccessPermissionStorage storage.AccessPermissionStorage
+	RevokeUseCase           common.RevokeAccessPermissionUseCaseInterface
+}
+
+// revokeUncovered godoc
+// Revokes the access of the member to the databases of the lost targets, keeping the databases still covered by the
+// remaining targets of the group or by the other groups of the member
+func (r *accessReconciler) revokeUncovered(
+	member dto.AccessGroupMemberOutputDTO,
+	groupID string,
+	lostTargets, remainingTargets []*entity.AccessGroupTarget,
+	operationUserID string,
+) []dto.ItemResultDTO {
+	otherGroupsTargets, err := r.GroupStorage.FindAllTargetsByMember(member.DatabaseUserID, groupID)
+	if err != nil {
+		return []dto.ItemResultDTO{{Item: member.Username, Success: false, Message: fmt.Sprintf(ErrRevokingAccessMsg, err.Error())}}
+	}
+	remainingTargets = slices.Concat(remainingTargets, otherGroupsTargets)
+
+	var results []dto.ItemResultDTO
+	for _, instanceID := range distinctInstances(lostTargets) {
+		permissions, err := r.AccessPermissionStorage.FindAllDTOs("", member.DatabaseUserID, instanceID)
+		if err != nil {
+			results = append(results, buildResult(member, instanceID, false, fmt.Sprintf(ErrRevokingAccessMsg, err.Error())))
+			continue
+		}
+		if len(permissions) == 0 {
+			continue
+		}
+		instanceName := permissions[0].DatabaseInstanceName
+		databasesIDs, coveredByGroup := uncoveredDatabases(permissions, lostTargets, remainingTargets)
+		switch {
+		case len(databasesIDs) > 0:
+			log.Printf("Revoking the access of member '%s' of access group %s to %d databases of instance '%s' no longer covered", member.Username, groupID, len(databasesIDs), instanceName)
+			success, message := r.revoke(member.DatabaseUserID, instanceID, databasesIDs, operationUserID)
+			results = append(results, buildResult(member, instanceName, success, message))
+		case coveredByGroup:
+			results = append(results, buildResult(member, instanceName, true, AccessKeptCoveredByGroupMsg))
+		default:
+			results = append(results, buildResult(member, instanceName, true, AccessKeptDirectGrantMsg))
+		}
+	}
+	return results
+}
+
+func (r *accessReconciler) revoke(databaseUserID, instanceID string, databasesIDs []string, operationUserID string) (bool, string) {
+	err := r.RevokeUseCase.RevokeDatabases(databaseUserID, instanceID, databasesIDs, operationUserID)
+	switch {
+	case errors.Is(err, common.ErrNoAccessibleInstancesFound):
+		return true, AccessAlreadyRevokedMsg
+	case err != nil:
+		return false, fmt.Sprintf(ErrRevokingAccessMsg, err.Error())
+	default:
+		return true, fmt.Sprintf(AccessRevokedMsg, len(databasesIDs))
+	}
+}
+
+// grant godoc
+// Grants the access of the targets to the members, as permissions granted by the group. The errors that prevent the
+// grant from running are returned in its output, since the change of the group is already saved and is reconciled again
+// when the members are added again.
+func grant(grantUseCase common.GrantAccessPermissionUseCaseInterface, groupID string, membersIDs []string, targets []*entity.AccessGroupTarget, operationUserID string) *dto.GrantAccessOutputDTO {
+	if len(membersIDs) == 0 || len(targets) == 0 {
+		return nil
+	}
+	input := dto.GrantAccessInputDTO{DatabaseUsersIDs: membersIDs, InstancesData: toInstancesData(targets), AccessGroupID: groupID}
+	output, err := grantUseCase.Execute(input, operationUserID)
+	if err != nil {
+		log.Printf("Error granting the access of the access group targets. Cause: %v", err)
+		return &dto.GrantAccessOutputDTO{HasErrors: true, Message: fmt.Sprintf(ErrGrantingAccessMsg, err.Error())}
+	}
+	return output
+}
+
+func buildChangeOutput(group *dto.AccessGroupOutputDTO, grantOutput *dto.GrantAccessOutputDTO, revocations []dto.ItemResultDTO) *dto.AccessGroupChangeOutputDTO {
+	output := &dto.AccessGroupChangeOutputDTO{
+		Message:     AccessGroupChangedMsg,
+		Group:       group,
+		Grant:       grantOutput,
+		Revocations: revocations,
+	}
+	output.HasErrors = grantOutput != nil && grantOutput.HasErrors
+	for _, revocation := range revocations {
+		if !revocation.Success {
+			output.HasErrors = true
+		}
+	}
+	if output.HasErrors {
+		output.Message = AccessGroupChangedWithErrorMsg
+	}
+	return output
+}
+
+// uncoveredDatabases godoc
+// Returns the databases of the permissions granted by access groups covered by the lost targets and not by the
+// remaining ones, and whether any of them is still covered by the remaining ones. The direct grants are never returned.
+func uncoveredDatabases(permissions []*dto.AccessPermissionOutputDTO, lostTargets, remainingTargets []*entity.AccessGroupTarget) ([]string, bool) {
+	var databasesIDs []string
+	coveredByGroup := false
+	for _, permission := range permissions {
+		if permission.GrantedByAccessGroupID == "" || !coversDatabase(lostTargets, permission.DatabaseInstanceID, permission.DatabaseID) {
+			continue
+		}
+		if coversDatabase(remainingTargets, permission.DatabaseInstanceID, permission.DatabaseID) {
+			coveredByGroup = true
+			continue
+		}
+		databasesIDs = append(databasesIDs, permission.DatabaseID)
+	}
+	return databasesIDs, coveredByGroup
+}
+
+func distinctInstances(targets []*entity.AccessGroupTarget) []string {
+	var instancesIDs []string
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if !seen[target.DatabaseInstanceID] {
+			seen[target.DatabaseInstanceID] = true
+			instancesIDs = append(instancesIDs, target.DatabaseInstanceID)
+		}
+	}
+	return instancesIDs
+}
+
+func buildResult(member dto.AccessGroupMemberOutputDTO, instance string, success bool, message string) dto.ItemResultDTO {
+	return dto.ItemResultDTO{Item: fmt.Sprintf("%s # %s", member.Username, instance), Success: success, Message: message}
+}
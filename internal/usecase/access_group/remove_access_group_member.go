@@ -0,0 +1,76 @@
+package accessgroup
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type RemoveAccessGroupMemberUseCase struct {
+	AccessGroupStorage storage.AccessGroupStorage
+	reconciler         *accessReconciler
+}
+
+func NewRemoveAccessGroupMemberUseCase(
+	accessGroupStorage storage.AccessGroupStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+	revokeUseCase common.RevokeAccessPermissionUseCaseInterface,
+) *RemoveAccessGroupMemberUseCase {
+	return &RemoveAccessGroupMemberUseCase{
+		AccessGroupStorage: accessGroupStorage,
+		reconciler: &accessReconciler{
+			GroupStorage:            accessGroupStorage,
+			AccessPermissionStorage: accessPermissionStorage,
+			RevokeUseCase:           revokeUseCase,
+		},
+	}
+}
+
+// Execute godoc
+/** Removes the database user from the group and revokes, through the revoke access use case, the access given by the
+targets of the group that no other group of the user or direct grant still covers. */
+func (uc *RemoveAccessGroupMemberUseCase) Execute(groupID, databaseUserID, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error) {
+	group, err := findGroup(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := uc.findMember(groupID, databaseUserID)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := uc.AccessGroupStorage.FindAllTargets(groupID)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := uc.AccessGroupStorage.DeleteMember(groupID, databaseUserID)
+	if err != nil {
+		log.Printf("Error removing member %s of access group %s. Cause: %v", databaseUserID, groupID, err)
+		return nil, err
+	}
+	if !removed {
+		return nil, ErrNotAccessGroupMember
+	}
+
+	log.Printf("Member '%s' removed from access group '%s' by user %s", member.Username, group.Name, operationUserID)
+	revocations := uc.reconciler.revokeUncovered(*member, groupID, targets, nil, operationUserID)
+	groupDTO, err := findGroupDTO(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return buildChangeOutput(groupDTO, nil, revocations), nil
+}
+
+func (uc *RemoveAccessGroupMemberUseCase) findMember(groupID, databaseUserID string) (*dto.AccessGroupMemberOutputDTO, error) {
+	members, err := uc.AccessGroupStorage.FindAllMemberDTOs(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if member.DatabaseUserID == databaseUserID {
+			return &member, nil
+		}
+	}
+	return nil, ErrNotAccessGroupMember
+}
@@ -0,0 +1,164 @@
+package accessgroup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const thirdInstanceID = "5d4c3b2a-1f0e-4d9c-8b7a-6f5e4d3c2b1a"
+
+func buildMember() dto.AccessGroupMemberOutputDTO {
+	return dto.AccessGroupMemberOutputDTO{DatabaseUserID: mocks.DbUserID, DatabaseUserName: "Foo Bar", Username: "foobar"}
+}
+
+func buildPermission(instanceID, instanceName, databaseID string) *dto.AccessPermissionOutputDTO {
+	p := buildDirectPermission(instanceID, instanceName, databaseID)
+	p.GrantedByAccessGroupID = mocks.AccessGroupID
+	return p
+}
+
+func buildDirectPermission(instanceID, instanceName, databaseID string) *dto.AccessPermissionOutputDTO {
+	return &dto.AccessPermissionOutputDTO{DatabaseUserID: mocks.DbUserID, DatabaseInstanceID: instanceID, DatabaseInstanceName: instanceName, DatabaseID: databaseID}
+}
+
+func TestGivenAUserNotMember_WhenExecuteRemoveAccessGroupMember_ThenShouldReturnError(t *testing.T) {
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewRemoveAccessGroupMemberUseCase(groupStorage, nil, revokeUC)
+	output, err := uc.Execute(mocks.AccessGroupID, mocks.DbUserID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrNotAccessGroupMember)
+	assert.Nil(t, output)
+	groupStorage.AssertNotCalled(t, "DeleteMember", mock.Anything, mock.Anything)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGivenAMember_WhenExecuteRemoveAccessGroupMember_ThenShouldRevokeOnlyTheDatabasesNotCoveredAnymore(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, ""),
+		mocks.BuildAccessGroupTarget(mocks.DatabaseInstanceId, mocks.DatabaseID),
+		mocks.BuildAccessGroupTarget(thirdInstanceID, ""),
+	}
+	otherGroupTargets := []*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, "")}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{buildMember()}, nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(targets, nil).Once()
+	groupStorage.On("DeleteMember", mocks.AccessGroupID, mocks.DbUserID).Return(true, nil).Once()
+	groupStorage.On("FindAllTargetsByMember", mocks.DbUserID, mocks.AccessGroupID).Return(otherGroupTargets, nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.QAInstanceId).
+		Return([]*dto.AccessPermissionOutputDTO{buildPermission(mocks.QAInstanceId, "QA", mocks.DatabaseID)}, nil).Once()
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.DatabaseInstanceId).Return([]*dto.AccessPermissionOutputDTO{
+		buildPermission(mocks.DatabaseInstanceId, "Production", mocks.DatabaseID),
+		buildPermission(mocks.DatabaseInstanceId, "Production", otherDatabaseID),
+	}, nil).Once()
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, thirdInstanceID).
+		Return([]*dto.AccessPermissionOutputDTO{buildPermission(thirdInstanceID, "Staging", mocks.DatabaseID)}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.DatabaseInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(nil).Once()
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, thirdInstanceID, []string{mocks.DatabaseID}, mocks.UserID).Return(nil).Once()
+
+	uc := NewRemoveAccessGroupMemberUseCase(groupStorage, accessStorage, revokeUC)
+	output, err := uc.Execute(mocks.AccessGroupID, mocks.DbUserID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{
+		{Item: "foobar # QA", Success: true, Message: AccessKeptCoveredByGroupMsg},
+		{Item: "foobar # Production", Success: true, Message: "Access revoked to 1 databases no longer covered by an access group of the user"},
+		{Item: "foobar # Staging", Success: true, Message: "Access revoked to 1 databases no longer covered by an access group of the user"},
+	}, output.Revocations)
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenATargetOfSomeDatabasesRemaining_WhenExecuteRemoveAccessGroupMember_ThenShouldRevokeTheOtherDatabasesOfTheInstance(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, "")}
+	otherGroupTargets := []*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, mocks.DatabaseID)}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{buildMember()}, nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(targets, nil).Once()
+	groupStorage.On("DeleteMember", mocks.AccessGroupID, mocks.DbUserID).Return(true, nil).Once()
+	groupStorage.On("FindAllTargetsByMember", mocks.DbUserID, mocks.AccessGroupID).Return(otherGroupTargets, nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.QAInstanceId).Return([]*dto.AccessPermissionOutputDTO{
+		buildPermission(mocks.QAInstanceId, "QA", mocks.DatabaseID),
+		buildPermission(mocks.QAInstanceId, "QA", otherDatabaseID),
+	}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{otherDatabaseID}, mocks.UserID).Return(errors.New("connection refused")).Once()
+
+	uc := NewRemoveAccessGroupMemberUseCase(groupStorage, accessStorage, revokeUC)
+	output, err := uc.Execute(mocks.AccessGroupID, mocks.DbUserID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{
+		{Item: "foobar # QA", Success: false, Message: "Error revoking the access: " + "connection refused"},
+	}, output.Revocations)
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenADirectGrantToADatabaseOfTheGroup_WhenExecuteRemoveAccessGroupMember_ThenShouldKeepIt(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, "")}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{buildMember()}, nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(targets, nil).Once()
+	groupStorage.On("DeleteMember", mocks.AccessGroupID, mocks.DbUserID).Return(true, nil).Once()
+	groupStorage.On("FindAllTargetsByMember", mocks.DbUserID, mocks.AccessGroupID).Return([]*entity.AccessGroupTarget{}, nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.QAInstanceId).Return([]*dto.AccessPermissionOutputDTO{
+		buildDirectPermission(mocks.QAInstanceId, "QA", mocks.DatabaseID),
+		buildPermission(mocks.QAInstanceId, "QA", otherDatabaseID),
+	}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{otherDatabaseID}, mocks.UserID).Return(nil).Once()
+
+	uc := NewRemoveAccessGroupMemberUseCase(groupStorage, accessStorage, revokeUC)
+	output, err := uc.Execute(mocks.AccessGroupID, mocks.DbUserID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{
+		{Item: "foobar # QA", Success: true, Message: "Access revoked to 1 databases no longer covered by an access group of the user"},
+	}, output.Revocations)
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenOnlyDirectGrantsToDatabasesOfTheGroup_WhenExecuteRemoveAccessGroupMember_ThenShouldKeepTheAccess(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{mocks.BuildAccessGroupTarget(mocks.QAInstanceId, mocks.DatabaseID)}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{buildMember()}, nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(targets, nil).Once()
+	groupStorage.On("DeleteMember", mocks.AccessGroupID, mocks.DbUserID).Return(true, nil).Once()
+	groupStorage.On("FindAllTargetsByMember", mocks.DbUserID, mocks.AccessGroupID).Return([]*entity.AccessGroupTarget{}, nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.QAInstanceId).
+		Return([]*dto.AccessPermissionOutputDTO{buildDirectPermission(mocks.QAInstanceId, "QA", mocks.DatabaseID)}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewRemoveAccessGroupMemberUseCase(groupStorage, accessStorage, revokeUC)
+	output, err := uc.Execute(mocks.AccessGroupID, mocks.DbUserID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{{Item: "foobar # QA", Success: true, Message: AccessKeptDirectGrantMsg}}, output.Revocations)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,116 @@
+package accessgroup
+
+import (
+	"log"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type UpdateAccessGroupTargetsUseCase struct {
+	AccessGroupStorage      storage.AccessGroupStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseStorage         storage.DatabaseStorage
+	GrantUseCase            common.GrantAccessPermissionUseCaseInterface
+	reconciler              *accessReconciler
+}
+
+func NewUpdateAccessGroupTargetsUseCase(
+	accessGroupStorage storage.AccessGroupStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	databaseStorage storage.DatabaseStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+	grantUseCase common.GrantAccessPermissionUseCaseInterface,
+	revokeUseCase common.RevokeAccessPermissionUseCaseInterface,
+) *UpdateAccessGroupTargetsUseCase {
+	return &UpdateAccessGroupTargetsUseCase{
+		AccessGroupStorage:      accessGroupStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		DatabaseStorage:         databaseStorage,
+		GrantUseCase:            grantUseCase,
+		reconciler: &accessReconciler{
+			GroupStorage:            accessGroupStorage,
+			AccessPermissionStorage: accessPermissionStorage,
+			RevokeUseCase:           revokeUseCase,
+		},
+	}
+}
+
+// Execute godoc
+/** Replaces the targets of the group and reconciles the access of every member: the targets added are granted to all
+members through the grant access use case, and the access given by the targets removed is revoked from each member,
+through the revoke access use case, unless still covered by the new targets, another group of the member or a direct
+grant. */
+func (uc *UpdateAccessGroupTargetsUseCase) Execute(groupID string, input dto.AccessGroupTargetsInputDTO, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error) {
+	group, err := findGroup(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	newTargets, err := buildTargets(uc.DatabaseInstanceStorage, uc.DatabaseStorage, groupID, input.InstancesData)
+	if err != nil {
+		return nil, err
+	}
+	currentTargets, err := uc.AccessGroupStorage.FindAllTargets(groupID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := uc.AccessGroupStorage.FindAllMemberDTOs(groupID)
+	if err != nil {
+		return nil, err
+	}
+	group.UpdatedAt = time.Now()
+	if err = uc.AccessGroupStorage.UpdateTargets(group, newTargets); err != nil {
+		log.Printf("Error updating the targets of access group %s. Cause: %v", groupID, err)
+		return nil, err
+	}
+
+	addedTargets := diffTargets(newTargets, currentTargets)
+	removedTargets := diffTargets(currentTargets, newTargets)
+	log.Printf("Targets of access group '%s' changed by user %s: %d added and %d removed, reconciling %d members",
+		group.Name, operationUserID, len(addedTargets), len(removedTargets), len(members))
+	membersIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		membersIDs = append(membersIDs, member.DatabaseUserID)
+	}
+	grantOutput := grant(uc.GrantUseCase, groupID, membersIDs, addedTargets, operationUserID)
+	var revocations []dto.ItemResultDTO
+	if len(removedTargets) > 0 {
+		for _, member := range members {
+			revocations = append(revocations, uc.reconciler.revokeUncovered(member, groupID, removedTargets, newTargets, operationUserID)...)
+		}
+	}
+
+	groupDTO, err := findGroupDTO(uc.AccessGroupStorage, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return buildChangeOutput(groupDTO, grantOutput, revocations), nil
+}
+
+// diffTargets godoc
+// Returns the targets that give access not covered by the other targets
+func diffTargets(targets, otherTargets []*entity.AccessGroupTarget) []*entity.AccessGroupTarget {
+	var diff []*entity.AccessGroupTarget
+	for _, target := range targets {
+		if target.DatabaseID.Valid && coversDatabase(otherTargets, target.DatabaseInstanceID, target.DatabaseID.String) {
+			continue
+		}
+		if !target.DatabaseID.Valid && hasTarget(otherTargets, target.Key()) {
+			continue
+		}
+		diff = append(diff, target)
+	}
+	return diff
+}
+
+func hasTarget(targets []*entity.AccessGroupTarget, key string) bool {
+	for _, target := range targets {
+		if target.Key() == key {
+			return true
+		}
+	}
+	return false
+}
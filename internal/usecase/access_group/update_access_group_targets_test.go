@@ -0,0 +1,86 @@
+package accessgroup
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenNewTargets_WhenExecuteUpdateAccessGroupTargets_ThenShouldGrantTheAddedAndRevokeTheRemoved(t *testing.T) {
+	currentTargets := []*entity.AccessGroupTarget{
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, ""),
+		mocks.BuildAccessGroupTarget(mocks.DatabaseInstanceId, mocks.DatabaseID),
+	}
+	groupStorage := new(mocks.AccessGroupStorageMock)
+	groupStorage.On("FindByID", mocks.AccessGroupID).Return(mocks.BuildAccessGroup(), nil).Once()
+	groupStorage.On("FindAllTargets", mocks.AccessGroupID).Return(currentTargets, nil).Once()
+	groupStorage.On("FindAllMemberDTOs", mocks.AccessGroupID).Return([]dto.AccessGroupMemberOutputDTO{buildMember()}, nil).Once()
+	groupStorage.On("UpdateTargets", mock.Anything, mock.Anything).Return(nil).Once()
+	groupStorage.On("FindAllTargetsByMember", mocks.DbUserID, mocks.AccessGroupID).Return([]*entity.AccessGroupTarget{}, nil).Once()
+	mockGroupDTOFetch(groupStorage)
+	instanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	instances := []*dto.DatabaseInstanceOutputDTO{{ID: mocks.DatabaseInstanceId, Name: "Production"}, {ID: thirdInstanceID, Name: "Staging"}}
+	instanceStorage.On("FindAllDTOs", "", "", []string{mocks.DatabaseInstanceId, thirdInstanceID}).Return(instances, nil).Once()
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAll", mocks.DatabaseInstanceId, []string{mocks.DatabaseID}).
+		Return([]*entity.Database{{ID: uuid.MustParse(mocks.DatabaseID), DatabaseInstanceID: mocks.DatabaseInstanceId}}, nil).Once()
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOs", "", mocks.DbUserID, mocks.QAInstanceId).
+		Return([]*dto.AccessPermissionOutputDTO{buildPermission(mocks.QAInstanceId, "QA", mocks.DatabaseID)}, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	expectedGrant := dto.GrantAccessInputDTO{
+		DatabaseUsersIDs: []string{mocks.DbUserID},
+		InstancesData:    []dto.InstanceDataDTO{{DatabaseInstanceID: thirdInstanceID}},
+		AccessGroupID:    mocks.AccessGroupID,
+	}
+	grantUC.On("Execute", expectedGrant, mocks.UserID).Return(&dto.GrantAccessOutputDTO{Message: "granted"}, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(common.ErrNoAccessibleInstancesFound).Once()
+
+	uc := NewUpdateAccessGroupTargetsUseCase(groupStorage, instanceStorage, databaseStorage, accessStorage, grantUC, revokeUC)
+	input := dto.AccessGroupTargetsInputDTO{InstancesData: []dto.InstanceDataDTO{
+		{DatabaseInstanceID: mocks.DatabaseInstanceId, DatabasesIDs: []string{mocks.DatabaseID}},
+		{DatabaseInstanceID: thirdInstanceID},
+	}}
+	output, err := uc.Execute(mocks.AccessGroupID, input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{{Item: "foobar # QA", Success: true, Message: AccessAlreadyRevokedMsg}}, output.Revocations)
+	grantUC.AssertExpectations(t)
+	revokeUC.AssertExpectations(t)
+	groupStorage.AssertExpectations(t)
+}
+
+func TestGivenTargetsOfAllAndSomeDatabases_WhenDiffTargets_ThenShouldIgnoreTheCoveredOnes(t *testing.T) {
+	allDatabases := mocks.BuildAccessGroupTarget(mocks.QAInstanceId, "")
+	oneDatabase := mocks.BuildAccessGroupTarget(mocks.QAInstanceId, mocks.DatabaseID)
+	otherInstance := mocks.BuildAccessGroupTarget(mocks.DatabaseInstanceId, mocks.DatabaseID)
+
+	assert.Empty(t, diffTargets([]*entity.AccessGroupTarget{oneDatabase}, []*entity.AccessGroupTarget{allDatabases}))
+	assert.Equal(t, []*entity.AccessGroupTarget{allDatabases}, diffTargets([]*entity.AccessGroupTarget{allDatabases}, []*entity.AccessGroupTarget{oneDatabase}))
+	assert.Equal(t, []*entity.AccessGroupTarget{otherInstance}, diffTargets([]*entity.AccessGroupTarget{oneDatabase, otherInstance}, []*entity.AccessGroupTarget{allDatabases}))
+}
+
+func TestGivenTargetsOfTheSameInstance_WhenToInstancesData_ThenShouldPreferAllDatabases(t *testing.T) {
+	targets := []*entity.AccessGroupTarget{
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, mocks.DatabaseID),
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, ""),
+		mocks.BuildAccessGroupTarget(mocks.QAInstanceId, otherDatabaseID),
+		mocks.BuildAccessGroupTarget(mocks.DatabaseInstanceId, mocks.DatabaseID),
+	}
+
+	instancesData := toInstancesData(targets)
+
+	assert.Equal(t, []dto.InstanceDataDTO{
+		{DatabaseInstanceID: mocks.QAInstanceId},
+		{DatabaseInstanceID: mocks.DatabaseInstanceId, DatabasesIDs: []string{mocks.DatabaseID}},
+	}, instancesData)
+}
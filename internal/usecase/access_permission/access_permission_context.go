@@ -2,21 +2,33 @@ package accesspermission
 
 import (
 	"errors"
+	"sync/atomic"
+	"time"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/pkg/executor"
 )
 
 var (
-	ErrInstanceDisabled         = errors.New("instance is disabled")
-	ErrRolesNotCreated          = errors.New("roles not created yet in instance")
-	ErrUserDisabled             = errors.New("user is disabled")
-	ErrDatabaseDisabled         = errors.New("database is disabled")
-	ErrRolesNotConfigured       = errors.New("roles not configured yet in database")
-	ErrDatabaseForbidden        = errors.New("database access is forbidden")
-	ErrInvalidRole              = errors.New("invalid role defined for user")
-	ErrUserAlreadyHasPermission = errors.New("user already has access permission")
+	ErrInstanceDisabled          = errors.New("instance is disabled")
+	ErrRolesNotCreated           = errors.New("roles not created yet in instance")
+	ErrUserDisabled              = errors.New("user is disabled")
+	ErrDatabaseDisabled          = errors.New("database is disabled")
+	ErrRolesNotConfigured        = errors.New("roles not configured yet in database")
+	ErrDatabaseForbidden         = errors.New("database access is forbidden")
+	ErrInvalidRole               = errors.New("invalid role defined for user")
+	ErrUserAlreadyHasPermission  = errors.New("user already has access permission")
+	ErrAccessApprovalRequired    = errors.New("the access to instances of ecosystems that require approval must be requested")
+	ErrDatabaseRoleNotFound      = errors.New("database role not found")
+	ErrApplicationRoleNotAllowed = errors.New("the application role can't be given per database, it must be the own role of the user")
+	ErrDatabaseRoleCoveredByOwn  = errors.New("the role given per database has no privilege beyond the own role of the user, whose membership applies to the whole instance, so it would change nothing")
+	ErrCouldNotRevokeDatabases   = errors.New("could not revoke the access to all the databases")
+	// errPermissionUpdated is returned by the validation of a database the user already has access to, when the grant
+	// only changed the expiration or the origin of the permission
+	errPermissionUpdated = errors.New("the access permission was updated")
 )
 
 const (
@@ -37,42 +49,92 @@ const (
 	ErrDeletingAccessOfUserMsg      = "failed to delete all access for user '%s' in instance '%s'. Details: %s"
 	ErrDatabaseForbiddenMsg         = "the database '%s' is blacklisted, therefore access is blocked for user '%s'"
 	UserAccessRevokedAndExcludedMsg = "the user '%s' has had their access revoked and was successfully removed from instance '%s'"
+	ErrRevokeConnectFailedMsg       = "failed to revoke the access of user '%s' to the database '%s' of instance '%s'. Details: %s"
+	ErrDeletingDatabaseAccessMsg    = "failed to delete the access of user '%s' to the database '%s' of instance '%s'. Details: %s"
+	DatabaseAccessRevokedMsg        = "the access of user '%s' to the database '%s' of instance '%s' was revoked"
+	PermissionExpirationUpdatedMsg  = "the expiration of the access permission of user '%s' on database '%s' of instance '%s' was changed to %s"
+	PermissionGrantedDirectlyMsg    = "the access permission of user '%s' on database '%s' of instance '%s' is now granted directly, no longer by an access group"
 	UserCreatedMsg                  = "the user '%s' was successfully created in instance '%s'"
 	PermissionGrantedMsg            = "access permission granted to user '%s' on database '%s' of instance '%s'"
+	PermissionGrantedUntilMsg       = "access permission granted to user '%s' on database '%s' of instance '%s' until %s"
+	WithDatabaseRoleMsg             = " with role '%s'"
+	WithScopeMsg                    = " restricted to %s"
+	ErrRegrantUnscopedFailedMsg     = "failed to grant again the databases without scope of user '%s' in instance '%s', required before the first scoped access. Details: %s"
+	UserDatabasesProcessedMsg       = "%d databases processed successfully"
+	UserDatabasesFailedMsg          = "%d of %d databases failed, check the access permission logs for details"
 )
 
 type globalContextOnGrant struct {
-	DBUsers            []*dto.DatabaseUserOutputDTO
-	DBIdsByInstance    map[string][]string
-	OperationUserID    string
+	DBUsers         []*dto.DatabaseUserOutputDTO
+	DBIdsByInstance map[string][]string
+	// RolesByDatabase is the role informed for the users in some databases, by database id
+	RolesByDatabase map[string]*entity.DatabaseRole
+	// ScopesByDatabase is the allowlist of schemas and tables informed for the users in some databases, by database id
+	ScopesByDatabase map[string]entity.AccessScope
+	// Roles are all the roles, to resolve the own role of the users, loaded only when roles or scopes are informed
+	Roles []*entity.DatabaseRole
+	// ScopedInstancesByUser are the instances where each user already has any scoped permission, by user id
+	ScopedInstancesByUser map[string][]string
+	OperationUserID       string
+	ExpiresAt             *time.Time
+	// AccessGroupID is the access group granting its targets, kept in the permissions. It's empty for a direct grant.
+	AccessGroupID      string
 	ForbiddenDatabases map[string]bool
 	GlobalErrChan      chan error
 	InstancesQty       int
 	UsersQty           int
+	Batch              *executor.Batch
+	Progress           common.ProgressReporter
+	// Recorders collect the statements of each instance in dry run, by instance id. It's nil when not in dry run.
+	Recorders map[string]*connector.StatementRecorder
 }
 
 func newGrantAccessGlobalContext(
 	dbUsers []*dto.DatabaseUserOutputDTO,
 	databaseIdsByInstance map[string][]string,
+	rolesByDatabase map[string]*entity.DatabaseRole,
+	scopesByDatabase map[string]entity.AccessScope,
+	roles []*entity.DatabaseRole,
+	scopedInstancesByUser map[string][]string,
 	operationUserID string,
+	expiresAt *time.Time,
 	forbiddenDatabases map[string]bool,
-	instancesQty, usersQty int) *globalContextOnGrant {
+	instancesQty, usersQty int,
+	batch *executor.Batch,
+	progress common.ProgressReporter,
+	recorders map[string]*connector.StatementRecorder) *globalContextOnGrant {
 	bufferSize := instancesQty * usersQty
 	return &globalContextOnGrant{
-		DBUsers:            dbUsers,
-		DBIdsByInstance:    databaseIdsByInstance,
-		OperationUserID:    operationUserID,
-		ForbiddenDatabases: forbiddenDatabases,
-		GlobalErrChan:      make(chan error, bufferSize),
-		InstancesQty:       instancesQty,
-		UsersQty:           usersQty,
+		DBUsers:               dbUsers,
+		DBIdsByInstance:       databaseIdsByInstance,
+		RolesByDatabase:       rolesByDatabase,
+		ScopesByDatabase:      scopesByDatabase,
+		Roles:                 roles,
+		ScopedInstancesByUser: scopedInstancesByUser,
+		OperationUserID:       operationUserID,
+		ExpiresAt:             expiresAt,
+		ForbiddenDatabases:    forbiddenDatabases,
+		GlobalErrChan:         make(chan error, bufferSize),
+		InstancesQty:          instancesQty,
+		UsersQty:              usersQty,
+		Batch:                 batch,
+		Progress:              progress,
+		Recorders:             recorders,
 	}
 }
 
+// DryRun godoc
+// Tells if the grant only records the statements it would execute, without persisting anything
+func (g *globalContextOnGrant) DryRun() bool {
+	return g.Recorders != nil
+}
+
 type instanceContextOnGrant struct {
 	GlobalCtx     *globalContextOnGrant
 	Instance      *dto.DatabaseInstanceOutputDTO
 	InstanceIndex int
+	// Recorder collects the statements of the instance in dry run, nil otherwise
+	Recorder *connector.StatementRecorder
 }
 
 func newGrantAccessInstanceContext(
@@ -83,6 +145,7 @@ func newGrantAccessInstanceContext(
 		GlobalCtx:     globalCtx,
 		Instance:      instanceDTO,
 		InstanceIndex: instanceIndex,
+		Recorder:      globalCtx.Recorders[instanceDTO.ID],
 	}
 }
 
@@ -92,6 +155,12 @@ type userContextOnGrant struct {
 	DBUser          *dto.DatabaseUserOutputDTO
 	UserIndex       int
 	OperationUserID string
+	// Scoped is set when the user has or is receiving any scoped permission in the instance, so its databases without
+	// scope are granted with the role in each database instead of the membership of the whole instance
+	Scoped bool
+	// Databases of the user still being processed and the ones that failed, to report the user once all of them finish
+	PendingDatabases atomic.Int32
+	FailedDatabases  atomic.Int32
 }
 
 func newGrantAccessUserContext(
@@ -129,21 +198,76 @@ func newGrantAccessDatabaseContext(
 	}
 }
 
+// DatabaseRole godoc
+// Returns the role informed for the user in the database, or nil when the user has its own role in it
+func (d *databaseContextOnGrant) DatabaseRole() *entity.DatabaseRole {
+	role := d.UserCtx.InstanceCtx.GlobalCtx.RolesByDatabase[d.Database.ID.String()]
+	if role == nil || role.ID.String() == d.UserCtx.DBUser.DatabaseRoleID {
+		return nil
+	}
+	return role
+}
+
+// Scope godoc
+// Returns the allowlist of schemas and tables informed for the user in the database, empty for the whole database
+func (d *databaseContextOnGrant) Scope() entity.AccessScope {
+	return d.UserCtx.InstanceCtx.GlobalCtx.ScopesByDatabase[d.Database.ID.String()]
+}
+
+// EffectiveRole godoc
+// Returns the role the user has in the database, the informed one or its own role. It's nil when the roles were not
+// loaded, which only happens when no role nor scope is informed.
+func (d *databaseContextOnGrant) EffectiveRole() *entity.DatabaseRole {
+	if role := d.DatabaseRole(); role != nil {
+		return role
+	}
+	return findRoleByID(d.UserCtx.InstanceCtx.GlobalCtx.Roles, d.UserCtx.DBUser.DatabaseRoleID)
+}
+
+func findRoleByID(roles []*entity.DatabaseRole, roleID string) *entity.DatabaseRole {
+	for _, role := range roles {
+		if role.ID.String() == roleID {
+			return role
+		}
+	}
+	return nil
+}
+
+// RoleName godoc
+// Returns the name of the role the user has in the database
+func (d *databaseContextOnGrant) RoleName() string {
+	if role := d.DatabaseRole(); role != nil {
+		return string(role.Name)
+	}
+	return d.UserCtx.DBUser.DatabaseRoleName
+}
+
 type revokeAccessContext struct {
 	Instance        *dto.DatabaseInstanceOutputDTO
 	User            *entity.DatabaseUser
 	InstancesQty    int
 	InstanceIndex   int
 	OperationUserID string
+	Progress        common.ProgressReporter
+	// Recorder collects the statements of the instance in dry run, nil otherwise
+	Recorder *connector.StatementRecorder
 }
 
-func newRevokeAccessContext(instance *dto.DatabaseInstanceOutputDTO, user *entity.DatabaseUser, instancesQty, instanceIndex int, operationUserID string) *revokeAccessContext {
+func newRevokeAccessContext(
+	instance *dto.DatabaseInstanceOutputDTO,
+	user *entity.DatabaseUser,
+	instancesQty, instanceIndex int,
+	operationUserID string,
+	progress common.ProgressReporter,
+	recorder *connector.StatementRecorder) *revokeAccessContext {
 	return &revokeAccessContext{
 		Instance:        instance,
 		User:            user,
 		InstancesQty:    instancesQty,
 		InstanceIndex:   instanceIndex,
 		OperationUserID: operationUserID,
+		Progress:        progress,
+		Recorder:        recorder,
 	}
 }
 
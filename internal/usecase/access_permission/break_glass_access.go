@@ -0,0 +1,349 @@
+package accesspermission
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+)
+
+const (
+	BreakGlassAccessGrantedMsg         = "Break-glass access granted successfully."
+	NotificationBreakGlassGranted      = "BREAK_GLASS_GRANTED"
+	NotificationBreakGlassRevoked      = "BREAK_GLASS_REVOKED"
+	BreakGlassGrantedMsg               = "break-glass access granted to user '%s' with role '%s' on database '%s' of instance '%s' until %s. Incident: %s"
+	BreakGlassRevokedMsg               = "break-glass access of user '%s' to database '%s' of instance '%s' revoked, its window closed. Incident: %s"
+	ErrInstanceNotFoundMsg             = "the instance '%s' was not found"
+	ErrDatabaseNotFoundInInstanceMsg   = "the database '%s' was not found in instance '%s'"
+	ErrGrantBreakGlassRoleFailedMsg    = "failed to grant role '%s' to user '%s' on database '%s' of instance '%s'. Details: %s"
+	ErrRevokeBreakGlassRoleFailedMsg   = "failed to revoke role '%s' from user '%s' on database '%s' of instance '%s'. Details: %s"
+	ErrBreakGlassNotGrantedAnywhereMsg = "The break-glass access could not be granted. Check the access permission logs for details."
+)
+
+type BreakGlassAccessUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	BreakGlassAccessStorage storage.BreakGlassAccessStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseStorage         storage.DatabaseStorage
+	ForbiddenObjectsStorage storage.ForbiddenObjectsStorage
+	ApplicationUserStorage  storage.ApplicationUserStorage
+	Notifier                notifier.Notifier
+	Window                  time.Duration
+}
+
+func NewBreakGlassAccessUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	breakGlassAccessStorage storage.BreakGlassAccessStorage,
+	databaseUserStorage storage.DatabaseUserStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	databaseStorage storage.DatabaseStorage,
+	forbiddenObjectsStorage storage.ForbiddenObjectsStorage,
+	applicationUserStorage storage.ApplicationUserStorage,
+	notifier notifier.Notifier,
+	window time.Duration,
+) *BreakGlassAccessUseCase {
+	return &BreakGlassAccessUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		BreakGlassAccessStorage: breakGlassAccessStorage,
+		DatabaseUserStorage:     databaseUserStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		DatabaseStorage:         databaseStorage,
+		ForbiddenObjectsStorage: forbiddenObjectsStorage,
+		ApplicationUserStorage:  applicationUserStorage,
+		Notifier:                notifier,
+		Window:                  window,
+	}
+}
+
+type breakGlassContext struct {
+	DBUser             *dto.DatabaseUserOutputDTO
+	OperationUserID    string
+	IncidentReference  string
+	ExpiresAt          time.Time
+	ForbiddenDatabases map[string]bool
+}
+
+// Execute godoc
+/** Grants to the user the entity.BreakGlassRole in the selected databases for the window of the use case, without
+approval, even in the instances of ecosystems that require it. The user is created in the instances where it doesn't
+exist yet, and receives access to the databases it can't access yet, with access permissions that expire with the window.
+Every grant is logged with the break-glass type, and the access granted is notified once the process ends.
+The role is revoked by RevokeExpiredBreakGlassAccessUseCase when the window closes, while the access permissions are
+revoked by RevokeExpiredAccessPermissionsUseCase like any other expired permission.
+Like the grant of access, an error in a database is logged and the process continues with the others. */
+func (uc *BreakGlassAccessUseCase) Execute(input dto.BreakGlassAccessInputDTO, operationUserID string) (*dto.GrantBreakGlassAccessOutputDTO, error) {
+	dbUser, err := uc.fetchDatabaseUser(input.DatabaseUserID)
+	if err != nil {
+		return nil, err
+	}
+	operationUser, err := uc.ApplicationUserStorage.FindByID(operationUserID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the operation user. Cause: %w", err)
+	}
+	instancesIDs := make([]string, 0, len(input.InstancesData))
+	for _, instanceData := range input.InstancesData {
+		instancesIDs = append(instancesIDs, instanceData.DatabaseInstanceID)
+	}
+	dbInstances, err := uc.DatabaseInstanceStorage.FindAllDTOs("", "", instancesIDs)
+	if err != nil {
+		return nil, err
+	}
+	forbiddenDatabases, err := uc.ForbiddenObjectsStorage.FindAllDatabases()
+	if err != nil {
+		return nil, fmt.Errorf("error when fetching forbidden databases. Cause: %v", err)
+	}
+	bgCtx := &breakGlassContext{
+		DBUser:             dbUser,
+		OperationUserID:    operationUserID,
+		IncidentReference:  strings.TrimSpace(input.IncidentReference),
+		ExpiresAt:          time.Now().Add(uc.Window),
+		ForbiddenDatabases: make(map[string]bool, len(forbiddenDatabases)),
+	}
+	for _, forbiddenDatabase := range forbiddenDatabases {
+		bgCtx.ForbiddenDatabases[forbiddenDatabase.Name] = true
+	}
+
+	log.Printf("Granting break-glass access to user '%s' in %d instances until %s. Incident: %s. Requester: %s",
+		dbUser.Username, len(input.InstancesData), bgCtx.ExpiresAt.Format(time.RFC3339), bgCtx.IncidentReference, operationUser.Email)
+	// Each instance writes only its own position, that is read after the batch finishes
+	resultsByInstance := make([][]dto.ItemResultDTO, len(input.InstancesData))
+	batch := config.GetExecutor().NewBatch("break-glass access")
+	for idx, instanceData := range input.InstancesData {
+		instanceIdx := slices.IndexFunc(dbInstances, func(instance *dto.DatabaseInstanceOutputDTO) bool {
+			return instance.ID == instanceData.DatabaseInstanceID
+		})
+		if instanceIdx < 0 {
+			resultsByInstance[idx] = []dto.ItemResultDTO{{Item: instanceData.DatabaseInstanceID, Message: fmt.Sprintf(ErrInstanceNotFoundMsg, instanceData.DatabaseInstanceID)}}
+			continue
+		}
+		batch.Go(instanceData.DatabaseInstanceID, func(time.Duration) {
+			resultsByInstance[idx] = uc.processInstance(bgCtx, dbInstances[instanceIdx], instanceData.DatabasesIDs)
+		})
+	}
+	batch.Wait()
+
+	output := &dto.GrantBreakGlassAccessOutputDTO{Message: BreakGlassAccessGrantedMsg, ExpiresAt: bgCtx.ExpiresAt}
+	var granted []string
+	for _, results := range resultsByInstance {
+		for _, result := range results {
+			output.Databases = append(output.Databases, result)
+			if result.Success {
+				granted = append(granted, result.Item)
+			} else {
+				output.HasErrors = true
+				output.Message = SomeErrorsDuringProcessMsg
+			}
+		}
+	}
+	if len(granted) == 0 {
+		output.Message = ErrBreakGlassNotGrantedAnywhereMsg
+		return output, nil
+	}
+	uc.notifyGranted(bgCtx, operationUser, granted)
+	return output, nil
+}
+
+func (uc *BreakGlassAccessUseCase) fetchDatabaseUser(databaseUserID string) (*dto.DatabaseUserOutputDTO, error) {
+	dbUsers, err := uc.DatabaseUserStorage.FindAllDTOs([]string{databaseUserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(dbUsers) == 0 {
+		return nil, common.ErrDatabaseUserNotFound
+	}
+	dbUser := dbUsers[0]
+	if !dbUser.Enabled {
+		return nil, ErrUserDisabled
+	}
+	if !entity.ValidateRoleName(dbUser.DatabaseRoleName) {
+		return nil, ErrInvalidRole
+	}
+	return dbUser, nil
+}
+
+// processInstance godoc
+// Returns the result of each database selected in the instance, or a single result for the instance when none of its
+// databases can be processed
+func (uc *BreakGlassAccessUseCase) processInstance(bgCtx *breakGlassContext, instance *dto.DatabaseInstanceOutputDTO, databasesIDs []string) []dto.ItemResultDTO {
+	instanceFailed := func(msg string) []dto.ItemResultDTO {
+		uc.newLog(instance.ID, bgCtx.DBUser.ID, "", bgCtx.OperationUserID, msg, false)
+		return []dto.ItemResultDTO{{Item: instance.Name, Message: msg}}
+	}
+	if !instance.Enabled {
+		return instanceFailed(fmt.Sprintf(ErrInstanceDisabledMsg, instance.Name))
+	}
+	if !instance.RolesCreated {
+		return instanceFailed(fmt.Sprintf(ErrRolesNotCreatedMsg, instance.Name))
+	}
+	databases, err := uc.DatabaseStorage.FindAll(instance.ID, databasesIDs)
+	if err != nil {
+		return instanceFailed(fmt.Sprintf(ErrFetchingDatabasesMsg, instance.Name, err.Error()))
+	}
+	if len(databases) == 0 {
+		return instanceFailed(fmt.Sprintf(ErrNoDatabasesFoundMsg, instance.Name))
+	}
+	targetInstance, err := connector.NewDatabaseConnector(instance, "")
+	if err != nil {
+		return instanceFailed(fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error()))
+	}
+	if errMsg := uc.createUserIfNotExists(bgCtx, instance, targetInstance); errMsg != "" {
+		return instanceFailed(errMsg)
+	}
+
+	results := make([]dto.ItemResultDTO, 0, len(databasesIDs))
+	for _, databaseID := range databasesIDs {
+		databaseIdx := slices.IndexFunc(databases, func(database *entity.Database) bool {
+			return database.ID.String() == databaseID
+		})
+		if databaseIdx < 0 {
+			results = append(results, dto.ItemResultDTO{Item: fmt.Sprintf("%s # %s", instance.Name, databaseID), Message: fmt.Sprintf(ErrDatabaseNotFoundInInstanceMsg, databaseID, instance.Name)})
+			continue
+		}
+		database := databases[databaseIdx]
+		result := dto.ItemResultDTO{Item: fmt.Sprintf("%s # %s", instance.Name, database.Name), Success: true}
+		if err := uc.processDatabase(bgCtx, instance, database); err != nil {
+			result.Success = false
+			result.Message = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// createUserIfNotExists godoc
+// Returns the message of the failure, or an empty message when the user exists or was created
+func (uc *BreakGlassAccessUseCase) createUserIfNotExists(bgCtx *breakGlassContext, instance *dto.DatabaseInstanceOutputDTO, targetInstance connector.DatabaseTCPConnectorInterface) string {
+	dbUser := bgCtx.DBUser
+	userExists, err := targetInstance.UserExists(dbUser.Username)
+	if err != nil {
+		return fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, err.Error())
+	}
+	if userExists {
+		return ""
+	}
+	decryptedPwd, err := config.GetCryptoHelper().Decrypt(dbUser.Password)
+	if err != nil {
+		return fmt.Sprintf(ErrInvalidUserMsg, dbUser.Username, err.Error())
+	}
+	err = targetInstance.CreateUser(&connector.DatabaseUser{Username: dbUser.Username, Password: decryptedPwd, Role: dbUser.DatabaseRoleName})
+	if err != nil {
+		return fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, err.Error())
+	}
+	uc.newLog(instance.ID, dbUser.ID, "", bgCtx.OperationUserID, fmt.Sprintf(UserCreatedMsg, dbUser.Username, instance.Name), true)
+	return ""
+}
+
+func (uc *BreakGlassAccessUseCase) processDatabase(bgCtx *breakGlassContext, instance *dto.DatabaseInstanceOutputDTO, database *entity.Database) error {
+	dbUser := bgCtx.DBUser
+	databaseID := database.ID.String()
+	databaseFailed := func(msg string, err error) error {
+		uc.newLog(instance.ID, dbUser.ID, databaseID, bgCtx.OperationUserID, msg, false)
+		return err
+	}
+	if bgCtx.ForbiddenDatabases[database.Name] && !entity.CheckRoleApplication(dbUser.DatabaseRoleName) {
+		return databaseFailed(fmt.Sprintf(ErrDatabaseForbiddenMsg, database.Name, dbUser.Username), ErrDatabaseForbidden)
+	}
+	if !database.Enabled {
+		return databaseFailed(fmt.Sprintf(ErrDatabaseDisabledMsg, database.Name, instance.Name), ErrDatabaseDisabled)
+	}
+	if !database.RolesConfigured {
+		return databaseFailed(fmt.Sprintf(ErrRolesNotConfiguredMsg, database.Name, instance.Name), ErrRolesNotConfigured)
+	}
+	hasAccess, err := uc.AccessPermissionStorage.Exists(databaseID, dbUser.ID)
+	if err != nil {
+		return err
+	}
+	targetDatabase, err := connector.NewDatabaseConnector(instance, database.Name)
+	if err != nil {
+		return databaseFailed(fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error()), err)
+	}
+	if !hasAccess {
+		if err := targetDatabase.GrantConnect(dbUser.Username); err != nil {
+			return databaseFailed(fmt.Sprintf(ErrGrantConnectFailedMsg, dbUser.Username, database.Name, instance.Name, err.Error()), err)
+		}
+		accessPermission, err := entity.NewAccessPermission(databaseID, dbUser.ID, bgCtx.OperationUserID, &bgCtx.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		if err := uc.AccessPermissionStorage.Save(accessPermission); err != nil {
+			return err
+		}
+	}
+
+	// The access is saved before the role is granted, so a role granted is always revoked when the window closes
+	breakGlassRole := string(entity.BreakGlassRole)
+	roleGranted := dbUser.DatabaseRoleName != breakGlassRole
+	breakGlassAccess, err := entity.NewBreakGlassAccess(databaseID, dbUser.ID, bgCtx.IncidentReference, roleGranted, bgCtx.OperationUserID, bgCtx.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	if err := uc.BreakGlassAccessStorage.Save(breakGlassAccess); err != nil {
+		return err
+	}
+	if roleGranted {
+		if err := targetDatabase.GrantRole(dbUser.Username, breakGlassRole); err != nil {
+			uc.closeBreakGlassAccess(breakGlassAccess)
+			return databaseFailed(fmt.Sprintf(ErrGrantBreakGlassRoleFailedMsg, breakGlassRole, dbUser.Username, database.Name, instance.Name, err.Error()), err)
+		}
+	}
+	msg := fmt.Sprintf(BreakGlassGrantedMsg, dbUser.Username, breakGlassRole, database.Name, instance.Name, bgCtx.ExpiresAt.Format(time.RFC3339), bgCtx.IncidentReference)
+	log.Print(msg)
+	uc.newLog(instance.ID, dbUser.ID, databaseID, bgCtx.OperationUserID, msg, true)
+	return nil
+}
+
+// closeBreakGlassAccess godoc
+// Closes the access whose role could not be granted, so it's not listed as open nor revoked when the window closes.
+// When it can't be closed, the revocation of a role not granted is harmless.
+func (uc *BreakGlassAccessUseCase) closeBreakGlassAccess(breakGlassAccess *entity.BreakGlassAccess) {
+	if err := uc.BreakGlassAccessStorage.UpdateRevokedAt(breakGlassAccess.ID.String(), time.Now()); err != nil {
+		log.Printf("ERROR: could not close the break-glass access %s whose role was not granted. Cause: %v", breakGlassAccess.ID, err)
+	}
+}
+
+func (uc *BreakGlassAccessUseCase) notifyGranted(bgCtx *breakGlassContext, operationUser *entity.ApplicationUser, granted []string) {
+	err := uc.Notifier.Notify(notifier.Notification{
+		Event:   NotificationBreakGlassGranted,
+		Subject: fmt.Sprintf("Break-glass access granted to %s", bgCtx.DBUser.Username),
+		Message: fmt.Sprintf("%s granted the role %s to %s on %d databases without approval, until %s. Incident: %s",
+			operationUser.Email, entity.BreakGlassRole, bgCtx.DBUser.Username, len(granted), bgCtx.ExpiresAt.Format(time.RFC3339), bgCtx.IncidentReference),
+		Fields: map[string]string{
+			"databaseUser":      bgCtx.DBUser.Username,
+			"grantedBy":         operationUser.Email,
+			"incidentReference": bgCtx.IncidentReference,
+			"expiresAt":         bgCtx.ExpiresAt.Format(time.RFC3339),
+			"databases":         strings.Join(granted, ", "),
+		},
+		Time: time.Now(),
+	})
+	if err != nil {
+		log.Printf("ERROR: could not notify the break-glass access granted to user '%s'. Cause: %v", bgCtx.DBUser.Username, err)
+	}
+}
+
+// newLog godoc
+// Saves a log of the break-glass type. The access was already changed in the instance, so a failure saving the log is
+// only reported in the application log.
+func (uc *BreakGlassAccessUseCase) newLog(instanceID, dbUserID, databaseID, operationUserID, message string, success bool) {
+	saveBreakGlassLog(uc.AccessPermissionStorage, instanceID, dbUserID, databaseID, operationUserID, message, success)
+}
+
+func saveBreakGlassLog(accessStorage storage.AccessPermissionStorage, instanceID, dbUserID, databaseID, operationUserID, message string, success bool) {
+	breakGlassLog, err := entity.NewAccessPermissionLogOfType(entity.AccessPermissionLogTypeBreakGlass, instanceID, dbUserID, databaseID, message, operationUserID, success)
+	if err == nil {
+		err = accessStorage.SaveLog(breakGlassLog)
+	}
+	if err != nil {
+		log.Printf("Error when saving break-glass log for instance %s and database user %s. Cause: %v", instanceID, dbUserID, err)
+	}
+}
@@ -0,0 +1,203 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const breakGlassWindow = time.Hour
+
+func buildBreakGlassInput(instanceID string) dto.BreakGlassAccessInputDTO {
+	return dto.BreakGlassAccessInputDTO{
+		DatabaseUserID:    mocks.DbUserID,
+		InstancesData:     []dto.InstanceDataDTO{{DatabaseInstanceID: instanceID, DatabasesIDs: []string{mocks.DatabaseID}}},
+		IncidentReference: " INC-1234 ",
+	}
+}
+
+func TestGivenAnUnknownUser_WhenExecuteBreakGlassAccess_ThenShouldReturnError(t *testing.T) {
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{}, nil).Once()
+
+	uc := NewBreakGlassAccessUseCase(nil, nil, dbUserStorage, nil, nil, nil, nil, nil, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(mocks.DatabaseInstanceId), mocks.UserID)
+
+	assert.ErrorIs(t, err, common.ErrDatabaseUserNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenADisabledUser_WhenExecuteBreakGlassAccess_ThenShouldReturnError(t *testing.T) {
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{mocks.BuildDisabledDbUserJohnDTO()}, nil).Once()
+
+	uc := NewBreakGlassAccessUseCase(nil, nil, dbUserStorage, nil, nil, nil, nil, nil, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(mocks.DatabaseInstanceId), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrUserDisabled)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnErrorInDbWhenFetchingInstances_WhenExecuteBreakGlassAccess_ThenShouldReturnError(t *testing.T) {
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{mocks.BuildDbUserJohnDTO()}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", mocks.UserID).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{mocks.DatabaseInstanceId}).Return([]*dto.DatabaseInstanceOutputDTO{}, sql.ErrConnDone).Once()
+
+	uc := NewBreakGlassAccessUseCase(nil, nil, dbUserStorage, dbInstanceStorage, nil, nil, userStorage, nil, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(mocks.DatabaseInstanceId), mocks.UserID)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, output)
+}
+
+func TestGivenAUserWithoutAccess_WhenExecuteBreakGlassAccess_ThenShouldGrantAccessAndRoleUntilTheWindowCloses(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	dbUser.DatabaseRoleName = string(entity.UserRO)
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", mocks.UserID).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{mocks.DatabaseID}).Return([]*entity.Database{database}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("Exists", mocks.DatabaseID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("Save", mock.MatchedBy(func(a *entity.AccessPermission) bool {
+		return a.ExpiresAt.Valid && a.DatabaseUserID == dbUser.ID
+	})).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool {
+		return l.Type == entity.AccessPermissionLogTypeBreakGlass && l.Success
+	})).Return(nil).Once()
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("Save", mock.MatchedBy(func(b *entity.BreakGlassAccess) bool {
+		return b.RoleGranted && b.IncidentReference == "INC-1234" && b.DatabaseID == mocks.DatabaseID
+	})).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+	notifierMock.On("Notify", mock.MatchedBy(func(n notifier.Notification) bool {
+		return n.Event == NotificationBreakGlassGranted && n.Fields["incidentReference"] == "INC-1234"
+	})).Return(nil).Once()
+
+	uc := NewBreakGlassAccessUseCase(accessPermissionStorage, breakGlassStorage, dbUserStorage, dbInstanceStorage, dbStorage,
+		forbiddenObjStorage, userStorage, notifierMock, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(instance.ID), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, BreakGlassAccessGrantedMsg, output.Message)
+	assert.WithinDuration(t, time.Now().Add(breakGlassWindow), output.ExpiresAt, time.Minute)
+	assert.Equal(t, []dto.ItemResultDTO{{Item: instance.Name + " # " + database.Name, Success: true}}, output.Databases)
+	accessPermissionStorage.AssertExpectations(t)
+	breakGlassStorage.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestGivenAUserAlreadyWithTheBreakGlassRole_WhenExecuteBreakGlassAccess_ThenShouldNotGrantTheRoleAgain(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", mocks.UserID).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{mocks.DatabaseID}).Return([]*entity.Database{mocks.BuildSettingsDatabase()}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("Exists", mocks.DatabaseID, dbUser.ID).Return(true, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("Save", mock.MatchedBy(func(b *entity.BreakGlassAccess) bool { return !b.RoleGranted })).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+	notifierMock.On("Notify", mock.Anything).Return(nil).Once()
+
+	uc := NewBreakGlassAccessUseCase(accessPermissionStorage, breakGlassStorage, dbUserStorage, dbInstanceStorage, dbStorage,
+		forbiddenObjStorage, userStorage, notifierMock, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(instance.ID), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+	breakGlassStorage.AssertExpectations(t)
+}
+
+func TestGivenAnErrorConnectingToInstance_WhenExecuteBreakGlassAccess_ThenShouldReturnOutputErrorWithoutNotifying(t *testing.T) {
+	instance := mocks.BuildDummyErrorInstance()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{mocks.BuildDbUserJohnDTO()}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", mocks.UserID).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{mocks.DatabaseID}).Return([]*entity.Database{mocks.BuildSettingsDatabase()}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool {
+		return l.Type == entity.AccessPermissionLogTypeBreakGlass && !l.Success
+	})).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+
+	uc := NewBreakGlassAccessUseCase(accessPermissionStorage, nil, dbUserStorage, dbInstanceStorage, dbStorage,
+		forbiddenObjStorage, userStorage, notifierMock, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(instance.ID), mocks.UserID)
+
+	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, ErrBreakGlassNotGrantedAnywhereMsg, output.Message)
+	accessPermissionStorage.AssertExpectations(t)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestGivenAnErrorWhenGrantingTheRole_WhenExecuteBreakGlassAccess_ThenShouldCloseTheAccessSavedBeforeTheGrant(t *testing.T) {
+	dbUser := mocks.BuildDbUserDummyErrorGrantDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", mocks.UserID).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{mocks.DatabaseID}).Return([]*entity.Database{mocks.BuildSettingsDatabase()}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("Exists", mocks.DatabaseID, dbUser.ID).Return(true, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool { return !l.Success })).Return(nil).Once()
+	var savedAccess *entity.BreakGlassAccess
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("Save", mock.MatchedBy(func(b *entity.BreakGlassAccess) bool { return b.RoleGranted })).
+		Run(func(args mock.Arguments) { savedAccess = args.Get(0).(*entity.BreakGlassAccess) }).Return(nil).Once()
+	breakGlassStorage.On("UpdateRevokedAt", mock.Anything, mock.Anything).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+
+	uc := NewBreakGlassAccessUseCase(accessPermissionStorage, breakGlassStorage, dbUserStorage, dbInstanceStorage, dbStorage,
+		forbiddenObjStorage, userStorage, notifierMock, breakGlassWindow)
+	output, err := uc.Execute(buildBreakGlassInput(instance.ID), mocks.UserID)
+
+	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
+	assert.True(t, output.HasErrors)
+	breakGlassStorage.AssertExpectations(t)
+	breakGlassStorage.AssertCalled(t, "UpdateRevokedAt", savedAccess.ID.String(), mock.Anything)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
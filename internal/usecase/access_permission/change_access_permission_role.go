@@ -0,0 +1,193 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var (
+	ErrAccessPermissionNotFound = errors.New("access permission not found")
+	ErrSameDatabaseRole         = errors.New("the user already has this role in the database")
+)
+
+const (
+	ErrChangeRoleFailedMsg = "failed to change the role of user '%s' on database '%s' of instance '%s' to '%s'. Details: %s"
+	RoleChangedMsg         = "the role of user '%s' on database '%s' of instance '%s' was changed from '%s' to '%s'"
+)
+
+type ChangeAccessPermissionRoleUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	ForbiddenObjectsStorage storage.ForbiddenObjectsStorage
+}
+
+func NewChangeAccessPermissionRoleUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	databaseRoleStorage storage.DatabaseRoleStorage,
+	databaseUserStorage storage.DatabaseUserStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	forbiddenObjectsStorage storage.ForbiddenObjectsStorage,
+) *ChangeAccessPermissionRoleUseCase {
+	return &ChangeAccessPermissionRoleUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		DatabaseRoleStorage:     databaseRoleStorage,
+		DatabaseUserStorage:     databaseUserStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		ForbiddenObjectsStorage: forbiddenObjectsStorage,
+	}
+}
+
+// Execute godoc
+/** Responsible for changing the role the user has in the database of an access permission.
+The privileges of the new role are granted in the database in place of the ones of the current role, without revoking the
+access of the user. Choosing the user's own role makes the permission follow it again. A scoped permission keeps its
+scope, with the privileges of the new role.
+The same rules of the grant apply: the instance must be enabled, the ecosystem must not require approval, the
+application role can't be given per database and neither can a role with no privilege beyond the own role of the user,
+outside the instances where the user has scoped access. */
+func (useCase *ChangeAccessPermissionRoleUseCase) Execute(input dto.ChangeAccessPermissionRoleInputDTO, permissionID, operationUserID string) (*dto.AccessPermissionOutputDTO, error) {
+	permission, err := useCase.AccessPermissionStorage.FindDTOByID(permissionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAccessPermissionNotFound
+		}
+		return nil, err
+	}
+	newRole, err := useCase.DatabaseRoleStorage.FindByID(input.DatabaseRoleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDatabaseRoleNotFound
+		}
+		return nil, err
+	}
+	if newRole.IsApplication() {
+		return nil, ErrApplicationRoleNotAllowed
+	}
+	if newRole.ID.String() == permission.DatabaseRoleID {
+		return nil, ErrSameDatabaseRole
+	}
+	dbUser, err := useCase.DatabaseUserStorage.FindDTOByID(permission.DatabaseUserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDatabaseUserNotFound
+		}
+		return nil, err
+	}
+	instance, err := useCase.DatabaseInstanceStorage.FindDTOByID(permission.DatabaseInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err = useCase.validate(permission, instance); err != nil {
+		return nil, err
+	}
+	if err = useCase.validateOwnRole(permission, dbUser, newRole); err != nil {
+		return nil, err
+	}
+
+	if err = useCase.changeRole(permission, instance, dbUser, newRole, operationUserID); err != nil {
+		return nil, err
+	}
+	return useCase.AccessPermissionStorage.FindDTOByID(permissionID)
+}
+
+func (useCase *ChangeAccessPermissionRoleUseCase) validate(permission *dto.AccessPermissionOutputDTO, instance *dto.DatabaseInstanceOutputDTO) error {
+	if instance.EcosystemRequiresApproval {
+		return fmt.Errorf("%w: %s", ErrAccessApprovalRequired, instance.Name)
+	}
+	if !instance.Enabled {
+		return ErrInstanceDisabled
+	}
+	forbiddenDatabases, err := useCase.ForbiddenObjectsStorage.FindAllDatabases()
+	if err != nil {
+		return fmt.Errorf("error when fetching forbidden databases. Cause: %v", err)
+	}
+	for _, forbiddenDatabase := range forbiddenDatabases {
+		if forbiddenDatabase.Name == permission.DatabaseName {
+			return ErrDatabaseForbidden
+		}
+	}
+	return nil
+}
+
+// validateOwnRole godoc
+// Refuses a new role that would change nothing: the own role of the user is a membership that applies to the whole
+// instance, unless the user has scoped access in it, so a role with no privilege beyond it doesn't take any away
+func (useCase *ChangeAccessPermissionRoleUseCase) validateOwnRole(permission *dto.AccessPermissionOutputDTO, dbUser *dto.DatabaseUserOutputDTO, newRole *entity.DatabaseRole) error {
+	if newRole.ID.String() == dbUser.DatabaseRoleID || permission.Scope != nil {
+		return nil
+	}
+	scopedInstancesByUser, err := useCase.AccessPermissionStorage.FindAllScopedInstancesIDsByUsers([]string{dbUser.ID})
+	if err != nil {
+		return fmt.Errorf("error when fetching the instances where the user has scoped permissions. Cause: %v", err)
+	}
+	if slices.Contains(scopedInstancesByUser[dbUser.ID], permission.DatabaseInstanceID) {
+		return nil
+	}
+	ownRole, err := useCase.DatabaseRoleStorage.FindByID(dbUser.DatabaseRoleID)
+	if err != nil {
+		return fmt.Errorf("error when fetching the own role of the user. Cause: %v", err)
+	}
+	if newRole.IsCoveredBy(ownRole) {
+		return fmt.Errorf("%w: role '%s' for user '%s', whose own role is '%s'", ErrDatabaseRoleCoveredByOwn, newRole.DisplayName, dbUser.Username, ownRole.DisplayName)
+	}
+	return nil
+}
+
+func (useCase *ChangeAccessPermissionRoleUseCase) changeRole(
+	permission *dto.AccessPermissionOutputDTO,
+	instance *dto.DatabaseInstanceOutputDTO,
+	dbUser *dto.DatabaseUserOutputDTO,
+	newRole *entity.DatabaseRole,
+	operationUserID string) error {
+	targetDatabase, err := connector.NewDatabaseConnector(instance, permission.DatabaseName)
+	if err != nil {
+		useCase.newLog(permission, operationUserID, fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error()), false)
+		return err
+	}
+	log.Printf("Changing the role of user '%s' on database '%s' of instance '%s' to '%s'. Requester: %s",
+		dbUser.Username, permission.DatabaseName, instance.Name, newRole.Name, operationUserID)
+	if permission.Scope != nil {
+		scope := entity.AccessScope{Schemas: permission.Scope.Schemas, Tables: permission.Scope.Tables}
+		err = targetDatabase.GrantConnectWithScope(dbUser.Username, connector.NewDatabaseRoles([]*entity.DatabaseRole{newRole})[0], scope)
+	} else {
+		err = targetDatabase.GrantConnectWithRole(dbUser.Username, string(newRole.Name))
+	}
+	if err != nil {
+		msg := fmt.Sprintf(ErrChangeRoleFailedMsg, dbUser.Username, permission.DatabaseName, instance.Name, newRole.DisplayName, err.Error())
+		useCase.newLog(permission, operationUserID, msg, false)
+		return fmt.Errorf("change of role failed with instance. Cause: %v", err)
+	}
+
+	databaseRoleID := entity.PermissionDatabaseRoleID(newRole.ID.String(), dbUser.DatabaseRoleID)
+	if err = useCase.AccessPermissionStorage.UpdateDatabaseRole(permission.ID, databaseRoleID); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf(RoleChangedMsg, dbUser.Username, permission.DatabaseName, instance.Name, permission.DatabaseRoleName, newRole.DisplayName)
+	log.Print(msg)
+	useCase.newLog(permission, operationUserID, msg, true)
+	return nil
+}
+
+// newLog godoc
+// Saves a log of the change. The role was already changed in the instance, so a failure saving the log is only
+// reported in the application log.
+func (useCase *ChangeAccessPermissionRoleUseCase) newLog(permission *dto.AccessPermissionOutputDTO, operationUserID, message string, success bool) {
+	changeLog, err := entity.NewAccessPermissionLog(permission.DatabaseInstanceID, permission.DatabaseUserID, permission.DatabaseID, message, operationUserID, success)
+	if err == nil {
+		err = useCase.AccessPermissionStorage.SaveLog(changeLog)
+	}
+	if err != nil {
+		log.Printf("Error when saving change of role log for instance %s and database user %s. Cause: %v", permission.DatabaseInstanceID, permission.DatabaseUserID, err)
+	}
+}
@@ -0,0 +1,208 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const permissionID = "4c8e2f1a-9b3d-4e6f-8a7c-1d2e3f4a5b6c"
+
+func buildPermissionDTO(dbUser *dto.DatabaseUserOutputDTO, instance *dto.DatabaseInstanceOutputDTO) *dto.AccessPermissionOutputDTO {
+	database := mocks.BuildSettingsDatabase()
+	return &dto.AccessPermissionOutputDTO{
+		ID:                 permissionID,
+		DatabaseUserID:     dbUser.ID,
+		DatabaseRoleID:     dbUser.DatabaseRoleID,
+		DatabaseRoleName:   dbUser.DatabaseRoleDisplayName,
+		DatabaseInstanceID: instance.ID,
+		DatabaseID:         database.ID.String(),
+		DatabaseName:       database.Name,
+	}
+}
+
+func TestGivenAnUnknownPermission_WhenExecuteChangeRole_ThenShouldReturnError(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindDTOByID", permissionID).Return(&dto.AccessPermissionOutputDTO{}, sql.ErrNoRows).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, nil, nil, nil, nil)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: uuid.NewString()}, permissionID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrAccessPermissionNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenTheApplicationRole_WhenExecuteChangeRole_ThenShouldReturnError(t *testing.T) {
+	permission := buildPermissionDTO(mocks.BuildDbUserJohnDTO(), mocks.BuildAzInstanceDTO())
+	applicationRole := &entity.DatabaseRole{ID: uuid.New(), Name: entity.Application}
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindDTOByID", permissionID).Return(permission, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", applicationRole.ID.String()).Return(applicationRole, nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, nil, nil, nil)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: applicationRole.ID.String()}, permissionID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrApplicationRoleNotAllowed)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnInstanceOfAnEcosystemRequiringApproval_WhenExecuteChangeRole_ThenShouldReturnErrorWithoutChanging(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	instance.EcosystemRequiresApproval = true
+	role := mocks.BuildReadOnlyRole()
+	accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage := buildChangeRoleStorages(dbUser, instance, role)
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, nil)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: role.ID.String()}, permissionID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrAccessApprovalRequired)
+	assert.Nil(t, output)
+	accessPermissionStorage.AssertNotCalled(t, "UpdateDatabaseRole", mock.Anything, mock.Anything)
+}
+
+func TestGivenAnotherRole_WhenExecuteChangeRole_ThenShouldChangeTheRoleInPlaceAndLogIt(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	role := buildStrongerRole()
+	accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage := buildChangeRoleStorages(dbUser, instance, role)
+	accessPermissionStorage.On("UpdateDatabaseRole", permissionID, sql.NullString{String: role.ID.String(), Valid: true}).Return(nil).Once()
+	msg := fmt.Sprintf(RoleChangedMsg, dbUser.Username, "settings", instance.Name, dbUser.DatabaseRoleDisplayName, role.DisplayName)
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, mocks.DatabaseID, msg, mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenObjStorage)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: role.ID.String()}, permissionID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	accessPermissionStorage.AssertNumberOfCalls(t, "UpdateDatabaseRole", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "FindDTOByID", 2)
+}
+
+func TestGivenTheOwnRoleOfTheUser_WhenExecuteChangeRole_ThenShouldClearTheRoleOfThePermission(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	ownRole := &entity.DatabaseRole{ID: uuid.MustParse(dbUser.DatabaseRoleID), Name: entity.DevOps, DisplayName: "DevOps"}
+	permission := buildPermissionDTO(dbUser, instance)
+	permission.DatabaseRoleID = mocks.BuildReadOnlyRole().ID.String()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindDTOByID", permissionID).Return(permission, nil)
+	accessPermissionStorage.On("UpdateDatabaseRole", permissionID, sql.NullString{String: ownRole.ID.String()}).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", ownRole.ID.String()).Return(ownRole, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindDTOByID", dbUser.ID).Return(dbUser, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindDTOByID", instance.ID).Return(instance, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenObjStorage)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: ownRole.ID.String()}, permissionID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	accessPermissionStorage.AssertNumberOfCalls(t, "UpdateDatabaseRole", 1)
+}
+
+func TestGivenAnErrorChangingTheRoleInTheInstance_WhenExecuteChangeRole_ThenShouldLogTheFailureWithoutChanging(t *testing.T) {
+	dbUser := mocks.BuildDbUserDummyErrorGrantDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	role := buildStrongerRole()
+	accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage := buildChangeRoleStorages(dbUser, instance, role)
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool { return !l.Success })).Return(nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenObjStorage)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: role.ID.String()}, permissionID, mocks.UserID)
+
+	assert.Error(t, err)
+	assert.Nil(t, output)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+	accessPermissionStorage.AssertNotCalled(t, "UpdateDatabaseRole", mock.Anything, mock.Anything)
+}
+
+func TestGivenARoleCoveredByTheOwnRoleOfTheUser_WhenExecuteChangeRole_ThenShouldReturnErrorWithoutChanging(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	role := mocks.BuildReadOnlyRole()
+	accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage := buildChangeRoleStorages(dbUser, instance, role)
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenObjStorage)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: role.ID.String()}, permissionID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrDatabaseRoleCoveredByOwn)
+	assert.Nil(t, output)
+	accessPermissionStorage.AssertNotCalled(t, "UpdateDatabaseRole", mock.Anything, mock.Anything)
+	accessPermissionStorage.AssertNotCalled(t, "SaveLog", mock.Anything)
+}
+
+func TestGivenARoleCoveredByTheOwnRoleInAScopedInstance_WhenExecuteChangeRole_ThenShouldChangeTheRole(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	role := mocks.BuildReadOnlyRole()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindDTOByID", permissionID).Return(buildPermissionDTO(dbUser, instance), nil)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", []string{dbUser.ID}).Return(map[string][]string{dbUser.ID: {instance.ID}}, nil).Once()
+	accessPermissionStorage.On("UpdateDatabaseRole", permissionID, sql.NullString{String: role.ID.String(), Valid: true}).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindDTOByID", dbUser.ID).Return(dbUser, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindDTOByID", instance.ID).Return(instance, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewChangeAccessPermissionRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenObjStorage)
+	output, err := uc.Execute(dto.ChangeAccessPermissionRoleInputDTO{DatabaseRoleID: role.ID.String()}, permissionID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	accessPermissionStorage.AssertNumberOfCalls(t, "UpdateDatabaseRole", 1)
+	roleStorage.AssertNotCalled(t, "FindByID", dbUser.DatabaseRoleID)
+}
+
+func buildStrongerRole() *entity.DatabaseRole {
+	role := mocks.BuildDeveloperRole()
+	role.ID = uuid.New()
+	role.Name = entity.DevOps
+	role.DisplayName = "DevOps"
+	role.Privileges.Schema = append(role.Privileges.Schema, "CREATE")
+	role.Privileges.Table = append(role.Privileges.Table, "TRUNCATE")
+	return role
+}
+
+func buildChangeRoleStorages(dbUser *dto.DatabaseUserOutputDTO, instance *dto.DatabaseInstanceOutputDTO, role *entity.DatabaseRole) (
+	*mocks.AccessPermissionStorageMock, *mocks.DatabaseRoleStorageMock, *mocks.DatabaseUserStorageMock, *mocks.DatabaseInstanceStorageMock) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindDTOByID", permissionID).Return(buildPermissionDTO(dbUser, instance), nil)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", []string{dbUser.ID}).Return(map[string][]string{}, nil)
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+	roleStorage.On("FindByID", dbUser.DatabaseRoleID).Return(mocks.BuildDeveloperRole(), nil)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindDTOByID", dbUser.ID).Return(dbUser, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindDTOByID", instance.ID).Return(instance, nil).Once()
+	return accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage
+}
@@ -1,10 +1,12 @@
 package accesspermission
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/zgsolucoes/zg-data-guard/config"
@@ -12,10 +14,12 @@ import (
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
 )
 
 const (
 	AccessGrantedMsg           = "Access permissions created successfully."
+	AccessGrantDryRunMsg       = "Dry run: the access permissions were validated and nothing was executed. The plan has the statements the grant would execute."
 	SomeErrorsDuringProcessMsg = "Some errors occurred during the process. Check the logs for more details."
 )
 
@@ -25,6 +29,7 @@ type GrantAccessPermissionUseCase struct {
 	DatabaseInstanceStorage storage.DatabaseInstanceStorage
 	DatabaseStorage         storage.DatabaseStorage
 	ForbiddenObjectsStorage storage.ForbiddenObjectsStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
 }
 
 func NewGrantAccessPermissionUseCase(
@@ -33,6 +38,7 @@ func NewGrantAccessPermissionUseCase(
 	databaseInstanceStorage storage.DatabaseInstanceStorage,
 	databaseStorage storage.DatabaseStorage,
 	forbiddenObjectsStorage storage.ForbiddenObjectsStorage,
+	databaseRoleStorage storage.DatabaseRoleStorage,
 ) *GrantAccessPermissionUseCase {
 	return &GrantAccessPermissionUseCase{
 		AccessPermissionStorage: accessPermissionStorage,
@@ -40,6 +46,7 @@ func NewGrantAccessPermissionUseCase(
 		DatabaseInstanceStorage: databaseInstanceStorage,
 		DatabaseStorage:         databaseStorage,
 		ForbiddenObjectsStorage: forbiddenObjectsStorage,
+		DatabaseRoleStorage:     databaseRoleStorage,
 	}
 }
 
@@ -49,9 +56,32 @@ If the user is already created in the instance, it grants the connect permission
 It returns an output DTO that has a flag indicating if the process has errors and a message with the result.
 For each error that occurs inside the instance context during the process, it's logged, persisted and the process continues.
 The process is divided into four main contexts: global, instance, user and database.
-The process occurs concurrently for each instance, user and database. I.e., if there are 3 instances, 5 users and 4 databases, there will be 60 goroutines running concurrently. */
+The instances, users and databases are processed as tasks of the shared executor, that limits how many of them run at the same time in total and in each instance.
+I.e., if there are 3 instances, 5 users and 4 databases, the 60 grants run at most with the concurrency configured for the executor. */
 func (useCase *GrantAccessPermissionUseCase) Execute(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error) {
+	return useCase.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each user in each instance as an item of the progress once all its databases are processed,
+// or the instance as a single item when it can't be processed. Every message logged about an instance, a user or a
+// database is reported as an event of the progress.
+func (useCase *GrantAccessPermissionUseCase) ExecuteWithProgress(input dto.GrantAccessInputDTO, operationUserID string, progress common.ProgressReporter) (*dto.GrantAccessOutputDTO, error) {
+	return useCase.grantAccess(input, operationUserID, progress, false)
+}
+
+// ExecuteApproved godoc
+// Same as Execute for an approved access request, the only way to grant access to the instances of ecosystems that
+// require approval. The operation user is the one who approved the request.
+func (useCase *GrantAccessPermissionUseCase) ExecuteApproved(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error) {
+	return useCase.grantAccess(input, operationUserID, common.NoProgress, true)
+}
+
+func (useCase *GrantAccessPermissionUseCase) grantAccess(input dto.GrantAccessInputDTO, operationUserID string, progress common.ProgressReporter, approved bool) (*dto.GrantAccessOutputDTO, error) {
 	start := time.Now()
+	if err := input.ValidateExpiresAt(); err != nil {
+		return nil, err
+	}
 	dbUsers, err := useCase.DatabaseUserStorage.FindAllDTOs(input.DatabaseUsersIDs)
 	if err != nil {
 		return nil, err
@@ -62,38 +92,84 @@ func (useCase *GrantAccessPermissionUseCase) Execute(input dto.GrantAccessInputD
 	if err != nil {
 		return nil, err
 	}
+	if !approved {
+		if err = checkApprovalNotRequired(dbInstances); err != nil {
+			return nil, err
+		}
+	}
 
+	scopesByDatabase, err := buildDatabasesScopes(input.InstancesData)
+	if err != nil {
+		return nil, err
+	}
+	roles, rolesByDatabase, err := useCase.fetchDatabasesRoles(input.InstancesData, len(scopesByDatabase) > 0)
+	if err != nil {
+		return nil, err
+	}
 	forbiddenDatabaseMap, err := useCase.fetchForbiddenDatabases()
 	if err != nil {
 		return nil, err
 	}
+	scopedInstancesByUser, err := useCase.AccessPermissionStorage.FindAllScopedInstancesIDsByUsers(input.DatabaseUsersIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error when fetching the instances where the users have scoped permissions. Cause: %v", err)
+	}
+	if err = validateDatabasesRoles(input.InstancesData, dbUsers, roles, rolesByDatabase, scopesByDatabase, scopedInstancesByUser); err != nil {
+		return nil, err
+	}
 	instancesQty := len(dbInstances)
 	usersQty := len(dbUsers)
-	globalCtx := newGrantAccessGlobalContext(dbUsers, dbIdsByInstance, operationUserID, forbiddenDatabaseMap, instancesQty, usersQty)
+	batch := config.GetExecutor().NewBatch("grant access")
+	recorders := common.NewStatementRecorders(input.DryRun, dbInstances)
+	globalCtx := newGrantAccessGlobalContext(dbUsers, dbIdsByInstance, rolesByDatabase, scopesByDatabase, roles, scopedInstancesByUser, operationUserID, input.ExpiresAt, forbiddenDatabaseMap, instancesQty, usersQty, batch, progress, recorders)
+	globalCtx.AccessGroupID = input.AccessGroupID
+	progress.AddItems(instancesQty * usersQty)
 	log.Printf("Starting to process grant permissions to %d users in %d instances", usersQty, instancesQty)
-	var wg sync.WaitGroup
-	wg.Add(instancesQty)
 	for idx, dbInstance := range dbInstances {
-		go func(instanceDTO *dto.DatabaseInstanceOutputDTO, instanceIdx int) {
-			defer wg.Done()
-			instanceCtx := newGrantAccessInstanceContext(globalCtx, instanceDTO, instanceIdx)
+		batch.Go(dbInstance.ID, func(time.Duration) {
+			instanceCtx := newGrantAccessInstanceContext(globalCtx, dbInstance, idx)
 			errValidating := useCase.validateInstance(instanceCtx)
 			if errValidating != nil {
 				globalCtx.GlobalErrChan <- errValidating
+				reportInstanceFailed(instanceCtx, errValidating)
 				return
 			}
 			if err := useCase.processInstance(instanceCtx); err != nil {
 				globalCtx.GlobalErrChan <- err
+				reportInstanceFailed(instanceCtx, err)
 			}
-		}(dbInstance, idx)
+		})
 	}
 
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(globalCtx.GlobalErrChan)
 	}()
+	output := buildGrantAccessOutput(globalCtx.GlobalErrChan)
+	output.Execution = common.BuildExecutionOutput(batch.Stats())
+	if input.DryRun {
+		output.Plan = common.BuildDryRunPlan(dbInstances, recorders)
+		if !output.HasErrors {
+			output.Message = AccessGrantDryRunMsg
+		}
+	}
 	log.Printf("All %d instances processed. Elapsed time: %s", instancesQty, time.Since(start))
-	return buildGrantAccessOutput(globalCtx.GlobalErrChan), nil
+	return output, nil
+}
+
+// checkApprovalNotRequired godoc
+// Refuses the whole grant when any instance belongs to an ecosystem that requires approval, listing those instances
+func checkApprovalNotRequired(dbInstances []*dto.DatabaseInstanceOutputDTO) error {
+	var instancesRequiringApproval []string
+	for _, dbInstance := range dbInstances {
+		if dbInstance.EcosystemRequiresApproval {
+			instancesRequiringApproval = append(instancesRequiringApproval, dbInstance.Name)
+		}
+	}
+	if len(instancesRequiringApproval) > 0 {
+		return fmt.Errorf("%w: %s", ErrAccessApprovalRequired, strings.Join(instancesRequiringApproval, ", "))
+	}
+	return nil
 }
 
 func (useCase *GrantAccessPermissionUseCase) prepareInstanceData(instancesData []dto.InstanceDataDTO) ([]string, map[string][]string) {
@@ -107,6 +183,91 @@ func (useCase *GrantAccessPermissionUseCase) prepareInstanceData(instancesData [
 	return dbInstancesIds, databasesIdsByInstance
 }
 
+// fetchDatabasesRoles godoc
+// Finds all the roles and the ones informed for the databases, by database id. The roles are only loaded when any is
+// informed or any scope, whose privileges come from the role of the user. The application role is refused, since it's
+// the only one allowed in forbidden databases and must be the own role of the user.
+func (useCase *GrantAccessPermissionUseCase) fetchDatabasesRoles(instancesData []dto.InstanceDataDTO, hasScopes bool) ([]*entity.DatabaseRole, map[string]*entity.DatabaseRole, error) {
+	rolesByDatabase := make(map[string]*entity.DatabaseRole)
+	hasRoles := slices.ContainsFunc(instancesData, func(i dto.InstanceDataDTO) bool { return len(i.DatabasesRolesIDs) > 0 })
+	if !hasRoles && !hasScopes {
+		return nil, rolesByDatabase, nil
+	}
+	roles, err := useCase.DatabaseRoleStorage.FindAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when fetching database roles. Cause: %v", err)
+	}
+	for _, instanceData := range instancesData {
+		for databaseID, roleID := range instanceData.DatabasesRolesIDs {
+			role := findRoleByID(roles, roleID)
+			if role == nil {
+				return nil, nil, fmt.Errorf("%w: %s", ErrDatabaseRoleNotFound, roleID)
+			}
+			if role.IsApplication() {
+				return nil, nil, ErrApplicationRoleNotAllowed
+			}
+			rolesByDatabase[databaseID] = role
+		}
+	}
+	return roles, rolesByDatabase, nil
+}
+
+// validateDatabasesRoles godoc
+// Refuses the roles informed for the databases that would change nothing for a user: the own role of the user is a
+// membership that applies to the whole instance, so a role with no privilege beyond it only adds what the user already
+// has. Instances where the user has or is receiving a scoped access are skipped, since the membership of the own role is
+// revoked there and each database receives the privileges of its role.
+func validateDatabasesRoles(
+	instancesData []dto.InstanceDataDTO,
+	dbUsers []*dto.DatabaseUserOutputDTO,
+	roles []*entity.DatabaseRole,
+	rolesByDatabase map[string]*entity.DatabaseRole,
+	scopesByDatabase map[string]entity.AccessScope,
+	scopedInstancesByUser map[string][]string,
+) error {
+	for _, instanceData := range instancesData {
+		if len(instanceData.DatabasesRolesIDs) == 0 {
+			continue
+		}
+		scopedInstance := false
+		for databaseID := range instanceData.DatabasesScopes {
+			if _, found := scopesByDatabase[databaseID]; found {
+				scopedInstance = true
+			}
+		}
+		for _, dbUser := range dbUsers {
+			ownRole := findRoleByID(roles, dbUser.DatabaseRoleID)
+			if ownRole == nil || scopedInstance || slices.Contains(scopedInstancesByUser[dbUser.ID], instanceData.DatabaseInstanceID) {
+				continue
+			}
+			for databaseID := range instanceData.DatabasesRolesIDs {
+				if role := rolesByDatabase[databaseID]; role.IsCoveredBy(ownRole) {
+					return fmt.Errorf("%w: role '%s' informed for database %s and user '%s', whose own role is '%s'", ErrDatabaseRoleCoveredByOwn, role.DisplayName, databaseID, dbUser.Username, ownRole.DisplayName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// buildDatabasesScopes godoc
+// Builds the scopes informed for the databases, by database id
+func buildDatabasesScopes(instancesData []dto.InstanceDataDTO) (map[string]entity.AccessScope, error) {
+	scopesByDatabase := make(map[string]entity.AccessScope)
+	for _, instanceData := range instancesData {
+		for databaseID, scopeDTO := range instanceData.DatabasesScopes {
+			scope, err := entity.NewAccessScope(scopeDTO.Schemas, scopeDTO.Tables)
+			if err != nil {
+				return nil, err
+			}
+			if !scope.IsEmpty() {
+				scopesByDatabase[databaseID] = scope
+			}
+		}
+	}
+	return scopesByDatabase, nil
+}
+
 func (useCase *GrantAccessPermissionUseCase) fetchForbiddenDatabases() (map[string]bool, error) {
 	forbiddenDatabases, err := useCase.ForbiddenObjectsStorage.FindAllDatabases()
 	if err != nil {
@@ -131,32 +292,29 @@ func (useCase *GrantAccessPermissionUseCase) validateInstance(instanceCtx *insta
 }
 
 func (useCase *GrantAccessPermissionUseCase) processInstance(instanceCtx *instanceContextOnGrant) error {
-	targetInstance, err := connector.NewDatabaseConnector(instanceCtx.Instance, "")
+	targetInstance, err := common.NewConnector(instanceCtx.Instance, "", instanceCtx.Recorder)
 	if err != nil {
 		return useCase.registerInstanceValidationError(instanceCtx, fmt.Sprintf(ErrCreatingConnectorMsg, instanceCtx.Instance.Name, err.Error()), err)
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(instanceCtx.GlobalCtx.UsersQty)
-	logInstanceContextWithIndex(instanceCtx, fmt.Sprintf("starting processing %d users...", instanceCtx.GlobalCtx.UsersQty), false)
-	defer logInstanceContextWithIndex(instanceCtx, fmt.Sprintf("finished processing %d users", instanceCtx.GlobalCtx.UsersQty), false)
+	// The users are submitted to the executor and not waited, since this task holds a slot of the instance
+	logInstanceContextWithIndex(instanceCtx, fmt.Sprintf("submitting %d users to process...", instanceCtx.GlobalCtx.UsersQty), false)
 	for idx, userDTO := range instanceCtx.GlobalCtx.DBUsers {
-		go func(userDTO *dto.DatabaseUserOutputDTO, userIndex int) {
-			defer wg.Done()
-
-			userCtx := newGrantAccessUserContext(instanceCtx, targetInstance, userDTO, userIndex)
+		instanceCtx.GlobalCtx.Batch.Go(instanceCtx.Instance.ID, func(time.Duration) {
+			userCtx := newGrantAccessUserContext(instanceCtx, targetInstance, userDTO, idx)
 			errValidating := useCase.validateUser(userCtx)
 			if errValidating != nil {
 				instanceCtx.GlobalCtx.GlobalErrChan <- errValidating
+				reportUser(userCtx, false, errValidating.Error())
 				return
 			}
 
 			if err := useCase.processUser(userCtx); err != nil {
 				instanceCtx.GlobalCtx.GlobalErrChan <- err
+				reportUser(userCtx, false, err.Error())
 			}
-		}(userDTO, idx)
+		})
 	}
-	wg.Wait()
 
 	return nil
 }
@@ -185,9 +343,56 @@ func (useCase *GrantAccessPermissionUseCase) processUser(userCtx *userContextOnG
 		}
 	}
 
+	alreadyScoped := slices.Contains(userCtx.InstanceCtx.GlobalCtx.ScopedInstancesByUser[userCtx.DBUser.ID], userCtx.InstanceCtx.Instance.ID)
+	userCtx.Scoped = alreadyScoped || useCase.hasScopesInInstance(userCtx.InstanceCtx)
+	if userCtx.Scoped && !alreadyScoped && userExists {
+		if err = useCase.regrantUnscopedDatabases(userCtx); err != nil {
+			return err
+		}
+	}
+
 	return useCase.processDatabases(userCtx)
 }
 
+func (useCase *GrantAccessPermissionUseCase) hasScopesInInstance(instanceCtx *instanceContextOnGrant) bool {
+	for _, databaseID := range instanceCtx.GlobalCtx.DBIdsByInstance[instanceCtx.Instance.ID] {
+		if _, found := instanceCtx.GlobalCtx.ScopesByDatabase[databaseID]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// regrantUnscopedDatabases godoc
+// A scoped grant revokes the membership of the user in its role, which applies to the whole instance, so the databases
+// the user already has access to without scope are granted again with the role in each database
+func (useCase *GrantAccessPermissionUseCase) regrantUnscopedDatabases(userCtx *userContextOnGrant) error {
+	instance := userCtx.InstanceCtx.Instance
+	permissions, err := useCase.AccessPermissionStorage.FindAllDTOs("", userCtx.DBUser.ID, instance.ID)
+	if err == nil {
+		for _, permission := range permissions {
+			if permission.Scope != nil {
+				continue
+			}
+			role := findRoleByID(userCtx.InstanceCtx.GlobalCtx.Roles, permission.DatabaseRoleID)
+			if role == nil {
+				err = fmt.Errorf("%w: %s", ErrDatabaseRoleNotFound, permission.DatabaseRoleID)
+				break
+			}
+			logUserContextWithIndex(userCtx, fmt.Sprintf("granting again database '%s' with role '%s' before the first scoped access", permission.DatabaseName, role.Name), false)
+			targetDatabase, _ := common.NewConnector(instance, permission.DatabaseName, userCtx.InstanceCtx.Recorder)
+			if err = targetDatabase.GrantConnectWithRole(userCtx.DBUser.Username, string(role.Name)); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		errRegranting := fmt.Errorf("could not grant again the databases without scope. Cause: %v", err)
+		return useCase.registerUserValidationError(userCtx, fmt.Sprintf(ErrRegrantUnscopedFailedMsg, userCtx.DBUser.Username, instance.Name, err.Error()), errRegranting)
+	}
+	return nil
+}
+
 func (useCase *GrantAccessPermissionUseCase) createUser(userCtx *userContextOnGrant) error {
 	if !entity.ValidateRoleName(userCtx.DBUser.DatabaseRoleName) {
 		return useCase.registerUserValidationError(userCtx, fmt.Sprintf(ErrInvalidRoleMsg, userCtx.DBUser.DatabaseRoleName, userCtx.DBUser.Username), ErrInvalidRole)
@@ -211,6 +416,9 @@ func (useCase *GrantAccessPermissionUseCase) createUser(userCtx *userContextOnGr
 	}
 
 	logUserContextWithIndex(userCtx, "user created successfully!", false)
+	if userCtx.InstanceCtx.GlobalCtx.DryRun() {
+		return nil
+	}
 	logMsg := fmt.Sprintf(UserCreatedMsg, userCtx.DBUser.Username, userCtx.InstanceCtx.Instance.Name)
 	errLog := useCase.newLog(userCtx.InstanceCtx.Instance.ID, userCtx.DBUser.ID, "", userCtx.OperationUserID, logMsg, true)
 	if errLog != nil {
@@ -226,22 +434,19 @@ func (useCase *GrantAccessPermissionUseCase) processDatabases(userCtx *userConte
 		return err
 	}
 
-	var wg sync.WaitGroup
 	databasesQty := len(databases)
-	wg.Add(databasesQty)
-	logUserContextWithIndex(userCtx, fmt.Sprintf("starting processing %d databases...", databasesQty), false)
-	defer logUserContextWithIndex(userCtx, fmt.Sprintf("finished processing %d databases", databasesQty), false)
+	userCtx.PendingDatabases.Store(int32(databasesQty))
+	logUserContextWithIndex(userCtx, fmt.Sprintf("submitting %d databases to process...", databasesQty), false)
 	for idx, database := range databases {
-		go func(database *entity.Database, databaseIndex int) {
-			defer wg.Done()
-
-			databaseCtx := newGrantAccessDatabaseContext(userCtx, database, databaseIndex, databasesQty)
-			if err := useCase.processDatabase(databaseCtx); err != nil {
+		userCtx.InstanceCtx.GlobalCtx.Batch.Go(userCtx.InstanceCtx.Instance.ID, func(time.Duration) {
+			databaseCtx := newGrantAccessDatabaseContext(userCtx, database, idx, databasesQty)
+			err := useCase.processDatabase(databaseCtx)
+			if err != nil {
 				userCtx.InstanceCtx.GlobalCtx.GlobalErrChan <- err
 			}
-		}(database, idx)
+			databaseProcessed(userCtx, databasesQty, err)
+		})
 	}
-	wg.Wait()
 
 	return nil
 }
@@ -270,7 +475,8 @@ func (useCase *GrantAccessPermissionUseCase) fetchDatabases(userCtx *userContext
 func (useCase *GrantAccessPermissionUseCase) processDatabase(databaseCtx *databaseContextOnGrant) error {
 	if err := useCase.validateDatabase(databaseCtx); err != nil {
 		// If the database is forbidden, it's not considered an error and should be ignored for granting permissions.
-		if errors.Is(err, ErrDatabaseForbidden) {
+		// Neither is a permission the user already had, when only its expiration or origin was changed.
+		if errors.Is(err, ErrDatabaseForbidden) || errors.Is(err, errPermissionUpdated) {
 			return nil
 		}
 		return err
@@ -278,36 +484,154 @@ func (useCase *GrantAccessPermissionUseCase) processDatabase(databaseCtx *databa
 
 	dbUserDTO := databaseCtx.UserCtx.DBUser
 	instanceDTO := databaseCtx.UserCtx.InstanceCtx.Instance
-	targetDatabase, _ := connector.NewDatabaseConnector(instanceDTO, databaseCtx.Database.Name)
+	targetDatabase, _ := common.NewConnector(instanceDTO, databaseCtx.Database.Name, databaseCtx.UserCtx.InstanceCtx.Recorder)
 
+	databaseRole := databaseCtx.DatabaseRole()
+	scope := databaseCtx.Scope()
 	logDatabaseContextWithIndex(databaseCtx, "granting connect permission to user", false)
-	err := targetDatabase.GrantConnect(dbUserDTO.Username)
+	err := useCase.grantConnect(databaseCtx, targetDatabase)
 	if err != nil {
 		logMsgPt := fmt.Sprintf(ErrGrantConnectFailedMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceDTO.Name, err.Error())
 		return useCase.registerDatabaseValidationError(databaseCtx, logMsgPt, fmt.Errorf("grant connect failed with instance. Cause: %v", err))
 	}
 
 	logDatabaseContextWithIndex(databaseCtx, "connect permission granted to user successfully!", false)
-	msgGranted := fmt.Sprintf(PermissionGrantedMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceDTO.Name)
-	err = useCase.newLog(instanceDTO.ID, dbUserDTO.ID, databaseCtx.Database.ID.String(), databaseCtx.OperationUserID, msgGranted, true)
+	if databaseCtx.UserCtx.InstanceCtx.GlobalCtx.DryRun() {
+		return nil
+	}
+	expiresAt := databaseCtx.UserCtx.InstanceCtx.GlobalCtx.ExpiresAt
+	err = useCase.newLog(instanceDTO.ID, dbUserDTO.ID, databaseCtx.Database.ID.String(), databaseCtx.OperationUserID, buildPermissionGrantedMsg(databaseCtx, expiresAt), true)
 	if err != nil {
 		logDatabaseContextWithIndex(databaseCtx, fmt.Sprintf("could not create log. Cause: %v", err), true)
 		return err
 	}
 
-	accessPermission, err := entity.NewAccessPermission(databaseCtx.Database.ID.String(), dbUserDTO.ID, databaseCtx.OperationUserID)
+	accessPermission, err := entity.NewAccessPermission(databaseCtx.Database.ID.String(), dbUserDTO.ID, databaseCtx.OperationUserID, expiresAt)
 	if err != nil {
 		logDatabaseContextWithIndex(databaseCtx, fmt.Sprintf("could not create access permission. Cause: %v", err), true)
 		return err
 	}
+	if databaseRole != nil {
+		accessPermission.SetDatabaseRole(databaseRole.ID.String(), dbUserDTO.DatabaseRoleID)
+	}
+	accessPermission.Scope = scope
+	if accessGroupID := databaseCtx.UserCtx.InstanceCtx.GlobalCtx.AccessGroupID; accessGroupID != "" {
+		accessPermission.GrantedByAccessGroupID = sql.NullString{String: accessGroupID, Valid: true}
+	}
 
 	return useCase.AccessPermissionStorage.Save(accessPermission)
 }
 
+// grantConnect godoc
+// Grants the access to the database with the scope, the role informed, or the own role of the user. The users with any
+// scoped permission in the instance are no longer members of their role, so they receive it in each database.
+func (useCase *GrantAccessPermissionUseCase) grantConnect(databaseCtx *databaseContextOnGrant, targetDatabase connector.DatabaseTCPConnectorInterface) error {
+	username := databaseCtx.UserCtx.DBUser.Username
+	if scope := databaseCtx.Scope(); !scope.IsEmpty() {
+		role := databaseCtx.EffectiveRole()
+		if role == nil {
+			return fmt.Errorf("%w: %s", ErrDatabaseRoleNotFound, databaseCtx.UserCtx.DBUser.DatabaseRoleID)
+		}
+		return targetDatabase.GrantConnectWithScope(username, connector.NewDatabaseRoles([]*entity.DatabaseRole{role})[0], scope)
+	}
+	if databaseRole := databaseCtx.DatabaseRole(); databaseRole != nil {
+		return targetDatabase.GrantConnectWithRole(username, string(databaseRole.Name))
+	}
+	if databaseCtx.UserCtx.Scoped {
+		return targetDatabase.GrantConnectWithRole(username, databaseCtx.UserCtx.DBUser.DatabaseRoleName)
+	}
+	return targetDatabase.GrantConnect(username)
+}
+
+func buildPermissionGrantedMsg(databaseCtx *databaseContextOnGrant, expiresAt *time.Time) string {
+	dbUserDTO := databaseCtx.UserCtx.DBUser
+	instanceName := databaseCtx.UserCtx.InstanceCtx.Instance.Name
+	msgGranted := fmt.Sprintf(PermissionGrantedMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceName)
+	if expiresAt != nil {
+		msgGranted = fmt.Sprintf(PermissionGrantedUntilMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceName, expiresAt.Format(time.RFC3339))
+	}
+	if databaseRole := databaseCtx.DatabaseRole(); databaseRole != nil {
+		msgGranted += fmt.Sprintf(WithDatabaseRoleMsg, databaseRole.DisplayName)
+	}
+	if scope := databaseCtx.Scope(); !scope.IsEmpty() {
+		msgGranted += fmt.Sprintf(WithScopeMsg, scope.String())
+	}
+	return msgGranted
+}
+
+// updatePermission godoc
+// Extends the expiration of the permission the user already has in the database to the one of the grant, and makes it
+// a direct grant when it was granted by an access group and the grant is direct, so removing the user from the group
+// keeps it. The access itself is already granted, so errPermissionUpdated is returned to skip the grant. Nothing is
+// changed in dry run.
+func (useCase *GrantAccessPermissionUseCase) updatePermission(databaseCtx *databaseContextOnGrant, extendsExpiration, grantsDirectly bool) error {
+	globalCtx := databaseCtx.UserCtx.InstanceCtx.GlobalCtx
+	dbUserDTO := databaseCtx.UserCtx.DBUser
+	instanceDTO := databaseCtx.UserCtx.InstanceCtx.Instance
+	databaseID := databaseCtx.Database.ID.String()
+	if extendsExpiration {
+		msg := fmt.Sprintf(PermissionExpirationUpdatedMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceDTO.Name, globalCtx.ExpiresAt.Format(time.RFC3339))
+		err := useCase.applyPermissionUpdate(databaseCtx, msg, func() error {
+			return useCase.AccessPermissionStorage.UpdateExpiresAt(databaseID, dbUserDTO.ID, globalCtx.ExpiresAt)
+		})
+		if err != nil {
+			logDatabaseContextWithIndex(databaseCtx, fmt.Sprintf("could not change the expiration of the permission. Cause: %v", err), true)
+			return err
+		}
+	}
+	if grantsDirectly {
+		msg := fmt.Sprintf(PermissionGrantedDirectlyMsg, dbUserDTO.Username, databaseCtx.Database.Name, instanceDTO.Name)
+		err := useCase.applyPermissionUpdate(databaseCtx, msg, func() error {
+			return useCase.AccessPermissionStorage.UpdateAccessGroup(databaseID, dbUserDTO.ID, sql.NullString{})
+		})
+		if err != nil {
+			logDatabaseContextWithIndex(databaseCtx, fmt.Sprintf("could not change the origin of the permission. Cause: %v", err), true)
+			return err
+		}
+	}
+	return errPermissionUpdated
+}
+
+func (useCase *GrantAccessPermissionUseCase) applyPermissionUpdate(databaseCtx *databaseContextOnGrant, msg string, update func() error) error {
+	if databaseCtx.UserCtx.InstanceCtx.GlobalCtx.DryRun() {
+		logDatabaseContextWithIndex(databaseCtx, msg, false)
+		return nil
+	}
+	if err := update(); err != nil {
+		return err
+	}
+	logDatabaseContextWithIndex(databaseCtx, msg, false)
+	instanceID := databaseCtx.UserCtx.InstanceCtx.Instance.ID
+	return useCase.newLog(instanceID, databaseCtx.UserCtx.DBUser.ID, databaseCtx.Database.ID.String(), databaseCtx.OperationUserID, msg, true)
+}
+
+// findPermission godoc
+// Finds the permission the user already has in the database, or nil when it no longer exists
+func (useCase *GrantAccessPermissionUseCase) findPermission(databaseCtx *databaseContextOnGrant) (*dto.AccessPermissionOutputDTO, error) {
+	permissions, err := useCase.AccessPermissionStorage.FindAllDTOs(databaseCtx.Database.ID.String(), databaseCtx.UserCtx.DBUser.ID, "")
+	if err != nil || len(permissions) == 0 {
+		return nil, err
+	}
+	return permissions[0], nil
+}
+
+// extendsExpiration godoc
+// Tells if the grant expires later than the permission the user already has in the database. A permanent permission is
+// never shortened to the expiration of the grant, nor is an expiration added to it.
+func extendsExpiration(expiresAt *time.Time, permission *dto.AccessPermissionOutputDTO) bool {
+	return expiresAt != nil && permission != nil && permission.ExpiresAt != nil && expiresAt.After(*permission.ExpiresAt)
+}
+
+// grantsDirectly godoc
+// Tells if a direct grant takes over the permission an access group granted to the user in the database
+func grantsDirectly(accessGroupID string, permission *dto.AccessPermissionOutputDTO) bool {
+	return accessGroupID == "" && permission != nil && permission.GrantedByAccessGroupID != ""
+}
+
 func (useCase *GrantAccessPermissionUseCase) validateDatabase(databaseCtx *databaseContextOnGrant) error {
 	currentDBName := databaseCtx.Database.Name
 	currentUser := databaseCtx.UserCtx.DBUser.Username
-	if databaseCtx.UserCtx.InstanceCtx.GlobalCtx.ForbiddenDatabases[currentDBName] && !entity.CheckRoleApplication(databaseCtx.UserCtx.DBUser.DatabaseRoleName) {
+	if databaseCtx.UserCtx.InstanceCtx.GlobalCtx.ForbiddenDatabases[currentDBName] && !entity.CheckRoleApplication(databaseCtx.RoleName()) {
 		logMsgPt := fmt.Sprintf(ErrDatabaseForbiddenMsg, currentDBName, currentUser)
 		return useCase.registerDatabaseValidationError(databaseCtx, logMsgPt, ErrDatabaseForbidden)
 	}
@@ -318,6 +642,17 @@ func (useCase *GrantAccessPermissionUseCase) validateDatabase(databaseCtx *datab
 	}
 	instanceFromDB := databaseCtx.UserCtx.InstanceCtx.Instance
 	if exists {
+		permission, err := useCase.findPermission(databaseCtx)
+		if err != nil {
+			logDatabaseContextWithIndex(databaseCtx, fmt.Sprintf("could not find the permission of the user. Cause: %v", err), true)
+			return err
+		}
+		globalCtx := databaseCtx.UserCtx.InstanceCtx.GlobalCtx
+		extends := extendsExpiration(globalCtx.ExpiresAt, permission)
+		direct := grantsDirectly(globalCtx.AccessGroupID, permission)
+		if extends || direct {
+			return useCase.updatePermission(databaseCtx, extends, direct)
+		}
 		logMsgPt := fmt.Sprintf(ErrUserAlreadyHasPermissionMsg, currentUser, currentDBName, instanceFromDB.Name)
 		return useCase.registerDatabaseValidationError(databaseCtx, logMsgPt, ErrUserAlreadyHasPermission)
 	}
@@ -335,22 +670,27 @@ func (useCase *GrantAccessPermissionUseCase) validateDatabase(databaseCtx *datab
 
 func (useCase *GrantAccessPermissionUseCase) registerInstanceValidationError(instanceCtx *instanceContextOnGrant, logMsgPt string, errorToThrow error) error {
 	logInstanceContextWithIndex(instanceCtx, errorToThrow.Error(), true)
-	return useCase.registerLogAndThrowError(instanceCtx.Instance.ID, "", "", instanceCtx.GlobalCtx.OperationUserID, logMsgPt, errorToThrow)
+	return useCase.registerLogAndThrowError(instanceCtx.GlobalCtx, instanceCtx.Instance.ID, "", "", logMsgPt, errorToThrow)
 }
 
 func (useCase *GrantAccessPermissionUseCase) registerUserValidationError(userCtx *userContextOnGrant, logMsgPt string, errorToThrow error) error {
 	logUserContextWithIndex(userCtx, errorToThrow.Error(), true)
-	return useCase.registerLogAndThrowError(userCtx.InstanceCtx.Instance.ID, userCtx.DBUser.ID, "", userCtx.OperationUserID, logMsgPt, errorToThrow)
+	return useCase.registerLogAndThrowError(userCtx.InstanceCtx.GlobalCtx, userCtx.InstanceCtx.Instance.ID, userCtx.DBUser.ID, "", logMsgPt, errorToThrow)
 }
 
 func (useCase *GrantAccessPermissionUseCase) registerDatabaseValidationError(databaseCtx *databaseContextOnGrant, logMsgPt string, errorToThrow error) error {
 	logDatabaseContextWithIndex(databaseCtx, errorToThrow.Error(), true)
 	instanceID := databaseCtx.UserCtx.InstanceCtx.Instance.ID
-	return useCase.registerLogAndThrowError(instanceID, databaseCtx.UserCtx.DBUser.ID, databaseCtx.Database.ID.String(), databaseCtx.OperationUserID, logMsgPt, errorToThrow)
+	return useCase.registerLogAndThrowError(databaseCtx.UserCtx.InstanceCtx.GlobalCtx, instanceID, databaseCtx.UserCtx.DBUser.ID, databaseCtx.Database.ID.String(), logMsgPt, errorToThrow)
 }
 
-func (useCase *GrantAccessPermissionUseCase) registerLogAndThrowError(instanceID, dbUserID, databaseID, operationUserID, logMsgPt string, errorToThrow error) error {
-	errLog := useCase.newLog(instanceID, dbUserID, databaseID, operationUserID, logMsgPt, false)
+// registerLogAndThrowError godoc
+// Persists the log of the error and returns the error. Nothing is persisted in dry run.
+func (useCase *GrantAccessPermissionUseCase) registerLogAndThrowError(globalCtx *globalContextOnGrant, instanceID, dbUserID, databaseID, logMsgPt string, errorToThrow error) error {
+	if globalCtx.DryRun() {
+		return errorToThrow
+	}
+	errLog := useCase.newLog(instanceID, dbUserID, databaseID, globalCtx.OperationUserID, logMsgPt, false)
 	if errLog != nil {
 		return errLog
 	}
@@ -373,6 +713,7 @@ func (useCase *GrantAccessPermissionUseCase) newLog(instanceID, dbUserID, databa
 }
 
 func logInstanceContextWithIndex(instanceCtx *instanceContextOnGrant, message string, isError bool) {
+	instanceCtx.GlobalCtx.Progress.Event(buildInstanceEvent(instanceCtx, message, isError))
 	if isError {
 		message = fmt.Sprintf("ERROR: %s", message)
 	}
@@ -380,6 +721,7 @@ func logInstanceContextWithIndex(instanceCtx *instanceContextOnGrant, message st
 }
 
 func logUserContextWithIndex(userCtx *userContextOnGrant, message string, isError bool) {
+	userCtx.InstanceCtx.GlobalCtx.Progress.Event(buildUserEvent(userCtx, message, isError))
 	if isError {
 		message = fmt.Sprintf("ERROR: %s", message)
 	}
@@ -390,6 +732,7 @@ func logUserContextWithIndex(userCtx *userContextOnGrant, message string, isErro
 func logDatabaseContextWithIndex(databaseCtx *databaseContextOnGrant, message string, isError bool) {
 	instanceCtx := databaseCtx.UserCtx.InstanceCtx
 	globalCtx := instanceCtx.GlobalCtx
+	globalCtx.Progress.Event(buildDatabaseEvent(databaseCtx, message, isError))
 	if isError {
 		message = fmt.Sprintf("ERROR: %s", message)
 	}
@@ -400,6 +743,67 @@ func logDatabaseContextWithIndex(databaseCtx *databaseContextOnGrant, message st
 		instanceCtx.Instance.Name, databaseCtx.UserCtx.DBUser.Username, databaseCtx.Database.Name, message)
 }
 
+func buildInstanceEvent(instanceCtx *instanceContextOnGrant, message string, isError bool) dto.ProgressEventDTO {
+	return dto.ProgressEventDTO{
+		Instance:      instanceCtx.Instance.Name,
+		InstanceIndex: instanceCtx.InstanceIndex + 1,
+		InstancesQty:  instanceCtx.GlobalCtx.InstancesQty,
+		Message:       message,
+		Error:         isError,
+		Time:          time.Now(),
+	}
+}
+
+func buildUserEvent(userCtx *userContextOnGrant, message string, isError bool) dto.ProgressEventDTO {
+	event := buildInstanceEvent(userCtx.InstanceCtx, message, isError)
+	event.User = userCtx.DBUser.Username
+	event.UserIndex = userCtx.UserIndex + 1
+	event.UsersQty = userCtx.InstanceCtx.GlobalCtx.UsersQty
+	return event
+}
+
+func buildDatabaseEvent(databaseCtx *databaseContextOnGrant, message string, isError bool) dto.ProgressEventDTO {
+	event := buildUserEvent(databaseCtx.UserCtx, message, isError)
+	event.Database = databaseCtx.Database.Name
+	event.DatabaseIndex = databaseCtx.DatabaseIndex + 1
+	event.DatabasesQty = databaseCtx.DatabasesQty
+	return event
+}
+
+// reportInstanceFailed godoc
+// When the instance can't be processed none of its users will be, so they are replaced by a single item for the instance
+func reportInstanceFailed(instanceCtx *instanceContextOnGrant, err error) {
+	progress := instanceCtx.GlobalCtx.Progress
+	progress.AddItems(1 - instanceCtx.GlobalCtx.UsersQty)
+	progress.ItemProcessed(dto.ItemResultDTO{Item: instanceCtx.Instance.Name, Success: false, Message: err.Error()})
+}
+
+// databaseProcessed godoc
+// Reports the user once the last of its databases is processed. A user that already had access to a database is not
+// considered a failure, so a grant processed again reports the users that already have access as successful.
+func databaseProcessed(userCtx *userContextOnGrant, databasesQty int, err error) {
+	if err != nil && !errors.Is(err, ErrUserAlreadyHasPermission) {
+		userCtx.FailedDatabases.Add(1)
+	}
+	if userCtx.PendingDatabases.Add(-1) > 0 {
+		return
+	}
+	failedQty := int(userCtx.FailedDatabases.Load())
+	if failedQty > 0 {
+		reportUser(userCtx, false, fmt.Sprintf(UserDatabasesFailedMsg, failedQty, databasesQty))
+		return
+	}
+	reportUser(userCtx, true, fmt.Sprintf(UserDatabasesProcessedMsg, databasesQty))
+}
+
+func reportUser(userCtx *userContextOnGrant, success bool, message string) {
+	userCtx.InstanceCtx.GlobalCtx.Progress.ItemProcessed(dto.ItemResultDTO{
+		Item:    fmt.Sprintf("%s # %s", userCtx.InstanceCtx.Instance.Name, userCtx.DBUser.Username),
+		Success: success,
+		Message: message,
+	})
+}
+
 func buildGrantAccessOutput(errCh chan error) *dto.GrantAccessOutputDTO {
 	output := &dto.GrantAccessOutputDTO{
 		HasErrors: false,
@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -19,7 +21,7 @@ func TestGivenAnErrorInDbWhenFetchingDBUsers_WhenExecuteGrantAccess_ThenShouldRe
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindAllDTOs", []string{}).Return([]*dto.DatabaseUserOutputDTO{}, sql.ErrConnDone).Once()
 
-	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, nil, nil, nil)
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, nil, nil, nil, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -34,7 +36,7 @@ func TestGivenAnErrorInDbWhenFetchingInstances_WhenExecuteGrantAccess_ThenShould
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{}).Return([]*dto.DatabaseInstanceOutputDTO{}, sql.ErrConnDone).Once()
 
-	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, nil)
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, nil, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -52,7 +54,7 @@ func TestGivenAnErrorInDbWhenFetchingForbiddenDatabases_WhenExecuteGrantAccess_T
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), sql.ErrConnDone).Once()
 
-	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -70,11 +72,12 @@ func TestGivenAnErrorInDbWhenSavingLog_WhenExecuteGrantAccess_ThenShouldReturnOu
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	accessPermissionStorage.On("SaveLog", mock.Anything).Return(sql.ErrConnDone).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{}, InstancesData: []dto.InstanceDataDTO{{DatabaseInstanceID: instance.ID}}}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -102,7 +105,7 @@ func TestGivenAnInstanceWithoutRolesCreated_WhenExecuteGrantAccess_ThenShouldRet
 
 func TestGivenAnErrorWhenCreatingConnector_WhenExecuteGrantAccess_ThenShouldReturnOutputError(t *testing.T) {
 	instance := mocks.BuildConnectorNotImplementedInstance()
-	expectedLogMsg := fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, "the database technology 'mysql' don't have a connector implemented")
+	expectedLogMsg := fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, "the database technology 'oracle' don't have a connector implemented")
 	runGrantLoggingSingleError(t, nil, instance, nil, expectedLogMsg, false)
 }
 
@@ -143,6 +146,7 @@ func TestGivenAnErrorInDBWhenFetchingDatabases_WhenExecuteGrantAccess_ThenShould
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	userCreatedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, "", fmt.Sprintf(UserCreatedMsg, dbUser.Username, instance.Name), mocks.UserID, true)
 	errFetchingDbsLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, "", fmt.Sprintf(ErrFetchingDatabasesMsg, instance.Name, sql.ErrConnDone.Error()), mocks.UserID, false)
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(userCreatedLog)).Return(nil).Once()
@@ -152,7 +156,7 @@ func TestGivenAnErrorInDBWhenFetchingDatabases_WhenExecuteGrantAccess_ThenShould
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{dbUser.ID}, InstancesData: []dto.InstanceDataDTO{{DatabaseInstanceID: instance.ID}}}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -218,13 +222,14 @@ func TestGivenAnErrorWhenCheckingUserPermission_WhenExecuteGrantAccess_ThenShoul
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, sql.ErrConnDone).Once()
 	dbStorage := new(mocks.DatabaseStorageMock)
 	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID)
 
 	assert.NoError(t, err)
@@ -248,13 +253,14 @@ func TestGivenAnErrorWhenCreatingLog_WhenExecuteGrantAccess_ThenShouldReturnOutp
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
 	dbStorage := new(mocks.DatabaseStorageMock)
 	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(buildGrantInput(dbUser, instance, []*entity.Database{database}), "")
 
 	assert.NoError(t, err)
@@ -281,6 +287,7 @@ func TestGivenValidInputWithForbiddenDatabases_WhenExecuteGrantAccess_ThenShould
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	logMsg := fmt.Sprintf(PermissionGrantedMsg, dbUser.Username, notForbiddenDB.Name, instance.Name)
 	logForbiddenMsg := fmt.Sprintf(ErrDatabaseForbiddenMsg, databases[0].Name, dbUser.Username)
 	logForbiddenMsg2 := fmt.Sprintf(ErrDatabaseForbiddenMsg, databases[1].Name, dbUser.Username)
@@ -291,20 +298,21 @@ func TestGivenValidInputWithForbiddenDatabases_WhenExecuteGrantAccess_ThenShould
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(forbiddenLog)).Return(nil).Once()
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(forbiddenLog2)).Return(nil).Once()
-	expectedAccess, _ := entity.NewAccessPermission(allowedDbID, dbUser.ID, mocks.UserID)
+	expectedAccess, _ := entity.NewAccessPermission(allowedDbID, dbUser.ID, mocks.UserID, nil)
 	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
 	dbStorage := new(mocks.DatabaseStorageMock)
 	dbStorage.On("FindAll", instance.ID, []string{allowedDbID, forbiddenDbID, forbiddenDbID2}).Return(databases, nil).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(buildGrantInput(dbUser, instance, databases), mocks.UserID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, output)
 	assert.False(t, output.HasErrors)
 	assert.Equal(t, AccessGrantedMsg, output.Message)
+	assert.Equal(t, 5, output.Execution.Tasks, "one task for the instance, one for the user and one for each database")
 	dbUserStorage.AssertNumberOfCalls(t, "FindAllDTOs", 1)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindAllDTOs", 1)
 	forbiddenObjStorage.AssertNumberOfCalls(t, "FindAllDatabases", 1)
@@ -324,18 +332,19 @@ func TestGivenValidInput_WhenExecuteGrantAccess_ThenShouldReturnOutputSuccess(t
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	logMsg := fmt.Sprintf(PermissionGrantedMsg, dbUser.Username, database.Name, instance.Name)
 	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
 	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
-	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID)
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
 	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
 	dbStorage := new(mocks.DatabaseStorageMock)
 	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID)
 
 	assert.NoError(t, err)
@@ -351,6 +360,530 @@ func TestGivenValidInput_WhenExecuteGrantAccess_ThenShouldReturnOutputSuccess(t
 	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
 }
 
+func TestGivenDryRun_WhenExecuteGrantAccess_ThenShouldReturnThePlanWithoutGrantingOrSaving(t *testing.T) {
+	dbUser := mocks.BuildDbUserDummyDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.DryRun = true
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, AccessGrantDryRunMsg, output.Message)
+	assert.Len(t, output.Plan, 1)
+	assert.Equal(t, instance.ID, output.Plan[0].DatabaseInstanceID)
+	assert.Equal(t, instance.Name, output.Plan[0].Instance)
+	expectedDatabases := []dto.DryRunDatabaseOutputDTO{
+		{Database: "dummy-test-db", Statements: []string{"CREATE USER dummy-user PASSWORD ******** IN ROLE user_ro"}},
+		{Database: database.Name, Statements: []string{"GRANT CONNECT ON settings TO dummy-user"}},
+	}
+	assert.Equal(t, expectedDatabases, output.Plan[0].Databases, "the password should never be in the plan")
+	accessPermissionStorage.AssertNotCalled(t, "SaveLog", mock.Anything)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenAnExpiration_WhenExecuteGrantAccess_ThenShouldGrantAnAccessThatExpires(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	expiresAt := time.Now().Add(4 * time.Hour)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	logMsg := fmt.Sprintf(PermissionGrantedUntilMsg, dbUser.Username, database.Name, instance.Name, expiresAt.Format(time.RFC3339))
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, &expiresAt)
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.ExpiresAt = &expiresAt
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestGivenAnExpirationForAnAccessAlreadyExistent_WhenExecuteGrantAccess_ThenShouldUpdateItsExpirationWithoutGrantingAgain(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	expiresAt := time.Now().Add(4 * time.Hour)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	currentExpiresAt := time.Now().Add(time.Hour)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(true, nil).Once()
+	accessPermissionStorage.On("FindAllDTOs", dbID, dbUser.ID, "").Return([]*dto.AccessPermissionOutputDTO{{DatabaseID: dbID, DatabaseUserID: dbUser.ID, ExpiresAt: &currentExpiresAt}}, nil).Once()
+	accessPermissionStorage.On("UpdateExpiresAt", dbID, dbUser.ID, &expiresAt).Return(nil).Once()
+	logMsg := fmt.Sprintf(PermissionExpirationUpdatedMsg, dbUser.Username, database.Name, instance.Name, expiresAt.Format(time.RFC3339))
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.ExpiresAt = &expiresAt
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, AccessGrantedMsg, output.Message)
+	accessPermissionStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenAnExpirationForAPermanentAccess_WhenExecuteGrantAccess_ThenShouldKeepItPermanent(t *testing.T) {
+	runGrantWithExpirationOfExistentAccess(t, nil)
+}
+
+func TestGivenAnExpirationSoonerThanTheOneOfTheExistentAccess_WhenExecuteGrantAccess_ThenShouldNotShortenIt(t *testing.T) {
+	currentExpiresAt := time.Now().Add(8 * time.Hour)
+	runGrantWithExpirationOfExistentAccess(t, &currentExpiresAt)
+}
+
+func TestGivenAGrantOfAnAccessGroup_WhenExecuteGrantAccess_ThenShouldKeepTheGroupInThePermission(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
+	expectedAccess.GrantedByAccessGroupID = sql.NullString{String: mocks.AccessGroupID, Valid: true}
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.AccessGroupID = mocks.AccessGroupID
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertExpectations(t)
+}
+
+func TestGivenADirectGrantOfAnAccessOfAnAccessGroup_WhenExecuteGrantAccess_ThenShouldMakeItADirectGrant(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(true, nil).Once()
+	accessPermissionStorage.On("FindAllDTOs", dbID, dbUser.ID, "").Return([]*dto.AccessPermissionOutputDTO{{DatabaseID: dbID, DatabaseUserID: dbUser.ID, GrantedByAccessGroupID: mocks.AccessGroupID}}, nil).Once()
+	accessPermissionStorage.On("UpdateAccessGroup", dbID, dbUser.ID, sql.NullString{}).Return(nil).Once()
+	logMsg := fmt.Sprintf(PermissionGrantedDirectlyMsg, dbUser.Username, database.Name, instance.Name)
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenAnExpirationAlreadyPassed_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	expiredAt := time.Now().Add(-time.Minute)
+
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, nil, nil, nil, nil)
+	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{mocks.DbUserID}, ExpiresAt: &expiredAt}, mocks.UserID)
+
+	assert.ErrorIs(t, err, dto.ErrExpiresAtNotInTheFuture)
+	assert.Nil(t, output)
+	dbUserStorage.AssertNotCalled(t, "FindAllDTOs", mock.Anything)
+}
+
+func TestGivenAnInstanceOfAnEcosystemRequiringApproval_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	instance := mocks.BuildAzInstanceDTO()
+	instance.EcosystemRequiresApproval = true
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{mocks.DbUserID}).Return([]*dto.DatabaseUserOutputDTO{}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage, nil)
+	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{mocks.DbUserID}, InstancesData: []dto.InstanceDataDTO{{DatabaseInstanceID: instance.ID}}}, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrAccessApprovalRequired)
+	assert.ErrorContains(t, err, instance.Name)
+	assert.Nil(t, output)
+	forbiddenObjStorage.AssertNotCalled(t, "FindAllDatabases")
+}
+
+func TestGivenAnApprovedAccessRequest_WhenExecuteApprovedGrantAccess_ThenShouldGrantInEcosystemsRequiringApproval(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	instance.EcosystemRequiresApproval = true
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.ExecuteApproved(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, AccessGrantedMsg, output.Message)
+	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestGivenARoleForADatabase_WhenExecuteGrantAccess_ThenShouldGrantTheAccessWithTheRole(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	dbUser.DatabaseRoleID = mocks.BuildReadOnlyRole().ID.String()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	role := mocks.BuildDeveloperRole()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	logMsg := fmt.Sprintf(PermissionGrantedMsg, dbUser.Username, database.Name, instance.Name) + fmt.Sprintf(WithDatabaseRoleMsg, role.DisplayName)
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
+	expectedAccess.SetDatabaseRole(role.ID.String(), dbUser.DatabaseRoleID)
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesRolesIDs = map[string]string{dbID: role.ID.String()}
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, roleStorage)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.True(t, expectedAccess.DatabaseRoleID.Valid)
+	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+}
+
+func TestGivenAScopeForADatabase_WhenExecuteGrantAccess_ThenShouldGrantAgainTheUnscopedDatabasesAndSaveTheScope(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	unscopedPermission := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseID: uuid.NewString(), DatabaseName: "orders", DatabaseRoleID: dbUser.DatabaseRoleID}
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", []string{dbUser.ID}).Return(map[string][]string{}, nil).Once()
+	accessPermissionStorage.On("FindAllDTOs", "", dbUser.ID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{unscopedPermission}, nil).Once()
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	scope := entity.AccessScope{Schemas: []string{"reporting"}, Tables: []string{"public.orders"}}
+	logMsg := fmt.Sprintf(PermissionGrantedMsg, dbUser.Username, database.Name, instance.Name) + fmt.Sprintf(WithScopeMsg, scope.String())
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
+	expectedAccess.Scope = scope
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesScopes = map[string]dto.AccessScopeDTO{dbID: {Schemas: []string{"reporting", " reporting"}, Tables: []string{"public.orders"}}}
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, roleStorage)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertNumberOfCalls(t, "FindAllDTOs", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+}
+
+func TestGivenAUserAlreadyScopedInTheInstance_WhenExecuteGrantAccessWithoutScope_ThenShouldNotGrantAgainTheOtherDatabases(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", []string{dbUser.ID}).Return(map[string][]string{dbUser.ID: {instance.ID}}, nil).Once()
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	expectedAccess, _ := entity.NewAccessPermission(dbID, dbUser.ID, mocks.UserID, nil)
+	accessPermissionStorage.On("Save", compareAccessPermission(expectedAccess)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	accessPermissionStorage.AssertNotCalled(t, "FindAllDTOs", mock.Anything, mock.Anything, mock.Anything)
+	accessPermissionStorage.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestGivenAnInvalidScopeForADatabase_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesScopes = map[string]dto.AccessScopeDTO{database.ID.String(): {Tables: []string{"db.public.orders"}}}
+
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, nil, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, entity.ErrInvalidScopeObject)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnUnknownRoleForADatabase_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesRolesIDs = map[string]string{database.ID.String(): uuid.NewString()}
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, nil, nil, roleStorage)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrDatabaseRoleNotFound)
+	assert.Nil(t, output)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenARoleForADatabaseCoveredByTheOwnRole_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", []string{dbUser.ID}).Return(map[string][]string{}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesRolesIDs = map[string]string{database.ID.String(): mocks.BuildReadOnlyRole().ID.String()}
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage, roleStorage)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrDatabaseRoleCoveredByOwn)
+	assert.Nil(t, output)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenARoleForADatabaseCoveredByTheOwnRoleInAScopedInstance_WhenExecuteGrantAccess_ThenShouldAcceptTheRole(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	roles := mocks.BuildRolesList()
+	rolesByDatabase := map[string]*entity.DatabaseRole{database.ID.String(): mocks.BuildReadOnlyRole()}
+	instancesData := []dto.InstanceDataDTO{{
+		DatabaseInstanceID: instance.ID,
+		DatabasesIDs:       []string{database.ID.String()},
+		DatabasesRolesIDs:  map[string]string{database.ID.String(): mocks.BuildReadOnlyRole().ID.String()},
+	}}
+
+	err := validateDatabasesRoles(instancesData, []*dto.DatabaseUserOutputDTO{dbUser}, roles, rolesByDatabase, nil, map[string][]string{dbUser.ID: {instance.ID}})
+
+	assert.NoError(t, err)
+}
+
+func TestGivenTheApplicationRoleForADatabase_WhenExecuteGrantAccess_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	applicationRole := &entity.DatabaseRole{ID: uuid.New(), Name: entity.Application, DisplayName: "Application"}
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(append(mocks.BuildRolesList(), applicationRole), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.InstancesData[0].DatabasesRolesIDs = map[string]string{database.ID.String(): applicationRole.ID.String()}
+
+	uc := NewGrantAccessPermissionUseCase(nil, dbUserStorage, dbInstanceStorage, nil, nil, roleStorage)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrApplicationRoleNotAllowed)
+	assert.Nil(t, output)
+}
+
+func TestGivenAProgressReporter_WhenExecuteGrantAccess_ThenShouldReportTheEventsAndTheUserProcessed(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(false, nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	accessPermissionStorage.On("Save", mock.Anything).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	progress := new(mocks.ProgressReporterMock)
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.ExecuteWithProgress(buildGrantInput(dbUser, instance, []*entity.Database{database}), mocks.UserID, progress)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, 1, progress.Total())
+	assert.Equal(t, []dto.ItemResultDTO{{
+		Item:    instance.Name + " # " + dbUser.Username,
+		Success: true,
+		Message: fmt.Sprintf(UserDatabasesProcessedMsg, 1),
+	}}, progress.Items())
+	events := progress.Events()
+	assert.NotEmpty(t, events)
+	lastEvent := events[len(events)-1]
+	assert.Equal(t, "connect permission granted to user successfully!", lastEvent.Message)
+	assert.False(t, lastEvent.Error)
+	assert.Equal(t, instance.Name, lastEvent.Instance)
+	assert.Equal(t, 1, lastEvent.InstanceIndex)
+	assert.Equal(t, 1, lastEvent.InstancesQty)
+	assert.Equal(t, dbUser.Username, lastEvent.User)
+	assert.Equal(t, 1, lastEvent.UserIndex)
+	assert.Equal(t, database.Name, lastEvent.Database)
+	assert.Equal(t, 1, lastEvent.DatabaseIndex)
+	assert.Equal(t, 1, lastEvent.DatabasesQty)
+}
+
+func TestGivenADisabledInstanceAndAProgressReporter_WhenExecuteGrantAccess_ThenShouldReportTheInstanceAsFailed(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildQAInstanceDTO()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	progress := new(mocks.ProgressReporterMock)
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, nil, forbiddenObjStorage, nil)
+	output, err := uc.ExecuteWithProgress(buildGrantInput(dbUser, instance, nil), mocks.UserID, progress)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, 1, progress.Total())
+	assert.Equal(t, []dto.ItemResultDTO{{Item: instance.Name, Success: false, Message: ErrInstanceDisabled.Error()}}, progress.Items())
+	events := progress.Events()
+	assert.Len(t, events, 1)
+	assert.True(t, events[0].Error)
+	assert.Equal(t, ErrInstanceDisabled.Error(), events[0].Message)
+	assert.Empty(t, events[0].User)
+}
+
 func runGrantLoggingSingleError(t *testing.T, dbUser *dto.DatabaseUserOutputDTO, instance *dto.DatabaseInstanceOutputDTO, db *entity.Database, expectedLogMsg string, accessExists bool) {
 	dbUserID := getDBUserIDFromDTO(dbUser)
 	dbID := getDbID(db)
@@ -359,8 +892,12 @@ func runGrantLoggingSingleError(t *testing.T, dbUser *dto.DatabaseUserOutputDTO,
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
 	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUserID, dbID, expectedLogMsg, mocks.UserID, false)
 	accessPermissionStorage.On("Exists", dbID, dbUserID).Return(accessExists, nil).Once()
+	if accessExists {
+		accessPermissionStorage.On("FindAllDTOs", dbID, dbUserID, "").Return([]*dto.AccessPermissionOutputDTO{{DatabaseID: dbID, DatabaseUserID: dbUserID}}, nil).Once()
+	}
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
 	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
 	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
@@ -371,7 +908,7 @@ func runGrantLoggingSingleError(t *testing.T, dbUser *dto.DatabaseUserOutputDTO,
 		dbStorage.On("FindAllEnabled", instance.ID).Return([]*entity.Database{}, nil).Once()
 	}
 
-	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage)
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
 	output, err := uc.Execute(dto.GrantAccessInputDTO{DatabaseUsersIDs: []string{dbUserID}, InstancesData: []dto.InstanceDataDTO{{DatabaseInstanceID: instance.ID}}}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -388,6 +925,40 @@ func runGrantLoggingSingleError(t *testing.T, dbUser *dto.DatabaseUserOutputDTO,
 	}
 }
 
+func runGrantWithExpirationOfExistentAccess(t *testing.T, currentExpiresAt *time.Time) {
+	dbUser := mocks.BuildDbUserJohnDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	database := mocks.BuildSettingsDatabase()
+	dbID := database.ID.String()
+	expiresAt := time.Now().Add(4 * time.Hour)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string{dbUser.ID}).Return([]*dto.DatabaseUserOutputDTO{dbUser}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllScopedInstancesIDsByUsers", mock.Anything).Return(map[string][]string{}, nil)
+	accessPermissionStorage.On("Exists", dbID, dbUser.ID).Return(true, nil).Once()
+	accessPermissionStorage.On("FindAllDTOs", dbID, dbUser.ID, "").Return([]*dto.AccessPermissionOutputDTO{{DatabaseID: dbID, DatabaseUserID: dbUser.ID, ExpiresAt: currentExpiresAt}}, nil).Once()
+	logMsg := fmt.Sprintf(ErrUserAlreadyHasPermissionMsg, dbUser.Username, database.Name, instance.Name)
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUser.ID, dbID, logMsg, mocks.UserID, false)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbStorage := new(mocks.DatabaseStorageMock)
+	dbStorage.On("FindAll", instance.ID, []string{dbID}).Return([]*entity.Database{database}, nil).Once()
+	forbiddenObjStorage := new(mocks.ForbiddenObjectsStorageMock)
+	forbiddenObjStorage.On("FindAllDatabases").Return(mocks.BuildForbiddenDatabasesList(), nil).Once()
+	input := buildGrantInput(dbUser, instance, []*entity.Database{database})
+	input.ExpiresAt = &expiresAt
+
+	uc := NewGrantAccessPermissionUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, dbStorage, forbiddenObjStorage, nil)
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	accessPermissionStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertNotCalled(t, "UpdateExpiresAt", mock.Anything, mock.Anything, mock.Anything)
+	accessPermissionStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
 func buildGrantInput(dbUser *dto.DatabaseUserOutputDTO, instance *dto.DatabaseInstanceOutputDTO, databases []*entity.Database) dto.GrantAccessInputDTO {
 	databaseIDs := make([]string, len(databases))
 	for i, db := range databases {
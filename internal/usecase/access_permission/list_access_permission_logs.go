@@ -18,12 +18,14 @@ func NewListAccessPermissionLogsUseCase(permissionStorage storage.AccessPermissi
 	}
 }
 
-func (uc *ListAccessPermissionLogsUseCase) Execute(page, limit int) ([]*dto.AccessPermissionLogOutputDTO, int, error) {
-	logsDTOs, err := uc.AccessPermissionStorage.FindAllLogsDTOs(page, limit)
+// Execute godoc
+// Lists the logs from the most recent, only of the given type when it's informed
+func (uc *ListAccessPermissionLogsUseCase) Execute(logType string, page, limit int) ([]*dto.AccessPermissionLogOutputDTO, int, error) {
+	logsDTOs, err := uc.AccessPermissionStorage.FindAllLogsDTOs(logType, page, limit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error fetching access permission logs! Cause: %w", err)
 	}
-	totalCount, err := uc.AccessPermissionStorage.LogCount()
+	totalCount, err := uc.AccessPermissionStorage.LogCount(logType)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error fetching access permission logs count! Cause: %w", err)
 	}
@@ -7,15 +7,16 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
 )
 
 func TestGivenAnErrorInDb_WhenExecuteListAccessPermissionLogs_ThenShouldReturnError(t *testing.T) {
 	accessStorage := new(mocks.AccessPermissionStorageMock)
-	accessStorage.On("FindAllLogsDTOs", mocks.DefaultPage, mocks.DefaultLimit).Return([]*dto.AccessPermissionLogOutputDTO{}, sql.ErrConnDone).Once()
+	accessStorage.On("FindAllLogsDTOs", "", mocks.DefaultPage, mocks.DefaultLimit).Return([]*dto.AccessPermissionLogOutputDTO{}, sql.ErrConnDone).Once()
 
 	uc := NewListAccessPermissionLogsUseCase(accessStorage)
-	accessObtained, totalCount, err := uc.Execute(mocks.DefaultPage, mocks.DefaultLimit)
+	accessObtained, totalCount, err := uc.Execute("", mocks.DefaultPage, mocks.DefaultLimit)
 
 	assert.Error(t, err, "error expected when some error in db")
 	assert.EqualError(t, err, "error fetching access permission logs! Cause: sql: connection is already closed")
@@ -27,11 +28,11 @@ func TestGivenAnErrorInDb_WhenExecuteListAccessPermissionLogs_ThenShouldReturnEr
 
 func TestGivenAnErrorInDb_WhenExecuteLogCount_ThenShouldReturnError(t *testing.T) {
 	accessStorage := new(mocks.AccessPermissionStorageMock)
-	accessStorage.On("FindAllLogsDTOs", mocks.DefaultPage, mocks.DefaultLimit).Return(mocks.BuildAccessPermissionLogDTOList(), nil).Once()
-	accessStorage.On("LogCount").Return(0, sql.ErrConnDone).Once()
+	accessStorage.On("FindAllLogsDTOs", "", mocks.DefaultPage, mocks.DefaultLimit).Return(mocks.BuildAccessPermissionLogDTOList(), nil).Once()
+	accessStorage.On("LogCount", "").Return(0, sql.ErrConnDone).Once()
 
 	uc := NewListAccessPermissionLogsUseCase(accessStorage)
-	accessObtained, totalCount, err := uc.Execute(mocks.DefaultPage, mocks.DefaultLimit)
+	accessObtained, totalCount, err := uc.Execute("", mocks.DefaultPage, mocks.DefaultLimit)
 
 	assert.Error(t, err, "error expected when some error in db")
 	assert.EqualError(t, err, "error fetching access permission logs count! Cause: sql: connection is already closed")
@@ -45,11 +46,11 @@ func TestGivenAnErrorInDb_WhenExecuteLogCount_ThenShouldReturnError(t *testing.T
 func TestGivenSomeLogs_WhenExecuteListAccessPermissionLogs_ThenShouldListAllPermissionLogs(t *testing.T) {
 	logList := mocks.BuildAccessPermissionLogDTOList()
 	accessStorage := new(mocks.AccessPermissionStorageMock)
-	accessStorage.On("FindAllLogsDTOs", mocks.DefaultPage, mocks.DefaultLimit).Return(logList, nil).Once()
-	accessStorage.On("LogCount").Return(len(logList), nil).Once()
+	accessStorage.On("FindAllLogsDTOs", "", mocks.DefaultPage, mocks.DefaultLimit).Return(logList, nil).Once()
+	accessStorage.On("LogCount", "").Return(len(logList), nil).Once()
 
 	uc := NewListAccessPermissionLogsUseCase(accessStorage)
-	permissionsObtained, totalCount, err := uc.Execute(mocks.DefaultPage, mocks.DefaultLimit)
+	permissionsObtained, totalCount, err := uc.Execute("", mocks.DefaultPage, mocks.DefaultLimit)
 
 	assert.NoError(t, err, "no error expected")
 	assert.Equal(t, len(permissionsObtained), len(logList), "3 access permission logs expected")
@@ -57,3 +58,19 @@ func TestGivenSomeLogs_WhenExecuteListAccessPermissionLogs_ThenShouldListAllPerm
 	accessStorage.AssertNumberOfCalls(t, "FindAllLogsDTOs", 1)
 	accessStorage.AssertNumberOfCalls(t, "LogCount", 1)
 }
+
+func TestGivenALogType_WhenExecuteListAccessPermissionLogs_ThenShouldListOnlyTheLogsOfTheType(t *testing.T) {
+	logType := string(entity.AccessPermissionLogTypeBreakGlass)
+	logList := mocks.BuildAccessPermissionLogDTOList()[:1]
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllLogsDTOs", logType, mocks.DefaultPage, mocks.DefaultLimit).Return(logList, nil).Once()
+	accessStorage.On("LogCount", logType).Return(len(logList), nil).Once()
+
+	uc := NewListAccessPermissionLogsUseCase(accessStorage)
+	logsObtained, totalCount, err := uc.Execute(logType, mocks.DefaultPage, mocks.DefaultLimit)
+
+	assert.NoError(t, err, "no error expected")
+	assert.Len(t, logsObtained, 1)
+	assert.Equal(t, 1, totalCount)
+	accessStorage.AssertExpectations(t)
+}
@@ -0,0 +1,266 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var (
+	ErrSameDatabaseUserRole               = errors.New("the database user already has this role")
+	ErrApplicationRoleMigrationNotAllowed = errors.New("the role of the user can't be migrated from or to the application role, the user must be created again")
+	ErrScopedUserRoleMigrationNotAllowed  = errors.New("the role of a user with access scoped to schemas or tables can't be migrated, change the role of each permission instead")
+)
+
+const (
+	RoleMigratedMsg             = "Role of the user migrated successfully in %d database instances."
+	RoleMigrationIncompleteMsg  = "The role could not be migrated in every instance, so the user keeps the old role. Check the access permission logs and run the migration again to finish it."
+	UserRoleMigratedMsg         = "the role of user '%s' was migrated from '%s' to '%s' in instance '%s'"
+	ErrMigrateUserRoleFailedMsg = "failed to migrate the role of user '%s' from '%s' to '%s' in instance '%s'. Details: %s"
+	ErrClearingDatabaseRolesMsg = "the role of user '%s' was migrated in instance '%s', but the permissions with role '%s' could not be updated. Details: %s"
+)
+
+type MigrateDatabaseUserRoleUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+}
+
+func NewMigrateDatabaseUserRoleUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	databaseRoleStorage storage.DatabaseRoleStorage,
+	databaseUserStorage storage.DatabaseUserStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+) *MigrateDatabaseUserRoleUseCase {
+	return &MigrateDatabaseUserRoleUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		DatabaseRoleStorage:     databaseRoleStorage,
+		DatabaseUserStorage:     databaseUserStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+	}
+}
+
+type roleMigration struct {
+	User            *entity.DatabaseUser
+	OldRole         *entity.DatabaseRole
+	NewRole         *entity.DatabaseRole
+	OperationUserID string
+}
+
+type roleMigrationResult struct {
+	Instance    *dto.DatabaseInstanceOutputDTO
+	Permissions []*dto.AccessPermissionOutputDTO
+	Err         error
+	LogMessage  string
+}
+
+// Execute godoc
+/** Responsible for migrating the own role of a database user that already has access permissions.
+On every instance where the user exists, the new role is granted and the old one revoked, concurrently, as tasks of the
+shared executor. The databases whose permissions follow the user's own role move to the new role, while the ones with a
+role of their own keep it. The outcome of each instance is persisted as an access permission log.
+The user record only gets the new role once every instance succeeds; otherwise the migration can be run again, since
+running it again on the instances already migrated changes nothing. */
+func (useCase *MigrateDatabaseUserRoleUseCase) Execute(input dto.MigrateDatabaseUserRoleInputDTO, dbUserID, operationUserID string) (*dto.MigrateDatabaseUserRoleOutputDTO, error) {
+	migration, err := useCase.buildMigration(input, dbUserID, operationUserID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := useCase.AccessPermissionStorage.FindAllDTOs("", dbUserID, "")
+	if err != nil {
+		return nil, err
+	}
+	// The new role would be granted to the whole instances, bypassing the scopes
+	if slices.ContainsFunc(permissions, func(p *dto.AccessPermissionOutputDTO) bool { return p.Scope != nil }) {
+		return nil, ErrScopedUserRoleMigrationNotAllowed
+	}
+	permissionsByInstance := make(map[string][]*dto.AccessPermissionOutputDTO)
+	instancesIDs := make([]string, 0)
+	for _, permission := range permissions {
+		if _, found := permissionsByInstance[permission.DatabaseInstanceID]; !found {
+			instancesIDs = append(instancesIDs, permission.DatabaseInstanceID)
+		}
+		permissionsByInstance[permission.DatabaseInstanceID] = append(permissionsByInstance[permission.DatabaseInstanceID], permission)
+	}
+
+	log.Printf("Migrating the role of database user '%s' from '%s' to '%s' in %d database instances. Requester: %s",
+		migration.User.Username, migration.OldRole.Name, migration.NewRole.Name, len(instancesIDs), operationUserID)
+	output := &dto.MigrateDatabaseUserRoleOutputDTO{
+		Message:   fmt.Sprintf(RoleMigratedMsg, len(instancesIDs)),
+		Instances: make([]dto.ItemResultDTO, 0, len(instancesIDs)),
+	}
+	allMigrated := true
+	if len(instancesIDs) > 0 {
+		instances, errFetching := useCase.DatabaseInstanceStorage.FindAllDTOs("", "", instancesIDs)
+		if errFetching != nil {
+			return nil, errFetching
+		}
+		allMigrated = useCase.migrateInstances(migration, instances, permissionsByInstance, output)
+	}
+
+	if allMigrated {
+		if err = useCase.updateUserRole(migration); err != nil {
+			return nil, err
+		}
+	} else {
+		output.Message = RoleMigrationIncompleteMsg
+	}
+	output.DatabaseUser, err = useCase.DatabaseUserStorage.FindDTOByID(dbUserID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Role migration finished for database user '%s' with errors: %t", migration.User.Username, output.HasErrors)
+	return output, nil
+}
+
+func (useCase *MigrateDatabaseUserRoleUseCase) buildMigration(input dto.MigrateDatabaseUserRoleInputDTO, dbUserID, operationUserID string) (*roleMigration, error) {
+	dbUser, err := useCase.DatabaseUserStorage.FindByID(dbUserID)
+	if err != nil {
+		return nil, common.HandleFindError(err, common.ErrDatabaseUserNotFound)
+	}
+	if dbUser.DatabaseRoleID == input.DatabaseRoleID {
+		return nil, ErrSameDatabaseUserRole
+	}
+	newRole, err := useCase.findRole(input.DatabaseRoleID)
+	if err != nil {
+		return nil, err
+	}
+	oldRole, err := useCase.findRole(dbUser.DatabaseRoleID)
+	if err != nil {
+		return nil, err
+	}
+	if oldRole.IsApplication() || newRole.IsApplication() {
+		return nil, ErrApplicationRoleMigrationNotAllowed
+	}
+	return &roleMigration{User: dbUser, OldRole: oldRole, NewRole: newRole, OperationUserID: operationUserID}, nil
+}
+
+func (useCase *MigrateDatabaseUserRoleUseCase) findRole(roleID string) (*entity.DatabaseRole, error) {
+	role, err := useCase.DatabaseRoleStorage.FindByID(roleID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDatabaseRoleNotFound
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+func (useCase *MigrateDatabaseUserRoleUseCase) migrateInstances(
+	migration *roleMigration,
+	instances []*dto.DatabaseInstanceOutputDTO,
+	permissionsByInstance map[string][]*dto.AccessPermissionOutputDTO,
+	output *dto.MigrateDatabaseUserRoleOutputDTO) bool {
+	allMigrated := true
+	resultCh := make(chan *roleMigrationResult, len(instances))
+	batch := config.GetExecutor().NewBatch("migrate role")
+	for _, instance := range instances {
+		batch.Go(instance.ID, func(time.Duration) {
+			resultCh <- migrateRoleInInstance(migration, instance, permissionsByInstance[instance.ID])
+		})
+	}
+	go func() {
+		batch.Wait()
+		close(resultCh)
+	}()
+
+	for result := range resultCh {
+		if result.Err == nil {
+			useCase.clearPermissionsWithNewRole(migration, result)
+		}
+		if result.Err != nil {
+			log.Printf("Error: instance {%s}: %v", result.Instance.Name, result.Err)
+			output.HasErrors = true
+			allMigrated = false
+		}
+		output.Instances = append(output.Instances, dto.ItemResultDTO{
+			Item:    result.Instance.Name,
+			Success: result.Err == nil,
+			Message: result.LogMessage,
+		})
+		useCase.persistLog(migration, result, output)
+	}
+	output.Execution = common.BuildExecutionOutput(batch.Stats())
+	return allMigrated
+}
+
+// migrateRoleInInstance godoc
+// Changes the own role of the user in the instance. Only the databases whose permissions follow the own role are
+// passed to the connector, so the ones with a role of their own keep it.
+func migrateRoleInInstance(migration *roleMigration, instance *dto.DatabaseInstanceOutputDTO, permissions []*dto.AccessPermissionOutputDTO) *roleMigrationResult {
+	result := &roleMigrationResult{Instance: instance, Permissions: permissions}
+	failed := func(err error) *roleMigrationResult {
+		result.Err = err
+		result.LogMessage = fmt.Sprintf(ErrMigrateUserRoleFailedMsg, migration.User.Username, migration.OldRole.Name, migration.NewRole.Name, instance.Name, err.Error())
+		return result
+	}
+	if !instance.Enabled {
+		return failed(fmt.Errorf(ErrInstanceDisabledMsg, instance.Name))
+	}
+	targetInstance, err := connector.NewDatabaseConnector(instance, "")
+	if err != nil {
+		result.Err = fmt.Errorf("could not create connector. Details: %w", err)
+		result.LogMessage = fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error())
+		return result
+	}
+	databases := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission.DatabaseRoleID == migration.OldRole.ID.String() {
+			databases = append(databases, permission.DatabaseName)
+		}
+	}
+	err = targetInstance.ChangeUserRole(migration.User.Username, string(migration.OldRole.Name), string(migration.NewRole.Name), databases)
+	if err != nil {
+		return failed(err)
+	}
+	result.LogMessage = fmt.Sprintf(UserRoleMigratedMsg, migration.User.Username, migration.OldRole.Name, migration.NewRole.Name, instance.Name)
+	return result
+}
+
+// clearPermissionsWithNewRole godoc
+// The permissions that had the new role as a role of their own now match the user's own role, so they follow it again
+func (useCase *MigrateDatabaseUserRoleUseCase) clearPermissionsWithNewRole(migration *roleMigration, result *roleMigrationResult) {
+	for _, permission := range result.Permissions {
+		if permission.DatabaseRoleID != migration.NewRole.ID.String() {
+			continue
+		}
+		if err := useCase.AccessPermissionStorage.UpdateDatabaseRole(permission.ID, sql.NullString{}); err != nil {
+			result.Err = err
+			result.LogMessage = fmt.Sprintf(ErrClearingDatabaseRolesMsg, migration.User.Username, result.Instance.Name, migration.NewRole.Name, err.Error())
+			return
+		}
+	}
+}
+
+func (useCase *MigrateDatabaseUserRoleUseCase) persistLog(migration *roleMigration, result *roleMigrationResult, output *dto.MigrateDatabaseUserRoleOutputDTO) {
+	accessLog, err := entity.NewAccessPermissionLog(result.Instance.ID, migration.User.ID.String(), "", result.LogMessage, migration.OperationUserID, result.Err == nil)
+	if err == nil {
+		err = useCase.AccessPermissionStorage.SaveLog(accessLog)
+	}
+	if err != nil {
+		log.Printf("Error: could not save role migration log for instance '%s'. Cause: %v", result.Instance.Name, err)
+		output.HasErrors = true
+	}
+}
+
+func (useCase *MigrateDatabaseUserRoleUseCase) updateUserRole(migration *roleMigration) error {
+	dbUser := migration.User
+	if err := dbUser.Update(dbUser.Name, migration.NewRole.ID.String(), dbUser.Team, dbUser.Position); err != nil {
+		return err
+	}
+	if err := useCase.DatabaseUserStorage.Update(dbUser); err != nil {
+		return fmt.Errorf("the role was migrated in every instance, but the database user %s could not be updated. Cause: %w", dbUser.ID.String(), err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func buildDevOpsRole(dbUser *entity.DatabaseUser) *entity.DatabaseRole {
+	return &entity.DatabaseRole{ID: uuid.MustParse(dbUser.DatabaseRoleID), Name: entity.DevOps, DisplayName: "DevOps"}
+}
+
+func TestGivenTheSameRole_WhenExecuteMigrateRole_ThenShouldReturnError(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
+
+	uc := NewMigrateDatabaseUserRoleUseCase(nil, nil, dbUserStorage, nil)
+	output, err := uc.Execute(dto.MigrateDatabaseUserRoleInputDTO{DatabaseRoleID: dbUser.DatabaseRoleID}, dbUser.ID.String(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrSameDatabaseUserRole)
+	assert.Nil(t, output)
+}
+
+func TestGivenTheApplicationRole_WhenExecuteMigrateRole_ThenShouldReturnError(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohn()
+	applicationRole := &entity.DatabaseRole{ID: uuid.New(), Name: entity.Application}
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", applicationRole.ID.String()).Return(applicationRole, nil).Once()
+	roleStorage.On("FindByID", dbUser.DatabaseRoleID).Return(buildDevOpsRole(dbUser), nil).Once()
+
+	uc := NewMigrateDatabaseUserRoleUseCase(nil, roleStorage, dbUserStorage, nil)
+	output, err := uc.Execute(dto.MigrateDatabaseUserRoleInputDTO{DatabaseRoleID: applicationRole.ID.String()}, dbUser.ID.String(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrApplicationRoleMigrationNotAllowed)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnUserWithAccess_WhenExecuteMigrateRole_ThenShouldMigrateEveryInstanceAndUpdateTheUser(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohn()
+	oldRole := buildDevOpsRole(dbUser)
+	newRole := mocks.BuildReadOnlyRole()
+	instance := mocks.BuildAzInstanceDTO()
+	followingOwnRole := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseInstanceID: instance.ID, DatabaseName: "orders", DatabaseRoleID: oldRole.ID.String()}
+	withNewRole := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseInstanceID: instance.ID, DatabaseName: "logs", DatabaseRoleID: newRole.ID.String()}
+	accessPermissionStorage, roleStorage, dbUserStorage := buildMigrateRoleStorages(dbUser, oldRole, newRole, followingOwnRole, withNewRole)
+	accessPermissionStorage.On("UpdateDatabaseRole", withNewRole.ID, sql.NullString{}).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool { return l.Success })).Return(nil).Once()
+	dbUserStorage.On("Update", mock.MatchedBy(func(u *entity.DatabaseUser) bool { return u.DatabaseRoleID == newRole.ID.String() })).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+
+	uc := NewMigrateDatabaseUserRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage)
+	output, err := uc.Execute(dto.MigrateDatabaseUserRoleInputDTO{DatabaseRoleID: newRole.ID.String()}, dbUser.ID.String(), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, "Role of the user migrated successfully in 1 database instances.", output.Message)
+	assert.Len(t, output.Instances, 1)
+	assert.True(t, output.Instances[0].Success)
+	accessPermissionStorage.AssertNumberOfCalls(t, "UpdateDatabaseRole", 1)
+	dbUserStorage.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestGivenAnErrorInOneOfTheInstances_WhenExecuteMigrateRole_ThenShouldReportItAndKeepTheOldRole(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohn()
+	oldRole := buildDevOpsRole(dbUser)
+	newRole := mocks.BuildReadOnlyRole()
+	instance := mocks.BuildAzInstanceDTO()
+	errorInstance := mocks.BuildDummyErrorInstance()
+	permission := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseInstanceID: instance.ID, DatabaseName: "orders", DatabaseRoleID: oldRole.ID.String()}
+	errorPermission := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseInstanceID: errorInstance.ID, DatabaseName: "orders", DatabaseRoleID: oldRole.ID.String()}
+	accessPermissionStorage, roleStorage, dbUserStorage := buildMigrateRoleStorages(dbUser, oldRole, newRole, permission, errorPermission)
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Twice()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID, errorInstance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance, errorInstance}, nil).Once()
+
+	uc := NewMigrateDatabaseUserRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, dbInstanceStorage)
+	output, err := uc.Execute(dto.MigrateDatabaseUserRoleInputDTO{DatabaseRoleID: newRole.ID.String()}, dbUser.ID.String(), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, RoleMigrationIncompleteMsg, output.Message)
+	assert.Len(t, output.Instances, 2)
+	for _, result := range output.Instances {
+		assert.Equal(t, result.Item == instance.Name, result.Success)
+	}
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 2)
+	dbUserStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGivenAnUserWithScopedAccess_WhenExecuteMigrateRole_ThenShouldReturnErrorWithoutMigrating(t *testing.T) {
+	dbUser := mocks.BuildDbUserJohn()
+	oldRole := buildDevOpsRole(dbUser)
+	newRole := mocks.BuildReadOnlyRole()
+	instance := mocks.BuildAzInstanceDTO()
+	scoped := &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseInstanceID: instance.ID, DatabaseName: "orders", DatabaseRoleID: oldRole.ID.String(),
+		Scope: &dto.AccessScopeDTO{Schemas: []string{"reporting"}}}
+	accessPermissionStorage, roleStorage, dbUserStorage := buildMigrateRoleStorages(dbUser, oldRole, newRole, scoped)
+
+	uc := NewMigrateDatabaseUserRoleUseCase(accessPermissionStorage, roleStorage, dbUserStorage, nil)
+	output, err := uc.Execute(dto.MigrateDatabaseUserRoleInputDTO{DatabaseRoleID: newRole.ID.String()}, dbUser.ID.String(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrScopedUserRoleMigrationNotAllowed)
+	assert.Nil(t, output)
+	dbUserStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func buildMigrateRoleStorages(dbUser *entity.DatabaseUser, oldRole, newRole *entity.DatabaseRole, permissions ...*dto.AccessPermissionOutputDTO) (
+	*mocks.AccessPermissionStorageMock, *mocks.DatabaseRoleStorageMock, *mocks.DatabaseUserStorageMock) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUser.ID.String(), "").Return(permissions, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", newRole.ID.String()).Return(newRole, nil).Once()
+	roleStorage.On("FindByID", oldRole.ID.String()).Return(oldRole, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
+	dbUserStorage.On("FindDTOByID", dbUser.ID.String()).Return(mocks.BuildDbUserJohnDTO(), nil).Once()
+	return accessPermissionStorage, roleStorage, dbUserStorage
+}
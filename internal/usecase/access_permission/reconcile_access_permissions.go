@@ -0,0 +1,499 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var ErrNoDatabaseInstancesFound = fmt.Errorf("no database instances found with the provided IDs")
+
+// Types of the drifts found by the reconciliation
+const (
+	DriftMissingUser  = "MISSING_USER"
+	DriftMissingGrant = "MISSING_GRANT"
+	DriftExtraGrant   = "EXTRA_GRANT"
+)
+
+const (
+	DriftsFoundMsg                 = "%d drifts found"
+	DriftsFoundAndCorrectedMsg     = "%d drifts found, %d corrected"
+	MissingUserDetailsMsg          = "the user has %d access permissions, but doesn't exist in the instance"
+	CantConnectDetailsMsg          = "the user can't connect to the database"
+	MissingRoleDetailsMsg          = "the user doesn't have the role '%s' in the database"
+	ExtraDatabaseDetailsMsg        = "the user can connect to the database without an access permission"
+	ExtraUserDetailsMsg            = "the user exists in the instance without any access permission"
+	UserDisabledNotCorrectedMsg    = "the user is disabled, revoke its access permissions instead"
+	ReconcileUserCreatedMsg        = "the user '%s' was missing in instance '%s' and was created again by the reconciliation"
+	ReconcilePermissionGrantedMsg  = "access permission of user '%s' on database '%s' of instance '%s' granted again by the reconciliation"
+	ReconcileUserRemovedMsg        = "the user '%s' had access to instance '%s' without any access permission and was removed by the reconciliation"
+	ReconcileDatabaseRevokedMsg    = "the access of user '%s' to database '%s' of instance '%s' without an access permission was revoked by the reconciliation"
+	ErrReconcileCreateUserMsg      = "failed to create again the user '%s' in instance '%s' by the reconciliation. Details: %s"
+	ErrReconcileGrantPermissionMsg = "failed to grant again the access permission of user '%s' on database '%s' of instance '%s' by the reconciliation. Details: %s"
+	ErrReconcileRemoveUserMsg      = "failed to remove the user '%s' from instance '%s' by the reconciliation. Details: %s"
+	ErrReconcileRevokeDatabaseMsg  = "failed to revoke the access of user '%s' to database '%s' of instance '%s' by the reconciliation. Details: %s"
+)
+
+type ReconcileAccessPermissionsUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
+}
+
+func NewReconcileAccessPermissionsUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	databaseUserStorage storage.DatabaseUserStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	databaseRoleStorage storage.DatabaseRoleStorage,
+) *ReconcileAccessPermissionsUseCase {
+	return &ReconcileAccessPermissionsUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		DatabaseUserStorage:     databaseUserStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		DatabaseRoleStorage:     databaseRoleStorage,
+	}
+}
+
+// reconcileContext godoc
+// Data shared by the reconciliation of every instance
+type reconcileContext struct {
+	UsersByID       map[string]*dto.DatabaseUserOutputDTO
+	RolesByID       map[string]*entity.DatabaseRole
+	Apply           bool
+	OperationUserID string
+}
+
+// instanceReconciliation godoc
+// The reconciliation of a single instance, collecting its drifts in the output
+type instanceReconciliation struct {
+	Ctx       *reconcileContext
+	Instance  *dto.DatabaseInstanceOutputDTO
+	Connector connector.DatabaseTCPConnectorInterface
+	Output    *dto.ReconcileAccessOutputDTO
+}
+
+// Execute godoc
+/** Responsible for comparing the access permissions with the access the users have in all enabled database instances
+or in the selected database instances, concurrently, as tasks of the shared executor. For each instance it reports:
+- MISSING_USER: a user with access permissions doesn't exist in the instance.
+- MISSING_GRANT: a user can't connect to a database of its access permissions, or doesn't have the role of the
+permission in it, when the technology keeps the roles by database.
+- EXTRA_GRANT: a user can connect to a database without an access permission, or a user of the zg-data-guard exists in
+the instance without any access permission.
+With the apply mode, the drifts are fixed with the same connector calls of the grant and the revocation: missing users
+are created and granted their access permissions again, missing grants are granted again and the users without any
+access permission are removed from the instance, while the access to the extra databases of the users with access
+permissions is revoked database by database. Each correction is persisted as an access permission log of the RECONCILE type.
+*/
+func (uc *ReconcileAccessPermissionsUseCase) Execute(input dto.ReconcileAccessInputDTO, operationUserID string) ([]*dto.ReconcileAccessOutputDTO, error) {
+	return uc.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each database instance as an item of the progress
+func (uc *ReconcileAccessPermissionsUseCase) ExecuteWithProgress(input dto.ReconcileAccessInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.ReconcileAccessOutputDTO, error) {
+	var dbInstances []*dto.DatabaseInstanceOutputDTO
+	var err error
+	if len(input.DatabaseInstancesIDs) > 0 {
+		log.Printf("Reconciling the access permissions of the selected %d database instances (apply: %t). Requester: %s", len(input.DatabaseInstancesIDs), input.Apply, operationUserID)
+		dbInstances, err = uc.DatabaseInstanceStorage.FindAllDTOs("", "", input.DatabaseInstancesIDs)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if len(dbInstances) == 0 {
+			return nil, ErrNoDatabaseInstancesFound
+		}
+	} else {
+		log.Printf("Reconciling the access permissions of all enabled database instances (apply: %t). Requester: %s", input.Apply, operationUserID)
+		dbInstances, err = uc.DatabaseInstanceStorage.FindAllDTOsEnabled("", "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, err := uc.buildContext(input.Apply, operationUserID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.reconcileInstances(ctx, dbInstances, progress), nil
+}
+
+func (uc *ReconcileAccessPermissionsUseCase) buildContext(apply bool, operationUserID string) (*reconcileContext, error) {
+	dbUsers, err := uc.DatabaseUserStorage.FindAllDTOs(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching database users. Cause: %w", err)
+	}
+	roles, err := uc.DatabaseRoleStorage.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching database roles. Cause: %w", err)
+	}
+	ctx := &reconcileContext{
+		UsersByID:       make(map[string]*dto.DatabaseUserOutputDTO, len(dbUsers)),
+		RolesByID:       make(map[string]*entity.DatabaseRole, len(roles)),
+		Apply:           apply,
+		OperationUserID: operationUserID,
+	}
+	for _, dbUser := range dbUsers {
+		ctx.UsersByID[dbUser.ID] = dbUser
+	}
+	for _, role := range roles {
+		ctx.RolesByID[role.ID.String()] = role
+	}
+	return ctx, nil
+}
+
+func (uc *ReconcileAccessPermissionsUseCase) reconcileInstances(ctx *reconcileContext, dbInstances []*dto.DatabaseInstanceOutputDTO, progress common.ProgressReporter) []*dto.ReconcileAccessOutputDTO {
+	instancesQty := len(dbInstances)
+	progress.AddItems(instancesQty)
+	resultsChan := make(chan *dto.ReconcileAccessOutputDTO, instancesQty)
+	batch := config.GetExecutor().NewBatch("reconcile access")
+
+	for idx, instance := range dbInstances {
+		batch.Go(instance.ID, func(queueTime time.Duration) {
+			log.Printf("Reconciling the access permissions of instance [%s] %s (%d/%d)", instance.EcosystemName, instance.Name, idx+1, instancesQty)
+			output := uc.reconcileInstance(ctx, instance)
+			output.QueueTimeMs = queueTime.Milliseconds()
+			progress.ItemProcessed(dto.ItemResultDTO{Item: output.Instance, Success: output.Success, Message: output.Message})
+			resultsChan <- output
+		})
+	}
+
+	// Wait for all tasks to finish and close the channels
+	go func() {
+		batch.Wait()
+		close(resultsChan)
+	}()
+
+	var outputs []*dto.ReconcileAccessOutputDTO
+	for result := range resultsChan {
+		outputs = append(outputs, result)
+	}
+	return outputs
+}
+
+func (uc *ReconcileAccessPermissionsUseCase) reconcileInstance(ctx *reconcileContext, instance *dto.DatabaseInstanceOutputDTO) *dto.ReconcileAccessOutputDTO {
+	output := &dto.ReconcileAccessOutputDTO{
+		DatabaseInstanceID: instance.ID,
+		Success:            false,
+		Instance:           instance.Name,
+		Ecosystem:          instance.EcosystemName,
+		Technology:         instance.DatabaseTechnologyName,
+	}
+	if !instance.Enabled {
+		output.Message = fmt.Sprintf(ErrInstanceDisabledMsg, instance.Name)
+		return output
+	}
+	targetInstance, err := connector.NewDatabaseConnector(instance, "")
+	if err != nil {
+		output.Message = fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error())
+		return output
+	}
+	permissions, err := uc.AccessPermissionStorage.FindAllDTOs("", "", instance.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		output.Message = fmt.Sprintf("error while fetching the access permissions of the instance. Cause: %v", err)
+		return output
+	}
+	logins, err := targetInstance.ListLogins()
+	if err != nil {
+		output.Message = fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, err.Error())
+		return output
+	}
+
+	r := &instanceReconciliation{Ctx: ctx, Instance: instance, Connector: targetInstance, Output: output}
+	permissionsByUser := groupPermissionsByUser(permissions)
+	for _, dbUser := range sortedUsers(ctx, func(dbUser *dto.DatabaseUserOutputDTO) bool { return permissionsByUser[dbUser.ID] != nil }) {
+		loginExists := slices.ContainsFunc(logins, func(l *connector.Login) bool { return l.Name == dbUser.Username })
+		if err = uc.reconcileUser(r, dbUser, permissionsByUser[dbUser.ID], loginExists); err != nil {
+			output.Message = fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, err.Error())
+			return output
+		}
+	}
+	uc.reconcileUsersWithoutPermissions(r, logins, permissionsByUser)
+
+	output.Success = true
+	output.Message = buildReconcileMessage(output.Drifts, ctx.Apply)
+	return output
+}
+
+func groupPermissionsByUser(permissions []*dto.AccessPermissionOutputDTO) map[string][]*dto.AccessPermissionOutputDTO {
+	permissionsByUser := make(map[string][]*dto.AccessPermissionOutputDTO)
+	for _, permission := range permissions {
+		permissionsByUser[permission.DatabaseUserID] = append(permissionsByUser[permission.DatabaseUserID], permission)
+	}
+	return permissionsByUser
+}
+
+// sortedUsers godoc
+// Returns the users that match the filter sorted by username, so the drifts are always reported in the same order
+func sortedUsers(ctx *reconcileContext, filter func(*dto.DatabaseUserOutputDTO) bool) []*dto.DatabaseUserOutputDTO {
+	var users []*dto.DatabaseUserOutputDTO
+	for _, dbUser := range ctx.UsersByID {
+		if filter(dbUser) {
+			users = append(users, dbUser)
+		}
+	}
+	slices.SortFunc(users, func(a, b *dto.DatabaseUserOutputDTO) int { return strings.Compare(a.Username, b.Username) })
+	return users
+}
+
+func (uc *ReconcileAccessPermissionsUseCase) reconcileUser(r *instanceReconciliation, dbUser *dto.DatabaseUserOutputDTO, permissions []*dto.AccessPermissionOutputDTO, loginExists bool) error {
+	var access *connector.UserAccess
+	if loginExists {
+		var err error
+		if access, err = r.Connector.FindUserAccess(dbUser.Username); err != nil {
+			return err
+		}
+	}
+	if access == nil {
+		drift := dto.AccessDriftDTO{
+			Type:           DriftMissingUser,
+			DatabaseUserID: dbUser.ID,
+			Username:       dbUser.Username,
+			Details:        fmt.Sprintf(MissingUserDetailsMsg, len(permissions)),
+		}
+		if r.Ctx.Apply {
+			uc.recreateUser(r, dbUser, permissions, &drift)
+		}
+		r.Output.Drifts = append(r.Output.Drifts, drift)
+		return nil
+	}
+
+	scoped := slices.ContainsFunc(permissions, func(p *dto.AccessPermissionOutputDTO) bool { return p.Scope != nil })
+	permittedDatabases := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		permittedDatabases = append(permittedDatabases, permission.DatabaseName)
+		details, roleMissing := missingGrantDetails(r.Ctx, access, permission)
+		if details == "" {
+			continue
+		}
+		drift := dto.AccessDriftDTO{
+			Type:           DriftMissingGrant,
+			DatabaseUserID: dbUser.ID,
+			Username:       dbUser.Username,
+			Database:       permission.DatabaseName,
+			Details:        details,
+		}
+		if r.Ctx.Apply {
+			uc.correctMissingGrant(r, dbUser, permission, scoped || roleMissing, &drift)
+		}
+		r.Output.Drifts = append(r.Output.Drifts, drift)
+	}
+
+	extraDatabases := make([]string, 0)
+	for databaseName := range access.RolesByDatabase {
+		if !slices.Contains(permittedDatabases, databaseName) && !slices.Contains(access.PublicDatabases, databaseName) {
+			extraDatabases = append(extraDatabases, databaseName)
+		}
+	}
+	slices.Sort(extraDatabases)
+	for _, databaseName := range extraDatabases {
+		drift := dto.AccessDriftDTO{
+			Type:           DriftExtraGrant,
+			DatabaseUserID: dbUser.ID,
+			Username:       dbUser.Username,
+			Database:       databaseName,
+			Details:        ExtraDatabaseDetailsMsg,
+		}
+		if r.Ctx.Apply {
+			uc.correctExtraDatabase(r, dbUser, databaseName, &drift)
+		}
+		r.Output.Drifts = append(r.Output.Drifts, drift)
+	}
+	return nil
+}
+
+// correctExtraDatabase godoc
+// Revokes the access of the user to the database without an access permission, the same way the revocation does for
+// each database, logging the correction
+func (uc *ReconcileAccessPermissionsUseCase) correctExtraDatabase(r *instanceReconciliation, dbUser *dto.DatabaseUserOutputDTO, databaseName string, drift *dto.AccessDriftDTO) {
+	targetDatabase, err := connector.NewDatabaseConnector(r.Instance, databaseName)
+	if err == nil {
+		err = targetDatabase.RevokeConnect(dbUser.Username)
+	}
+	drift.Corrected = err == nil
+	drift.Correction = fmt.Sprintf(ReconcileDatabaseRevokedMsg, dbUser.Username, databaseName, r.Instance.Name)
+	if err != nil {
+		drift.Correction = fmt.Sprintf(ErrReconcileRevokeDatabaseMsg, dbUser.Username, databaseName, r.Instance.Name, err.Error())
+	}
+	uc.persistLog(r, dbUser.ID, "", drift.Correction, drift.Corrected)
+}
+
+// missingGrantDetails godoc
+// Tells why the access of the permission is missing in the instance, empty when it isn't, and if only the role is
+// missing. The role is only checked when the technology keeps the roles by database, and not for the scoped
+// permissions, whose privileges are granted to the user itself.
+func missingGrantDetails(ctx *reconcileContext, access *connector.UserAccess, permission *dto.AccessPermissionOutputDTO) (string, bool) {
+	roles, canConnect := access.RolesByDatabase[permission.DatabaseName]
+	if !canConnect {
+		if slices.Contains(access.PublicDatabases, permission.DatabaseName) {
+			return "", false
+		}
+		return CantConnectDetailsMsg, false
+	}
+	role := ctx.RolesByID[permission.DatabaseRoleID]
+	if roles == nil || permission.Scope != nil || role == nil || slices.Contains(roles, string(role.Name)) {
+		return "", false
+	}
+	return fmt.Sprintf(MissingRoleDetailsMsg, role.Name), true
+}
+
+// reconcileUsersWithoutPermissions godoc
+// Reports the users of the zg-data-guard that exist in the instance without any access permission, removing them with
+// the apply mode
+func (uc *ReconcileAccessPermissionsUseCase) reconcileUsersWithoutPermissions(r *instanceReconciliation, logins []*connector.Login, permissionsByUser map[string][]*dto.AccessPermissionOutputDTO) {
+	usersWithoutPermissions := sortedUsers(r.Ctx, func(dbUser *dto.DatabaseUserOutputDTO) bool {
+		_, hasPermissions := permissionsByUser[dbUser.ID]
+		return !hasPermissions && slices.ContainsFunc(logins, func(l *connector.Login) bool { return l.Name == dbUser.Username })
+	})
+	for _, dbUser := range usersWithoutPermissions {
+		drift := dto.AccessDriftDTO{
+			Type:           DriftExtraGrant,
+			DatabaseUserID: dbUser.ID,
+			Username:       dbUser.Username,
+			Details:        ExtraUserDetailsMsg,
+		}
+		if r.Ctx.Apply {
+			if err := r.Connector.RevokeUserPrivilegesAndRemove(dbUser.Username); err != nil {
+				drift.Correction = fmt.Sprintf(ErrReconcileRemoveUserMsg, dbUser.Username, r.Instance.Name, err.Error())
+			} else {
+				drift.Corrected = true
+				drift.Correction = fmt.Sprintf(ReconcileUserRemovedMsg, dbUser.Username, r.Instance.Name)
+			}
+			uc.persistLog(r, dbUser.ID, "", drift.Correction, drift.Corrected)
+		}
+		r.Output.Drifts = append(r.Output.Drifts, drift)
+	}
+}
+
+// recreateUser godoc
+// Creates the missing user again, with the password and the role kept by the zg-data-guard, and grants it all its
+// access permissions in the instance again
+func (uc *ReconcileAccessPermissionsUseCase) recreateUser(r *instanceReconciliation, dbUser *dto.DatabaseUserOutputDTO, permissions []*dto.AccessPermissionOutputDTO, drift *dto.AccessDriftDTO) {
+	if !dbUser.Enabled {
+		drift.Correction = UserDisabledNotCorrectedMsg
+		return
+	}
+	err := createUserInInstance(r.Connector, dbUser)
+	if err != nil {
+		drift.Correction = fmt.Sprintf(ErrReconcileCreateUserMsg, dbUser.Username, r.Instance.Name, err.Error())
+		uc.persistLog(r, dbUser.ID, "", drift.Correction, false)
+		return
+	}
+	uc.persistLog(r, dbUser.ID, "", fmt.Sprintf(ReconcileUserCreatedMsg, dbUser.Username, r.Instance.Name), true)
+
+	scoped := slices.ContainsFunc(permissions, func(p *dto.AccessPermissionOutputDTO) bool { return p.Scope != nil })
+	failedDatabases := 0
+	for _, permission := range permissions {
+		if err = uc.grantAgain(r, dbUser, permission, scoped); err != nil {
+			failedDatabases++
+		}
+	}
+	drift.Corrected = failedDatabases == 0
+	drift.Correction = fmt.Sprintf(ReconcileUserCreatedMsg, dbUser.Username, r.Instance.Name)
+	if failedDatabases > 0 {
+		drift.Correction += ", but " + fmt.Sprintf(UserDatabasesFailedMsg, failedDatabases, len(permissions))
+	}
+}
+
+func createUserInInstance(targetInstance connector.DatabaseTCPConnectorInterface, dbUser *dto.DatabaseUserOutputDTO) error {
+	if !entity.ValidateRoleName(dbUser.DatabaseRoleName) {
+		return fmt.Errorf(ErrInvalidRoleMsg, dbUser.DatabaseRoleName, dbUser.Username)
+	}
+	decryptedPwd, err := config.GetCryptoHelper().Decrypt(dbUser.Password)
+	if err != nil {
+		return fmt.Errorf("error decrypting password. Cause: %v", err)
+	}
+	return targetInstance.CreateUser(&connector.DatabaseUser{
+		Username: dbUser.Username,
+		Password: decryptedPwd,
+		Role:     dbUser.DatabaseRoleName,
+	})
+}
+
+// correctMissingGrant godoc
+// Grants the access permission again. withRole is set when the user has any scoped permission in the instance or only
+// the role is missing, since GrantConnect would only restore the connection.
+func (uc *ReconcileAccessPermissionsUseCase) correctMissingGrant(r *instanceReconciliation, dbUser *dto.DatabaseUserOutputDTO, permission *dto.AccessPermissionOutputDTO, withRole bool, drift *dto.AccessDriftDTO) {
+	if !dbUser.Enabled {
+		drift.Correction = UserDisabledNotCorrectedMsg
+		return
+	}
+	err := uc.grantAgain(r, dbUser, permission, withRole)
+	drift.Corrected = err == nil
+	drift.Correction = fmt.Sprintf(ReconcilePermissionGrantedMsg, dbUser.Username, permission.DatabaseName, r.Instance.Name)
+	if err != nil {
+		drift.Correction = fmt.Sprintf(ErrReconcileGrantPermissionMsg, dbUser.Username, permission.DatabaseName, r.Instance.Name, err.Error())
+	}
+}
+
+// grantAgain godoc
+// Grants the access permission again the same way the grant does, logging the correction
+func (uc *ReconcileAccessPermissionsUseCase) grantAgain(r *instanceReconciliation, dbUser *dto.DatabaseUserOutputDTO, permission *dto.AccessPermissionOutputDTO, withRole bool) error {
+	err := grantPermissionAgain(r.Ctx, r.Instance, dbUser, permission, withRole)
+	message := fmt.Sprintf(ReconcilePermissionGrantedMsg, dbUser.Username, permission.DatabaseName, r.Instance.Name)
+	if err != nil {
+		message = fmt.Sprintf(ErrReconcileGrantPermissionMsg, dbUser.Username, permission.DatabaseName, r.Instance.Name, err.Error())
+	}
+	uc.persistLog(r, dbUser.ID, permission.DatabaseID, message, err == nil)
+	return err
+}
+
+// grantPermissionAgain godoc
+// Grants the access permission with its scope, with the role in the database when it isn't the own role of the user or
+// withRole is set, or with the own role of the user
+func grantPermissionAgain(ctx *reconcileContext, instance *dto.DatabaseInstanceOutputDTO, dbUser *dto.DatabaseUserOutputDTO, permission *dto.AccessPermissionOutputDTO, withRole bool) error {
+	role := ctx.RolesByID[permission.DatabaseRoleID]
+	if role == nil {
+		return fmt.Errorf("%w: %s", ErrDatabaseRoleNotFound, permission.DatabaseRoleID)
+	}
+	targetDatabase, err := connector.NewDatabaseConnector(instance, permission.DatabaseName)
+	if err != nil {
+		return err
+	}
+	if permission.Scope != nil {
+		scope, errScope := entity.NewAccessScope(permission.Scope.Schemas, permission.Scope.Tables)
+		if errScope != nil {
+			return errScope
+		}
+		return targetDatabase.GrantConnectWithScope(dbUser.Username, connector.NewDatabaseRoles([]*entity.DatabaseRole{role})[0], scope)
+	}
+	if withRole || string(role.Name) != dbUser.DatabaseRoleName {
+		return targetDatabase.GrantConnectWithRole(dbUser.Username, string(role.Name))
+	}
+	return targetDatabase.GrantConnect(dbUser.Username)
+}
+
+func (uc *ReconcileAccessPermissionsUseCase) persistLog(r *instanceReconciliation, dbUserID, databaseID, message string, success bool) {
+	accessLog, err := entity.NewAccessPermissionLogOfType(entity.AccessPermissionLogTypeReconcile, r.Instance.ID, dbUserID, databaseID, message, r.Ctx.OperationUserID, success)
+	if err == nil {
+		err = uc.AccessPermissionStorage.SaveLog(accessLog)
+	}
+	if err != nil {
+		log.Printf("Error: could not save reconciliation log for instance '%s'. Cause: %v", r.Instance.Name, err)
+	}
+}
+
+func buildReconcileMessage(drifts []dto.AccessDriftDTO, apply bool) string {
+	if !apply {
+		return fmt.Sprintf(DriftsFoundMsg, len(drifts))
+	}
+	corrected := 0
+	for _, drift := range drifts {
+		if drift.Corrected {
+			corrected++
+		}
+	}
+	return fmt.Sprintf(DriftsFoundAndCorrectedMsg, len(drifts), corrected)
+}
@@ -0,0 +1,119 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+// buildReconcileStorages godoc
+// johndoe has access to dummy-db-1 and dummy-db-5, but the dummy connector only lets it connect to dummy-db-1 and
+// dummy-db-2. The dummy user has access to dummy-db-1, but doesn't exist in the instance, and foobar exists in the
+// instance without any access permission.
+func buildReconcileStorages(instance *dto.DatabaseInstanceOutputDTO) (*mocks.AccessPermissionStorageMock, *mocks.DatabaseUserStorageMock, *mocks.DatabaseInstanceStorageMock, *mocks.DatabaseRoleStorageMock) {
+	john := mocks.BuildDbUserJohnDTO()
+	dummy := mocks.BuildDbUserDummyDTO()
+	readOnlyRole := mocks.BuildReadOnlyRole()
+	devOpsRole := &entity.DatabaseRole{ID: uuid.MustParse(john.DatabaseRoleID), Name: entity.DevOps}
+	permissions := []*dto.AccessPermissionOutputDTO{
+		{DatabaseUserID: john.ID, DatabaseRoleID: john.DatabaseRoleID, DatabaseID: uuid.NewString(), DatabaseName: "dummy-db-1"},
+		{DatabaseUserID: john.ID, DatabaseRoleID: john.DatabaseRoleID, DatabaseID: uuid.NewString(), DatabaseName: "dummy-db-5"},
+		{DatabaseUserID: dummy.ID, DatabaseRoleID: readOnlyRole.ID.String(), DatabaseID: uuid.NewString(), DatabaseName: "dummy-db-1"},
+	}
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", "", instance.ID).Return(permissions, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string(nil)).Return([]*dto.DatabaseUserOutputDTO{john, mocks.BuildDbUserFooDTO(), dummy}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return([]*entity.DatabaseRole{devOpsRole, readOnlyRole}, nil).Once()
+	return accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage
+}
+
+func TestGivenInputWithIdsNotExistent_WhenExecuteReconcileAccess_ThenShouldReturnError(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{"1"}).Return([]*dto.DatabaseInstanceOutputDTO{}, sql.ErrNoRows).Once()
+
+	uc := NewReconcileAccessPermissionsUseCase(nil, nil, dbInstanceStorage, nil)
+	outputs, err := uc.Execute(dto.ReconcileAccessInputDTO{DatabaseInstancesIDs: []string{"1"}}, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrNoDatabaseInstancesFound)
+	assert.Nil(t, outputs)
+}
+
+func TestGivenDriftsInTheInstance_WhenExecuteReconcileAccess_ThenShouldReportThemWithoutChangingAnything(t *testing.T) {
+	instance := mocks.BuildAzInstanceDTO()
+	accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage := buildReconcileStorages(instance)
+
+	uc := NewReconcileAccessPermissionsUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage)
+	outputs, err := uc.Execute(dto.ReconcileAccessInputDTO{}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.True(t, outputs[0].Success)
+	assert.Equal(t, fmt.Sprintf(DriftsFoundMsg, 4), outputs[0].Message)
+	assert.Equal(t, []dto.AccessDriftDTO{
+		{Type: DriftMissingUser, DatabaseUserID: "dummy-id", Username: "dummy-user", Details: fmt.Sprintf(MissingUserDetailsMsg, 1)},
+		{Type: DriftMissingGrant, DatabaseUserID: mocks.BuildDbUserJohnDTO().ID, Username: "johndoe", Database: "dummy-db-5", Details: CantConnectDetailsMsg},
+		{Type: DriftExtraGrant, DatabaseUserID: mocks.BuildDbUserJohnDTO().ID, Username: "johndoe", Database: "dummy-db-2", Details: ExtraDatabaseDetailsMsg},
+		{Type: DriftExtraGrant, DatabaseUserID: mocks.DbUserID, Username: "foobar", Details: ExtraUserDetailsMsg},
+	}, outputs[0].Drifts)
+	accessPermissionStorage.AssertNotCalled(t, "SaveLog", mock.Anything)
+}
+
+func TestGivenDriftsInTheInstance_WhenExecuteReconcileAccessWithApply_ThenShouldCorrectThemAndLogEachCorrection(t *testing.T) {
+	instance := mocks.BuildAzInstanceDTO()
+	accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage := buildReconcileStorages(instance)
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool {
+		return l.Type == entity.AccessPermissionLogTypeReconcile && l.Success
+	})).Return(nil).Times(5)
+
+	uc := NewReconcileAccessPermissionsUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage)
+	outputs, err := uc.Execute(dto.ReconcileAccessInputDTO{Apply: true}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.True(t, outputs[0].Success)
+	assert.Equal(t, fmt.Sprintf(DriftsFoundAndCorrectedMsg, 4, 4), outputs[0].Message)
+	drifts := outputs[0].Drifts
+	assert.Len(t, drifts, 4)
+	assert.True(t, drifts[0].Corrected)
+	assert.Equal(t, fmt.Sprintf(ReconcileUserCreatedMsg, "dummy-user", instance.Name), drifts[0].Correction)
+	assert.True(t, drifts[1].Corrected)
+	assert.Equal(t, fmt.Sprintf(ReconcilePermissionGrantedMsg, "johndoe", "dummy-db-5", instance.Name), drifts[1].Correction)
+	assert.True(t, drifts[2].Corrected)
+	assert.Equal(t, fmt.Sprintf(ReconcileDatabaseRevokedMsg, "johndoe", "dummy-db-2", instance.Name), drifts[2].Correction)
+	assert.True(t, drifts[3].Corrected)
+	assert.Equal(t, fmt.Sprintf(ReconcileUserRemovedMsg, "foobar", instance.Name), drifts[3].Correction)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 5)
+}
+
+func TestGivenAnErrorListingTheLogins_WhenExecuteReconcileAccess_ThenShouldReturnSuccessFalse(t *testing.T) {
+	instance := mocks.BuildDummyErrorInstance()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string(nil)).Return([]*dto.DatabaseUserOutputDTO{mocks.BuildDbUserJohnDTO()}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", "", instance.ID).Return([]*dto.AccessPermissionOutputDTO{}, nil).Once()
+
+	uc := NewReconcileAccessPermissionsUseCase(accessPermissionStorage, dbUserStorage, dbInstanceStorage, roleStorage)
+	outputs, err := uc.Execute(dto.ReconcileAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, Apply: true}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.False(t, outputs[0].Success)
+	assert.Equal(t, fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, "error listing logins from "+instance.Name), outputs[0].Message)
+	assert.Empty(t, outputs[0].Drifts)
+}
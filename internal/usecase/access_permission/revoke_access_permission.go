@@ -5,8 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
@@ -15,28 +16,43 @@ import (
 	"github.com/zgsolucoes/zg-data-guard/pkg/utils"
 )
 
+const (
+	AccessRevokeDryRunMsg = "Dry run: the revoke of the access of user '%s' in %d database instances was validated and nothing was executed. The plan has the statements the revoke would execute."
+)
+
 type RevokeAccessPermissionUseCase struct {
 	AccessPermissionStorage storage.AccessPermissionStorage
 	DatabaseInstanceStorage storage.DatabaseInstanceStorage
 	DatabaseUserStorage     storage.DatabaseUserStorage
+	BreakGlassAccessStorage storage.BreakGlassAccessStorage
 }
 
 func NewRevokeAccessPermissionUseCase(
 	accessStorage storage.AccessPermissionStorage,
 	instanceStorage storage.DatabaseInstanceStorage,
 	dbUserStorage storage.DatabaseUserStorage,
+	breakGlassStorage storage.BreakGlassAccessStorage,
 ) *RevokeAccessPermissionUseCase {
 	return &RevokeAccessPermissionUseCase{
-		AccessPermissionStorage: accessStorage, DatabaseInstanceStorage: instanceStorage, DatabaseUserStorage: dbUserStorage}
+		AccessPermissionStorage: accessStorage, DatabaseInstanceStorage: instanceStorage, DatabaseUserStorage: dbUserStorage,
+		BreakGlassAccessStorage: breakGlassStorage}
 }
 
 // Execute godoc
 /** Responsible for revoking access from the selected database instances or from all instances accessible by the user.
-It revokes the user's access concurrently.
+It revokes the user's access concurrently, as tasks of the shared executor.
 It returns an output DTO that has a flag indicating if the process has errors and a message with the result.
 For each error that occurs inside the instance context during the process, it's logged, persisted and the process continues.
+In dry run, the statements each instance would execute are returned in the plan, and nothing is executed nor persisted.
 */
 func (useCase *RevokeAccessPermissionUseCase) Execute(input dto.RevokeAccessInputDTO, operationUserID string) (*dto.RevokeAccessOutputDTO, error) {
+	return useCase.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each instance as an item of the progress once the access of the user is removed from it,
+// and every message logged about an instance as an event of the progress
+func (useCase *RevokeAccessPermissionUseCase) ExecuteWithProgress(input dto.RevokeAccessInputDTO, operationUserID string, progress common.ProgressReporter) (*dto.RevokeAccessOutputDTO, error) {
 	userToRevoke, err := useCase.fetchDatabaseUser(input.DatabaseUserID)
 	if err != nil {
 		return nil, err
@@ -46,7 +62,101 @@ func (useCase *RevokeAccessPermissionUseCase) Execute(input dto.RevokeAccessInpu
 	if err != nil {
 		return nil, err
 	}
-	return useCase.revokeAccess(instancesToRevoke, userToRevoke, operationUserID)
+	return useCase.revokeAccess(instancesToRevoke, userToRevoke, operationUserID, input.DryRun, progress)
+}
+
+// RevokeDatabases godoc
+/** Revokes the access of the user to some databases of the instance only, keeping the user and its access to the other
+databases. Each database has its access revoked in the instance and its permission deleted, and the result is logged.
+When the databases are all the ones the user has access to in the instance, the user is removed from the instance as
+in Execute. The databases without permission of the user are ignored, since their access was already revoked, and when
+none of them has a permission anymore common.ErrNoAccessibleInstancesFound is returned. */
+func (useCase *RevokeAccessPermissionUseCase) RevokeDatabases(databaseUserID, instanceID string, databasesIDs []string, operationUserID string) error {
+	permissions, err := useCase.AccessPermissionStorage.FindAllDTOs("", databaseUserID, instanceID)
+	if err != nil {
+		return err
+	}
+	var permissionsToRevoke []*dto.AccessPermissionOutputDTO
+	for _, permission := range permissions {
+		if utils.Contains(databasesIDs, permission.DatabaseID) {
+			permissionsToRevoke = append(permissionsToRevoke, permission)
+		}
+	}
+	if len(permissionsToRevoke) == 0 {
+		return common.ErrNoAccessibleInstancesFound
+	}
+	if len(permissionsToRevoke) == len(permissions) {
+		return useCase.revokeInstance(databaseUserID, instanceID, operationUserID)
+	}
+
+	userToRevoke, err := useCase.fetchDatabaseUser(databaseUserID)
+	if err != nil {
+		return err
+	}
+	instance, err := useCase.DatabaseInstanceStorage.FindDTOByID(instanceID)
+	if err != nil {
+		return err
+	}
+	log.Printf("Revoking access from database user '%s' to %d databases of instance '%s'. Requester: %s", userToRevoke.Username, len(permissionsToRevoke), instance.Name, operationUserID)
+	failedQty := 0
+	for _, permission := range permissionsToRevoke {
+		if !useCase.revokeDatabase(permission, instance, userToRevoke.Username, operationUserID) {
+			failedQty++
+		}
+	}
+	if failedQty > 0 {
+		return fmt.Errorf("%w: %d of %d databases of instance '%s' failed", ErrCouldNotRevokeDatabases, failedQty, len(permissionsToRevoke), instance.Name)
+	}
+	return nil
+}
+
+func (useCase *RevokeAccessPermissionUseCase) revokeInstance(databaseUserID, instanceID, operationUserID string) error {
+	output, err := useCase.Execute(dto.RevokeAccessInputDTO{DatabaseUserID: databaseUserID, DatabaseInstancesIDs: []string{instanceID}}, operationUserID)
+	if err != nil {
+		return err
+	}
+	if output.HasErrors {
+		return fmt.Errorf("%w: %s", ErrCouldNotRevokeDatabases, output.Message)
+	}
+	return nil
+}
+
+// revokeDatabase godoc
+// Revokes the access of the user to the database of the permission and deletes it, logging the result. The permission
+// is kept when the access can't be revoked, so the revocation can be tried again.
+func (useCase *RevokeAccessPermissionUseCase) revokeDatabase(permission *dto.AccessPermissionOutputDTO, instance *dto.DatabaseInstanceOutputDTO, username, operationUserID string) bool {
+	targetDatabase, err := connector.NewDatabaseConnector(instance, permission.DatabaseName)
+	if err != nil {
+		useCase.saveDatabaseLog(permission, operationUserID, fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error()), false)
+		return false
+	}
+	if err = targetDatabase.RevokeConnect(username); err != nil {
+		useCase.saveDatabaseLog(permission, operationUserID, fmt.Sprintf(ErrRevokeConnectFailedMsg, username, permission.DatabaseName, instance.Name, err.Error()), false)
+		return false
+	}
+	if err = useCase.AccessPermissionStorage.Delete(permission.ID); err != nil {
+		useCase.saveDatabaseLog(permission, operationUserID, fmt.Sprintf(ErrDeletingDatabaseAccessMsg, username, permission.DatabaseName, instance.Name, err.Error()), false)
+		return false
+	}
+	useCase.saveDatabaseLog(permission, operationUserID, fmt.Sprintf(DatabaseAccessRevokedMsg, username, permission.DatabaseName, instance.Name), true)
+	return true
+}
+
+// saveDatabaseLog godoc
+// Saves a log of the revocation of a database. A failure saving the log is only reported in the application log.
+func (useCase *RevokeAccessPermissionUseCase) saveDatabaseLog(permission *dto.AccessPermissionOutputDTO, operationUserID, message string, success bool) {
+	if success {
+		log.Print(message)
+	} else {
+		log.Printf("Error: %s", message)
+	}
+	revokeLog, err := entity.NewAccessPermissionLog(permission.DatabaseInstanceID, permission.DatabaseUserID, permission.DatabaseID, message, operationUserID, success)
+	if err == nil {
+		err = useCase.AccessPermissionStorage.SaveLog(revokeLog)
+	}
+	if err != nil {
+		log.Printf("Error when saving revoke log for instance %s and database user %s. Cause: %v", permission.DatabaseInstanceID, permission.DatabaseUserID, err)
+	}
 }
 
 func (useCase *RevokeAccessPermissionUseCase) fetchDatabaseUser(userID string) (*entity.DatabaseUser, error) {
@@ -85,7 +195,12 @@ func (useCase *RevokeAccessPermissionUseCase) determineInstancesToRevoke(input d
 	return instancesToRevoke, nil
 }
 
-func (useCase *RevokeAccessPermissionUseCase) revokeAccess(dbInstances []*dto.DatabaseInstanceOutputDTO, dbUser *entity.DatabaseUser, operationUserID string) (*dto.RevokeAccessOutputDTO, error) {
+func (useCase *RevokeAccessPermissionUseCase) revokeAccess(
+	dbInstances []*dto.DatabaseInstanceOutputDTO,
+	dbUser *entity.DatabaseUser,
+	operationUserID string,
+	dryRun bool,
+	progress common.ProgressReporter) (*dto.RevokeAccessOutputDTO, error) {
 	instancesQty := len(dbInstances)
 	resultCh := make(chan *loggableRevokeResult, instancesQty)
 	output := &dto.RevokeAccessOutputDTO{
@@ -93,22 +208,29 @@ func (useCase *RevokeAccessPermissionUseCase) revokeAccess(dbInstances []*dto.Da
 		Message:   fmt.Sprintf("Successfully revoked access for user '%s' in %d database instances!", dbUser.Username, instancesQty),
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(instancesQty)
+	recorders := common.NewStatementRecorders(dryRun, dbInstances)
+	batch := config.GetExecutor().NewBatch("revoke access")
+	progress.AddItems(instancesQty)
 	for idx, instance := range dbInstances {
-		go func(instance *dto.DatabaseInstanceOutputDTO, instanceIndex int, resultCh chan<- *loggableRevokeResult) {
-			defer wg.Done()
-			revokeCtx := newRevokeAccessContext(instance, dbUser, instancesQty, instanceIndex, operationUserID)
+		batch.Go(instance.ID, func(time.Duration) {
+			revokeCtx := newRevokeAccessContext(instance, dbUser, instancesQty, idx, operationUserID, progress, recorders[instance.ID])
 			resultCh <- useCase.revokeUserAccessAndRemoveFromInstance(revokeCtx)
-		}(instance, idx, resultCh)
+		})
 	}
 
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(resultCh)
 	}()
 
 	useCase.processResult(resultCh, output)
+	output.Execution = common.BuildExecutionOutput(batch.Stats())
+	if dryRun {
+		output.Plan = common.BuildDryRunPlan(dbInstances, recorders)
+		if !output.HasErrors {
+			output.Message = fmt.Sprintf(AccessRevokeDryRunMsg, dbUser.Username, instancesQty)
+		}
+	}
 	log.Printf("Revoke access process finished for user '%s' in %d database instances", dbUser.Username, instancesQty)
 	return output, nil
 }
@@ -116,13 +238,18 @@ func (useCase *RevokeAccessPermissionUseCase) revokeAccess(dbInstances []*dto.Da
 func (useCase *RevokeAccessPermissionUseCase) revokeUserAccessAndRemoveFromInstance(revokeCtx *revokeAccessContext) *loggableRevokeResult {
 	result := &loggableRevokeResult{RevokeCtx: revokeCtx}
 
-	targetInstance, err := connector.NewDatabaseConnector(revokeCtx.Instance, "")
+	targetInstance, err := common.NewConnector(revokeCtx.Instance, "", revokeCtx.Recorder)
 	if err != nil {
 		result.Err = fmt.Errorf("could not create connector. Details: %w", err)
 		result.LogMessagePt = fmt.Sprintf(ErrCreatingConnectorMsg, revokeCtx.Instance.Name, err.Error())
 		return result
 	}
-	logRevokeContextWithIndex(revokeCtx, fmt.Sprintf("%s Revoking connection grants and removing user from instance", connector.ClusterConnectorPrefix))
+	if err = useCase.revokeScopes(revokeCtx); err != nil {
+		result.Err = fmt.Errorf("could not revoke the scopes of the user. Details: %w", err)
+		result.LogMessagePt = fmt.Sprintf(ErrRevokeAndDropUserFailedMsg, revokeCtx.User.Username, revokeCtx.Instance.Name, err.Error())
+		return result
+	}
+	logRevokeContextWithIndex(revokeCtx, fmt.Sprintf("%s Revoking connection grants and removing user from instance", connector.ClusterConnectorPrefix), false)
 	err = targetInstance.RevokeUserPrivilegesAndRemove(revokeCtx.User.Username)
 	if err != nil {
 		result.Err = fmt.Errorf("%s could not revoke and drop user. Details: %w", connector.ClusterConnectorPrefix, err)
@@ -130,15 +257,41 @@ func (useCase *RevokeAccessPermissionUseCase) revokeUserAccessAndRemoveFromInsta
 		return result
 	}
 
-	logRevokeContextWithIndex(revokeCtx, fmt.Sprintf("%s User access revoked and removed from instance", connector.ClusterConnectorPrefix))
+	logRevokeContextWithIndex(revokeCtx, fmt.Sprintf("%s User access revoked and removed from instance", connector.ClusterConnectorPrefix), false)
 	return result
 }
 
+// revokeScopes godoc
+// Removes the privileges of the scoped permissions of the user, which are held in each database by a role of the user
+// that is not removed with it
+func (useCase *RevokeAccessPermissionUseCase) revokeScopes(revokeCtx *revokeAccessContext) error {
+	permissions, err := useCase.AccessPermissionStorage.FindAllDTOs("", revokeCtx.User.ID.String(), revokeCtx.Instance.ID)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if permission.Scope == nil {
+			continue
+		}
+		logRevokeContextWithIndex(revokeCtx, fmt.Sprintf("Revoking the scope of the user in database '%s'", permission.DatabaseName), false)
+		targetDatabase, err := common.NewConnector(revokeCtx.Instance, permission.DatabaseName, revokeCtx.Recorder)
+		if err != nil {
+			return err
+		}
+		if err = targetDatabase.RevokeScope(revokeCtx.User.Username); err != nil {
+			return fmt.Errorf("database '%s': %w", permission.DatabaseName, err)
+		}
+	}
+	return nil
+}
+
 func (useCase *RevokeAccessPermissionUseCase) processResult(resultCh <-chan *loggableRevokeResult, output *dto.RevokeAccessOutputDTO) {
 	for loggableResult := range resultCh {
 		if loggableResult.Err != nil {
 			output.HasErrors = true
 			output.Message = SomeErrorsDuringProcessMsg
+		} else if loggableResult.RevokeCtx.Recorder != nil {
+			loggableResult.LogMessagePt = fmt.Sprintf(UserAccessRevokedAndExcludedMsg, loggableResult.RevokeCtx.User.Username, loggableResult.RevokeCtx.Instance.Name)
 		} else {
 			dbUserID := loggableResult.RevokeCtx.User.ID.String()
 			instanceID := loggableResult.RevokeCtx.Instance.ID
@@ -149,17 +302,37 @@ func (useCase *RevokeAccessPermissionUseCase) processResult(resultCh <-chan *log
 				loggableResult.Err = fmt.Errorf("could not delete all access from database user '%s' in database instance '%s'. Cause: %w", dbUserID, instanceID, err)
 				loggableResult.LogMessagePt = fmt.Sprintf(ErrDeletingAccessOfUserMsg, loggableResult.RevokeCtx.User.Username, loggableResult.RevokeCtx.Instance.Name, err.Error())
 			} else {
-				logRevokeContextWithIndex(loggableResult.RevokeCtx, "All user access to the respective instance has been successfully deleted!")
+				logRevokeContextWithIndex(loggableResult.RevokeCtx, "All user access to the respective instance has been successfully deleted!", false)
 				loggableResult.LogMessagePt = fmt.Sprintf(UserAccessRevokedAndExcludedMsg, loggableResult.RevokeCtx.User.Username, loggableResult.RevokeCtx.Instance.Name)
+				useCase.closeBreakGlassAccess(loggableResult.RevokeCtx)
 			}
 		}
 		useCase.persistLog(*loggableResult, output)
 	}
 }
 
+// closeBreakGlassAccess godoc
+// The user was removed from the instance along with the role of its break-glass access, so the ones still open there
+// are closed. A failure is only reported in the application log, since the expired access of a user no longer in the
+// instance is closed anyway when its window ends.
+func (useCase *RevokeAccessPermissionUseCase) closeBreakGlassAccess(revokeCtx *revokeAccessContext) {
+	err := useCase.BreakGlassAccessStorage.UpdateRevokedAtByUserAndInstance(revokeCtx.User.ID.String(), revokeCtx.Instance.ID, time.Now())
+	if err != nil {
+		log.Printf("Error closing the break-glass access of database user '%s' in instance '%s'. Cause: %v", revokeCtx.User.Username, revokeCtx.Instance.Name, err)
+	}
+}
+
 func (useCase *RevokeAccessPermissionUseCase) persistLog(loggableResult loggableRevokeResult, output *dto.RevokeAccessOutputDTO) {
 	if loggableResult.Err != nil {
-		logRevokeContextWithIndex(loggableResult.RevokeCtx, fmt.Sprintf("Error: %s", loggableResult.Err.Error()))
+		logRevokeContextWithIndex(loggableResult.RevokeCtx, loggableResult.Err.Error(), true)
+	}
+	loggableResult.RevokeCtx.Progress.ItemProcessed(dto.ItemResultDTO{
+		Item:    loggableResult.RevokeCtx.Instance.Name,
+		Success: loggableResult.Err == nil,
+		Message: loggableResult.LogMessagePt,
+	})
+	if loggableResult.RevokeCtx.Recorder != nil {
+		return
 	}
 	accessLog, err := newLog(loggableResult)
 	if err != nil {
@@ -199,7 +372,19 @@ func newLog(loggableResult loggableRevokeResult) (*entity.AccessPermissionLog, e
 	)
 }
 
-func logRevokeContextWithIndex(revokeCtx *revokeAccessContext, message string) {
+func logRevokeContextWithIndex(revokeCtx *revokeAccessContext, message string, isError bool) {
+	revokeCtx.Progress.Event(dto.ProgressEventDTO{
+		Instance:      revokeCtx.Instance.Name,
+		InstanceIndex: revokeCtx.InstanceIndex + 1,
+		InstancesQty:  revokeCtx.InstancesQty,
+		User:          revokeCtx.User.Username,
+		Message:       message,
+		Error:         isError,
+		Time:          time.Now(),
+	})
+	if isError {
+		message = fmt.Sprintf("Error: %s", message)
+	}
 	log.Printf("user {%s} | [%d/%d] instance {%s # %s}: %s",
 		revokeCtx.User.Username, revokeCtx.InstanceIndex+1, revokeCtx.InstancesQty, revokeCtx.Instance.EcosystemName, revokeCtx.Instance.Name, message)
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
@@ -18,7 +19,7 @@ func TestGivenAnErrorInDbWhenFetchingDBUser_WhenExecuteRevokeAccess_ThenShouldRe
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", "").Return(&entity.DatabaseUser{}, sql.ErrConnDone).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(nil, nil, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(nil, nil, dbUserStorage, nil)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseUserID: "", DatabaseInstancesIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -31,7 +32,7 @@ func TestGivenAnNotExistingIDWhenFetchingDBUser_WhenExecuteRevokeAccess_ThenShou
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", "").Return(&entity.DatabaseUser{}, sql.ErrNoRows).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(nil, nil, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(nil, nil, dbUserStorage, nil)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseUserID: "", DatabaseInstancesIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when database user not found in db")
@@ -44,9 +45,10 @@ func TestGivenAnErrorInDbWhenFetchingAccessibleInstances_WhenExecuteRevokeAccess
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", "").Return(&entity.DatabaseUser{}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", "").Return([]string{}, sql.ErrConnDone).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage, nil)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -60,9 +62,10 @@ func TestGivenAnUserWithNoAccessibleInstances_WhenExecuteRevokeAccess_ThenShould
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", "").Return(&entity.DatabaseUser{}, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", "").Return([]string{}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage, nil)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when the database user has no accessible instances")
@@ -78,9 +81,10 @@ func TestGivenNonAccessibleInstancesInput_WhenExecuteRevokeAccess_ThenShouldRetu
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUser.ID.String()).Return([]string{instance.ID}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, nil, dbUserStorage, nil)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{
 		DatabaseInstancesIDs: []string{"57200738-9b52-4c31-945b-fb1603df4f37", mocks.DatabaseInstanceId, mocks.DummyErrorInstanceId},
 		DatabaseUserID:       dbUser.ID.String()},
@@ -100,11 +104,12 @@ func TestGivenAnErrorInDbWhenFetchingInstances_WhenExecuteRevokeAccess_ThenShoul
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUser.ID.String()).Return([]string{instance.ID}, nil).Once()
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{}, sql.ErrConnDone).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUser.ID.String()}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -122,6 +127,7 @@ func TestGivenAnErrorInDbWhenDeletingAccess_WhenExecuteRevokeAccess_ThenShouldRe
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
 	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(sql.ErrConnDone).Once()
 	expectedLogMsg := fmt.Sprintf(ErrDeletingAccessOfUserMsg, dbUser.Username, instance.Name, sql.ErrConnDone.Error())
@@ -130,7 +136,7 @@ func TestGivenAnErrorInDbWhenDeletingAccess_WhenExecuteRevokeAccess_ThenShouldRe
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -151,12 +157,13 @@ func TestGivenAnErrorWhenCreatingLog_WhenExecuteRevokeAccess_ThenShouldReturnOut
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
 	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, "")
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged. The user ID is not informed")
@@ -176,6 +183,7 @@ func TestGivenAnErrorInDbWhenSavingLog_WhenExecuteRevokeAccess_ThenShouldReturnO
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
 	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
 	expectedLogMsg := fmt.Sprintf(UserAccessRevokedAndExcludedMsg, dbUser.Username, instance.Name)
@@ -184,7 +192,7 @@ func TestGivenAnErrorInDbWhenSavingLog_WhenExecuteRevokeAccess_ThenShouldReturnO
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -201,7 +209,7 @@ func TestGivenAnErrorInDbWhenSavingLog_WhenExecuteRevokeAccess_ThenShouldReturnO
 func TestGivenAnErrorWhenCreatingConnector_WhenExecuteRevokeAccess_ThenShouldReturnOutputError(t *testing.T) {
 	dbUser := mocks.BuildDbUserJohn()
 	instance := mocks.BuildConnectorNotImplementedInstance()
-	expectedLogMsg := fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, "the database technology 'mysql' don't have a connector implemented")
+	expectedLogMsg := fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, "the database technology 'oracle' don't have a connector implemented")
 	runRevokeLoggingSingleError(t, dbUser, instance, expectedLogMsg)
 }
 
@@ -212,6 +220,32 @@ func TestGivenAnErrorWhenRevokingPermissionsInInstance_WhenExecuteRevokeAccess_T
 	runRevokeLoggingSingleError(t, dbUser, instance, expectedLogMsg)
 }
 
+func TestGivenAnErrorInDbWhenFetchingTheScopes_WhenExecuteRevokeAccess_ThenShouldReturnOutputErrorWithoutRemovingTheUser(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUserID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{}, sql.ErrConnDone).Once()
+	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
+	expectedLogMsg := fmt.Sprintf(ErrRevokeAndDropUserFailedMsg, dbUser.Username, instance.Name, sql.ErrConnDone.Error())
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUserID, "", expectedLogMsg, mocks.UserID, false)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
+
+	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, SomeErrorsDuringProcessMsg, output.Message)
+	accessPermissionStorage.AssertNumberOfCalls(t, "FindAllDTOs", 1)
+	accessPermissionStorage.AssertNotCalled(t, "DeleteAllByUserAndInstance", dbUserID, instance.ID)
+	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
+}
+
 func TestGivenValidInput_WhenExecuteRevokeAccess_ThenShouldReturnOutputSuccess(t *testing.T) {
 	instance := mocks.BuildQAInstanceDTO()
 	dbUser := mocks.BuildDbUserJohn()
@@ -219,6 +253,7 @@ func TestGivenValidInput_WhenExecuteRevokeAccess_ThenShouldReturnOutputSuccess(t
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
 	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
 	expectedLogMsg := fmt.Sprintf(UserAccessRevokedAndExcludedMsg, dbUser.Username, instance.Name)
@@ -227,13 +262,18 @@ func TestGivenValidInput_WhenExecuteRevokeAccess_ThenShouldReturnOutputSuccess(t
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("UpdateRevokedAtByUserAndInstance", dbUserID, instance.ID, mock.Anything).Return(nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, breakGlassStorage)
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
 	assert.NotNil(t, output)
 	assert.False(t, output.HasErrors)
 	assert.Equal(t, "Successfully revoked access for user 'johndoe' in 1 database instances!", output.Message)
+	breakGlassStorage.AssertExpectations(t)
+	assert.Equal(t, 1, output.Execution.Tasks)
 	dbUserStorage.AssertNumberOfCalls(t, "FindByID", 1)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindAllDTOs", 1)
 	accessPermissionStorage.AssertNumberOfCalls(t, "FindAllAccessibleInstancesIDsByUser", 1)
@@ -241,11 +281,97 @@ func TestGivenValidInput_WhenExecuteRevokeAccess_ThenShouldReturnOutputSuccess(t
 	accessPermissionStorage.AssertNumberOfCalls(t, "SaveLog", 1)
 }
 
+func TestGivenAnErrorClosingTheBreakGlassAccess_WhenExecuteRevokeAccess_ThenShouldStillReturnOutputSuccess(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
+	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
+	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("UpdateRevokedAtByUserAndInstance", dbUserID, instance.ID, mock.Anything).Return(sql.ErrConnDone).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, breakGlassStorage)
+	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors, "the expired break-glass access of a user no longer in the instance is closed by its own job")
+	breakGlassStorage.AssertExpectations(t)
+}
+
+func TestGivenDryRun_WhenExecuteRevokeAccess_ThenShouldReturnThePlanWithoutDeletingOrLogging(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
+	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID, DryRun: true}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, fmt.Sprintf(AccessRevokeDryRunMsg, dbUser.Username, 1), output.Message)
+	assert.Len(t, output.Plan, 1)
+	assert.Equal(t, []dto.DryRunDatabaseOutputDTO{{Database: "dummy-test-db", Statements: []string{"DROP USER johndoe"}}}, output.Plan[0].Databases)
+	accessPermissionStorage.AssertNotCalled(t, "DeleteAllByUserAndInstance", mock.Anything, mock.Anything)
+	accessPermissionStorage.AssertNotCalled(t, "SaveLog", mock.Anything)
+}
+
+func TestGivenAProgressReporter_WhenExecuteRevokeAccess_ThenShouldReportTheEventsAndTheInstanceProcessed(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
+	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
+	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	progress := new(mocks.ProgressReporterMock)
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	output, err := uc.ExecuteWithProgress(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID, progress)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, 1, progress.Total())
+	assert.Equal(t, []dto.ItemResultDTO{{
+		Item:    instance.Name,
+		Success: true,
+		Message: fmt.Sprintf(UserAccessRevokedAndExcludedMsg, dbUser.Username, instance.Name),
+	}}, progress.Items())
+	events := progress.Events()
+	assert.Len(t, events, 3, "revoking, revoked and access deleted")
+	for _, event := range events {
+		assert.Equal(t, instance.Name, event.Instance)
+		assert.Equal(t, 1, event.InstanceIndex)
+		assert.Equal(t, 1, event.InstancesQty)
+		assert.Equal(t, dbUser.Username, event.User)
+		assert.False(t, event.Error)
+	}
+}
+
 func runRevokeLoggingSingleError(t *testing.T, dbUser *entity.DatabaseUser, instance *dto.DatabaseInstanceOutputDTO, expectedLogMsg string) {
 	dbUserID := getDBUserID(dbUser)
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", dbUser.ID.String()).Return(dbUser, nil).Once()
 	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", mock.Anything, mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil)
 	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUser.ID.String()).Return([]string{instance.ID}, nil).Once()
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
@@ -253,7 +379,7 @@ func runRevokeLoggingSingleError(t *testing.T, dbUser *entity.DatabaseUser, inst
 	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUserID, "", expectedLogMsg, mocks.UserID, false)
 	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
 
-	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage)
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
 	output, err := uc.Execute(dto.RevokeAccessInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DatabaseUserID: dbUserID}, mocks.UserID)
 
 	assert.NoError(t, err, "the error should be in the output, not in the process and has to be logged")
@@ -272,3 +398,103 @@ func getDBUserID(dbUser *entity.DatabaseUser) string {
 	}
 	return ""
 }
+
+func buildBreakGlassStorage() *mocks.BreakGlassAccessStorageMock {
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("UpdateRevokedAtByUserAndInstance", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return breakGlassStorage
+}
+
+func buildDatabasePermission(id, dbUserID string, instance *dto.DatabaseInstanceOutputDTO, databaseID, databaseName string) *dto.AccessPermissionOutputDTO {
+	return &dto.AccessPermissionOutputDTO{ID: id, DatabaseUserID: dbUserID, DatabaseInstanceID: instance.ID, DatabaseID: databaseID, DatabaseName: databaseName}
+}
+
+func TestGivenSomeDatabasesOfTheInstance_WhenRevokeDatabases_ThenShouldRevokeAndDeleteOnlyTheirPermissions(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUserID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{
+		buildDatabasePermission("p1", dbUserID, instance, mocks.DatabaseID, "orders"),
+		buildDatabasePermission("p2", dbUserID, instance, otherDatabaseID, "billing"),
+	}, nil).Once()
+	accessPermissionStorage.On("Delete", "p1").Return(nil).Once()
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUserID, mocks.DatabaseID, fmt.Sprintf(DatabaseAccessRevokedMsg, dbUser.Username, "orders", instance.Name), mocks.UserID, true)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindDTOByID", instance.ID).Return(instance, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	err := uc.RevokeDatabases(dbUserID, instance.ID, []string{mocks.DatabaseID, thirdDatabaseID}, mocks.UserID)
+
+	assert.NoError(t, err)
+	accessPermissionStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertNotCalled(t, "DeleteAllByUserAndInstance", mock.Anything, mock.Anything)
+}
+
+func TestGivenAllDatabasesOfTheInstance_WhenRevokeDatabases_ThenShouldRemoveTheUserFromTheInstance(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUserID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{
+		buildDatabasePermission("p1", dbUserID, instance, mocks.DatabaseID, "orders"),
+	}, nil)
+	accessPermissionStorage.On("FindAllAccessibleInstancesIDsByUser", dbUserID).Return([]string{instance.ID}, nil).Once()
+	accessPermissionStorage.On("DeleteAllByUserAndInstance", dbUserID, instance.ID).Return(nil).Once()
+	accessPermissionStorage.On("SaveLog", mock.Anything).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	err := uc.RevokeDatabases(dbUserID, instance.ID, []string{mocks.DatabaseID}, mocks.UserID)
+
+	assert.NoError(t, err)
+	accessPermissionStorage.AssertNumberOfCalls(t, "DeleteAllByUserAndInstance", 1)
+	accessPermissionStorage.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestGivenAnErrorWhenRevokingConnect_WhenRevokeDatabases_ThenShouldKeepThePermissionAndReturnError(t *testing.T) {
+	instance := mocks.BuildDummyErrorInstance()
+	dbUser := mocks.BuildDbUserJohn()
+	dbUserID := getDBUserID(dbUser)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUserID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{
+		buildDatabasePermission("p1", dbUserID, instance, mocks.DatabaseID, "orders"),
+		buildDatabasePermission("p2", dbUserID, instance, otherDatabaseID, "billing"),
+	}, nil).Once()
+	expectedLogMsg := fmt.Sprintf(ErrRevokeConnectFailedMsg, dbUser.Username, "orders", instance.Name, "error revoking connect: Instance(instance-dummy-test-error) - User(johndoe)")
+	expectedLog, _ := entity.NewAccessPermissionLog(instance.ID, dbUserID, mocks.DatabaseID, expectedLogMsg, mocks.UserID, false)
+	accessPermissionStorage.On("SaveLog", testdata.CompareLogs(expectedLog)).Return(nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindDTOByID", instance.ID).Return(instance, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, dbInstanceStorage, dbUserStorage, buildBreakGlassStorage())
+	err := uc.RevokeDatabases(dbUserID, instance.ID, []string{mocks.DatabaseID}, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrCouldNotRevokeDatabases)
+	accessPermissionStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestGivenDatabasesWithoutPermission_WhenRevokeDatabases_ThenShouldReturnNoAccessibleInstancesError(t *testing.T) {
+	instance := mocks.BuildQAInstanceDTO()
+	dbUserID := getDBUserID(mocks.BuildDbUserJohn())
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", dbUserID, instance.ID).Return([]*dto.AccessPermissionOutputDTO{
+		buildDatabasePermission("p2", dbUserID, instance, otherDatabaseID, "billing"),
+	}, nil).Once()
+
+	uc := NewRevokeAccessPermissionUseCase(accessPermissionStorage, new(mocks.DatabaseInstanceStorageMock), new(mocks.DatabaseUserStorageMock), nil)
+	err := uc.RevokeDatabases(dbUserID, instance.ID, []string{mocks.DatabaseID}, mocks.UserID)
+
+	assert.ErrorIs(t, err, common.ErrNoAccessibleInstancesFound)
+	accessPermissionStorage.AssertNotCalled(t, "Delete", mock.Anything)
+	accessPermissionStorage.AssertNotCalled(t, "DeleteAllByUserAndInstance", mock.Anything, mock.Anything)
+}
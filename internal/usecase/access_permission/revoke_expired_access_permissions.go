@@ -0,0 +1,106 @@
+package accesspermission
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var ErrCouldNotRevokeAllExpiredAccess = errors.New("could not revoke all expired access permissions")
+
+type RevokeExpiredAccessPermissionsUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	ApplicationUserStorage  storage.ApplicationUserStorage
+	RevokeAccessUseCase     common.RevokeAccessPermissionUseCaseInterface
+}
+
+func NewRevokeExpiredAccessPermissionsUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	applicationUserStorage storage.ApplicationUserStorage,
+	revokeAccessUseCase common.RevokeAccessPermissionUseCaseInterface,
+) *RevokeExpiredAccessPermissionsUseCase {
+	return &RevokeExpiredAccessPermissionsUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		ApplicationUserStorage:  applicationUserStorage,
+		RevokeAccessUseCase:     revokeAccessUseCase,
+	}
+}
+
+// Execute godoc
+/** Revokes the access of the users to the databases whose permissions have expired.
+The access is revoked through the revoke access use case, with the system user as operator, so each database revoked is
+logged like a manual revocation. Only the expired databases are revoked, the user keeps the access to the other
+databases of the instance, and is removed from the instance when all its permissions there have expired.
+When a revocation fails the permissions are kept and the revocation is tried again on the next execution. */
+func (uc *RevokeExpiredAccessPermissionsUseCase) Execute() error {
+	permissions, err := uc.AccessPermissionStorage.FindAllExpiredDTOs(time.Now())
+	if err != nil {
+		return fmt.Errorf("error fetching expired access permissions. Cause: %w", err)
+	}
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	systemUser, err := uc.ApplicationUserStorage.FindByEmail(entity.SystemUserEmail)
+	if err != nil {
+		return fmt.Errorf("error fetching the system user '%s'. Cause: %w", entity.SystemUserEmail, err)
+	}
+	expiredAccesses := groupExpiredByUserAndInstance(permissions)
+	log.Printf("Revoking %d expired access permissions in %d instances of database users", len(permissions), len(expiredAccesses))
+	failedQty := 0
+	for _, expiredAccess := range expiredAccesses {
+		if !uc.revokeExpiredAccess(expiredAccess, systemUser.ID.String()) {
+			failedQty++
+		}
+	}
+	if failedQty > 0 {
+		return fmt.Errorf("%w: %d of %d instances of database users failed", ErrCouldNotRevokeAllExpiredAccess, failedQty, len(expiredAccesses))
+	}
+	return nil
+}
+
+// expiredAccess godoc
+// The databases of an instance where the permissions of a user have expired
+type expiredAccess struct {
+	DatabaseUserID string
+	InstanceID     string
+	DatabasesIDs   []string
+}
+
+// groupExpiredByUserAndInstance godoc
+// Groups the expired permissions by user and instance, keeping the order they were found in
+func groupExpiredByUserAndInstance(permissions []*dto.AccessPermissionOutputDTO) []*expiredAccess {
+	var expiredAccesses []*expiredAccess
+	byUserAndInstance := make(map[string]*expiredAccess)
+	for _, permission := range permissions {
+		key := permission.DatabaseUserID + "/" + permission.DatabaseInstanceID
+		access, found := byUserAndInstance[key]
+		if !found {
+			access = &expiredAccess{DatabaseUserID: permission.DatabaseUserID, InstanceID: permission.DatabaseInstanceID}
+			byUserAndInstance[key] = access
+			expiredAccesses = append(expiredAccesses, access)
+		}
+		access.DatabasesIDs = append(access.DatabasesIDs, permission.DatabaseID)
+	}
+	return expiredAccesses
+}
+
+func (uc *RevokeExpiredAccessPermissionsUseCase) revokeExpiredAccess(access *expiredAccess, systemUserID string) bool {
+	log.Printf("Access permissions of database user '%s' expired in %d databases of instance '%s'", access.DatabaseUserID, len(access.DatabasesIDs), access.InstanceID)
+	err := uc.RevokeAccessUseCase.RevokeDatabases(access.DatabaseUserID, access.InstanceID, access.DatabasesIDs, systemUserID)
+	if err != nil {
+		// The access may have been revoked manually since the expired permissions were fetched
+		if errors.Is(err, common.ErrNoAccessibleInstancesFound) {
+			return true
+		}
+		log.Printf("Error revoking expired access permissions of database user '%s'. They will be tried again. Cause: %v", access.DatabaseUserID, err)
+		return false
+	}
+	return true
+}
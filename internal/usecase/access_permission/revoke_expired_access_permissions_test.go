@@ -0,0 +1,114 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const (
+	otherDbUserID   = "5b0a1f3e-2c4d-4e6f-8a9b-0c1d2e3f4a5b"
+	otherDatabaseID = "9d6e2b1a-4c3f-4a5e-8b7d-1f2e3d4c5b6a"
+	thirdDatabaseID = "2e7f3c4d-5a6b-4c8d-9e0f-1a2b3c4d5e6f"
+)
+
+func buildSystemUser() *entity.ApplicationUser {
+	return &entity.ApplicationUser{ID: uuid.MustParse(mocks.UserID), Name: "zg-service", Email: entity.SystemUserEmail, Enabled: true}
+}
+
+func buildExpiredPermission(dbUserID, instanceID, databaseID string) *dto.AccessPermissionOutputDTO {
+	return &dto.AccessPermissionOutputDTO{ID: uuid.NewString(), DatabaseUserID: dbUserID, DatabaseInstanceID: instanceID, DatabaseID: databaseID}
+}
+
+func TestGivenAnErrorInDbWhenFetchingExpiredAccess_WhenExecuteRevokeExpired_ThenShouldReturnError(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, sql.ErrConnDone).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewRevokeExpiredAccessPermissionsUseCase(accessPermissionStorage, nil, revokeUC)
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGivenNoExpiredAccess_WhenExecuteRevokeExpired_ThenShouldNotRevokeAnything(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.AccessPermissionOutputDTO{}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewRevokeExpiredAccessPermissionsUseCase(accessPermissionStorage, userStorage, revokeUC)
+	err := uc.Execute()
+
+	assert.NoError(t, err)
+	userStorage.AssertNotCalled(t, "FindByEmail", mock.Anything)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGivenAnErrorInDbWhenFetchingSystemUser_WhenExecuteRevokeExpired_ThenShouldReturnError(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllExpiredDTOs", mock.Anything).
+		Return([]*dto.AccessPermissionOutputDTO{buildExpiredPermission(mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID)}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByEmail", entity.SystemUserEmail).Return(&entity.ApplicationUser{}, sql.ErrNoRows).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewRevokeExpiredAccessPermissionsUseCase(accessPermissionStorage, userStorage, revokeUC)
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGivenExpiredAccess_WhenExecuteRevokeExpired_ThenShouldRevokeTheExpiredDatabasesOfEachInstanceAsTheSystemUser(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.AccessPermissionOutputDTO{
+		buildExpiredPermission(mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID),
+		buildExpiredPermission(mocks.DbUserID, mocks.QAInstanceId, otherDatabaseID),
+		buildExpiredPermission(mocks.DbUserID, mocks.DatabaseInstanceId, thirdDatabaseID),
+		buildExpiredPermission(otherDbUserID, mocks.QAInstanceId, mocks.DatabaseID),
+	}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByEmail", entity.SystemUserEmail).Return(buildSystemUser(), nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID, otherDatabaseID}, mocks.UserID).Return(nil).Once()
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.DatabaseInstanceId, []string{thirdDatabaseID}, mocks.UserID).Return(nil).Once()
+	revokeUC.On("RevokeDatabases", otherDbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(common.ErrNoAccessibleInstancesFound).Once()
+
+	uc := NewRevokeExpiredAccessPermissionsUseCase(accessPermissionStorage, userStorage, revokeUC)
+	err := uc.Execute()
+
+	assert.NoError(t, err, "an access already revoked is not an error")
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenARevocationWithErrors_WhenExecuteRevokeExpired_ThenShouldRevokeTheOthersAndReturnError(t *testing.T) {
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.AccessPermissionOutputDTO{
+		buildExpiredPermission(mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID),
+		buildExpiredPermission(otherDbUserID, mocks.QAInstanceId, mocks.DatabaseID),
+		buildExpiredPermission(otherDbUserID, mocks.DatabaseInstanceId, thirdDatabaseID),
+	}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByEmail", entity.SystemUserEmail).Return(buildSystemUser(), nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(ErrCouldNotRevokeDatabases).Once()
+	revokeUC.On("RevokeDatabases", otherDbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(sql.ErrConnDone).Once()
+	revokeUC.On("RevokeDatabases", otherDbUserID, mocks.DatabaseInstanceId, []string{thirdDatabaseID}, mocks.UserID).Return(nil).Once()
+
+	uc := NewRevokeExpiredAccessPermissionsUseCase(accessPermissionStorage, userStorage, revokeUC)
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, ErrCouldNotRevokeAllExpiredAccess)
+	assert.ErrorContains(t, err, "2 of 3 instances of database users failed")
+	revokeUC.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package accesspermission
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+)
+
+var ErrCouldNotRevokeAllExpiredBreakGlassAccess = errors.New("could not revoke all expired break-glass access")
+
+type RevokeExpiredBreakGlassAccessUseCase struct {
+	AccessPermissionStorage storage.AccessPermissionStorage
+	BreakGlassAccessStorage storage.BreakGlassAccessStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	ApplicationUserStorage  storage.ApplicationUserStorage
+	Notifier                notifier.Notifier
+}
+
+func NewRevokeExpiredBreakGlassAccessUseCase(
+	accessPermissionStorage storage.AccessPermissionStorage,
+	breakGlassAccessStorage storage.BreakGlassAccessStorage,
+	databaseInstanceStorage storage.DatabaseInstanceStorage,
+	applicationUserStorage storage.ApplicationUserStorage,
+	notifier notifier.Notifier,
+) *RevokeExpiredBreakGlassAccessUseCase {
+	return &RevokeExpiredBreakGlassAccessUseCase{
+		AccessPermissionStorage: accessPermissionStorage,
+		BreakGlassAccessStorage: breakGlassAccessStorage,
+		DatabaseInstanceStorage: databaseInstanceStorage,
+		ApplicationUserStorage:  applicationUserStorage,
+		Notifier:                notifier,
+	}
+}
+
+// Execute godoc
+/** Revokes the entity.BreakGlassRole granted by the break-glass access whose window closed, with the system user as
+operator. The role is granted in each database, so it's only revoked from a database once all the break-glass access
+of the user to the database closed. A user already removed from the instance, e.g. by the expiration of its access
+permissions, has nothing left to revoke.
+When a revocation fails the access stays open and the revocation is tried again on the next execution. */
+func (uc *RevokeExpiredBreakGlassAccessUseCase) Execute() error {
+	accesses, err := uc.BreakGlassAccessStorage.FindAllExpiredDTOs(time.Now())
+	if err != nil {
+		return fmt.Errorf("error fetching expired break-glass access. Cause: %w", err)
+	}
+	if len(accesses) == 0 {
+		return nil
+	}
+	systemUser, err := uc.ApplicationUserStorage.FindByEmail(entity.SystemUserEmail)
+	if err != nil {
+		return fmt.Errorf("error fetching the system user '%s'. Cause: %w", entity.SystemUserEmail, err)
+	}
+	instancesIDs := make([]string, 0, len(accesses))
+	for _, access := range accesses {
+		instancesIDs = append(instancesIDs, access.DatabaseInstanceID)
+	}
+	dbInstances, err := uc.DatabaseInstanceStorage.FindAllDTOs("", "", instancesIDs)
+	if err != nil {
+		return err
+	}
+	instancesByID := make(map[string]*dto.DatabaseInstanceOutputDTO, len(dbInstances))
+	for _, dbInstance := range dbInstances {
+		instancesByID[dbInstance.ID] = dbInstance
+	}
+
+	log.Printf("Revoking %d expired break-glass access", len(accesses))
+	// Each access writes only its own position, that is read after the batch finishes
+	revoked := make([]bool, len(accesses))
+	var failed atomic.Int32
+	batch := config.GetExecutor().NewBatch("revoke expired break-glass access")
+	for idx, access := range accesses {
+		batch.Go(access.DatabaseInstanceID, func(time.Duration) {
+			if err := uc.revokeAccess(access, instancesByID[access.DatabaseInstanceID], systemUser.ID.String()); err != nil {
+				log.Printf("Error revoking break-glass access '%s' of user '%s'. Cause: %v", access.ID, access.DatabaseUsername, err)
+				failed.Add(1)
+				return
+			}
+			revoked[idx] = true
+		})
+	}
+	batch.Wait()
+
+	uc.notifyRevoked(accesses, revoked, instancesByID)
+	if failed.Load() > 0 {
+		return fmt.Errorf("%w: %d of %d failed", ErrCouldNotRevokeAllExpiredBreakGlassAccess, failed.Load(), len(accesses))
+	}
+	return nil
+}
+
+func (uc *RevokeExpiredBreakGlassAccessUseCase) revokeAccess(access *dto.BreakGlassAccessOutputDTO, instance *dto.DatabaseInstanceOutputDTO, systemUserID string) error {
+	if instance == nil {
+		return fmt.Errorf("instance '%s' not found", access.DatabaseInstanceID)
+	}
+	accessFailed := func(msg string, err error) error {
+		saveBreakGlassLog(uc.AccessPermissionStorage, instance.ID, access.DatabaseUserID, access.DatabaseID, systemUserID, msg, false)
+		return err
+	}
+	breakGlassRole := string(entity.BreakGlassRole)
+	if access.RoleGranted {
+		targetDatabase, err := connector.NewDatabaseConnector(instance, access.DatabaseName)
+		if err != nil {
+			return accessFailed(fmt.Sprintf(ErrCreatingConnectorMsg, instance.Name, err.Error()), err)
+		}
+		userExists, err := targetDatabase.UserExists(access.DatabaseUsername)
+		if err != nil {
+			return accessFailed(fmt.Sprintf(ErrConnectionFailedMsg, instance.Name, err.Error()), err)
+		}
+		if userExists {
+			if err := targetDatabase.RevokeRole(access.DatabaseUsername, breakGlassRole); err != nil {
+				msg := fmt.Sprintf(ErrRevokeBreakGlassRoleFailedMsg, breakGlassRole, access.DatabaseUsername, access.DatabaseName, instance.Name, err.Error())
+				return accessFailed(msg, err)
+			}
+		}
+	}
+	if err := uc.BreakGlassAccessStorage.UpdateRevokedAt(access.ID, time.Now()); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf(BreakGlassRevokedMsg, access.DatabaseUsername, access.DatabaseName, instance.Name, access.IncidentReference)
+	log.Print(msg)
+	saveBreakGlassLog(uc.AccessPermissionStorage, instance.ID, access.DatabaseUserID, access.DatabaseID, systemUserID, msg, true)
+	return nil
+}
+
+func (uc *RevokeExpiredBreakGlassAccessUseCase) notifyRevoked(accesses []*dto.BreakGlassAccessOutputDTO, revoked []bool, instancesByID map[string]*dto.DatabaseInstanceOutputDTO) {
+	var revokedAccesses []string
+	for idx, access := range accesses {
+		if revoked[idx] {
+			revokedAccesses = append(revokedAccesses, fmt.Sprintf("%s # %s # %s (%s)",
+				access.DatabaseUsername, instancesByID[access.DatabaseInstanceID].Name, access.DatabaseName, access.IncidentReference))
+		}
+	}
+	if len(revokedAccesses) == 0 {
+		return
+	}
+	err := uc.Notifier.Notify(notifier.Notification{
+		Event:   NotificationBreakGlassRevoked,
+		Subject: "Break-glass access revoked",
+		Message: fmt.Sprintf("The window of %d break-glass access closed and the role %s was revoked", len(revokedAccesses), entity.BreakGlassRole),
+		Fields:  map[string]string{"accesses": strings.Join(revokedAccesses, ", ")},
+		Time:    time.Now(),
+	})
+	if err != nil {
+		log.Printf("ERROR: could not notify the break-glass access revoked. Cause: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package accesspermission
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDbWhenFetchingExpiredBreakGlass_WhenExecuteRevokeExpiredBreakGlass_ThenShouldReturnError(t *testing.T) {
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.BreakGlassAccessOutputDTO{}, sql.ErrConnDone).Once()
+
+	uc := NewRevokeExpiredBreakGlassAccessUseCase(nil, breakGlassStorage, nil, nil, nil)
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestGivenNoExpiredBreakGlass_WhenExecuteRevokeExpiredBreakGlass_ThenShouldNotRevokeAnything(t *testing.T) {
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.BreakGlassAccessOutputDTO{}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	notifierMock := new(mocks.NotifierMock)
+
+	uc := NewRevokeExpiredBreakGlassAccessUseCase(nil, breakGlassStorage, nil, userStorage, notifierMock)
+	err := uc.Execute()
+
+	assert.NoError(t, err)
+	userStorage.AssertNotCalled(t, "FindByEmail", mock.Anything)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
+func TestGivenExpiredBreakGlass_WhenExecuteRevokeExpiredBreakGlass_ThenShouldRevokeTheRoleAsTheSystemUserAndNotify(t *testing.T) {
+	access := mocks.BuildExpiredBreakGlassAccessDTO()
+	instance := mocks.BuildAzInstanceDTO()
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.BreakGlassAccessOutputDTO{access}, nil).Once()
+	breakGlassStorage.On("UpdateRevokedAt", access.ID, mock.Anything).Return(nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByEmail", entity.SystemUserEmail).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool {
+		return l.Type == entity.AccessPermissionLogTypeBreakGlass && l.Success && l.UserID == mocks.UserID
+	})).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+	notifierMock.On("Notify", mock.MatchedBy(func(n notifier.Notification) bool {
+		return n.Event == NotificationBreakGlassRevoked
+	})).Return(nil).Once()
+
+	uc := NewRevokeExpiredBreakGlassAccessUseCase(accessPermissionStorage, breakGlassStorage, dbInstanceStorage, userStorage, notifierMock)
+	err := uc.Execute()
+
+	assert.NoError(t, err)
+	breakGlassStorage.AssertExpectations(t)
+	accessPermissionStorage.AssertExpectations(t)
+	notifierMock.AssertExpectations(t)
+}
+
+func TestGivenAnErrorRevokingTheRole_WhenExecuteRevokeExpiredBreakGlass_ThenShouldKeepTheAccessOpenAndReturnError(t *testing.T) {
+	access := mocks.BuildExpiredBreakGlassAccessDTO()
+	instance := mocks.BuildDummyErrorInstance()
+	access.DatabaseInstanceID = instance.ID
+	breakGlassStorage := new(mocks.BreakGlassAccessStorageMock)
+	breakGlassStorage.On("FindAllExpiredDTOs", mock.Anything).Return([]*dto.BreakGlassAccessOutputDTO{access}, nil).Once()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByEmail", entity.SystemUserEmail).Return(buildSystemUser(), nil).Once()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("SaveLog", mock.MatchedBy(func(l *entity.AccessPermissionLog) bool {
+		return l.Type == entity.AccessPermissionLogTypeBreakGlass && !l.Success
+	})).Return(nil).Once()
+	notifierMock := new(mocks.NotifierMock)
+
+	uc := NewRevokeExpiredBreakGlassAccessUseCase(accessPermissionStorage, breakGlassStorage, dbInstanceStorage, userStorage, notifierMock)
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, ErrCouldNotRevokeAllExpiredBreakGlassAccess)
+	breakGlassStorage.AssertNotCalled(t, "UpdateRevokedAt", mock.Anything, mock.Anything)
+	notifierMock.AssertNotCalled(t, "Notify", mock.Anything)
+}
@@ -0,0 +1,43 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+var ErrAccessRequestNotFound = errors.New("access request not found")
+
+func findAccessRequest(accessRequestStorage storage.AccessRequestStorage, accessRequestID string) (*entity.AccessRequest, error) {
+	accessRequest, err := accessRequestStorage.FindByID(accessRequestID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Access request with id %s not found in database!", accessRequestID)
+		return nil, ErrAccessRequestNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching access request with id %s. Cause: %v", accessRequestID, err)
+		return nil, err
+	}
+	return accessRequest, nil
+}
+
+// saveReview godoc
+// Persists the review of a pending request, failing when it was reviewed by another user in the meantime. A request found
+// expired on review is persisted as expired and the review error is returned.
+func saveReview(accessRequestStorage storage.AccessRequestStorage, accessRequest *entity.AccessRequest, errReviewing error) error {
+	if errReviewing != nil && !errors.Is(errReviewing, entity.ErrAccessRequestExpired) {
+		return errReviewing
+	}
+	updated, err := accessRequestStorage.Update(accessRequest, entity.AccessRequestStatusRequested)
+	if err != nil {
+		return fmt.Errorf("error saving the review of access request %s. Cause: %w", accessRequest.ID, err)
+	}
+	if !updated {
+		return entity.ErrAccessRequestNotPending
+	}
+	return errReviewing
+}
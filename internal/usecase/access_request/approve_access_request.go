@@ -0,0 +1,74 @@
+package accessrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type ApproveAccessRequestUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+	GrantAccessUseCase   common.GrantAccessPermissionUseCaseInterface
+}
+
+func NewApproveAccessRequestUseCase(
+	accessRequestStorage storage.AccessRequestStorage,
+	grantAccessUseCase common.GrantAccessPermissionUseCaseInterface,
+) *ApproveAccessRequestUseCase {
+	return &ApproveAccessRequestUseCase{
+		AccessRequestStorage: accessRequestStorage,
+		GrantAccessUseCase:   grantAccessUseCase,
+	}
+}
+
+// Execute godoc
+/** Approves a pending access request and grants the access requested right away, with the approver as operator.
+The approver must be a user other than the requester. The request ends as executed with the output of the grant, that
+may hold errors of some instances like a direct grant, or as failed when the grant could not run at all. */
+func (uc *ApproveAccessRequestUseCase) Execute(accessRequestID string, input dto.ReviewAccessRequestInputDTO, operationUserID string) (*dto.AccessRequestOutputDTO, error) {
+	accessRequest, err := findAccessRequest(uc.AccessRequestStorage, accessRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err = saveReview(uc.AccessRequestStorage, accessRequest, accessRequest.Approve(operationUserID, input.Comment)); err != nil {
+		log.Printf("Error approving access request %s. Cause: %v", accessRequestID, err)
+		return nil, err
+	}
+	log.Printf("Access request %s approved by user %s, granting the access requested", accessRequestID, operationUserID)
+
+	uc.grantAccess(accessRequest, operationUserID)
+	updated, err := uc.AccessRequestStorage.Update(accessRequest, entity.AccessRequestStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("error saving the execution of access request %s. Cause: %w", accessRequestID, err)
+	}
+	if !updated {
+		return nil, entity.ErrAccessRequestNotApproved
+	}
+	log.Printf("Access request %s finished as %s", accessRequestID, accessRequest.Status)
+	return uc.AccessRequestStorage.FindDTOByID(accessRequestID)
+}
+
+func (uc *ApproveAccessRequestUseCase) grantAccess(accessRequest *entity.AccessRequest, operationUserID string) {
+	var grantInput dto.GrantAccessInputDTO
+	if err := json.Unmarshal([]byte(accessRequest.Input), &grantInput); err != nil {
+		_ = accessRequest.Fail(fmt.Errorf("error decoding the input of the access request. Cause: %w", err))
+		return
+	}
+	output, err := uc.GrantAccessUseCase.ExecuteApproved(grantInput, operationUserID)
+	if err != nil {
+		log.Printf("Error granting the access of access request %s. Cause: %v", accessRequest.ID, err)
+		_ = accessRequest.Fail(err)
+		return
+	}
+	result, err := json.Marshal(output)
+	if err != nil {
+		_ = accessRequest.Fail(fmt.Errorf("error encoding the result of the access request. Cause: %w", err))
+		return
+	}
+	_ = accessRequest.Execute(string(result))
+}
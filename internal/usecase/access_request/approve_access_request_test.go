@@ -0,0 +1,95 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenANonexistentAccessRequest_WhenExecuteApprove_ThenShouldReturnNotFound(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(&entity.AccessRequest{}, sql.ErrNoRows).Once()
+	uc := NewApproveAccessRequestUseCase(accessRequestStorage, nil)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{}, mocks.ReviewerUserID)
+
+	assert.ErrorIs(t, err, ErrAccessRequestNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenTheRequester_WhenExecuteApprove_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequest(), nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	uc := NewApproveAccessRequestUseCase(accessRequestStorage, grantUC)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{}, mocks.UserID)
+
+	assert.ErrorIs(t, err, entity.ErrAccessRequestReviewedByRequester)
+	assert.Nil(t, output)
+	accessRequestStorage.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	grantUC.AssertNotCalled(t, "ExecuteApproved", mock.Anything, mock.Anything)
+}
+
+func TestGivenAnAccessRequestReviewedInTheMeantime_WhenExecuteApprove_ThenShouldReturnErrorWithoutGranting(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequest(), nil).Once()
+	accessRequestStorage.On("Update", mock.Anything, entity.AccessRequestStatusRequested).Return(false, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	uc := NewApproveAccessRequestUseCase(accessRequestStorage, grantUC)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{}, mocks.ReviewerUserID)
+
+	assert.ErrorIs(t, err, entity.ErrAccessRequestNotPending)
+	assert.Nil(t, output)
+	grantUC.AssertNotCalled(t, "ExecuteApproved", mock.Anything, mock.Anything)
+}
+
+func TestGivenAPendingAccessRequest_WhenExecuteApprove_ThenShouldGrantTheAccessWithTheApproverAsOperator(t *testing.T) {
+	accessRequest := mocks.BuildAccessRequest()
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(accessRequest, nil).Once()
+	accessRequestStorage.On("Update", accessRequest, entity.AccessRequestStatusRequested).Return(true, nil).Once()
+	accessRequestStorage.On("Update", accessRequest, entity.AccessRequestStatusApproved).Return(true, nil).Once()
+	accessRequestStorage.On("FindDTOByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequestDTO(entity.AccessRequestStatusExecuted), nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	grantUC.On("ExecuteApproved", mocks.BuildAccessRequestGrantInput(), mocks.ReviewerUserID).
+		Return(&dto.GrantAccessOutputDTO{Message: "Access permissions created successfully."}, nil).Once()
+	uc := NewApproveAccessRequestUseCase(accessRequestStorage, grantUC)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{Comment: "ok"}, mocks.ReviewerUserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.AccessRequestStatusExecuted), output.Status)
+	assert.Equal(t, entity.AccessRequestStatusExecuted, accessRequest.Status)
+	assert.Equal(t, mocks.ReviewerUserID, accessRequest.ReviewedByUserID.String)
+	assert.Equal(t, "ok", accessRequest.ReviewComment)
+	assert.Contains(t, accessRequest.Result.String, "Access permissions created successfully.")
+	grantUC.AssertNumberOfCalls(t, "ExecuteApproved", 1)
+	accessRequestStorage.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestGivenAnErrorGrantingTheAccess_WhenExecuteApprove_ThenShouldKeepTheRequestAsFailed(t *testing.T) {
+	accessRequest := mocks.BuildAccessRequest()
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(accessRequest, nil).Once()
+	accessRequestStorage.On("Update", accessRequest, mock.Anything).Return(true, nil).Twice()
+	accessRequestStorage.On("FindDTOByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequestDTO(entity.AccessRequestStatusFailed), nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	grantUC.On("ExecuteApproved", mock.Anything, mocks.ReviewerUserID).Return((*dto.GrantAccessOutputDTO)(nil), errors.New("connection refused")).Once()
+	uc := NewApproveAccessRequestUseCase(accessRequestStorage, grantUC)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{}, mocks.ReviewerUserID)
+
+	assert.NoError(t, err, "the request was approved, the error of the grant is kept in it")
+	assert.Equal(t, string(entity.AccessRequestStatusFailed), output.Status)
+	assert.Equal(t, entity.AccessRequestStatusFailed, accessRequest.Status)
+	assert.Equal(t, "connection refused", accessRequest.Error)
+}
@@ -0,0 +1,60 @@
+package accessrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type CreateAccessRequestUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+	TTL                  time.Duration
+}
+
+func NewCreateAccessRequestUseCase(accessRequestStorage storage.AccessRequestStorage, ttl time.Duration) *CreateAccessRequestUseCase {
+	return &CreateAccessRequestUseCase{
+		AccessRequestStorage: accessRequestStorage,
+		TTL:                  ttl,
+	}
+}
+
+// Execute godoc
+/** Registers a request of access waiting for the review of another application user.
+The request expires when not reviewed within the TTL of the use case, or earlier when the access requested expires
+before that, since there would be nothing left to grant. */
+func (uc *CreateAccessRequestUseCase) Execute(input dto.AccessRequestInputDTO, requestedByUserID string) (*dto.AccessRequestOutputDTO, error) {
+	inputJSON, err := json.Marshal(input.GrantAccessInputDTO)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the input of the access request. Cause: %w", err)
+	}
+	expiresAt := time.Now().Add(uc.TTL)
+	if input.ExpiresAt != nil && input.ExpiresAt.Before(expiresAt) {
+		expiresAt = *input.ExpiresAt
+	}
+	accessRequest, err := entity.NewAccessRequest(string(inputJSON), input.Justification, requestedByUserID, expiresAt)
+	if err != nil {
+		log.Printf("Error creating access request. Cause: %v", err)
+		return nil, err
+	}
+	if err = uc.AccessRequestStorage.Save(accessRequest); err != nil {
+		log.Printf("Error saving access request. Cause: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Access request %s created by user %s, waiting for review until %s", accessRequest.ID, requestedByUserID, expiresAt.Format(time.RFC3339))
+	return &dto.AccessRequestOutputDTO{
+		ID:                accessRequest.ID.String(),
+		Status:            string(accessRequest.Status),
+		Input:             inputJSON,
+		Justification:     accessRequest.Justification,
+		RequestedByUserID: accessRequest.RequestedByUserID,
+		CreatedAt:         accessRequest.CreatedAt,
+		UpdatedAt:         accessRequest.UpdatedAt,
+		ExpiresAt:         accessRequest.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const justification = "On-call investigation of incident INC-42"
+
+func TestGivenAnErrorInDb_WhenExecuteCreateAccessRequest_ThenShouldReturnError(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("Save", mock.Anything).Return(sql.ErrConnDone).Once()
+	uc := NewCreateAccessRequestUseCase(accessRequestStorage, time.Hour)
+
+	output, err := uc.Execute(dto.AccessRequestInputDTO{GrantAccessInputDTO: mocks.BuildAccessRequestGrantInput(), Justification: justification}, mocks.UserID)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, output)
+}
+
+func TestGivenAValidInput_WhenExecuteCreateAccessRequest_ThenShouldSaveARequestExpiringAfterTheTTL(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("Save", mock.MatchedBy(func(r *entity.AccessRequest) bool {
+		return r.Status == entity.AccessRequestStatusRequested && r.RequestedByUserID == mocks.UserID && r.Justification == justification
+	})).Return(nil).Once()
+	uc := NewCreateAccessRequestUseCase(accessRequestStorage, 2*time.Hour)
+
+	output, err := uc.Execute(dto.AccessRequestInputDTO{GrantAccessInputDTO: mocks.BuildAccessRequestGrantInput(), Justification: justification}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.AccessRequestStatusRequested), output.Status)
+	assert.JSONEq(t, `{"databaseUsersIds":["`+mocks.DbUserID+`"],"instancesData":[{"databaseInstanceId":"`+mocks.QAInstanceId+`","databasesIds":null}]}`, string(output.Input))
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), output.ExpiresAt, time.Minute)
+	accessRequestStorage.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestGivenAnAccessExpiringBeforeTheTTL_WhenExecuteCreateAccessRequest_ThenShouldExpireTheRequestWithTheAccess(t *testing.T) {
+	accessExpiresAt := time.Now().Add(4 * time.Hour)
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("Save", mock.Anything).Return(nil).Once()
+	uc := NewCreateAccessRequestUseCase(accessRequestStorage, 24*time.Hour)
+	grantInput := mocks.BuildAccessRequestGrantInput()
+	grantInput.ExpiresAt = &accessExpiresAt
+
+	output, err := uc.Execute(dto.AccessRequestInputDTO{GrantAccessInputDTO: grantInput, Justification: justification}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, accessExpiresAt, output.ExpiresAt)
+}
@@ -0,0 +1,31 @@
+package accessrequest
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+)
+
+type ExpireAccessRequestsUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+}
+
+func NewExpireAccessRequestsUseCase(accessRequestStorage storage.AccessRequestStorage) *ExpireAccessRequestsUseCase {
+	return &ExpireAccessRequestsUseCase{AccessRequestStorage: accessRequestStorage}
+}
+
+// Execute godoc
+// Marks as expired the access requests not reviewed in time. A request is also found expired when reviewed, so this only
+// keeps the status of the requests up to date for the queries.
+func (uc *ExpireAccessRequestsUseCase) Execute() error {
+	expiredQty, err := uc.AccessRequestStorage.ExpireAllPending(time.Now())
+	if err != nil {
+		return fmt.Errorf("error expiring access requests. Cause: %w", err)
+	}
+	if expiredQty > 0 {
+		log.Printf("%d access requests expired without review", expiredQty)
+	}
+	return nil
+}
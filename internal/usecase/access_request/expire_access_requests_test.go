@@ -0,0 +1,32 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDb_WhenExecuteExpireAccessRequests_ThenShouldReturnError(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("ExpireAllPending", mock.Anything).Return(int64(0), sql.ErrConnDone).Once()
+	uc := NewExpireAccessRequestsUseCase(accessRequestStorage)
+
+	err := uc.Execute()
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestGivenPendingAccessRequestsExpired_WhenExecuteExpireAccessRequests_ThenShouldExpireThem(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("ExpireAllPending", mock.Anything).Return(int64(3), nil).Once()
+	uc := NewExpireAccessRequestsUseCase(accessRequestStorage)
+
+	err := uc.Execute()
+
+	assert.NoError(t, err)
+	accessRequestStorage.AssertNumberOfCalls(t, "ExpireAllPending", 1)
+}
@@ -0,0 +1,32 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type GetAccessRequestUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+}
+
+func NewGetAccessRequestUseCase(accessRequestStorage storage.AccessRequestStorage) *GetAccessRequestUseCase {
+	return &GetAccessRequestUseCase{AccessRequestStorage: accessRequestStorage}
+}
+
+func (uc *GetAccessRequestUseCase) Execute(accessRequestID string) (*dto.AccessRequestOutputDTO, error) {
+	accessRequestDTO, err := uc.AccessRequestStorage.FindDTOByID(accessRequestID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching access request with id %s. Cause: %v", accessRequestID, ErrAccessRequestNotFound)
+		return nil, ErrAccessRequestNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching access request with id %s. Cause: %v", accessRequestID, err)
+		return nil, err
+	}
+	log.Printf("Access request with id %s loaded successfully!", accessRequestID)
+	return accessRequestDTO, nil
+}
@@ -0,0 +1,34 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenANonexistentAccessRequest_WhenExecuteGet_ThenShouldReturnNotFound(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindDTOByID", mocks.AccessRequestID).Return((*dto.AccessRequestOutputDTO)(nil), sql.ErrNoRows).Once()
+	uc := NewGetAccessRequestUseCase(accessRequestStorage)
+
+	output, err := uc.Execute(mocks.AccessRequestID)
+
+	assert.ErrorIs(t, err, ErrAccessRequestNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnAccessRequest_WhenExecuteGet_ThenShouldReturnIt(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindDTOByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequestDTO(entity.AccessRequestStatusRequested), nil).Once()
+	uc := NewGetAccessRequestUseCase(accessRequestStorage)
+
+	output, err := uc.Execute(mocks.AccessRequestID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mocks.AccessRequestID, output.ID)
+}
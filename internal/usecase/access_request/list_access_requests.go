@@ -0,0 +1,30 @@
+package accessrequest
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListAccessRequestsUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+}
+
+func NewListAccessRequestsUseCase(accessRequestStorage storage.AccessRequestStorage) *ListAccessRequestsUseCase {
+	return &ListAccessRequestsUseCase{AccessRequestStorage: accessRequestStorage}
+}
+
+func (uc *ListAccessRequestsUseCase) Execute(status, requestedByUserID string, page, limit int) ([]*dto.AccessRequestOutputDTO, int, error) {
+	accessRequestDTOs, err := uc.AccessRequestStorage.FindAllDTOs(status, requestedByUserID, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching access requests! Cause: %w", err)
+	}
+	totalCount, err := uc.AccessRequestStorage.Count(status, requestedByUserID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching access requests count! Cause: %w", err)
+	}
+	log.Printf("List of access requests loaded successfully!")
+	return accessRequestDTOs, totalCount, nil
+}
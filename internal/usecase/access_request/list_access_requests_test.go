@@ -0,0 +1,39 @@
+package accessrequest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDb_WhenExecuteListAccessRequests_ThenShouldReturnError(t *testing.T) {
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindAllDTOs", "", "", 1, 10).Return([]*dto.AccessRequestOutputDTO{}, sql.ErrConnDone).Once()
+	uc := NewListAccessRequestsUseCase(accessRequestStorage)
+
+	accessRequests, total, err := uc.Execute("", "", 1, 10)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, accessRequests)
+	assert.Zero(t, total)
+}
+
+func TestGivenFilters_WhenExecuteListAccessRequests_ThenShouldReturnTheRequestsAndTheTotal(t *testing.T) {
+	status := string(entity.AccessRequestStatusRequested)
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindAllDTOs", status, mocks.UserID, 1, 10).
+		Return([]*dto.AccessRequestOutputDTO{mocks.BuildAccessRequestDTO(entity.AccessRequestStatusRequested)}, nil).Once()
+	accessRequestStorage.On("Count", status, mocks.UserID).Return(11, nil).Once()
+	uc := NewListAccessRequestsUseCase(accessRequestStorage)
+
+	accessRequests, total, err := uc.Execute(status, mocks.UserID, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, accessRequests, 1)
+	assert.Equal(t, 11, total)
+}
@@ -0,0 +1,31 @@
+package accessrequest
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type RejectAccessRequestUseCase struct {
+	AccessRequestStorage storage.AccessRequestStorage
+}
+
+func NewRejectAccessRequestUseCase(accessRequestStorage storage.AccessRequestStorage) *RejectAccessRequestUseCase {
+	return &RejectAccessRequestUseCase{AccessRequestStorage: accessRequestStorage}
+}
+
+// Execute godoc
+// Rejects a pending access request, that must be reviewed by a user other than the requester. Nothing is granted.
+func (uc *RejectAccessRequestUseCase) Execute(accessRequestID string, input dto.ReviewAccessRequestInputDTO, operationUserID string) (*dto.AccessRequestOutputDTO, error) {
+	accessRequest, err := findAccessRequest(uc.AccessRequestStorage, accessRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if err = saveReview(uc.AccessRequestStorage, accessRequest, accessRequest.Reject(operationUserID, input.Comment)); err != nil {
+		log.Printf("Error rejecting access request %s. Cause: %v", accessRequestID, err)
+		return nil, err
+	}
+	log.Printf("Access request %s rejected by user %s", accessRequestID, operationUserID)
+	return uc.AccessRequestStorage.FindDTOByID(accessRequestID)
+}
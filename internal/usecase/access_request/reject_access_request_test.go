@@ -0,0 +1,44 @@
+package accessrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAPendingAccessRequest_WhenExecuteReject_ThenShouldKeepTheReview(t *testing.T) {
+	accessRequest := mocks.BuildAccessRequest()
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(accessRequest, nil).Once()
+	accessRequestStorage.On("Update", accessRequest, entity.AccessRequestStatusRequested).Return(true, nil).Once()
+	accessRequestStorage.On("FindDTOByID", mocks.AccessRequestID).Return(mocks.BuildAccessRequestDTO(entity.AccessRequestStatusRejected), nil).Once()
+	uc := NewRejectAccessRequestUseCase(accessRequestStorage)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{Comment: "use the replica"}, mocks.ReviewerUserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.AccessRequestStatusRejected), output.Status)
+	assert.Equal(t, entity.AccessRequestStatusRejected, accessRequest.Status)
+	assert.Equal(t, "use the replica", accessRequest.ReviewComment)
+}
+
+func TestGivenAnExpiredAccessRequest_WhenExecuteReject_ThenShouldSaveItAsExpiredAndReturnError(t *testing.T) {
+	accessRequest := mocks.BuildAccessRequest()
+	accessRequest.ExpiresAt = time.Now().Add(-time.Minute)
+	accessRequestStorage := new(mocks.AccessRequestStorageMock)
+	accessRequestStorage.On("FindByID", mocks.AccessRequestID).Return(accessRequest, nil).Once()
+	accessRequestStorage.On("Update", accessRequest, entity.AccessRequestStatusRequested).Return(true, nil).Once()
+	uc := NewRejectAccessRequestUseCase(accessRequestStorage)
+
+	output, err := uc.Execute(mocks.AccessRequestID, dto.ReviewAccessRequestInputDTO{}, mocks.ReviewerUserID)
+
+	assert.ErrorIs(t, err, entity.ErrAccessRequestExpired)
+	assert.Nil(t, output)
+	assert.Equal(t, entity.AccessRequestStatusExpired, accessRequest.Status)
+	accessRequestStorage.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+// NewConnector godoc
+// Creates the connector of the database of the instance. With a recorder, which is only given in dry run, the
+// statements that would change the instance are recorded in it instead of executed.
+func NewConnector(instance *dto.DatabaseInstanceOutputDTO, databaseName string, recorder *connector.StatementRecorder) (connector.DatabaseTCPConnectorInterface, error) {
+	if recorder != nil {
+		return connector.NewDryRunDatabaseConnector(instance, databaseName, recorder)
+	}
+	return connector.NewDatabaseConnector(instance, databaseName)
+}
+
+// NewStatementRecorders godoc
+// Creates a recorder for each instance in dry run, by instance id. It's nil otherwise, so no recorder is ever found.
+func NewStatementRecorders(dryRun bool, instances []*dto.DatabaseInstanceOutputDTO) map[string]*connector.StatementRecorder {
+	if !dryRun {
+		return nil
+	}
+	recorders := make(map[string]*connector.StatementRecorder, len(instances))
+	for _, instance := range instances {
+		recorders[instance.ID] = connector.NewStatementRecorder()
+	}
+	return recorders
+}
+
+// BuildDryRunPlan godoc
+// Returns the statements recorded for each instance, in the order of the instances, skipping the ones without any
+func BuildDryRunPlan(instances []*dto.DatabaseInstanceOutputDTO, recorders map[string]*connector.StatementRecorder) []dto.DryRunInstanceOutputDTO {
+	plan := make([]dto.DryRunInstanceOutputDTO, 0, len(instances))
+	for _, instance := range instances {
+		recorder, found := recorders[instance.ID]
+		if !found {
+			continue
+		}
+		if databases := recorder.Plan(); len(databases) > 0 {
+			plan = append(plan, dto.DryRunInstanceOutputDTO{DatabaseInstanceID: instance.ID, Instance: instance.Name, Databases: databases})
+		}
+	}
+	return plan
+}
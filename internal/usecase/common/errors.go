@@ -9,6 +9,7 @@ var (
 	ErrTechnologyNotFound         = errors.New("technology not found")
 	ErrEcosystemNotFound          = errors.New("ecosystem not found")
 	ErrDatabaseUserNotFound       = errors.New("database user not found")
+	ErrDatabaseRoleNotFound       = errors.New("database role not found")
 	ErrNoAccessibleInstancesFound = errors.New("no accessible instances (clusters) found for the user with the provided IDs")
 )
 
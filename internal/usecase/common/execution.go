@@ -0,0 +1,18 @@
+package common
+
+import (
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/pkg/executor"
+)
+
+func BuildExecutionOutput(stats executor.Stats) *dto.ExecutionOutputDTO {
+	return &dto.ExecutionOutputDTO{
+		Tasks:                     stats.Tasks,
+		QueuedTasks:               stats.QueuedTasks,
+		MaxQueueTimeMs:            stats.MaxQueueTime.Milliseconds(),
+		TotalQueueTimeMs:          stats.TotalQueueTime.Milliseconds(),
+		MaxConcurrency:            stats.MaxConcurrency,
+		MaxConcurrencyPerInstance: stats.MaxConcurrencyPerKey,
+		Saturated:                 stats.QueuedTasks > 0,
+	}
+}
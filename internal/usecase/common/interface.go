@@ -6,4 +6,60 @@ import (
 
 type RevokeAccessPermissionUseCaseInterface interface {
 	Execute(input dto.RevokeAccessInputDTO, operationUserID string) (*dto.RevokeAccessOutputDTO, error)
+	RevokeDatabases(databaseUserID, instanceID string, databasesIDs []string, operationUserID string) error
+}
+
+type GrantAccessPermissionUseCaseInterface interface {
+	Execute(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error)
+	ExecuteApproved(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error)
+}
+
+type CreateEcosystemUseCaseInterface interface {
+	Execute(input dto.EcosystemInputDTO, createdByUserID string) (*dto.EcosystemOutputDTO, error)
+}
+
+type UpdateEcosystemUseCaseInterface interface {
+	Execute(input dto.EcosystemInputDTO, ecosystemID, operationUserID string) (*dto.EcosystemOutputDTO, error)
+}
+
+type DeleteEcosystemUseCaseInterface interface {
+	Execute(ecosystemID string, operationUserID string) error
+}
+
+type CreateDatabaseInstanceUseCaseInterface interface {
+	Execute(input dto.DatabaseInstanceInputDTO, createdByUserID string) (*dto.DatabaseInstanceOutputDTO, error)
+}
+
+type UpdateDatabaseInstanceUseCaseInterface interface {
+	Execute(input dto.DatabaseInstanceInputDTO, dbInstanceID, operationUserID string) (*dto.DatabaseInstanceOutputDTO, error)
+}
+
+type CreateDatabaseUserUseCaseInterface interface {
+	Execute(input dto.DatabaseUserInputDTO, createdByUserID string) (*dto.DatabaseUserOutputDTO, error)
+}
+
+type UpdateDatabaseUserUseCaseInterface interface {
+	Execute(input dto.UpdateDatabaseUserInputDTO, dbUserID, operationUserID string) (*dto.DatabaseUserOutputDTO, error)
+}
+
+// ChangeStatusUseCaseInterface godoc
+// Enables or disables a database instance or a database user
+type ChangeStatusUseCaseInterface interface {
+	Execute(id string, enabled bool, operationUserID string) (*dto.ChangeStatusOutputDTO, error)
+}
+
+type CreateAccessGroupUseCaseInterface interface {
+	Execute(input dto.AccessGroupInputDTO, operationUserID string) (*dto.AccessGroupOutputDTO, error)
+}
+
+type UpdateAccessGroupTargetsUseCaseInterface interface {
+	Execute(groupID string, input dto.AccessGroupTargetsInputDTO, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error)
+}
+
+type AddAccessGroupMembersUseCaseInterface interface {
+	Execute(groupID string, input dto.AccessGroupMembersInputDTO, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error)
+}
+
+type RemoveAccessGroupMemberUseCaseInterface interface {
+	Execute(groupID, databaseUserID, operationUserID string) (*dto.AccessGroupChangeOutputDTO, error)
 }
@@ -0,0 +1,28 @@
+package common
+
+import (
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+// ProgressReporter godoc
+// Follows the progress of an operation item by item, e.g. to keep the progress of a job processed in background or to
+// stream it to the client. AddItems is called as soon as the operation knows how many items it will process,
+// ItemProcessed once per item and Event for each message of the operation about one of its contexts.
+// All of them are called concurrently by the tasks of the operation, so they must not block.
+type ProgressReporter interface {
+	AddItems(qty int)
+	ItemProcessed(result dto.ItemResultDTO)
+	Event(event dto.ProgressEventDTO)
+}
+
+type noProgress struct{}
+
+func (noProgress) AddItems(int) {}
+
+func (noProgress) ItemProcessed(dto.ItemResultDTO) {}
+
+func (noProgress) Event(dto.ProgressEventDTO) {}
+
+// NoProgress godoc
+// Reporter of the operations whose progress is not followed, e.g. the ones answered synchronously
+var NoProgress ProgressReporter = noProgress{}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListOutdatedRolesDatabasesUseCase struct {
+	DatabaseStorage     storage.DatabaseStorage
+	DatabaseRoleStorage storage.DatabaseRoleStorage
+}
+
+func NewListOutdatedRolesDatabasesUseCase(databaseStorage storage.DatabaseStorage, roleStorage storage.DatabaseRoleStorage) *ListOutdatedRolesDatabasesUseCase {
+	return &ListOutdatedRolesDatabasesUseCase{DatabaseStorage: databaseStorage, DatabaseRoleStorage: roleStorage}
+}
+
+// Execute godoc
+// Lists the enabled databases whose roles weren't set up with the grants of the current roles template, i.e. never set
+// up or set up before a change in the roles or in the connectors. Setting up roles with OnlyOutdated re-applies them.
+func (uc *ListOutdatedRolesDatabasesUseCase) Execute(ecosystemID, databaseInstanceID string) (*dto.OutdatedRolesDatabasesOutputDTO, error) {
+	dataGuardRoles, err := uc.DatabaseRoleStorage.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching database roles. Cause: %w", err)
+	}
+	template := connector.NewRolesTemplate(connector.NewDatabaseRoles(dataGuardRoles))
+
+	databaseDTOs, err := uc.DatabaseStorage.FindAllDTOs(ecosystemID, databaseInstanceID)
+	if err != nil {
+		log.Printf("Error fetching databases! Cause: %v", err.Error())
+		return nil, err
+	}
+
+	output := &dto.OutdatedRolesDatabasesOutputDTO{
+		RolesTemplateVersion:  template.Version,
+		RolesTemplateChecksum: template.Checksum,
+		Databases:             make([]*dto.DatabaseOutputDTO, 0),
+	}
+	for _, databaseDTO := range databaseDTOs {
+		if !databaseDTO.Enabled {
+			continue
+		}
+		if !databaseDTO.RolesConfigured || databaseDTO.RolesTemplateChecksum != template.Checksum {
+			output.Databases = append(output.Databases, databaseDTO)
+		}
+	}
+	log.Printf("%d databases with roles outdated from the template version %d (%s)", len(output.Databases), template.Version, template.Checksum)
+	return output, nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDbWhileFetchingRoles_WhenExecuteListOutdatedRolesDatabases_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return([]*entity.DatabaseRole{}, sql.ErrConnDone).Once()
+
+	uc := NewListOutdatedRolesDatabasesUseCase(nil, roleStorage)
+	output, err := uc.Execute(mocks.EcosystemId, mocks.DatabaseInstanceId)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnErrorInDbWhileFetchingDatabases_WhenExecuteListOutdatedRolesDatabases_ThenShouldReturnError(t *testing.T) {
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAllDTOs", mocks.EcosystemId, mocks.DatabaseInstanceId).Return([]*dto.DatabaseOutputDTO{}, sql.ErrConnDone).Once()
+
+	uc := NewListOutdatedRolesDatabasesUseCase(databaseStorage, buildRoleStorage())
+	output, err := uc.Execute(mocks.EcosystemId, mocks.DatabaseInstanceId)
+
+	assert.EqualError(t, err, sql.ErrConnDone.Error())
+	assert.Nil(t, output)
+}
+
+func TestGivenSomeDbs_WhenExecuteListOutdatedRolesDatabases_ThenShouldListOnlyEnabledOutdatedOnes(t *testing.T) {
+	template := connector.NewRolesTemplate(connector.NewDatabaseRoles(mocks.BuildRolesList()))
+	databaseDtos := mocks.BuildDatabaseDTOList()
+	for _, databaseDto := range databaseDtos {
+		databaseDto.Enabled = true
+		databaseDto.RolesConfigured = true
+	}
+	databaseDtos[0].RolesTemplateVersion = template.Version
+	databaseDtos[0].RolesTemplateChecksum = template.Checksum
+	databaseDtos[1].RolesTemplateChecksum = "previous-checksum"
+	disabledDto := mocks.BuildDatabaseDTOExample()
+	disabledDto.Enabled = false
+	databaseDtos = append(databaseDtos, disabledDto)
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAllDTOs", mocks.EcosystemId, mocks.DatabaseInstanceId).Return(databaseDtos, nil).Once()
+
+	uc := NewListOutdatedRolesDatabasesUseCase(databaseStorage, buildRoleStorage())
+	output, err := uc.Execute(mocks.EcosystemId, mocks.DatabaseInstanceId)
+
+	assert.NoError(t, err, "no error expected")
+	assert.Equal(t, template.Version, output.RolesTemplateVersion)
+	assert.Equal(t, template.Checksum, output.RolesTemplateChecksum)
+	assert.Len(t, output.Databases, 2)
+	assert.Equal(t, databaseDtos[1].ID, output.Databases[0].ID)
+	assert.Equal(t, databaseDtos[2].ID, output.Databases[1].ID)
+}
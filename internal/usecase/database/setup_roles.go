@@ -5,36 +5,54 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/pkg/executor"
 	"github.com/zgsolucoes/zg-data-guard/pkg/utils"
 )
 
 var ErrNoDatabasesFound = fmt.Errorf("no databases found with the provided IDs")
 
+const (
+	SetupRolesDryRunMsg = "dry run: nothing was executed, the statements are the ones that would apply the grants to roles in database"
+)
+
 type SetupRolesInDatabasesUseCase struct {
 	DatabaseInstanceStorage storage.DatabaseInstanceStorage
 	DatabaseStorage         storage.DatabaseStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
 }
 
 func NewSetupRolesInDatabasesUseCase(
 	instanceStorage storage.DatabaseInstanceStorage,
 	databaseStorage storage.DatabaseStorage,
+	roleStorage storage.DatabaseRoleStorage,
 ) *SetupRolesInDatabasesUseCase {
-	return &SetupRolesInDatabasesUseCase{DatabaseInstanceStorage: instanceStorage, DatabaseStorage: databaseStorage}
+	return &SetupRolesInDatabasesUseCase{DatabaseInstanceStorage: instanceStorage, DatabaseStorage: databaseStorage, DatabaseRoleStorage: roleStorage}
 }
 
 // Execute godoc
 /** Responsible for applying grants to roles in all enabled databases or in the selected databases and/or database instance.
-It groups the databases by instance and applies the grants to roles in all databases of each instance concurrently.
-I.e. if there are 3 instances with 20 databases each, it will apply the grants to roles in all 60 databases concurrently.
+It groups the databases by instance and applies the grants to roles in all databases of each instance concurrently, as tasks of the shared executor.
+I.e. if there are 3 instances with 20 databases each, the 60 databases are processed at most with the concurrency configured for the executor.
 It returns a list of results for each database, indicating if the grants were applied successfully or not.
-The grant script for PostgreSQL is read from a file: internal/database/connector/scripts/postgres/setup_grants_roles_database.sql */
+The grants are generated by the connector of each technology from the privileges of the roles managed by Data Guard.
+Each database records the version and checksum of the grants applied (see connector.RolesTemplate), and with OnlyOutdated
+only the databases set up with other grants, or never set up, are processed.
+In dry run, each result has the statements that would be executed in the database, and nothing is executed nor updated. */
 func (uc *SetupRolesInDatabasesUseCase) Execute(input dto.SetupRolesInputDTO, operationUserID string) ([]*dto.SetupRolesOutputDTO, error) {
+	return uc.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each database as an item of the progress
+func (uc *SetupRolesInDatabasesUseCase) ExecuteWithProgress(input dto.SetupRolesInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.SetupRolesOutputDTO, error) {
 	if len(input.DatabasesIDs) > 0 || input.DatabaseInstanceID != "" {
 		log.Printf("Applying grants to roles in the selected %d databases and/or database instance %s. Requester: %s", len(input.DatabasesIDs), input.DatabaseInstanceID, operationUserID)
 		selectedDatabases, err := uc.DatabaseStorage.FindAll(input.DatabaseInstanceID, input.DatabasesIDs)
@@ -44,7 +62,7 @@ func (uc *SetupRolesInDatabasesUseCase) Execute(input dto.SetupRolesInputDTO, op
 		if len(selectedDatabases) == 0 {
 			return nil, ErrNoDatabasesFound
 		}
-		return uc.setupRoles(selectedDatabases)
+		return uc.setupRoles(selectedDatabases, input.OnlyOutdated, input.DryRun, progress)
 	}
 
 	log.Printf("Applying grants to roles in all enabled databases. Requester: %s", operationUserID)
@@ -52,32 +70,50 @@ func (uc *SetupRolesInDatabasesUseCase) Execute(input dto.SetupRolesInputDTO, op
 	if err != nil {
 		return nil, err
 	}
-	return uc.setupRoles(enabledDbs)
+	return uc.setupRoles(enabledDbs, input.OnlyOutdated, input.DryRun, progress)
 }
 
-func (uc *SetupRolesInDatabasesUseCase) setupRoles(databases []*entity.Database) ([]*dto.SetupRolesOutputDTO, error) {
+func (uc *SetupRolesInDatabasesUseCase) setupRoles(databases []*entity.Database, onlyOutdated, dryRun bool, progress common.ProgressReporter) ([]*dto.SetupRolesOutputDTO, error) {
+	dataGuardRoles, err := uc.DatabaseRoleStorage.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching database roles. Cause: %w", err)
+	}
+	roles := connector.NewDatabaseRoles(dataGuardRoles)
+	template := connector.NewRolesTemplate(roles)
+	if onlyOutdated {
+		databases = filterOutdatedDatabases(databases, template)
+		log.Printf("%d databases with roles outdated from the template version %d (%s)", len(databases), template.Version, template.Checksum)
+	}
+	progress.AddItems(len(databases))
 	groupedByInstance := utils.GroupByProperty(databases, func(d *entity.Database) string {
 		return d.DatabaseInstanceID
 	})
 	resultsChan := make(chan *dto.SetupRolesOutputDTO, len(databases))
 
-	var wg sync.WaitGroup
+	batch := config.GetExecutor().NewBatch("setup roles")
 	instancesQty := len(groupedByInstance)
-	wg.Add(instancesQty)
 
 	index := 0
 	for instanceID, instanceDatabases := range groupedByInstance {
-		go uc.executeSetupRolesForInstance(instanceID, instanceDatabases, &wg, resultsChan, index, instancesQty)
+		instanceIndex := index
+		batch.Go(instanceID, func(time.Duration) {
+			uc.executeSetupRolesForInstance(instanceID, instanceDatabases, roles, template, dryRun, batch, resultsChan, instanceIndex, instancesQty)
+		})
 		index++
 	}
 
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(resultsChan)
 	}()
 
 	var results []*dto.SetupRolesOutputDTO
 	for result := range resultsChan {
+		progress.ItemProcessed(dto.ItemResultDTO{
+			Item:    fmt.Sprintf("%s # %s", result.Instance, result.DatabaseName),
+			Success: result.Success,
+			Message: result.Message,
+		})
 		results = append(results, result)
 	}
 	return results, nil
@@ -86,10 +122,12 @@ func (uc *SetupRolesInDatabasesUseCase) setupRoles(databases []*entity.Database)
 func (uc *SetupRolesInDatabasesUseCase) executeSetupRolesForInstance(
 	instanceID string,
 	databases []*entity.Database,
-	wg *sync.WaitGroup,
+	roles []*connector.DatabaseRole,
+	template connector.RolesTemplate,
+	dryRun bool,
+	batch *executor.Batch,
 	resultsChan chan *dto.SetupRolesOutputDTO,
 	index, instancesQty int) {
-	defer wg.Done()
 
 	instanceDto, err := uc.DatabaseInstanceStorage.FindDTOByID(instanceID)
 	if err != nil {
@@ -104,14 +142,14 @@ func (uc *SetupRolesInDatabasesUseCase) executeSetupRolesForInstance(
 		return
 	}
 
+	// The databases are submitted to the executor and not waited, since this task holds a slot of the instance
 	databasesQty := len(databases)
 	for dbIndex, db := range databases {
-		wg.Add(1)
-		go func(db *entity.Database, dbIndex, databasesQty int) {
-			defer wg.Done()
-			result := uc.setupRolesForDatabase(instanceDto, db, dbIndex, databasesQty)
+		batch.Go(instanceID, func(queueTime time.Duration) {
+			result := uc.setupRolesForDatabase(instanceDto, db, roles, template, dryRun, dbIndex, databasesQty)
+			result.QueueTimeMs = queueTime.Milliseconds()
 			resultsChan <- result
-		}(db, dbIndex, databasesQty)
+		})
 	}
 }
 
@@ -133,7 +171,13 @@ func (uc *SetupRolesInDatabasesUseCase) markErrorInAllDatabases(
 	}
 }
 
-func (uc *SetupRolesInDatabasesUseCase) setupRolesForDatabase(instanceDto *dto.DatabaseInstanceOutputDTO, database *entity.Database, dbIndex, databaseQty int) *dto.SetupRolesOutputDTO {
+func (uc *SetupRolesInDatabasesUseCase) setupRolesForDatabase(
+	instanceDto *dto.DatabaseInstanceOutputDTO,
+	database *entity.Database,
+	roles []*connector.DatabaseRole,
+	template connector.RolesTemplate,
+	dryRun bool,
+	dbIndex, databaseQty int) *dto.SetupRolesOutputDTO {
 	output := dto.SetupRolesOutputDTO{
 		DatabaseID:         database.ID.String(),
 		DatabaseName:       database.Name,
@@ -150,17 +194,27 @@ func (uc *SetupRolesInDatabasesUseCase) setupRolesForDatabase(instanceDto *dto.D
 
 	log.Printf("[%d/%d] | [%s # %s # %s]: Applying grants to roles in database", dbIndex+1, databaseQty, instanceDto.EcosystemName, instanceDto.Name, database.Name)
 
-	c, err := connector.NewDatabaseConnector(instanceDto, database.Name)
+	var recorder *connector.StatementRecorder
+	if dryRun {
+		recorder = connector.NewStatementRecorder()
+	}
+	c, err := common.NewConnector(instanceDto, database.Name, recorder)
 	if err != nil {
 		output.Message = err.Error()
 		return &output
 	}
-	err = c.SetupGrantsToRoles()
+	err = c.SetupGrantsToRoles(roles)
 	if err != nil {
 		output.Message = err.Error()
 		return &output
 	}
-	err = uc.updateDatabaseRolesConfigured(database)
+	if dryRun {
+		output.Success = true
+		output.Message = SetupRolesDryRunMsg
+		output.Statements = recorder.Statements(c.Database())
+		return &output
+	}
+	err = uc.updateDatabaseRolesConfigured(database, template)
 	if err != nil {
 		output.Message = err.Error()
 		return &output
@@ -171,7 +225,17 @@ func (uc *SetupRolesInDatabasesUseCase) setupRolesForDatabase(instanceDto *dto.D
 	return &output
 }
 
-func (uc *SetupRolesInDatabasesUseCase) updateDatabaseRolesConfigured(database *entity.Database) error {
-	database.ConfigureRoles()
+func (uc *SetupRolesInDatabasesUseCase) updateDatabaseRolesConfigured(database *entity.Database, template connector.RolesTemplate) error {
+	database.ConfigureRoles(template.Version, template.Checksum)
 	return uc.DatabaseStorage.Update(database)
 }
+
+func filterOutdatedDatabases(databases []*entity.Database, template connector.RolesTemplate) []*entity.Database {
+	var outdated []*entity.Database
+	for _, database := range databases {
+		if database.IsRolesTemplateOutdated(template.Checksum) {
+			outdated = append(outdated, database)
+		}
+	}
+	return outdated
+}
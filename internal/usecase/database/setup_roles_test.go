@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
@@ -17,7 +18,7 @@ func TestGivenAnErrorInDb_WhenExecuteSetupRoles_ThenShouldReturnError(t *testing
 	databaseStorage := new(mocks.DatabaseStorageMock)
 	databaseStorage.On("FindAllEnabled", "").Return([]*entity.Database{}, sql.ErrConnDone).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, nil)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -31,7 +32,7 @@ func TestGivenAnErrorInDbWhileFetchingDatabases_WhenExecuteSetupRoles_ThenShould
 	databaseStorage := new(mocks.DatabaseStorageMock)
 	databaseStorage.On("FindAll", "1", mock.AnythingOfType("[]string")).Return([]*entity.Database{}, sql.ErrConnDone).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, nil)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{DatabaseInstanceID: "1"}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when some error in db")
@@ -45,7 +46,7 @@ func TestGivenInputWithIdsNotExistent_WhenExecuteSetupRoles_ThenShouldReturnErro
 	databaseStorage := new(mocks.DatabaseStorageMock)
 	databaseStorage.On("FindAll", "", []string{"1", "2"}).Return([]*entity.Database{}, sql.ErrNoRows).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, nil)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{DatabasesIDs: []string{"1", "2"}}, mocks.UserID)
 
 	assert.Error(t, err, "error expected when no databases found")
@@ -54,14 +55,29 @@ func TestGivenInputWithIdsNotExistent_WhenExecuteSetupRoles_ThenShouldReturnErro
 	databaseStorage.AssertNumberOfCalls(t, "FindAll", 1)
 }
 
+func TestGivenAnErrorInDbWhileFetchingRoles_WhenExecuteSetupRoles_ThenShouldReturnError(t *testing.T) {
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAllEnabled", "").Return(mocks.BuildDatabaseListSameInstanceAndOnlyEnabled(), nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return([]*entity.DatabaseRole{}, sql.ErrConnDone).Once()
+
+	uc := NewSetupRolesInDatabasesUseCase(nil, databaseStorage, roleStorage)
+	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, outputs)
+	roleStorage.AssertNumberOfCalls(t, "FindAll", 1)
+}
+
 func TestGivenAnErrorInDbWhileFetchingInstanceDTO_WhenExecuteSetupRoles_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
 	databasesToProcess := mocks.BuildDatabaseListSameInstanceAndOnlyEnabled()
 	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
 	dbInstanceStorage.On("FindDTOByID", mocks.DatabaseInstanceId).Return(&dto.DatabaseInstanceOutputDTO{}, sql.ErrConnDone).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
 
 	assert.NoError(t, err, "no error expected")
@@ -78,13 +94,14 @@ func TestGivenAnErrorInDbWhileFetchingInstanceDTO_WhenExecuteSetupRoles_ThenShou
 func TestGivenADisabledInstance_WhenExecuteSetupRoles_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
 	databasesToProcess := mocks.BuildDatabaseListSameInstanceAndOnlyEnabled()
 	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
 	databaseInstance := mocks.BuildAzInstanceDTO()
 	databaseInstance.Enabled = false
 	dbInstanceStorage.On("FindDTOByID", databaseInstance.ID).Return(databaseInstance, nil).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
 
 	assert.NoError(t, err, "no error expected")
@@ -101,6 +118,7 @@ func TestGivenADisabledInstance_WhenExecuteSetupRoles_ThenShouldReturnSuccessFal
 func TestGivenAnErrorInDbWhileUpdateDatabase_WhenExecuteSetupRoles_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
 	databasesToProcess := mocks.BuildDatabaseListSameInstanceAndOnlyEnabled()
 	databasesQty := len(databasesToProcess)
 	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
@@ -108,7 +126,7 @@ func TestGivenAnErrorInDbWhileUpdateDatabase_WhenExecuteSetupRoles_ThenShouldRet
 	databaseInstance := mocks.BuildAzInstanceDTO()
 	dbInstanceStorage.On("FindDTOByID", databaseInstance.ID).Return(databaseInstance, nil).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
 
 	assert.NoError(t, err, "no error expected")
@@ -126,18 +144,19 @@ func TestGivenAnErrorInDbWhileUpdateDatabase_WhenExecuteSetupRoles_ThenShouldRet
 func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteSetupRoles_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	databaseStorage := new(mocks.DatabaseStorageMock)
-	dbInstanceMysql := mocks.BuildConnectorNotImplementedInstance()
-	databaseMysql := &entity.Database{
-		Name:               "mysql-db",
-		DatabaseInstanceID: dbInstanceMysql.ID,
+	roleStorage := buildRoleStorage()
+	dbInstanceOracle := mocks.BuildConnectorNotImplementedInstance()
+	databaseOracle := &entity.Database{
+		Name:               "oracle-db",
+		DatabaseInstanceID: dbInstanceOracle.ID,
 		Enabled:            true,
 	}
-	databasesToProcess := []*entity.Database{databaseMysql}
+	databasesToProcess := []*entity.Database{databaseOracle}
 	databasesQty := len(databasesToProcess)
 	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
-	dbInstanceStorage.On("FindDTOByID", dbInstanceMysql.ID).Return(dbInstanceMysql, nil).Once()
+	dbInstanceStorage.On("FindDTOByID", dbInstanceOracle.ID).Return(dbInstanceOracle, nil).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{}, mocks.UserID)
 	notImplementedConnectorOutput := outputs[0]
 
@@ -145,10 +164,10 @@ func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteSetupRoles_ThenShou
 	assert.NotNil(t, outputs)
 	assert.Len(t, outputs, databasesQty)
 	assert.False(t, notImplementedConnectorOutput.Success)
-	assert.Equal(t, dbInstanceMysql.ID, notImplementedConnectorOutput.DatabaseInstanceID)
-	assert.Equal(t, dbInstanceMysql.Name, notImplementedConnectorOutput.Instance)
-	assert.Equal(t, databaseMysql.Name, notImplementedConnectorOutput.DatabaseName)
-	assert.Equal(t, "the database technology 'mysql' don't have a connector implemented", notImplementedConnectorOutput.Message)
+	assert.Equal(t, dbInstanceOracle.ID, notImplementedConnectorOutput.DatabaseInstanceID)
+	assert.Equal(t, dbInstanceOracle.Name, notImplementedConnectorOutput.Instance)
+	assert.Equal(t, databaseOracle.Name, notImplementedConnectorOutput.DatabaseName)
+	assert.Equal(t, "the database technology 'oracle' don't have a connector implemented", notImplementedConnectorOutput.Message)
 	databaseStorage.AssertNumberOfCalls(t, "FindAllEnabled", 1)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindDTOByID", 1)
 }
@@ -156,6 +175,7 @@ func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteSetupRoles_ThenShou
 func TestGivenSomeDatabases_WhenExecuteSetupRolesByIds_ThenShouldSetupOnlyValidOnes(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
 	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
 	databasesToProcess := mocks.BuildDatabaseListMixedScenarios()
 	var databaseIds []string
 	for _, database := range databasesToProcess {
@@ -168,7 +188,7 @@ func TestGivenSomeDatabases_WhenExecuteSetupRolesByIds_ThenShouldSetupOnlyValidO
 	dbInstanceStorage.On("FindDTOByID", mocks.DatabaseInstanceId).Return(mocks.BuildAzInstanceDTO(), nil).Once()
 	dbInstanceStorage.On("FindDTOByID", mocks.DummyErrorInstanceId).Return(mocks.BuildDummyErrorInstance(), nil).Once()
 
-	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
 	outputs, err := uc.Execute(dto.SetupRolesInputDTO{DatabasesIDs: databaseIds}, mocks.UserID)
 
 	successCount := 0
@@ -193,3 +213,76 @@ func TestGivenSomeDatabases_WhenExecuteSetupRolesByIds_ThenShouldSetupOnlyValidO
 	databaseStorage.AssertNumberOfCalls(t, "Update", 2)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindDTOByID", 3)
 }
+
+func TestGivenDatabasesSetUpWithCurrentAndPreviousTemplates_WhenExecuteSetupRolesOnlyOutdated_ThenShouldSetupOnlyOutdatedOnes(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
+	template := connector.NewRolesTemplate(connector.NewDatabaseRoles(mocks.BuildRolesList()))
+	databasesToProcess := mocks.BuildDatabaseListSameInstanceAndOnlyEnabled()
+	databasesToProcess[0].ConfigureRoles(template.Version, template.Checksum)
+	databasesToProcess[1].ConfigureRoles(template.Version-1, "previous-checksum")
+	databasesToProcess[2].RolesConfigured = false
+	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
+	databaseStorage.On("Update", mock.Anything).Return(nil).Times(2)
+	dbInstanceStorage.On("FindDTOByID", mocks.DatabaseInstanceId).Return(mocks.BuildAzInstanceDTO(), nil).Once()
+
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
+	outputs, err := uc.Execute(dto.SetupRolesInputDTO{OnlyOutdated: true}, mocks.UserID)
+
+	assert.NoError(t, err, "no error expected")
+	assert.Len(t, outputs, 2)
+	for _, output := range outputs {
+		assert.True(t, output.Success)
+		assert.NotEqual(t, databasesToProcess[0].Name, output.DatabaseName)
+	}
+	for _, database := range databasesToProcess {
+		assert.False(t, database.IsRolesTemplateOutdated(template.Checksum))
+		assert.Equal(t, connector.RolesTemplateVersion, database.RolesTemplateVersion)
+	}
+	databaseStorage.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestGivenNoOutdatedDatabases_WhenExecuteSetupRolesOnlyOutdated_ThenShouldSetupNone(t *testing.T) {
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
+	template := connector.NewRolesTemplate(connector.NewDatabaseRoles(mocks.BuildRolesList()))
+	databasesToProcess := mocks.BuildDatabaseListSameInstanceAndOnlyEnabled()
+	for _, database := range databasesToProcess {
+		database.ConfigureRoles(template.Version, template.Checksum)
+	}
+	databaseStorage.On("FindAllEnabled", "").Return(databasesToProcess, nil).Once()
+
+	uc := NewSetupRolesInDatabasesUseCase(nil, databaseStorage, roleStorage)
+	outputs, err := uc.Execute(dto.SetupRolesInputDTO{OnlyOutdated: true}, mocks.UserID)
+
+	assert.NoError(t, err, "no error expected")
+	assert.Empty(t, outputs)
+	databaseStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func buildRoleStorage() *mocks.DatabaseRoleStorageMock {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	return roleStorage
+}
+
+func TestGivenDryRun_WhenExecuteSetupRoles_ThenShouldReturnTheStatementsWithoutUpdatingTheDatabase(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	roleStorage := buildRoleStorage()
+	database := mocks.BuildSettingsDatabase()
+	databaseID := database.ID.String()
+	databaseStorage.On("FindAll", "", []string{databaseID}).Return([]*entity.Database{database}, nil).Once()
+	dbInstanceStorage.On("FindDTOByID", mocks.DatabaseInstanceId).Return(mocks.BuildAzInstanceDTO(), nil).Once()
+
+	uc := NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
+	outputs, err := uc.Execute(dto.SetupRolesInputDTO{DatabasesIDs: []string{databaseID}, DryRun: true}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.True(t, outputs[0].Success)
+	assert.Equal(t, SetupRolesDryRunMsg, outputs[0].Message)
+	assert.Equal(t, []string{"GRANT PRIVILEGES ON settings TO developer", "GRANT PRIVILEGES ON settings TO user_ro"}, outputs[0].Statements)
+	databaseStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
 )
 
 var ErrNoDatabaseInstancesFound = fmt.Errorf("no database instances found with the provided IDs")
@@ -29,7 +31,7 @@ func NewSyncDatabasesUseCase(dbInstanceStorage storage.DatabaseInstanceStorage,
 
 // Execute godoc
 /** Responsible for synchronizing the databases of all enabled database instances or of the selected database instances.
-It groups the databases by instance and synchronizes the databases of each instance concurrently.
+It groups the databases by instance and synchronizes the databases of each instance concurrently, as tasks of the shared executor.
 The synchronization process consists of comparing the databases of the instance with the databases of the zg-data-guard:
 - If a database exists in the instance and not in the zg-data-guard, it is created.
 - If a database exists in the instance and in the zg-data-guard, but with different sizes, the size is updated.
@@ -37,6 +39,12 @@ The synchronization process consists of comparing the databases of the instance
 It returns a list of results for each database instance, indicating if the databases were synchronized successfully or not.
 */
 func (uc *SyncDatabasesUseCase) Execute(input dto.SyncDatabasesInputDTO, operationUserID string) ([]*dto.SyncDatabasesOutputDTO, error) {
+	return uc.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each database instance as an item of the progress
+func (uc *SyncDatabasesUseCase) ExecuteWithProgress(input dto.SyncDatabasesInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.SyncDatabasesOutputDTO, error) {
 	if len(input.DatabaseInstancesIDs) > 0 {
 		log.Printf("Synchronizing databases of the selected %d database instances by user %s", len(input.DatabaseInstancesIDs), operationUserID)
 		selectedInstances, err := uc.DatabaseInstanceStorage.FindAllDTOs("", "", input.DatabaseInstancesIDs)
@@ -46,7 +54,7 @@ func (uc *SyncDatabasesUseCase) Execute(input dto.SyncDatabasesInputDTO, operati
 		if len(selectedInstances) == 0 {
 			return nil, ErrNoDatabaseInstancesFound
 		}
-		return uc.syncDatabasesFromInstances(operationUserID, selectedInstances)
+		return uc.syncDatabasesFromInstances(operationUserID, selectedInstances, progress)
 	}
 
 	log.Printf("Synchronizing databases of all enabled database instances by user %s", operationUserID)
@@ -54,28 +62,29 @@ func (uc *SyncDatabasesUseCase) Execute(input dto.SyncDatabasesInputDTO, operati
 	if err != nil {
 		return nil, err
 	}
-	return uc.syncDatabasesFromInstances(operationUserID, enabledInstances)
+	return uc.syncDatabasesFromInstances(operationUserID, enabledInstances, progress)
 }
 
-func (uc *SyncDatabasesUseCase) syncDatabasesFromInstances(operationUserID string, dbInstances []*dto.DatabaseInstanceOutputDTO) ([]*dto.SyncDatabasesOutputDTO, error) {
+func (uc *SyncDatabasesUseCase) syncDatabasesFromInstances(operationUserID string, dbInstances []*dto.DatabaseInstanceOutputDTO, progress common.ProgressReporter) ([]*dto.SyncDatabasesOutputDTO, error) {
 	instancesQty := len(dbInstances)
+	progress.AddItems(instancesQty)
 	resultsChan := make(chan *dto.SyncDatabasesOutputDTO, instancesQty)
 
-	var wg sync.WaitGroup
-	wg.Add(instancesQty)
+	batch := config.GetExecutor().NewBatch("sync databases")
 
-	// Start a goroutine for each instance to sync the databases with it and send the results to the channels to be processed later
+	// Submit a task for each instance to sync the databases with it and send the results to the channels to be processed later
 	for idx, instance := range dbInstances {
-		go func(idx int, instance *dto.DatabaseInstanceOutputDTO) {
-			defer wg.Done()
+		batch.Go(instance.ID, func(queueTime time.Duration) {
 			output := uc.syncDatabases(instance, operationUserID, idx, instancesQty)
+			output.QueueTimeMs = queueTime.Milliseconds()
+			progress.ItemProcessed(dto.ItemResultDTO{Item: output.Instance, Success: output.Success, Message: output.Message})
 			resultsChan <- output
-		}(idx, instance)
+		})
 	}
 
-	// Wait for all goroutines to finish and close the channels
+	// Wait for all tasks to finish and close the channels
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(resultsChan)
 	}()
 
@@ -191,8 +191,8 @@ func TestGivenInputWithIdsNotExistent_WhenExecuteSyncDatabases_ThenShouldReturnE
 
 func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteSyncDatabases_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
-	dbInstanceMysql := mocks.BuildConnectorNotImplementedInstance()
-	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceMysql}
+	dbInstanceOracle := mocks.BuildConnectorNotImplementedInstance()
+	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceOracle}
 	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return(dbInstances, nil).Once()
 
 	uc := NewSyncDatabasesUseCase(dbInstanceStorage, nil)
@@ -203,9 +203,9 @@ func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteSyncDatabases_ThenS
 	assert.NotNil(t, outputs)
 	assert.Len(t, outputs, 1)
 	assert.False(t, notImplementedConnectorOutput.Success)
-	assert.Equal(t, dbInstanceMysql.ID, notImplementedConnectorOutput.DatabaseInstanceID)
-	assert.Equal(t, dbInstanceMysql.Name, notImplementedConnectorOutput.Instance)
-	assert.Equal(t, "the database technology 'mysql' don't have a connector implemented", notImplementedConnectorOutput.Message)
+	assert.Equal(t, dbInstanceOracle.ID, notImplementedConnectorOutput.DatabaseInstanceID)
+	assert.Equal(t, dbInstanceOracle.Name, notImplementedConnectorOutput.Instance)
+	assert.Equal(t, "the database technology 'oracle' don't have a connector implemented", notImplementedConnectorOutput.Message)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindAllDTOsEnabled", 1)
 }
 
@@ -72,6 +72,11 @@ func (c *CreateDatabaseInstanceUseCase) Execute(input dto.DatabaseInstanceInputD
 		HostConnection:       databaseInstance.HostConnection.HostConnection,
 		PortConnection:       databaseInstance.HostConnection.PortConnection,
 		AdminUser:            databaseInstance.HostConnection.AdminUser,
+		SSLMode:              string(databaseInstance.HostConnection.SSLMode),
+		BastionHost:          databaseInstance.HostConnection.BastionHost,
+		BastionPort:          databaseInstance.HostConnection.BastionPort,
+		BastionUser:          databaseInstance.HostConnection.BastionUser,
+		BastionHostKey:       databaseInstance.HostConnection.BastionHostKey,
 		EcosystemID:          databaseInstance.EcosystemID,
 		DatabaseTechnologyID: databaseInstance.DatabaseTechnologyID,
 		Enabled:              databaseInstance.Enabled,
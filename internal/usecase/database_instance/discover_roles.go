@@ -0,0 +1,242 @@
+package instance
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type DiscoverRolesUseCase struct {
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	AccessPermissionStorage storage.AccessPermissionStorage
+	RoleFindingStorage      storage.RoleFindingStorage
+}
+
+func NewDiscoverRolesUseCase(
+	instanceStorage storage.DatabaseInstanceStorage,
+	dbUserStorage storage.DatabaseUserStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+	roleFindingStorage storage.RoleFindingStorage,
+) *DiscoverRolesUseCase {
+	return &DiscoverRolesUseCase{
+		DatabaseInstanceStorage: instanceStorage,
+		DatabaseUserStorage:     dbUserStorage,
+		AccessPermissionStorage: accessPermissionStorage,
+		RoleFindingStorage:      roleFindingStorage,
+	}
+}
+
+// Execute godoc
+/** Responsible for discovering the logins of all enabled database instances or of the selected database instances.
+It reads the logins of each instance concurrently, as tasks of the shared executor, and classifies each one:
+- SUPERUSER: the login has every privilege of the instance.
+- PRIVILEGED: the login can create roles (CREATEROLE) or bypass the row level security (BYPASSRLS).
+- UNKNOWN: the login doesn't belong to any database user of the zg-data-guard.
+- MISMATCHED: the login belongs to a database user, but the user is disabled, has no access permission in the instance
+or has roles not granted by the zg-data-guard.
+- MANAGED: any other login of a database user.
+The findings of each instance replace the ones of its previous discovery.
+It returns a list of results for each database instance, with the number of logins found by class.
+*/
+func (uc *DiscoverRolesUseCase) Execute(input dto.DiscoverRolesInputDTO, operationUserID string) ([]*dto.DiscoverRolesOutputDTO, error) {
+	return uc.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each database instance as an item of the progress
+func (uc *DiscoverRolesUseCase) ExecuteWithProgress(input dto.DiscoverRolesInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.DiscoverRolesOutputDTO, error) {
+	if len(input.DatabaseInstancesIDs) > 0 {
+		log.Printf("Discovering roles of the selected %d database instances. Requester: %s", len(input.DatabaseInstancesIDs), operationUserID)
+		selectedInstances, err := uc.DatabaseInstanceStorage.FindAllDTOs("", "", input.DatabaseInstancesIDs)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if len(selectedInstances) == 0 {
+			return nil, ErrNoDatabaseInstancesFound
+		}
+		return uc.discoverRolesInInstances(selectedInstances, operationUserID, progress)
+	}
+
+	log.Printf("Discovering roles of all enabled database instances. Requester: %s", operationUserID)
+	enabledInstances, err := uc.DatabaseInstanceStorage.FindAllDTOsEnabled("", "")
+	if err != nil {
+		return nil, err
+	}
+	return uc.discoverRolesInInstances(enabledInstances, operationUserID, progress)
+}
+
+func (uc *DiscoverRolesUseCase) discoverRolesInInstances(dbInstances []*dto.DatabaseInstanceOutputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.DiscoverRolesOutputDTO, error) {
+	dbUsers, err := uc.DatabaseUserStorage.FindAll(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching database users. Cause: %w", err)
+	}
+	dbUsersByUsername := make(map[string]*entity.DatabaseUser, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		dbUsersByUsername[dbUser.Username] = dbUser
+	}
+
+	instancesQty := len(dbInstances)
+	progress.AddItems(instancesQty)
+	resultsChan := make(chan *dto.DiscoverRolesOutputDTO, instancesQty)
+	batch := config.GetExecutor().NewBatch("discover roles")
+
+	for idx, instance := range dbInstances {
+		batch.Go(instance.ID, func(queueTime time.Duration) {
+			output := uc.discoverRolesInInstance(instance, dbUsersByUsername, operationUserID, idx, instancesQty)
+			output.QueueTimeMs = queueTime.Milliseconds()
+			progress.ItemProcessed(dto.ItemResultDTO{Item: output.Instance, Success: output.Success, Message: output.Message})
+			resultsChan <- output
+		})
+	}
+
+	// Wait for all tasks to finish and close the channels
+	go func() {
+		batch.Wait()
+		close(resultsChan)
+	}()
+
+	return uc.buildResultingList(resultsChan), nil
+}
+
+func (uc *DiscoverRolesUseCase) discoverRolesInInstance(
+	instanceDto *dto.DatabaseInstanceOutputDTO,
+	dbUsersByUsername map[string]*entity.DatabaseUser,
+	operationUserID string,
+	idx, instancesQty int) *dto.DiscoverRolesOutputDTO {
+	output := dto.DiscoverRolesOutputDTO{
+		DatabaseInstanceID: instanceDto.ID,
+		Success:            false,
+		Instance:           instanceDto.Name,
+		Ecosystem:          instanceDto.EcosystemName,
+		Technology:         instanceDto.DatabaseTechnologyName,
+	}
+	if !instanceDto.Enabled {
+		output.Message = "database instance is disabled"
+		return &output
+	}
+
+	c, err := connector.NewDatabaseConnector(instanceDto, "")
+	if err != nil {
+		output.Message = err.Error()
+		return &output
+	}
+	log.Printf("Discovering roles of instance [%s] %s (%d/%d)", instanceDto.EcosystemName, instanceDto.Name, idx+1, instancesQty)
+	logins, err := c.ListLogins()
+	if err != nil {
+		log.Printf("Error listing logins of instance [%s] %s. Cause: %v", instanceDto.EcosystemName, instanceDto.Name, err)
+		output.Message = err.Error()
+		return &output
+	}
+	usersWithPermission, err := uc.findUsersWithPermission(instanceDto.ID)
+	if err != nil {
+		output.Message = fmt.Sprintf("error while fetching the access permissions of the instance. Cause: %v", err)
+		return &output
+	}
+
+	discoveredAt := time.Now()
+	findings := make([]*entity.RoleFinding, 0, len(logins))
+	output.LoginsByClass = make(map[string]int)
+	for _, login := range logins {
+		dbUser := dbUsersByUsername[login.Name]
+		class, details := classifyLogin(login, dbUser, dbUser != nil && usersWithPermission[dbUser.ID.String()])
+		dbUserID := ""
+		if dbUser != nil {
+			dbUserID = dbUser.ID.String()
+		}
+		finding, err := entity.NewRoleFinding(instanceDto.ID, login.Name, dbUserID, class, details, operationUserID, discoveredAt)
+		if err != nil {
+			output.Message = err.Error()
+			return &output
+		}
+		findings = append(findings, finding)
+		output.LoginsByClass[string(class)]++
+	}
+	if err = uc.RoleFindingStorage.ReplaceAllByInstance(instanceDto.ID, findings); err != nil {
+		output.Message = fmt.Sprintf("error while saving the findings of the instance. Cause: %v", err)
+		return &output
+	}
+
+	output.Success = true
+	output.TotalLogins = len(logins)
+	output.Message = fmt.Sprintf("%d logins discovered successfully!", len(logins))
+	return &output
+}
+
+func (uc *DiscoverRolesUseCase) findUsersWithPermission(instanceID string) (map[string]bool, error) {
+	permissions, err := uc.AccessPermissionStorage.FindAllDTOs("", "", instanceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	usersWithPermission := make(map[string]bool)
+	for _, permission := range permissions {
+		usersWithPermission[permission.DatabaseUserID] = true
+	}
+	return usersWithPermission, nil
+}
+
+// classifyLogin godoc
+// Returns the class of the login, the most dangerous one that applies, and the reasons found, e.g.
+// "can create roles; not created by Data Guard"
+func classifyLogin(login *connector.Login, dbUser *entity.DatabaseUser, hasPermission bool) (entity.RoleFindingClass, string) {
+	var reasons []string
+	if login.Superuser {
+		reasons = append(reasons, "superuser")
+	}
+	if login.CreateRole {
+		reasons = append(reasons, "can create roles")
+	}
+	if login.BypassRLS {
+		reasons = append(reasons, "bypasses row level security")
+	}
+	mismatched := false
+	if dbUser == nil {
+		reasons = append(reasons, "not created by Data Guard")
+	} else {
+		if !dbUser.Enabled {
+			reasons = append(reasons, "the user is disabled in Data Guard")
+			mismatched = true
+		}
+		if !hasPermission {
+			reasons = append(reasons, "the user has no access permission in the instance")
+			mismatched = true
+		}
+	}
+	if len(login.UnmanagedRoles) > 0 {
+		reasons = append(reasons, fmt.Sprintf("roles not granted by Data Guard: %s", strings.Join(login.UnmanagedRoles, ", ")))
+		mismatched = true
+	}
+
+	details := strings.Join(reasons, "; ")
+	switch {
+	case login.Superuser:
+		return entity.RoleFindingSuperuser, details
+	case login.CreateRole || login.BypassRLS:
+		return entity.RoleFindingPrivileged, details
+	case dbUser == nil:
+		return entity.RoleFindingUnknown, details
+	case mismatched:
+		return entity.RoleFindingMismatched, details
+	default:
+		return entity.RoleFindingManaged, details
+	}
+}
+
+func (uc *DiscoverRolesUseCase) buildResultingList(resultsChan chan *dto.DiscoverRolesOutputDTO) []*dto.DiscoverRolesOutputDTO {
+	var outputs []*dto.DiscoverRolesOutputDTO
+	for result := range resultsChan {
+		outputs = append(outputs, result)
+	}
+
+	return outputs
+}
@@ -0,0 +1,99 @@
+package instance
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDbWhileFetchingUsers_WhenExecuteDiscoverRoles_ThenShouldReturnError(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return([]*dto.DatabaseInstanceOutputDTO{mocks.BuildAzInstanceDTO()}, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string(nil)).Return([]*entity.DatabaseUser{}, sql.ErrConnDone).Once()
+
+	uc := NewDiscoverRolesUseCase(dbInstanceStorage, dbUserStorage, nil, nil)
+	outputs, err := uc.Execute(dto.DiscoverRolesInputDTO{}, mocks.UserID)
+
+	assert.EqualError(t, err, "error while fetching database users. Cause: "+sql.ErrConnDone.Error())
+	assert.Nil(t, outputs)
+}
+
+func TestGivenInputWithIdsNotExistent_WhenExecuteDiscoverRoles_ThenShouldReturnError(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{"1"}).Return([]*dto.DatabaseInstanceOutputDTO{}, sql.ErrNoRows).Once()
+
+	uc := NewDiscoverRolesUseCase(dbInstanceStorage, nil, nil, nil)
+	outputs, err := uc.Execute(dto.DiscoverRolesInputDTO{DatabaseInstancesIDs: []string{"1"}}, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrNoDatabaseInstancesFound)
+	assert.Nil(t, outputs)
+}
+
+func TestGivenLoginsOfEachClass_WhenExecuteDiscoverRoles_ThenShouldClassifyThemAndReplaceTheFindingsOfTheInstance(t *testing.T) {
+	instance := mocks.BuildAzInstanceDTO()
+	john := mocks.BuildDbUserJohn()
+	foobar := &entity.DatabaseUser{ID: uuid.New(), Username: "foobar", Enabled: false}
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string(nil)).Return([]*entity.DatabaseUser{john, foobar}, nil).Once()
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", "", instance.ID).
+		Return([]*dto.AccessPermissionOutputDTO{{DatabaseUserID: john.ID.String()}, {DatabaseUserID: foobar.ID.String()}}, nil).Once()
+	roleFindingStorage := new(mocks.RoleFindingStorageMock)
+	var savedFindings []*entity.RoleFinding
+	roleFindingStorage.On("ReplaceAllByInstance", instance.ID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		savedFindings = args.Get(1).([]*entity.RoleFinding)
+	}).Once()
+
+	uc := NewDiscoverRolesUseCase(dbInstanceStorage, dbUserStorage, accessPermissionStorage, roleFindingStorage)
+	outputs, err := uc.Execute(dto.DiscoverRolesInputDTO{}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.True(t, outputs[0].Success)
+	assert.Equal(t, 5, outputs[0].TotalLogins)
+	assert.Equal(t, map[string]int{"MANAGED": 1, "MISMATCHED": 1, "UNKNOWN": 1, "PRIVILEGED": 1, "SUPERUSER": 1}, outputs[0].LoginsByClass)
+	assert.Len(t, savedFindings, 5)
+	findingsByLogin := make(map[string]*entity.RoleFinding)
+	for _, finding := range savedFindings {
+		findingsByLogin[finding.LoginName] = finding
+		assert.Equal(t, mocks.UserID, finding.DiscoveredByUserID)
+	}
+	assert.Equal(t, entity.RoleFindingManaged, findingsByLogin["johndoe"].Class)
+	assert.Equal(t, john.ID.String(), findingsByLogin["johndoe"].DatabaseUserID)
+	assert.Equal(t, entity.RoleFindingMismatched, findingsByLogin["foobar"].Class)
+	assert.Equal(t, "the user is disabled in Data Guard; roles not granted by Data Guard: reporting_admin", findingsByLogin["foobar"].Details)
+	assert.Equal(t, entity.RoleFindingUnknown, findingsByLogin["legacy_app"].Class)
+	assert.Empty(t, findingsByLogin["legacy_app"].DatabaseUserID)
+	assert.Equal(t, entity.RoleFindingPrivileged, findingsByLogin["etl"].Class)
+	assert.Equal(t, "can create roles; bypasses row level security; not created by Data Guard", findingsByLogin["etl"].Details)
+	assert.Equal(t, entity.RoleFindingSuperuser, findingsByLogin["postgres"].Class)
+	roleFindingStorage.AssertNumberOfCalls(t, "ReplaceAllByInstance", 1)
+}
+
+func TestGivenAnErrorListingLogins_WhenExecuteDiscoverRoles_ThenShouldReturnSuccessFalseKeepingThePreviousFindings(t *testing.T) {
+	instance := mocks.BuildDummyErrorInstance()
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAll", []string(nil)).Return([]*entity.DatabaseUser{mocks.BuildDbUserJohn()}, nil).Once()
+	roleFindingStorage := new(mocks.RoleFindingStorageMock)
+
+	uc := NewDiscoverRolesUseCase(dbInstanceStorage, dbUserStorage, nil, roleFindingStorage)
+	outputs, err := uc.Execute(dto.DiscoverRolesInputDTO{DatabaseInstancesIDs: []string{instance.ID}}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.False(t, outputs[0].Success)
+	assert.Equal(t, "error listing logins from instance-dummy-test-error", outputs[0].Message)
+	roleFindingStorage.AssertNotCalled(t, "ReplaceAllByInstance", mock.Anything, mock.Anything)
+}
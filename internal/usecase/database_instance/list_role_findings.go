@@ -0,0 +1,30 @@
+package instance
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListRoleFindingsUseCase struct {
+	RoleFindingStorage storage.RoleFindingStorage
+}
+
+func NewListRoleFindingsUseCase(roleFindingStorage storage.RoleFindingStorage) *ListRoleFindingsUseCase {
+	return &ListRoleFindingsUseCase{RoleFindingStorage: roleFindingStorage}
+}
+
+func (uc *ListRoleFindingsUseCase) Execute(databaseInstanceID, class string, page, limit int) ([]*dto.RoleFindingOutputDTO, int, error) {
+	findingDTOs, err := uc.RoleFindingStorage.FindAllDTOs(databaseInstanceID, class, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching role findings! Cause: %w", err)
+	}
+	totalCount, err := uc.RoleFindingStorage.Count(databaseInstanceID, class)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching role findings count! Cause: %w", err)
+	}
+	log.Printf("List of role findings loaded successfully!")
+	return findingDTOs, totalCount, nil
+}
@@ -0,0 +1,40 @@
+package instance
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDb_WhenExecuteListRoleFindings_ThenShouldReturnError(t *testing.T) {
+	roleFindingStorage := new(mocks.RoleFindingStorageMock)
+	roleFindingStorage.On("FindAllDTOs", "", "", 1, 10).Return([]*dto.RoleFindingOutputDTO{}, sql.ErrConnDone).Once()
+	uc := NewListRoleFindingsUseCase(roleFindingStorage)
+
+	findings, total, err := uc.Execute("", "", 1, 10)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, findings)
+	assert.Zero(t, total)
+	roleFindingStorage.AssertNotCalled(t, "Count", "", "")
+}
+
+func TestGivenFilters_WhenExecuteListRoleFindings_ThenShouldReturnTheFindingsAndTheTotal(t *testing.T) {
+	class := string(entity.RoleFindingUnknown)
+	finding := &dto.RoleFindingOutputDTO{DatabaseInstanceID: mocks.DatabaseInstanceId, LoginName: "legacy_app", Class: class}
+	roleFindingStorage := new(mocks.RoleFindingStorageMock)
+	roleFindingStorage.On("FindAllDTOs", mocks.DatabaseInstanceId, class, 2, 5).Return([]*dto.RoleFindingOutputDTO{finding}, nil).Once()
+	roleFindingStorage.On("Count", mocks.DatabaseInstanceId, class).Return(6, nil).Once()
+	uc := NewListRoleFindingsUseCase(roleFindingStorage)
+
+	findings, total, err := uc.Execute(mocks.DatabaseInstanceId, class, 2, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*dto.RoleFindingOutputDTO{finding}, findings)
+	assert.Equal(t, 6, total)
+}
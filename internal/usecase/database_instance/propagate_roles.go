@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const (
+	PropagateRolesDryRunMsg = "dry run: nothing was executed, the databases have the statements that would create the roles in database instance"
 )
 
 type PropagateRolesUseCase struct {
@@ -27,10 +33,17 @@ func NewPropagateRolesUseCase(
 
 // Execute godoc
 /** Responsible for creating all roles existing in zg-data-guard in all enabled database instances or in the selected database instances.
-It creates the roles concurrently in all instances.
+It creates the roles concurrently in all instances, as tasks of the shared executor.
 It returns a list of results for each database instance, indicating if the roles were created successfully or not.
+In dry run, each result has the statements that would be executed in the instance, and nothing is executed nor updated.
 */
 func (tc *PropagateRolesUseCase) Execute(input dto.PropagateRolesInputDTO, operationUserID string) ([]*dto.PropagateRolesOutputDTO, error) {
+	return tc.ExecuteWithProgress(input, operationUserID, common.NoProgress)
+}
+
+// ExecuteWithProgress godoc
+// Same as Execute, reporting each database instance as an item of the progress
+func (tc *PropagateRolesUseCase) ExecuteWithProgress(input dto.PropagateRolesInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.PropagateRolesOutputDTO, error) {
 	if len(input.DatabaseInstancesIDs) > 0 {
 		log.Printf("Propagating roles to the selected %d database instances. Requester: %s", len(input.DatabaseInstancesIDs), operationUserID)
 		selectedInstances, err := tc.DatabaseInstanceStorage.FindAllDTOs("", "", input.DatabaseInstancesIDs)
@@ -40,7 +53,7 @@ func (tc *PropagateRolesUseCase) Execute(input dto.PropagateRolesInputDTO, opera
 		if len(selectedInstances) == 0 {
 			return nil, ErrNoDatabaseInstancesFound
 		}
-		return tc.propagateRolesInInstances(selectedInstances)
+		return tc.propagateRolesInInstances(selectedInstances, input.DryRun, progress)
 	}
 
 	log.Printf("Propagating roles to all enabled database instances. Requester: %s", operationUserID)
@@ -48,39 +61,40 @@ func (tc *PropagateRolesUseCase) Execute(input dto.PropagateRolesInputDTO, opera
 	if err != nil {
 		return nil, err
 	}
-	return tc.propagateRolesInInstances(enabledInstances)
+	return tc.propagateRolesInInstances(enabledInstances, input.DryRun, progress)
 }
 
-func (tc *PropagateRolesUseCase) propagateRolesInInstances(dbInstances []*dto.DatabaseInstanceOutputDTO) ([]*dto.PropagateRolesOutputDTO, error) {
+func (tc *PropagateRolesUseCase) propagateRolesInInstances(dbInstances []*dto.DatabaseInstanceOutputDTO, dryRun bool, progress common.ProgressReporter) ([]*dto.PropagateRolesOutputDTO, error) {
 	roles, err := tc.DatabaseRoleStorage.FindAll()
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching database roles. Cause: %w", err)
 	}
 
 	instancesQty := len(dbInstances)
+	progress.AddItems(instancesQty)
 	resultsChan := make(chan *dto.PropagateRolesOutputDTO, instancesQty)
-	var wg sync.WaitGroup
-	wg.Add(instancesQty)
+	batch := config.GetExecutor().NewBatch("propagate roles")
+	recorders := common.NewStatementRecorders(dryRun, dbInstances)
 
 	for idx, instance := range dbInstances {
-		idx := idx
-		go func(instance *dto.DatabaseInstanceOutputDTO) {
-			defer wg.Done()
-			output := tc.propagateRolesToInstance(instance, roles, idx, instancesQty)
+		batch.Go(instance.ID, func(queueTime time.Duration) {
+			output := tc.propagateRolesToInstance(instance, roles, recorders[instance.ID], idx, instancesQty)
+			output.QueueTimeMs = queueTime.Milliseconds()
+			progress.ItemProcessed(dto.ItemResultDTO{Item: output.Instance, Success: output.Success, Message: output.Message})
 			resultsChan <- output
-		}(instance)
+		})
 	}
 
-	// Wait for all goroutines to finish and close the channels
+	// Wait for all tasks to finish and close the channels
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(resultsChan)
 	}()
 
 	return tc.buildResultingList(resultsChan), nil
 }
 
-func (tc *PropagateRolesUseCase) propagateRolesToInstance(instanceDto *dto.DatabaseInstanceOutputDTO, roles []*entity.DatabaseRole, idx int, instancesQty int) *dto.PropagateRolesOutputDTO {
+func (tc *PropagateRolesUseCase) propagateRolesToInstance(instanceDto *dto.DatabaseInstanceOutputDTO, roles []*entity.DatabaseRole, recorder *connector.StatementRecorder, idx int, instancesQty int) *dto.PropagateRolesOutputDTO {
 	output := dto.PropagateRolesOutputDTO{
 		DatabaseInstanceID: instanceDto.ID,
 		Success:            false,
@@ -93,22 +107,24 @@ func (tc *PropagateRolesUseCase) propagateRolesToInstance(instanceDto *dto.Datab
 		return &output
 	}
 
-	c, err := connector.NewDatabaseConnector(instanceDto, "")
+	c, err := common.NewConnector(instanceDto, "", recorder)
 	if err != nil {
 		output.Message = err.Error()
 		return &output
 	}
 	log.Printf("Creating roles in instance [%s] %s (%d/%d)", instanceDto.EcosystemName, instanceDto.Name, idx+1, instancesQty)
-	rolesForCreation := make([]*connector.DatabaseRole, 0, len(roles))
-	for _, role := range roles {
-		rolesForCreation = append(rolesForCreation, &connector.DatabaseRole{Name: role.Name})
-	}
-	err = c.CreateRoles(rolesForCreation)
+	err = c.CreateRoles(connector.NewDatabaseRoles(roles))
 	if err != nil {
 		log.Printf("Error creating roles in instance [%s] %s. Cause: %v", instanceDto.EcosystemName, instanceDto.Name, err)
 		output.Message = err.Error()
 		return &output
 	}
+	if recorder != nil {
+		output.Success = true
+		output.Message = PropagateRolesDryRunMsg
+		output.Databases = recorder.Plan()
+		return &output
+	}
 
 	output.Message = "roles created in database instance successfully!"
 	if err = tc.updateInstanceProperties(instanceDto.ID); err != nil {
@@ -124,14 +124,14 @@ func TestGivenAnErrorInDbWhileUpdateInstance_WhenExecutePropagateRoles_ThenShoul
 
 func TestGivenInstanceWithConnectorNotImplemented_WhenExecutePropagateRoles_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
-	dbInstanceMysql := &dto.DatabaseInstanceOutputDTO{
+	dbInstanceOracle := &dto.DatabaseInstanceOutputDTO{
 		ID:                        "2",
-		Name:                      "MySQL - Local",
-		DatabaseTechnologyName:    "MySQL",
+		Name:                      "Oracle - Local",
+		DatabaseTechnologyName:    "Oracle",
 		DatabaseTechnologyVersion: "1",
 		Enabled:                   true,
 	}
-	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceMysql}
+	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceOracle}
 	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return(dbInstances, nil).Once()
 	roleStorage := new(mocks.DatabaseRoleStorageMock)
 	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
@@ -143,8 +143,8 @@ func TestGivenInstanceWithConnectorNotImplemented_WhenExecutePropagateRoles_Then
 	assert.NotNil(t, outputs)
 	assert.Len(t, outputs, 1)
 	assert.False(t, outputs[0].Success)
-	assert.Equal(t, dbInstanceMysql.ID, outputs[0].DatabaseInstanceID)
-	assert.Equal(t, dbInstanceMysql.Name, outputs[0].Instance)
+	assert.Equal(t, dbInstanceOracle.ID, outputs[0].DatabaseInstanceID)
+	assert.Equal(t, dbInstanceOracle.Name, outputs[0].Instance)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindAllDTOsEnabled", 1)
 	roleStorage.AssertNumberOfCalls(t, "FindAll", 1)
 }
@@ -185,3 +185,23 @@ func TestGivenSomeInstances_WhenExecutePropagateRolesByIds_ThenShouldPropagateRo
 	dbInstanceStorage.AssertNumberOfCalls(t, "Update", 1)
 	roleStorage.AssertNumberOfCalls(t, "FindAll", 1)
 }
+
+func TestGivenDryRun_WhenExecutePropagateRoles_ThenShouldReturnTheStatementsWithoutUpdatingTheInstance(t *testing.T) {
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	instance := mocks.BuildAzInstanceDTO()
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string{instance.ID}).Return([]*dto.DatabaseInstanceOutputDTO{instance}, nil).Once()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+
+	uc := NewPropagateRolesUseCase(dbInstanceStorage, roleStorage)
+	outputs, err := uc.Execute(dto.PropagateRolesInputDTO{DatabaseInstancesIDs: []string{instance.ID}, DryRun: true}, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Len(t, outputs, 1)
+	assert.True(t, outputs[0].Success)
+	assert.Equal(t, PropagateRolesDryRunMsg, outputs[0].Message)
+	expectedDatabases := []dto.DryRunDatabaseOutputDTO{{Database: "dummy-test-db", Statements: []string{"CREATE ROLE developer", "CREATE ROLE user_ro"}}}
+	assert.Equal(t, expectedDatabases, outputs[0].Databases)
+	dbInstanceStorage.AssertNotCalled(t, "FindByID", mock.Anything)
+	dbInstanceStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
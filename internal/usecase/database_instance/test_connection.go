@@ -5,8 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/config"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
@@ -46,22 +47,20 @@ func (tc *TestConnectionUseCase) Execute(input dto.TestConnectionInputDTO) ([]*d
 func (tc *TestConnectionUseCase) testConnectionsFromInstances(dbInstances []*dto.DatabaseInstanceOutputDTO) ([]*dto.TestConnectionOutputDTO, error) {
 	instancesQty := len(dbInstances)
 	resultsChan := make(chan *dto.TestConnectionOutputDTO, instancesQty)
-	var wg sync.WaitGroup
-	wg.Add(instancesQty)
+	batch := config.GetExecutor().NewBatch("test connection")
 
-	// Start a goroutine for each instance to test the connection with it and send the results to the channels to be processed later
+	// Submit a task for each instance to test the connection with it and send the results to the channels to be processed later
 	for idx, instance := range dbInstances {
-		idx := idx
-		go func(instance *dto.DatabaseInstanceOutputDTO) {
-			defer wg.Done()
+		batch.Go(instance.ID, func(queueTime time.Duration) {
 			output := tc.testInstanceConnection(instance, idx, instancesQty)
+			output.QueueTimeMs = queueTime.Milliseconds()
 			resultsChan <- output
-		}(instance)
+		})
 	}
 
-	// Wait for all goroutines to finish and close the channels
+	// Wait for all tasks to finish and close the channels
 	go func() {
-		wg.Wait()
+		batch.Wait()
 		close(resultsChan)
 	}()
 
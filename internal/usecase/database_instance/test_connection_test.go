@@ -98,14 +98,14 @@ func TestGivenAnErrorInDbWhileUpdateInstance_WhenExecuteTestConnection_ThenShoul
 
 func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteTestConnection_ThenShouldReturnSuccessFalse(t *testing.T) {
 	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
-	dbInstanceMysql := &dto.DatabaseInstanceOutputDTO{
+	dbInstanceOracle := &dto.DatabaseInstanceOutputDTO{
 		ID:                        "2",
-		Name:                      "MySQL - AWS",
-		DatabaseTechnologyName:    "MySQL",
+		Name:                      "Oracle - AWS",
+		DatabaseTechnologyName:    "Oracle",
 		DatabaseTechnologyVersion: "1",
 		Enabled:                   true,
 	}
-	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceMysql}
+	dbInstances := []*dto.DatabaseInstanceOutputDTO{dbInstanceOracle}
 	dbInstanceStorage.On("FindAllDTOsEnabled", "", "").Return(dbInstances, nil).Once()
 
 	uc := NewTestConnectionUseCase(dbInstanceStorage)
@@ -115,8 +115,8 @@ func TestGivenInstanceWithConnectorNotImplemented_WhenExecuteTestConnection_Then
 	assert.NotNil(t, outputs)
 	assert.Len(t, outputs, 1)
 	assert.False(t, outputs[0].Success)
-	assert.Equal(t, dbInstanceMysql.ID, outputs[0].DatabaseInstanceID)
-	assert.Equal(t, dbInstanceMysql.Name, outputs[0].Instance)
+	assert.Equal(t, dbInstanceOracle.ID, outputs[0].DatabaseInstanceID)
+	assert.Equal(t, dbInstanceOracle.Name, outputs[0].Instance)
 	dbInstanceStorage.AssertNumberOfCalls(t, "FindAllDTOsEnabled", 1)
 }
 
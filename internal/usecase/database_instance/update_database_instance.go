@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/zgsolucoes/zg-data-guard/internal/database/connector"
 	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
 )
@@ -68,6 +69,8 @@ func (uc *UpdateDatabaseInstanceUseCase) Execute(input dto.DatabaseInstanceInput
 		logErrorWithID(err, errorUpdatingDatabaseInstance, dbInstanceID)
 		return nil, err
 	}
+	// Pools opened with the previous host, credentials or certificates must not be reused
+	connector.InvalidateConnections(dbInstance.ID.String())
 
 	isDisabled := dbInstance.DisabledAt.Valid
 	var disabledAt *time.Time
@@ -83,6 +86,11 @@ func (uc *UpdateDatabaseInstanceUseCase) Execute(input dto.DatabaseInstanceInput
 		HostConnection:       dbInstance.HostConnection.HostConnection,
 		PortConnection:       dbInstance.HostConnection.PortConnection,
 		AdminUser:            dbInstance.HostConnection.AdminUser,
+		SSLMode:              string(dbInstance.HostConnection.SSLMode),
+		BastionHost:          dbInstance.HostConnection.BastionHost,
+		BastionPort:          dbInstance.HostConnection.BastionPort,
+		BastionUser:          dbInstance.HostConnection.BastionUser,
+		BastionHostKey:       dbInstance.HostConnection.BastionHostKey,
 		EcosystemID:          dbInstance.EcosystemID,
 		DatabaseTechnologyID: dbInstance.DatabaseTechnologyID,
 		Enabled:              dbInstance.Enabled,
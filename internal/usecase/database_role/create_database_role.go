@@ -0,0 +1,55 @@
+package role
+
+import (
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+var (
+	ErrRoleNameAlreadyExists = errors.New("a database role with this name already exists")
+)
+
+type CreateDatabaseRoleUseCase struct {
+	DatabaseRoleStorage storage.DatabaseRoleStorage
+}
+
+func NewCreateDatabaseRoleUseCase(databaseRoleStorage storage.DatabaseRoleStorage) *CreateDatabaseRoleUseCase {
+	return &CreateDatabaseRoleUseCase{
+		DatabaseRoleStorage: databaseRoleStorage,
+	}
+}
+
+// Execute godoc
+// Creates a database role with the given privileges. The role only exists in the database instances after the roles are
+// propagated to them and the grants set up in their databases.
+func (uc *CreateDatabaseRoleUseCase) Execute(input dto.DatabaseRoleInputDTO, createdByUserID string) (*dto.DatabaseRoleOutputDTO, error) {
+	role, err := entity.NewDatabaseRole(input.Name, input.DisplayName, input.Description, buildRolePrivileges(input.Privileges), createdByUserID)
+	if err != nil {
+		log.Printf("Error creating database role. Cause: %v", err.Error())
+		return nil, err
+	}
+
+	nameExists, err := uc.DatabaseRoleStorage.CheckNameExists(input.Name)
+	if err != nil {
+		log.Printf("Error checking existance of database role with name %s. Cause: %v", input.Name, err.Error())
+		return nil, err
+	}
+	if nameExists {
+		log.Printf("Error creating database role. Cause: database role with name %s already exists", input.Name)
+		return nil, ErrRoleNameAlreadyExists
+	}
+
+	err = uc.DatabaseRoleStorage.Save(role)
+	if err != nil {
+		log.Printf("error creating database role: %v", err.Error())
+		return nil, err
+	}
+	_ = LoadKnownRoleNames(uc.DatabaseRoleStorage)
+
+	log.Printf("Database role %s (%v) created successfully by user %s!", role.Name, role.ID, createdByUserID)
+	return buildDatabaseRoleOutputDTO(role), nil
+}
@@ -0,0 +1,80 @@
+package role
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func buildReportingRoleInput() dto.DatabaseRoleInputDTO {
+	return dto.DatabaseRoleInputDTO{
+		Name:        "reporting",
+		DisplayName: "Reporting",
+		Description: "Role for the reporting tools",
+		Privileges: dto.RolePrivilegesDTO{
+			Schema:  []string{"usage"},
+			Table:   []string{"select"},
+			Default: dto.DefaultPrivilegesDTO{Table: []string{"select"}},
+		},
+	}
+}
+
+func TestGivenAnInvalidPrivilege_WhenExecuteCreate_ThenShouldReturnError(t *testing.T) {
+	input := buildReportingRoleInput()
+	input.Privileges.Table = append(input.Privileges.Table, "DROP")
+
+	uc := NewCreateDatabaseRoleUseCase(new(mocks.DatabaseRoleStorageMock))
+	output, err := uc.Execute(input, mocks.UserID)
+
+	assert.ErrorIs(t, err, entity.ErrInvalidRolePrivilege)
+	assert.Nil(t, output)
+}
+
+func TestGivenAnExistingName_WhenExecuteCreate_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("CheckNameExists", "reporting").Return(true, nil).Once()
+
+	uc := NewCreateDatabaseRoleUseCase(roleStorage)
+	output, err := uc.Execute(buildReportingRoleInput(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrRoleNameAlreadyExists)
+	assert.Nil(t, output)
+	roleStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenAnErrorInDb_WhenExecuteCreate_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("CheckNameExists", "reporting").Return(false, nil).Once()
+	roleStorage.On("Save", mock.Anything).Return(sql.ErrConnDone).Once()
+
+	uc := NewCreateDatabaseRoleUseCase(roleStorage)
+	output, err := uc.Execute(buildReportingRoleInput(), mocks.UserID)
+
+	assert.EqualError(t, err, sql.ErrConnDone.Error())
+	assert.Nil(t, output)
+}
+
+func TestGivenAValidInput_WhenExecuteCreate_ThenShouldCreateRoleAndLoadItsName(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("CheckNameExists", "reporting").Return(false, nil).Once()
+	roleStorage.On("Save", mock.Anything).Return(nil).Once()
+	roleStorage.On("FindAll").Return([]*entity.DatabaseRole{{Name: "reporting"}}, nil).Once()
+	defer entity.SetKnownRoleNames([]entity.RoleName{entity.UserRO, entity.Developer, entity.DevOps, entity.Application})
+
+	uc := NewCreateDatabaseRoleUseCase(roleStorage)
+	output, err := uc.Execute(buildReportingRoleInput(), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "reporting", output.Name)
+	assert.True(t, output.ReadOnly)
+	assert.Equal(t, []string{"SELECT"}, output.Privileges.Table)
+	assert.Equal(t, []string{"SELECT"}, output.Privileges.Default.Table)
+	assert.True(t, entity.ValidateRoleName("reporting"))
+	roleStorage.AssertNumberOfCalls(t, "Save", 1)
+}
@@ -0,0 +1,53 @@
+package role
+
+import (
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+func buildDatabaseRoleOutputDTO(role *entity.DatabaseRole) *dto.DatabaseRoleOutputDTO {
+	output := &dto.DatabaseRoleOutputDTO{
+		ID:          role.ID.String(),
+		Name:        string(role.Name),
+		DisplayName: role.DisplayName,
+		Description: role.Description,
+		ReadOnly:    role.ReadOnly,
+		Privileges: dto.RolePrivilegesDTO{
+			Database: role.Privileges.Database,
+			Schema:   role.Privileges.Schema,
+			Table:    role.Privileges.Table,
+			Sequence: role.Privileges.Sequence,
+			Function: role.Privileges.Function,
+			Type:     role.Privileges.Type,
+			Default: dto.DefaultPrivilegesDTO{
+				Table:    role.Privileges.Default.Table,
+				Sequence: role.Privileges.Default.Sequence,
+				Function: role.Privileges.Default.Function,
+				Type:     role.Privileges.Default.Type,
+			},
+		},
+		CreatedAt:       role.CreatedAt,
+		CreatedByUserID: role.CreatedByUserID,
+	}
+	if !role.UpdatedAt.IsZero() {
+		output.UpdatedAt = &role.UpdatedAt
+	}
+	return output
+}
+
+func buildRolePrivileges(privileges dto.RolePrivilegesDTO) entity.RolePrivileges {
+	return entity.RolePrivileges{
+		Database: privileges.Database,
+		Schema:   privileges.Schema,
+		Table:    privileges.Table,
+		Sequence: privileges.Sequence,
+		Function: privileges.Function,
+		Type:     privileges.Type,
+		Default: entity.DefaultPrivileges{
+			Table:    privileges.Default.Table,
+			Sequence: privileges.Default.Sequence,
+			Function: privileges.Default.Function,
+			Type:     privileges.Default.Type,
+		},
+	}
+}
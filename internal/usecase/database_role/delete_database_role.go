@@ -0,0 +1,54 @@
+package role
+
+import (
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var (
+	ErrPredefinedRoleDeletion = errors.New("the predefined database roles can't be deleted")
+	ErrDatabaseRoleInUse      = errors.New("the database role is in use by database users or access permissions, change their roles before deleting it")
+)
+
+type DeleteDatabaseRoleUseCase struct {
+	DatabaseRoleStorage storage.DatabaseRoleStorage
+}
+
+func NewDeleteDatabaseRoleUseCase(databaseRoleStorage storage.DatabaseRoleStorage) *DeleteDatabaseRoleUseCase {
+	return &DeleteDatabaseRoleUseCase{
+		DatabaseRoleStorage: databaseRoleStorage,
+	}
+}
+
+// Execute godoc
+// Deletes a database role not used by any database user or access permission. The role is kept in the database
+// instances, where it no longer has any member.
+func (uc *DeleteDatabaseRoleUseCase) Execute(roleID, operationUserID string) error {
+	role, err := uc.DatabaseRoleStorage.FindByID(roleID)
+	if err != nil {
+		log.Printf("Error fetching database role with id %s. Cause: %v", roleID, err.Error())
+		return common.HandleFindError(err, common.ErrDatabaseRoleNotFound)
+	}
+	if role.IsPredefined() {
+		return ErrPredefinedRoleDeletion
+	}
+	inUse, err := uc.DatabaseRoleStorage.IsInUse(roleID)
+	if err != nil {
+		log.Printf("Error checking the usage of database role %s. Cause: %v", role.Name, err.Error())
+		return err
+	}
+	if inUse {
+		return ErrDatabaseRoleInUse
+	}
+
+	if err = uc.DatabaseRoleStorage.Delete(roleID); err != nil {
+		log.Printf("Error deleting database role with id %s. Cause: %v", roleID, err.Error())
+		return common.HandleFindError(err, common.ErrDatabaseRoleNotFound)
+	}
+	_ = LoadKnownRoleNames(uc.DatabaseRoleStorage)
+	log.Printf("Database role %s (%s) deleted successfully by user %s!", role.Name, roleID, operationUserID)
+	return nil
+}
@@ -0,0 +1,65 @@
+package role
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func buildReportingRole() *entity.DatabaseRole {
+	return &entity.DatabaseRole{ID: uuid.New(), Name: "reporting", DisplayName: "Reporting", CreatedByUserID: mocks.UserID}
+}
+
+func TestGivenAnNonexistentId_WhenExecuteDelete_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", "1").Return((*entity.DatabaseRole)(nil), sql.ErrNoRows).Once()
+
+	err := NewDeleteDatabaseRoleUseCase(roleStorage).Execute("1", mocks.UserID)
+
+	assert.ErrorIs(t, err, common.ErrDatabaseRoleNotFound)
+}
+
+func TestGivenAPredefinedRole_WhenExecuteDelete_ThenShouldReturnError(t *testing.T) {
+	role := mocks.BuildDeveloperRole()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+
+	err := NewDeleteDatabaseRoleUseCase(roleStorage).Execute(role.ID.String(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrPredefinedRoleDeletion)
+	roleStorage.AssertNotCalled(t, "Delete", role.ID.String())
+}
+
+func TestGivenARoleInUse_WhenExecuteDelete_ThenShouldReturnError(t *testing.T) {
+	role := buildReportingRole()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+	roleStorage.On("IsInUse", role.ID.String()).Return(true, nil).Once()
+
+	err := NewDeleteDatabaseRoleUseCase(roleStorage).Execute(role.ID.String(), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrDatabaseRoleInUse)
+	roleStorage.AssertNotCalled(t, "Delete", role.ID.String())
+}
+
+func TestGivenAnUnusedRole_WhenExecuteDelete_ThenShouldDeleteIt(t *testing.T) {
+	role := buildReportingRole()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+	roleStorage.On("IsInUse", role.ID.String()).Return(false, nil).Once()
+	roleStorage.On("Delete", role.ID.String()).Return(nil).Once()
+	roleStorage.On("FindAll").Return(mocks.BuildRolesList(), nil).Once()
+	defer entity.SetKnownRoleNames([]entity.RoleName{entity.UserRO, entity.Developer, entity.DevOps, entity.Application})
+
+	err := NewDeleteDatabaseRoleUseCase(roleStorage).Execute(role.ID.String(), mocks.UserID)
+
+	assert.NoError(t, err)
+	roleStorage.AssertNumberOfCalls(t, "Delete", 1)
+	roleStorage.AssertNumberOfCalls(t, "FindAll", 1)
+}
@@ -0,0 +1,28 @@
+package role
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type GetDatabaseRoleUseCase struct {
+	DatabaseRoleStorage storage.DatabaseRoleStorage
+}
+
+func NewGetDatabaseRoleUseCase(databaseRoleStorage storage.DatabaseRoleStorage) *GetDatabaseRoleUseCase {
+	return &GetDatabaseRoleUseCase{
+		DatabaseRoleStorage: databaseRoleStorage,
+	}
+}
+
+func (uc *GetDatabaseRoleUseCase) Execute(roleID string) (*dto.DatabaseRoleOutputDTO, error) {
+	role, err := uc.DatabaseRoleStorage.FindByID(roleID)
+	if err != nil {
+		log.Printf("Error fetching database role with id %s. Cause: %v", roleID, err.Error())
+		return nil, common.HandleFindError(err, common.ErrDatabaseRoleNotFound)
+	}
+	return buildDatabaseRoleOutputDTO(role), nil
+}
@@ -0,0 +1,34 @@
+package role
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnNonexistentId_WhenExecuteGet_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", "1").Return((*entity.DatabaseRole)(nil), sql.ErrNoRows).Once()
+
+	output, err := NewGetDatabaseRoleUseCase(roleStorage).Execute("1")
+
+	assert.ErrorIs(t, err, common.ErrDatabaseRoleNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenAValidId_WhenExecuteGet_ThenShouldReturnRoleWithPrivileges(t *testing.T) {
+	role := mocks.BuildDeveloperRole()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+
+	output, err := NewGetDatabaseRoleUseCase(roleStorage).Execute(role.ID.String())
+
+	assert.NoError(t, err)
+	assert.Equal(t, role.ID.String(), output.ID)
+	assert.Equal(t, role.Privileges.Table, output.Privileges.Table)
+}
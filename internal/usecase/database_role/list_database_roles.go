@@ -25,15 +25,7 @@ func (uc *ListDatabaseRolesUseCase) Execute() ([]*dto.DatabaseRoleOutputDTO, err
 	}
 	rolesDTO := make([]*dto.DatabaseRoleOutputDTO, 0, len(roles))
 	for _, role := range roles {
-		rolesDTO = append(rolesDTO, &dto.DatabaseRoleOutputDTO{
-			ID:              role.ID.String(),
-			Name:            string(role.Name),
-			DisplayName:     role.DisplayName,
-			Description:     role.Description,
-			ReadOnly:        role.ReadOnly,
-			CreatedAt:       role.CreatedAt,
-			CreatedByUserID: role.CreatedByUserID,
-		})
+		rolesDTO = append(rolesDTO, buildDatabaseRoleOutputDTO(role))
 	}
 	log.Printf("List of database roles loaded successfully!")
 	return rolesDTO, nil
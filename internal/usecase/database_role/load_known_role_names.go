@@ -0,0 +1,25 @@
+package role
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+// LoadKnownRoleNames godoc
+// Loads the names of the roles from the storage into the entity registry used by the connectors to recognize the Data
+// Guard roles. It runs at startup, after each change to the roles and periodically, so every replica sees the new roles.
+func LoadKnownRoleNames(roleStorage storage.DatabaseRoleStorage) error {
+	roles, err := roleStorage.FindAll()
+	if err != nil {
+		log.Printf("Error loading the names of the database roles. Cause: %v", err)
+		return err
+	}
+	names := make([]entity.RoleName, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	entity.SetKnownRoleNames(names)
+	return nil
+}
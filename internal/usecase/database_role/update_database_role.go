@@ -0,0 +1,44 @@
+package role
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type UpdateDatabaseRoleUseCase struct {
+	DatabaseRoleStorage storage.DatabaseRoleStorage
+}
+
+func NewUpdateDatabaseRoleUseCase(databaseRoleStorage storage.DatabaseRoleStorage) *UpdateDatabaseRoleUseCase {
+	return &UpdateDatabaseRoleUseCase{
+		DatabaseRoleStorage: databaseRoleStorage,
+	}
+}
+
+// Execute godoc
+// Updates the display name, description and privileges of a database role. The new privileges apply to the databases
+// the next time the grants are set up in them.
+func (uc *UpdateDatabaseRoleUseCase) Execute(input dto.UpdateDatabaseRoleInputDTO, roleID, operationUserID string) (*dto.DatabaseRoleOutputDTO, error) {
+	role, err := uc.DatabaseRoleStorage.FindByID(roleID)
+	if err != nil {
+		log.Printf("Error fetching database role with id %s. Cause: %v", roleID, err.Error())
+		return nil, common.HandleFindError(err, common.ErrDatabaseRoleNotFound)
+	}
+
+	role.Update(input.DisplayName, input.Description, buildRolePrivileges(input.Privileges))
+	if err = role.Validate(); err != nil {
+		log.Printf("Error updating database role %s. Cause: %v", role.Name, err.Error())
+		return nil, err
+	}
+	err = uc.DatabaseRoleStorage.Update(role)
+	if err != nil {
+		log.Printf("error updating database role: %v", err.Error())
+		return nil, err
+	}
+
+	log.Printf("Database role %s (%v) updated successfully by user %s!", role.Name, role.ID, operationUserID)
+	return buildDatabaseRoleOutputDTO(role), nil
+}
@@ -0,0 +1,47 @@
+package role
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnNonexistentId_WhenExecuteUpdate_ThenShouldReturnError(t *testing.T) {
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", "1").Return((*entity.DatabaseRole)(nil), sql.ErrNoRows).Once()
+
+	uc := NewUpdateDatabaseRoleUseCase(roleStorage)
+	output, err := uc.Execute(dto.UpdateDatabaseRoleInputDTO{}, "1", mocks.UserID)
+
+	assert.ErrorIs(t, err, common.ErrDatabaseRoleNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenNewPrivileges_WhenExecuteUpdate_ThenShouldUpdateRoleKeepingItsName(t *testing.T) {
+	role := mocks.BuildReadOnlyRole()
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roleStorage.On("FindByID", role.ID.String()).Return(role, nil).Once()
+	roleStorage.On("Update", mock.Anything).Return(nil).Once()
+	input := dto.UpdateDatabaseRoleInputDTO{
+		DisplayName: "Read and Write",
+		Privileges:  dto.RolePrivilegesDTO{Schema: []string{"USAGE"}, Table: []string{"SELECT", "INSERT"}},
+	}
+
+	uc := NewUpdateDatabaseRoleUseCase(roleStorage)
+	output, err := uc.Execute(input, role.ID.String(), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.UserRO), output.Name)
+	assert.Equal(t, "Read and Write", output.DisplayName)
+	assert.False(t, output.ReadOnly)
+	assert.Equal(t, []string{"SELECT", "INSERT"}, output.Privileges.Table)
+	assert.NotNil(t, output.UpdatedAt)
+	roleStorage.AssertNumberOfCalls(t, "Update", 1)
+}
@@ -15,15 +15,6 @@ import (
 	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
 )
 
-type RevokeAccessPermissionUseCaseMock struct {
-	mock.Mock
-}
-
-func (m *RevokeAccessPermissionUseCaseMock) Execute(input dto.RevokeAccessInputDTO, operationUserID string) (*dto.RevokeAccessOutputDTO, error) {
-	args := m.Called(input, operationUserID)
-	return args.Get(0).(*dto.RevokeAccessOutputDTO), args.Error(1)
-}
-
 func TestGivenAnErrorInDbWhileCheckHost_WhenExecuteChangeStatus_ThenShouldReturnError(t *testing.T) {
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
 	dbUserStorage.On("FindByID", mocks.DbUserID).Return(&entity.DatabaseUser{}, sql.ErrConnDone).Once()
@@ -90,7 +81,7 @@ func TestGivenAnErrorOnRevokingAccess_WhenExecuteChangeStatusToDisable_ThenShoul
 	dbUser := mocks.BuildDbUserJohn()
 	dbUserID := dbUser.ID.String()
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
-	mockRevoke := new(RevokeAccessPermissionUseCaseMock)
+	mockRevoke := new(mocks.RevokeAccessPermissionUseCaseMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	revokeInput := dto.RevokeAccessInputDTO{DatabaseUserID: dbUserID, DatabaseInstancesIDs: []string{}}
 	mockRevoke.On("Execute", revokeInput, mocks.UserID).Return(&dto.RevokeAccessOutputDTO{}, sql.ErrConnDone).Once()
@@ -109,7 +100,7 @@ func TestExecuteToDisable_WhenNoAccessibleInstancesFound_ShouldDisableUser(t *te
 	dbUser := mocks.BuildDbUserJohn()
 	dbUserID := dbUser.ID.String()
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
-	mockRevoke := new(RevokeAccessPermissionUseCaseMock)
+	mockRevoke := new(mocks.RevokeAccessPermissionUseCaseMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	revokeInput := dto.RevokeAccessInputDTO{DatabaseUserID: dbUserID, DatabaseInstancesIDs: []string{}}
 	mockRevoke.On("Execute", revokeInput, mocks.UserID).Return(&dto.RevokeAccessOutputDTO{}, common.ErrNoAccessibleInstancesFound).Once()
@@ -132,7 +123,7 @@ func TestExecuteToDisable_WhenRevokeAllAccess_ShouldDisableUser(t *testing.T) {
 	dbUser := mocks.BuildDbUserJohn()
 	dbUserID := dbUser.ID.String()
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
-	mockRevoke := new(RevokeAccessPermissionUseCaseMock)
+	mockRevoke := new(mocks.RevokeAccessPermissionUseCaseMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	revokeInput := dto.RevokeAccessInputDTO{DatabaseUserID: dbUserID, DatabaseInstancesIDs: []string{}}
 	mockRevoke.On("Execute", revokeInput, mocks.UserID).Return(&dto.RevokeAccessOutputDTO{HasErrors: false}, nil).Once()
@@ -155,7 +146,7 @@ func TestExecuteToDisable_WhenRevokeAccessHasErrors_ShouldReturnError(t *testing
 	dbUser := mocks.BuildDbUserJohn()
 	dbUserID := dbUser.ID.String()
 	dbUserStorage := new(mocks.DatabaseUserStorageMock)
-	mockRevoke := new(RevokeAccessPermissionUseCaseMock)
+	mockRevoke := new(mocks.RevokeAccessPermissionUseCaseMock)
 	dbUserStorage.On("FindByID", dbUserID).Return(dbUser, nil).Once()
 	revokeInput := dto.RevokeAccessInputDTO{DatabaseUserID: dbUserID, DatabaseInstancesIDs: []string{}}
 	mockRevoke.On("Execute", revokeInput, mocks.UserID).Return(&dto.RevokeAccessOutputDTO{HasErrors: true}, nil).Once()
@@ -15,7 +15,7 @@ import (
 const errorUpdatingDatabaseUser = "Error updating database user"
 
 var (
-	ErrDatabaseUserHasAccessPermissions = errors.New("database user has access permissions and cannot have their role changed")
+	ErrDatabaseUserHasAccessPermissions = errors.New("database user has access permissions and cannot have their role changed, use the role migration instead")
 )
 
 type UpdateDatabaseUserUseCase struct {
@@ -0,0 +1,151 @@
+package desiredstate
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type ApplyDesiredStateUseCase struct {
+	PlanUseCase                     *PlanDesiredStateUseCase
+	CreateEcosystemUseCase          common.CreateEcosystemUseCaseInterface
+	UpdateEcosystemUseCase          common.UpdateEcosystemUseCaseInterface
+	DeleteEcosystemUseCase          common.DeleteEcosystemUseCaseInterface
+	CreateDatabaseInstanceUseCase   common.CreateDatabaseInstanceUseCaseInterface
+	UpdateDatabaseInstanceUseCase   common.UpdateDatabaseInstanceUseCaseInterface
+	ChangeStatusInstanceUseCase     common.ChangeStatusUseCaseInterface
+	CreateDatabaseUserUseCase       common.CreateDatabaseUserUseCaseInterface
+	UpdateDatabaseUserUseCase       common.UpdateDatabaseUserUseCaseInterface
+	ChangeStatusUserUseCase         common.ChangeStatusUseCaseInterface
+	CreateAccessGroupUseCase        common.CreateAccessGroupUseCaseInterface
+	UpdateAccessGroupTargetsUseCase common.UpdateAccessGroupTargetsUseCaseInterface
+	AddAccessGroupMembersUseCase    common.AddAccessGroupMembersUseCaseInterface
+	RemoveAccessGroupMemberUseCase  common.RemoveAccessGroupMemberUseCaseInterface
+	GrantUseCase                    common.GrantAccessPermissionUseCaseInterface
+	RevokeUseCase                   common.RevokeAccessPermissionUseCaseInterface
+}
+
+func NewApplyDesiredStateUseCase(
+	planUseCase *PlanDesiredStateUseCase,
+	createEcosystemUseCase common.CreateEcosystemUseCaseInterface,
+	updateEcosystemUseCase common.UpdateEcosystemUseCaseInterface,
+	deleteEcosystemUseCase common.DeleteEcosystemUseCaseInterface,
+	createDatabaseInstanceUseCase common.CreateDatabaseInstanceUseCaseInterface,
+	updateDatabaseInstanceUseCase common.UpdateDatabaseInstanceUseCaseInterface,
+	changeStatusInstanceUseCase common.ChangeStatusUseCaseInterface,
+	createDatabaseUserUseCase common.CreateDatabaseUserUseCaseInterface,
+	updateDatabaseUserUseCase common.UpdateDatabaseUserUseCaseInterface,
+	changeStatusUserUseCase common.ChangeStatusUseCaseInterface,
+	createAccessGroupUseCase common.CreateAccessGroupUseCaseInterface,
+	updateAccessGroupTargetsUseCase common.UpdateAccessGroupTargetsUseCaseInterface,
+	addAccessGroupMembersUseCase common.AddAccessGroupMembersUseCaseInterface,
+	removeAccessGroupMemberUseCase common.RemoveAccessGroupMemberUseCaseInterface,
+	grantUseCase common.GrantAccessPermissionUseCaseInterface,
+	revokeUseCase common.RevokeAccessPermissionUseCaseInterface,
+) *ApplyDesiredStateUseCase {
+	return &ApplyDesiredStateUseCase{
+		PlanUseCase:                     planUseCase,
+		CreateEcosystemUseCase:          createEcosystemUseCase,
+		UpdateEcosystemUseCase:          updateEcosystemUseCase,
+		DeleteEcosystemUseCase:          deleteEcosystemUseCase,
+		CreateDatabaseInstanceUseCase:   createDatabaseInstanceUseCase,
+		UpdateDatabaseInstanceUseCase:   updateDatabaseInstanceUseCase,
+		ChangeStatusInstanceUseCase:     changeStatusInstanceUseCase,
+		CreateDatabaseUserUseCase:       createDatabaseUserUseCase,
+		UpdateDatabaseUserUseCase:       updateDatabaseUserUseCase,
+		ChangeStatusUserUseCase:         changeStatusUserUseCase,
+		CreateAccessGroupUseCase:        createAccessGroupUseCase,
+		UpdateAccessGroupTargetsUseCase: updateAccessGroupTargetsUseCase,
+		AddAccessGroupMembersUseCase:    addAccessGroupMembersUseCase,
+		RemoveAccessGroupMemberUseCase:  removeAccessGroupMemberUseCase,
+		GrantUseCase:                    grantUseCase,
+		RevokeUseCase:                   revokeUseCase,
+	}
+}
+
+// Execute godoc
+/** Plans the desired state, as the plan use case does, and applies each change in order through the use cases of the
+API, so every change is validated and logged as when made directly. A change that fails doesn't stop the others: it's
+reported with its error, as the changes that depend on it, e.g. the grants to a user that couldn't be created. */
+func (uc *ApplyDesiredStateUseCase) Execute(input dto.DesiredStateInputDTO, prune bool, operationUserID string) (*dto.DesiredStateOutputDTO, error) {
+	p, err := uc.PlanUseCase.plan(input, prune)
+	if err != nil {
+		return nil, err
+	}
+	changes := p.allChanges()
+	log.Printf("Applying the desired state with %d changes (prune: %t). Requester: %s", len(changes), prune, operationUserID)
+
+	a := &applier{UseCase: uc, IDs: p.Current.resolvedIDs(), OperationUserID: operationUserID}
+	output := &dto.DesiredStateOutputDTO{Changes: make([]dto.DesiredStateChangeDTO, 0, len(changes)), Warnings: p.Warnings}
+	appliedQty := 0
+	for _, change := range changes {
+		message, err := change.apply(a)
+		success := err == nil
+		if success {
+			appliedQty++
+		} else {
+			log.Printf("Error applying the change %s %s %s. Cause: %v", change.Change.Action, change.Change.Kind, change.Change.Name, err)
+			message = err.Error()
+			output.HasErrors = true
+		}
+		change.Change.Success = &success
+		change.Change.Message = message
+		output.Changes = append(output.Changes, change.Change)
+	}
+	output.Message = NoChangesMsg
+	if len(changes) > 0 {
+		output.Message = fmt.Sprintf(ChangesAppliedMsg, appliedQty, len(changes))
+	}
+	return output, nil
+}
+
+// applier godoc
+// State shared by the changes while they are applied
+type applier struct {
+	UseCase         *ApplyDesiredStateUseCase
+	IDs             *resolvedIDs
+	OperationUserID string
+}
+
+// id godoc
+// Returns the ID of the entity by its natural key, which is missing when the change that would create it failed
+func (a *applier) id(ids map[string]string, kind, key string) (string, error) {
+	id, found := ids[key]
+	if !found {
+		return "", fmt.Errorf("%s %s not found", strings.ToLower(kind), key)
+	}
+	return id, nil
+}
+
+// instancesData godoc
+// Builds the targets of a group in the shape of a grant, with one entry per instance. An instance targeted as a whole
+// covers all its databases, even when some of them are targeted too.
+func (a *applier) instancesData(targets []resolvedTarget) ([]dto.InstanceDataDTO, error) {
+	var instancesData []dto.InstanceDataDTO
+	indexByInstance := make(map[string]int)
+	wholeInstances := make(map[string]bool)
+	for _, t := range targets {
+		if t.DatabaseID == "" {
+			wholeInstances[t.Instance] = true
+		}
+	}
+	for _, t := range targets {
+		idx, found := indexByInstance[t.Instance]
+		if !found {
+			instanceID, err := a.id(a.IDs.Instances, KindInstance, t.Instance)
+			if err != nil {
+				return nil, err
+			}
+			idx = len(instancesData)
+			indexByInstance[t.Instance] = idx
+			instancesData = append(instancesData, dto.InstanceDataDTO{DatabaseInstanceID: instanceID})
+		}
+		if t.DatabaseID != "" && !wholeInstances[t.Instance] {
+			instancesData[idx].DatabasesIDs = append(instancesData[idx].DatabasesIDs, t.DatabaseID)
+		}
+	}
+	return instancesData, nil
+}
@@ -0,0 +1,133 @@
+package desiredstate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const (
+	gcpEcosystemID = "8f3c2b1a-6d4e-4f5a-9b8c-7d6e5f4a3b2c"
+	janeID         = "2c4e6a8b-1d3f-4a5b-8c7d-9e0f1a2b3c4d"
+)
+
+func TestGivenADesiredStateWithNewEntities_WhenExecuteApplyDesiredStateWithPrune_ThenShouldApplyTheChangesUsingTheCreatedIDs(t *testing.T) {
+	s := buildStoredState()
+	createEcosystemUC := new(mocks.CreateEcosystemUseCaseMock)
+	createEcosystemUC.On("Execute", dto.EcosystemInputDTO{Code: "gcp", DisplayName: "GCP"}, mocks.UserID).
+		Return(&dto.EcosystemOutputDTO{ID: gcpEcosystemID}, nil).Once()
+	createDBUserUC := new(mocks.CreateDatabaseUserUseCaseMock)
+	createDBUserUC.On("Execute", mock.MatchedBy(func(input dto.DatabaseUserInputDTO) bool { return input.Email == janeEmail }), mocks.UserID).
+		Return(&dto.DatabaseUserOutputDTO{ID: janeID}, nil).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	expectedGrant := dto.GrantAccessInputDTO{
+		DatabaseUsersIDs: []string{janeID},
+		InstancesData:    []dto.InstanceDataDTO{{DatabaseInstanceID: s.Instance.ID, DatabasesIDs: []string{settingsDatabaseID, jobsDatabaseID}}},
+	}
+	grantUC.On("Execute", expectedGrant, mocks.UserID).Return(&dto.GrantAccessOutputDTO{Message: "access granted"}, nil).Once()
+	changeStatusUserUC := new(mocks.ChangeStatusUseCaseMock)
+	changeStatusUserUC.On("Execute", s.Foo.ID, false, mocks.UserID).Return(&dto.ChangeStatusOutputDTO{ID: s.Foo.ID}, nil).Once()
+
+	uc := NewApplyDesiredStateUseCase(s.newPlanUseCase(), createEcosystemUC, nil, nil, nil, nil, nil, createDBUserUC, nil,
+		changeStatusUserUC, nil, nil, nil, nil, grantUC, nil)
+	output, err := uc.Execute(s.buildPrunedDesiredState(), true, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, "4 of 4 changes applied successfully", output.Message)
+	assert.Len(t, output.Changes, 4)
+	for _, change := range output.Changes {
+		assert.True(t, *change.Success)
+	}
+	assert.Equal(t, "access granted", output.Changes[2].Message)
+	grantUC.AssertNumberOfCalls(t, "Execute", 1)
+	changeStatusUserUC.AssertNumberOfCalls(t, "Execute", 1)
+}
+
+func TestGivenAnErrorCreatingAUser_WhenExecuteApplyDesiredState_ThenShouldReportTheFailureOfItsGrantsAndApplyTheOtherChanges(t *testing.T) {
+	s := buildStoredState()
+	createEcosystemUC := new(mocks.CreateEcosystemUseCaseMock)
+	createEcosystemUC.On("Execute", mock.Anything, mocks.UserID).Return(&dto.EcosystemOutputDTO{ID: gcpEcosystemID}, nil).Once()
+	createDBUserUC := new(mocks.CreateDatabaseUserUseCaseMock)
+	createDBUserUC.On("Execute", mock.Anything, mocks.UserID).
+		Return((*dto.DatabaseUserOutputDTO)(nil), errors.New("user already exists")).Once()
+	grantUC := new(mocks.GrantAccessPermissionUseCaseMock)
+	changeStatusUserUC := new(mocks.ChangeStatusUseCaseMock)
+	changeStatusUserUC.On("Execute", s.Foo.ID, false, mocks.UserID).Return(&dto.ChangeStatusOutputDTO{ID: s.Foo.ID}, nil).Once()
+
+	uc := NewApplyDesiredStateUseCase(s.newPlanUseCase(), createEcosystemUC, nil, nil, nil, nil, nil, createDBUserUC, nil,
+		changeStatusUserUC, nil, nil, nil, nil, grantUC, nil)
+	output, err := uc.Execute(s.buildPrunedDesiredState(), true, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, "2 of 4 changes applied successfully", output.Message)
+	assert.False(t, *output.Changes[1].Success)
+	assert.Equal(t, "user already exists", output.Changes[1].Message)
+	assert.False(t, *output.Changes[2].Success)
+	assert.Equal(t, "user jane@email.com not found", output.Changes[2].Message)
+	assert.True(t, *output.Changes[3].Success)
+	grantUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGivenAnAccessToADatabaseNotDesired_WhenExecuteApplyDesiredStateWithPrune_ThenShouldRevokeOnlyThatDatabase(t *testing.T) {
+	s := buildStoredState()
+	s.Permissions = append(s.Permissions, &dto.AccessPermissionOutputDTO{
+		DatabaseUserID:       s.John.ID,
+		DatabaseUserEmail:    s.John.Email,
+		DatabaseInstanceID:   s.Instance.ID,
+		DatabaseInstanceName: s.Instance.Name,
+		DatabaseID:           jobsDatabaseID,
+		DatabaseName:         "jobs",
+	})
+	input := dto.DesiredStateInputDTO{
+		Ecosystems: []dto.DesiredEcosystemDTO{{Code: "azure", DisplayName: "Azure"}},
+		Instances:  []dto.DesiredInstanceDTO{s.desiredInstance()},
+		Users:      []dto.DesiredUserDTO{desiredUser(s.John), desiredUser(s.Foo)},
+		Grants: []dto.DesiredGrantDTO{
+			{User: s.John.Email, Instance: s.Instance.Name, Databases: []string{"settings"}},
+			{User: s.Foo.Email, Instance: s.Instance.Name, Databases: []string{"settings"}},
+		},
+	}
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", s.John.ID, s.Instance.ID, []string{jobsDatabaseID}, mocks.UserID).Return(nil).Once()
+
+	uc := NewApplyDesiredStateUseCase(s.newPlanUseCase(), nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, revokeUC)
+	output, err := uc.Execute(input, true, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.DesiredStateChangeDTO{{
+		Action:  ActionRevoke,
+		Kind:    KindAccess,
+		Name:    s.John.Email + " # " + s.Instance.Name,
+		Details: "databases: jobs",
+		Success: output.Changes[0].Success,
+		Message: fmt.Sprintf(DatabasesRevokedMsg, 1),
+	}}, output.Changes)
+	assert.True(t, *output.Changes[0].Success)
+	revokeUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+	revokeUC.AssertNumberOfCalls(t, "RevokeDatabases", 1)
+}
+
+func TestGivenAnInvalidReference_WhenExecuteApplyDesiredState_ThenShouldReturnErrorWithoutApplyingChanges(t *testing.T) {
+	s := buildStoredState()
+	input := s.buildPrunedDesiredState()
+	input.Instances[0].Ecosystem = "aws"
+	createEcosystemUC := new(mocks.CreateEcosystemUseCaseMock)
+
+	uc := NewApplyDesiredStateUseCase(s.newPlanUseCase(), createEcosystemUC, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil)
+	output, err := uc.Execute(input, true, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrInvalidDesiredState)
+	assert.Nil(t, output)
+	createEcosystemUC.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,171 @@
+package desiredstate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	ActionCreate        = "CREATE"
+	ActionUpdate        = "UPDATE"
+	ActionEnable        = "ENABLE"
+	ActionUpdateTargets = "UPDATE_TARGETS"
+	ActionAddMembers    = "ADD_MEMBERS"
+	ActionRemoveMember  = "REMOVE_MEMBER"
+	ActionGrant         = "GRANT"
+	ActionRevoke        = "REVOKE"
+	ActionDisable       = "DISABLE"
+	ActionDelete        = "DELETE"
+)
+
+const (
+	KindEcosystem = "ECOSYSTEM"
+	KindInstance  = "INSTANCE"
+	KindUser      = "USER"
+	KindGroup     = "GROUP"
+	KindAccess    = "ACCESS"
+)
+
+const (
+	NoChangesMsg                  = "no changes, the storages are already in the desired state"
+	ChangesPlannedMsg             = "%d changes planned"
+	ChangesAppliedMsg             = "%d of %d changes applied successfully"
+	DatabaseNotFoundWarnMsg       = "database %s of instance %s not found, sync the databases of the instance and apply again"
+	DatabasesOfNewInstanceWarnMsg = "the databases of instance %s are only known once it is created and its databases are synced, apply again afterward"
+	GroupNotDeletedWarnMsg        = "access group %s isn't in the desired state, but groups can't be deleted: only its members are removed"
+	EcosystemWithInstancesWarnMsg = "ecosystem %s isn't in the desired state, but it can't be deleted while it has instances"
+	AccessAlreadyRevokedMsg       = "access already revoked"
+	DatabasesRevokedMsg           = "access to %d databases revoked"
+	emptyValue                    = "(empty)"
+	allDatabases                  = "*"
+	targetSeparator               = "/"
+	accessSeparator               = " # "
+	defaultBastionPort            = "22"
+	pageSize                      = 500
+	changedSecret                 = "changed"
+)
+
+var ErrInvalidDesiredState = errors.New("invalid desired state")
+
+// currentState godoc
+// The entities stored, indexed by the natural keys used in the desired state
+type currentState struct {
+	EcosystemsByCode      map[string]*dto.EcosystemOutputDTO
+	EcosystemCodesByID    map[string]string
+	Technologies          []*dto.TechnologyOutputDTO
+	InstancesByName       map[string]*dto.DatabaseInstanceOutputDTO
+	DuplicatedInstances   map[string]bool
+	DatabasesByInstanceID map[string]map[string]*dto.DatabaseOutputDTO
+	UsersByEmail          map[string]*dto.DatabaseUserOutputDTO
+	RolesByName           map[string]*entity.DatabaseRole
+	GroupsByName          map[string]*dto.AccessGroupOutputDTO
+	Permissions           []*dto.AccessPermissionOutputDTO
+}
+
+// resolvedIDs godoc
+// IDs of the entities by their natural keys, starting with the stored ones and filled with the ones created while the
+// changes are applied, so a change can reference an entity created by a previous one
+type resolvedIDs struct {
+	Ecosystems map[string]string
+	Instances  map[string]string
+	Users      map[string]string
+	Groups     map[string]string
+}
+
+func (s *currentState) resolvedIDs() *resolvedIDs {
+	ids := &resolvedIDs{
+		Ecosystems: make(map[string]string),
+		Instances:  make(map[string]string),
+		Users:      make(map[string]string),
+		Groups:     make(map[string]string),
+	}
+	for code, ecosystem := range s.EcosystemsByCode {
+		ids.Ecosystems[code] = ecosystem.ID
+	}
+	for name, instance := range s.InstancesByName {
+		ids.Instances[name] = instance.ID
+	}
+	for email, dbUser := range s.UsersByEmail {
+		ids.Users[email] = dbUser.ID
+	}
+	for name, group := range s.GroupsByName {
+		ids.Groups[name] = group.ID
+	}
+	return ids
+}
+
+// plannedChange godoc
+// A change of the plan with the operation that applies it, returning the message of its result
+type plannedChange struct {
+	Change dto.DesiredStateChangeDTO
+	apply  func(a *applier) (string, error)
+}
+
+// resolvedTarget godoc
+// Target of an access group, with the database already resolved. An empty database covers all the databases of the
+// instance.
+type resolvedTarget struct {
+	Instance     string
+	DatabaseName string
+	DatabaseID   string
+}
+
+func (t resolvedTarget) key() string {
+	if t.DatabaseName == "" {
+		return t.Instance + targetSeparator + allDatabases
+	}
+	return t.Instance + targetSeparator + t.DatabaseName
+}
+
+// accessKey godoc
+// Access of a user, by email, to an instance, by name
+type accessKey struct {
+	User     string
+	Instance string
+}
+
+func (k accessKey) String() string {
+	return k.User + accessSeparator + k.Instance
+}
+
+// fieldDiffs godoc
+// Differences between the stored and the desired values of the fields of an entity, e.g. "team: Team A -> Team B"
+type fieldDiffs []string
+
+func (d *fieldDiffs) compare(field, current, desired string) {
+	if current != desired {
+		*d = append(*d, fmt.Sprintf("%s: %s -> %s", field, displayValue(current), displayValue(desired)))
+	}
+}
+
+func (d *fieldDiffs) String() string {
+	return strings.Join(*d, "; ")
+}
+
+func displayValue(value string) string {
+	if value == "" {
+		return emptyValue
+	}
+	return value
+}
+
+// findAllPages godoc
+// Fetches every page of a paginated storage query
+func findAllPages[T any](find func(page, limit int) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		items, err := find(page, pageSize)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < pageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,908 @@
+package desiredstate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+type PlanDesiredStateUseCase struct {
+	EcosystemStorage        storage.EcosystemStorage
+	TechnologyStorage       storage.DatabaseTechnologyStorage
+	DatabaseInstanceStorage storage.DatabaseInstanceStorage
+	DatabaseStorage         storage.DatabaseStorage
+	DatabaseUserStorage     storage.DatabaseUserStorage
+	DatabaseRoleStorage     storage.DatabaseRoleStorage
+	AccessGroupStorage      storage.AccessGroupStorage
+	AccessPermissionStorage storage.AccessPermissionStorage
+}
+
+func NewPlanDesiredStateUseCase(
+	ecosystemStorage storage.EcosystemStorage,
+	technologyStorage storage.DatabaseTechnologyStorage,
+	dbInstanceStorage storage.DatabaseInstanceStorage,
+	databaseStorage storage.DatabaseStorage,
+	dbUserStorage storage.DatabaseUserStorage,
+	roleStorage storage.DatabaseRoleStorage,
+	accessGroupStorage storage.AccessGroupStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+) *PlanDesiredStateUseCase {
+	return &PlanDesiredStateUseCase{
+		EcosystemStorage:        ecosystemStorage,
+		TechnologyStorage:       technologyStorage,
+		DatabaseInstanceStorage: dbInstanceStorage,
+		DatabaseStorage:         databaseStorage,
+		DatabaseUserStorage:     dbUserStorage,
+		DatabaseRoleStorage:     roleStorage,
+		AccessGroupStorage:      accessGroupStorage,
+		AccessPermissionStorage: accessPermissionStorage,
+	}
+}
+
+// Execute godoc
+/** Responsible for comparing the desired state with the stored ecosystems, instances, database users, access groups
+and access permissions, returning the changes needed to reach it, in the order they are applied:
+- ecosystems, instances and users created, updated or enabled;
+- access groups created, with their targets replaced and their members added;
+- access granted to the databases of the grants not permitted yet.
+With prune, anything the desired state doesn't mention is removed as well: members removed from the groups, the access
+to instances and databases not granted revoked, users and instances disabled and ecosystems deleted. The references of the desired
+state must then be declared in it, since the entities not declared are removed.
+Differences that can't be changed are returned as warnings. */
+func (uc *PlanDesiredStateUseCase) Execute(input dto.DesiredStateInputDTO, prune bool) (*dto.DesiredStateOutputDTO, error) {
+	p, err := uc.plan(input, prune)
+	if err != nil {
+		return nil, err
+	}
+	changes := p.changes()
+	message := NoChangesMsg
+	if len(changes) > 0 {
+		message = fmt.Sprintf(ChangesPlannedMsg, len(changes))
+	}
+	log.Printf("Desired state planned with %d changes and %d warnings", len(changes), len(p.Warnings))
+	return &dto.DesiredStateOutputDTO{Message: message, Changes: changes, Warnings: p.Warnings}, nil
+}
+
+func (uc *PlanDesiredStateUseCase) plan(input dto.DesiredStateInputDTO, prune bool) (*planner, error) {
+	current, err := uc.loadCurrentState()
+	if err != nil {
+		return nil, err
+	}
+	p := &planner{Desired: input, Current: current, Prune: prune}
+	for _, step := range []func() error{p.planEcosystems, p.planInstances, p.planUsers, p.planGroups, p.planGrants} {
+		if err = step(); err != nil {
+			return nil, err
+		}
+	}
+	if prune {
+		p.planRevocations()
+		p.planRemovals()
+	}
+	return p, nil
+}
+
+func (uc *PlanDesiredStateUseCase) loadCurrentState() (*currentState, error) {
+	ecosystems, err := findAllPages(uc.EcosystemStorage.FindAll)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the ecosystems. Cause: %w", err)
+	}
+	technologies, err := findAllPages(uc.TechnologyStorage.FindAll)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the technologies. Cause: %w", err)
+	}
+	instances, err := uc.DatabaseInstanceStorage.FindAllDTOs("", "", nil)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while fetching the database instances. Cause: %w", err)
+	}
+	databases, err := uc.DatabaseStorage.FindAllDTOs("", "")
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while fetching the databases. Cause: %w", err)
+	}
+	dbUsers, err := uc.DatabaseUserStorage.FindAllDTOs(nil)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while fetching the database users. Cause: %w", err)
+	}
+	roles, err := uc.DatabaseRoleStorage.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the database roles. Cause: %w", err)
+	}
+	groups, err := uc.loadGroups()
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching the access groups. Cause: %w", err)
+	}
+	permissions, err := uc.AccessPermissionStorage.FindAllDTOs("", "", "")
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("error while fetching the access permissions. Cause: %w", err)
+	}
+
+	current := &currentState{
+		EcosystemsByCode:      make(map[string]*dto.EcosystemOutputDTO, len(ecosystems)),
+		EcosystemCodesByID:    make(map[string]string, len(ecosystems)),
+		Technologies:          technologies,
+		InstancesByName:       make(map[string]*dto.DatabaseInstanceOutputDTO, len(instances)),
+		DuplicatedInstances:   make(map[string]bool),
+		DatabasesByInstanceID: make(map[string]map[string]*dto.DatabaseOutputDTO),
+		UsersByEmail:          make(map[string]*dto.DatabaseUserOutputDTO, len(dbUsers)),
+		RolesByName:           make(map[string]*entity.DatabaseRole, len(roles)),
+		GroupsByName:          make(map[string]*dto.AccessGroupOutputDTO, len(groups)),
+		Permissions:           permissions,
+	}
+	for _, ecosystem := range ecosystems {
+		current.EcosystemsByCode[ecosystem.Code] = ecosystem
+		current.EcosystemCodesByID[ecosystem.ID] = ecosystem.Code
+	}
+	for _, instance := range instances {
+		if _, found := current.InstancesByName[instance.Name]; found {
+			current.DuplicatedInstances[instance.Name] = true
+		}
+		current.InstancesByName[instance.Name] = instance
+	}
+	for _, database := range databases {
+		if current.DatabasesByInstanceID[database.DatabaseInstanceID] == nil {
+			current.DatabasesByInstanceID[database.DatabaseInstanceID] = make(map[string]*dto.DatabaseOutputDTO)
+		}
+		current.DatabasesByInstanceID[database.DatabaseInstanceID][database.Name] = database
+	}
+	for _, dbUser := range dbUsers {
+		current.UsersByEmail[dbUser.Email] = dbUser
+	}
+	for _, role := range roles {
+		current.RolesByName[string(role.Name)] = role
+	}
+	for _, group := range groups {
+		current.GroupsByName[group.Name] = group
+	}
+	return current, nil
+}
+
+func (uc *PlanDesiredStateUseCase) loadGroups() ([]*dto.AccessGroupOutputDTO, error) {
+	groups, err := findAllPages(uc.AccessGroupStorage.FindAllDTOs)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if group.Targets, err = uc.AccessGroupStorage.FindAllTargetDTOs(group.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if group.Members, err = uc.AccessGroupStorage.FindAllMemberDTOs(group.ID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// planner godoc
+// Builds the changes from the stored state to the desired one. The removals of the prune are kept apart, since they
+// are applied after every other change.
+type planner struct {
+	Desired  dto.DesiredStateInputDTO
+	Current  *currentState
+	Prune    bool
+	Changes  []*plannedChange
+	Removals []*plannedChange
+	Warnings []string
+}
+
+func (p *planner) add(action, kind, name, details string, apply func(a *applier) (string, error)) {
+	p.Changes = append(p.Changes, newPlannedChange(action, kind, name, details, apply))
+}
+
+func (p *planner) addRemoval(action, kind, name, details string, apply func(a *applier) (string, error)) {
+	p.Removals = append(p.Removals, newPlannedChange(action, kind, name, details, apply))
+}
+
+func newPlannedChange(action, kind, name, details string, apply func(a *applier) (string, error)) *plannedChange {
+	return &plannedChange{
+		Change: dto.DesiredStateChangeDTO{Action: action, Kind: kind, Name: name, Details: details},
+		apply:  apply,
+	}
+}
+
+func (p *planner) warn(format string, args ...any) {
+	p.Warnings = append(p.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (p *planner) allChanges() []*plannedChange {
+	return append(slices.Clone(p.Changes), p.Removals...)
+}
+
+func (p *planner) changes() []dto.DesiredStateChangeDTO {
+	allChanges := p.allChanges()
+	changes := make([]dto.DesiredStateChangeDTO, 0, len(allChanges))
+	for _, change := range allChanges {
+		changes = append(changes, change.Change)
+	}
+	return changes
+}
+
+func (p *planner) planEcosystems() error {
+	for _, e := range p.Desired.Ecosystems {
+		input := dto.EcosystemInputDTO{Code: e.Code, DisplayName: e.DisplayName, RequiresAccessApproval: e.RequiresAccessApproval}
+		current := p.Current.EcosystemsByCode[e.Code]
+		if current == nil {
+			p.add(ActionCreate, KindEcosystem, e.Code, "", func(a *applier) (string, error) {
+				output, err := a.UseCase.CreateEcosystemUseCase.Execute(input, a.OperationUserID)
+				if err != nil {
+					return "", err
+				}
+				a.IDs.Ecosystems[e.Code] = output.ID
+				return "ecosystem created", nil
+			})
+			continue
+		}
+
+		var diffs fieldDiffs
+		diffs.compare("displayName", current.DisplayName, e.DisplayName)
+		diffs.compare("requiresAccessApproval", strconv.FormatBool(current.RequiresAccessApproval), strconv.FormatBool(e.RequiresAccessApproval))
+		if len(diffs) > 0 {
+			p.add(ActionUpdate, KindEcosystem, e.Code, diffs.String(), func(a *applier) (string, error) {
+				_, err := a.UseCase.UpdateEcosystemUseCase.Execute(input, current.ID, a.OperationUserID)
+				return "ecosystem updated", err
+			})
+		}
+	}
+	return nil
+}
+
+func (p *planner) planInstances() error {
+	for _, i := range p.Desired.Instances {
+		if !p.knownEcosystem(i.Ecosystem) {
+			return fmt.Errorf("%w: ecosystem %s of instance %s not found", ErrInvalidDesiredState, i.Ecosystem, i.Name)
+		}
+		technology := p.findTechnology(i.Technology, i.TechnologyVersion)
+		if technology == nil {
+			return fmt.Errorf("%w: technology %s %s of instance %s not found", ErrInvalidDesiredState, i.Technology, i.TechnologyVersion, i.Name)
+		}
+		current, err := p.currentInstance(i.Name)
+		if err != nil {
+			return err
+		}
+		input := toInstanceInput(i, technology.ID)
+		if current == nil {
+			if i.AdminUser == "" || i.AdminPassword == "" {
+				return fmt.Errorf("%w: the admin user and password are required to create the instance %s", ErrInvalidDesiredState, i.Name)
+			}
+			details := fmt.Sprintf("ecosystem: %s; technology: %s %s", i.Ecosystem, technology.Name, technology.Version)
+			p.add(ActionCreate, KindInstance, i.Name, details, func(a *applier) (string, error) {
+				var err error
+				if input.EcosystemID, err = a.id(a.IDs.Ecosystems, KindEcosystem, i.Ecosystem); err != nil {
+					return "", err
+				}
+				output, err := a.UseCase.CreateDatabaseInstanceUseCase.Execute(input, a.OperationUserID)
+				if err != nil {
+					return "", err
+				}
+				a.IDs.Instances[i.Name] = output.ID
+				return "database instance created", nil
+			})
+			continue
+		}
+
+		if diffs := p.instanceDiffs(current, i, input); len(diffs) > 0 {
+			p.add(ActionUpdate, KindInstance, i.Name, diffs.String(), func(a *applier) (string, error) {
+				var err error
+				if input.EcosystemID, err = a.id(a.IDs.Ecosystems, KindEcosystem, i.Ecosystem); err != nil {
+					return "", err
+				}
+				_, err = a.UseCase.UpdateDatabaseInstanceUseCase.Execute(input, current.ID, a.OperationUserID)
+				return "database instance updated", err
+			})
+		}
+		if !current.Enabled {
+			p.add(ActionEnable, KindInstance, i.Name, "", func(a *applier) (string, error) {
+				_, err := a.UseCase.ChangeStatusInstanceUseCase.Execute(current.ID, true, a.OperationUserID)
+				return "database instance enabled", err
+			})
+		}
+	}
+	return nil
+}
+
+func toInstanceInput(i dto.DesiredInstanceDTO, technologyID string) dto.DatabaseInstanceInputDTO {
+	input := dto.DatabaseInstanceInputDTO{
+		Name:                 i.Name,
+		Host:                 i.Host,
+		Port:                 i.Port,
+		HostConnection:       i.HostConnection,
+		PortConnection:       i.PortConnection,
+		AdminUser:            i.AdminUser,
+		AdminPassword:        i.AdminPassword,
+		SSLMode:              i.SSLMode,
+		BastionHost:          i.BastionHost,
+		BastionPort:          i.BastionPort,
+		BastionUser:          i.BastionUser,
+		BastionHostKey:       i.BastionHostKey,
+		DatabaseTechnologyID: technologyID,
+		Note:                 i.Note,
+	}
+	if input.HostConnection == "" {
+		input.HostConnection = i.Host
+	}
+	if input.PortConnection == "" {
+		input.PortConnection = i.Port
+	}
+	if input.BastionHost != "" && input.BastionPort == "" {
+		input.BastionPort = defaultBastionPort
+	}
+	return input
+}
+
+// instanceDiffs godoc
+// Compares the fields of the instance managed by the desired state. The admin user, the admin password and the SSL
+// mode are only compared when informed, since the current ones are kept otherwise.
+func (p *planner) instanceDiffs(current *dto.DatabaseInstanceOutputDTO, i dto.DesiredInstanceDTO, input dto.DatabaseInstanceInputDTO) fieldDiffs {
+	var diffs fieldDiffs
+	diffs.compare("ecosystem", p.Current.EcosystemCodesByID[current.EcosystemID], i.Ecosystem)
+	if current.DatabaseTechnologyID != input.DatabaseTechnologyID {
+		diffs.compare("technology", current.DatabaseTechnologyName+" "+current.DatabaseTechnologyVersion, i.Technology+" "+i.TechnologyVersion)
+	}
+	diffs.compare("host", current.Host, input.Host)
+	diffs.compare("port", current.Port, input.Port)
+	diffs.compare("hostConnection", current.HostConnection, input.HostConnection)
+	diffs.compare("portConnection", current.PortConnection, input.PortConnection)
+	if input.AdminUser != "" {
+		diffs.compare("adminUser", current.AdminUser, input.AdminUser)
+	}
+	if input.AdminPassword != "" && !sameSecret(current.AdminPassword, input.AdminPassword) {
+		diffs = append(diffs, "adminPassword: "+changedSecret)
+	}
+	if input.SSLMode != "" {
+		diffs.compare("sslMode", current.SSLMode, input.SSLMode)
+	}
+	diffs.compare("bastionHost", current.BastionHost, input.BastionHost)
+	diffs.compare("bastionPort", current.BastionPort, input.BastionPort)
+	diffs.compare("bastionUser", current.BastionUser, input.BastionUser)
+	diffs.compare("bastionHostKey", current.BastionHostKey, input.BastionHostKey)
+	diffs.compare("note", current.Note, input.Note)
+	return diffs
+}
+
+func sameSecret(cipherHex, plainText string) bool {
+	decrypted, err := config.GetCryptoHelper().Decrypt(cipherHex)
+	return err == nil && decrypted == plainText
+}
+
+func (p *planner) planUsers() error {
+	for _, u := range p.Desired.Users {
+		role := p.Current.RolesByName[u.Role]
+		if role == nil {
+			return fmt.Errorf("%w: role %s of user %s not found", ErrInvalidDesiredState, u.Role, u.Email)
+		}
+		current := p.Current.UsersByEmail[u.Email]
+		if current == nil {
+			input := dto.DatabaseUserInputDTO{Name: u.Name, Email: u.Email, Team: u.Team, Position: u.Position, DatabaseRoleID: role.ID.String()}
+			p.add(ActionCreate, KindUser, u.Email, "role: "+u.Role, func(a *applier) (string, error) {
+				output, err := a.UseCase.CreateDatabaseUserUseCase.Execute(input, a.OperationUserID)
+				if err != nil {
+					return "", err
+				}
+				a.IDs.Users[u.Email] = output.ID
+				return "database user created", nil
+			})
+			continue
+		}
+
+		var diffs fieldDiffs
+		diffs.compare("name", current.Name, u.Name)
+		diffs.compare("team", current.Team, u.Team)
+		diffs.compare("position", current.Position, u.Position)
+		diffs.compare("role", current.DatabaseRoleName, u.Role)
+		if len(diffs) > 0 {
+			input := dto.UpdateDatabaseUserInputDTO{Name: u.Name, DatabaseRoleID: role.ID.String(), Team: u.Team, Position: u.Position}
+			p.add(ActionUpdate, KindUser, u.Email, diffs.String(), func(a *applier) (string, error) {
+				_, err := a.UseCase.UpdateDatabaseUserUseCase.Execute(input, current.ID, a.OperationUserID)
+				return "database user updated", err
+			})
+		}
+		if !current.Enabled {
+			p.add(ActionEnable, KindUser, u.Email, "", func(a *applier) (string, error) {
+				_, err := a.UseCase.ChangeStatusUserUseCase.Execute(current.ID, true, a.OperationUserID)
+				return "database user enabled", err
+			})
+		}
+	}
+	return nil
+}
+
+func (p *planner) planGroups() error {
+	for _, g := range p.Desired.Groups {
+		targets, err := p.resolveTargets(g)
+		if err != nil {
+			return err
+		}
+		for _, member := range g.Members {
+			if !p.knownUser(member) {
+				return fmt.Errorf("%w: member %s of access group %s not found", ErrInvalidDesiredState, member, g.Name)
+			}
+		}
+
+		current := p.Current.GroupsByName[g.Name]
+		if current == nil {
+			p.add(ActionCreate, KindGroup, g.Name, describeTargets(targets), func(a *applier) (string, error) {
+				instancesData, err := a.instancesData(targets)
+				if err != nil {
+					return "", err
+				}
+				input := dto.AccessGroupInputDTO{Name: g.Name, Description: g.Description, InstancesData: instancesData}
+				output, err := a.UseCase.CreateAccessGroupUseCase.Execute(input, a.OperationUserID)
+				if err != nil {
+					return "", err
+				}
+				a.IDs.Groups[g.Name] = output.ID
+				return "access group created", nil
+			})
+			p.planAddMembers(g.Name, g.Members)
+			continue
+		}
+
+		p.planTargetsUpdate(current, targets)
+		var missingMembers []string
+		for _, member := range g.Members {
+			if !slices.ContainsFunc(current.Members, func(m dto.AccessGroupMemberOutputDTO) bool { return m.DatabaseUserEmail == member }) {
+				missingMembers = append(missingMembers, member)
+			}
+		}
+		p.planAddMembers(g.Name, missingMembers)
+		if p.Prune {
+			for _, member := range current.Members {
+				if !slices.Contains(g.Members, member.DatabaseUserEmail) {
+					p.planRemoveMember(current, member)
+				}
+			}
+		}
+	}
+
+	if p.Prune {
+		for _, group := range sortedValues(p.Current.GroupsByName) {
+			if slices.ContainsFunc(p.Desired.Groups, func(g dto.DesiredGroupDTO) bool { return g.Name == group.Name }) {
+				continue
+			}
+			p.warn(GroupNotDeletedWarnMsg, group.Name)
+			for _, member := range group.Members {
+				p.planRemoveMember(group, member)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveTargets godoc
+// Resolves the databases of the targets of the group. Databases not found are left out with a warning, as the ones of
+// instances not created yet, whose databases are only known after they are synced.
+func (p *planner) resolveTargets(g dto.DesiredGroupDTO) ([]resolvedTarget, error) {
+	var targets []resolvedTarget
+	for _, t := range g.Targets {
+		if !p.knownInstance(t.Instance) {
+			return nil, fmt.Errorf("%w: instance %s of a target of access group %s not found", ErrInvalidDesiredState, t.Instance, g.Name)
+		}
+		if len(t.Databases) == 0 {
+			targets = append(targets, resolvedTarget{Instance: t.Instance})
+			continue
+		}
+		databases, err := p.resolveDatabases(t.Instance, t.Databases)
+		if err != nil {
+			return nil, err
+		}
+		for _, database := range databases {
+			targets = append(targets, resolvedTarget{Instance: t.Instance, DatabaseName: database.Name, DatabaseID: database.ID})
+		}
+	}
+	return targets, nil
+}
+
+// resolveDatabases godoc
+// Finds the stored databases of the instance by name, warning about the ones not found
+func (p *planner) resolveDatabases(instanceName string, names []string) ([]*dto.DatabaseOutputDTO, error) {
+	instance, err := p.currentInstance(instanceName)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		p.warn(DatabasesOfNewInstanceWarnMsg, instanceName)
+		return nil, nil
+	}
+	var databases []*dto.DatabaseOutputDTO
+	for _, name := range names {
+		database := p.Current.DatabasesByInstanceID[instance.ID][name]
+		if database == nil {
+			p.warn(DatabaseNotFoundWarnMsg, name, instanceName)
+			continue
+		}
+		databases = append(databases, database)
+	}
+	return databases, nil
+}
+
+func describeTargets(targets []resolvedTarget) string {
+	keys := make([]string, 0, len(targets))
+	for _, t := range targets {
+		keys = append(keys, t.key())
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return "targets: " + strings.Join(keys, ", ")
+}
+
+func (p *planner) planTargetsUpdate(current *dto.AccessGroupOutputDTO, targets []resolvedTarget) {
+	currentKeys := make(map[string]bool, len(current.Targets))
+	for _, t := range current.Targets {
+		currentKeys[currentTargetKey(t)] = true
+	}
+	desiredKeys := make(map[string]bool, len(targets))
+	var diffs []string
+	for _, t := range targets {
+		desiredKeys[t.key()] = true
+		if !currentKeys[t.key()] {
+			diffs = append(diffs, "+"+t.key())
+		}
+	}
+	for _, t := range current.Targets {
+		if !desiredKeys[currentTargetKey(t)] {
+			diffs = append(diffs, "-"+currentTargetKey(t))
+		}
+	}
+	if len(diffs) == 0 {
+		return
+	}
+	p.add(ActionUpdateTargets, KindGroup, current.Name, strings.Join(diffs, ", "), func(a *applier) (string, error) {
+		instancesData, err := a.instancesData(targets)
+		if err != nil {
+			return "", err
+		}
+		output, err := a.UseCase.UpdateAccessGroupTargetsUseCase.Execute(current.ID, dto.AccessGroupTargetsInputDTO{InstancesData: instancesData}, a.OperationUserID)
+		return groupChangeResult(output, err)
+	})
+}
+
+func currentTargetKey(t dto.AccessGroupTargetOutputDTO) string {
+	if t.DatabaseName == nil {
+		return resolvedTarget{Instance: t.DatabaseInstanceName}.key()
+	}
+	return resolvedTarget{Instance: t.DatabaseInstanceName, DatabaseName: *t.DatabaseName}.key()
+}
+
+func (p *planner) planAddMembers(groupName string, members []string) {
+	if len(members) == 0 {
+		return
+	}
+	p.add(ActionAddMembers, KindGroup, groupName, "members: "+strings.Join(members, ", "), func(a *applier) (string, error) {
+		groupID, err := a.id(a.IDs.Groups, KindGroup, groupName)
+		if err != nil {
+			return "", err
+		}
+		input := dto.AccessGroupMembersInputDTO{}
+		for _, member := range members {
+			memberID, err := a.id(a.IDs.Users, KindUser, member)
+			if err != nil {
+				return "", err
+			}
+			input.DatabaseUsersIDs = append(input.DatabaseUsersIDs, memberID)
+		}
+		output, err := a.UseCase.AddAccessGroupMembersUseCase.Execute(groupID, input, a.OperationUserID)
+		return groupChangeResult(output, err)
+	})
+}
+
+func (p *planner) planRemoveMember(group *dto.AccessGroupOutputDTO, member dto.AccessGroupMemberOutputDTO) {
+	p.add(ActionRemoveMember, KindGroup, group.Name, "member: "+member.DatabaseUserEmail, func(a *applier) (string, error) {
+		output, err := a.UseCase.RemoveAccessGroupMemberUseCase.Execute(group.ID, member.DatabaseUserID, a.OperationUserID)
+		return groupChangeResult(output, err)
+	})
+}
+
+func groupChangeResult(output *dto.AccessGroupChangeOutputDTO, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if output.HasErrors {
+		return "", errors.New(output.Message)
+	}
+	return output.Message, nil
+}
+
+// planGrants godoc
+// Grants the databases of the grants not permitted yet to the user, whether directly or through a group. The grants
+// of the same user and instance are merged into a single change.
+func (p *planner) planGrants() error {
+	var keys []accessKey
+	databasesByKey := make(map[accessKey][]string)
+	for _, g := range p.Desired.Grants {
+		if !p.knownUser(g.User) {
+			return fmt.Errorf("%w: user %s of a grant not found", ErrInvalidDesiredState, g.User)
+		}
+		if !p.knownInstance(g.Instance) {
+			return fmt.Errorf("%w: instance %s of a grant not found", ErrInvalidDesiredState, g.Instance)
+		}
+		key := accessKey{User: g.User, Instance: g.Instance}
+		if _, found := databasesByKey[key]; !found {
+			keys = append(keys, key)
+		}
+		for _, database := range g.Databases {
+			if !slices.Contains(databasesByKey[key], database) {
+				databasesByKey[key] = append(databasesByKey[key], database)
+			}
+		}
+	}
+
+	permitted := p.permittedDatabases()
+	for _, key := range keys {
+		databases, err := p.resolveDatabases(key.Instance, databasesByKey[key])
+		if err != nil {
+			return err
+		}
+		var names, ids []string
+		for _, database := range databases {
+			if permitted[key][database.Name] {
+				continue
+			}
+			names = append(names, database.Name)
+			ids = append(ids, database.ID)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		instanceID := p.Current.InstancesByName[key.Instance].ID
+		p.add(ActionGrant, KindAccess, key.String(), "databases: "+strings.Join(names, ", "), func(a *applier) (string, error) {
+			dbUserID, err := a.id(a.IDs.Users, KindUser, key.User)
+			if err != nil {
+				return "", err
+			}
+			input := dto.GrantAccessInputDTO{
+				DatabaseUsersIDs: []string{dbUserID},
+				InstancesData:    []dto.InstanceDataDTO{{DatabaseInstanceID: instanceID, DatabasesIDs: ids}},
+			}
+			output, err := a.UseCase.GrantUseCase.Execute(input, a.OperationUserID)
+			if err != nil {
+				return "", err
+			}
+			if output.HasErrors {
+				return "", errors.New(output.Message)
+			}
+			return output.Message, nil
+		})
+	}
+	return nil
+}
+
+// permittedDatabases godoc
+// Names of the databases each user, by email, has access to in each instance, by name
+func (p *planner) permittedDatabases() map[accessKey]map[string]bool {
+	permitted := make(map[accessKey]map[string]bool)
+	for _, permission := range p.Current.Permissions {
+		key := accessKey{User: permission.DatabaseUserEmail, Instance: permission.DatabaseInstanceName}
+		if permitted[key] == nil {
+			permitted[key] = make(map[string]bool)
+		}
+		permitted[key][permission.DatabaseName] = true
+	}
+	return permitted
+}
+
+// desiredCoverage godoc
+// Databases of each instance the desired state gives to each user, directly or through its groups. A nil set of
+// databases covers all the databases of the instance.
+func (p *planner) desiredCoverage() map[accessKey]map[string]bool {
+	coverage := make(map[accessKey]map[string]bool)
+	cover := func(key accessKey, database string) {
+		databases, found := coverage[key]
+		if found && databases == nil {
+			return
+		}
+		if database == "" {
+			coverage[key] = nil
+			return
+		}
+		if databases == nil {
+			databases = make(map[string]bool)
+			coverage[key] = databases
+		}
+		databases[database] = true
+	}
+	for _, g := range p.Desired.Grants {
+		for _, database := range g.Databases {
+			cover(accessKey{User: g.User, Instance: g.Instance}, database)
+		}
+	}
+	for _, g := range p.Desired.Groups {
+		for _, member := range g.Members {
+			for _, t := range g.Targets {
+				if len(t.Databases) == 0 {
+					cover(accessKey{User: member, Instance: t.Instance}, "")
+				}
+				for _, database := range t.Databases {
+					cover(accessKey{User: member, Instance: t.Instance}, database)
+				}
+			}
+		}
+	}
+	return coverage
+}
+
+// planRevocations godoc
+// Revokes the access of the users to the instances the desired state doesn't give them, and to the databases it
+// doesn't give them when it gives them only some databases of the instance. The access of the users and to the
+// instances removed by the prune is revoked when they are disabled.
+func (p *planner) planRevocations() {
+	coverage := p.desiredCoverage()
+	var keys []accessKey
+	permissionsByKey := make(map[accessKey][]*dto.AccessPermissionOutputDTO)
+	for _, permission := range p.Current.Permissions {
+		key := accessKey{User: permission.DatabaseUserEmail, Instance: permission.DatabaseInstanceName}
+		if !p.desiredUser(key.User) || !p.desiredInstance(key.Instance) {
+			continue
+		}
+		if _, found := permissionsByKey[key]; !found {
+			keys = append(keys, key)
+		}
+		permissionsByKey[key] = append(permissionsByKey[key], permission)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	for _, key := range keys {
+		permissions := permissionsByKey[key]
+		databases, covered := coverage[key]
+		if !covered {
+			p.planRevoke(key, permissions[0].DatabaseUserID, permissions[0].DatabaseInstanceID)
+			continue
+		}
+		if databases == nil {
+			continue
+		}
+		var extraDatabases []*dto.AccessPermissionOutputDTO
+		for _, permission := range permissions {
+			if !databases[permission.DatabaseName] {
+				extraDatabases = append(extraDatabases, permission)
+			}
+		}
+		if len(extraDatabases) > 0 {
+			p.planRevokeDatabases(key, extraDatabases)
+		}
+	}
+}
+
+// planRevokeDatabases godoc
+// Revokes the access of the user to the databases of the permissions only, keeping its access to the other databases
+// of the instance
+func (p *planner) planRevokeDatabases(key accessKey, permissions []*dto.AccessPermissionOutputDTO) {
+	var names, databasesIDs []string
+	for _, permission := range permissions {
+		names = append(names, permission.DatabaseName)
+		databasesIDs = append(databasesIDs, permission.DatabaseID)
+	}
+	sort.Strings(names)
+	dbUserID, instanceID := permissions[0].DatabaseUserID, permissions[0].DatabaseInstanceID
+	p.add(ActionRevoke, KindAccess, key.String(), "databases: "+strings.Join(names, ", "), func(a *applier) (string, error) {
+		err := a.UseCase.RevokeUseCase.RevokeDatabases(dbUserID, instanceID, databasesIDs, a.OperationUserID)
+		if errors.Is(err, common.ErrNoAccessibleInstancesFound) {
+			// Already revoked by the update of the targets of a group or the removal of the user from a group
+			return AccessAlreadyRevokedMsg, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(DatabasesRevokedMsg, len(databasesIDs)), nil
+	})
+}
+
+func (p *planner) planRevoke(key accessKey, dbUserID, instanceID string) {
+	p.add(ActionRevoke, KindAccess, key.String(), "", func(a *applier) (string, error) {
+		input := dto.RevokeAccessInputDTO{DatabaseUserID: dbUserID, DatabaseInstancesIDs: []string{instanceID}}
+		output, err := a.UseCase.RevokeUseCase.Execute(input, a.OperationUserID)
+		if errors.Is(err, common.ErrNoAccessibleInstancesFound) {
+			// Already revoked by the removal of the user from a group
+			return AccessAlreadyRevokedMsg, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if output.HasErrors {
+			return "", errors.New(output.Message)
+		}
+		return output.Message, nil
+	})
+}
+
+// planRemovals godoc
+// Disables the users and the instances the desired state doesn't mention, revoking their access, and deletes the
+// ecosystems without instances
+func (p *planner) planRemovals() {
+	for _, dbUser := range sortedValues(p.Current.UsersByEmail) {
+		if dbUser.Enabled && !p.desiredUser(dbUser.Email) {
+			p.addRemoval(ActionDisable, KindUser, dbUser.Email, "", func(a *applier) (string, error) {
+				_, err := a.UseCase.ChangeStatusUserUseCase.Execute(dbUser.ID, false, a.OperationUserID)
+				return "database user disabled", err
+			})
+		}
+	}
+	ecosystemsWithInstances := make(map[string]bool)
+	for _, instance := range sortedValues(p.Current.InstancesByName) {
+		ecosystemsWithInstances[instance.EcosystemID] = true
+		if instance.Enabled && !p.desiredInstance(instance.Name) {
+			p.addRemoval(ActionDisable, KindInstance, instance.Name, "", func(a *applier) (string, error) {
+				_, err := a.UseCase.ChangeStatusInstanceUseCase.Execute(instance.ID, false, a.OperationUserID)
+				return "database instance disabled", err
+			})
+		}
+	}
+	for _, ecosystem := range sortedValues(p.Current.EcosystemsByCode) {
+		if slices.ContainsFunc(p.Desired.Ecosystems, func(e dto.DesiredEcosystemDTO) bool { return e.Code == ecosystem.Code }) {
+			continue
+		}
+		if ecosystemsWithInstances[ecosystem.ID] {
+			p.warn(EcosystemWithInstancesWarnMsg, ecosystem.Code)
+			continue
+		}
+		p.addRemoval(ActionDelete, KindEcosystem, ecosystem.Code, "", func(a *applier) (string, error) {
+			return "ecosystem deleted", a.UseCase.DeleteEcosystemUseCase.Execute(ecosystem.ID, a.OperationUserID)
+		})
+	}
+}
+
+func (p *planner) desiredUser(email string) bool {
+	return slices.ContainsFunc(p.Desired.Users, func(u dto.DesiredUserDTO) bool { return u.Email == email })
+}
+
+func (p *planner) desiredInstance(name string) bool {
+	return slices.ContainsFunc(p.Desired.Instances, func(i dto.DesiredInstanceDTO) bool { return i.Name == name })
+}
+
+// knownUser godoc
+// Checks that a user referenced by the desired state is declared in it or, without prune, is stored
+func (p *planner) knownUser(email string) bool {
+	return p.desiredUser(email) || (!p.Prune && p.Current.UsersByEmail[email] != nil)
+}
+
+func (p *planner) knownInstance(name string) bool {
+	return p.desiredInstance(name) || (!p.Prune && p.Current.InstancesByName[name] != nil)
+}
+
+func (p *planner) knownEcosystem(code string) bool {
+	declared := slices.ContainsFunc(p.Desired.Ecosystems, func(e dto.DesiredEcosystemDTO) bool { return e.Code == code })
+	return declared || (!p.Prune && p.Current.EcosystemsByCode[code] != nil)
+}
+
+// currentInstance godoc
+// Returns the stored instance with the name, or nil when there is none. Instances are only identified by name in the
+// desired state, so an error is returned when more than one has the name.
+func (p *planner) currentInstance(name string) (*dto.DatabaseInstanceOutputDTO, error) {
+	if p.Current.DuplicatedInstances[name] {
+		return nil, fmt.Errorf("%w: there is more than one instance named %s", ErrInvalidDesiredState, name)
+	}
+	return p.Current.InstancesByName[name], nil
+}
+
+func (p *planner) findTechnology(name, version string) *dto.TechnologyOutputDTO {
+	for _, technology := range p.Current.Technologies {
+		if strings.EqualFold(technology.Name, name) && technology.Version == version {
+			return technology
+		}
+	}
+	return nil
+}
+
+// sortedValues godoc
+// Returns the values of the map sorted by key, so the changes are always planned in the same order
+func sortedValues[T any](m map[string]T) []T {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]T, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, m[key])
+	}
+	return values
+}
@@ -0,0 +1,244 @@
+package desiredstate
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const (
+	settingsDatabaseID = "0b6b1f7e-3c1a-4b8e-9f1d-2a7c5e4d3b21"
+	jobsDatabaseID     = "5d2e8a4c-7b3f-4e1a-8c9d-1f6b2a3e4c57"
+	janeEmail          = "jane@email.com"
+)
+
+// storedState godoc
+// The state of the storages of the tests: the Azure ecosystem with its instance and the databases settings and jobs,
+// John and Foo with access to settings and the access groups informed
+type storedState struct {
+	Instance    *dto.DatabaseInstanceOutputDTO
+	John        *dto.DatabaseUserOutputDTO
+	Foo         *dto.DatabaseUserOutputDTO
+	Groups      []*dto.AccessGroupOutputDTO
+	Permissions []*dto.AccessPermissionOutputDTO
+}
+
+func buildStoredState() *storedState {
+	instance := mocks.BuildAzInstanceDTO()
+	instance.EcosystemID = mocks.EcosystemId
+	instance.DatabaseTechnologyID = mocks.TechnologyId
+	instance.Host = "10.1.1.1"
+	instance.Port = "5432"
+	s := &storedState{Instance: instance, John: mocks.BuildDbUserJohnDTO(), Foo: mocks.BuildDbUserFooDTO()}
+	for _, dbUser := range []*dto.DatabaseUserOutputDTO{s.John, s.Foo} {
+		s.Permissions = append(s.Permissions, &dto.AccessPermissionOutputDTO{
+			DatabaseUserID:       dbUser.ID,
+			DatabaseUserEmail:    dbUser.Email,
+			DatabaseInstanceID:   instance.ID,
+			DatabaseInstanceName: instance.Name,
+			DatabaseID:           settingsDatabaseID,
+			DatabaseName:         "settings",
+		})
+	}
+	return s
+}
+
+func (s *storedState) newPlanUseCase() *PlanDesiredStateUseCase {
+	ecosystemStorage := new(mocks.EcosystemStorageMock)
+	ecosystemStorage.On("FindAll", 1, pageSize).
+		Return([]*dto.EcosystemOutputDTO{{ID: mocks.EcosystemId, Code: "azure", DisplayName: "Azure"}}, nil)
+	technologyStorage := new(mocks.TechnologyStorageMock)
+	technologyStorage.On("FindAll", 1, pageSize).
+		Return([]*dto.TechnologyOutputDTO{{ID: mocks.TechnologyId, Name: s.Instance.DatabaseTechnologyName, Version: "2"}}, nil)
+	dbInstanceStorage := new(mocks.DatabaseInstanceStorageMock)
+	dbInstanceStorage.On("FindAllDTOs", "", "", []string(nil)).Return([]*dto.DatabaseInstanceOutputDTO{s.Instance}, nil)
+	databaseStorage := new(mocks.DatabaseStorageMock)
+	databaseStorage.On("FindAllDTOs", "", "").Return([]*dto.DatabaseOutputDTO{
+		{ID: settingsDatabaseID, Name: "settings", DatabaseInstanceID: s.Instance.ID},
+		{ID: jobsDatabaseID, Name: "jobs", DatabaseInstanceID: s.Instance.ID},
+	}, nil)
+	dbUserStorage := new(mocks.DatabaseUserStorageMock)
+	dbUserStorage.On("FindAllDTOs", []string(nil)).Return([]*dto.DatabaseUserOutputDTO{s.John, s.Foo}, nil)
+	roleStorage := new(mocks.DatabaseRoleStorageMock)
+	roles := append(mocks.BuildRolesList(), &entity.DatabaseRole{ID: uuid.MustParse(s.John.DatabaseRoleID), Name: "devops"})
+	roleStorage.On("FindAll").Return(roles, nil)
+	accessGroupStorage := new(mocks.AccessGroupStorageMock)
+	accessGroupStorage.On("FindAllDTOs", 1, pageSize).Return(s.Groups, nil)
+	for _, group := range s.Groups {
+		accessGroupStorage.On("FindAllTargetDTOs", group.ID).Return(group.Targets, nil)
+		accessGroupStorage.On("FindAllMemberDTOs", group.ID).Return(group.Members, nil)
+	}
+	accessPermissionStorage := new(mocks.AccessPermissionStorageMock)
+	accessPermissionStorage.On("FindAllDTOs", "", "", "").Return(s.Permissions, nil)
+
+	return NewPlanDesiredStateUseCase(ecosystemStorage, technologyStorage, dbInstanceStorage, databaseStorage,
+		dbUserStorage, roleStorage, accessGroupStorage, accessPermissionStorage)
+}
+
+func (s *storedState) desiredInstance() dto.DesiredInstanceDTO {
+	return dto.DesiredInstanceDTO{
+		Name:              s.Instance.Name,
+		Ecosystem:         "azure",
+		Technology:        s.Instance.DatabaseTechnologyName,
+		TechnologyVersion: "2",
+		Host:              "10.1.1.1",
+		Port:              "5432",
+	}
+}
+
+func desiredUser(dbUser *dto.DatabaseUserOutputDTO) dto.DesiredUserDTO {
+	return dto.DesiredUserDTO{Email: dbUser.Email, Name: dbUser.Name, Team: dbUser.Team, Position: dbUser.Position, Role: dbUser.DatabaseRoleName}
+}
+
+// buildPrunedDesiredState godoc
+// Keeps the Azure ecosystem, its instance and John, creates the GCP ecosystem and Jane with access to both databases
+// and leaves Foo out
+func (s *storedState) buildPrunedDesiredState() dto.DesiredStateInputDTO {
+	return dto.DesiredStateInputDTO{
+		Ecosystems: []dto.DesiredEcosystemDTO{{Code: "azure", DisplayName: "Azure"}, {Code: "gcp", DisplayName: "GCP"}},
+		Instances:  []dto.DesiredInstanceDTO{s.desiredInstance()},
+		Users: []dto.DesiredUserDTO{
+			desiredUser(s.John),
+			{Email: janeEmail, Name: "Jane Doe", Team: "Team A", Role: "developer"},
+		},
+		Grants: []dto.DesiredGrantDTO{
+			{User: janeEmail, Instance: s.Instance.Name, Databases: []string{"settings"}},
+			{User: janeEmail, Instance: s.Instance.Name, Databases: []string{"jobs"}},
+			{User: s.John.Email, Instance: s.Instance.Name, Databases: []string{"settings"}},
+		},
+	}
+}
+
+func TestGivenAnErrorInDbWhileFetchingEcosystems_WhenExecutePlanDesiredState_ThenShouldReturnError(t *testing.T) {
+	ecosystemStorage := new(mocks.EcosystemStorageMock)
+	ecosystemStorage.On("FindAll", 1, pageSize).Return([]*dto.EcosystemOutputDTO{}, sql.ErrConnDone).Once()
+
+	uc := NewPlanDesiredStateUseCase(ecosystemStorage, nil, nil, nil, nil, nil, nil, nil)
+	output, err := uc.Execute(dto.DesiredStateInputDTO{}, false)
+
+	assert.EqualError(t, err, "error while fetching the ecosystems. Cause: "+sql.ErrConnDone.Error())
+	assert.Nil(t, output)
+}
+
+func TestGivenADesiredStateWithNewEntities_WhenExecutePlanDesiredStateWithPrune_ThenShouldPlanCreationsGrantsAndRemovals(t *testing.T) {
+	s := buildStoredState()
+
+	output, err := s.newPlanUseCase().Execute(s.buildPrunedDesiredState(), true)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, "4 changes planned", output.Message)
+	assert.Equal(t, []dto.DesiredStateChangeDTO{
+		{Action: ActionCreate, Kind: KindEcosystem, Name: "gcp"},
+		{Action: ActionCreate, Kind: KindUser, Name: janeEmail, Details: "role: developer"},
+		{Action: ActionGrant, Kind: KindAccess, Name: janeEmail + " # " + s.Instance.Name, Details: "databases: settings, jobs"},
+		{Action: ActionDisable, Kind: KindUser, Name: s.Foo.Email},
+	}, output.Changes)
+	assert.Empty(t, output.Warnings)
+}
+
+func TestGivenADesiredStateWithChangedEntities_WhenExecutePlanDesiredState_ThenShouldPlanUpdatesAndWarnAboutUnknownDatabases(t *testing.T) {
+	s := buildStoredState()
+	s.Instance.Enabled = false
+	john := desiredUser(s.John)
+	john.Team = "Team C"
+	input := dto.DesiredStateInputDTO{
+		Instances: []dto.DesiredInstanceDTO{s.desiredInstance()},
+		Users:     []dto.DesiredUserDTO{john},
+		Grants:    []dto.DesiredGrantDTO{{User: s.John.Email, Instance: s.Instance.Name, Databases: []string{"settings", "reports"}}},
+	}
+
+	output, err := s.newPlanUseCase().Execute(input, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2 changes planned", output.Message)
+	assert.Equal(t, []dto.DesiredStateChangeDTO{
+		{Action: ActionEnable, Kind: KindInstance, Name: s.Instance.Name},
+		{Action: ActionUpdate, Kind: KindUser, Name: s.John.Email, Details: "team: Team B -> Team C"},
+	}, output.Changes)
+	assert.Equal(t, []string{fmt.Sprintf(DatabaseNotFoundWarnMsg, "reports", s.Instance.Name)}, output.Warnings)
+}
+
+func TestGivenTheStoredState_WhenExecutePlanDesiredState_ThenShouldReturnNoChanges(t *testing.T) {
+	s := buildStoredState()
+	input := dto.DesiredStateInputDTO{
+		Ecosystems: []dto.DesiredEcosystemDTO{{Code: "azure", DisplayName: "Azure"}},
+		Instances:  []dto.DesiredInstanceDTO{s.desiredInstance()},
+		Users:      []dto.DesiredUserDTO{desiredUser(s.John), desiredUser(s.Foo)},
+		Grants: []dto.DesiredGrantDTO{
+			{User: s.John.Email, Instance: s.Instance.Name, Databases: []string{"settings"}},
+			{User: s.Foo.Email, Instance: s.Instance.Name, Databases: []string{"settings"}},
+		},
+	}
+
+	output, err := s.newPlanUseCase().Execute(input, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, NoChangesMsg, output.Message)
+	assert.Empty(t, output.Changes)
+	assert.Empty(t, output.Warnings)
+}
+
+func TestGivenAGrantToAUserNotDeclared_WhenExecutePlanDesiredStateWithPrune_ThenShouldReturnInvalidDesiredStateError(t *testing.T) {
+	s := buildStoredState()
+	input := s.buildPrunedDesiredState()
+	input.Grants = append(input.Grants, dto.DesiredGrantDTO{User: s.Foo.Email, Instance: s.Instance.Name, Databases: []string{"jobs"}})
+
+	output, err := s.newPlanUseCase().Execute(input, true)
+
+	assert.ErrorIs(t, err, ErrInvalidDesiredState)
+	assert.EqualError(t, err, "invalid desired state: user foobar@email.com of a grant not found")
+	assert.Nil(t, output)
+}
+
+func TestGivenAnInstanceWithATechnologyNotFound_WhenExecutePlanDesiredState_ThenShouldReturnInvalidDesiredStateError(t *testing.T) {
+	s := buildStoredState()
+	instance := s.desiredInstance()
+	instance.TechnologyVersion = "3"
+
+	output, err := s.newPlanUseCase().Execute(dto.DesiredStateInputDTO{Instances: []dto.DesiredInstanceDTO{instance}}, false)
+
+	assert.ErrorIs(t, err, ErrInvalidDesiredState)
+	assert.Nil(t, output)
+}
+
+func TestGivenAGroupWithChangedTargetsAndMembers_WhenExecutePlanDesiredStateWithPrune_ThenShouldPlanTheGroupChangesAndRevocations(t *testing.T) {
+	s := buildStoredState()
+	s.Groups = []*dto.AccessGroupOutputDTO{{
+		ID:      mocks.AccessGroupID,
+		Name:    "analysts",
+		Targets: []dto.AccessGroupTargetOutputDTO{{DatabaseInstanceID: s.Instance.ID, DatabaseInstanceName: s.Instance.Name}},
+		Members: []dto.AccessGroupMemberOutputDTO{
+			{DatabaseUserID: s.John.ID, DatabaseUserEmail: s.John.Email},
+			{DatabaseUserID: s.Foo.ID, DatabaseUserEmail: s.Foo.Email},
+		},
+	}}
+	input := dto.DesiredStateInputDTO{
+		Ecosystems: []dto.DesiredEcosystemDTO{{Code: "azure", DisplayName: "Azure"}},
+		Instances:  []dto.DesiredInstanceDTO{s.desiredInstance()},
+		Users:      []dto.DesiredUserDTO{desiredUser(s.John), desiredUser(s.Foo)},
+		Groups: []dto.DesiredGroupDTO{{
+			Name:    "analysts",
+			Targets: []dto.DesiredTargetDTO{{Instance: s.Instance.Name, Databases: []string{"jobs"}}},
+			Members: []string{s.John.Email},
+		}},
+	}
+
+	output, err := s.newPlanUseCase().Execute(input, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.DesiredStateChangeDTO{
+		{Action: ActionUpdateTargets, Kind: KindGroup, Name: "analysts", Details: "+" + s.Instance.Name + "/jobs, -" + s.Instance.Name + "/*"},
+		{Action: ActionRemoveMember, Kind: KindGroup, Name: "analysts", Details: "member: " + s.Foo.Email},
+		{Action: ActionRevoke, Kind: KindAccess, Name: s.Foo.Email + " # " + s.Instance.Name},
+		{Action: ActionRevoke, Kind: KindAccess, Name: s.John.Email + " # " + s.Instance.Name, Details: "databases: settings"},
+	}, output.Changes)
+	assert.Empty(t, output.Warnings)
+}
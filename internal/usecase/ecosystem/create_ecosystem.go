@@ -24,7 +24,7 @@ func NewCreateEcosystemUseCase(ecosystemStorage storage.EcosystemStorage) *Creat
 }
 
 func (c *CreateEcosystemUseCase) Execute(input dto.EcosystemInputDTO, createdByUserID string) (*dto.EcosystemOutputDTO, error) {
-	ecosystem, err := entity.NewEcosystem(input.Code, input.DisplayName, createdByUserID)
+	ecosystem, err := entity.NewEcosystem(input.Code, input.DisplayName, input.RequiresAccessApproval, createdByUserID)
 	if err != nil {
 		log.Printf("Error creating ecosystem. Cause: %v", err.Error())
 		return nil, err
@@ -48,10 +48,11 @@ func (c *CreateEcosystemUseCase) Execute(input dto.EcosystemInputDTO, createdByU
 
 	log.Printf("Ecosystem %v created successfully by user %s!", ecosystem.ID, createdByUserID)
 	return &dto.EcosystemOutputDTO{
-		ID:              ecosystem.ID.String(),
-		Code:            ecosystem.Code,
-		DisplayName:     ecosystem.DisplayName,
-		CreatedByUserID: ecosystem.CreatedByUserID,
-		CreatedAt:       ecosystem.CreatedAt,
+		ID:                     ecosystem.ID.String(),
+		Code:                   ecosystem.Code,
+		DisplayName:            ecosystem.DisplayName,
+		RequiresAccessApproval: ecosystem.RequiresAccessApproval,
+		CreatedByUserID:        ecosystem.CreatedByUserID,
+		CreatedAt:              ecosystem.CreatedAt,
 	}, nil
 }
@@ -44,12 +44,13 @@ func (uc *GetEcosystemUseCase) Execute(ecosystemID string) (*dto.EcosystemOutput
 	}
 	log.Printf("Ecosystem with id %s loaded successfully!", ecosystemID)
 	return &dto.EcosystemOutputDTO{
-		ID:            ecosystem.ID.String(),
-		Code:          ecosystem.Code,
-		DisplayName:   ecosystem.DisplayName,
-		CreatedAt:     ecosystem.CreatedAt,
-		CreatedByUser: user.Name,
-		UpdatedAt:     &ecosystem.UpdatedAt,
+		ID:                     ecosystem.ID.String(),
+		Code:                   ecosystem.Code,
+		DisplayName:            ecosystem.DisplayName,
+		RequiresAccessApproval: ecosystem.RequiresAccessApproval,
+		CreatedAt:              ecosystem.CreatedAt,
+		CreatedByUser:          user.Name,
+		UpdatedAt:              &ecosystem.UpdatedAt,
 	}, nil
 }
 
@@ -42,7 +42,7 @@ func TestGivenAnErrorInDb_WhenExecuteGet_ThenShouldReturnError(t *testing.T) {
 func TestGivenAnErrorWhileFindingUser_WhenExecuteGet_ThenShouldReturnError(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
 	userStorage := new(mocks.UserStorageMock)
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, mocks.UserID)
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, mocks.UserID)
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 	userStorage.On("FindByID", ecosystem.CreatedByUserID).Return(&entity.ApplicationUser{}, sql.ErrConnDone).Once()
 	uc := NewGetEcosystemUseCase(ecosystemStorage, userStorage)
@@ -60,7 +60,7 @@ func TestGivenAValidId_WhenExecuteGet_ThenShouldReturnEcosystem(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
 	userStorage := new(mocks.UserStorageMock)
 	user, _ := entity.NewApplicationUser("Foo Bar", "foobar@email.com")
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, user.ID.String())
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, user.ID.String())
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 
 	userStorage.On("FindByID", ecosystem.CreatedByUserID).Return(user, nil).Once()
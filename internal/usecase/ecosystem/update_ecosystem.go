@@ -43,7 +43,7 @@ func (uc *UpdateEcosystemUseCase) Execute(input dto.EcosystemInputDTO, ecosystem
 		}
 	}
 
-	ecosystem.Update(input.Code, input.DisplayName)
+	ecosystem.Update(input.Code, input.DisplayName, input.RequiresAccessApproval)
 	err = uc.EcosystemStorage.Update(ecosystem)
 	if err != nil {
 		log.Printf("error updating ecosystem: %v", err.Error())
@@ -52,10 +52,11 @@ func (uc *UpdateEcosystemUseCase) Execute(input dto.EcosystemInputDTO, ecosystem
 
 	log.Printf("Ecosystem %v updated successfully by user %s!", ecosystem.ID, operationUserID)
 	return &dto.EcosystemOutputDTO{
-		ID:          ecosystem.ID.String(),
-		Code:        ecosystem.Code,
-		DisplayName: ecosystem.DisplayName,
-		CreatedAt:   ecosystem.CreatedAt,
-		UpdatedAt:   &ecosystem.UpdatedAt,
+		ID:                     ecosystem.ID.String(),
+		Code:                   ecosystem.Code,
+		DisplayName:            ecosystem.DisplayName,
+		RequiresAccessApproval: ecosystem.RequiresAccessApproval,
+		CreatedAt:              ecosystem.CreatedAt,
+		UpdatedAt:              &ecosystem.UpdatedAt,
 	}, nil
 }
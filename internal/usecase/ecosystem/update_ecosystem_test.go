@@ -47,7 +47,7 @@ func TestGivenAnErrorInDbWhileFindById_WhenExecuteUpdate_ThenShouldReturnError(t
 
 func TestGivenAnErrorWhileCheckingCodeExists_WhenExecuteUpdate_ThenShouldReturnError(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, mocks.UserID)
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, mocks.UserID)
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 	ecosystemStorage.On("CheckCodeExists", updatedInput.Code).Return(false, sql.ErrConnDone).Once()
 	uc := NewUpdateEcosystemUseCase(ecosystemStorage)
@@ -63,7 +63,7 @@ func TestGivenAnErrorWhileCheckingCodeExists_WhenExecuteUpdate_ThenShouldReturnE
 
 func TestGivenAnInputWithAlreadyExistingCode_WhenExecuteUpdate_ThenShouldReturnError(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, mocks.UserID)
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, mocks.UserID)
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 	ecosystemStorage.On("CheckCodeExists", updatedInput.Code).Return(true, nil).Once()
 	uc := NewUpdateEcosystemUseCase(ecosystemStorage)
@@ -79,7 +79,7 @@ func TestGivenAnInputWithAlreadyExistingCode_WhenExecuteUpdate_ThenShouldReturnE
 
 func TestGivenAnErrorInDbWhileUpdate_WhenExecuteUpdate_ThenShouldReturnError(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, mocks.UserID)
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, mocks.UserID)
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 	ecosystemStorage.On("CheckCodeExists", updatedInput.Code).Return(false, nil).Once()
 	ecosystemStorage.On("Update", ecosystem).Return(sql.ErrConnDone).Once()
@@ -96,7 +96,7 @@ func TestGivenAnErrorInDbWhileUpdate_WhenExecuteUpdate_ThenShouldReturnError(t *
 }
 func TestGivenAValidIdAndInput_WhenExecuteUpdate_ThenShouldReturnEcosystem(t *testing.T) {
 	ecosystemStorage := new(mocks.EcosystemStorageMock)
-	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, mocks.UserID)
+	ecosystem, _ := entity.NewEcosystem(validInput.Code, validInput.DisplayName, validInput.RequiresAccessApproval, mocks.UserID)
 	ecosystemStorage.On("FindByID", ecosystem.ID.String()).Return(ecosystem, nil).Once()
 	ecosystemStorage.On("CheckCodeExists", updatedInput.Code).Return(false, nil).Once()
 	ecosystemStorage.On("Update", ecosystem).Return(nil).Once()
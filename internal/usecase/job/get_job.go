@@ -0,0 +1,34 @@
+package job
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+var ErrJobNotFound = errors.New("job not found")
+
+type GetJobUseCase struct {
+	JobStorage storage.JobStorage
+}
+
+func NewGetJobUseCase(jobStorage storage.JobStorage) *GetJobUseCase {
+	return &GetJobUseCase{JobStorage: jobStorage}
+}
+
+func (uc *GetJobUseCase) Execute(jobID string) (*dto.JobOutputDTO, error) {
+	jobDTO, err := uc.JobStorage.FindDTOByID(jobID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching job with id %s. Cause: %v", jobID, ErrJobNotFound)
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching job with id %s. Cause: %v", jobID, err)
+		return nil, err
+	}
+	log.Printf("Job with id %s loaded successfully!", jobID)
+	return jobDTO, nil
+}
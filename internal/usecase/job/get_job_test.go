@@ -0,0 +1,48 @@
+package job
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenANonexistentId_WhenExecuteGetJob_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindDTOByID", mocks.JobID).Return(&dto.JobOutputDTO{}, sql.ErrNoRows).Once()
+	uc := NewGetJobUseCase(jobStorage)
+
+	_, err := uc.Execute(mocks.JobID)
+
+	assert.EqualError(t, err, ErrJobNotFound.Error())
+	jobStorage.AssertNumberOfCalls(t, "FindDTOByID", 1)
+}
+
+func TestGivenAnErrorInDb_WhenExecuteGetJob_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindDTOByID", mocks.JobID).Return(&dto.JobOutputDTO{}, sql.ErrConnDone).Once()
+	uc := NewGetJobUseCase(jobStorage)
+
+	_, err := uc.Execute(mocks.JobID)
+
+	assert.EqualError(t, err, sql.ErrConnDone.Error())
+}
+
+func TestGivenAValidId_WhenExecuteGetJob_ThenShouldReturnTheJobWithItsItems(t *testing.T) {
+	jobDTO := mocks.BuildJobDTO()
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindDTOByID", mocks.JobID).Return(jobDTO, nil).Once()
+	uc := NewGetJobUseCase(jobStorage)
+
+	output, err := uc.Execute(mocks.JobID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, jobDTO.ID, output.ID)
+	assert.Equal(t, jobDTO.Status, output.Status)
+	assert.Equal(t, 2, output.TotalItems)
+	assert.Equal(t, 1, output.ProcessedItems)
+	assert.Len(t, output.Items, 1)
+}
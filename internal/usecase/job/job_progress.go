@@ -0,0 +1,107 @@
+package job
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+// jobProgress godoc
+// Collects the progress reported by the tasks of a job and saves it periodically, so the tasks don't wait for the
+// database and a job with thousands of items doesn't update its row for each one of them
+type jobProgress struct {
+	job     *entity.Job
+	save    func(job *entity.Job)
+	done    chan struct{}
+	stopped chan struct{}
+
+	mu        sync.Mutex
+	total     int
+	processed int
+	failed    int
+	items     []dto.ItemResultDTO
+	changed   bool
+}
+
+func newJobProgress(job *entity.Job, save func(job *entity.Job)) *jobProgress {
+	return &jobProgress{
+		job:     job,
+		save:    save,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		items:   make([]dto.ItemResultDTO, 0),
+	}
+}
+
+func (p *jobProgress) AddItems(qty int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total += qty
+	p.changed = true
+}
+
+func (p *jobProgress) ItemProcessed(result dto.ItemResultDTO) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.processed++
+	if !result.Success {
+		p.failed++
+	}
+	p.items = append(p.items, result)
+	p.changed = true
+}
+
+// Event godoc
+// The messages of the operation are not kept in the job, only the result of its items
+func (p *jobProgress) Event(dto.ProgressEventDTO) {}
+
+// saveEvery godoc
+// Saves the progress in the job on each interval, until stop is called
+func (p *jobProgress) saveEvery(interval time.Duration) {
+	defer close(p.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if p.apply() {
+				p.save(p.job)
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// stop godoc
+// Stops saving the progress and applies the last one to the job, that is saved with the final status of the job
+func (p *jobProgress) stop() {
+	close(p.done)
+	<-p.stopped
+	p.apply()
+}
+
+// apply godoc
+// Copies the progress to the job when it changed since the last copy. Only the goroutine of saveEvery, or the one of
+// the job once saveEvery stopped, changes the job.
+func (p *jobProgress) apply() bool {
+	p.mu.Lock()
+	if !p.changed {
+		p.mu.Unlock()
+		return false
+	}
+	p.changed = false
+	total, processed, failed := p.total, p.processed, p.failed
+	items, err := json.Marshal(p.items)
+	p.mu.Unlock()
+	if err != nil {
+		log.Printf("Error encoding the items of job %s. Cause: %v", p.job.ID, err)
+		return false
+	}
+	p.job.UpdateProgress(total, processed, failed, string(items))
+	return true
+}
@@ -0,0 +1,185 @@
+package job
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const defaultProgressInterval = 2 * time.Second
+
+var ErrJobTypeNotRegistered = errors.New("job type not registered")
+
+// JobFunc godoc
+// Executes the operation of a job type from the JSON of its input, returning its output DTO
+type JobFunc func(input, operationUserID string, progress common.ProgressReporter) (any, error)
+
+// NewJobFunc godoc
+// Adapts the ExecuteWithProgress of a use case to a JobFunc, decoding the input DTO of the job
+func NewJobFunc[I any, O any](execute func(I, string, common.ProgressReporter) (O, error)) JobFunc {
+	return func(input, operationUserID string, progress common.ProgressReporter) (any, error) {
+		var inputDTO I
+		if err := json.Unmarshal([]byte(input), &inputDTO); err != nil {
+			return nil, fmt.Errorf("error decoding the input of the job. Cause: %w", err)
+		}
+		return execute(inputDTO, operationUserID, progress)
+	}
+}
+
+// JobRunner godoc
+// Runs operations in background as jobs persisted in the jobs table, so the request that submits them returns right away.
+// The progress of a running job is saved periodically, and jobs interrupted by a shutdown are run again from scratch by
+// RecoverUnfinished on the next start. The tasks of the operations still run in the shared executor, so jobs don't
+// bypass its concurrency limits.
+type JobRunner struct {
+	JobStorage       storage.JobStorage
+	jobFuncs         map[string]JobFunc
+	progressInterval time.Duration
+	wg               sync.WaitGroup
+	stopped          atomic.Bool
+}
+
+func NewJobRunner(jobStorage storage.JobStorage) *JobRunner {
+	return &JobRunner{
+		JobStorage:       jobStorage,
+		jobFuncs:         make(map[string]JobFunc),
+		progressInterval: defaultProgressInterval,
+	}
+}
+
+func (r *JobRunner) Register(jobType string, jobFunc JobFunc) {
+	r.jobFuncs[jobType] = jobFunc
+}
+
+// Submit godoc
+// Persists a pending job with the input of the operation and starts it in background
+func (r *JobRunner) Submit(jobType string, input any, operationUserID string) (*dto.JobOutputDTO, error) {
+	jobFunc, registered := r.jobFuncs[jobType]
+	if !registered {
+		return nil, fmt.Errorf("%w: %s", ErrJobTypeNotRegistered, jobType)
+	}
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding the input of the job. Cause: %w", err)
+	}
+	job, err := entity.NewJob(jobType, string(inputJSON), operationUserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.JobStorage.Save(job); err != nil {
+		return nil, fmt.Errorf("error saving job. Cause: %w", err)
+	}
+	log.Printf("Job %s of type %s submitted by user %s", job.ID, jobType, operationUserID)
+
+	// The output is built before starting the job, that changes it from then on
+	output := &dto.JobOutputDTO{
+		ID:              job.ID.String(),
+		Type:            job.Type,
+		Status:          string(job.Status),
+		Input:           inputJSON,
+		CreatedByUserID: job.CreatedByUserID,
+		CreatedAt:       job.CreatedAt,
+		UpdatedAt:       job.UpdatedAt,
+	}
+	r.start(job, jobFunc)
+	return output, nil
+}
+
+// RecoverUnfinished godoc
+// Runs again the jobs left pending or running by the previous execution of the application. The operations are safe to
+// run again: what was already done in the previous attempt is skipped or applied again with the same result.
+func (r *JobRunner) RecoverUnfinished() error {
+	jobs, err := r.JobStorage.FindAllUnfinished()
+	if err != nil {
+		return fmt.Errorf("error fetching unfinished jobs. Cause: %w", err)
+	}
+	for _, job := range jobs {
+		jobFunc, registered := r.jobFuncs[job.Type]
+		if !registered {
+			log.Printf("Job %s can't be recovered, its type %s is not registered", job.ID, job.Type)
+			job.Fail(fmt.Errorf("%w: %s", ErrJobTypeNotRegistered, job.Type))
+			r.save(job)
+			continue
+		}
+		log.Printf("Recovering job %s of type %s, interrupted during attempt %d", job.ID, job.Type, job.Attempts)
+		r.start(job, jobFunc)
+	}
+	return nil
+}
+
+// Wait godoc
+// Waits for every job started by the runner
+func (r *JobRunner) Wait() {
+	r.wg.Wait()
+}
+
+// Stop godoc
+// Stops saving the jobs still running when the application shuts down, so they stay running in the jobs table and are
+// recovered on the next start, instead of being saved with the errors caused by the shutdown
+func (r *JobRunner) Stop() {
+	r.stopped.Store(true)
+}
+
+func (r *JobRunner) start(job *entity.Job, jobFunc JobFunc) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(job, jobFunc)
+	}()
+}
+
+func (r *JobRunner) run(job *entity.Job, jobFunc JobFunc) {
+	start := time.Now()
+	job.Start()
+	r.save(job)
+	log.Printf("Job %s of type %s started (attempt %d)", job.ID, job.Type, job.Attempts)
+
+	progress := newJobProgress(job, r.save)
+	go progress.saveEvery(r.progressInterval)
+	result, err := r.execute(job, jobFunc, progress)
+	progress.stop()
+
+	if err == nil {
+		var resultJSON []byte
+		resultJSON, err = json.Marshal(result)
+		if err == nil {
+			job.Succeed(string(resultJSON))
+		}
+	}
+	if err != nil {
+		job.Fail(err)
+		log.Printf("Job %s of type %s failed after %s. Cause: %v", job.ID, job.Type, time.Since(start), err)
+	} else {
+		log.Printf("Job %s of type %s finished: %d of %d items processed, %d failed. Elapsed time: %s",
+			job.ID, job.Type, job.ProcessedItems, job.TotalItems, job.FailedItems, time.Since(start))
+	}
+	r.save(job)
+}
+
+func (r *JobRunner) execute(job *entity.Job, jobFunc JobFunc, progress common.ProgressReporter) (result any, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job %s panicked: %v", job.ID, recovered)
+		}
+	}()
+	return jobFunc(job.Input, job.CreatedByUserID, progress)
+}
+
+func (r *JobRunner) save(job *entity.Job) {
+	if r.stopped.Load() {
+		log.Printf("Job %s not saved with status %s, it will be recovered on the next start", job.ID, job.Status)
+		return
+	}
+	if err := r.JobStorage.Update(job); err != nil {
+		log.Printf("Error saving job %s with status %s. Cause: %v", job.ID, job.Status, err)
+	}
+}
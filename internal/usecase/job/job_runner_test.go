@@ -0,0 +1,227 @@
+package job
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+var errSyncFailed = errors.New("no database instances found with the provided IDs")
+
+func syncDatabasesJob(t *testing.T, err error) JobFunc {
+	return NewJobFunc(func(input dto.SyncDatabasesInputDTO, operationUserID string, progress common.ProgressReporter) ([]*dto.SyncDatabasesOutputDTO, error) {
+		assert.Equal(t, mocks.UserID, operationUserID)
+		if err != nil {
+			return nil, err
+		}
+		progress.AddItems(len(input.DatabaseInstancesIDs))
+		outputs := make([]*dto.SyncDatabasesOutputDTO, 0, len(input.DatabaseInstancesIDs))
+		for idx, id := range input.DatabaseInstancesIDs {
+			output := &dto.SyncDatabasesOutputDTO{DatabaseInstanceID: id, Success: idx == 0, Message: "synchronized"}
+			progress.ItemProcessed(dto.ItemResultDTO{Item: id, Success: output.Success, Message: output.Message})
+			outputs = append(outputs, output)
+		}
+		return outputs, nil
+	})
+}
+
+func TestGivenAnUnregisteredType_WhenSubmitJob_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	runner := NewJobRunner(jobStorage)
+
+	output, err := runner.Submit(entity.JobTypeSetupRoles, dto.SetupRolesInputDTO{}, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrJobTypeNotRegistered)
+	assert.Nil(t, output)
+	jobStorage.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestGivenAnErrorInDb_WhenSubmitJob_ThenShouldReturnErrorWithoutRunningIt(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Return(sql.ErrConnDone).Once()
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSyncDatabases, syncDatabasesJob(t, nil))
+
+	output, err := runner.Submit(entity.JobTypeSyncDatabases, dto.SyncDatabasesInputDTO{}, mocks.UserID)
+	runner.Wait()
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, output)
+	jobStorage.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestGivenARegisteredType_WhenSubmitJob_ThenShouldReturnThePendingJobAndRunItInBackground(t *testing.T) {
+	var job *entity.Job
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Run(func(args mock.Arguments) { job = args.Get(0).(*entity.Job) }).Return(nil).Once()
+	jobStorage.On("Update", mock.Anything).Return(nil)
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSyncDatabases, syncDatabasesJob(t, nil))
+	input := dto.SyncDatabasesInputDTO{DatabaseInstancesIDs: []string{mocks.DatabaseInstanceId, mocks.QAInstanceId}}
+
+	output, err := runner.Submit(entity.JobTypeSyncDatabases, input, mocks.UserID)
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.JobStatusPending), output.Status)
+	assert.Equal(t, entity.JobTypeSyncDatabases, output.Type)
+	assert.NotEmpty(t, output.ID)
+	assert.JSONEq(t, `{"databaseInstancesIds":["`+mocks.DatabaseInstanceId+`","`+mocks.QAInstanceId+`"]}`, string(output.Input))
+	runner.Wait()
+
+	assert.Equal(t, output.ID, job.ID.String())
+	assert.Equal(t, entity.JobStatusSucceeded, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, 2, job.TotalItems)
+	assert.Equal(t, 2, job.ProcessedItems)
+	assert.Equal(t, 1, job.FailedItems)
+	var items []dto.ItemResultDTO
+	assert.NoError(t, json.Unmarshal([]byte(job.Items), &items))
+	assert.Len(t, items, 2)
+	var result []*dto.SyncDatabasesOutputDTO
+	assert.NoError(t, json.Unmarshal([]byte(job.Result.String), &result))
+	assert.Len(t, result, 2)
+	assert.True(t, job.FinishedAt.Valid)
+	jobStorage.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestGivenAnOperationError_WhenRunJob_ThenShouldFailTheJob(t *testing.T) {
+	var job *entity.Job
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Run(func(args mock.Arguments) { job = args.Get(0).(*entity.Job) }).Return(nil).Once()
+	jobStorage.On("Update", mock.Anything).Return(nil)
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSyncDatabases, syncDatabasesJob(t, errSyncFailed))
+
+	_, err := runner.Submit(entity.JobTypeSyncDatabases, dto.SyncDatabasesInputDTO{}, mocks.UserID)
+	assert.NoError(t, err)
+	runner.Wait()
+
+	assert.Equal(t, entity.JobStatusFailed, job.Status)
+	assert.Equal(t, errSyncFailed.Error(), job.Error)
+	assert.False(t, job.Result.Valid)
+}
+
+func TestGivenAPanicInTheOperation_WhenRunJob_ThenShouldFailTheJob(t *testing.T) {
+	var job *entity.Job
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Run(func(args mock.Arguments) { job = args.Get(0).(*entity.Job) }).Return(nil).Once()
+	jobStorage.On("Update", mock.Anything).Return(nil)
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSetupRoles, func(string, string, common.ProgressReporter) (any, error) {
+		panic("unexpected")
+	})
+
+	_, err := runner.Submit(entity.JobTypeSetupRoles, dto.SetupRolesInputDTO{}, mocks.UserID)
+	assert.NoError(t, err)
+	runner.Wait()
+
+	assert.Equal(t, entity.JobStatusFailed, job.Status)
+	assert.Contains(t, job.Error, "panicked: unexpected")
+}
+
+func TestGivenALongJob_WhenRunJob_ThenShouldSaveTheProgressWhileRunning(t *testing.T) {
+	progressSaved := make(chan struct{})
+	var once sync.Once
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Return(nil).Once()
+	jobStorage.On("Update", mock.Anything).Run(func(args mock.Arguments) {
+		job := args.Get(0).(*entity.Job)
+		if job.Status == entity.JobStatusRunning && job.ProcessedItems == 1 {
+			once.Do(func() { close(progressSaved) })
+		}
+	}).Return(nil)
+	runner := NewJobRunner(jobStorage)
+	runner.progressInterval = 10 * time.Millisecond
+	runner.Register(entity.JobTypePropagateRoles, func(_, _ string, progress common.ProgressReporter) (any, error) {
+		progress.AddItems(2)
+		progress.ItemProcessed(dto.ItemResultDTO{Item: "instance-1", Success: true})
+		select {
+		case <-progressSaved:
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("progress not saved while the job was running")
+		}
+		progress.ItemProcessed(dto.ItemResultDTO{Item: "instance-2", Success: true})
+		return nil, nil
+	})
+
+	_, err := runner.Submit(entity.JobTypePropagateRoles, dto.PropagateRolesInputDTO{}, mocks.UserID)
+	assert.NoError(t, err)
+	runner.Wait()
+
+	lastUpdate := jobStorage.Calls[len(jobStorage.Calls)-1].Arguments.Get(0).(*entity.Job)
+	assert.Equal(t, entity.JobStatusSucceeded, lastUpdate.Status)
+	assert.Equal(t, 2, lastUpdate.ProcessedItems)
+}
+
+func TestGivenJobsInterruptedByShutdown_WhenRecoverUnfinished_ThenShouldRunThemAgain(t *testing.T) {
+	input, _ := json.Marshal(dto.SyncDatabasesInputDTO{DatabaseInstancesIDs: []string{mocks.DatabaseInstanceId}})
+	interrupted, _ := entity.NewJob(entity.JobTypeSyncDatabases, string(input), mocks.UserID)
+	interrupted.Start()
+	interrupted.UpdateProgress(1, 0, 0, "[]")
+	unknown, _ := entity.NewJob("REMOVED_TYPE", "{}", mocks.UserID)
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindAllUnfinished").Return([]*entity.Job{interrupted, unknown}, nil).Once()
+	jobStorage.On("Update", mock.Anything).Return(nil)
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSyncDatabases, syncDatabasesJob(t, nil))
+
+	err := runner.RecoverUnfinished()
+	runner.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.JobStatusSucceeded, interrupted.Status)
+	assert.Equal(t, 2, interrupted.Attempts)
+	assert.Equal(t, 1, interrupted.ProcessedItems)
+	assert.Equal(t, entity.JobStatusFailed, unknown.Status)
+	assert.Contains(t, unknown.Error, ErrJobTypeNotRegistered.Error())
+}
+
+func TestGivenAnErrorInDb_WhenRecoverUnfinished_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindAllUnfinished").Return([]*entity.Job{}, sql.ErrConnDone).Once()
+	runner := NewJobRunner(jobStorage)
+
+	err := runner.RecoverUnfinished()
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestGivenAnInvalidInput_WhenRunJobFunc_ThenShouldReturnError(t *testing.T) {
+	jobFunc := syncDatabasesJob(t, nil)
+
+	_, err := jobFunc(`{"databaseInstancesIds":"not a list"}`, mocks.UserID, common.NoProgress)
+
+	assert.ErrorContains(t, err, "error decoding the input of the job")
+}
+
+func TestGivenAStoppedRunner_WhenJobFinishes_ThenShouldLeaveItToBeRecovered(t *testing.T) {
+	running := make(chan struct{})
+	stop := make(chan struct{})
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("Save", mock.Anything).Return(nil).Once()
+	jobStorage.On("Update", mock.Anything).Run(func(mock.Arguments) { close(running) }).Return(nil).Once()
+	runner := NewJobRunner(jobStorage)
+	runner.Register(entity.JobTypeSetupRoles, func(string, string, common.ProgressReporter) (any, error) {
+		<-stop
+		return nil, errors.New("sql: database is closed")
+	})
+
+	_, err := runner.Submit(entity.JobTypeSetupRoles, dto.SetupRolesInputDTO{}, mocks.UserID)
+	assert.NoError(t, err)
+	<-running
+	runner.Stop()
+	close(stop)
+	runner.Wait()
+
+	jobStorage.AssertNumberOfCalls(t, "Update", 1)
+}
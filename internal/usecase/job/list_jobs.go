@@ -0,0 +1,30 @@
+package job
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListJobsUseCase struct {
+	JobStorage storage.JobStorage
+}
+
+func NewListJobsUseCase(jobStorage storage.JobStorage) *ListJobsUseCase {
+	return &ListJobsUseCase{JobStorage: jobStorage}
+}
+
+func (uc *ListJobsUseCase) Execute(jobType, status string, page, limit int) ([]*dto.JobOutputDTO, int, error) {
+	jobDTOs, err := uc.JobStorage.FindAllDTOs(jobType, status, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching jobs! Cause: %w", err)
+	}
+	totalCount, err := uc.JobStorage.Count(jobType, status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching jobs count! Cause: %w", err)
+	}
+	log.Printf("List of jobs loaded successfully!")
+	return jobDTOs, totalCount, nil
+}
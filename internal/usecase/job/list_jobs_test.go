@@ -0,0 +1,50 @@
+package job
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnErrorInDb_WhenExecuteListJobs_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindAllDTOs", "", "", 1, 10).Return([]*dto.JobOutputDTO{}, sql.ErrConnDone).Once()
+	uc := NewListJobsUseCase(jobStorage)
+
+	jobs, total, err := uc.Execute("", "", 1, 10)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, jobs)
+	assert.Zero(t, total)
+	jobStorage.AssertNotCalled(t, "Count", "", "")
+}
+
+func TestGivenAnErrorInDbWhenCounting_WhenExecuteListJobs_ThenShouldReturnError(t *testing.T) {
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindAllDTOs", "", "", 1, 10).Return([]*dto.JobOutputDTO{mocks.BuildJobDTO()}, nil).Once()
+	jobStorage.On("Count", "", "").Return(0, sql.ErrConnDone).Once()
+	uc := NewListJobsUseCase(jobStorage)
+
+	_, _, err := uc.Execute("", "", 1, 10)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+}
+
+func TestGivenFilters_WhenExecuteListJobs_ThenShouldReturnTheJobsAndTheTotal(t *testing.T) {
+	status := string(entity.JobStatusRunning)
+	jobStorage := new(mocks.JobStorageMock)
+	jobStorage.On("FindAllDTOs", entity.JobTypeSyncDatabases, status, 2, 1).Return([]*dto.JobOutputDTO{mocks.BuildJobDTO()}, nil).Once()
+	jobStorage.On("Count", entity.JobTypeSyncDatabases, status).Return(3, nil).Once()
+	uc := NewListJobsUseCase(jobStorage)
+
+	jobs, total, err := uc.Execute(entity.JobTypeSyncDatabases, status, 2, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, 3, total)
+}
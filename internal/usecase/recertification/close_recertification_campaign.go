@@ -0,0 +1,178 @@
+package recertification
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const (
+	CampaignClosedMsg             = "Recertification campaign closed, %d permissions had their access revoked."
+	CampaignKeptOpenMsg           = "Recertification campaign kept open, since the access of some permissions could not be revoked. Check the revocations for details and close the campaign again to retry them."
+	AccessRevokedMsg              = "The access to the database was revoked"
+	AccessAlreadyRevokedMsg       = "The access was already revoked"
+	DatabaseUserRemovedMsg        = "The database user no longer exists"
+	ErrRevokingRecertificationMsg = "Error revoking the access: %s"
+	ErrReadingPermissionMsg       = "Error reading the permission of the item: %s"
+)
+
+type CloseRecertificationCampaignUseCase struct {
+	CampaignStorage         storage.RecertificationCampaignStorage
+	AccessPermissionStorage storage.AccessPermissionStorage
+	RevokeUseCase           common.RevokeAccessPermissionUseCaseInterface
+}
+
+func NewCloseRecertificationCampaignUseCase(
+	campaignStorage storage.RecertificationCampaignStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+	revokeUseCase common.RevokeAccessPermissionUseCaseInterface,
+) *CloseRecertificationCampaignUseCase {
+	return &CloseRecertificationCampaignUseCase{
+		CampaignStorage:         campaignStorage,
+		AccessPermissionStorage: accessPermissionStorage,
+		RevokeUseCase:           revokeUseCase,
+	}
+}
+
+// Execute godoc
+/** Revokes the permissions decided to be revoked in an open campaign and closes it, so no decision is accepted anymore.
+Items still pending are kept. Only the database of each item is revoked, through the revoke of databases with the user
+closing the campaign as operator, so the other permissions of the user in the instance are kept; the user is removed
+from the instance only when no permission is left there. The database is revoked only while the permission reviewed
+still exists, so an access granted again after it was revoked is kept. The result of each revocation is saved in its item for the
+evidence report. When a revocation fails the campaign is kept open, so closing it again retries the revocations still
+pending, while the ones already done keep their result. */
+func (uc *CloseRecertificationCampaignUseCase) Execute(campaignID, operationUserID string) (*dto.CloseRecertificationCampaignOutputDTO, error) {
+	campaign, err := findCampaign(uc.CampaignStorage, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsOpen() {
+		return nil, entity.ErrRecertificationCampaignClosed
+	}
+	items, err := uc.CampaignStorage.FindAllItems(campaignID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the items of recertification campaign %s. Cause: %w", campaignID, err)
+	}
+	log.Printf("Closing recertification campaign %s by user %s, revoking the access decided", campaignID, operationUserID)
+
+	output := &dto.CloseRecertificationCampaignOutputDTO{Revocations: make([]dto.ItemResultDTO, 0)}
+	var itemsRevoked []*entity.RecertificationItem
+	for _, item := range items {
+		if item.Decision != entity.RecertificationDecisionRevoke {
+			continue
+		}
+		result := uc.revoke(item, operationUserID)
+		output.Revocations = append(output.Revocations, result)
+		if !result.Success {
+			output.HasErrors = true
+		}
+		itemsRevoked = append(itemsRevoked, item)
+	}
+	if len(itemsRevoked) > 0 {
+		if err = uc.CampaignStorage.UpdateItems(itemsRevoked); err != nil {
+			log.Printf("Error saving the revocations of recertification campaign %s. Cause: %v", campaignID, err)
+			return nil, err
+		}
+	}
+
+	if output.HasErrors {
+		log.Printf("Recertification campaign %s kept open, some of its %d revocations failed", campaignID, len(itemsRevoked))
+		output.Message = CampaignKeptOpenMsg
+	} else {
+		if err = uc.close(campaign, operationUserID); err != nil {
+			return nil, err
+		}
+		log.Printf("Recertification campaign %s closed after revoking the access of %d permissions", campaignID, len(itemsRevoked))
+		output.Message = fmt.Sprintf(CampaignClosedMsg, len(itemsRevoked))
+	}
+	output.Campaign, err = uc.CampaignStorage.FindDTOByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (uc *CloseRecertificationCampaignUseCase) close(campaign *entity.RecertificationCampaign, operationUserID string) error {
+	if err := campaign.Close(operationUserID); err != nil {
+		return err
+	}
+	updated, err := uc.CampaignStorage.Update(campaign, entity.RecertificationCampaignStatusOpen)
+	if err != nil {
+		return fmt.Errorf("error closing recertification campaign %s. Cause: %w", campaign.ID, err)
+	}
+	if !updated {
+		return entity.ErrRecertificationCampaignClosed
+	}
+	return nil
+}
+
+// revoke godoc
+// Revokes the access to the database of the item and records the result in it. When the permission reviewed no longer
+// exists, its access was already revoked, by a previous close of the campaign or otherwise, and the result recorded
+// then is kept. The item fails when its permission can't be read, since the database to revoke is unknown.
+func (uc *CloseRecertificationCampaignUseCase) revoke(item *entity.RecertificationItem, operationUserID string) dto.ItemResultDTO {
+	permission, err := snapshotPermission(item)
+	result := dto.ItemResultDTO{
+		Item:    fmt.Sprintf("%s # %s # %s", permission.DatabaseUserName, permission.DatabaseInstanceName, permission.DatabaseName),
+		Success: true,
+	}
+	if err != nil {
+		result.Success = false
+		result.Message = fmt.Sprintf(ErrReadingPermissionMsg, err.Error())
+		item.RevocationResult = result.Message
+		return result
+	}
+	err = uc.revokeIfPermissionExists(item, permission, operationUserID)
+	switch {
+	case errors.Is(err, common.ErrNoAccessibleInstancesFound):
+		result.Message = AccessAlreadyRevokedMsg
+		if item.RevocationResult != "" {
+			result.Message = item.RevocationResult
+		}
+	case errors.Is(err, common.ErrDatabaseUserNotFound):
+		result.Message = DatabaseUserRemovedMsg
+	case err != nil:
+		result.Success = false
+		result.Message = fmt.Sprintf(ErrRevokingRecertificationMsg, err.Error())
+	default:
+		result.Message = AccessRevokedMsg
+	}
+	item.RevocationResult = result.Message
+	return result
+}
+
+// revokeIfPermissionExists godoc
+// Revokes the database of the permission reviewed, returning common.ErrNoAccessibleInstancesFound when it no longer
+// exists. A permission of the same database granted again afterward is a new one, so it isn't revoked.
+func (uc *CloseRecertificationCampaignUseCase) revokeIfPermissionExists(item *entity.RecertificationItem, permission dto.AccessPermissionOutputDTO, operationUserID string) error {
+	_, err := uc.AccessPermissionStorage.FindDTOByID(item.AccessPermissionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return common.ErrNoAccessibleInstancesFound
+	}
+	if err != nil {
+		return err
+	}
+	return uc.RevokeUseCase.RevokeDatabases(item.DatabaseUserID, item.DatabaseInstanceID, []string{permission.DatabaseID}, operationUserID)
+}
+
+// snapshotPermission godoc
+// Returns the permission of the item as it was when the campaign was created
+func snapshotPermission(item *entity.RecertificationItem) (dto.AccessPermissionOutputDTO, error) {
+	var permission dto.AccessPermissionOutputDTO
+	err := json.Unmarshal([]byte(item.Permission), &permission)
+	if err != nil {
+		log.Printf("Error reading the permission of recertification item %s. Cause: %v", item.ID, err)
+	}
+	if permission.DatabaseUserName == "" {
+		permission.DatabaseUserName = item.DatabaseUserID
+	}
+	return permission, err
+}
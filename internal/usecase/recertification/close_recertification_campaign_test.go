@@ -0,0 +1,188 @@
+package recertification
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const otherDatabaseID = "8b3c4d5e-6f70-4182-93a4-b5c6d7e8f9a0"
+
+func TestGivenAnUnknownCampaign_WhenExecuteClose_ThenShouldReturnError(t *testing.T) {
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(&entity.RecertificationCampaign{}, sql.ErrNoRows).Once()
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, nil, nil)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrRecertificationCampaignNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenAClosedCampaign_WhenExecuteClose_ThenShouldNotRevokeAnything(t *testing.T) {
+	campaign := mocks.BuildOpenRecertificationCampaign()
+	_ = campaign.Close(mocks.UserID)
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(campaign, nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, nil, revokeUC)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.ErrorIs(t, err, entity.ErrRecertificationCampaignClosed)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "FindAllItems", mock.Anything, mock.Anything)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGivenACampaignClosedInTheMeantime_WhenExecuteClose_ThenShouldReturnError(t *testing.T) {
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string(nil)).Return([]*entity.RecertificationItem{}, nil).Once()
+	campaignStorage.On("Update", mock.Anything, entity.RecertificationCampaignStatusOpen).Return(false, nil).Once()
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, nil, new(mocks.RevokeAccessPermissionUseCaseMock))
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.ErrorIs(t, err, entity.ErrRecertificationCampaignClosed)
+	assert.Nil(t, output)
+}
+
+func TestGivenItemsToRevoke_WhenExecuteClose_ThenShouldRevokeOnlyTheirDatabasesAndCloseTheCampaign(t *testing.T) {
+	revokedItem := buildItemOfDatabase(t, mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+	keptItemSameInstance := buildItemOfDatabase(t, mocks.DbUserID, mocks.QAInstanceId, otherDatabaseID, entity.RecertificationDecisionKeep)
+	pendingItemOtherUser := buildItemOfDatabase(t, otherUserID, mocks.QAInstanceId, mocks.DatabaseID, entity.RecertificationDecisionPending)
+	revokedItemAlreadyRevoked := buildItemOfDatabase(t, otherUserID, mocks.DatabaseInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+	items := []*entity.RecertificationItem{revokedItem, keptItemSameInstance, pendingItemOtherUser, revokedItemAlreadyRevoked}
+
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string(nil)).Return(items, nil).Once()
+	campaignStorage.On("UpdateItems", []*entity.RecertificationItem{revokedItem, revokedItemAlreadyRevoked}).Return(nil).Once()
+	campaignStorage.On("Update", mock.MatchedBy(func(c *entity.RecertificationCampaign) bool {
+		return c.Status == entity.RecertificationCampaignStatusClosed && c.ClosedByUserID.String == mocks.UserID
+	}), entity.RecertificationCampaignStatusOpen).Return(true, nil).Once()
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(nil).Once()
+	revokeUC.On("RevokeDatabases", otherUserID, mocks.DatabaseInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(common.ErrNoAccessibleInstancesFound).Once()
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, buildAccessStorage(), revokeUC)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, "Recertification campaign closed, 2 permissions had their access revoked.", output.Message)
+	assert.Equal(t, []dto.ItemResultDTO{
+		{Item: "Foo Bar # qa-instance # orders", Success: true, Message: AccessRevokedMsg},
+		{Item: "Foo Bar # qa-instance # orders", Success: true, Message: AccessAlreadyRevokedMsg},
+	}, output.Revocations)
+	assert.Equal(t, AccessRevokedMsg, revokedItem.RevocationResult)
+	assert.Empty(t, keptItemSameInstance.RevocationResult)
+	assert.Empty(t, pendingItemOtherUser.RevocationResult)
+	assert.Equal(t, AccessAlreadyRevokedMsg, revokedItemAlreadyRevoked.RevocationResult)
+	campaignStorage.AssertExpectations(t)
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenARevocationFailing_WhenExecuteClose_ThenShouldKeepTheCampaignOpenToRetry(t *testing.T) {
+	revokedItem := buildItemOfDatabase(t, mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+	revokedItem.RevocationResult = AccessRevokedMsg
+	revokedItemFailing := buildItemOfDatabase(t, otherUserID, mocks.DatabaseInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string(nil)).Return([]*entity.RecertificationItem{revokedItem, revokedItemFailing}, nil).Once()
+	campaignStorage.On("UpdateItems", []*entity.RecertificationItem{revokedItem, revokedItemFailing}).Return(nil).Once()
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+	revokeUC.On("RevokeDatabases", mocks.DbUserID, mocks.QAInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(common.ErrNoAccessibleInstancesFound).Once()
+	revokeUC.On("RevokeDatabases", otherUserID, mocks.DatabaseInstanceId, []string{mocks.DatabaseID}, mocks.UserID).Return(sql.ErrConnDone).Once()
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, buildAccessStorage(), revokeUC)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, CampaignKeptOpenMsg, output.Message)
+	assert.Equal(t, AccessRevokedMsg, revokedItem.RevocationResult, "the result of the revocation done before should be kept")
+	assert.Equal(t, "Error revoking the access: "+sql.ErrConnDone.Error(), revokedItemFailing.RevocationResult)
+	assert.False(t, output.Revocations[1].Success)
+	campaignStorage.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	campaignStorage.AssertExpectations(t)
+	revokeUC.AssertExpectations(t)
+}
+
+func TestGivenAPermissionGrantedAgainAfterRevoked_WhenExecuteClose_ThenShouldNotRevokeTheNewAccess(t *testing.T) {
+	revokedItem := buildItemOfDatabase(t, mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string(nil)).Return([]*entity.RecertificationItem{revokedItem}, nil).Once()
+	campaignStorage.On("UpdateItems", []*entity.RecertificationItem{revokedItem}).Return(nil).Once()
+	campaignStorage.On("Update", mock.Anything, entity.RecertificationCampaignStatusOpen).Return(true, nil).Once()
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, buildAccessStorage(revokedItem.AccessPermissionID), revokeUC)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.False(t, output.HasErrors)
+	assert.Equal(t, []dto.ItemResultDTO{{Item: "Foo Bar # qa-instance # orders", Success: true, Message: AccessAlreadyRevokedMsg}}, output.Revocations)
+	assert.Equal(t, AccessAlreadyRevokedMsg, revokedItem.RevocationResult)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	campaignStorage.AssertExpectations(t)
+}
+
+func TestGivenAnItemWithAPermissionThatCantBeRead_WhenExecuteClose_ThenShouldFailItAndKeepTheCampaignOpen(t *testing.T) {
+	revokedItem := buildItemOfDatabase(t, mocks.DbUserID, mocks.QAInstanceId, mocks.DatabaseID, entity.RecertificationDecisionRevoke)
+	revokedItem.Permission = `{"databaseId":`
+
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string(nil)).Return([]*entity.RecertificationItem{revokedItem}, nil).Once()
+	campaignStorage.On("UpdateItems", []*entity.RecertificationItem{revokedItem}).Return(nil).Once()
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+	accessStorage := buildAccessStorage()
+	revokeUC := new(mocks.RevokeAccessPermissionUseCaseMock)
+
+	uc := NewCloseRecertificationCampaignUseCase(campaignStorage, accessStorage, revokeUC)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.True(t, output.HasErrors)
+	assert.Equal(t, CampaignKeptOpenMsg, output.Message)
+	assert.False(t, output.Revocations[0].Success)
+	assert.Equal(t, fmt.Sprintf(ErrReadingPermissionMsg, "unexpected end of JSON input"), revokedItem.RevocationResult)
+	accessStorage.AssertNotCalled(t, "FindDTOByID", mock.Anything)
+	revokeUC.AssertNotCalled(t, "RevokeDatabases", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	campaignStorage.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+// buildAccessStorage godoc
+// Finds the permissions of the items, except the ones of the IDs informed, which no longer exist
+func buildAccessStorage(removedPermissionsIDs ...string) *mocks.AccessPermissionStorageMock {
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	for _, id := range removedPermissionsIDs {
+		accessStorage.On("FindDTOByID", id).Return((*dto.AccessPermissionOutputDTO)(nil), sql.ErrNoRows)
+	}
+	accessStorage.On("FindDTOByID", mock.Anything).Return(&dto.AccessPermissionOutputDTO{}, nil)
+	return accessStorage
+}
+
+func buildItemOfDatabase(t *testing.T, databaseUserID, instanceID, databaseID string, decision entity.RecertificationDecision) *entity.RecertificationItem {
+	item := buildItem(t, databaseUserID, instanceID, reviewerID, decision)
+	item.AccessPermissionID = uuid.NewString()
+	item.Permission = fmt.Sprintf(`{"databaseUserName":"Foo Bar","databaseInstanceName":"qa-instance","databaseId":"%s","databaseName":"orders"}`, databaseID)
+	return item
+}
@@ -0,0 +1,112 @@
+package recertification
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type CreateRecertificationCampaignUseCase struct {
+	CampaignStorage         storage.RecertificationCampaignStorage
+	AccessPermissionStorage storage.AccessPermissionStorage
+	ApplicationUserStorage  storage.ApplicationUserStorage
+}
+
+func NewCreateRecertificationCampaignUseCase(
+	campaignStorage storage.RecertificationCampaignStorage,
+	accessPermissionStorage storage.AccessPermissionStorage,
+	applicationUserStorage storage.ApplicationUserStorage,
+) *CreateRecertificationCampaignUseCase {
+	return &CreateRecertificationCampaignUseCase{
+		CampaignStorage:         campaignStorage,
+		AccessPermissionStorage: accessPermissionStorage,
+		ApplicationUserStorage:  applicationUserStorage,
+	}
+}
+
+// Execute godoc
+/** Opens a campaign with a snapshot of the current access permissions in its scope, all of them or those of the
+ecosystem and/or the team informed. The permissions are assigned to the reviewers in turns by database user, so all the
+permissions of a user are reviewed by the same reviewer. */
+func (uc *CreateRecertificationCampaignUseCase) Execute(input dto.RecertificationCampaignInputDTO, operationUserID string) (*dto.RecertificationCampaignOutputDTO, error) {
+	reviewersIDs, err := uc.validateReviewers(input.ReviewersIDs)
+	if err != nil {
+		return nil, err
+	}
+	campaign, err := entity.NewRecertificationCampaign(input.Name, input.EcosystemID, input.Team, operationUserID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := uc.AccessPermissionStorage.FindAllDTOsByScope(input.EcosystemID, campaign.Team)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the access permissions to review. Cause: %w", err)
+	}
+	if len(permissions) == 0 {
+		return nil, ErrNoAccessPermissionsToReview
+	}
+	items, err := buildItems(campaign.ID.String(), permissions, reviewersIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.CampaignStorage.Save(campaign, items); err != nil {
+		log.Printf("Error saving recertification campaign. Cause: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Recertification campaign %s created by user %s with %d permissions assigned to %d reviewers",
+		campaign.ID, operationUserID, len(items), len(reviewersIDs))
+	return uc.CampaignStorage.FindDTOByID(campaign.ID.String())
+}
+
+// validateReviewers godoc
+// Returns the reviewers informed without duplicates, failing when any of them is not an application user
+func (uc *CreateRecertificationCampaignUseCase) validateReviewers(reviewersIDs []string) ([]string, error) {
+	var uniqueIDs []string
+	seen := make(map[string]bool, len(reviewersIDs))
+	for _, reviewerID := range reviewersIDs {
+		if seen[reviewerID] {
+			continue
+		}
+		seen[reviewerID] = true
+		_, err := uc.ApplicationUserStorage.FindByID(reviewerID)
+		if err != nil && errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrReviewerNotFound, reviewerID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		uniqueIDs = append(uniqueIDs, reviewerID)
+	}
+	return uniqueIDs, nil
+}
+
+// buildItems godoc
+// The permissions come ordered by user, so the reviewer changes along with the user
+func buildItems(campaignID string, permissions []*dto.AccessPermissionOutputDTO, reviewersIDs []string) ([]*entity.RecertificationItem, error) {
+	items := make([]*entity.RecertificationItem, 0, len(permissions))
+	reviewerIdx := -1
+	previousUserID := ""
+	for _, permission := range permissions {
+		if permission.DatabaseUserID != previousUserID {
+			reviewerIdx = (reviewerIdx + 1) % len(reviewersIDs)
+			previousUserID = permission.DatabaseUserID
+		}
+		snapshot, err := json.Marshal(permission)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding the snapshot of access permission %s. Cause: %w", permission.ID, err)
+		}
+		item, err := entity.NewRecertificationItem(campaignID, permission.ID, permission.DatabaseUserID, permission.DatabaseInstanceID,
+			string(snapshot), reviewersIDs[reviewerIdx])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,90 @@
+package recertification
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+const (
+	reviewerID  = "6f1d2c3b-4a5e-4f60-8172-93a4b5c6d7e8"
+	otherUserID = "7a2b3c4d-5e6f-4071-8293-a4b5c6d7e8f9"
+)
+
+func buildCampaignInput(reviewersIDs ...string) dto.RecertificationCampaignInputDTO {
+	return dto.RecertificationCampaignInputDTO{Name: "Q3 2024 recertification", Team: "Team B", ReviewersIDs: reviewersIDs}
+}
+
+func buildPermissionsToReview() []*dto.AccessPermissionOutputDTO {
+	return []*dto.AccessPermissionOutputDTO{
+		{ID: uuid.NewString(), DatabaseUserID: mocks.DbUserID, DatabaseUserName: "Foo Bar", DatabaseInstanceID: mocks.QAInstanceId, DatabaseID: mocks.DatabaseID},
+		{ID: uuid.NewString(), DatabaseUserID: mocks.DbUserID, DatabaseUserName: "Foo Bar", DatabaseInstanceID: mocks.DatabaseInstanceId, DatabaseID: mocks.DatabaseID},
+		{ID: uuid.NewString(), DatabaseUserID: otherUserID, DatabaseUserName: "John Doe", DatabaseInstanceID: mocks.QAInstanceId, DatabaseID: mocks.DatabaseID},
+	}
+}
+
+func TestGivenAnUnknownReviewer_WhenExecuteCreateCampaign_ThenShouldReturnError(t *testing.T) {
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", reviewerID).Return(&entity.ApplicationUser{}, sql.ErrNoRows).Once()
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+
+	uc := NewCreateRecertificationCampaignUseCase(campaignStorage, nil, userStorage)
+	output, err := uc.Execute(buildCampaignInput(reviewerID), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrReviewerNotFound)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGivenNoPermissionsInScope_WhenExecuteCreateCampaign_ThenShouldReturnError(t *testing.T) {
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", reviewerID).Return(&entity.ApplicationUser{}, nil).Once()
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOsByScope", "", "Team B").Return([]*dto.AccessPermissionOutputDTO{}, nil).Once()
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+
+	uc := NewCreateRecertificationCampaignUseCase(campaignStorage, accessStorage, userStorage)
+	output, err := uc.Execute(buildCampaignInput(reviewerID), mocks.UserID)
+
+	assert.ErrorIs(t, err, ErrNoAccessPermissionsToReview)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestGivenPermissionsInScope_WhenExecuteCreateCampaign_ThenShouldAssignAllPermissionsOfAUserToTheSameReviewer(t *testing.T) {
+	permissions := buildPermissionsToReview()
+	userStorage := new(mocks.UserStorageMock)
+	userStorage.On("FindByID", reviewerID).Return(&entity.ApplicationUser{}, nil).Once()
+	userStorage.On("FindByID", mocks.UserID).Return(&entity.ApplicationUser{}, nil).Once()
+	accessStorage := new(mocks.AccessPermissionStorageMock)
+	accessStorage.On("FindAllDTOsByScope", "", "Team B").Return(permissions, nil).Once()
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	var savedItems []*entity.RecertificationItem
+	campaignStorage.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		savedItems = args.Get(1).([]*entity.RecertificationItem)
+	}).Return(nil).Once()
+	campaignStorage.On("FindDTOByID", mock.Anything).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+
+	uc := NewCreateRecertificationCampaignUseCase(campaignStorage, accessStorage, userStorage)
+	output, err := uc.Execute(buildCampaignInput(reviewerID, mocks.UserID, reviewerID), mocks.UserID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	userStorage.AssertNumberOfCalls(t, "FindByID", 2)
+	assert.Len(t, savedItems, 3)
+	for idx, item := range savedItems {
+		assert.Equal(t, permissions[idx].ID, item.AccessPermissionID)
+		assert.Equal(t, entity.RecertificationDecisionPending, item.Decision)
+		assert.Contains(t, item.Permission, permissions[idx].ID)
+	}
+	assert.Equal(t, reviewerID, savedItems[0].ReviewerID)
+	assert.Equal(t, reviewerID, savedItems[1].ReviewerID)
+	assert.Equal(t, mocks.UserID, savedItems[2].ReviewerID)
+}
@@ -0,0 +1,58 @@
+package recertification
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type DecideRecertificationItemsUseCase struct {
+	CampaignStorage storage.RecertificationCampaignStorage
+}
+
+func NewDecideRecertificationItemsUseCase(campaignStorage storage.RecertificationCampaignStorage) *DecideRecertificationItemsUseCase {
+	return &DecideRecertificationItemsUseCase{CampaignStorage: campaignStorage}
+}
+
+// Execute godoc
+/** Records the decisions of a reviewer, keep or revoke, on items of an open campaign assigned to them. The decisions are
+saved all together, none is saved when any of the items is not found or is assigned to another reviewer. A decision may
+be changed while the campaign is open. */
+func (uc *DecideRecertificationItemsUseCase) Execute(campaignID string, input dto.DecideRecertificationItemsInputDTO, operationUserID string) (*dto.RecertificationCampaignOutputDTO, error) {
+	campaign, err := findCampaign(uc.CampaignStorage, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsOpen() {
+		return nil, entity.ErrRecertificationCampaignClosed
+	}
+	itemsIDs := make([]string, 0, len(input.Decisions))
+	for _, decision := range input.Decisions {
+		itemsIDs = append(itemsIDs, decision.ItemID)
+	}
+	items, err := uc.CampaignStorage.FindAllItems(campaignID, itemsIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching the recertification items. Cause: %w", err)
+	}
+	for _, decision := range input.Decisions {
+		itemIdx := slices.IndexFunc(items, func(item *entity.RecertificationItem) bool {
+			return item.ID.String() == decision.ItemID
+		})
+		if itemIdx < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrRecertificationItemNotFound, decision.ItemID)
+		}
+		if err = items[itemIdx].Decide(operationUserID, entity.RecertificationDecision(decision.Decision), decision.Comment); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, decision.ItemID)
+		}
+	}
+	if err = uc.CampaignStorage.UpdateItems(items); err != nil {
+		log.Printf("Error saving the decisions on recertification campaign %s. Cause: %v", campaignID, err)
+		return nil, err
+	}
+	log.Printf("%d decisions recorded by user %s on recertification campaign %s", len(items), operationUserID, campaignID)
+	return uc.CampaignStorage.FindDTOByID(campaignID)
+}
@@ -0,0 +1,90 @@
+package recertification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func buildItem(t *testing.T, databaseUserID, instanceID, reviewer string, decision entity.RecertificationDecision) *entity.RecertificationItem {
+	item, err := entity.NewRecertificationItem(mocks.RecertificationCampaignID, mocks.DatabaseID, databaseUserID, instanceID,
+		`{"databaseUserName":"Foo Bar"}`, reviewer)
+	assert.NoError(t, err)
+	item.Decision = decision
+	return item
+}
+
+func buildDecisionsInput(items ...*entity.RecertificationItem) dto.DecideRecertificationItemsInputDTO {
+	var input dto.DecideRecertificationItemsInputDTO
+	for _, item := range items {
+		input.Decisions = append(input.Decisions, dto.RecertificationDecisionInputDTO{ItemID: item.ID.String(), Decision: "REVOKE", Comment: "left the team"})
+	}
+	return input
+}
+
+func TestGivenAClosedCampaign_WhenExecuteDecide_ThenShouldReturnError(t *testing.T) {
+	campaign := mocks.BuildOpenRecertificationCampaign()
+	_ = campaign.Close(mocks.UserID)
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(campaign, nil).Once()
+
+	uc := NewDecideRecertificationItemsUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, buildDecisionsInput(buildItem(t, mocks.DbUserID, mocks.QAInstanceId, reviewerID, entity.RecertificationDecisionPending)), reviewerID)
+
+	assert.ErrorIs(t, err, entity.ErrRecertificationCampaignClosed)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "FindAllItems", mock.Anything, mock.Anything)
+}
+
+func TestGivenAnItemNotInTheCampaign_WhenExecuteDecide_ThenShouldReturnError(t *testing.T) {
+	item := buildItem(t, mocks.DbUserID, mocks.QAInstanceId, reviewerID, entity.RecertificationDecisionPending)
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string{item.ID.String()}).Return([]*entity.RecertificationItem{}, nil).Once()
+
+	uc := NewDecideRecertificationItemsUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, buildDecisionsInput(item), reviewerID)
+
+	assert.ErrorIs(t, err, ErrRecertificationItemNotFound)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "UpdateItems", mock.Anything)
+}
+
+func TestGivenAnItemOfAnotherReviewer_WhenExecuteDecide_ThenShouldSaveNoDecision(t *testing.T) {
+	ownItem := buildItem(t, mocks.DbUserID, mocks.QAInstanceId, reviewerID, entity.RecertificationDecisionPending)
+	otherItem := buildItem(t, otherUserID, mocks.QAInstanceId, mocks.UserID, entity.RecertificationDecisionPending)
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, mock.Anything).Return([]*entity.RecertificationItem{ownItem, otherItem}, nil).Once()
+
+	uc := NewDecideRecertificationItemsUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, buildDecisionsInput(ownItem, otherItem), reviewerID)
+
+	assert.ErrorIs(t, err, entity.ErrRecertificationItemOfOtherReviewer)
+	assert.Nil(t, output)
+	campaignStorage.AssertNotCalled(t, "UpdateItems", mock.Anything)
+}
+
+func TestGivenItemsOfTheReviewer_WhenExecuteDecide_ThenShouldSaveTheDecisions(t *testing.T) {
+	item := buildItem(t, mocks.DbUserID, mocks.QAInstanceId, reviewerID, entity.RecertificationDecisionKeep)
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItems", mocks.RecertificationCampaignID, []string{item.ID.String()}).Return([]*entity.RecertificationItem{item}, nil).Once()
+	campaignStorage.On("UpdateItems", []*entity.RecertificationItem{item}).Return(nil).Once()
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+
+	uc := NewDecideRecertificationItemsUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID, buildDecisionsInput(item), reviewerID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, entity.RecertificationDecisionRevoke, item.Decision, "a decision may be changed while the campaign is open")
+	assert.Equal(t, "left the team", item.Comment)
+	assert.True(t, item.DecidedAt.Valid)
+	campaignStorage.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package recertification
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type GetRecertificationCampaignUseCase struct {
+	CampaignStorage storage.RecertificationCampaignStorage
+}
+
+func NewGetRecertificationCampaignUseCase(campaignStorage storage.RecertificationCampaignStorage) *GetRecertificationCampaignUseCase {
+	return &GetRecertificationCampaignUseCase{CampaignStorage: campaignStorage}
+}
+
+func (uc *GetRecertificationCampaignUseCase) Execute(campaignID string) (*dto.RecertificationCampaignOutputDTO, error) {
+	campaignDTO, err := uc.CampaignStorage.FindDTOByID(campaignID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Error fetching recertification campaign with id %s. Cause: %v", campaignID, ErrRecertificationCampaignNotFound)
+		return nil, ErrRecertificationCampaignNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching recertification campaign with id %s. Cause: %v", campaignID, err)
+		return nil, err
+	}
+	log.Printf("Recertification campaign with id %s loaded successfully!", campaignID)
+	return campaignDTO, nil
+}
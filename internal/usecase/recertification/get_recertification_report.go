@@ -0,0 +1,36 @@
+package recertification
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type GetRecertificationReportUseCase struct {
+	CampaignStorage storage.RecertificationCampaignStorage
+}
+
+func NewGetRecertificationReportUseCase(campaignStorage storage.RecertificationCampaignStorage) *GetRecertificationReportUseCase {
+	return &GetRecertificationReportUseCase{CampaignStorage: campaignStorage}
+}
+
+// Execute godoc
+// Builds the evidence of a campaign, open or closed, with all of its items
+func (uc *GetRecertificationReportUseCase) Execute(campaignID string) (*dto.RecertificationReportOutputDTO, error) {
+	campaignDTO, err := NewGetRecertificationCampaignUseCase(uc.CampaignStorage).Execute(campaignID)
+	if err != nil {
+		return nil, err
+	}
+	itemDTOs, err := uc.CampaignStorage.FindAllItemDTOs(campaignID, "", "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching recertification items! Cause: %w", err)
+	}
+	if itemDTOs == nil {
+		itemDTOs = make([]*dto.RecertificationItemOutputDTO, 0)
+	}
+	log.Printf("Evidence report of recertification campaign %s built with %d items", campaignID, len(itemDTOs))
+	return &dto.RecertificationReportOutputDTO{Campaign: campaignDTO, Items: itemDTOs, GeneratedAt: time.Now()}, nil
+}
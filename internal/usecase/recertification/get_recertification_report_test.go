@@ -0,0 +1,37 @@
+package recertification
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAnUnknownCampaign_WhenExecuteGetReport_ThenShouldReturnError(t *testing.T) {
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(&dto.RecertificationCampaignOutputDTO{}, sql.ErrNoRows).Once()
+
+	uc := NewGetRecertificationReportUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID)
+
+	assert.ErrorIs(t, err, ErrRecertificationCampaignNotFound)
+	assert.Nil(t, output)
+}
+
+func TestGivenACampaign_WhenExecuteGetReport_ThenShouldReturnAllItems(t *testing.T) {
+	items := []*dto.RecertificationItemOutputDTO{{ID: "1", Decision: "KEEP"}, {ID: "2", Decision: "REVOKE", RevocationResult: "revoked"}}
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindDTOByID", mocks.RecertificationCampaignID).Return(mocks.BuildRecertificationCampaignDTO(), nil).Once()
+	campaignStorage.On("FindAllItemDTOs", mocks.RecertificationCampaignID, "", "", 0, 0).Return(items, nil).Once()
+
+	uc := NewGetRecertificationReportUseCase(campaignStorage)
+	output, err := uc.Execute(mocks.RecertificationCampaignID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mocks.RecertificationCampaignID, output.Campaign.ID)
+	assert.Equal(t, items, output.Items)
+	assert.False(t, output.GeneratedAt.IsZero())
+}
@@ -0,0 +1,30 @@
+package recertification
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListRecertificationCampaignsUseCase struct {
+	CampaignStorage storage.RecertificationCampaignStorage
+}
+
+func NewListRecertificationCampaignsUseCase(campaignStorage storage.RecertificationCampaignStorage) *ListRecertificationCampaignsUseCase {
+	return &ListRecertificationCampaignsUseCase{CampaignStorage: campaignStorage}
+}
+
+func (uc *ListRecertificationCampaignsUseCase) Execute(status string, page, limit int) ([]*dto.RecertificationCampaignOutputDTO, int, error) {
+	campaignDTOs, err := uc.CampaignStorage.FindAllDTOs(status, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching recertification campaigns! Cause: %w", err)
+	}
+	totalCount, err := uc.CampaignStorage.Count(status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching recertification campaigns count! Cause: %w", err)
+	}
+	log.Printf("List of recertification campaigns loaded successfully!")
+	return campaignDTOs, totalCount, nil
+}
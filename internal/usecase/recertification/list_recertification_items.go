@@ -0,0 +1,35 @@
+package recertification
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+type ListRecertificationItemsUseCase struct {
+	CampaignStorage storage.RecertificationCampaignStorage
+}
+
+func NewListRecertificationItemsUseCase(campaignStorage storage.RecertificationCampaignStorage) *ListRecertificationItemsUseCase {
+	return &ListRecertificationItemsUseCase{CampaignStorage: campaignStorage}
+}
+
+// Execute godoc
+// Lists the items of a campaign, optionally only those assigned to a reviewer and/or with a decision
+func (uc *ListRecertificationItemsUseCase) Execute(campaignID, reviewerID, decision string, page, limit int) ([]*dto.RecertificationItemOutputDTO, int, error) {
+	if _, err := findCampaign(uc.CampaignStorage, campaignID); err != nil {
+		return nil, 0, err
+	}
+	itemDTOs, err := uc.CampaignStorage.FindAllItemDTOs(campaignID, reviewerID, decision, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching recertification items! Cause: %w", err)
+	}
+	totalCount, err := uc.CampaignStorage.CountItems(campaignID, reviewerID, decision)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error fetching recertification items count! Cause: %w", err)
+	}
+	log.Printf("List of items of recertification campaign %s loaded successfully!", campaignID)
+	return itemDTOs, totalCount, nil
+}
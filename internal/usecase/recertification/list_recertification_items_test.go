@@ -0,0 +1,39 @@
+package recertification
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/testdata/mocks"
+)
+
+func TestGivenAReviewer_WhenExecuteListItems_ThenShouldReturnTheItemsAssignedToThem(t *testing.T) {
+	items := []*dto.RecertificationItemOutputDTO{{ID: "1", ReviewerID: reviewerID, Decision: "PENDING"}}
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindByID", mocks.RecertificationCampaignID).Return(mocks.BuildOpenRecertificationCampaign(), nil).Once()
+	campaignStorage.On("FindAllItemDTOs", mocks.RecertificationCampaignID, reviewerID, "PENDING", 1, 10).Return(items, nil).Once()
+	campaignStorage.On("CountItems", mocks.RecertificationCampaignID, reviewerID, "PENDING").Return(1, nil).Once()
+
+	uc := NewListRecertificationItemsUseCase(campaignStorage)
+	output, total, err := uc.Execute(mocks.RecertificationCampaignID, reviewerID, "PENDING", 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, items, output)
+	assert.Equal(t, 1, total)
+}
+
+func TestGivenAnErrorInDbWhenCounting_WhenExecuteListCampaigns_ThenShouldReturnError(t *testing.T) {
+	campaignStorage := new(mocks.RecertificationCampaignStorageMock)
+	campaignStorage.On("FindAllDTOs", "OPEN", 1, 10).Return([]*dto.RecertificationCampaignOutputDTO{mocks.BuildRecertificationCampaignDTO()}, nil).Once()
+	campaignStorage.On("Count", "OPEN").Return(0, sql.ErrConnDone).Once()
+
+	uc := NewListRecertificationCampaignsUseCase(campaignStorage)
+	output, total, err := uc.Execute("OPEN", 1, 10)
+
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.Nil(t, output)
+	assert.Zero(t, total)
+}
@@ -0,0 +1,30 @@
+package recertification
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+var (
+	ErrRecertificationCampaignNotFound = errors.New("recertification campaign not found")
+	ErrRecertificationItemNotFound     = errors.New("recertification item not found in the campaign")
+	ErrReviewerNotFound                = errors.New("reviewer not found")
+	ErrNoAccessPermissionsToReview     = errors.New("no access permissions found in the scope of the campaign")
+)
+
+func findCampaign(campaignStorage storage.RecertificationCampaignStorage, campaignID string) (*entity.RecertificationCampaign, error) {
+	campaign, err := campaignStorage.FindByID(campaignID)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		log.Printf("Recertification campaign with id %s not found in database!", campaignID)
+		return nil, ErrRecertificationCampaignNotFound
+	}
+	if err != nil {
+		log.Printf("Error fetching recertification campaign with id %s. Cause: %v", campaignID, err)
+		return nil, err
+	}
+	return campaign, nil
+}
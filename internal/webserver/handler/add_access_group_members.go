@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+)
+
+const opAddAccessGroupMembers = "add-access-group-members"
+
+var addAccessGroupMembersUC *accessGroupUsecase.AddAccessGroupMembersUseCase
+
+// AddAccessGroupMembersHandler godoc
+// @BasePath /api/v1
+// @Summary Add members to an access group
+// @Description Add the database users to the group and grant them the access of its targets.
+// @Description The members are kept even if the grant fails, adding them again grants the access that couldn't be granted before.
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param id query string true "Access group ID"
+// @Param request body dto.AccessGroupMembersInputDTO true "Request body"
+// @Success 200 {object} AccessGroupChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-group/members [post]
+// @Security ApiKeyAuth
+func AddAccessGroupMembersHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.AccessGroupMembersInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := addAccessGroupMembersUC.Execute(id, input, userID)
+	if err != nil {
+		sendError(w, accessGroupErrorCode(err), buildErrorMessage(opAddAccessGroupMembers, err))
+		return
+	}
+
+	sendSuccess(w, opAddAccessGroupMembers, output)
+}
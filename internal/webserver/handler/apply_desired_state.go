@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	desiredStateUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/desired_state"
+)
+
+const (
+	opApplyDesiredState = "apply-desired-state"
+)
+
+var applyDesiredStateUC *desiredStateUsecase.ApplyDesiredStateUseCase
+
+// ApplyDesiredStateHandler godoc
+// @BasePath /api/v1
+// @Summary Apply a desired state file to the stored ecosystems, instances, database users, access groups and access permissions
+// @Description Plans the desired state, as the plan endpoint does, and applies each change in order through the same operations of the API. A change that fails doesn't stop the others: each change is returned with its result, and hasErrors is true when any failed.
+// @Description With prune, what the desired state doesn't mention is removed as well.
+// @Tags Desired State
+// @Accept application/x-yaml
+// @Produce json
+// @Param request body dto.DesiredStateInputDTO true "Desired state"
+// @Param prune query bool false "Also remove what the desired state doesn't mention"
+// @Success 200 {object} DesiredStateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /desired-state/apply [post]
+// @Security ApiKeyAuth
+func ApplyDesiredStateHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	prune, hasError := getPruneQueryParam(w, r)
+	if hasError {
+		return
+	}
+	input, hasError := decodeDesiredState(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := applyDesiredStateUC.Execute(input, prune, userID)
+	if err != nil {
+		sendDesiredStateError(w, opApplyDesiredState, err)
+		return
+	}
+
+	sendSuccess(w, opApplyDesiredState, output)
+}
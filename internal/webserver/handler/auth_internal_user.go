@@ -6,10 +6,11 @@ import (
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	userUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/user"
 )
 
-const zgInternalUserEmail = "zg-service@email.com"
+const zgInternalUserEmail = entity.SystemUserEmail
 
 var (
 	getUserUC *userUsecase.GetUserUseCase
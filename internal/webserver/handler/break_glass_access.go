@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessPermissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+var (
+	breakGlassAccessUC              *accessPermissionUsecase.BreakGlassAccessUseCase
+	revokeExpiredBreakGlassAccessUC *accessPermissionUsecase.RevokeExpiredBreakGlassAccessUseCase
+)
+
+const opBreakGlassAccess = "break-glass-access"
+
+// BreakGlassAccessHandler godoc
+// @BasePath /api/v1
+// @Summary Grant emergency access to a user in a set of databases, without approval
+// @Description Grant the devops role to a user in the databases informed for a short, fixed window (BREAK_GLASS_WINDOW), even in ecosystems that require approval.
+// @Description The incident reference is required. Every grant is logged with the BREAK_GLASS type, the access granted is notified and the role is revoked automatically when the window closes.
+// @Tags Access Permission
+// @Accept json
+// @Produce json
+// @Param request body dto.BreakGlassAccessInputDTO true "Request body"
+// @Success 200 {object} BreakGlassAccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-permission/break-glass [post]
+// @Security ApiKeyAuth
+func BreakGlassAccessHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.BreakGlassAccessInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := breakGlassAccessUC.Execute(input, userID)
+	if err != nil {
+		log.Printf("error granting break-glass access: %v", err.Error())
+		sendError(w, breakGlassAccessErrorCode(err), buildErrorMessage(opBreakGlassAccess, err))
+		return
+	}
+
+	sendSuccess(w, opBreakGlassAccess, output)
+}
+
+func breakGlassAccessErrorCode(err error) int {
+	if errors.Is(err, common.ErrDatabaseUserNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, accessPermissionUsecase.ErrUserDisabled) || errors.Is(err, accessPermissionUsecase.ErrInvalidRole) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
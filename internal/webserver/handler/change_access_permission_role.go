@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessPermissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const opChangeAccessPermissionRole = "change-access-permission-role"
+
+var changeAccessPermissionRoleUC *accessPermissionUsecase.ChangeAccessPermissionRoleUseCase
+
+// ChangeAccessPermissionRoleHandler godoc
+// @BasePath /api/v1
+// @Summary Change the role of a user in the database of an access permission
+// @Description Grant the privileges of the role in the database in place of the ones of the current role, without revoking the access of the user. Choosing the user's own role makes the permission follow it again.
+// @Description The application role can't be given per database (400), and the permissions of instances of ecosystems that require approval can't be changed (403).
+// @Description The own role of the user applies to the whole instance, so a role with no privilege beyond it is refused (400), except in the instances where the user has scoped access.
+// @Tags Access Permission
+// @Accept json
+// @Produce json
+// @Param id query string true "Access permission ID"
+// @Param request body dto.ChangeAccessPermissionRoleInputDTO true "Request body"
+// @Success 200 {object} AccessPermissionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-permission/change-role [post]
+// @Security ApiKeyAuth
+func ChangeAccessPermissionRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.ChangeAccessPermissionRoleInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := changeAccessPermissionRoleUC.Execute(input, id, userID)
+	if err != nil {
+		log.Printf("error changing the role of access permission: %v", err.Error())
+		sendError(w, changeAccessPermissionRoleErrorCode(err), buildErrorMessage(opChangeAccessPermissionRole, err))
+		return
+	}
+
+	sendSuccess(w, opChangeAccessPermissionRole, output)
+}
+
+func changeAccessPermissionRoleErrorCode(err error) int {
+	switch {
+	case errors.Is(err, accessPermissionUsecase.ErrAccessPermissionNotFound),
+		errors.Is(err, accessPermissionUsecase.ErrDatabaseRoleNotFound),
+		errors.Is(err, common.ErrDatabaseUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, accessPermissionUsecase.ErrAccessApprovalRequired),
+		errors.Is(err, accessPermissionUsecase.ErrDatabaseForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, accessPermissionUsecase.ErrApplicationRoleNotAllowed),
+		errors.Is(err, accessPermissionUsecase.ErrDatabaseRoleCoveredByOwn),
+		errors.Is(err, accessPermissionUsecase.ErrSameDatabaseRole),
+		errors.Is(err, accessPermissionUsecase.ErrInstanceDisabled):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const opCloseRecertificationCampaign = "close-recertification-campaign"
+
+var closeRecertificationCampaignUC *recertificationUsecase.CloseRecertificationCampaignUseCase
+
+// CloseRecertificationCampaignHandler godoc
+// @BasePath /api/v1
+// @Summary Close an access recertification campaign, revoking the permissions decided to be revoked
+// @Description Revoke the access of the items decided as REVOKE and close the campaign, so no more decisions are accepted. Items still pending are kept.
+// @Description Only the database of each item is revoked, the other permissions of the user in the instance are kept. The user is removed from the instance only when no permission is left there. A permission removed since the review isn't revoked, so an access granted again afterward is kept.
+// @Description When a revocation fails, it's returned with hasErrors and the campaign is kept open: closing it again retries the revocations still pending.
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param id query string true "Recertification campaign ID"
+// @Success 200 {object} CloseRecertificationCampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign/close [post]
+// @Security ApiKeyAuth
+func CloseRecertificationCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := closeRecertificationCampaignUC.Execute(id, userID)
+	if err != nil {
+		sendError(w, recertificationErrorCode(err), buildErrorMessage(opCloseRecertificationCampaign, err))
+		return
+	}
+
+	sendSuccess(w, opCloseRecertificationCampaign, output)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const opCreateAccessGroup = "create-access-group"
+
+var createAccessGroupUC *accessGroupUsecase.CreateAccessGroupUseCase
+
+// CreateAccessGroupHandler godoc
+// @BasePath /api/v1
+// @Summary Create an access group
+// @Description Create an access group with its targets, the instances and databases its members get access to. An instance without databases targets all its enabled databases.
+// @Description Instances of ecosystems that require approval can't be targets. The group is created without members, so nothing is granted.
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param request body dto.AccessGroupInputDTO true "Request body"
+// @Success 201 {object} AccessGroupResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-group [post]
+// @Security ApiKeyAuth
+func CreateAccessGroupHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.AccessGroupInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := createAccessGroupUC.Execute(input, userID)
+	if err != nil {
+		sendError(w, accessGroupErrorCode(err), buildErrorMessage(opCreateAccessGroup, err))
+		return
+	}
+
+	sendCreated(w, opCreateAccessGroup, output)
+}
+
+func accessGroupErrorCode(err error) int {
+	switch {
+	case errors.Is(err, accessGroupUsecase.ErrAccessGroupNotFound),
+		errors.Is(err, accessGroupUsecase.ErrNotAccessGroupMember),
+		errors.Is(err, accessGroupUsecase.ErrTargetInstanceNotFound),
+		errors.Is(err, accessGroupUsecase.ErrTargetDatabaseNotFound),
+		errors.Is(err, common.ErrDatabaseUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, accessGroupUsecase.ErrTargetRequiresApproval):
+		return http.StatusForbidden
+	case errors.Is(err, accessGroupUsecase.ErrAccessGroupAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, accessGroupUsecase.ErrInstanceInformedTwice):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
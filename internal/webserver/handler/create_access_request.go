@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
+)
+
+const opCreateAccessRequest = "create-access-request"
+
+var createAccessRequestUC *accessRequestUsecase.CreateAccessRequestUseCase
+
+// CreateAccessRequestHandler godoc
+// @BasePath /api/v1
+// @Summary Request access to a set of instances and their respective databases
+// @Description Request the same access of a grant, with a justification. The access is granted once the request is approved by another user, the only way to grant access to instances of ecosystems that require approval.
+// @Description The request expires when not reviewed within ACCESS_REQUEST_TTL, or earlier when the access requested expires before that.
+// @Tags Access Request
+// @Accept json
+// @Produce json
+// @Param request body dto.AccessRequestInputDTO true "Request body"
+// @Success 201 {object} AccessRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-request [post]
+// @Security ApiKeyAuth
+func CreateAccessRequestHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.AccessRequestInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := createAccessRequestUC.Execute(input, userID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opCreateAccessRequest, err))
+		return
+	}
+
+	sendCreated(w, opCreateAccessRequest, output)
+}
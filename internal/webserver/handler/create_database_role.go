@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
+)
+
+const opCreateDatabaseRole = "create-database-role"
+
+var createDatabaseRoleUC *roleUsecase.CreateDatabaseRoleUseCase
+
+// CreateDatabaseRoleHandler godoc
+// @BasePath /api/v1
+// @Summary Create a database role
+// @Description Create a new database role with its privileges, in the vocabulary of PostgreSQL, which the connectors translate to each technology.
+// @Description The role must be propagated to the instances and the grants set up in their databases before being given to users.
+// @Tags Database Role
+// @Accept json
+// @Produce json
+// @Param request body dto.DatabaseRoleInputDTO true "Request body"
+// @Success 201 {object} CreateDatabaseRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-role [post]
+// @Security ApiKeyAuth
+func CreateDatabaseRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.DatabaseRoleInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	outputRole, err := createDatabaseRoleUC.Execute(input, userID)
+	if err != nil {
+		sendError(w, databaseRoleErrorCode(err), buildErrorMessage(opCreateDatabaseRole, err))
+		return
+	}
+
+	sendCreated(w, opCreateDatabaseRole, outputRole)
+}
+
+func databaseRoleErrorCode(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrInvalidRoleName),
+		errors.Is(err, entity.ErrInvalidDisplayName),
+		errors.Is(err, entity.ErrInvalidRolePrivilege),
+		errors.Is(err, entity.ErrRoleWithoutPrivileges):
+		return http.StatusBadRequest
+	case errors.Is(err, common.ErrDatabaseRoleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, roleUsecase.ErrRoleNameAlreadyExists),
+		errors.Is(err, roleUsecase.ErrPredefinedRoleDeletion),
+		errors.Is(err, roleUsecase.ErrDatabaseRoleInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
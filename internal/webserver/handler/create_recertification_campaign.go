@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const opCreateRecertificationCampaign = "create-recertification-campaign"
+
+var createRecertificationCampaignUC *recertificationUsecase.CreateRecertificationCampaignUseCase
+
+// CreateRecertificationCampaignHandler godoc
+// @BasePath /api/v1
+// @Summary Create an access recertification campaign
+// @Description Take a snapshot of the current access permissions, all of them or those of an ecosystem and/or of the database users of a team, and assign them to the reviewers.
+// @Description All permissions of a database user are assigned to the same reviewer, the users are distributed among the reviewers in turn.
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param request body dto.RecertificationCampaignInputDTO true "Request body"
+// @Success 201 {object} RecertificationCampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign [post]
+// @Security ApiKeyAuth
+func CreateRecertificationCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.RecertificationCampaignInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := createRecertificationCampaignUC.Execute(input, userID)
+	if err != nil {
+		sendError(w, createRecertificationCampaignErrorCode(err), buildErrorMessage(opCreateRecertificationCampaign, err))
+		return
+	}
+
+	sendCreated(w, opCreateRecertificationCampaign, output)
+}
+
+func createRecertificationCampaignErrorCode(err error) int {
+	switch {
+	case errors.Is(err, recertificationUsecase.ErrReviewerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, recertificationUsecase.ErrNoAccessPermissionsToReview):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
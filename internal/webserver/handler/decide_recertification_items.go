@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const opDecideRecertificationItems = "decide-recertification-items"
+
+var decideRecertificationItemsUC *recertificationUsecase.DecideRecertificationItemsUseCase
+
+// DecideRecertificationItemsHandler godoc
+// @BasePath /api/v1
+// @Summary Record the decisions of a reviewer in an access recertification campaign
+// @Description Record whether each permission should be kept or revoked. Only the reviewer assigned to an item can decide it, and the decision may be changed while the campaign is open.
+// @Description No decision is recorded when any of the items is invalid. The revocations run when the campaign is closed.
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param id query string true "Recertification campaign ID"
+// @Param request body dto.DecideRecertificationItemsInputDTO true "Request body"
+// @Success 200 {object} RecertificationCampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign/decide [post]
+// @Security ApiKeyAuth
+func DecideRecertificationItemsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.DecideRecertificationItemsInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := decideRecertificationItemsUC.Execute(id, input, userID)
+	if err != nil {
+		sendError(w, recertificationErrorCode(err), buildErrorMessage(opDecideRecertificationItems, err))
+		return
+	}
+
+	sendSuccess(w, opDecideRecertificationItems, output)
+}
+
+func recertificationErrorCode(err error) int {
+	switch {
+	case errors.Is(err, recertificationUsecase.ErrRecertificationCampaignNotFound),
+		errors.Is(err, recertificationUsecase.ErrRecertificationItemNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrRecertificationItemOfOtherReviewer):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrRecertificationCampaignClosed):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrInvalidRecertificationDecision):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
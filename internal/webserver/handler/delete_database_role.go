@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
+)
+
+const opDeleteDatabaseRole = "delete-database-role"
+
+var deleteDatabaseRoleUC *roleUsecase.DeleteDatabaseRoleUseCase
+
+// DeleteDatabaseRoleHandler godoc
+// @Summary Delete a database role
+// @Description Delete a database role that no database user or access permission uses. The predefined roles can't be deleted.
+// @Tags Database Role
+// @Accept json
+// @Produce json
+// @Param id query string true "Database Role ID"
+// @Success 200 {object} DeleteDatabaseRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-role [delete]
+// @Security ApiKeyAuth
+func DeleteDatabaseRoleHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	if err := deleteDatabaseRoleUC.Execute(id, userID); err != nil {
+		sendError(w, databaseRoleErrorCode(err), buildErrorMessage(opDeleteDatabaseRole, err))
+		return
+	}
+
+	sendSuccess(w, opDeleteDatabaseRole, nil)
+}
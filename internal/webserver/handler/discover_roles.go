@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	usecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_instance"
+)
+
+const (
+	opDiscoverRoles = "discover-roles"
+)
+
+var discoverRolesUC *usecase.DiscoverRolesUseCase
+
+// DiscoverRolesHandler godoc
+// @BasePath /api/v1
+// @Summary Discover the logins of the selected database instances, if instances ids are not provided, discover in all enabled instances
+// @Description Reads the logins of each instance and classifies them as MANAGED, MISMATCHED (a Data Guard user whose permissions don't match), UNKNOWN (created outside Data Guard), PRIVILEGED (CREATEROLE or BYPASSRLS) or SUPERUSER. The findings replace the ones of the previous discovery of the instance and are listed by the role findings endpoint.
+// @Tags Database Instance
+// @Accept json
+// @Produce json
+// @Param request body dto.DiscoverRolesInputDTO false "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
+// @Success 200 {object} DiscoverRolesResponse
+// @Success 202 {object} SubmitJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-instance/discover-roles [post]
+// @Security ApiKeyAuth
+func DiscoverRolesHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.DiscoverRolesInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	for _, id := range input.DatabaseInstancesIDs {
+		if !validateUUID(w, id, "databaseInstancesIds list contains a value that") {
+			return
+		}
+	}
+
+	if async {
+		submitJob(w, opDiscoverRoles, entity.JobTypeDiscoverRoles, input, userID)
+		return
+	}
+
+	outputs, err := discoverRolesUC.Execute(input, userID)
+	if err != nil && errors.Is(err, usecase.ErrNoDatabaseInstancesFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opDiscoverRoles, err))
+		return
+	}
+	if err != nil {
+		log.Printf("error in operation %s: %v", opDiscoverRoles, err)
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opDiscoverRoles, err))
+		return
+	}
+
+	sendSuccessList(w, opDiscoverRoles, outputs, len(outputs), 0, 0)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+)
+
+const opGetAccessGroup = "get-access-group"
+
+var getAccessGroupUC *accessGroupUsecase.GetAccessGroupUseCase
+
+// GetAccessGroupHandler godoc
+// @BasePath /api/v1
+// @Summary Get an access group
+// @Description Get an access group with its targets and members
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param id query string true "Access group ID"
+// @Success 200 {object} AccessGroupResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-group [get]
+// @Security ApiKeyAuth
+func GetAccessGroupHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := getAccessGroupUC.Execute(id)
+	if err != nil {
+		sendError(w, accessGroupErrorCode(err), buildErrorMessage(opGetAccessGroup, err))
+		return
+	}
+
+	sendSuccess(w, opGetAccessGroup, output)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
+)
+
+const opGetAccessRequest = "get-access-request"
+
+var getAccessRequestUC *accessRequestUsecase.GetAccessRequestUseCase
+
+// GetAccessRequestHandler godoc
+// @BasePath /api/v1
+// @Summary Get an access request
+// @Description Get an access request with its trail: the access requested and its justification, the review and the result of the grant once approved
+// @Tags Access Request
+// @Accept json
+// @Produce json
+// @Param id query string true "Access request ID"
+// @Success 200 {object} AccessRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-request [get]
+// @Security ApiKeyAuth
+func GetAccessRequestHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := getAccessRequestUC.Execute(id)
+	if err != nil && errors.Is(err, accessRequestUsecase.ErrAccessRequestNotFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opGetAccessRequest, err))
+		return
+	}
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opGetAccessRequest, err))
+		return
+	}
+
+	sendSuccess(w, opGetAccessRequest, output)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
+)
+
+const opGetDatabaseRole = "get-database-role"
+
+var getDatabaseRoleUC *roleUsecase.GetDatabaseRoleUseCase
+
+// GetDatabaseRoleHandler godoc
+// @BasePath /api/v1
+// @Summary Get a database role
+// @Description Get an existing database role with its privileges
+// @Tags Database Role
+// @Accept json
+// @Produce json
+// @Param id query string true "Database Role ID"
+// @Success 200 {object} GetDatabaseRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-role [get]
+// @Security ApiKeyAuth
+func GetDatabaseRoleHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	outputRole, err := getDatabaseRoleUC.Execute(id)
+	if err != nil {
+		sendError(w, databaseRoleErrorCode(err), buildErrorMessage(opGetDatabaseRole, err))
+		return
+	}
+
+	sendSuccess(w, opGetDatabaseRole, outputRole)
+}
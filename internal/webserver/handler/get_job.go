@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	jobUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/job"
+)
+
+const opGetJob = "get-job"
+
+var getJobUC *jobUsecase.GetJobUseCase
+
+// GetJobHandler godoc
+// @BasePath /api/v1
+// @Summary Get a job
+// @Description Get a job with its status, progress counters, the result of each processed item and the result of the operation once finished
+// @Tags Job
+// @Accept json
+// @Produce json
+// @Param id query string true "Job ID"
+// @Success 200 {object} GetJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /job [get]
+// @Security ApiKeyAuth
+func GetJobHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	outputJob, err := getJobUC.Execute(id)
+	if err != nil && errors.Is(err, jobUsecase.ErrJobNotFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opGetJob, err))
+		return
+	}
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opGetJob, err))
+		return
+	}
+
+	sendSuccess(w, opGetJob, outputJob)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const opGetRecertificationCampaign = "get-recertification-campaign"
+
+var getRecertificationCampaignUC *recertificationUsecase.GetRecertificationCampaignUseCase
+
+// GetRecertificationCampaignHandler godoc
+// @BasePath /api/v1
+// @Summary Get an access recertification campaign
+// @Description Get a recertification campaign with the count of its items by decision
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param id query string true "Recertification campaign ID"
+// @Success 200 {object} RecertificationCampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign [get]
+// @Security ApiKeyAuth
+func GetRecertificationCampaignHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := getRecertificationCampaignUC.Execute(id)
+	if err != nil && errors.Is(err, recertificationUsecase.ErrRecertificationCampaignNotFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opGetRecertificationCampaign, err))
+		return
+	}
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opGetRecertificationCampaign, err))
+		return
+	}
+
+	sendSuccess(w, opGetRecertificationCampaign, output)
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const (
+	opGetRecertificationReport = "get-recertification-report"
+	paramFormat                = "format"
+	formatJSON                 = "json"
+	formatCSV                  = "csv"
+)
+
+var getRecertificationReportUC *recertificationUsecase.GetRecertificationReportUseCase
+
+// GetRecertificationReportHandler godoc
+// @BasePath /api/v1
+// @Summary Export the evidence report of an access recertification campaign
+// @Description Export every permission reviewed in the campaign, as it was when the campaign was created, with the reviewer, the decision and its comment and the result of the revocation.
+// @Description With format csv the report is downloaded as a CSV file, one line per permission.
+// @Tags Recertification
+// @Accept json
+// @Produce json,text/csv
+// @Param id query string true "Recertification campaign ID"
+// @Param format query string false "Format of the report, json by default" Enums(json, csv)
+// @Success 200 {object} RecertificationReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign/report [get]
+// @Security ApiKeyAuth
+func GetRecertificationReportHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+	format := r.URL.Query().Get(paramFormat)
+	if format != emptyString && format != formatJSON && format != formatCSV {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s must be %s or %s", paramFormat, formatJSON, formatCSV))
+		return
+	}
+
+	output, err := getRecertificationReportUC.Execute(id)
+	if err != nil && errors.Is(err, recertificationUsecase.ErrRecertificationCampaignNotFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opGetRecertificationReport, err))
+		return
+	}
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opGetRecertificationReport, err))
+		return
+	}
+
+	if format == formatCSV {
+		sendRecertificationReportCSV(w, output)
+		return
+	}
+	sendSuccess(w, opGetRecertificationReport, output)
+}
+
+func sendRecertificationReportCSV(w http.ResponseWriter, report *dto.RecertificationReportOutputDTO) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"recertification-%s.csv\"", report.Campaign.ID))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{
+		"campaign", "campaignStatus", "accessPermissionId", "databaseUser", "databaseUserEmail", "team", "role", "ecosystem",
+		"databaseInstance", "database", "grantedBy", "grantedAt", "expiresAt", "reviewer", "decision", "comment", "decidedAt",
+		"revocationResult",
+	})
+	for _, item := range report.Items {
+		p := item.Permission
+		_ = writer.Write([]string{
+			report.Campaign.Name,
+			report.Campaign.Status,
+			p.ID,
+			p.DatabaseUserName,
+			p.DatabaseUserEmail,
+			p.DatabaseUserTeam,
+			p.DatabaseRoleName,
+			p.EcosystemName,
+			p.DatabaseInstanceName,
+			p.DatabaseName,
+			p.GrantedByUserName,
+			p.GrantedAt.Format(time.RFC3339),
+			formatOptionalTime(p.ExpiresAt),
+			item.Reviewer,
+			item.Decision,
+			item.Comment,
+			formatOptionalTime(item.DecidedAt),
+			item.RevocationResult,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("error writing the report of recertification campaign %s. Cause: %v", report.Campaign.ID, err)
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return emptyString
+	}
+	return t.Format(time.RFC3339)
+}
@@ -2,27 +2,40 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	accessPermissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
 )
 
 var grantAccessPermissionUC *accessPermissionUsecase.GrantAccessPermissionUseCase
 
-const opGrantAccess = "grant-access"
+const (
+	opGrantAccess       = "grant-access"
+	opStreamGrantAccess = "stream-grant-access"
+)
 
 // GrantAccessHandler godoc
 // @BasePath /api/v1
 // @Summary Grant connection access to a set of users to a set of instances and their respective databases
 // @Description Grant connection access to a set of users to a set of instances and their respective databases
+// @Description The access to instances of ecosystems that require approval is refused (403), it must be requested through an access request
+// @Description The users have their own role in the databases, unless a role is informed for a database in databasesRolesIds. The application role can't be informed per database.
+// @Description The own role of a user applies to the whole instance, so a role with no privilege beyond it is refused (400), except in the instances where the user has scoped access.
+// @Description With dryRun, the grant is validated and the statements it would execute are returned in the plan, by instance and database. Nothing is executed in the instances nor persisted.
 // @Tags Access Permission
 // @Accept json
 // @Produce json
 // @Param request body dto.GrantAccessInputDTO true "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
 // @Success 200 {object} GrantAccessResponse
+// @Success 202 {object} SubmitJobResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /access-permission/grant [post]
@@ -32,6 +45,10 @@ func GrantAccessHandler(w http.ResponseWriter, r *http.Request) {
 	if hasError || userID == emptyString {
 		return
 	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
 
 	var input dto.GrantAccessInputDTO
 	err := json.NewDecoder(r.Body).Decode(&input)
@@ -47,12 +64,71 @@ func GrantAccessHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if async {
+		submitJob(w, opGrantAccess, entity.JobTypeGrantAccess, input, userID)
+		return
+	}
+
 	output, err := grantAccessPermissionUC.Execute(input, userID)
 	if err != nil {
 		log.Printf("error granting access: %v", err.Error())
-		sendError(w, http.StatusInternalServerError, buildErrorMessage(opGrantAccess, err))
+		sendError(w, grantAccessErrorCode(err), buildErrorMessage(opGrantAccess, err))
 		return
 	}
 
 	sendSuccess(w, opGrantAccess, output)
 }
+
+// StreamGrantAccessHandler godoc
+// @BasePath /api/v1
+// @Summary Grant connection access streaming the progress of the operation as Server-Sent Events
+// @Description Same as the grant of access, answered with a text/event-stream. Each message about an instance, a user or a database is sent as a "progress" event (dto.ProgressEventDTO) and each user processed in an instance as an "item" event (dto.ItemProgressEventDTO).
+// @Description The stream ends with a "result" event with the dto.GrantAccessOutputDTO, or an "error" event with the body of an error response.
+// @Tags Access Permission
+// @Accept json
+// @Produce text/event-stream
+// @Param request body dto.GrantAccessInputDTO true "Request body"
+// @Success 200 {object} dto.GrantAccessOutputDTO "Data of the result event"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-permission/grant/stream [post]
+// @Security ApiKeyAuth
+func StreamGrantAccessHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.GrantAccessInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	streamProgress(w, r, opStreamGrantAccess, func(progress common.ProgressReporter) (any, error) {
+		return grantAccessPermissionUC.ExecuteWithProgress(input, userID, progress)
+	}, grantAccessErrorCode)
+}
+
+func grantAccessErrorCode(err error) int {
+	switch {
+	case errors.Is(err, accessPermissionUsecase.ErrAccessApprovalRequired):
+		return http.StatusForbidden
+	case errors.Is(err, accessPermissionUsecase.ErrDatabaseRoleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, accessPermissionUsecase.ErrApplicationRoleNotAllowed),
+		errors.Is(err, accessPermissionUsecase.ErrDatabaseRoleCoveredByOwn),
+		errors.Is(err, entity.ErrInvalidScopeObject):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -1,14 +1,22 @@
 package handler
 
 import (
+	"log"
+
 	"github.com/zgsolucoes/zg-data-guard/config"
 	database "github.com/zgsolucoes/zg-data-guard/internal/database/storage"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
 	permissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
 	databaseUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database"
 	dbInstanceUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_instance"
 	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
 	databaseUserUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_user"
+	desiredStateUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/desired_state"
 	ecosystemUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/ecosystem"
+	jobUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/job"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
 	technologyUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/technology"
 	userUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/user"
 )
@@ -24,11 +32,18 @@ var (
 	dbUserStorage           database.DatabaseUserStorage
 	accessStorage           database.AccessPermissionStorage
 	forbiddenObjectsStorage database.ForbiddenObjectsStorage
+	jobStorage              database.JobStorage
+	accessRequestStorage    database.AccessRequestStorage
+	breakGlassStorage       database.BreakGlassAccessStorage
+	recertificationStorage  database.RecertificationCampaignStorage
+	accessGroupStorage      database.AccessGroupStorage
+	roleFindingStorage      database.RoleFindingStorage
 )
 
 func InitializeAPIDependencies() {
 	initializeEntityStorages()
 	initializeUseCases()
+	startScheduledTasks()
 }
 
 func initializeEntityStorages() {
@@ -42,17 +57,28 @@ func initializeEntityStorages() {
 	dbUserStorage = database.NewPostgresDatabaseUserStorage(db)
 	accessStorage = database.NewPostgresAccessPermissionStorage(db)
 	forbiddenObjectsStorage = database.NewPostgresForbiddenObjectsStorage(db)
+	jobStorage = database.NewPostgresJobStorage(db)
+	accessRequestStorage = database.NewPostgresAccessRequestStorage(db)
+	breakGlassStorage = database.NewPostgresBreakGlassAccessStorage(db)
+	recertificationStorage = database.NewPostgresRecertificationCampaignStorage(db)
+	accessGroupStorage = database.NewPostgresAccessGroupStorage(db)
+	roleFindingStorage = database.NewPostgresRoleFindingStorage(db)
 }
 
 func initializeUseCases() {
 	initializeUserUseCases(appUserStorage)
 	initializeEcosystemUseCases(ecosystemStorage, appUserStorage)
 	initializeTechnologyUseCases(technologyStorage, appUserStorage)
-	initializeDatabaseInstanceUseCases(instanceStorage, ecosystemStorage, technologyStorage, databaseStorage, roleStorage, accessStorage)
+	initializeDatabaseInstanceUseCases(instanceStorage, ecosystemStorage, technologyStorage, databaseStorage, roleStorage, accessStorage, dbUserStorage, roleFindingStorage)
 	initializeDatabaseUseCases(instanceStorage, databaseStorage)
 	initializeDatabaseRoleUseCases(roleStorage)
-	initializeAccessPermissionUseCases(accessStorage, dbUserStorage, instanceStorage, databaseStorage, forbiddenObjectsStorage)
+	initializeAccessPermissionUseCases(accessStorage, dbUserStorage, instanceStorage, databaseStorage, forbiddenObjectsStorage, appUserStorage)
 	initializeDatabaseUserUseCases(dbUserStorage, roleStorage, accessStorage)
+	initializeAccessRequestUseCases(accessRequestStorage)
+	initializeRecertificationUseCases(recertificationStorage, accessStorage, appUserStorage)
+	initializeAccessGroupUseCases(accessGroupStorage, instanceStorage, databaseStorage, dbUserStorage, accessStorage)
+	initializeDesiredStateUseCases()
+	initializeJobUseCases(jobStorage)
 }
 
 func initializeUserUseCases(appUserStorage database.ApplicationUserStorage) {
@@ -82,6 +108,8 @@ func initializeDatabaseInstanceUseCases(
 	databaseStorage database.DatabaseStorage,
 	roleStorage database.DatabaseRoleStorage,
 	accessStorage database.AccessPermissionStorage,
+	dbUserStorage database.DatabaseUserStorage,
+	roleFindingStorage database.RoleFindingStorage,
 ) {
 	createDBInstanceUC = dbInstanceUsecase.NewCreateDatabaseInstanceUseCase(dbInstanceStorage, ecosystemStorage, technologyStorage)
 	getDBInstanceUC = dbInstanceUsecase.NewGetDatabaseInstanceUseCase(dbInstanceStorage)
@@ -91,16 +119,26 @@ func initializeDatabaseInstanceUseCases(
 	syncDatabasesUC = databaseUsecase.NewSyncDatabasesUseCase(dbInstanceStorage, databaseStorage)
 	propagateRolesUC = dbInstanceUsecase.NewPropagateRolesUseCase(dbInstanceStorage, roleStorage)
 	changeStatusInstanceUC = dbInstanceUsecase.NewChangeStatusDatabaseInstanceUseCase(dbInstanceStorage, databaseStorage, accessStorage)
+	discoverRolesUC = dbInstanceUsecase.NewDiscoverRolesUseCase(dbInstanceStorage, dbUserStorage, accessStorage, roleFindingStorage)
+	listRoleFindingsUC = dbInstanceUsecase.NewListRoleFindingsUseCase(roleFindingStorage)
 }
 
 func initializeDatabaseUseCases(dbInstanceStorage database.DatabaseInstanceStorage, databaseStorage database.DatabaseStorage) {
 	getDatabaseUC = databaseUsecase.NewGetDatabaseUseCase(databaseStorage)
 	listDatabasesUC = databaseUsecase.NewListDatabasesUseCase(databaseStorage)
-	setupRolesUC = databaseUsecase.NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage)
+	setupRolesUC = databaseUsecase.NewSetupRolesInDatabasesUseCase(dbInstanceStorage, databaseStorage, roleStorage)
+	listOutdatedRolesDatabasesUC = databaseUsecase.NewListOutdatedRolesDatabasesUseCase(databaseStorage, roleStorage)
 }
 
 func initializeDatabaseRoleUseCases(roleStorage database.DatabaseRoleStorage) {
+	if err := roleUsecase.LoadKnownRoleNames(roleStorage); err != nil {
+		log.Printf("Only the predefined database roles will be recognized until the names are loaded again")
+	}
 	listDatabaseRolesUC = roleUsecase.NewListDatabaseRolesUseCase(roleStorage)
+	createDatabaseRoleUC = roleUsecase.NewCreateDatabaseRoleUseCase(roleStorage)
+	getDatabaseRoleUC = roleUsecase.NewGetDatabaseRoleUseCase(roleStorage)
+	updateDatabaseRoleUC = roleUsecase.NewUpdateDatabaseRoleUseCase(roleStorage)
+	deleteDatabaseRoleUC = roleUsecase.NewDeleteDatabaseRoleUseCase(roleStorage)
 }
 
 func initializeDatabaseUserUseCases(
@@ -121,9 +159,88 @@ func initializeAccessPermissionUseCases(
 	dbInstanceStorage database.DatabaseInstanceStorage,
 	databaseStorage database.DatabaseStorage,
 	forbiddenStorage database.ForbiddenObjectsStorage,
+	appUserStorage database.ApplicationUserStorage,
 ) {
-	grantAccessPermissionUC = permissionUsecase.NewGrantAccessPermissionUseCase(accessStorage, dbUserStorage, dbInstanceStorage, databaseStorage, forbiddenStorage)
+	grantAccessPermissionUC = permissionUsecase.NewGrantAccessPermissionUseCase(accessStorage, dbUserStorage, dbInstanceStorage, databaseStorage, forbiddenStorage, roleStorage)
+	changeAccessPermissionRoleUC = permissionUsecase.NewChangeAccessPermissionRoleUseCase(accessStorage, roleStorage, dbUserStorage, dbInstanceStorage, forbiddenStorage)
+	migrateDatabaseUserRoleUC = permissionUsecase.NewMigrateDatabaseUserRoleUseCase(accessStorage, roleStorage, dbUserStorage, dbInstanceStorage)
 	listAccessPermissionsUC = permissionUsecase.NewListAccessPermissionsUseCase(accessStorage)
 	listAccessPermissionLogsUC = permissionUsecase.NewListAccessPermissionLogsUseCase(accessStorage)
-	revokeAccessPermissionUC = permissionUsecase.NewRevokeAccessPermissionUseCase(accessStorage, dbInstanceStorage, dbUserStorage)
+	revokeAccessPermissionUC = permissionUsecase.NewRevokeAccessPermissionUseCase(accessStorage, dbInstanceStorage, dbUserStorage, breakGlassStorage)
+	revokeExpiredAccessPermissionsUC = permissionUsecase.NewRevokeExpiredAccessPermissionsUseCase(accessStorage, appUserStorage, revokeAccessPermissionUC)
+	breakGlassAccessUC = permissionUsecase.NewBreakGlassAccessUseCase(accessStorage, breakGlassStorage, dbUserStorage, dbInstanceStorage, databaseStorage,
+		forbiddenStorage, appUserStorage, config.GetNotifier(), config.GetBreakGlassWindow())
+	revokeExpiredBreakGlassAccessUC = permissionUsecase.NewRevokeExpiredBreakGlassAccessUseCase(accessStorage, breakGlassStorage, dbInstanceStorage, appUserStorage, config.GetNotifier())
+	reconcileAccessUC = permissionUsecase.NewReconcileAccessPermissionsUseCase(accessStorage, dbUserStorage, dbInstanceStorage, roleStorage)
+}
+
+func initializeAccessRequestUseCases(accessRequestStorage database.AccessRequestStorage) {
+	createAccessRequestUC = accessRequestUsecase.NewCreateAccessRequestUseCase(accessRequestStorage, config.GetAccessRequestTTL())
+	approveAccessRequestUC = accessRequestUsecase.NewApproveAccessRequestUseCase(accessRequestStorage, grantAccessPermissionUC)
+	rejectAccessRequestUC = accessRequestUsecase.NewRejectAccessRequestUseCase(accessRequestStorage)
+	getAccessRequestUC = accessRequestUsecase.NewGetAccessRequestUseCase(accessRequestStorage)
+	listAccessRequestsUC = accessRequestUsecase.NewListAccessRequestsUseCase(accessRequestStorage)
+	expireAccessRequestsUC = accessRequestUsecase.NewExpireAccessRequestsUseCase(accessRequestStorage)
+}
+
+func initializeRecertificationUseCases(
+	recertificationStorage database.RecertificationCampaignStorage,
+	accessStorage database.AccessPermissionStorage,
+	appUserStorage database.ApplicationUserStorage,
+) {
+	createRecertificationCampaignUC = recertificationUsecase.NewCreateRecertificationCampaignUseCase(recertificationStorage, accessStorage, appUserStorage)
+	getRecertificationCampaignUC = recertificationUsecase.NewGetRecertificationCampaignUseCase(recertificationStorage)
+	listRecertificationCampaignsUC = recertificationUsecase.NewListRecertificationCampaignsUseCase(recertificationStorage)
+	listRecertificationItemsUC = recertificationUsecase.NewListRecertificationItemsUseCase(recertificationStorage)
+	decideRecertificationItemsUC = recertificationUsecase.NewDecideRecertificationItemsUseCase(recertificationStorage)
+	closeRecertificationCampaignUC = recertificationUsecase.NewCloseRecertificationCampaignUseCase(recertificationStorage, accessStorage, revokeAccessPermissionUC)
+	getRecertificationReportUC = recertificationUsecase.NewGetRecertificationReportUseCase(recertificationStorage)
+}
+
+func initializeAccessGroupUseCases(
+	accessGroupStorage database.AccessGroupStorage,
+	dbInstanceStorage database.DatabaseInstanceStorage,
+	databaseStorage database.DatabaseStorage,
+	dbUserStorage database.DatabaseUserStorage,
+	accessStorage database.AccessPermissionStorage,
+) {
+	createAccessGroupUC = accessGroupUsecase.NewCreateAccessGroupUseCase(accessGroupStorage, dbInstanceStorage, databaseStorage)
+	getAccessGroupUC = accessGroupUsecase.NewGetAccessGroupUseCase(accessGroupStorage)
+	listAccessGroupsUC = accessGroupUsecase.NewListAccessGroupsUseCase(accessGroupStorage)
+	updateAccessGroupTargetsUC = accessGroupUsecase.NewUpdateAccessGroupTargetsUseCase(accessGroupStorage, dbInstanceStorage, databaseStorage, accessStorage,
+		grantAccessPermissionUC, revokeAccessPermissionUC)
+	addAccessGroupMembersUC = accessGroupUsecase.NewAddAccessGroupMembersUseCase(accessGroupStorage, dbUserStorage, grantAccessPermissionUC)
+	removeAccessGroupMemberUC = accessGroupUsecase.NewRemoveAccessGroupMemberUseCase(accessGroupStorage, accessStorage, revokeAccessPermissionUC)
+}
+
+// initializeDesiredStateUseCases godoc
+// The desired state is applied through the use cases of the API, so it must be called after they are initialized
+func initializeDesiredStateUseCases() {
+	planDesiredStateUC = desiredStateUsecase.NewPlanDesiredStateUseCase(ecosystemStorage, technologyStorage, instanceStorage, databaseStorage,
+		dbUserStorage, roleStorage, accessGroupStorage, accessStorage)
+	applyDesiredStateUC = desiredStateUsecase.NewApplyDesiredStateUseCase(planDesiredStateUC,
+		createEcosystemUC, updateEcosystemUC, deleteEcosystemUC,
+		createDBInstanceUC, updateDBInstanceUC, changeStatusInstanceUC,
+		createDBUserUC, updateDBUserUC, changeStatusDBUserUC,
+		createAccessGroupUC, updateAccessGroupTargetsUC, addAccessGroupMembersUC, removeAccessGroupMemberUC,
+		grantAccessPermissionUC, revokeAccessPermissionUC)
+}
+
+// initializeJobUseCases godoc
+// Registers the operations that can be processed in background and runs again the jobs interrupted by the last shutdown,
+// so it must be called after the use cases of these operations are initialized
+func initializeJobUseCases(jobStorage database.JobStorage) {
+	jobRunner = jobUsecase.NewJobRunner(jobStorage)
+	jobRunner.Register(entity.JobTypeGrantAccess, jobUsecase.NewJobFunc(grantAccessPermissionUC.ExecuteWithProgress))
+	jobRunner.Register(entity.JobTypeSyncDatabases, jobUsecase.NewJobFunc(syncDatabasesUC.ExecuteWithProgress))
+	jobRunner.Register(entity.JobTypeSetupRoles, jobUsecase.NewJobFunc(setupRolesUC.ExecuteWithProgress))
+	jobRunner.Register(entity.JobTypePropagateRoles, jobUsecase.NewJobFunc(propagateRolesUC.ExecuteWithProgress))
+	jobRunner.Register(entity.JobTypeDiscoverRoles, jobUsecase.NewJobFunc(discoverRolesUC.ExecuteWithProgress))
+	jobRunner.Register(entity.JobTypeReconcileAccess, jobUsecase.NewJobFunc(reconcileAccessUC.ExecuteWithProgress))
+	getJobUC = jobUsecase.NewGetJobUseCase(jobStorage)
+	listJobsUC = jobUsecase.NewListJobsUseCase(jobStorage)
+
+	if err := jobRunner.RecoverUnfinished(); err != nil {
+		log.Printf("Error recovering the jobs interrupted by the last shutdown. Cause: %v", err)
+	}
 }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+)
+
+const opListAccessGroups = "list-access-groups"
+
+var listAccessGroupsUC *accessGroupUsecase.ListAccessGroupsUseCase
+
+// ListAccessGroupsHandler godoc
+// @BasePath /api/v1
+// @Summary List the access groups
+// @Description List the access groups by name, with the count of their targets and members
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListAccessGroupsResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-groups [get]
+// @Security ApiKeyAuth
+func ListAccessGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	page, limit := getQueryParamPageAndLimit(r)
+
+	groupDTOs, totalCount, err := listAccessGroupsUC.Execute(page, limit)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListAccessGroups, err))
+		return
+	}
+	if groupDTOs == nil {
+		groupDTOs = make([]*dto.AccessGroupOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListAccessGroups, groupDTOs, totalCount, limit, page)
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	usecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
 )
 
@@ -20,6 +22,7 @@ var listAccessPermissionLogsUC *usecase.ListAccessPermissionLogsUseCase
 // @Tags Access Permission
 // @Accept json
 // @Produce json
+// @Param type query string false "Log type" Enums(ACCESS, BREAK_GLASS, RECONCILE)
 // @Param page query int false "Page number"
 // @Param limit query int false "Limit per page"
 // @Success 200 {object} ListAccessPermissionLogsResponse
@@ -28,8 +31,13 @@ var listAccessPermissionLogsUC *usecase.ListAccessPermissionLogsUseCase
 // @Router /access-permission/logs [get]
 // @Security ApiKeyAuth
 func ListAccessPermissionLogsHandler(w http.ResponseWriter, r *http.Request) {
+	logType := r.URL.Query().Get(paramType)
+	if logType != emptyString && !entity.ValidateAccessPermissionLogType(logType) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid access permission log type", paramType))
+		return
+	}
 	page, limit := getQueryParamPageAndLimit(r)
-	logsDTOs, totalLogsCount, err := listAccessPermissionLogsUC.Execute(page, limit)
+	logsDTOs, totalLogsCount, err := listAccessPermissionLogsUC.Execute(logType, page, limit)
 	if err != nil {
 		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListAccessPermissionLogs, err))
 		return
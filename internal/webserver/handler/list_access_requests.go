@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
+)
+
+const (
+	opListAccessRequests   = "list-access-requests"
+	paramRequestedByUserID = "requestedByUserId"
+)
+
+var listAccessRequestsUC *accessRequestUsecase.ListAccessRequestsUseCase
+
+// ListAccessRequestsHandler godoc
+// @BasePath /api/v1
+// @Summary List the access requests
+// @Description List the access requests from the most recent, with their review. The result of the grant of each request is returned by the get access request endpoint.
+// @Tags Access Request
+// @Accept json
+// @Produce json
+// @Param status query string false "Access request status" Enums(REQUESTED, APPROVED, REJECTED, EXECUTED, FAILED, EXPIRED)
+// @Param requestedByUserId query string false "ID of the application user who requested the access"
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListAccessRequestsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-requests [get]
+// @Security ApiKeyAuth
+func ListAccessRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get(paramStatus)
+	if status != emptyString && !entity.ValidateAccessRequestStatus(status) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid access request status", paramStatus))
+		return
+	}
+	requestedByUserID := r.URL.Query().Get(paramRequestedByUserID)
+	if validateUUIDParam(w, requestedByUserID, paramRequestedByUserID) {
+		return
+	}
+	page, limit := getQueryParamPageAndLimit(r)
+
+	accessRequestDTOs, totalCount, err := listAccessRequestsUC.Execute(status, requestedByUserID, page, limit)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListAccessRequests, err))
+		return
+	}
+	if accessRequestDTOs == nil {
+		accessRequestDTOs = make([]*dto.AccessRequestOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListAccessRequests, accessRequestDTOs, totalCount, limit, page)
+}
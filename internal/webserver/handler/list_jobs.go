@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	jobUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/job"
+)
+
+const (
+	opListJobs  = "list-jobs"
+	paramType   = "type"
+	paramStatus = "status"
+)
+
+var listJobsUC *jobUsecase.ListJobsUseCase
+
+// ListJobsHandler godoc
+// @BasePath /api/v1
+// @Summary List the jobs
+// @Description List the jobs from the most recent, with their status and progress counters. The items and the result of each job are returned by the get job endpoint.
+// @Tags Job
+// @Accept json
+// @Produce json
+// @Param type query string false "Job type" Enums(GRANT_ACCESS, SYNC_DATABASES, SETUP_ROLES, PROPAGATE_ROLES, DISCOVER_ROLES, RECONCILE_ACCESS)
+// @Param status query string false "Job status" Enums(PENDING, RUNNING, SUCCEEDED, FAILED)
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListJobsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /jobs [get]
+// @Security ApiKeyAuth
+func ListJobsHandler(w http.ResponseWriter, r *http.Request) {
+	jobType := r.URL.Query().Get(paramType)
+	status := r.URL.Query().Get(paramStatus)
+	if status != emptyString && !entity.ValidateJobStatus(status) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid job status", paramStatus))
+		return
+	}
+	page, limit := getQueryParamPageAndLimit(r)
+
+	jobDTOs, totalJobsCount, err := listJobsUC.Execute(jobType, status, page, limit)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListJobs, err))
+		return
+	}
+	if jobDTOs == nil {
+		jobDTOs = make([]*dto.JobOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListJobs, jobDTOs, totalJobsCount, limit, page)
+}
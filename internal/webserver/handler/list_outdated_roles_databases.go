@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	dbUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database"
+)
+
+const opListOutdatedRolesDatabases = "list-outdated-roles-databases"
+
+var listOutdatedRolesDatabasesUC *dbUsecase.ListOutdatedRolesDatabasesUseCase
+
+// ListOutdatedRolesDatabasesHandler godoc
+// @BasePath /api/v1
+// @Summary List the databases with outdated roles
+// @Description List the enabled databases whose roles weren't set up with the current roles template, i.e. never set up or set up before a change in the roles or in the grants generated by the connectors.
+// @Description Setup roles with onlyOutdated to re-apply the grants only in these databases.
+// @Tags Database
+// @Accept json
+// @Produce json
+// @Param ecosystemId query string false "Ecosystem ID"
+// @Param databaseInstanceId query string false "Database Instance ID"
+// @Success 200 {object} ListOutdatedRolesDatabasesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /databases/outdated-roles [get]
+// @Security ApiKeyAuth
+func ListOutdatedRolesDatabasesHandler(w http.ResponseWriter, r *http.Request) {
+	ecosystemID := r.URL.Query().Get(paramEcosystemID)
+	databaseInstanceID := r.URL.Query().Get(paramDatabaseInstanceID)
+	if (ecosystemID != "" && !validateUUID(w, ecosystemID, paramEcosystemID)) || (databaseInstanceID != "" && !validateUUID(w, databaseInstanceID, paramDatabaseInstanceID)) {
+		return
+	}
+
+	output, err := listOutdatedRolesDatabasesUC.Execute(ecosystemID, databaseInstanceID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListOutdatedRolesDatabases, err))
+		return
+	}
+
+	sendSuccess(w, opListOutdatedRolesDatabases, output)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const opListRecertificationCampaigns = "list-recertification-campaigns"
+
+var listRecertificationCampaignsUC *recertificationUsecase.ListRecertificationCampaignsUseCase
+
+// ListRecertificationCampaignsHandler godoc
+// @BasePath /api/v1
+// @Summary List the access recertification campaigns
+// @Description List the recertification campaigns from the most recent, with the count of their items by decision
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param status query string false "Recertification campaign status" Enums(OPEN, CLOSED)
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListRecertificationCampaignsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaigns [get]
+// @Security ApiKeyAuth
+func ListRecertificationCampaignsHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get(paramStatus)
+	if status != emptyString && !entity.ValidateRecertificationCampaignStatus(status) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid recertification campaign status", paramStatus))
+		return
+	}
+	page, limit := getQueryParamPageAndLimit(r)
+
+	campaignDTOs, totalCount, err := listRecertificationCampaignsUC.Execute(status, page, limit)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListRecertificationCampaigns, err))
+		return
+	}
+	if campaignDTOs == nil {
+		campaignDTOs = make([]*dto.RecertificationCampaignOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListRecertificationCampaigns, campaignDTOs, totalCount, limit, page)
+}
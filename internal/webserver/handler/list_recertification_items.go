@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	recertificationUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/recertification"
+)
+
+const (
+	opListRecertificationItems = "list-recertification-items"
+	paramReviewerID            = "reviewerId"
+	paramDecision              = "decision"
+)
+
+var listRecertificationItemsUC *recertificationUsecase.ListRecertificationItemsUseCase
+
+// ListRecertificationItemsHandler godoc
+// @BasePath /api/v1
+// @Summary List the items of an access recertification campaign
+// @Description List the permissions under review in a campaign, ordered by database user, instance and database, with the decision of their reviewer.
+// @Description Filter by reviewerId and decision PENDING to get the work left to a reviewer.
+// @Tags Recertification
+// @Accept json
+// @Produce json
+// @Param id query string true "Recertification campaign ID"
+// @Param reviewerId query string false "ID of the application user assigned as reviewer"
+// @Param decision query string false "Decision of the reviewer" Enums(PENDING, KEEP, REVOKE)
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListRecertificationItemsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recertification-campaign/items [get]
+// @Security ApiKeyAuth
+func ListRecertificationItemsHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+	reviewerID := r.URL.Query().Get(paramReviewerID)
+	if validateUUIDParam(w, reviewerID, paramReviewerID) {
+		return
+	}
+	decision := r.URL.Query().Get(paramDecision)
+	if decision != emptyString && !entity.ValidateRecertificationDecision(decision) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid recertification decision", paramDecision))
+		return
+	}
+	page, limit := getQueryParamPageAndLimit(r)
+
+	itemDTOs, totalCount, err := listRecertificationItemsUC.Execute(id, reviewerID, decision, page, limit)
+	if err != nil && errors.Is(err, recertificationUsecase.ErrRecertificationCampaignNotFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opListRecertificationItems, err))
+		return
+	}
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListRecertificationItems, err))
+		return
+	}
+	if itemDTOs == nil {
+		itemDTOs = make([]*dto.RecertificationItemOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListRecertificationItems, itemDTOs, totalCount, limit, page)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	usecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_instance"
+)
+
+const (
+	opListRoleFindings = "list-role-findings"
+	paramClass         = "class"
+)
+
+var listRoleFindingsUC *usecase.ListRoleFindingsUseCase
+
+// ListRoleFindingsHandler godoc
+// @BasePath /api/v1
+// @Summary List the logins found by the last role discovery of each database instance
+// @Description List the logins found by the last role discovery of each database instance, from the most dangerous class (SUPERUSER) to the least (MANAGED), with the reasons of their class and when they were discovered.
+// @Tags Database Instance
+// @Accept json
+// @Produce json
+// @Param databaseInstanceId query string false "Database instance ID" Format(uuid)
+// @Param class query string false "Class of the login" Enums(MANAGED, MISMATCHED, UNKNOWN, PRIVILEGED, SUPERUSER)
+// @Param page query int false "Page number"
+// @Param limit query int false "Limit per page"
+// @Success 200 {object} ListRoleFindingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /role-findings [get]
+// @Security ApiKeyAuth
+func ListRoleFindingsHandler(w http.ResponseWriter, r *http.Request) {
+	databaseInstanceID := r.URL.Query().Get(paramDatabaseInstanceID)
+	if databaseInstanceID != emptyString && !validateUUID(w, databaseInstanceID, paramDatabaseInstanceID) {
+		return
+	}
+	class := r.URL.Query().Get(paramClass)
+	if class != emptyString && !entity.ValidateRoleFindingClass(class) {
+		sendError(w, http.StatusBadRequest, fmt.Sprintf("%s is not a valid role finding class", paramClass))
+		return
+	}
+	page, limit := getQueryParamPageAndLimit(r)
+
+	findingDTOs, totalFindingsCount, err := listRoleFindingsUC.Execute(databaseInstanceID, class, page, limit)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opListRoleFindings, err))
+		return
+	}
+	if findingDTOs == nil {
+		findingDTOs = make([]*dto.RoleFindingOutputDTO, 0)
+	}
+
+	sendSuccessList(w, opListRoleFindings, findingDTOs, totalFindingsCount, limit, page)
+}
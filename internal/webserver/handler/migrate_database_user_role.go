@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessPermissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const opMigrateDatabaseUserRole = "migrate-database-user-role"
+
+var migrateDatabaseUserRoleUC *accessPermissionUsecase.MigrateDatabaseUserRoleUseCase
+
+// MigrateDatabaseUserRoleHandler godoc
+// @BasePath /api/v1
+// @Summary Migrate the role of a database user with access permissions
+// @Description Grant the new role and revoke the old one on every instance where the user exists, logging the outcome of each instance. The databases with a role of their own keep it.
+// @Description The user keeps the old role until every instance is migrated (hasErrors); the migration can be run again to finish it.
+// @Tags Database User
+// @Accept json
+// @Produce json
+// @Param id query string true "Database User ID"
+// @Param request body dto.MigrateDatabaseUserRoleInputDTO true "Request body"
+// @Success 200 {object} MigrateDatabaseUserRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-user/migrate-role [post]
+// @Security ApiKeyAuth
+func MigrateDatabaseUserRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.MigrateDatabaseUserRoleInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := migrateDatabaseUserRoleUC.Execute(input, id, userID)
+	if err != nil {
+		log.Printf("error migrating the role of database user: %v", err.Error())
+		sendError(w, migrateDatabaseUserRoleErrorCode(err), buildErrorMessage(opMigrateDatabaseUserRole, err))
+		return
+	}
+
+	sendSuccess(w, opMigrateDatabaseUserRole, output)
+}
+
+func migrateDatabaseUserRoleErrorCode(err error) int {
+	switch {
+	case errors.Is(err, common.ErrDatabaseUserNotFound),
+		errors.Is(err, accessPermissionUsecase.ErrDatabaseRoleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, accessPermissionUsecase.ErrSameDatabaseUserRole),
+		errors.Is(err, accessPermissionUsecase.ErrApplicationRoleMigrationNotAllowed),
+		errors.Is(err, accessPermissionUsecase.ErrScopedUserRoleMigrationNotAllowed):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	desiredStateUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/desired_state"
+)
+
+const (
+	opPlanDesiredState = "plan-desired-state"
+)
+
+var planDesiredStateUC *desiredStateUsecase.PlanDesiredStateUseCase
+
+// PlanDesiredStateHandler godoc
+// @BasePath /api/v1
+// @Summary Compare a desired state file with the stored ecosystems, instances, database users, access groups and access permissions
+// @Description Receives the desired state as YAML (or JSON) and returns the changes needed to reach it, in the order they are applied: creates, updates, group changes and grants. Nothing is changed.
+// @Description With prune, the changes also remove what the file doesn't mention: members removed from the groups, access to instances and databases revoked, users and instances disabled and ecosystems deleted. Differences that can't be changed are returned as warnings.
+// @Tags Desired State
+// @Accept application/x-yaml
+// @Produce json
+// @Param request body dto.DesiredStateInputDTO true "Desired state"
+// @Param prune query bool false "Also remove what the desired state doesn't mention"
+// @Success 200 {object} DesiredStateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /desired-state/plan [post]
+// @Security ApiKeyAuth
+func PlanDesiredStateHandler(w http.ResponseWriter, r *http.Request) {
+	prune, hasError := getPruneQueryParam(w, r)
+	if hasError {
+		return
+	}
+	input, hasError := decodeDesiredState(w, r)
+	if hasError {
+		return
+	}
+
+	output, err := planDesiredStateUC.Execute(input, prune)
+	if err != nil {
+		sendDesiredStateError(w, opPlanDesiredState, err)
+		return
+	}
+
+	sendSuccess(w, opPlanDesiredState, output)
+}
+
+// decodeDesiredState godoc
+// Decodes and validates the desired state of the request body. Unknown fields are rejected, so a typo doesn't silently
+// remove a field with prune.
+func decodeDesiredState(w http.ResponseWriter, r *http.Request) (dto.DesiredStateInputDTO, bool) {
+	var input dto.DesiredStateInputDTO
+	decoder := yaml.NewDecoder(r.Body)
+	decoder.KnownFields(true)
+	err := decoder.Decode(&input)
+	if errors.Is(err, io.EOF) {
+		sendError(w, http.StatusBadRequest, "the desired state is empty")
+		return input, true
+	}
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body: "+err.Error())
+		return input, true
+	}
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return input, true
+	}
+	return input, false
+}
+
+func sendDesiredStateError(w http.ResponseWriter, operation string, err error) {
+	if errors.Is(err, desiredStateUsecase.ErrInvalidDesiredState) {
+		sendError(w, http.StatusBadRequest, buildErrorMessage(operation, err))
+		return
+	}
+	log.Printf("error in operation %s: %v", operation, err)
+	sendError(w, http.StatusInternalServerError, buildErrorMessage(operation, err))
+}
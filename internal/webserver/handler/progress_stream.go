@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
+)
+
+const (
+	sseEventProgress = "progress"
+	sseEventItem     = "item"
+	sseEventResult   = "result"
+	sseEventError    = "error"
+
+	sseHeartbeatInterval = 15 * time.Second
+)
+
+type sseEvent struct {
+	name string
+	data any
+}
+
+// progressStream godoc
+// Queues the progress reported by the tasks of an operation to be written as Server-Sent Events by the handler, so the
+// tasks never wait for a slow client. Once the client is gone the stream is closed and the progress is discarded.
+type progressStream struct {
+	mu        sync.Mutex
+	events    []sseEvent
+	closed    bool
+	notify    chan struct{}
+	total     int
+	processed int
+	failed    int
+}
+
+func newProgressStream() *progressStream {
+	return &progressStream{notify: make(chan struct{}, 1)}
+}
+
+func (s *progressStream) AddItems(qty int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total += qty
+}
+
+func (s *progressStream) ItemProcessed(result dto.ItemResultDTO) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.processed++
+	if !result.Success {
+		s.failed++
+	}
+	s.push(sseEventItem, dto.ItemProgressEventDTO{
+		ItemResultDTO:  result,
+		TotalItems:     s.total,
+		ProcessedItems: s.processed,
+		FailedItems:    s.failed,
+	})
+}
+
+func (s *progressStream) Event(event dto.ProgressEventDTO) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.push(sseEventProgress, event)
+}
+
+// push godoc
+// Must be called holding the lock of the stream
+func (s *progressStream) push(name string, data any) {
+	if s.closed {
+		return
+	}
+	s.events = append(s.events, sseEvent{name: name, data: data})
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *progressStream) drain() []sseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.events
+	s.events = nil
+	return events
+}
+
+func (s *progressStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.events = nil
+}
+
+type operationResult struct {
+	output any
+	err    error
+}
+
+// streamProgress godoc
+// Runs the operation streaming its progress as Server-Sent Events: a "progress" event for each message of the operation
+// and an "item" event for each item processed, with the counters of the operation. The stream ends with a "result"
+// event with the output of the operation, or an "error" event with the same body of an error response, whose code is
+// given by errorCode. If the client disconnects the operation still runs until the end, like a synchronous request.
+func streamProgress(
+	w http.ResponseWriter,
+	r *http.Request,
+	operation string,
+	execute func(progress common.ProgressReporter) (any, error),
+	errorCode func(err error) int) {
+	controller := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disables the buffering of the response by proxies like nginx, that would hold the events until the end
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		log.Printf("WARN: the response of %s can't be flushed, the events will only be sent at the end. Cause: %v", operation, err)
+	}
+
+	stream := newProgressStream()
+	done := make(chan operationResult, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- operationResult{err: fmt.Errorf("operation panicked: %v", recovered)}
+			}
+		}()
+		output, err := execute(stream)
+		done <- operationResult{output: output, err: err}
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-stream.notify:
+			if err := writeEvents(w, controller, stream.drain()); err != nil {
+				log.Printf("Stopped streaming %s, the client is gone. The operation continues. Cause: %v", operation, err)
+				stream.close()
+				return
+			}
+		case <-heartbeat.C:
+			// Comments are ignored by the clients and keep idle connections from being closed by proxies
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err == nil {
+				_ = controller.Flush()
+			}
+		case result := <-done:
+			events := stream.drain()
+			if result.err != nil {
+				log.Printf("error in %s: %v", operation, result.err)
+				code := errorCode(result.err)
+				events = append(events, sseEvent{name: sseEventError, data: map[string]any{
+					"message":   buildErrorMessage(operation, result.err),
+					"errorCode": code,
+				}})
+			} else {
+				events = append(events, sseEvent{name: sseEventResult, data: result.output})
+			}
+			if err := writeEvents(w, controller, events); err != nil {
+				log.Printf("Could not send the result of %s, the client is gone. Cause: %v", operation, err)
+			}
+			return
+		case <-r.Context().Done():
+			log.Printf("Client disconnected from the stream of %s. The operation continues.", operation)
+			stream.close()
+			return
+		}
+	}
+}
+
+func writeEvents(w http.ResponseWriter, controller *http.ResponseController, events []sseEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	for _, event := range events {
+		data, err := json.Marshal(event.data)
+		if err != nil {
+			log.Printf("Error encoding %s event. Cause: %v", event.name, err)
+			continue
+		}
+		if _, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.name, data); err != nil {
+			return err
+		}
+	}
+	if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	usecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_instance"
 )
 
@@ -20,11 +21,14 @@ var propagateRolesUC *usecase.PropagateRolesUseCase
 // @BasePath /api/v1
 // @Summary Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances
 // @Description Propagates all database role records to the selected database instances, if instances ids are not provided, propagate to all enabled instances
+// @Description With dryRun, each result has the statements that would be executed in the instance, by database. Nothing is executed nor persisted.
 // @Tags Database Instance
 // @Accept json
 // @Produce json
 // @Param request body dto.PropagateRolesInputDTO false "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
 // @Success 200 {object} PropagateRolesResponse
+// @Success 202 {object} SubmitJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -35,6 +39,10 @@ func PropagateRolesHandler(w http.ResponseWriter, r *http.Request) {
 	if hasError || userID == emptyString {
 		return
 	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
 
 	var input dto.PropagateRolesInputDTO
 	err := json.NewDecoder(r.Body).Decode(&input)
@@ -52,6 +60,11 @@ func PropagateRolesHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if async {
+		submitJob(w, opPropagateRoles, entity.JobTypePropagateRoles, input, userID)
+		return
+	}
+
 	outputs, err := propagateRolesUC.Execute(input, userID)
 	if err != nil && errors.Is(err, usecase.ErrNoDatabaseInstancesFound) {
 		sendError(w, http.StatusNotFound, buildErrorMessage(opPropagateRoles, err))
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	accessPermissionUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_permission"
+)
+
+const (
+	opReconcileAccess = "reconcile-access"
+)
+
+var reconcileAccessUC *accessPermissionUsecase.ReconcileAccessPermissionsUseCase
+
+// ReconcileAccessHandler godoc
+// @BasePath /api/v1
+// @Summary Compare the access permissions with the effective access in the selected database instances, if instances ids are not provided, compare in all enabled instances
+// @Description Reports, for each instance, the drifts between the stored access permissions and the access the users have in the instance: MISSING_USER (the user doesn't exist in the instance), MISSING_GRANT (the user can't connect to a permitted database or lacks its role) and EXTRA_GRANT (the user can connect to a database without permission, or exists in the instance without any permission).
+// @Description With apply, the drifts are corrected by granting the access again, recreating the missing users, removing the users without permissions and revoking the access to the extra databases. Each correction is logged as a RECONCILE access permission log.
+// @Tags Access Permission
+// @Accept json
+// @Produce json
+// @Param request body dto.ReconcileAccessInputDTO false "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
+// @Success 200 {object} ReconcileAccessResponse
+// @Success 202 {object} SubmitJobResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-permission/reconcile [post]
+// @Security ApiKeyAuth
+func ReconcileAccessHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.ReconcileAccessInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	for _, id := range input.DatabaseInstancesIDs {
+		if !validateUUID(w, id, "databaseInstancesIds list contains a value that") {
+			return
+		}
+	}
+
+	if async {
+		submitJob(w, opReconcileAccess, entity.JobTypeReconcileAccess, input, userID)
+		return
+	}
+
+	outputs, err := reconcileAccessUC.Execute(input, userID)
+	if err != nil && errors.Is(err, accessPermissionUsecase.ErrNoDatabaseInstancesFound) {
+		sendError(w, http.StatusNotFound, buildErrorMessage(opReconcileAccess, err))
+		return
+	}
+	if err != nil {
+		log.Printf("error in operation %s: %v", opReconcileAccess, err)
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(opReconcileAccess, err))
+		return
+	}
+
+	sendSuccessList(w, opReconcileAccess, outputs, len(outputs), 0, 0)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+)
+
+const opRemoveAccessGroupMember = "remove-access-group-member"
+
+var removeAccessGroupMemberUC *accessGroupUsecase.RemoveAccessGroupMemberUseCase
+
+// RemoveAccessGroupMemberHandler godoc
+// @BasePath /api/v1
+// @Summary Remove a member from an access group
+// @Description Remove the database user from the group and revoke the databases of its targets, except those still covered by another group of the user. The permissions granted directly are kept, even to databases the group covers.
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param id query string true "Access group ID"
+// @Param databaseUserId query string true "Database User ID"
+// @Success 200 {object} AccessGroupChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-group/member [delete]
+// @Security ApiKeyAuth
+func RemoveAccessGroupMemberHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+	databaseUserID := r.URL.Query().Get(paramDatabaseUserID)
+	if databaseUserID == emptyString {
+		sendError(w, http.StatusBadRequest, paramDatabaseUserID+" is required")
+		return
+	}
+	if !validateUUID(w, databaseUserID, paramDatabaseUserID) {
+		return
+	}
+
+	output, err := removeAccessGroupMemberUC.Execute(id, databaseUserID, userID)
+	if err != nil {
+		sendError(w, accessGroupErrorCode(err), buildErrorMessage(opRemoveAccessGroupMember, err))
+		return
+	}
+
+	sendSuccess(w, opRemoveAccessGroupMember, output)
+}
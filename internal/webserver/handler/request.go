@@ -18,6 +18,8 @@ const (
 	defaultLimit = 250
 	trueString   = "true"
 	falseString  = "false"
+	paramAsync   = "async"
+	paramPrune   = "prune"
 )
 
 func getUserIDFromAuthenticatedRequest(w http.ResponseWriter, r *http.Request) (string, bool) {
@@ -93,3 +95,23 @@ func validateBoolQueryParam(w http.ResponseWriter, value, param string) bool {
 func getQueryParamBoolValue(value string) bool {
 	return value == trueString
 }
+
+// getAsyncQueryParam godoc
+// Reads the async query param of the operations that can be processed in background as a job
+func getAsyncQueryParam(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	asyncParam := r.URL.Query().Get(paramAsync)
+	if !validateBoolQueryParam(w, asyncParam, paramAsync) {
+		return false, true
+	}
+	return getQueryParamBoolValue(asyncParam), false
+}
+
+// getPruneQueryParam godoc
+// Reads the prune query param of the desired state operations
+func getPruneQueryParam(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	pruneParam := r.URL.Query().Get(paramPrune)
+	if !validateBoolQueryParam(w, pruneParam, paramPrune) {
+		return false, true
+	}
+	return getQueryParamBoolValue(pruneParam), false
+}
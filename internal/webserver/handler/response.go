@@ -25,6 +25,12 @@ func sendCreated(w http.ResponseWriter, operation string, data any) {
 	sendSuccessfulContent(w, operation, data, 0, 0, 0)
 }
 
+func sendAccepted(w http.ResponseWriter, operation string, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	sendSuccessfulContent(w, operation, data, 0, 0, 0)
+}
+
 func sendSuccess(w http.ResponseWriter, operation string, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -156,6 +162,11 @@ type ListDatabasesResponse struct {
 	Total   int                     `json:"total"`
 }
 
+type ListOutdatedRolesDatabasesResponse struct {
+	Message string                              `json:"message"`
+	Data    dto.OutdatedRolesDatabasesOutputDTO `json:"data"`
+}
+
 type TestConnectionResponse struct {
 	Message string                        `json:"message"`
 	Data    []dto.TestConnectionOutputDTO `json:"data"`
@@ -178,12 +189,54 @@ type SyncDatabasesResponse struct {
 	Total   int                          `json:"total"`
 }
 
+type DiscoverRolesResponse struct {
+	Message string                       `json:"message"`
+	Data    []dto.DiscoverRolesOutputDTO `json:"data"`
+	Total   int                          `json:"total"`
+}
+
+type DesiredStateResponse struct {
+	Message string                    `json:"message"`
+	Data    dto.DesiredStateOutputDTO `json:"data"`
+}
+
+type ReconcileAccessResponse struct {
+	Message string                         `json:"message"`
+	Data    []dto.ReconcileAccessOutputDTO `json:"data"`
+	Total   int                            `json:"total"`
+}
+
+type ListRoleFindingsResponse struct {
+	Message string                     `json:"message"`
+	Data    []dto.RoleFindingOutputDTO `json:"data"`
+	Total   int                        `json:"total"`
+}
+
 type PropagateRolesResponse struct {
 	Message string                        `json:"message"`
 	Data    []dto.PropagateRolesOutputDTO `json:"data"`
 	Total   int                           `json:"total"`
 }
 
+type CreateDatabaseRoleResponse struct {
+	Message string                    `json:"message"`
+	Data    dto.DatabaseRoleOutputDTO `json:"data"`
+}
+
+type UpdateDatabaseRoleResponse struct {
+	Message string                    `json:"message"`
+	Data    dto.DatabaseRoleOutputDTO `json:"data"`
+}
+
+type GetDatabaseRoleResponse struct {
+	Message string                    `json:"message"`
+	Data    dto.DatabaseRoleOutputDTO `json:"data"`
+}
+
+type DeleteDatabaseRoleResponse struct {
+	Message string `json:"message"`
+}
+
 type ListDatabaseRolesResponse struct {
 	Message string                      `json:"message"`
 	Data    []dto.DatabaseRoleOutputDTO `json:"data"`
@@ -212,12 +265,27 @@ type GrantAccessResponse struct {
 	Data    dto.GrantAccessOutputDTO `json:"data"`
 }
 
+type BreakGlassAccessResponse struct {
+	Message string                             `json:"message"`
+	Data    dto.GrantBreakGlassAccessOutputDTO `json:"data"`
+}
+
 type ListAccessPermissionsResponse struct {
 	Message string                          `json:"message"`
 	Data    []dto.AccessPermissionOutputDTO `json:"data"`
 	Total   int                             `json:"total"`
 }
 
+type AccessPermissionResponse struct {
+	Message string                        `json:"message"`
+	Data    dto.AccessPermissionOutputDTO `json:"data"`
+}
+
+type MigrateDatabaseUserRoleResponse struct {
+	Message string                               `json:"message"`
+	Data    dto.MigrateDatabaseUserRoleOutputDTO `json:"data"`
+}
+
 type RevokeAccessResponse struct {
 	Message string                    `json:"message"`
 	Data    dto.RevokeAccessOutputDTO `json:"data"`
@@ -233,3 +301,73 @@ type ChangeStatusResponse struct {
 	Message string                    `json:"message"`
 	Data    dto.ChangeStatusOutputDTO `json:"data"`
 }
+
+type SubmitJobResponse struct {
+	Message string           `json:"message"`
+	Data    dto.JobOutputDTO `json:"data"`
+}
+
+type GetJobResponse struct {
+	Message string           `json:"message"`
+	Data    dto.JobOutputDTO `json:"data"`
+}
+
+type ListJobsResponse struct {
+	Message string             `json:"message"`
+	Data    []dto.JobOutputDTO `json:"data"`
+	Total   int                `json:"total"`
+}
+
+type AccessRequestResponse struct {
+	Message string                     `json:"message"`
+	Data    dto.AccessRequestOutputDTO `json:"data"`
+}
+
+type ListAccessRequestsResponse struct {
+	Message string                       `json:"message"`
+	Data    []dto.AccessRequestOutputDTO `json:"data"`
+	Total   int                          `json:"total"`
+}
+
+type RecertificationCampaignResponse struct {
+	Message string                               `json:"message"`
+	Data    dto.RecertificationCampaignOutputDTO `json:"data"`
+}
+
+type ListRecertificationCampaignsResponse struct {
+	Message string                                 `json:"message"`
+	Data    []dto.RecertificationCampaignOutputDTO `json:"data"`
+	Total   int                                    `json:"total"`
+}
+
+type ListRecertificationItemsResponse struct {
+	Message string                             `json:"message"`
+	Data    []dto.RecertificationItemOutputDTO `json:"data"`
+	Total   int                                `json:"total"`
+}
+
+type CloseRecertificationCampaignResponse struct {
+	Message string                                    `json:"message"`
+	Data    dto.CloseRecertificationCampaignOutputDTO `json:"data"`
+}
+
+type RecertificationReportResponse struct {
+	Message string                             `json:"message"`
+	Data    dto.RecertificationReportOutputDTO `json:"data"`
+}
+
+type AccessGroupResponse struct {
+	Message string                   `json:"message"`
+	Data    dto.AccessGroupOutputDTO `json:"data"`
+}
+
+type ListAccessGroupsResponse struct {
+	Message string                     `json:"message"`
+	Data    []dto.AccessGroupOutputDTO `json:"data"`
+	Total   int                        `json:"total"`
+}
+
+type AccessGroupChangeResponse struct {
+	Message string                         `json:"message"`
+	Data    dto.AccessGroupChangeOutputDTO `json:"data"`
+}
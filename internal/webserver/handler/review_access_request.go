@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
+)
+
+const (
+	opApproveAccessRequest = "approve-access-request"
+	opRejectAccessRequest  = "reject-access-request"
+)
+
+var (
+	approveAccessRequestUC *accessRequestUsecase.ApproveAccessRequestUseCase
+	rejectAccessRequestUC  *accessRequestUsecase.RejectAccessRequestUseCase
+)
+
+// ApproveAccessRequestHandler godoc
+// @BasePath /api/v1
+// @Summary Approve an access request, granting the access requested
+// @Description Approve a pending access request and grant the access requested right away, with the approver as operator. The approver must be a user other than the requester.
+// @Description The request ends as EXECUTED with the result of the grant, that may have errors in some instances like a direct grant, or as FAILED when the grant could not run.
+// @Tags Access Request
+// @Accept json
+// @Produce json
+// @Param id query string true "Access request ID"
+// @Param request body dto.ReviewAccessRequestInputDTO false "Request body"
+// @Success 200 {object} AccessRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-request/approve [post]
+// @Security ApiKeyAuth
+func ApproveAccessRequestHandler(w http.ResponseWriter, r *http.Request) {
+	reviewAccessRequest(w, r, opApproveAccessRequest, approveAccessRequestUC.Execute)
+}
+
+// RejectAccessRequestHandler godoc
+// @BasePath /api/v1
+// @Summary Reject an access request
+// @Description Reject a pending access request, nothing is granted. The reviewer must be a user other than the requester.
+// @Tags Access Request
+// @Accept json
+// @Produce json
+// @Param id query string true "Access request ID"
+// @Param request body dto.ReviewAccessRequestInputDTO false "Request body"
+// @Success 200 {object} AccessRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-request/reject [post]
+// @Security ApiKeyAuth
+func RejectAccessRequestHandler(w http.ResponseWriter, r *http.Request) {
+	reviewAccessRequest(w, r, opRejectAccessRequest, rejectAccessRequestUC.Execute)
+}
+
+func reviewAccessRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	operation string,
+	review func(accessRequestID string, input dto.ReviewAccessRequestInputDTO, operationUserID string) (*dto.AccessRequestOutputDTO, error)) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	// The body is optional, the review may have no comment
+	var input dto.ReviewAccessRequestInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	output, err := review(id, input, userID)
+	if err != nil {
+		sendError(w, reviewAccessRequestErrorCode(err), buildErrorMessage(operation, err))
+		return
+	}
+
+	sendSuccess(w, operation, output)
+}
+
+func reviewAccessRequestErrorCode(err error) int {
+	switch {
+	case errors.Is(err, accessRequestUsecase.ErrAccessRequestNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrAccessRequestReviewedByRequester):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrAccessRequestNotPending),
+		errors.Is(err, entity.ErrAccessRequestNotApproved),
+		errors.Is(err, entity.ErrAccessRequestExpired):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
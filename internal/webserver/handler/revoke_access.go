@@ -11,14 +11,21 @@ import (
 	"github.com/zgsolucoes/zg-data-guard/internal/usecase/common"
 )
 
-var revokeAccessPermissionUC *accessPermissionUsecase.RevokeAccessPermissionUseCase
+var (
+	revokeAccessPermissionUC         *accessPermissionUsecase.RevokeAccessPermissionUseCase
+	revokeExpiredAccessPermissionsUC *accessPermissionUsecase.RevokeExpiredAccessPermissionsUseCase
+)
 
-const opRevokeAccess = "revoke-access"
+const (
+	opRevokeAccess       = "revoke-access"
+	opStreamRevokeAccess = "stream-revoke-access"
+)
 
 // RevokeAccessHandler godoc
 // @BasePath /api/v1
 // @Summary Revoke connection access to a specific user from a set of instances and their respective databases
 // @Description If no instance is provided, it revokes access from all instances accessible by the user.
+// @Description With dryRun, the statements the revoke would execute are returned in the plan, by instance and database. Nothing is executed in the instances nor persisted.
 // @Tags Access Permission
 // @Accept json
 // @Produce json
@@ -50,18 +57,59 @@ func RevokeAccessHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	output, err := revokeAccessPermissionUC.Execute(input, userID)
-	if err != nil && errors.Is(err, common.ErrDatabaseUserNotFound) {
-		sendError(w, http.StatusNotFound, buildErrorMessage(opRevokeAccess, err))
+	if err != nil {
+		sendError(w, revokeAccessErrorCode(err), buildErrorMessage(opRevokeAccess, err))
 		return
 	}
-	if err != nil && errors.Is(err, common.ErrNoAccessibleInstancesFound) {
-		sendError(w, http.StatusBadRequest, buildErrorMessage(opRevokeAccess, err))
+
+	sendSuccess(w, opRevokeAccess, output)
+}
+
+// StreamRevokeAccessHandler godoc
+// @BasePath /api/v1
+// @Summary Revoke connection access streaming the progress of the operation as Server-Sent Events
+// @Description Same as the revoke of access, answered with a text/event-stream. Each message about an instance is sent as a "progress" event (dto.ProgressEventDTO) and each instance processed as an "item" event (dto.ItemProgressEventDTO).
+// @Description The stream ends with a "result" event with the dto.RevokeAccessOutputDTO, or an "error" event with the body of an error response.
+// @Tags Access Permission
+// @Accept json
+// @Produce text/event-stream
+// @Param request body dto.RevokeAccessInputDTO true "Request body"
+// @Success 200 {object} dto.RevokeAccessOutputDTO "Data of the result event"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-permission/revoke/stream [post]
+// @Security ApiKeyAuth
+func StreamRevokeAccessHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
 		return
 	}
+
+	var input dto.RevokeAccessInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
-		sendError(w, http.StatusInternalServerError, buildErrorMessage(opRevokeAccess, err))
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
 		return
 	}
 
-	sendSuccess(w, opRevokeAccess, output)
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	streamProgress(w, r, opStreamRevokeAccess, func(progress common.ProgressReporter) (any, error) {
+		return revokeAccessPermissionUC.ExecuteWithProgress(input, userID, progress)
+	}, revokeAccessErrorCode)
+}
+
+func revokeAccessErrorCode(err error) int {
+	if errors.Is(err, common.ErrDatabaseUserNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, common.ErrNoAccessibleInstancesFound) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
 }
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"log"
+
+	"github.com/zgsolucoes/zg-data-guard/config"
+	accessRequestUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_request"
+	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
+	"github.com/zgsolucoes/zg-data-guard/pkg/scheduler"
+)
+
+var (
+	taskScheduler          *scheduler.Scheduler
+	expireAccessRequestsUC *accessRequestUsecase.ExpireAccessRequestsUseCase
+)
+
+// startScheduledTasks godoc
+// Starts the tasks run periodically by the application, so it must be called after their use cases are initialized
+func startScheduledTasks() {
+	taskScheduler = scheduler.NewScheduler()
+	taskScheduler.Every("revoke expired access permissions", config.GetAccessExpirationCheckInterval(), func() {
+		if err := revokeExpiredAccessPermissionsUC.Execute(); err != nil {
+			log.Printf("Error revoking expired access permissions. Cause: %v", err)
+		}
+	})
+	taskScheduler.Every("revoke expired break-glass access", config.GetAccessExpirationCheckInterval(), func() {
+		if err := revokeExpiredBreakGlassAccessUC.Execute(); err != nil {
+			log.Printf("Error revoking expired break-glass access. Cause: %v", err)
+		}
+	})
+	taskScheduler.Every("refresh database role names", config.GetRoleNamesRefreshInterval(), func() {
+		_ = roleUsecase.LoadKnownRoleNames(roleStorage)
+	})
+	taskScheduler.Every("expire access requests", config.GetAccessExpirationCheckInterval(), func() {
+		if err := expireAccessRequestsUC.Execute(); err != nil {
+			log.Printf("Error expiring access requests. Cause: %v", err)
+		}
+	})
+}
+
+// StopScheduledTasks godoc
+// Called on shutdown, waits for the scheduled tasks running and doesn't start them anymore
+func StopScheduledTasks() {
+	if taskScheduler != nil {
+		taskScheduler.Stop()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	dbUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database"
 )
 
@@ -20,11 +21,14 @@ var setupRolesUC *dbUsecase.SetupRolesInDatabasesUseCase
 // @BasePath /api/v1
 // @Summary Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances
 // @Description Setup roles (applying grants) in the selected databases, if databases ids are not provided, setup roles in all enabled databases belonging to the enabled instances
+// @Description With dryRun, each result has the statements that would be executed in the database. Nothing is executed nor persisted.
 // @Tags Database
 // @Accept json
 // @Produce json
 // @Param request body dto.SetupRolesInputDTO false "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
 // @Success 200 {object} SetupRolesResponse
+// @Success 202 {object} SubmitJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -35,6 +39,10 @@ func SetupRolesHandler(w http.ResponseWriter, r *http.Request) {
 	if hasError || userID == emptyString {
 		return
 	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
 
 	var input dto.SetupRolesInputDTO
 	err := json.NewDecoder(r.Body).Decode(&input)
@@ -55,6 +63,11 @@ func SetupRolesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if async {
+		submitJob(w, opSetupRoles, entity.JobTypeSetupRoles, input, userID)
+		return
+	}
+
 	outputs, err := setupRolesUC.Execute(input, userID)
 	if err != nil && errors.Is(err, dbUsecase.ErrNoDatabasesFound) {
 		sendError(w, http.StatusNotFound, buildErrorMessage(opSetupRoles, err))
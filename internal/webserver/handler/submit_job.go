@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	jobUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/job"
+)
+
+var jobRunner *jobUsecase.JobRunner
+
+// submitJob godoc
+// Answers an operation requested with async=true, processing it in background as a job whose ID is returned right away
+func submitJob(w http.ResponseWriter, operation, jobType string, input any, userID string) {
+	job, err := jobRunner.Submit(jobType, input, userID)
+	if err != nil {
+		log.Printf("error submitting job of operation %s: %v", operation, err)
+		sendError(w, http.StatusInternalServerError, buildErrorMessage(operation, err))
+		return
+	}
+	sendAccepted(w, operation, job)
+}
+
+// StopJobs godoc
+// Called on shutdown, the jobs still running are left to be recovered on the next start
+func StopJobs() {
+	if jobRunner != nil {
+		jobRunner.Stop()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
 	dbUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database"
 )
 
@@ -24,7 +25,9 @@ var syncDatabasesUC *dbUsecase.SyncDatabasesUseCase
 // @Accept json
 // @Produce json
 // @Param request body dto.SyncDatabasesInputDTO false "Request body"
+// @Param async query bool false "Process the operation in background as a job, returning the job right away"
 // @Success 200 {object} SyncDatabasesResponse
+// @Success 202 {object} SubmitJobResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -35,6 +38,10 @@ func SyncDatabasesHandler(w http.ResponseWriter, r *http.Request) {
 	if hasError || userID == emptyString {
 		return
 	}
+	async, hasError := getAsyncQueryParam(w, r)
+	if hasError {
+		return
+	}
 
 	var input dto.SyncDatabasesInputDTO
 	err := json.NewDecoder(r.Body).Decode(&input)
@@ -52,6 +59,11 @@ func SyncDatabasesHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if async {
+		submitJob(w, opSyncDatabases, entity.JobTypeSyncDatabases, input, userID)
+		return
+	}
+
 	syncOutputs, err := syncDatabasesUC.Execute(input, userID)
 	if err != nil && errors.Is(err, dbUsecase.ErrNoDatabaseInstancesFound) {
 		sendError(w, http.StatusNotFound, buildErrorMessage(opSyncDatabases, err))
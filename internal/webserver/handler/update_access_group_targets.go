@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	accessGroupUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/access_group"
+)
+
+const opUpdateAccessGroupTargets = "update-access-group-targets"
+
+var updateAccessGroupTargetsUC *accessGroupUsecase.UpdateAccessGroupTargetsUseCase
+
+// UpdateAccessGroupTargetsHandler godoc
+// @BasePath /api/v1
+// @Summary Replace the targets of an access group
+// @Description Replace the targets of the group and reconcile the access of every member: the targets added are granted to all members, and the databases of the targets removed are revoked from each member.
+// @Description Only the permissions granted by groups to databases no longer covered by a target of the groups of the member are revoked, keeping the permissions granted directly. The user is removed from an instance once no permission is left there.
+// @Tags Access Group
+// @Accept json
+// @Produce json
+// @Param id query string true "Access group ID"
+// @Param request body dto.AccessGroupTargetsInputDTO true "Request body"
+// @Success 200 {object} AccessGroupChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /access-group/targets [put]
+// @Security ApiKeyAuth
+func UpdateAccessGroupTargetsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	var input dto.AccessGroupTargetsInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := updateAccessGroupTargetsUC.Execute(id, input, userID)
+	if err != nil {
+		sendError(w, accessGroupErrorCode(err), buildErrorMessage(opUpdateAccessGroupTargets, err))
+		return
+	}
+
+	sendSuccess(w, opUpdateAccessGroupTargets, output)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	roleUsecase "github.com/zgsolucoes/zg-data-guard/internal/usecase/database_role"
+)
+
+const opUpdateDatabaseRole = "update-database-role"
+
+var updateDatabaseRoleUC *roleUsecase.UpdateDatabaseRoleUseCase
+
+// UpdateDatabaseRoleHandler godoc
+// @BasePath /api/v1
+// @Summary Update a database role
+// @Description Update the display name, description and privileges of a database role. The name can't be changed.
+// @Description The new privileges apply to each database the next time the grants are set up in it.
+// @Tags Database Role
+// @Accept json
+// @Produce json
+// @Param id query string true "Database Role ID"
+// @Param request body dto.UpdateDatabaseRoleInputDTO true "Request body"
+// @Success 200 {object} UpdateDatabaseRoleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /database-role [put]
+// @Security ApiKeyAuth
+func UpdateDatabaseRoleHandler(w http.ResponseWriter, r *http.Request) {
+	id, hasError := getIDFromQueryParamsAndValidate(w, r)
+	if hasError {
+		return
+	}
+
+	userID, hasError := getUserIDFromAuthenticatedRequest(w, r)
+	if hasError || userID == emptyString {
+		return
+	}
+
+	var input dto.UpdateDatabaseRoleInputDTO
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		log.Printf("error decoding request body: %v", err.Error())
+		sendError(w, http.StatusBadRequest, "error decoding request body")
+		return
+	}
+
+	if err = input.Validate(); err != nil {
+		log.Printf("validation error: %v", err.Error())
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	outputRole, err := updateDatabaseRoleUC.Execute(input, id, userID)
+	if err != nil {
+		sendError(w, databaseRoleErrorCode(err), buildErrorMessage(opUpdateDatabaseRole, err))
+		return
+	}
+
+	sendSuccess(w, opUpdateDatabaseRole, outputRole)
+}
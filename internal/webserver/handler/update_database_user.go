@@ -19,6 +19,7 @@ var updateDBUserUC *dbUsecase.UpdateDatabaseUserUseCase
 // @BasePath /api/v1
 // @Summary Update a database user
 // @Description Update an existing database user
+// @Description The role of a user with access permissions can't be changed here (409), use /database-user/migrate-role instead.
 // @Tags Database User
 // @Accept json
 // @Produce json
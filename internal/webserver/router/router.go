@@ -17,6 +17,7 @@ import (
 	"github.com/zgsolucoes/zg-data-guard/docs"
 
 	"github.com/zgsolucoes/zg-data-guard/config"
+	"github.com/zgsolucoes/zg-data-guard/internal/webserver/handler"
 )
 
 const (
@@ -78,11 +79,13 @@ func initializeServerWithGracefulShutdown(webServer *http.Server) {
 	// Wait for OS signals to shut down the server
 	<-shutdownSignal
 	log.Println("Interrupt signal received. Shutting down server...")
+	handler.StopScheduledTasks()
 	ctx, shutdownRelease := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownRelease()
 
 	if err := webServer.Shutdown(ctx); err != nil {
 		log.Fatalf("HTTP shutdown error: %v", err)
 	}
+	handler.StopJobs()
 	log.Println("Graceful shutdown completed on server.")
 }
@@ -48,6 +48,11 @@ func setupProtectedAPIRoutes(r *chi.Mux, basePath string) {
 		createDatabaseRoleRoutes(apiRouter)
 		createDatabaseUserRoutes(apiRouter)
 		createAccessPermissionRoutes(apiRouter)
+		createAccessRequestRoutes(apiRouter)
+		createRecertificationRoutes(apiRouter)
+		createAccessGroupRoutes(apiRouter)
+		createDesiredStateRoutes(apiRouter)
+		createJobRoutes(apiRouter)
 	})
 
 	r.Mount(buildPath(basePath, apiBasePath+apiVersionV1), apiRouter)
@@ -87,8 +92,10 @@ func createDatabaseInstanceRoutes(r chi.Router) {
 		r.Post("/test-connection", handler.TestConnectionHandler)
 		r.Post("/propagate-roles", handler.PropagateRolesHandler)
 		r.Post("/sync-databases", handler.SyncDatabasesHandler)
+		r.Post("/discover-roles", handler.DiscoverRolesHandler)
 	})
 	r.Get("/database-instances", handler.ListDatabaseInstancesHandler)
+	r.Get("/role-findings", handler.ListRoleFindingsHandler)
 }
 
 func createDatabaseRoutes(r chi.Router) {
@@ -97,9 +104,16 @@ func createDatabaseRoutes(r chi.Router) {
 		r.Post("/setup-roles", handler.SetupRolesHandler)
 	})
 	r.Get("/databases", handler.ListDatabasesHandler)
+	r.Get("/databases/outdated-roles", handler.ListOutdatedRolesDatabasesHandler)
 }
 
 func createDatabaseRoleRoutes(r chi.Router) {
+	r.Route("/database-role", func(r chi.Router) {
+		r.Post("/", handler.CreateDatabaseRoleHandler)
+		r.Get("/", handler.GetDatabaseRoleHandler)
+		r.Put("/", handler.UpdateDatabaseRoleHandler)
+		r.Delete("/", handler.DeleteDatabaseRoleHandler)
+	})
 	r.Get("/database-roles", handler.ListDatabaseRolesHandler)
 }
 
@@ -110,6 +124,7 @@ func createDatabaseUserRoutes(r chi.Router) {
 		r.Put("/", handler.UpdateDatabaseUserHandler)
 		r.Get("/credentials", handler.GetDatabaseUserCredentialsHandler)
 		r.Patch("/change-status", handler.ChangeStatusDatabaseUserHandler)
+		r.Post("/migrate-role", handler.MigrateDatabaseUserRoleHandler)
 	})
 	r.Get("/database-users", handler.ListDatabaseUsersHandler)
 }
@@ -117,12 +132,62 @@ func createDatabaseUserRoutes(r chi.Router) {
 func createAccessPermissionRoutes(r chi.Router) {
 	r.Route("/access-permission", func(r chi.Router) {
 		r.Post("/grant", handler.GrantAccessHandler)
+		r.Post("/grant/stream", handler.StreamGrantAccessHandler)
 		r.Post("/revoke", handler.RevokeAccessHandler)
+		r.Post("/revoke/stream", handler.StreamRevokeAccessHandler)
+		r.Post("/break-glass", handler.BreakGlassAccessHandler)
+		r.Post("/change-role", handler.ChangeAccessPermissionRoleHandler)
+		r.Post("/reconcile", handler.ReconcileAccessHandler)
 		r.Get("/logs", handler.ListAccessPermissionLogsHandler)
 	})
 	r.Get("/access-permissions", handler.ListAccessPermissionsHandler)
 }
 
+func createAccessRequestRoutes(r chi.Router) {
+	r.Route("/access-request", func(r chi.Router) {
+		r.Post("/", handler.CreateAccessRequestHandler)
+		r.Get("/", handler.GetAccessRequestHandler)
+		r.Post("/approve", handler.ApproveAccessRequestHandler)
+		r.Post("/reject", handler.RejectAccessRequestHandler)
+	})
+	r.Get("/access-requests", handler.ListAccessRequestsHandler)
+}
+
+func createRecertificationRoutes(r chi.Router) {
+	r.Route("/recertification-campaign", func(r chi.Router) {
+		r.Post("/", handler.CreateRecertificationCampaignHandler)
+		r.Get("/", handler.GetRecertificationCampaignHandler)
+		r.Get("/items", handler.ListRecertificationItemsHandler)
+		r.Post("/decide", handler.DecideRecertificationItemsHandler)
+		r.Post("/close", handler.CloseRecertificationCampaignHandler)
+		r.Get("/report", handler.GetRecertificationReportHandler)
+	})
+	r.Get("/recertification-campaigns", handler.ListRecertificationCampaignsHandler)
+}
+
+func createAccessGroupRoutes(r chi.Router) {
+	r.Route("/access-group", func(r chi.Router) {
+		r.Post("/", handler.CreateAccessGroupHandler)
+		r.Get("/", handler.GetAccessGroupHandler)
+		r.Put("/targets", handler.UpdateAccessGroupTargetsHandler)
+		r.Post("/members", handler.AddAccessGroupMembersHandler)
+		r.Delete("/member", handler.RemoveAccessGroupMemberHandler)
+	})
+	r.Get("/access-groups", handler.ListAccessGroupsHandler)
+}
+
+func createDesiredStateRoutes(r chi.Router) {
+	r.Route("/desired-state", func(r chi.Router) {
+		r.Post("/plan", handler.PlanDesiredStateHandler)
+		r.Post("/apply", handler.ApplyDesiredStateHandler)
+	})
+}
+
+func createJobRoutes(r chi.Router) {
+	r.Get("/job", handler.GetJobHandler)
+	r.Get("/jobs", handler.ListJobsHandler)
+}
+
 func buildPath(basePath, path string) string {
 	if config.GetEnvironment() == config.EnvDevelopment {
 		return path
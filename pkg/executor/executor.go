@@ -0,0 +1,156 @@
+package executor
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Executor godoc
+// Runs tasks with a limit of concurrent tasks, both in total and per key (e.g. the database instance a task connects
+// to). Tasks beyond the limits wait in line for a free slot. Tasks are submitted through a Batch, that keeps the
+// statistics of the operation that submitted them.
+type Executor struct {
+	maxConcurrency       int
+	maxConcurrencyPerKey int
+	global               chan struct{}
+
+	mu   sync.Mutex
+	keys map[string]*keySlots
+}
+
+type keySlots struct {
+	slots chan struct{}
+	users int
+}
+
+// Stats godoc
+// Statistics of the tasks of a batch. A task is queued when it had to wait for a free slot.
+type Stats struct {
+	Tasks                int
+	QueuedTasks          int
+	MaxQueueTime         time.Duration
+	TotalQueueTime       time.Duration
+	MaxConcurrency       int
+	MaxConcurrencyPerKey int
+}
+
+func NewExecutor(maxConcurrency, maxConcurrencyPerKey int) *Executor {
+	if maxConcurrencyPerKey > maxConcurrency {
+		maxConcurrencyPerKey = maxConcurrency
+	}
+	return &Executor{
+		maxConcurrency:       maxConcurrency,
+		maxConcurrencyPerKey: maxConcurrencyPerKey,
+		global:               make(chan struct{}, maxConcurrency),
+		keys:                 make(map[string]*keySlots),
+	}
+}
+
+// acquire godoc
+// Waits for a slot of the key and then for a global slot, so a task waiting for its key doesn't hold a global slot
+// that a task of another key could use. Returns the function that frees both slots and whether the task had to wait.
+func (e *Executor) acquire(key string) (func(), bool) {
+	e.mu.Lock()
+	ks, exists := e.keys[key]
+	if !exists {
+		ks = &keySlots{slots: make(chan struct{}, e.maxConcurrencyPerKey)}
+		e.keys[key] = ks
+	}
+	ks.users++
+	e.mu.Unlock()
+
+	queued := false
+	select {
+	case ks.slots <- struct{}{}:
+	default:
+		queued = true
+		ks.slots <- struct{}{}
+	}
+	select {
+	case e.global <- struct{}{}:
+	default:
+		queued = true
+		e.global <- struct{}{}
+	}
+
+	return func() {
+		<-e.global
+		<-ks.slots
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		ks.users--
+		if ks.users == 0 {
+			delete(e.keys, key)
+		}
+	}, queued
+}
+
+// Batch godoc
+// Group of tasks submitted by an operation, e.g. a grant of access to several users in several instances
+type Batch struct {
+	executor *Executor
+	name     string
+	wg       sync.WaitGroup
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+func (e *Executor) NewBatch(name string) *Batch {
+	return &Batch{
+		executor: e,
+		name:     name,
+		stats:    Stats{MaxConcurrency: e.maxConcurrency, MaxConcurrencyPerKey: e.maxConcurrencyPerKey},
+	}
+}
+
+// Go godoc
+// Runs the task once there are free slots for it, receiving the time it waited in line.
+// Tasks may submit other tasks to the same batch, but must not wait for them, since they hold their slots.
+func (b *Batch) Go(key string, task func(queueTime time.Duration)) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		start := time.Now()
+		release, queued := b.executor.acquire(key)
+		defer release()
+		queueTime := time.Since(start)
+		if queued {
+			log.Printf("%s | [%s]: task waited %s for a free slot (limits: %d in total, %d per instance)",
+				b.name, key, queueTime.Round(time.Millisecond), b.executor.maxConcurrency, b.executor.maxConcurrencyPerKey)
+		}
+		b.record(queued, queueTime)
+		task(queueTime)
+	}()
+}
+
+// Wait godoc
+// Waits for every task of the batch, including the ones submitted by other tasks, and returns the statistics
+func (b *Batch) Wait() Stats {
+	b.wg.Wait()
+	stats := b.Stats()
+	if stats.QueuedTasks > 0 {
+		log.Printf("%s | executor saturated: %d of %d tasks waited for a free slot, longest wait %s",
+			b.name, stats.QueuedTasks, stats.Tasks, stats.MaxQueueTime.Round(time.Millisecond))
+	}
+	return stats
+}
+
+func (b *Batch) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+func (b *Batch) record(queued bool, queueTime time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Tasks++
+	if !queued {
+		return
+	}
+	b.stats.QueuedTasks++
+	b.stats.TotalQueueTime += queueTime
+	b.stats.MaxQueueTime = max(b.stats.MaxQueueTime, queueTime)
+}
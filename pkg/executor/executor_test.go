@@ -0,0 +1,116 @@
+package executor
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// concurrencyProbe records the highest number of tasks running at the same time, in total and per key
+type concurrencyProbe struct {
+	mu         sync.Mutex
+	running    int
+	runningKey map[string]int
+	maxTotal   int
+	maxKey     map[string]int
+}
+
+func newConcurrencyProbe() *concurrencyProbe {
+	return &concurrencyProbe{runningKey: make(map[string]int), maxKey: make(map[string]int)}
+}
+
+func (p *concurrencyProbe) task(key string) func(time.Duration) {
+	return func(time.Duration) {
+		p.mu.Lock()
+		p.running++
+		p.runningKey[key]++
+		p.maxTotal = max(p.maxTotal, p.running)
+		p.maxKey[key] = max(p.maxKey[key], p.runningKey[key])
+		p.mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		p.mu.Lock()
+		p.running--
+		p.runningKey[key]--
+		p.mu.Unlock()
+	}
+}
+
+func TestGivenMoreTasksThanLimits_WhenRunBatch_ThenShouldRespectGlobalAndPerKeyLimits(t *testing.T) {
+	executor := NewExecutor(3, 2)
+	batch := executor.NewBatch("test")
+	probe := newConcurrencyProbe()
+
+	for _, key := range []string{"instance-1", "instance-2", "instance-3"} {
+		for i := 0; i < 4; i++ {
+			batch.Go(key, probe.task(key))
+		}
+	}
+	stats := batch.Wait()
+
+	assert.Equal(t, 3, probe.maxTotal)
+	for key, maxKey := range probe.maxKey {
+		assert.LessOrEqual(t, maxKey, 2, "tasks of %s exceeded the limit per key", key)
+	}
+	assert.Equal(t, 12, stats.Tasks)
+	assert.Greater(t, stats.QueuedTasks, 0)
+	assert.Greater(t, stats.MaxQueueTime, time.Duration(0))
+	assert.GreaterOrEqual(t, stats.TotalQueueTime, stats.MaxQueueTime)
+	assert.Equal(t, 3, stats.MaxConcurrency)
+	assert.Equal(t, 2, stats.MaxConcurrencyPerKey)
+	assert.Empty(t, executor.keys, "the slots of a key should be removed when it has no tasks")
+}
+
+func TestGivenTasksWithinLimits_WhenRunBatch_ThenNoTaskShouldBeQueued(t *testing.T) {
+	batch := NewExecutor(4, 4).NewBatch("test")
+	var queueTimes []time.Duration
+	var mu sync.Mutex
+
+	for i := 0; i < 4; i++ {
+		batch.Go("instance-1", func(queueTime time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			queueTimes = append(queueTimes, queueTime)
+		})
+	}
+	stats := batch.Wait()
+
+	assert.Equal(t, 4, stats.Tasks)
+	assert.Equal(t, 0, stats.QueuedTasks)
+	assert.Equal(t, time.Duration(0), stats.TotalQueueTime)
+	assert.Len(t, queueTimes, 4)
+}
+
+func TestGivenTasksSubmittingOtherTasks_WhenWait_ThenShouldWaitForAllOfThemWithoutDeadlock(t *testing.T) {
+	batch := NewExecutor(1, 1).NewBatch("test")
+	var mu sync.Mutex
+	executed := 0
+	count := func(time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		executed++
+	}
+
+	batch.Go("instance-1", func(time.Duration) {
+		count(0)
+		for i := 0; i < 3; i++ {
+			batch.Go("instance-1", func(time.Duration) {
+				count(0)
+				batch.Go("instance-1", count)
+			})
+		}
+	})
+	stats := batch.Wait()
+
+	assert.Equal(t, 7, executed)
+	assert.Equal(t, 7, stats.Tasks)
+}
+
+func TestGivenPerKeyLimitGreaterThanGlobal_WhenNewExecutor_ThenShouldCapPerKeyLimit(t *testing.T) {
+	executor := NewExecutor(2, 5)
+
+	assert.Equal(t, 2, executor.maxConcurrencyPerKey)
+}
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Notification godoc
+// Message about an event of the application that someone must be aware of, e.g. an emergency access granted.
+// Fields holds the details of the event, like the user and the databases involved.
+type Notification struct {
+	Event   string            `json:"event"`
+	Subject string            `json:"subject"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Time    time.Time         `json:"time"`
+}
+
+// Notifier godoc
+// Delivers notifications to the people responsible for the events. Implementations must be safe for concurrent use.
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// LogNotifier godoc
+// Writes the notifications to the application log, used when no other notifier is configured
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(notification Notification) error {
+	fields := make([]string, 0, len(notification.Fields))
+	for name, value := range notification.Fields {
+		fields = append(fields, fmt.Sprintf("%s=%q", name, value))
+	}
+	sort.Strings(fields)
+	log.Printf("NOTIFICATION [%s] %s: %s %s", notification.Event, notification.Subject, notification.Message, strings.Join(fields, " "))
+	return nil
+}
+
+// WebhookNotifier godoc
+// Posts the notifications as JSON to a URL, e.g. an incoming webhook of a chat or an alerting tool.
+// Any status other than 2xx is considered a failure.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (n *WebhookNotifier) Notify(notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error sending notification %s to the webhook. Cause: %w", notification.Event, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook refused notification %s with status %d", notification.Event, resp.StatusCode)
+	}
+	return nil
+}
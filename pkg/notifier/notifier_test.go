@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildNotification() Notification {
+	return Notification{
+		Event:   "BREAK_GLASS_GRANTED",
+		Subject: "Break-glass access granted",
+		Message: "john.doe received emergency access",
+		Fields:  map[string]string{"incidentReference": "INC-1234"},
+		Time:    time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestGivenAWebhook_WhenNotify_ThenShouldPostTheNotificationAsJSON(t *testing.T) {
+	var received Notification
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, time.Second).Notify(buildNotification())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, buildNotification(), received)
+}
+
+func TestGivenAWebhookReturningAnError_WhenNotify_ThenShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, time.Second).Notify(buildNotification())
+
+	assert.EqualError(t, err, "webhook refused notification BREAK_GLASS_GRANTED with status 502")
+}
+
+func TestGivenAnUnreachableWebhook_WhenNotify_ThenShouldReturnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	err := NewWebhookNotifier(server.URL, time.Second).Notify(buildNotification())
+
+	assert.ErrorContains(t, err, "error sending notification BREAK_GLASS_GRANTED to the webhook")
+}
+
+func TestGivenANotification_WhenNotifyToLog_ThenShouldNotFail(t *testing.T) {
+	assert.NoError(t, NewLogNotifier().Notify(buildNotification()))
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Scheduler godoc
+// Runs tasks periodically in background: each task runs once when scheduled and then on each interval. A run never
+// overlaps the previous run of the same task, if it takes longer than the interval the next run starts right after it.
+type Scheduler struct {
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	stopped sync.Once
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+// Every godoc
+// Schedules the task to run now and on each interval, until the scheduler is stopped
+func (s *Scheduler) Every(name string, interval time.Duration, task func()) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		log.Printf("Task %s scheduled to run every %s", name, interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.run(name, task)
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				// A stop requested while the task was running has priority over a tick already elapsed
+				select {
+				case <-s.stop:
+					return
+				default:
+				}
+			}
+		}
+	}()
+}
+
+// Stop godoc
+// Stops scheduling new runs and waits for the runs in progress
+func (s *Scheduler) Stop() {
+	s.stopped.Do(func() {
+		close(s.stop)
+	})
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(name string, task func()) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			log.Printf("Task %s panicked: %v", name, recovered)
+		}
+	}()
+	task()
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGivenATask_WhenScheduleEvery_ThenShouldRunItNowAndOnEachInterval(t *testing.T) {
+	var runs atomic.Int32
+	s := NewScheduler()
+
+	s.Every("count", 10*time.Millisecond, func() { runs.Add(1) })
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	s.Stop()
+}
+
+func TestGivenATaskRunning_WhenStop_ThenShouldWaitForItAndNotRunItAgain(t *testing.T) {
+	var runs atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := NewScheduler()
+	s.Every("slow", time.Millisecond, func() {
+		if runs.Add(1) == 1 {
+			close(started)
+			<-release
+		}
+	})
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("stop should wait for the task running")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	<-stopped
+
+	assert.Equal(t, int32(1), runs.Load())
+	s.Stop()
+}
+
+func TestGivenATaskThatPanics_WhenScheduleEvery_ThenShouldKeepRunningIt(t *testing.T) {
+	var runs atomic.Int32
+	s := NewScheduler()
+
+	s.Every("panic", time.Millisecond, func() {
+		runs.Add(1)
+		panic("unexpected")
+	})
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, time.Millisecond)
+	s.Stop()
+}
@@ -0,0 +1,108 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const AccessGroupID = "9e4d2c1b-7a6f-4b3e-8d2c-5f1a0b9c8d7e"
+
+type AccessGroupStorageMock struct {
+	mock.Mock
+}
+
+func (m *AccessGroupStorageMock) Save(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error {
+	args := m.Called(g, targets)
+	return args.Error(0)
+}
+
+func (m *AccessGroupStorageMock) UpdateTargets(g *entity.AccessGroup, targets []*entity.AccessGroupTarget) error {
+	args := m.Called(g, targets)
+	return args.Error(0)
+}
+
+func (m *AccessGroupStorageMock) CheckNameExists(name string) (bool, error) {
+	args := m.Called(name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindByID(id string) (*entity.AccessGroup, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.AccessGroup), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindDTOByID(id string) (*dto.AccessGroupOutputDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(*dto.AccessGroupOutputDTO), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindAllDTOs(page, limit int) ([]*dto.AccessGroupOutputDTO, error) {
+	args := m.Called(page, limit)
+	return args.Get(0).([]*dto.AccessGroupOutputDTO), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) Count() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindAllTargets(accessGroupID string) ([]*entity.AccessGroupTarget, error) {
+	args := m.Called(accessGroupID)
+	return args.Get(0).([]*entity.AccessGroupTarget), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindAllTargetsByMember(databaseUserID, exceptAccessGroupID string) ([]*entity.AccessGroupTarget, error) {
+	args := m.Called(databaseUserID, exceptAccessGroupID)
+	return args.Get(0).([]*entity.AccessGroupTarget), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindAllTargetDTOs(accessGroupID string) ([]dto.AccessGroupTargetOutputDTO, error) {
+	args := m.Called(accessGroupID)
+	return args.Get(0).([]dto.AccessGroupTargetOutputDTO), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) SaveMembers(members []*entity.AccessGroupMember) error {
+	args := m.Called(members)
+	return args.Error(0)
+}
+
+func (m *AccessGroupStorageMock) DeleteMember(accessGroupID, databaseUserID string) (bool, error) {
+	args := m.Called(accessGroupID, databaseUserID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AccessGroupStorageMock) FindAllMemberDTOs(accessGroupID string) ([]dto.AccessGroupMemberOutputDTO, error) {
+	args := m.Called(accessGroupID)
+	return args.Get(0).([]dto.AccessGroupMemberOutputDTO), args.Error(1)
+}
+
+func BuildAccessGroup() *entity.AccessGroup {
+	return &entity.AccessGroup{
+		ID:              uuid.MustParse(AccessGroupID),
+		Name:            "Team B analysts",
+		CreatedByUserID: UserID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+func BuildAccessGroupDTO() *dto.AccessGroupOutputDTO {
+	return &dto.AccessGroupOutputDTO{
+		ID:              AccessGroupID,
+		Name:            "Team B analysts",
+		CreatedByUserID: UserID,
+		CreatedByUser:   "Foo Bar",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+func BuildAccessGroupTarget(databaseInstanceID, databaseID string) *entity.AccessGroupTarget {
+	target, _ := entity.NewAccessGroupTarget(AccessGroupID, databaseInstanceID, databaseID)
+	return target
+}
@@ -1,6 +1,7 @@
 package mocks
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -23,6 +24,26 @@ func (a *AccessPermissionStorageMock) Save(accessPermission *entity.AccessPermis
 	return args.Error(0)
 }
 
+func (a *AccessPermissionStorageMock) UpdateDatabaseRole(id string, databaseRoleID sql.NullString) error {
+	args := a.Called(id, databaseRoleID)
+	return args.Error(0)
+}
+
+func (a *AccessPermissionStorageMock) UpdateExpiresAt(databaseID, databaseUserID string, expiresAt *time.Time) error {
+	args := a.Called(databaseID, databaseUserID, expiresAt)
+	return args.Error(0)
+}
+
+func (a *AccessPermissionStorageMock) UpdateAccessGroup(databaseID, databaseUserID string, accessGroupID sql.NullString) error {
+	args := a.Called(databaseID, databaseUserID, accessGroupID)
+	return args.Error(0)
+}
+
+func (a *AccessPermissionStorageMock) FindDTOByID(id string) (*dto.AccessPermissionOutputDTO, error) {
+	args := a.Called(id)
+	return args.Get(0).(*dto.AccessPermissionOutputDTO), args.Error(1)
+}
+
 func (a *AccessPermissionStorageMock) Exists(databaseId, databaseUserId string) (bool, error) {
 	args := a.Called(databaseId, databaseUserId)
 	return args.Bool(0), args.Error(1)
@@ -33,6 +54,11 @@ func (a *AccessPermissionStorageMock) FindAllDTOs(databaseId, databaseUserId, da
 	return args.Get(0).([]*dto.AccessPermissionOutputDTO), args.Error(1)
 }
 
+func (a *AccessPermissionStorageMock) FindAllDTOsByScope(ecosystemID, team string) ([]*dto.AccessPermissionOutputDTO, error) {
+	args := a.Called(ecosystemID, team)
+	return args.Get(0).([]*dto.AccessPermissionOutputDTO), args.Error(1)
+}
+
 func (a *AccessPermissionStorageMock) SaveLog(log *entity.AccessPermissionLog) error {
 	args := a.Called(log)
 	return args.Error(0)
@@ -43,11 +69,26 @@ func (a *AccessPermissionStorageMock) FindAllAccessibleInstancesIDsByUser(userID
 	return args.Get(0).([]string), args.Error(1)
 }
 
-func (a *AccessPermissionStorageMock) FindAllLogsDTOs(page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error) {
-	args := a.Called(page, limit)
+func (a *AccessPermissionStorageMock) FindAllExpiredDTOs(now time.Time) ([]*dto.AccessPermissionOutputDTO, error) {
+	args := a.Called(now)
+	return args.Get(0).([]*dto.AccessPermissionOutputDTO), args.Error(1)
+}
+
+func (a *AccessPermissionStorageMock) FindAllScopedInstancesIDsByUsers(userIDs []string) (map[string][]string, error) {
+	args := a.Called(userIDs)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (a *AccessPermissionStorageMock) FindAllLogsDTOs(logType string, page, limit int) ([]*dto.AccessPermissionLogOutputDTO, error) {
+	args := a.Called(logType, page, limit)
 	return args.Get(0).([]*dto.AccessPermissionLogOutputDTO), args.Error(1)
 }
 
+func (a *AccessPermissionStorageMock) Delete(id string) error {
+	args := a.Called(id)
+	return args.Error(0)
+}
+
 func (a *AccessPermissionStorageMock) DeleteAllByUserAndInstance(databaseUserID, instanceID string) error {
 	args := a.Called(databaseUserID, instanceID)
 	return args.Error(0)
@@ -63,8 +104,8 @@ func (a *AccessPermissionStorageMock) CheckIfUserHasAccessPermission(databaseUse
 	return args.Bool(0), args.Error(1)
 }
 
-func (a *AccessPermissionStorageMock) LogCount() (int, error) {
-	args := a.Called()
+func (a *AccessPermissionStorageMock) LogCount(logType string) (int, error) {
+	args := a.Called(logType)
 	return args.Int(0), args.Error(1)
 }
 
@@ -128,3 +169,31 @@ func BuildAccessPermissionLogDTOList() []*dto.AccessPermissionLogOutputDTO {
 		},
 	}
 }
+
+type RevokeAccessPermissionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RevokeAccessPermissionUseCaseMock) Execute(input dto.RevokeAccessInputDTO, operationUserID string) (*dto.RevokeAccessOutputDTO, error) {
+	args := m.Called(input, operationUserID)
+	return args.Get(0).(*dto.RevokeAccessOutputDTO), args.Error(1)
+}
+
+func (m *RevokeAccessPermissionUseCaseMock) RevokeDatabases(databaseUserID, instanceID string, databasesIDs []string, operationUserID string) error {
+	args := m.Called(databaseUserID, instanceID, databasesIDs, operationUserID)
+	return args.Error(0)
+}
+
+type GrantAccessPermissionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GrantAccessPermissionUseCaseMock) Execute(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error) {
+	args := m.Called(input, operationUserID)
+	return args.Get(0).(*dto.GrantAccessOutputDTO), args.Error(1)
+}
+
+func (m *GrantAccessPermissionUseCaseMock) ExecuteApproved(input dto.GrantAccessInputDTO, operationUserID string) (*dto.GrantAccessOutputDTO, error) {
+	args := m.Called(input, operationUserID)
+	return args.Get(0).(*dto.GrantAccessOutputDTO), args.Error(1)
+}
@@ -0,0 +1,82 @@
+package mocks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const (
+	AccessRequestID = "5d1c7a3e-9b2f-4e68-8a0d-6c4f1e2b9a73"
+	ReviewerUserID  = "b6f4e2a1-0c3d-4f5e-9a8b-7c6d5e4f3a21"
+)
+
+type AccessRequestStorageMock struct {
+	mock.Mock
+}
+
+func (m *AccessRequestStorageMock) Save(r *entity.AccessRequest) error {
+	args := m.Called(r)
+	return args.Error(0)
+}
+
+func (m *AccessRequestStorageMock) Update(r *entity.AccessRequest, previousStatus entity.AccessRequestStatus) (bool, error) {
+	args := m.Called(r, previousStatus)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AccessRequestStorageMock) FindByID(id string) (*entity.AccessRequest, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.AccessRequest), args.Error(1)
+}
+
+func (m *AccessRequestStorageMock) FindDTOByID(id string) (*dto.AccessRequestOutputDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(*dto.AccessRequestOutputDTO), args.Error(1)
+}
+
+func (m *AccessRequestStorageMock) FindAllDTOs(status, requestedByUserID string, page, limit int) ([]*dto.AccessRequestOutputDTO, error) {
+	args := m.Called(status, requestedByUserID, page, limit)
+	return args.Get(0).([]*dto.AccessRequestOutputDTO), args.Error(1)
+}
+
+func (m *AccessRequestStorageMock) Count(status, requestedByUserID string) (int, error) {
+	args := m.Called(status, requestedByUserID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *AccessRequestStorageMock) ExpireAllPending(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func BuildAccessRequestGrantInput() dto.GrantAccessInputDTO {
+	return dto.GrantAccessInputDTO{
+		DatabaseUsersIDs: []string{DbUserID},
+		InstancesData:    []dto.InstanceDataDTO{{DatabaseInstanceID: QAInstanceId}},
+	}
+}
+
+// BuildAccessRequest godoc
+// Pending access request of the user UserID, that can be reviewed by ReviewerUserID
+func BuildAccessRequest() *entity.AccessRequest {
+	input, _ := json.Marshal(BuildAccessRequestGrantInput())
+	accessRequest, _ := entity.NewAccessRequest(string(input), "On-call investigation of incident INC-42", UserID, time.Now().Add(time.Hour))
+	return accessRequest
+}
+
+func BuildAccessRequestDTO(status entity.AccessRequestStatus) *dto.AccessRequestOutputDTO {
+	return &dto.AccessRequestOutputDTO{
+		ID:                AccessRequestID,
+		Status:            string(status),
+		Justification:     "On-call investigation of incident INC-42",
+		RequestedByUserID: UserID,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+		ExpiresAt:         time.Now().Add(time.Hour),
+	}
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const BreakGlassAccessID = "0f6b3c2a-8d41-4e7a-b5c9-1e2d3f4a5b6c"
+
+type BreakGlassAccessStorageMock struct {
+	mock.Mock
+}
+
+func (m *BreakGlassAccessStorageMock) Save(b *entity.BreakGlassAccess) error {
+	args := m.Called(b)
+	return args.Error(0)
+}
+
+func (m *BreakGlassAccessStorageMock) FindAllExpiredDTOs(now time.Time) ([]*dto.BreakGlassAccessOutputDTO, error) {
+	args := m.Called(now)
+	return args.Get(0).([]*dto.BreakGlassAccessOutputDTO), args.Error(1)
+}
+
+func (m *BreakGlassAccessStorageMock) UpdateRevokedAt(id string, revokedAt time.Time) error {
+	args := m.Called(id, revokedAt)
+	return args.Error(0)
+}
+
+func (m *BreakGlassAccessStorageMock) UpdateRevokedAtByUserAndInstance(databaseUserID, databaseInstanceID string, revokedAt time.Time) error {
+	args := m.Called(databaseUserID, databaseInstanceID, revokedAt)
+	return args.Error(0)
+}
+
+func BuildExpiredBreakGlassAccessDTO() *dto.BreakGlassAccessOutputDTO {
+	return &dto.BreakGlassAccessOutputDTO{
+		ID:                 BreakGlassAccessID,
+		DatabaseUserID:     DbUserID,
+		DatabaseUsername:   "foobar",
+		DatabaseInstanceID: DatabaseInstanceId,
+		DatabaseID:         DatabaseID,
+		DatabaseName:       "settings",
+		IncidentReference:  "INC-1234",
+		RoleGranted:        true,
+		GrantedByUserID:    UserID,
+		GrantedAt:          time.Now().Add(-2 * time.Hour),
+		ExpiresAt:          time.Now().Add(-time.Hour),
+	}
+}
@@ -113,9 +113,9 @@ func BuildDummyErrorInstance() *dto.DatabaseInstanceOutputDTO {
 func BuildConnectorNotImplementedInstance() *dto.DatabaseInstanceOutputDTO {
 	return &dto.DatabaseInstanceOutputDTO{
 		ID:                        "57200738-9b52-4c31-945b-fb1603df4f37",
-		Name:                      "MySQL - AWS",
+		Name:                      "Oracle - AWS",
 		EcosystemName:             "AWS",
-		DatabaseTechnologyName:    "MySQL",
+		DatabaseTechnologyName:    "Oracle",
 		DatabaseTechnologyVersion: "5",
 		AdminUser:                 "admin",
 		AdminPassword:             encryptedPwd,
@@ -11,6 +11,31 @@ type DatabaseRoleStorageMock struct {
 	mock.Mock
 }
 
+func (m *DatabaseRoleStorageMock) Save(role *entity.DatabaseRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *DatabaseRoleStorageMock) Update(role *entity.DatabaseRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *DatabaseRoleStorageMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *DatabaseRoleStorageMock) CheckNameExists(name string) (bool, error) {
+	args := m.Called(name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *DatabaseRoleStorageMock) IsInUse(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *DatabaseRoleStorageMock) FindAll() ([]*entity.DatabaseRole, error) {
 	args := m.Called()
 	return args.Get(0).([]*entity.DatabaseRole), args.Error(1)
@@ -35,6 +60,13 @@ func BuildDeveloperRole() *entity.DatabaseRole {
 		DisplayName: "Developer",
 		Description: "Role for developers",
 		ReadOnly:    false,
+		Privileges: entity.RolePrivileges{
+			Schema:   []string{"USAGE"},
+			Table:    []string{"SELECT", "INSERT", "UPDATE", "DELETE"},
+			Sequence: []string{"USAGE", "SELECT"},
+			Function: []string{"EXECUTE"},
+		},
+		CreatedByUserID: UserID,
 	}
 }
 
@@ -45,5 +77,12 @@ func BuildReadOnlyRole() *entity.DatabaseRole {
 		DisplayName: "Read Only User",
 		Description: "Role for read only users",
 		ReadOnly:    true,
+		Privileges: entity.RolePrivileges{
+			Schema:   []string{"USAGE"},
+			Table:    []string{"SELECT"},
+			Sequence: []string{"SELECT"},
+			Function: []string{"EXECUTE"},
+		},
+		CreatedByUserID: UserID,
 	}
 }
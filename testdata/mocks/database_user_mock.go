@@ -168,3 +168,21 @@ func BuildDbUserDummyErrorGrantDTO() *dto.DatabaseUserOutputDTO {
 	user.Username = connector.DummyTestUserErrorOnGrant
 	return user
 }
+
+type CreateDatabaseUserUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *CreateDatabaseUserUseCaseMock) Execute(input dto.DatabaseUserInputDTO, createdByUserID string) (*dto.DatabaseUserOutputDTO, error) {
+	args := m.Called(input, createdByUserID)
+	return args.Get(0).(*dto.DatabaseUserOutputDTO), args.Error(1)
+}
+
+type ChangeStatusUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ChangeStatusUseCaseMock) Execute(id string, enabled bool, operationUserID string) (*dto.ChangeStatusOutputDTO, error) {
+	args := m.Called(id, enabled, operationUserID)
+	return args.Get(0).(*dto.ChangeStatusOutputDTO), args.Error(1)
+}
@@ -42,3 +42,12 @@ func (m *EcosystemStorageMock) FindAll(page, limit int) ([]*dto.EcosystemOutputD
 	args := m.Called(page, limit)
 	return args.Get(0).([]*dto.EcosystemOutputDTO), args.Error(1)
 }
+
+type CreateEcosystemUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *CreateEcosystemUseCaseMock) Execute(input dto.EcosystemInputDTO, createdByUserID string) (*dto.EcosystemOutputDTO, error) {
+	args := m.Called(input, createdByUserID)
+	return args.Get(0).(*dto.EcosystemOutputDTO), args.Error(1)
+}
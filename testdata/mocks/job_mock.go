@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const JobID = "7f0e2b8c-3d4a-4c59-9a61-2f5c8e1d0b47"
+
+type JobStorageMock struct {
+	mock.Mock
+}
+
+func (m *JobStorageMock) Save(job *entity.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *JobStorageMock) Update(job *entity.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *JobStorageMock) FindByID(id string) (*entity.Job, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.Job), args.Error(1)
+}
+
+func (m *JobStorageMock) FindDTOByID(id string) (*dto.JobOutputDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(*dto.JobOutputDTO), args.Error(1)
+}
+
+func (m *JobStorageMock) FindAllDTOs(jobType, status string, page, limit int) ([]*dto.JobOutputDTO, error) {
+	args := m.Called(jobType, status, page, limit)
+	return args.Get(0).([]*dto.JobOutputDTO), args.Error(1)
+}
+
+func (m *JobStorageMock) Count(jobType, status string) (int, error) {
+	args := m.Called(jobType, status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *JobStorageMock) FindAllUnfinished() ([]*entity.Job, error) {
+	args := m.Called()
+	return args.Get(0).([]*entity.Job), args.Error(1)
+}
+
+func BuildJobDTO() *dto.JobOutputDTO {
+	return &dto.JobOutputDTO{
+		ID:              JobID,
+		Type:            entity.JobTypeSyncDatabases,
+		Status:          string(entity.JobStatusRunning),
+		TotalItems:      2,
+		ProcessedItems:  1,
+		Items:           []dto.ItemResultDTO{{Item: "dummy-test - QA", Success: true, Message: "3 databases synchronized successfully!"}},
+		Attempts:        1,
+		CreatedByUserID: UserID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
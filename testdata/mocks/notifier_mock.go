@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/pkg/notifier"
+)
+
+type NotifierMock struct {
+	mock.Mock
+}
+
+func (m *NotifierMock) Notify(notification notifier.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
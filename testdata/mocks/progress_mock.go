@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"sync"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+)
+
+// ProgressReporterMock godoc
+// Records the progress reported by an operation, that is reported concurrently by its tasks
+type ProgressReporterMock struct {
+	mu     sync.Mutex
+	total  int
+	items  []dto.ItemResultDTO
+	events []dto.ProgressEventDTO
+}
+
+func (m *ProgressReporterMock) AddItems(qty int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.total += qty
+}
+
+func (m *ProgressReporterMock) ItemProcessed(result dto.ItemResultDTO) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = append(m.items, result)
+}
+
+func (m *ProgressReporterMock) Event(event dto.ProgressEventDTO) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *ProgressReporterMock) Total() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+func (m *ProgressReporterMock) Items() []dto.ItemResultDTO {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]dto.ItemResultDTO(nil), m.items...)
+}
+
+func (m *ProgressReporterMock) Events() []dto.ProgressEventDTO {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]dto.ProgressEventDTO(nil), m.events...)
+}
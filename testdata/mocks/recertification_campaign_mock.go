@@ -0,0 +1,85 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+const RecertificationCampaignID = "4b8e2f1a-6c3d-4e5f-9a0b-1c2d3e4f5a6b"
+
+type RecertificationCampaignStorageMock struct {
+	mock.Mock
+}
+
+func (m *RecertificationCampaignStorageMock) Save(c *entity.RecertificationCampaign, items []*entity.RecertificationItem) error {
+	args := m.Called(c, items)
+	return args.Error(0)
+}
+
+func (m *RecertificationCampaignStorageMock) Update(c *entity.RecertificationCampaign, previousStatus entity.RecertificationCampaignStatus) (bool, error) {
+	args := m.Called(c, previousStatus)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) FindByID(id string) (*entity.RecertificationCampaign, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.RecertificationCampaign), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) FindDTOByID(id string) (*dto.RecertificationCampaignOutputDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(*dto.RecertificationCampaignOutputDTO), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) FindAllDTOs(status string, page, limit int) ([]*dto.RecertificationCampaignOutputDTO, error) {
+	args := m.Called(status, page, limit)
+	return args.Get(0).([]*dto.RecertificationCampaignOutputDTO), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) Count(status string) (int, error) {
+	args := m.Called(status)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) FindAllItems(campaignID string, ids []string) ([]*entity.RecertificationItem, error) {
+	args := m.Called(campaignID, ids)
+	return args.Get(0).([]*entity.RecertificationItem), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) UpdateItems(items []*entity.RecertificationItem) error {
+	args := m.Called(items)
+	return args.Error(0)
+}
+
+func (m *RecertificationCampaignStorageMock) FindAllItemDTOs(campaignID, reviewerID, decision string, page, limit int) ([]*dto.RecertificationItemOutputDTO, error) {
+	args := m.Called(campaignID, reviewerID, decision, page, limit)
+	return args.Get(0).([]*dto.RecertificationItemOutputDTO), args.Error(1)
+}
+
+func (m *RecertificationCampaignStorageMock) CountItems(campaignID, reviewerID, decision string) (int, error) {
+	args := m.Called(campaignID, reviewerID, decision)
+	return args.Int(0), args.Error(1)
+}
+
+func BuildOpenRecertificationCampaign() *entity.RecertificationCampaign {
+	c, _ := entity.NewRecertificationCampaign("Q3 2024 recertification", "", "", UserID)
+	return c
+}
+
+func BuildRecertificationCampaignDTO() *dto.RecertificationCampaignOutputDTO {
+	return &dto.RecertificationCampaignOutputDTO{
+		ID:              RecertificationCampaignID,
+		Name:            "Q3 2024 recertification",
+		Status:          string(entity.RecertificationCampaignStatusOpen),
+		CreatedByUserID: UserID,
+		CreatedByUser:   "Susan Smith",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		TotalItems:      2,
+		PendingItems:    2,
+	}
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/zgsolucoes/zg-data-guard/internal/dto"
+	"github.com/zgsolucoes/zg-data-guard/internal/entity"
+)
+
+type RoleFindingStorageMock struct {
+	mock.Mock
+}
+
+func (m *RoleFindingStorageMock) ReplaceAllByInstance(databaseInstanceID string, findings []*entity.RoleFinding) error {
+	args := m.Called(databaseInstanceID, findings)
+	return args.Error(0)
+}
+
+func (m *RoleFindingStorageMock) FindAllDTOs(databaseInstanceID, class string, page, limit int) ([]*dto.RoleFindingOutputDTO, error) {
+	args := m.Called(databaseInstanceID, class, page, limit)
+	return args.Get(0).([]*dto.RoleFindingOutputDTO), args.Error(1)
+}
+
+func (m *RoleFindingStorageMock) Count(databaseInstanceID, class string) (int, error) {
+	args := m.Called(databaseInstanceID, class)
+	return args.Int(0), args.Error(1)
+}